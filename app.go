@@ -9,18 +9,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"projecthub/internal/claude"
 	"projecthub/internal/docker"
+	"projecthub/internal/forge"
 	"projecthub/internal/git"
 	"projecthub/internal/iterm"
+	"projecthub/internal/k8s"
 	"projecthub/internal/logging"
+	"projecthub/internal/notify"
 	"projecthub/internal/remote"
+	"projecthub/internal/shell"
 	"projecthub/internal/state"
 	"projecthub/internal/structure"
 	"projecthub/internal/teams"
@@ -35,18 +43,31 @@ type App struct {
 	ctx              context.Context
 	terminalManager  *terminal.Manager
 	dockerManager    *docker.Manager
+	k8sManager       *k8s.Manager
 	stateManager     *state.Manager
 	gitManager       *git.Manager
 	claudeDetector   *claude.Detector
 	toolsManager     *claude.ToolsManager
+	claudeRunner     *claude.Runner
 	testWatcher      *testing.Watcher
 	coverageWatcher  *testing.CoverageWatcher
+	rerunWatcher     *testing.RerunWatcher
+	shardRunner      *testing.ShardRunner
 	testScanner      *testing.TestScanner
 	structureScanner *structure.Scanner
+	structureWatcher *structure.Watcher
 	remoteServer     *remote.Server
+	remoteAudit      *remote.AuditLog
+	remoteRecorder   *remote.SessionRecorder
+	pendingTOTP      map[string]string // approved client token -> TOTP secret awaiting its first code
 	ngrokTunnel      *remote.NgrokTunnel
 	itermController  *iterm.Controller
 	coverageStopChan chan struct{}
+	bisectCancel     map[string]context.CancelFunc
+	searchCancel     map[string]context.CancelFunc
+	testNotifyTimers map[string]*time.Timer
+	windowBlurred    bool
+	healthTimer      *time.Timer
 	teamsWatcher     *teams.Watcher
 	teamsStopChan    chan struct{}
 	voiceProcess     *exec.Cmd
@@ -71,31 +92,55 @@ func (a *App) startup(ctx context.Context) {
 		logging.Info("Application starting", "version", "1.0.0")
 	}
 
-	// Initialize state manager first
+	// Initialize state manager first; without it the app would start empty
+	// and overwrite the saved state, so refuse to start
 	stateMgr, err := state.NewManager()
 	if err != nil {
 		logging.Error("Failed to initialize state manager", "error", err)
-	} else {
-		a.stateManager = stateMgr
-		a.stateManager.SetContext(ctx)
-		// Clear all terminals at startup (PTYs don't survive restart)
-		a.stateManager.ClearAllTerminals()
+		runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+			Type:    runtime.ErrorDialog,
+			Title:   "Cannot load saved state",
+			Message: fmt.Sprintf("%v\n\nYour projects and settings in ~/.projecthub were left untouched. Fix the problem and start the app again.", err),
+		})
+		runtime.Quit(ctx)
+		return
 	}
+	a.stateManager = stateMgr
+	a.stateManager.SetContext(ctx)
+	// Clear all terminals at startup (PTYs don't survive restart)
+	a.stateManager.ClearAllTerminals()
 
 	// Initialize terminal manager
 	a.terminalManager = terminal.NewManager()
 	a.terminalManager.SetOutputHandler(a.onTerminalOutput)
 	a.terminalManager.SetExitHandler(a.onTerminalExit)
 
-	// Initialize docker manager
-	dockerMgr, err := docker.NewManager()
+	// Initialize docker manager with the active project's Docker context
+	// (the default context uses the custom host from settings, otherwise autodetect)
+	dockerHost, dockerContext := "", ""
+	if a.stateManager != nil {
+		dockerHost = a.stateManager.GetDockerHost()
+		dockerContext = a.stateManager.GetProjectDockerContext(a.stateManager.GetActiveProjectID())
+	}
+	dockerMgr, err := docker.NewManagerForContext(dockerContext, dockerHost)
 	if err != nil {
 		logging.Warn("Docker not available", "error", err)
 	} else {
 		a.dockerManager = dockerMgr
-		logging.Info("Docker manager initialized")
+		a.watchDockerEvents()
+		logging.Info("Docker manager initialized", "context", dockerMgr.Context(), "host", dockerMgr.Host())
+	}
+
+	// Initialize the audit log and session recordings of remote clients
+	// (kept across sessions)
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		a.remoteAudit = remote.NewAuditLog(filepath.Join(homeDir, ".projecthub", remote.AuditLogFile))
+		a.remoteRecorder = remote.NewSessionRecorder(filepath.Join(homeDir, ".projecthub", remote.RecordingsDir))
 	}
 
+	// Initialize Kubernetes manager (kubectl + kubeconfig)
+	a.k8sManager = k8s.NewManager()
+
 	// Initialize git manager
 	a.gitManager = git.NewManager()
 
@@ -105,8 +150,27 @@ func (a *App) startup(ctx context.Context) {
 	// Initialize tools manager for agents, skills, hooks
 	a.toolsManager = claude.NewToolsManager()
 
+	// Initialize headless Claude job runner
+	a.claudeRunner = claude.NewRunner(2)
+	a.claudeRunner.SetEventHandler(func(event claude.JobEvent) {
+		runtime.EventsEmit(a.ctx, "claude-job-"+event.Type, event)
+	})
+
 	// Initialize test output watcher
 	a.testWatcher = testing.NewWatcher()
+	a.testWatcher.SetWorkDirFunc(func(termID string) string {
+		// Bisect runs are fed in as "bisect:<repo path>"
+		if repoPath, ok := strings.CutPrefix(termID, "bisect:"); ok {
+			return repoPath
+		}
+		if a.terminalManager != nil {
+			if term := a.terminalManager.Get(termID); term != nil {
+				return term.Info().WorkDir
+			}
+		}
+		return ""
+	})
+	a.testWatcher.SetRunFinishedHandler(a.onTestRunFinished)
 
 	// Initialize coverage watcher
 	a.coverageWatcher = testing.NewCoverageWatcher()
@@ -115,14 +179,41 @@ func (a *App) startup(ctx context.Context) {
 			"projectPath": projectPath,
 			"summary":     summary,
 		})
+		a.checkCoverageThresholds(projectPath, summary)
+		a.recordCoverageHistory(projectPath)
+	})
+	a.coverageWatcher.SetMutationHandler(func(projectPath string, summary *testing.MutationSummary) {
+		runtime.EventsEmit(a.ctx, "mutation-update", map[string]interface{}{
+			"projectPath": projectPath,
+			"summary":     summary,
+		})
+		if !summary.Running && summary.Error == "" {
+			a.recordCoverageHistory(projectPath)
+		}
 	})
 
 	// Initialize structure scanner
 	a.structureScanner = structure.NewScanner()
+	a.structureWatcher = structure.NewWatcher(a.structureScanner)
+	a.structureWatcher.SetChangeHandler(func(delta *structure.StructureDelta) {
+		runtime.EventsEmit(a.ctx, "structure-changed", delta)
+	})
 
 	// Initialize test scanner
 	a.testScanner = testing.NewTestScanner()
 
+	// Initialize watch mode test reruns
+	a.rerunWatcher = testing.NewRerunWatcher(a.structureScanner)
+	a.rerunWatcher.SetUpdateHandler(func(result *testing.RerunResult) {
+		runtime.EventsEmit(a.ctx, "test-watch-update", result)
+	})
+
+	// Initialize sharded test runs
+	a.shardRunner = testing.NewShardRunner(a.testScanner)
+	a.shardRunner.SetUpdateHandler(func(run *testing.ShardRun) {
+		runtime.EventsEmit(a.ctx, "test-shards-update", run)
+	})
+
 	// Initialize iTerm2 controller (no polling - sync on demand only)
 	a.itermController = iterm.NewController()
 	logging.Info("iTerm2 controller initialized")
@@ -182,6 +273,9 @@ func (a *App) startup(ctx context.Context) {
 	a.teamsWatcher.SetUpdateCallback(func(allTeams map[string]*teams.TeamSnapshot) {
 		runtime.EventsEmit(a.ctx, "teams-update", allTeams)
 	})
+	a.teamsWatcher.SetSubagentCallback(func(sessions []teams.SessionSubagents) {
+		runtime.EventsEmit(a.ctx, "subagents-update", sessions)
+	})
 
 	// Restore window state after a short delay (needs window to be ready)
 	const windowReadyDelay = 150 * time.Millisecond
@@ -196,10 +290,24 @@ func (a *App) shutdown(ctx context.Context) {
 	// Save window state before closing
 	a.saveWindowState()
 
-	// Stop coverage watcher
+	// Stop coverage watcher and any mutation run
 	if a.coverageStopChan != nil {
 		close(a.coverageStopChan)
 	}
+	if a.coverageWatcher != nil {
+		a.coverageWatcher.StopAll()
+	}
+	// Stop test watch mode and any rerun in progress
+	if a.rerunWatcher != nil {
+		a.rerunWatcher.StopAll()
+	}
+	if a.shardRunner != nil {
+		a.shardRunner.StopAll()
+	}
+	// Stop structure watching
+	if a.structureWatcher != nil {
+		a.structureWatcher.StopAll()
+	}
 	// Stop teams watcher
 	if a.teamsStopChan != nil {
 		close(a.teamsStopChan)
@@ -213,11 +321,20 @@ func (a *App) shutdown(ctx context.Context) {
 	if a.terminalManager != nil {
 		a.terminalManager.CloseAll()
 	}
+	if a.claudeRunner != nil {
+		a.claudeRunner.StopAll()
+	}
+	if a.k8sManager != nil {
+		a.k8sManager.StopAllPodLogs()
+	}
 	if a.dockerManager != nil {
+		a.dockerManager.StopWatchingEvents()
+		a.dockerManager.StopAllLogFollowers()
 		a.dockerManager.Close()
 	}
 	if a.stateManager != nil {
 		a.stateManager.SaveSync()
+		a.stateManager.Close()
 	}
 }
 
@@ -319,6 +436,35 @@ func (a *App) onTerminalOutput(id string, data []byte) {
 			if a.stateManager != nil {
 				a.stateManager.EmitClaudeStatus(id, string(status))
 			}
+			a.pushRemoteClaudeStatus(id, status)
+			// Separate event for notifications: Claude is blocked waiting for the user
+			if status.IsBlocked() {
+				projectID := ""
+				if a.stateManager != nil {
+					projectID, _ = a.stateManager.GetTerminalByID(id)
+				}
+				runtime.EventsEmit(a.ctx, "claude-blocked", map[string]string{
+					"projectId":  projectID,
+					"terminalId": id,
+					"status":     string(status),
+				})
+				a.notifyRemoteBlocked(projectID, id, status)
+			}
+		}
+		if session, changed := a.claudeDetector.ConsumeSessionChange(id); changed && a.stateManager != nil {
+			a.stateManager.SetTerminalClaudeSession(id, session.Model, session.Mode)
+		}
+		// Context window estimate, so the UI can warn before auto-compaction
+		if usage, changed := a.claudeDetector.ConsumeContextChange(id); changed {
+			projectID := ""
+			if a.stateManager != nil {
+				projectID, _ = a.stateManager.GetTerminalByID(id)
+			}
+			runtime.EventsEmit(a.ctx, "claude-context-usage", map[string]interface{}{
+				"projectId":  projectID,
+				"terminalId": id,
+				"usage":      usage,
+			})
 		}
 	}
 
@@ -331,6 +477,7 @@ func (a *App) onTerminalOutput(id string, data []byte) {
 				"terminalId": id,
 				"summary":    summary,
 			})
+			a.pushRemoteTestStatus(id, summary)
 		}
 	}
 
@@ -346,6 +493,156 @@ func (a *App) onTerminalOutput(id string, data []byte) {
 	}
 }
 
+// testNotifyDelay lets runs that finish more than once (several packages or
+// test binaries) settle into one notification
+const testNotifyDelay = 2 * time.Second
+
+// onTestRunFinished schedules a notification for a test run that finished in
+// a terminal the user isn't looking at
+func (a *App) onTestRunFinished(termID string, summary testing.TestSummary) {
+	if a.stateManager == nil {
+		return
+	}
+	projectID, term := a.stateManager.GetTerminalByID(termID)
+	if term == nil {
+		return // Bisect and watch mode runs aren't terminals
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.testNotifyTimers == nil {
+		a.testNotifyTimers = make(map[string]*time.Timer)
+	}
+	if timer, ok := a.testNotifyTimers[termID]; ok {
+		timer.Stop()
+	}
+	a.testNotifyTimers[termID] = time.AfterFunc(testNotifyDelay, func() {
+		a.mu.Lock()
+		delete(a.testNotifyTimers, termID)
+		a.mu.Unlock()
+		a.notifyTestRun(projectID, termID, summary)
+		if summary.Failed > 0 {
+			a.notifyRemoteTestFailure(projectID, termID, summary)
+		}
+	})
+}
+
+// notifyTestRun shows a native notification for a finished test run, unless
+// the project turned them off or the terminal is in front of the user
+func (a *App) notifyTestRun(projectID, termID string, summary testing.TestSummary) {
+	mode := a.stateManager.GetProjectTestNotifications(projectID)
+	if mode == state.TestNotifyOff || (mode == state.TestNotifyFailures && summary.Failed == 0) {
+		return
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return
+	}
+	a.mu.RLock()
+	focused := !a.windowBlurred
+	a.mu.RUnlock()
+	if focused && a.stateManager.GetActiveProjectID() == projectID &&
+		(project.ActiveTerminalID == "" || project.ActiveTerminalID == termID) {
+		return
+	}
+
+	title := fmt.Sprintf("%s: tests passed", project.Name)
+	if summary.Failed > 0 {
+		title = fmt.Sprintf("%s: %d failed", project.Name, summary.Failed)
+	}
+	message := fmt.Sprintf("%d passed, %d failed", summary.Passed, summary.Failed)
+	if summary.Skipped > 0 {
+		message += fmt.Sprintf(", %d skipped", summary.Skipped)
+	}
+	if summary.Duration > 0 {
+		message += fmt.Sprintf(" in %.1fs", summary.Duration/1000)
+	}
+	if a.terminalManager != nil {
+		if term := a.terminalManager.Get(termID); term != nil {
+			message += " (" + term.Info().Name + ")"
+		}
+	}
+
+	err := notify.Send(title, message, func() {
+		// Bring the window forward; the frontend switches to the project and terminal
+		runtime.WindowUnminimise(a.ctx)
+		runtime.WindowShow(a.ctx)
+		a.stateManager.SetActiveTerminal(projectID, termID)
+		runtime.EventsEmit(a.ctx, "test-notification-clicked", map[string]string{
+			"projectId":  projectID,
+			"terminalId": termID,
+		})
+	})
+	if err != nil {
+		logging.Warn("Test notification failed", "error", err)
+	}
+}
+
+// remoteTerminalLabel names a terminal for remote notifications: its project
+// and, when known, the terminal's name
+func (a *App) remoteTerminalLabel(projectID, termID string) string {
+	label := "Claudilandia"
+	if a.stateManager != nil {
+		if project := a.stateManager.GetProject(projectID); project != nil {
+			label = project.Name
+		}
+	}
+	if a.terminalManager != nil {
+		if term := a.terminalManager.Get(termID); term != nil {
+			label += " (" + term.Info().Name + ")"
+		}
+	}
+	return label
+}
+
+// notifyRemoteBlocked tells remote devices that Claude is waiting for the user
+func (a *App) notifyRemoteBlocked(projectID, termID string, status claude.Status) {
+	body := "Claude is waiting for your input"
+	switch status {
+	case claude.StatusWaitingPermission:
+		body = "Claude is asking for permission"
+	case claude.StatusRateLimited:
+		body = "Claude hit the rate limit"
+	}
+	a.notifyRemote(projectID, a.remoteTerminalLabel(projectID, termID), body, "claude-"+termID)
+}
+
+// pushRemoteClaudeStatus sends a terminal's Claude status to remote clients.
+// Claude stopping usually means it changed files, so the project's git status
+// goes along.
+func (a *App) pushRemoteClaudeStatus(termID string, status claude.Status) {
+	if a.remoteServer == nil || !a.remoteServer.IsRunning() || a.stateManager == nil {
+		return
+	}
+	projectID, _ := a.stateManager.GetTerminalByID(termID)
+	if projectID == "" {
+		return
+	}
+	a.remoteServer.BroadcastClaudeStatus(projectID, termID, string(status))
+	if status == claude.StatusIdle || status.IsBlocked() {
+		go a.remoteServer.BroadcastGitStatus(projectID)
+	}
+}
+
+// pushRemoteTestStatus sends a terminal's test run to remote clients; a nil
+// summary forgets the terminal
+func (a *App) pushRemoteTestStatus(termID string, summary *testing.TestSummary) {
+	if a.remoteServer == nil || !a.remoteServer.IsRunning() || a.stateManager == nil {
+		return
+	}
+	projectID, _ := a.stateManager.GetTerminalByID(termID)
+	if projectID == "" && summary != nil {
+		return // Bisect and watch mode runs aren't terminals
+	}
+	a.remoteServer.BroadcastTestStatus(projectID, termID, summary)
+}
+
+// notifyRemoteTestFailure tells remote devices that a test run failed
+func (a *App) notifyRemoteTestFailure(projectID, termID string, summary testing.TestSummary) {
+	body := fmt.Sprintf("%d failed, %d passed", summary.Failed, summary.Passed)
+	a.notifyRemote(projectID, a.remoteTerminalLabel(projectID, termID), body, "tests-"+termID)
+}
+
 func (a *App) onTerminalExit(id string) {
 	// Clean up Claude detector state for this terminal
 	if a.claudeDetector != nil {
@@ -353,11 +650,16 @@ func (a *App) onTerminalExit(id string) {
 		if a.stateManager != nil {
 			a.stateManager.EmitClaudeStatus(id, string(claude.StatusNone))
 		}
+		a.pushRemoteClaudeStatus(id, claude.StatusNone)
 	}
 	// Clean up test watcher state for this terminal
 	if a.testWatcher != nil {
 		a.testWatcher.RemoveTerminal(id)
 	}
+	a.pushRemoteTestStatus(id, nil)
+	if a.remoteServer != nil {
+		a.remoteServer.ForgetOutput(id)
+	}
 	if a.stateManager != nil {
 		a.stateManager.EmitTerminalExit(id)
 	}
@@ -423,7 +725,12 @@ func (a *App) DeleteProject(id string) error {
 func (a *App) SetActiveProject(id string) {
 	if a.stateManager != nil {
 		a.stateManager.SetActiveProject(id)
+		// Without a local daemon only remote contexts are worth connecting to
+		if dockerContext := a.stateManager.GetProjectDockerContext(id); a.dockerManager != nil || dockerContext != "" {
+			a.switchDockerContext(dockerContext)
+		}
 	}
+	a.scheduleServicesHealth()
 }
 
 // GetActiveProject returns the active project ID
@@ -962,48 +1269,9 @@ func (a *App) StartVoiceRecognition(lang string) string {
 		a.voiceStdin = nil
 	}
 
-	// Find the voice_input binary using same candidate pattern as Python bridge
-	execPath, _ := os.Executable()
-	baseDir := filepath.Dir(execPath)
-	candidates := []string{
-		filepath.Join(baseDir, "..", "..", "..", "..", "..", "scripts", "voice_input"),
-		filepath.Join(baseDir, "..", "..", "scripts", "voice_input"),
-		filepath.Join(baseDir, "scripts", "voice_input"),
-	}
-
-	var binaryPath string
-	for _, p := range candidates {
-		if _, err := os.Stat(p); err == nil {
-			binaryPath = p
-			break
-		}
-	}
-
-	if binaryPath == "" {
-		// Try to compile it
-		sourceCandidates := []string{
-			filepath.Join(baseDir, "..", "..", "..", "..", "..", "scripts", "voice_input.swift"),
-			filepath.Join(baseDir, "..", "..", "scripts", "voice_input.swift"),
-			filepath.Join(baseDir, "scripts", "voice_input.swift"),
-		}
-		var sourcePath string
-		for _, p := range sourceCandidates {
-			if _, err := os.Stat(p); err == nil {
-				sourcePath = p
-				break
-			}
-		}
-		if sourcePath == "" {
-			return "ERROR: voice_input.swift not found"
-		}
-
-		targetPath := sourcePath[:len(sourcePath)-6] // strip .swift
-		logging.Info("Compiling voice_input", "source", sourcePath, "target", targetPath)
-		cmd := exec.Command("swiftc", "-O", "-o", targetPath, sourcePath, "-framework", "Speech", "-framework", "AVFoundation")
-		if out, err := cmd.CombinedOutput(); err != nil {
-			return "ERROR: compile failed: " + string(out)
-		}
-		binaryPath = targetPath
+	binaryPath, err := voiceInputBinary()
+	if err != nil {
+		return "ERROR: " + err.Error()
 	}
 
 	if lang == "" {
@@ -1043,6 +1311,51 @@ func (a *App) StartVoiceRecognition(lang string) string {
 	return "OK"
 }
 
+// voiceInputBinary finds the voice_input binary using the same candidate
+// pattern as the Python bridge, compiling it when it is missing or older than
+// its source
+func voiceInputBinary() (string, error) {
+	execPath, _ := os.Executable()
+	baseDir := filepath.Dir(execPath)
+	candidates := []string{
+		filepath.Join(baseDir, "..", "..", "..", "..", "..", "scripts", "voice_input"),
+		filepath.Join(baseDir, "..", "..", "scripts", "voice_input"),
+		filepath.Join(baseDir, "scripts", "voice_input"),
+	}
+
+	for _, p := range candidates {
+		binary, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		// A binary built before the source changed lacks its newer modes
+		if source, err := os.Stat(p + ".swift"); err == nil && source.ModTime().After(binary.ModTime()) {
+			break
+		}
+		return p, nil
+	}
+
+	// Try to compile it
+	var sourcePath string
+	for _, p := range candidates {
+		if _, err := os.Stat(p + ".swift"); err == nil {
+			sourcePath = p + ".swift"
+			break
+		}
+	}
+	if sourcePath == "" {
+		return "", fmt.Errorf("voice_input.swift not found")
+	}
+
+	targetPath := strings.TrimSuffix(sourcePath, ".swift")
+	logging.Info("Compiling voice_input", "source", sourcePath, "target", targetPath)
+	cmd := exec.Command("swiftc", "-O", "-o", targetPath, sourcePath, "-framework", "Speech", "-framework", "AVFoundation")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("compile failed: %s", out)
+	}
+	return targetPath, nil
+}
+
 // StopVoiceRecognition stops the voice recognition process
 func (a *App) StopVoiceRecognition() {
 	a.voiceMu.Lock()
@@ -1059,6 +1372,102 @@ func (a *App) StopVoiceRecognition() {
 	}
 }
 
+// transcribeAudio transcribes a clip recorded by a remote client: with
+// whisper.cpp when a model is configured, otherwise with voice_input's file
+// mode (macOS speech recognition)
+func transcribeAudio(ctx context.Context, whisperModel, audioPath, lang string) (string, error) {
+	if whisperModel != "" {
+		return transcribeWithWhisper(ctx, whisperModel, audioPath, lang)
+	}
+
+	// Apple's recognizer reads WAV, M4A/AAC and MP3, not the WebM and Ogg
+	// clips Android browsers record
+	if ext := filepath.Ext(audioPath); ext == ".webm" || ext == ".ogg" {
+		wavPath, err := convertToWAV(ctx, audioPath)
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(wavPath)
+		audioPath = wavPath
+	}
+
+	binaryPath, err := voiceInputBinary()
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.CommandContext(ctx, binaryPath, lang, "--file", audioPath).Output()
+	// voice_input reports errors as JSON lines too
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		var msg struct {
+			Type    string `json:"type"`
+			Text    string `json:"text"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil {
+			continue
+		}
+		switch msg.Type {
+		case "final":
+			return msg.Text, nil
+		case "error":
+			return "", fmt.Errorf("%s", msg.Message)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("voice_input failed: %w", err)
+	}
+	return "", fmt.Errorf("voice_input returned no transcript")
+}
+
+// transcribeWithWhisper runs whisper.cpp on a clip. It reads 16 kHz WAV
+// only, so the clip is converted first.
+func transcribeWithWhisper(ctx context.Context, model, audioPath, lang string) (string, error) {
+	if _, err := os.Stat(model); err != nil {
+		return "", fmt.Errorf("whisper model not found: %s", model)
+	}
+	var binaryPath string
+	for _, name := range []string{"whisper-cli", "whisper-cpp"} {
+		if p, err := exec.LookPath(name); err == nil {
+			binaryPath = p
+			break
+		}
+	}
+	if binaryPath == "" {
+		return "", fmt.Errorf("whisper-cli not found (brew install whisper-cpp)")
+	}
+
+	wavPath, err := convertToWAV(ctx, audioPath)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(wavPath)
+
+	// whisper takes the language without its region: "pl-PL" -> "pl"
+	language := strings.ToLower(strings.FieldsFunc(lang, func(r rune) bool { return r == '-' || r == '_' })[0])
+	cmd := exec.CommandContext(ctx, binaryPath, "-m", model, "-f", wavPath, "-l", language, "-nt", "-np")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("whisper failed: %w", err)
+	}
+	return strings.Join(strings.Fields(string(out)), " "), nil
+}
+
+// convertToWAV converts an audio file to 16 kHz mono WAV with ffmpeg. The
+// caller removes the returned file.
+func convertToWAV(ctx context.Context, audioPath string) (string, error) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		return "", fmt.Errorf("ffmpeg is needed to convert %s audio (brew install ffmpeg)", strings.TrimPrefix(filepath.Ext(audioPath), "."))
+	}
+	wavPath := audioPath + ".16k.wav"
+	cmd := exec.CommandContext(ctx, "ffmpeg", "-nostdin", "-loglevel", "error", "-y", "-i", audioPath, "-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le", wavPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(wavPath)
+		return "", fmt.Errorf("audio conversion failed: %s", strings.TrimSpace(string(out)))
+	}
+	return wavPath, nil
+}
+
 // ============================================
 // Agent Teams Methods
 // ============================================
@@ -1099,6 +1508,14 @@ func (a *App) GetTeamHistory() []teams.TeamHistoryEntry {
 	return a.teamsWatcher.GetHistory()
 }
 
+// GetSubagentSessions returns recent sessions that spawned subagents via the Task tool
+func (a *App) GetSubagentSessions() []teams.SessionSubagents {
+	if a.teamsWatcher == nil {
+		return []teams.SessionSubagents{}
+	}
+	return a.teamsWatcher.GetSubagentSessions()
+}
+
 // ============================================
 // Browser Methods
 // ============================================
@@ -1165,14 +1582,109 @@ func (a *App) GetTestHistory(projectID string) []state.TestRun {
 	return a.stateManager.GetTestHistory(projectID)
 }
 
-// AddTestRun adds a single test run to project history
+// AddTestRun adds a single test run to project history, tagged with the
+// code version it ran against so flaky tests can be told from regressions
 func (a *App) AddTestRun(projectID string, run state.TestRun) error {
 	if a.stateManager == nil {
 		return fmt.Errorf("state manager not initialized")
 	}
+	if run.CodeVersion == "" && a.gitManager != nil {
+		if project := a.stateManager.GetProject(projectID); project != nil {
+			run.CodeVersion = a.gitManager.GetWorkTreeVersion(project.Path)
+		}
+	}
 	return a.stateManager.AddTestRun(projectID, run)
 }
 
+// GetTestHistoryLimit returns how many test runs and coverage points a project keeps
+func (a *App) GetTestHistoryLimit(projectID string) int {
+	if a.stateManager == nil {
+		return state.DefaultTestHistoryLimit
+	}
+	return a.stateManager.GetProjectTestHistoryLimit(projectID)
+}
+
+// SetTestHistoryLimit sets how many test runs and coverage points a project keeps
+func (a *App) SetTestHistoryLimit(projectID string, limit int) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	return a.stateManager.SetProjectTestHistoryLimit(projectID, limit)
+}
+
+// ExportTestHistory returns a project's test runs and coverage history as
+// "csv" or "json"
+func (a *App) ExportTestHistory(projectID, format string) (string, error) {
+	if a.stateManager == nil {
+		return "", fmt.Errorf("state manager not initialized")
+	}
+	data, err := a.stateManager.ExportTestHistory(projectID, format)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SaveTestHistoryExport asks where to save a project's exported history and
+// writes it there. Returns the path, or "" when the dialog was cancelled.
+func (a *App) SaveTestHistoryExport(projectID, format string) (string, error) {
+	content, err := a.ExportTestHistory(projectID, format)
+	if err != nil {
+		return "", err
+	}
+	name := "test-history"
+	if project := a.stateManager.GetProject(projectID); project != nil {
+		name = project.Name + "-test-history"
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Test History",
+		DefaultFilename: name + "." + format,
+		Filters:         []runtime.FileFilter{{DisplayName: strings.ToUpper(format), Pattern: "*." + format}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, os.WriteFile(path, []byte(content), 0644)
+}
+
+// recordCoverageHistory saves the latest point of a project's coverage trend
+// so it outlives the session
+func (a *App) recordCoverageHistory(projectPath string) {
+	if a.stateManager == nil {
+		return
+	}
+	projectID := a.stateManager.GetProjectIDByPath(projectPath)
+	entry := a.coverageWatcher.GetLatestHistoryEntry(projectPath)
+	if projectID == "" || entry == nil {
+		return
+	}
+	a.stateManager.AddCoverageRecord(projectID, state.CoverageRecord{
+		Timestamp:     entry.Timestamp,
+		Lines:         entry.Lines,
+		Functions:     entry.Functions,
+		Branches:      entry.Branches,
+		MutationScore: entry.MutationScore,
+	})
+}
+
+// GetTestDurationReport returns the suite duration trend and the limit
+// slowest tests of a project
+func (a *App) GetTestDurationReport(projectID string, limit int) state.TestDurationReport {
+	if a.stateManager == nil {
+		return state.TestDurationReport{Runs: []state.RunDuration{}, Slowest: []state.SlowTest{}}
+	}
+	return a.stateManager.GetTestDurationReport(projectID, limit)
+}
+
+// GetFlakyTests returns the tests that both passed and failed without code
+// changes in between, according to the project's test history
+func (a *App) GetFlakyTests(projectID string) []state.FlakyTest {
+	if a.stateManager == nil {
+		return []state.FlakyTest{}
+	}
+	return a.stateManager.GetFlakyTests(projectID)
+}
+
 // ============================================
 // Prompt Methods
 // ============================================
@@ -1301,109 +1813,1087 @@ func (a *App) IsDockerAvailable() bool {
 	return a.dockerManager.IsAvailable()
 }
 
-// GetContainers returns all containers
-func (a *App) GetContainers(all bool) ([]docker.Container, error) {
-	if a.dockerManager == nil {
-		return nil, fmt.Errorf("docker not available")
+// GetDockerHost returns the daemon address in use and the configured override
+func (a *App) GetDockerHost() map[string]string {
+	result := map[string]string{"active": "", "configured": ""}
+	if a.stateManager != nil {
+		result["configured"] = a.stateManager.GetDockerHost()
 	}
-	return a.dockerManager.ListContainers(all)
+	if a.dockerManager != nil {
+		result["active"] = a.dockerManager.Host()
+	}
+	return result
 }
 
-// GetDockerProjectContainers returns containers for current project
-func (a *App) GetDockerProjectContainers(projectName string) ([]docker.Container, error) {
-	if a.dockerManager == nil {
-		return nil, fmt.Errorf("docker not available")
-	}
-	return a.dockerManager.ListContainersForProject(projectName)
+// DetectDockerHosts lists known Docker, Podman, Colima, OrbStack and Rancher
+// Desktop sockets and whether they respond
+func (a *App) DetectDockerHosts() []docker.HostCandidate {
+	return docker.DetectHosts()
 }
 
-// StartContainer starts a container
-func (a *App) StartContainer(id string) error {
-	if a.dockerManager == nil {
+// SetDockerHost saves a custom daemon address (empty to autodetect) and reconnects
+// when the default context is in use
+func (a *App) SetDockerHost(host string) error {
+	host = strings.TrimSpace(host)
+	mgr, err := docker.NewManagerWithHost(host)
+	if err != nil {
+		return err
+	}
+	if !mgr.IsAvailable() {
+		mgr.Close()
+		if host == "" {
+			return fmt.Errorf("no running Docker daemon found")
+		}
+		return fmt.Errorf("docker daemon not reachable at %s", host)
+	}
+
+	if a.stateManager != nil {
+		a.stateManager.SetDockerHost(host)
+	}
+	if a.dockerManager != nil && a.dockerManager.Context() != docker.DefaultContext {
+		// The setting applies the next time the default context is selected
+		mgr.Close()
+		return nil
+	}
+	a.replaceDockerManager(mgr)
+	return nil
+}
+
+// GetDockerContexts lists the Docker CLI contexts (local and remote daemons)
+func (a *App) GetDockerContexts() ([]docker.DockerContext, error) {
+	return docker.ListContexts()
+}
+
+// GetActiveDockerContext returns the Docker context currently connected
+func (a *App) GetActiveDockerContext() string {
+	if a.dockerManager == nil {
+		return ""
+	}
+	return a.dockerManager.Context()
+}
+
+// SetProjectDockerContext selects the Docker context used while a project is
+// active and switches to it if the project is the active one
+func (a *App) SetProjectDockerContext(projectID, contextName string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	if contextName == docker.DefaultContext {
+		contextName = ""
+	}
+	if err := a.stateManager.SetProjectDockerContext(projectID, contextName); err != nil {
+		return fmt.Errorf("project not found")
+	}
+	if projectID != a.stateManager.GetActiveProjectID() {
+		return nil
+	}
+	return a.switchDockerContext(contextName)
+}
+
+// switchDockerContext connects to the given context unless already connected
+func (a *App) switchDockerContext(contextName string) error {
+	if contextName == "" {
+		contextName = docker.DefaultContext
+	}
+	if a.dockerManager != nil && a.dockerManager.Context() == contextName {
+		return nil
+	}
+
+	host := ""
+	if a.stateManager != nil {
+		host = a.stateManager.GetDockerHost()
+	}
+	mgr, err := docker.NewManagerForContext(contextName, host)
+	if err != nil {
+		logging.Warn("Failed to switch Docker context", "context", contextName, "error", err)
+		return err
+	}
+	a.replaceDockerManager(mgr)
+	return nil
+}
+
+// replaceDockerManager swaps in a new Docker connection and notifies the frontend
+func (a *App) replaceDockerManager(mgr *docker.Manager) {
+	if a.dockerManager != nil {
+		a.dockerManager.StopWatchingEvents()
+		a.dockerManager.StopAllLogFollowers()
+		a.dockerManager.Close()
+	}
+	a.dockerManager = mgr
+	a.watchDockerEvents()
+	logging.Info("Docker manager reconnected", "context", mgr.Context(), "host", mgr.Host())
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "docker-context-changed", map[string]string{
+			"context": mgr.Context(),
+			"host":    mgr.Host(),
+		})
+	}
+}
+
+// watchDockerEvents pushes container lifecycle and health changes to the
+// frontend as docker-container-event, so the container list stays live
+func (a *App) watchDockerEvents() {
+	a.dockerManager.WatchEvents(func(event docker.ContainerEvent) {
+		if a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "docker-container-event", event)
+		}
+		if event.Project != "" {
+			a.scheduleServicesHealth()
+		}
+	})
+}
+
+// GetProjectServicesHealth aggregates the health of a project's compose services
+func (a *App) GetProjectServicesHealth(projectID string) (*docker.ServicesHealth, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	return a.dockerManager.GetServicesHealth(project.Path)
+}
+
+// scheduleServicesHealth emits project-services-health for the active project,
+// coalescing bursts of container events (e.g. compose up) into one update
+func (a *App) scheduleServicesHealth() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.healthTimer != nil {
+		a.healthTimer.Stop()
+	}
+	a.healthTimer = time.AfterFunc(500*time.Millisecond, a.emitServicesHealth)
+}
+
+// emitServicesHealth sends the active project's aggregated service health
+func (a *App) emitServicesHealth() {
+	if a.ctx == nil || a.stateManager == nil {
+		return
+	}
+	projectID := a.stateManager.GetActiveProjectID()
+	if projectID == "" {
+		return
+	}
+	health, err := a.GetProjectServicesHealth(projectID)
+	if err != nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "project-services-health", map[string]interface{}{
+		"projectId": projectID,
+		"health":    health,
+	})
+}
+
+// GetContainers returns all containers
+func (a *App) GetContainers(all bool) ([]docker.Container, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.ListContainers(all)
+}
+
+// GetDockerProjectContainers returns containers for current project
+func (a *App) GetDockerProjectContainers(projectName string) ([]docker.Container, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.ListContainersForProject(projectName)
+}
+
+// GetDockerURLSuggestions returns localhost URLs for ports published by the
+// project's running containers, for the browser tab and bookmarks
+func (a *App) GetDockerURLSuggestions(projectName string) []docker.URLSuggestion {
+	if a.dockerManager == nil {
+		return []docker.URLSuggestion{}
+	}
+	containers, err := a.dockerManager.ListContainersForProject(projectName)
+	if err != nil {
+		return []docker.URLSuggestion{}
+	}
+	return docker.SuggestURLs(containers)
+}
+
+// StartContainer starts a container
+func (a *App) StartContainer(id string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.StartContainer(id)
+}
+
+// StopContainer stops a container
+func (a *App) StopContainer(id string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.StopContainer(id)
+}
+
+// RestartContainer restarts a container
+func (a *App) RestartContainer(id string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.RestartContainer(id)
+}
+
+// RemoveContainer removes a container; force also removes a running one
+func (a *App) RemoveContainer(id string, force bool) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	a.dockerManager.StopFollowingLogs(id)
+	return a.dockerManager.RemoveContainer(id, force)
+}
+
+// PruneDockerSystem removes stopped containers, unused networks, images and build
+// cache (and anonymous volumes when requested). dryRun only reports what would go.
+func (a *App) PruneDockerSystem(dryRun, allImages, volumes bool) (*docker.SystemPruneReport, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.SystemPrune(dryRun, allImages, volumes)
+}
+
+// InspectContainer returns a container's env (secrets masked), mounts, labels,
+// networks and command for the details drawer
+func (a *App) InspectContainer(id string) (*docker.ContainerDetails, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.InspectContainer(id)
+}
+
+// GetContainerResources returns a container's restart policy and CPU/memory limits
+func (a *App) GetContainerResources(id string) (*docker.ContainerResources, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.GetContainerResources(id)
+}
+
+// UpdateContainerResources changes a container's restart policy and limits in place.
+// Returns warnings reported by the daemon.
+func (a *App) UpdateContainerResources(id string, resources docker.ContainerResources) ([]string, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.UpdateContainerResources(id, resources)
+}
+
+// ListContainerFiles lists a directory inside a running container
+func (a *App) ListContainerFiles(id, dir string) ([]docker.ContainerFile, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.ListContainerFiles(id, dir)
+}
+
+// CopyFromContainer copies a file or directory out of a container into a host directory
+func (a *App) CopyFromContainer(id, containerPath, hostDir string) (*docker.CopyResult, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	result, err := a.dockerManager.CopyFromContainer(id, containerPath, hostDir)
+	if err != nil {
+		logging.Warn("Copy from container failed", "container", id, "error", err)
+	}
+	return result, err
+}
+
+// CopyToContainer copies a host file or directory into a directory inside a container
+func (a *App) CopyToContainer(id, hostPath, containerDir string) (*docker.CopyResult, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	result, err := a.dockerManager.CopyToContainer(id, hostPath, containerDir)
+	if err != nil {
+		logging.Warn("Copy to container failed", "container", id, "path", logging.MaskPath(hostPath), "error", err)
+	}
+	return result, err
+}
+
+// GetContainerLogs gets container logs
+func (a *App) GetContainerLogs(id string) (string, error) {
+	if a.dockerManager == nil {
+		return "", fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.GetContainerLogs(id, 100)
+}
+
+// FollowContainerLogs streams a container's logs as "docker-log" events
+func (a *App) FollowContainerLogs(id string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.FollowLogs(id, 100, func(line docker.LogLine) {
+		runtime.EventsEmit(a.ctx, "docker-log", line)
+	})
+}
+
+// StopContainerLogs stops streaming logs for a container
+func (a *App) StopContainerLogs(id string) {
+	if a.dockerManager != nil {
+		a.dockerManager.StopFollowingLogs(id)
+	}
+}
+
+// FollowComposeLogs streams the combined logs of a project's compose services.
+// Lines are emitted as "docker-compose-log" events tagged with service and color.
+func (a *App) FollowComposeLogs(projectID string) ([]docker.ComposeLogService, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	return a.dockerManager.FollowComposeLogs(project.Path, 100, func(line docker.ComposeLogLine) {
+		runtime.EventsEmit(a.ctx, "docker-compose-log", map[string]interface{}{
+			"projectId":   projectID,
+			"project":     line.Project,
+			"service":     line.Service,
+			"containerId": line.ContainerID,
+			"color":       line.Color,
+			"stream":      line.Stream,
+			"line":        line.Line,
+		})
+	})
+}
+
+// StopComposeLogs stops the combined compose logs stream of a project
+func (a *App) StopComposeLogs(projectID string) {
+	if a.dockerManager == nil || a.stateManager == nil {
+		return
+	}
+	if project := a.stateManager.GetProject(projectID); project != nil {
+		a.dockerManager.StopComposeLogs(project.Path)
+	}
+}
+
+// GetDockerImages returns local images
+func (a *App) GetDockerImages() ([]docker.Image, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.ListImages()
+}
+
+// PullDockerImage pulls an image in the background, emitting "docker-image-pull"
+// progress events and a final "docker-image-pull-complete" event
+func (a *App) PullDockerImage(ref string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+
+	go func() {
+		err := a.dockerManager.PullImage(ref, func(p docker.Progress) {
+			runtime.EventsEmit(a.ctx, "docker-image-pull", p)
+		})
+		result := map[string]interface{}{"ref": ref}
+		if err != nil {
+			logging.Warn("Image pull failed", "ref", ref, "error", err)
+			result["error"] = err.Error()
+		}
+		runtime.EventsEmit(a.ctx, "docker-image-pull-complete", result)
+	}()
+
+	return nil
+}
+
+// GetDockerRegistries returns the registries logged in to from the app
+func (a *App) GetDockerRegistries() []state.DockerRegistry {
+	if a.stateManager == nil {
+		return []state.DockerRegistry{}
+	}
+	return a.stateManager.GetDockerRegistries()
+}
+
+// DockerRegistryLogin verifies registry credentials and stores them in the keychain
+func (a *App) DockerRegistryLogin(server, username, password string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+	host, err := a.dockerManager.RegistryLogin(server, username, password)
+	if err != nil {
+		return err
+	}
+	if a.stateManager != nil {
+		a.stateManager.SaveDockerRegistry(host, username)
+	}
+	return nil
+}
+
+// DockerRegistryLogout removes stored credentials for a registry
+func (a *App) DockerRegistryLogout(server string) error {
+	if err := docker.RegistryLogout(server); err != nil {
+		return err
+	}
+	if a.stateManager != nil {
+		a.stateManager.RemoveDockerRegistry(docker.NormalizeRegistry(server))
+	}
+	return nil
+}
+
+// PushImage pushes a tagged image to its registry in the background.
+// Progress is emitted as "docker-image-push" events and the result as
+// "docker-image-push-complete".
+func (a *App) PushImage(tag string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+
+	go func() {
+		err := a.dockerManager.PushImage(tag, func(p docker.Progress) {
+			runtime.EventsEmit(a.ctx, "docker-image-push", p)
+		})
+		result := map[string]interface{}{"ref": tag}
+		if err != nil {
+			logging.Warn("Image push failed", "ref", tag, "error", err)
+			result["error"] = err.Error()
+		}
+		runtime.EventsEmit(a.ctx, "docker-image-push-complete", result)
+	}()
+
+	return nil
+}
+
+// BuildImage builds an image from a project Dockerfile in the background.
+// Output lines are emitted as "docker-build-output" events and the result
+// (success, error, final image size) as "docker-build-complete".
+func (a *App) BuildImage(projectPath, dockerfile, tag string) error {
+	if a.dockerManager == nil {
+		return fmt.Errorf("docker not available")
+	}
+
+	go func() {
+		result, err := a.dockerManager.BuildImage(context.Background(), projectPath, dockerfile, tag, func(line string) {
+			runtime.EventsEmit(a.ctx, "docker-build-output", map[string]interface{}{
+				"tag":  tag,
+				"line": line,
+			})
+		})
+		if err != nil {
+			result = &docker.BuildResult{Tag: tag, Error: err.Error()}
+		}
+		if !result.Success {
+			logging.Warn("Image build failed", "tag", tag, "error", result.Error)
+		}
+		runtime.EventsEmit(a.ctx, "docker-build-complete", result)
+	}()
+
+	return nil
+}
+
+// RemoveDockerImage removes an image
+func (a *App) RemoveDockerImage(id string, force bool) ([]string, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.RemoveImage(id, force)
+}
+
+// PruneDockerImages removes dangling images, or all unused images when all is set
+func (a *App) PruneDockerImages(all bool) (*docker.PruneResult, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	return a.dockerManager.PruneImages(all)
+}
+
+// OpenContainerShell opens an interactive shell inside a running container as a
+// terminal of the active project, so it gets the usual output streaming and
+// Claude detection
+func (a *App) OpenContainerShell(containerID string) (*TerminalInfo, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+
+	projectID := a.stateManager.GetActiveProjectID()
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("no active project")
+	}
+
+	command, containerName, err := a.dockerManager.ShellCommand(containerID)
+	if err != nil {
+		return nil, err
+	}
+	return a.createCommandTerminal(projectID, "docker: "+containerName, project.Path, command)
+}
+
+// createCommandTerminal creates a project terminal running a single command
+func (a *App) createCommandTerminal(projectID, name, workDir, command string) (*TerminalInfo, error) {
+	if a.terminalManager == nil {
+		return nil, fmt.Errorf("terminal manager not initialized")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+
+	termState, err := a.stateManager.CreateTerminal(projectID, name, workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	term, err := a.terminalManager.CreateCommandWithID(termState.ID, termState.Name, workDir, command)
+	if err != nil {
+		a.stateManager.DeleteTerminal(projectID, termState.ID)
+		return nil, err
+	}
+
+	a.stateManager.SetTerminalRunning(projectID, termState.ID, true)
+
+	if a.remoteServer != nil && a.remoteServer.IsRunning() {
+		a.remoteServer.BroadcastTerminalsList()
+	}
+
+	info := term.Info()
+	return &TerminalInfo{
+		ID:        info.ID,
+		ProjectID: projectID,
+		Name:      info.Name,
+		WorkDir:   info.WorkDir,
+		Running:   info.Running,
+	}, nil
+}
+
+// GetDevContainerConfig returns the project's devcontainer.json summary and
+// container state, or nil if the project has no dev container
+func (a *App) GetDevContainerConfig(projectPath string) (*docker.DevContainerConfig, error) {
+	cfg, err := docker.FindDevContainerConfig(projectPath)
+	if err != nil || cfg == nil {
+		return cfg, err
+	}
+	cfg.CLIAvailable = docker.IsDevContainerCLIAvailable()
+	if a.dockerManager != nil {
+		cfg.ContainerID, cfg.Running, _ = a.dockerManager.FindDevContainer(projectPath)
+	}
+	return cfg, nil
+}
+
+// StartDevContainer builds and starts the project's dev container in the
+// background, emitting "devcontainer-output" lines and "devcontainer-complete"
+func (a *App) StartDevContainer(projectPath string) error {
+	if a.dockerManager == nil {
 		return fmt.Errorf("docker not available")
 	}
-	return a.dockerManager.StartContainer(id)
+
+	go func() {
+		result, err := a.dockerManager.DevContainerUp(context.Background(), projectPath, func(line string) {
+			runtime.EventsEmit(a.ctx, "devcontainer-output", map[string]interface{}{
+				"projectPath": projectPath,
+				"line":        line,
+			})
+		})
+		payload := map[string]interface{}{
+			"projectPath": projectPath,
+			"result":      result,
+		}
+		if err != nil {
+			logging.Warn("Dev container start failed", "project", logging.MaskPath(projectPath), "error", err)
+			payload["error"] = err.Error()
+		}
+		runtime.EventsEmit(a.ctx, "devcontainer-complete", payload)
+	}()
+
+	return nil
+}
+
+// OpenDevContainerShell opens a terminal inside the project's running dev
+// container, as the configured remote user in the workspace folder
+func (a *App) OpenDevContainerShell(projectID string) (*TerminalInfo, error) {
+	if a.dockerManager == nil {
+		return nil, fmt.Errorf("docker not available")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, os.ErrNotExist
+	}
+
+	command, _, err := a.dockerManager.DevContainerShellCommand(project.Path)
+	if err != nil {
+		return nil, err
+	}
+	return a.createCommandTerminal(projectID, "devcontainer", project.Path, command)
+}
+
+// ============================================
+// Kubernetes Methods
+// ============================================
+
+// IsK8sAvailable checks if kubectl and a kubeconfig are available
+func (a *App) IsK8sAvailable() bool {
+	if a.k8sManager == nil {
+		return false
+	}
+	return a.k8sManager.IsAvailable()
+}
+
+// GetK8sContexts lists the kubeconfig contexts
+func (a *App) GetK8sContexts() ([]k8s.KubeContext, error) {
+	if a.k8sManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not initialized")
+	}
+	return a.k8sManager.Contexts()
+}
+
+// GetProjectK8sTarget returns the Kubernetes context and namespace of a project
+func (a *App) GetProjectK8sTarget(projectID string) k8s.Target {
+	if a.stateManager == nil {
+		return k8s.Target{}
+	}
+	kubeContext, namespace := a.stateManager.GetProjectK8sTarget(projectID)
+	return k8s.Target{Context: kubeContext, Namespace: namespace}
+}
+
+// SetProjectK8sTarget sets the Kubernetes context and namespace of a project
+func (a *App) SetProjectK8sTarget(projectID, kubeContext, namespace string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	if err := a.stateManager.SetProjectK8sTarget(projectID, strings.TrimSpace(kubeContext), strings.TrimSpace(namespace)); err != nil {
+		return fmt.Errorf("project not found")
+	}
+	return nil
+}
+
+// GetK8sPods lists pods in the project's namespace
+func (a *App) GetK8sPods(projectID string) ([]k8s.Pod, error) {
+	if a.k8sManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not initialized")
+	}
+	return a.k8sManager.ListPods(a.GetProjectK8sTarget(projectID))
+}
+
+// GetK8sDeployments lists deployments in the project's namespace
+func (a *App) GetK8sDeployments(projectID string) ([]k8s.Deployment, error) {
+	if a.k8sManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not initialized")
+	}
+	return a.k8sManager.ListDeployments(a.GetProjectK8sTarget(projectID))
+}
+
+// FollowPodLogs streams a pod's logs as "k8s-log" events until StopPodLogs is called
+func (a *App) FollowPodLogs(projectID, pod, container string) error {
+	if a.k8sManager == nil {
+		return fmt.Errorf("kubernetes manager not initialized")
+	}
+	return a.k8sManager.FollowPodLogs(a.GetProjectK8sTarget(projectID), pod, container, 200, func(line k8s.LogLine) {
+		runtime.EventsEmit(a.ctx, "k8s-log", line)
+	})
+}
+
+// StopPodLogs stops streaming a pod's logs
+func (a *App) StopPodLogs(projectID, pod string) {
+	if a.k8sManager != nil {
+		a.k8sManager.StopPodLogs(a.GetProjectK8sTarget(projectID), pod)
+	}
+}
+
+// OpenPodShell opens a `kubectl exec` shell in a pod as a project terminal
+func (a *App) OpenPodShell(projectID, pod, container string) (*TerminalInfo, error) {
+	if a.k8sManager == nil {
+		return nil, fmt.Errorf("kubernetes manager not initialized")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	command := a.k8sManager.ExecShellCommand(a.GetProjectK8sTarget(projectID), pod, container)
+	return a.createCommandTerminal(projectID, "k8s: "+pod, project.Path, command)
+}
+
+// ============================================
+// Git Methods
+// ============================================
+
+// IsGitRepo checks if a path is a git repository
+func (a *App) IsGitRepo(path string) bool {
+	if a.gitManager == nil {
+		return false
+	}
+	return a.gitManager.IsGitRepo(path)
+}
+
+// GetGitChangedFiles returns list of changed files in repo
+func (a *App) GetGitChangedFiles(path string) ([]git.ChangedFile, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetChangedFiles(path)
+}
+
+// GetGitFileDiff returns the diff for a specific file
+func (a *App) GetGitFileDiff(repoPath, filePath string) (*git.FileDiff, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetFileDiff(repoPath, filePath)
+}
+
+// GetGitFileDiffChunk returns a window of diff lines for files too large for GetGitFileDiff
+func (a *App) GetGitFileDiffChunk(repoPath, filePath string, offset, limit int) (*git.DiffChunk, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetFileDiffChunk(repoPath, filePath, offset, limit)
+}
+
+// GetGitCurrentBranch returns the current branch name
+func (a *App) GetGitCurrentBranch(path string) string {
+	if a.gitManager == nil {
+		return ""
+	}
+	return a.gitManager.GetCurrentBranch(path)
+}
+
+// GetGitStatus returns git status counts (staged, unstaged, untracked)
+func (a *App) GetGitStatus(path string) map[string]int {
+	if a.gitManager == nil {
+		return map[string]int{"staged": 0, "unstaged": 0, "untracked": 0}
+	}
+	staged, unstaged, untracked := a.gitManager.GetStatus(path)
+	return map[string]int{
+		"staged":    staged,
+		"unstaged":  unstaged,
+		"untracked": untracked,
+	}
+}
+
+// GetGitHistory returns commit history for a repository
+func (a *App) GetGitHistory(path string, limit int) ([]git.CommitInfo, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetCommitHistory(path, limit)
+}
+
+// GetGitignore returns the parsed entries of the repository's .gitignore
+func (a *App) GetGitignore(repoPath string) ([]git.GitignoreEntry, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetGitignore(repoPath)
+}
+
+// AddGitignorePatterns appends patterns to .gitignore, skipping duplicates
+func (a *App) AddGitignorePatterns(repoPath string, patterns []string) ([]string, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.AppendGitignore(repoPath, patterns)
+}
+
+// ValidateGitignorePattern returns an error message for an invalid pattern, or "" if valid
+func (a *App) ValidateGitignorePattern(pattern string) string {
+	if err := git.ValidateGitignorePattern(pattern); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// CheckGitIgnore reports whether a path is ignored and which rule matches it
+func (a *App) CheckGitIgnore(repoPath, path string) (*git.IgnoreCheck, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.CheckIgnore(repoPath, path)
+}
+
+// IgnoreGitFile adds a single file to .gitignore (quick action from the changes list)
+func (a *App) IgnoreGitFile(repoPath, filePath string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.IgnoreFile(repoPath, filePath)
+}
+
+// GetGitRepoRoots returns the project's repository and any nested repositories
+// (separately managed packages, vendored repos, submodules) with their status
+func (a *App) GetGitRepoRoots(projectPath string) ([]git.RepoRoot, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.FindRepoRoots(projectPath, 0)
+}
+
+// GitCherryPick applies a commit onto the current branch, reporting conflicts
+func (a *App) GitCherryPick(repoPath, hash string) (*git.PickResult, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.CherryPick(repoPath, hash)
+}
+
+// GitRevert reverts a commit on the current branch, reporting conflicts
+func (a *App) GitRevert(repoPath, hash string) (*git.PickResult, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.Revert(repoPath, hash)
+}
+
+// GitAbortCherryPick aborts a conflicted cherry-pick
+func (a *App) GitAbortCherryPick(repoPath string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.AbortCherryPick(repoPath)
+}
+
+// GitAbortRevert aborts a conflicted revert
+func (a *App) GitAbortRevert(repoPath string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.AbortRevert(repoPath)
+}
+
+// GetGitHooks lists repository git hooks, including husky/lefthook/pre-commit setups
+func (a *App) GetGitHooks(repoPath string) (*git.HooksInfo, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.GetHooks(repoPath)
+}
+
+// GetGitHookTemplates returns the standard hook templates that can be installed
+func (a *App) GetGitHookTemplates() []git.HookTemplate {
+	return git.GetHookTemplates()
+}
+
+// InstallGitHookTemplate installs a hook template into the repository
+func (a *App) InstallGitHookTemplate(repoPath, templateID string, overwrite bool) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.InstallHookTemplate(repoPath, templateID, overwrite)
 }
 
-// StopContainer stops a container
-func (a *App) StopContainer(id string) error {
-	if a.dockerManager == nil {
-		return fmt.Errorf("docker not available")
+// RemoveGitHook removes a hook that was installed from a template
+func (a *App) RemoveGitHook(repoPath, hookName string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
 	}
-	return a.dockerManager.StopContainer(id)
+	return a.gitManager.RemoveHook(repoPath, hookName)
 }
 
-// RestartContainer restarts a container
-func (a *App) RestartContainer(id string) error {
-	if a.dockerManager == nil {
-		return fmt.Errorf("docker not available")
+// GetGitRemoteInfo returns provider metadata parsed from the origin remote URL
+func (a *App) GetGitRemoteInfo(repoPath string) (*forge.RemoteInfo, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.dockerManager.RestartContainer(id)
+	remoteURL, err := a.gitManager.GetRemoteURL(repoPath, "origin")
+	if err != nil {
+		return nil, err
+	}
+	return forge.ParseRemoteURL(remoteURL)
 }
 
-// GetContainerLogs gets container logs
-func (a *App) GetContainerLogs(id string) (string, error) {
-	if a.dockerManager == nil {
-		return "", fmt.Errorf("docker not available")
+// forgeForRepo returns the forge client for the repository's origin remote
+func (a *App) forgeForRepo(repoPath string) (forge.Forge, error) {
+	info, err := a.GetGitRemoteInfo(repoPath)
+	if err != nil {
+		return nil, err
 	}
-	return a.dockerManager.GetContainerLogs(id, 100)
+	return forge.New(info, forge.TokenFromEnv(info.Provider))
 }
 
-// ============================================
-// Git Methods
-// ============================================
+// GetMergeRequests returns open pull/merge requests from GitHub, GitLab or Bitbucket
+func (a *App) GetMergeRequests(repoPath string) ([]forge.MergeRequest, error) {
+	f, err := a.forgeForRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	return f.ListMergeRequests(ctx)
+}
 
-// IsGitRepo checks if a path is a git repository
-func (a *App) IsGitRepo(path string) bool {
+// GetPipelineStatus returns the CI status of the current HEAD commit
+func (a *App) GetPipelineStatus(repoPath string) (*forge.PipelineStatus, error) {
+	f, err := a.forgeForRepo(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	head := a.gitManager.GetHeadHash(repoPath)
+	if head == "" {
+		return nil, fmt.Errorf("repository has no commits")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	return f.GetPipelineStatus(ctx, head)
+}
+
+// GetGitIdentity returns the repository's author identity and signing configuration
+func (a *App) GetGitIdentity(repoPath string) (*git.IdentityStatus, error) {
 	if a.gitManager == nil {
-		return false
+		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.gitManager.IsGitRepo(path)
+	return a.gitManager.GetIdentity(repoPath), nil
 }
 
-// GetGitChangedFiles returns list of changed files in repo
-func (a *App) GetGitChangedFiles(path string) ([]git.ChangedFile, error) {
+// SetGitIdentity sets user.name/user.email for this repository only
+func (a *App) SetGitIdentity(repoPath, name, email string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	return a.gitManager.SetIdentity(repoPath, name, email)
+}
+
+// GetGitCommitSignatures returns signature status for recent commits
+func (a *App) GetGitCommitSignatures(repoPath string, limit int) ([]git.CommitSignature, error) {
 	if a.gitManager == nil {
 		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.gitManager.GetChangedFiles(path)
+	return a.gitManager.GetCommitSignatures(repoPath, limit)
 }
 
-// GetGitFileDiff returns the diff for a specific file
-func (a *App) GetGitFileDiff(repoPath, filePath string) (*git.FileDiff, error) {
+// StartGitBisect starts a bisect session between a bad and a known good revision
+func (a *App) StartGitBisect(repoPath, bad, good string) (*git.BisectStatus, error) {
 	if a.gitManager == nil {
 		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.gitManager.GetFileDiff(repoPath, filePath)
+	return a.gitManager.StartBisect(repoPath, bad, good)
 }
 
-// GetGitCurrentBranch returns the current branch name
-func (a *App) GetGitCurrentBranch(path string) string {
+// MarkGitBisect marks the current bisect commit as good, bad or skip
+func (a *App) MarkGitBisect(repoPath, verdict string) (*git.BisectStatus, error) {
 	if a.gitManager == nil {
-		return ""
+		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.gitManager.GetCurrentBranch(path)
+	return a.gitManager.MarkBisect(repoPath, verdict)
 }
 
-// GetGitStatus returns git status counts (staged, unstaged, untracked)
-func (a *App) GetGitStatus(path string) map[string]int {
+// GetGitBisectStatus returns the state of the current bisect session
+func (a *App) GetGitBisectStatus(repoPath string) *git.BisectStatus {
 	if a.gitManager == nil {
-		return map[string]int{"staged": 0, "unstaged": 0, "untracked": 0}
+		return &git.BisectStatus{Log: []string{}}
 	}
-	staged, unstaged, untracked := a.gitManager.GetStatus(path)
-	return map[string]int{
-		"staged":    staged,
-		"unstaged":  unstaged,
-		"untracked": untracked,
+	return a.gitManager.GetBisectStatus(repoPath)
+}
+
+// ResetGitBisect stops any automated run and ends the bisect session
+func (a *App) ResetGitBisect(repoPath string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
 	}
+	a.StopGitBisectRun(repoPath)
+	return a.gitManager.ResetBisect(repoPath)
 }
 
-// GetGitHistory returns commit history for a repository
-func (a *App) GetGitHistory(path string, limit int) ([]git.CommitInfo, error) {
+// RunGitBisect runs the bisect automatically in the background using a shell
+// command, or a package.json script when command is empty. Each step's output
+// goes through the test watcher; progress is emitted as "git-bisect-progress"
+// and the result as "git-bisect-complete".
+func (a *App) RunGitBisect(repoPath, command, script string) error {
+	if a.gitManager == nil {
+		return fmt.Errorf("git manager not initialized")
+	}
+	if command == "" && script != "" {
+		command = "npm run " + shell.Quote(script)
+	}
+	if command == "" {
+		return fmt.Errorf("a test command or script is required")
+	}
+	if !a.gitManager.IsBisecting(repoPath) {
+		return fmt.Errorf("no bisect in progress")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	if a.bisectCancel == nil {
+		a.bisectCancel = make(map[string]context.CancelFunc)
+	}
+	if _, running := a.bisectCancel[repoPath]; running {
+		a.mu.Unlock()
+		cancel()
+		return fmt.Errorf("bisect run already in progress")
+	}
+	a.bisectCancel[repoPath] = cancel
+	a.mu.Unlock()
+
+	watcherID := "bisect:" + repoPath
+
+	go func() {
+		defer func() {
+			a.mu.Lock()
+			delete(a.bisectCancel, repoPath)
+			a.mu.Unlock()
+			cancel()
+			if a.testWatcher != nil {
+				a.testWatcher.RemoveTerminal(watcherID)
+			}
+		}()
+
+		status, err := a.gitManager.RunBisect(ctx, repoPath, command, func(step git.BisectStep) {
+			var summary *testing.TestSummary
+			if a.testWatcher != nil {
+				// Feed line by line, the way terminal output arrives
+				for _, line := range strings.SplitAfter(step.Output, "\n") {
+					summary, _ = a.testWatcher.Analyze(watcherID, []byte(line))
+				}
+				a.testWatcher.ResetTerminal(watcherID)
+			}
+			runtime.EventsEmit(a.ctx, "git-bisect-progress", map[string]interface{}{
+				"repoPath":    repoPath,
+				"step":        step,
+				"testSummary": summary,
+			})
+		})
+
+		result := map[string]interface{}{
+			"repoPath": repoPath,
+			"status":   status,
+		}
+		if err != nil {
+			logging.Warn("Bisect run stopped", "repo", logging.MaskPath(repoPath), "error", err)
+			result["error"] = err.Error()
+		}
+		runtime.EventsEmit(a.ctx, "git-bisect-complete", result)
+	}()
+
+	return nil
+}
+
+// StopGitBisectRun cancels an automated bisect run, leaving the session active
+func (a *App) StopGitBisectRun(repoPath string) {
+	a.mu.Lock()
+	cancel, ok := a.bisectCancel[repoPath]
+	a.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// GetRepoStats returns contributor, commit frequency and churn hotspot statistics
+// for the last `days` days
+func (a *App) GetRepoStats(repoPath string, days int) (*git.RepoStats, error) {
 	if a.gitManager == nil {
 		return nil, fmt.Errorf("git manager not initialized")
 	}
-	return a.gitManager.GetCommitHistory(path, limit)
+	return a.gitManager.GetRepoStats(repoPath, days)
 }
 
 // ============================================
@@ -1445,6 +2935,19 @@ func (a *App) SaveAgentContent(path, content string) error {
 	return a.toolsManager.SaveAgentContent(path, content)
 }
 
+// ValidateAgentContent checks an agent file's frontmatter and returns any issues
+func (a *App) ValidateAgentContent(content string) []claude.AgentIssue {
+	return claude.ValidateAgentContent(content)
+}
+
+// CreateAgent validates and writes a new agent to the project's .claude/agents/
+func (a *App) CreateAgent(projectPath, name string, frontmatter claude.AgentFrontmatter, body string) (*claude.Agent, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.CreateAgent(projectPath, name, frontmatter, body)
+}
+
 // GetClaudemd reads the CLAUDE.md file from a project
 func (a *App) GetClaudemd(projectPath string) string {
 	claudemdPath := filepath.Join(projectPath, "CLAUDE.md")
@@ -1462,6 +2965,72 @@ func (a *App) SaveClaudemd(projectPath, content string) error {
 	return os.WriteFile(claudemdPath, []byte(content), 0644)
 }
 
+// GetClaudemdHierarchy returns the global, project and nested CLAUDE.md files for a project
+func (a *App) GetClaudemdHierarchy(projectPath string) []claude.ClaudemdFile {
+	if a.toolsManager == nil {
+		return []claude.ClaudemdFile{}
+	}
+	files, _ := a.toolsManager.GetClaudemdHierarchy(projectPath)
+	return files
+}
+
+// GetClaudemdFile reads one file from the project's CLAUDE.md hierarchy
+func (a *App) GetClaudemdFile(projectPath, path string) (string, error) {
+	if a.toolsManager == nil {
+		return "", fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.GetClaudemdFile(projectPath, path)
+}
+
+// SaveClaudemdFile saves one file in the project's CLAUDE.md hierarchy
+func (a *App) SaveClaudemdFile(projectPath, path, content string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SaveClaudemdFile(projectPath, path, content)
+}
+
+// GetMemoryFiles returns Claude's auto-memory files for a project and the global memory
+func (a *App) GetMemoryFiles(projectPath string) []claude.MemoryFile {
+	if a.toolsManager == nil {
+		return []claude.MemoryFile{}
+	}
+	files, _ := a.toolsManager.GetMemoryFiles(projectPath)
+	return files
+}
+
+// GetMemoryContent reads a memory file
+func (a *App) GetMemoryContent(projectPath, path string) (string, error) {
+	if a.toolsManager == nil {
+		return "", fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.GetMemoryContent(projectPath, path)
+}
+
+// SaveMemoryContent writes a memory file
+func (a *App) SaveMemoryContent(projectPath, path, content string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SaveMemoryContent(projectPath, path, content)
+}
+
+// GetMemoryChanges returns diffs of memory files changed since they were last reviewed
+func (a *App) GetMemoryChanges(projectPath string) ([]claude.MemoryChange, error) {
+	if a.toolsManager == nil {
+		return []claude.MemoryChange{}, nil
+	}
+	return a.toolsManager.GetMemoryChanges(projectPath)
+}
+
+// AcknowledgeMemoryChanges marks the project's current memory as reviewed
+func (a *App) AcknowledgeMemoryChanges(projectPath string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.AcknowledgeMemoryChanges(projectPath)
+}
+
 // GetAvailableSkills returns skills from the Claude plugins marketplace
 func (a *App) GetAvailableSkills() []claude.Skill {
 	if a.toolsManager == nil {
@@ -1471,56 +3040,266 @@ func (a *App) GetAvailableSkills() []claude.Skill {
 	return skills
 }
 
-// GetInstalledSkills returns the names of skills installed in the project
-func (a *App) GetInstalledSkills(projectPath string) []string {
+// GetInstalledSkills returns the project's skills with their update/modification status
+func (a *App) GetInstalledSkills(projectPath string) []claude.InstalledSkill {
 	if a.toolsManager == nil {
-		return []string{}
+		return []claude.InstalledSkill{}
+	}
+	skills, _ := a.toolsManager.GetInstalledSkills(projectPath)
+	return skills
+}
+
+// InstallSkill copies a skill from a marketplace to the project
+func (a *App) InstallSkill(projectPath, skillName, marketplace string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.InstallSkill(projectPath, skillName, marketplace)
+}
+
+// UninstallSkill removes a skill from the project
+func (a *App) UninstallSkill(projectPath, skillName string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.UninstallSkill(projectPath, skillName)
+}
+
+// GetSkillUpgradeDiff returns the file diffs updating a skill from its source would apply
+func (a *App) GetSkillUpgradeDiff(projectPath, skillName string) ([]claude.SkillFileDiff, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.GetSkillUpgradeDiff(projectPath, skillName)
+}
+
+// GetMarketplaces returns the installed plugin marketplaces
+func (a *App) GetMarketplaces() []claude.Marketplace {
+	if a.toolsManager == nil {
+		return []claude.Marketplace{}
+	}
+	marketplaces, _ := a.toolsManager.GetMarketplaces()
+	return marketplaces
+}
+
+// AddMarketplace adds a custom plugin marketplace repo
+func (a *App) AddMarketplace(source string) (*claude.Marketplace, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.AddMarketplace(source)
+}
+
+// RemoveMarketplace removes a custom plugin marketplace
+func (a *App) RemoveMarketplace(name string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.RemoveMarketplace(name)
+}
+
+// UpdateSkill re-copies a project skill from the marketplace and reports changed files
+func (a *App) UpdateSkill(projectPath, skillName string) (*claude.SkillUpdateReport, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.UpdateSkill(projectPath, skillName)
+}
+
+// GetProjectHooks returns hooks configured in the project
+func (a *App) GetProjectHooks(projectPath string) []claude.Hook {
+	if a.toolsManager == nil {
+		return []claude.Hook{}
+	}
+	hooks, _ := a.toolsManager.GetProjectHooks(projectPath)
+	return hooks
+}
+
+// InstallHook adds a hook to the project's settings.json
+func (a *App) InstallHook(projectPath, hookType string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.InstallHook(projectPath, hookType)
+}
+
+// GetProjectDependencies reads dependencies from package.json
+func (a *App) GetProjectDependencies(projectPath string) map[string]string {
+	if a.toolsManager == nil {
+		return map[string]string{}
+	}
+	deps, _ := a.toolsManager.GetProjectDependencies(projectPath)
+	return deps
+}
+
+// CheckLibraryStatus checks which libraries from a list are installed
+func (a *App) CheckLibraryStatus(projectPath string, libs []string) []claude.LibStatus {
+	if a.toolsManager == nil {
+		return []claude.LibStatus{}
+	}
+	statuses, _ := a.toolsManager.CheckLibraryStatus(projectPath, libs)
+	return statuses
+}
+
+// RunClaudeJob queues a headless `claude -p` job in the project directory
+func (a *App) RunClaudeJob(projectID, prompt, model string, allowedTools []string) (*claude.Job, error) {
+	if a.claudeRunner == nil {
+		return nil, fmt.Errorf("claude runner not initialized")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	return a.claudeRunner.Submit(claude.JobRequest{
+		ProjectID:    projectID,
+		Prompt:       prompt,
+		WorkDir:      project.Path,
+		Model:        model,
+		AllowedTools: allowedTools,
+	})
+}
+
+// CancelClaudeJob cancels a queued or running headless job
+func (a *App) CancelClaudeJob(jobID string) error {
+	if a.claudeRunner == nil {
+		return fmt.Errorf("claude runner not initialized")
+	}
+	return a.claudeRunner.Cancel(jobID)
+}
+
+// GetClaudeJobs returns headless jobs of a project ("" for all), newest first
+func (a *App) GetClaudeJobs(projectID string) []claude.Job {
+	if a.claudeRunner == nil {
+		return []claude.Job{}
+	}
+	return a.claudeRunner.Jobs(projectID)
+}
+
+// GetClaudeJob returns a single headless job including its output
+func (a *App) GetClaudeJob(jobID string) (*claude.Job, error) {
+	if a.claudeRunner == nil {
+		return nil, fmt.Errorf("claude runner not initialized")
+	}
+	job, ok := a.claudeRunner.Job(jobID)
+	if !ok {
+		return nil, fmt.Errorf("job not found")
+	}
+	return job, nil
+}
+
+// ClearClaudeJobs removes finished headless jobs from the history
+func (a *App) ClearClaudeJobs() {
+	if a.claudeRunner != nil {
+		a.claudeRunner.ClearFinished()
+	}
+}
+
+// GetClaudeVersion reports the installed Claude Code version and whether an update is available
+func (a *App) GetClaudeVersion(force bool) (*claude.VersionInfo, error) {
+	if a.claudeDetector == nil {
+		return nil, fmt.Errorf("claude detector not initialized")
+	}
+	return a.claudeDetector.CheckVersion(force)
+}
+
+// UpdateClaudeCLI runs the Claude Code update command in a terminal of the project
+func (a *App) UpdateClaudeCLI(projectID string) (*TerminalInfo, error) {
+	if a.claudeDetector == nil {
+		return nil, fmt.Errorf("claude detector not initialized")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	info, err := a.claudeDetector.CheckVersion(false)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Installed {
+		return nil, fmt.Errorf("claude CLI not found")
+	}
+	return a.createCommandTerminal(projectID, "Claude update", project.Path, info.UpdateCommand)
+}
+
+// GetResumableSessions lists past Claude sessions of a project, newest first
+func (a *App) GetResumableSessions(projectPath string) []claude.ResumableSession {
+	if a.toolsManager == nil {
+		return []claude.ResumableSession{}
 	}
-	skills, _ := a.toolsManager.GetInstalledSkills(projectPath)
-	return skills
+	sessions, _ := a.toolsManager.GetResumableSessions(projectPath)
+	return sessions
 }
 
-// InstallSkill copies a skill from the marketplace to the project
-func (a *App) InstallSkill(projectPath, skillName string) error {
+// ResumeClaudeSession opens a terminal running `claude --resume` for a past
+// session, in the directory the session was started from
+func (a *App) ResumeClaudeSession(projectID, sessionID string) (*TerminalInfo, error) {
 	if a.toolsManager == nil {
-		return fmt.Errorf("tools manager not initialized")
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+
+	command, err := claude.ResumeCommand(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir := project.Path
+	sessions, _ := a.toolsManager.GetResumableSessions(project.Path)
+	for _, s := range sessions {
+		if s.ID == sessionID {
+			workDir = s.WorkDir
+			break
+		}
 	}
-	return a.toolsManager.InstallSkill(projectPath, skillName)
+	return a.createCommandTerminal(projectID, "claude: resume", workDir, command)
 }
 
-// GetProjectHooks returns hooks configured in the project
-func (a *App) GetProjectHooks(projectPath string) []claude.Hook {
+// GetCheckpoints lists the Claude checkpoints of a project's recent sessions, newest first
+func (a *App) GetCheckpoints(projectPath string) []claude.Checkpoint {
 	if a.toolsManager == nil {
-		return []claude.Hook{}
+		return []claude.Checkpoint{}
 	}
-	hooks, _ := a.toolsManager.GetProjectHooks(projectPath)
-	return hooks
+	checkpoints, _ := a.toolsManager.GetCheckpoints(projectPath)
+	return checkpoints
 }
 
-// InstallHook adds a hook to the project's settings.json
-func (a *App) InstallHook(projectPath, hookType string) error {
+// RestoreCheckpoint rolls back the files a session edited after a checkpoint
+func (a *App) RestoreCheckpoint(projectPath, sessionID, checkpointID string) ([]claude.CheckpointFile, error) {
 	if a.toolsManager == nil {
-		return fmt.Errorf("tools manager not initialized")
+		return nil, fmt.Errorf("tools manager not initialized")
 	}
-	return a.toolsManager.InstallHook(projectPath, hookType)
+	return a.toolsManager.RestoreCheckpoint(projectPath, sessionID, checkpointID)
 }
 
-// GetProjectDependencies reads dependencies from package.json
-func (a *App) GetProjectDependencies(projectPath string) map[string]string {
+// GetBackgroundTasks lists the background shells Claude started in a project's recent sessions
+func (a *App) GetBackgroundTasks(projectPath string) []claude.BackgroundTask {
 	if a.toolsManager == nil {
-		return map[string]string{}
+		return []claude.BackgroundTask{}
 	}
-	deps, _ := a.toolsManager.GetProjectDependencies(projectPath)
-	return deps
+	tasks, _ := a.toolsManager.GetBackgroundTasks(projectPath)
+	return tasks
 }
 
-// CheckLibraryStatus checks which libraries from a list are installed
-func (a *App) CheckLibraryStatus(projectPath string, libs []string) []claude.LibStatus {
+// KillBackgroundTask stops a background shell Claude left running
+func (a *App) KillBackgroundTask(projectPath, sessionID, taskID string) error {
 	if a.toolsManager == nil {
-		return []claude.LibStatus{}
+		return fmt.Errorf("tools manager not initialized")
 	}
-	statuses, _ := a.toolsManager.CheckLibraryStatus(projectPath, libs)
-	return statuses
+	return a.toolsManager.KillBackgroundTask(projectPath, sessionID, taskID)
 }
 
 // ============================================
@@ -1578,6 +3357,126 @@ func (a *App) DeleteCommand(path string) error {
 	return a.toolsManager.DeleteCommand(path)
 }
 
+// PreviewCommand renders a command with sample arguments and returns any frontmatter issues
+func (a *App) PreviewCommand(content, arguments string) claude.CommandPreview {
+	return claude.PreviewCommand(content, arguments)
+}
+
+// ============================================
+// Output Styles Methods
+// ============================================
+
+// GetProjectOutputStyles returns output styles from the project's .claude/output-styles/
+func (a *App) GetProjectOutputStyles(projectPath string) []claude.OutputStyle {
+	if a.toolsManager == nil {
+		return []claude.OutputStyle{}
+	}
+	styles, _ := a.toolsManager.GetProjectOutputStyles(projectPath)
+	return styles
+}
+
+// GetGlobalOutputStyles returns output styles from ~/.claude/output-styles/
+func (a *App) GetGlobalOutputStyles() []claude.OutputStyle {
+	if a.toolsManager == nil {
+		return []claude.OutputStyle{}
+	}
+	styles, _ := a.toolsManager.GetGlobalOutputStyles()
+	return styles
+}
+
+// GetOutputStyleContent reads an output style file
+func (a *App) GetOutputStyleContent(path string) string {
+	if a.toolsManager == nil {
+		return ""
+	}
+	content, _ := a.toolsManager.GetOutputStyleContent(path)
+	return content
+}
+
+// SaveOutputStyleContent saves an output style file
+func (a *App) SaveOutputStyleContent(path, content string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SaveOutputStyleContent(path, content)
+}
+
+// GetTemplateOutputStyles returns output styles from the template repo
+func (a *App) GetTemplateOutputStyles() []claude.TemplateItem {
+	if a.toolsManager == nil {
+		return []claude.TemplateItem{}
+	}
+	repoPath := a.toolsManager.GetTemplateRepoPath()
+	if repoPath == "" {
+		return []claude.TemplateItem{}
+	}
+	styles, _ := a.toolsManager.GetTemplateOutputStyles(repoPath)
+	return styles
+}
+
+// InstallOutputStyle copies an output style into the project (or ~/.claude when global)
+func (a *App) InstallOutputStyle(projectPath, sourcePath string, global bool) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.InstallOutputStyle(projectPath, sourcePath, global)
+}
+
+// ============================================
+// Rules Methods
+// ============================================
+
+// GetProjectRules returns rules from the project's .claude/rules/
+func (a *App) GetProjectRules(projectPath string) []claude.Rule {
+	if a.toolsManager == nil {
+		return []claude.Rule{}
+	}
+	rules, _ := a.toolsManager.GetProjectRules(projectPath)
+	return rules
+}
+
+// GetGlobalRules returns rules from ~/.claude/rules/
+func (a *App) GetGlobalRules() []claude.Rule {
+	if a.toolsManager == nil {
+		return []claude.Rule{}
+	}
+	rules, _ := a.toolsManager.GetGlobalRules()
+	return rules
+}
+
+// GetRuleContent reads a rule file
+func (a *App) GetRuleContent(path string) string {
+	if a.toolsManager == nil {
+		return ""
+	}
+	content, _ := a.toolsManager.GetRuleContent(path)
+	return content
+}
+
+// SaveRuleContent saves a rule file
+func (a *App) SaveRuleContent(path, content string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SaveRuleContent(path, content)
+}
+
+// CreateRule creates a rule in the project (or ~/.claude when global)
+func (a *App) CreateRule(projectPath, name, content string, global bool) (*claude.Rule, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.CreateRule(projectPath, name, content, global)
+}
+
+// DeleteRule deletes a rule file
+func (a *App) DeleteRule(path string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.DeleteRule(path)
+}
+
 // ============================================
 // MCP Methods
 // ============================================
@@ -1616,6 +3515,87 @@ func (a *App) RemoveMCPServer(projectPath, name string) error {
 	return a.toolsManager.RemoveMCPServer(projectPath, name)
 }
 
+// AddUserMCPServer adds a user-scope MCP server to ~/.claude.json
+func (a *App) AddUserMCPServer(server claude.MCPServer) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.AddUserMCPServer(server)
+}
+
+// UpdateUserMCPServer replaces (and optionally renames) a user-scope MCP server
+func (a *App) UpdateUserMCPServer(name string, server claude.MCPServer) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.UpdateUserMCPServer(name, server)
+}
+
+// RemoveUserMCPServer removes a user-scope MCP server from ~/.claude.json
+func (a *App) RemoveUserMCPServer(name string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.RemoveUserMCPServer(name)
+}
+
+// TestMCPServer performs the MCP handshake with a server and lists its tools
+func (a *App) TestMCPServer(server claude.MCPServer) (*claude.MCPTestResult, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.TestMCPServer(server)
+}
+
+// GetMCPSecrets returns the names of MCP secrets stored in the keychain
+func (a *App) GetMCPSecrets() []string {
+	if a.toolsManager == nil {
+		return []string{}
+	}
+	names, _ := a.toolsManager.GetMCPSecrets()
+	return names
+}
+
+// SetMCPSecret stores a secret referenced from MCP configs as ${secret:NAME}
+func (a *App) SetMCPSecret(name, value string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SetMCPSecret(name, value)
+}
+
+// DeleteMCPSecret removes an MCP secret from the keychain
+func (a *App) DeleteMCPSecret(name string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.DeleteMCPSecret(name)
+}
+
+// ResolveMCPSecrets returns the server with its ${secret:NAME} placeholders resolved
+func (a *App) ResolveMCPSecrets(server claude.MCPServer) (*claude.MCPServer, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	resolved, err := a.toolsManager.ResolveMCPSecrets(server)
+	if err != nil {
+		return nil, err
+	}
+	return &resolved, nil
+}
+
+// SecureMCPEnv moves the server's plaintext env values into the keychain
+func (a *App) SecureMCPEnv(server claude.MCPServer) (*claude.MCPServer, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	secured, err := a.toolsManager.SecureMCPEnv(server)
+	if err != nil {
+		return nil, err
+	}
+	return &secured, nil
+}
+
 // ============================================
 // Enhanced Hooks Methods
 // ============================================
@@ -1645,55 +3625,195 @@ func (a *App) AddHook(projectPath string, hook claude.Hook) error {
 	return a.toolsManager.AddHook(projectPath, hook)
 }
 
-// RemoveHook removes a hook from project settings
-func (a *App) RemoveHook(projectPath, hookType, matcher string) error {
+// RemoveHook removes a hook from project settings
+func (a *App) RemoveHook(projectPath, hookType, matcher string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.RemoveHook(projectPath, hookType, matcher)
+}
+
+// GetProjectPermissions returns the permission rules of settings.json and settings.local.json
+func (a *App) GetProjectPermissions(projectPath string) []claude.PermissionRule {
+	if a.toolsManager == nil {
+		return []claude.PermissionRule{}
+	}
+	rules, _ := a.toolsManager.GetProjectPermissions(projectPath)
+	return rules
+}
+
+// AddPermissionRule adds an allow, ask or deny rule to the settings file of its source
+func (a *App) AddPermissionRule(projectPath string, rule claude.PermissionRule) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.AddPermissionRule(projectPath, rule)
+}
+
+// RemovePermissionRule removes a rule from the settings file of its source
+func (a *App) RemovePermissionRule(projectPath string, rule claude.PermissionRule) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.RemovePermissionRule(projectPath, rule)
+}
+
+// GetHookScriptContent reads the content of a hook script file
+func (a *App) GetHookScriptContent(projectPath, scriptPath string) string {
+	if a.toolsManager == nil {
+		return ""
+	}
+	content, _ := a.toolsManager.GetHookScriptContent(projectPath, scriptPath)
+	return content
+}
+
+// GetProjectHookScripts returns list of hook scripts in .claude/hooks/ folder
+func (a *App) GetProjectHookScripts(projectPath string) []string {
+	if a.toolsManager == nil {
+		return []string{}
+	}
+	scripts, _ := a.toolsManager.GetProjectHookScripts(projectPath)
+	return scripts
+}
+
+// CreateHookScript creates a new hook script file in .claude/hooks/
+func (a *App) CreateHookScript(projectPath, scriptName, content string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.CreateHookScript(projectPath, scriptName, content)
+}
+
+// DeleteHookScript deletes a hook script file
+func (a *App) DeleteHookScript(projectPath, scriptName string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.DeleteHookScript(projectPath, scriptName)
+}
+
+// InstallTemplateHook installs a hook from template repo to project
+func (a *App) InstallTemplateHook(projectPath string, hook claude.HookEntry) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	repoPath := a.toolsManager.GetTemplateRepoPath()
+	return a.toolsManager.InstallTemplateHook(projectPath, hook, repoPath)
+}
+
+// IsHookLoggingEnabled reports whether the project's hook invocations are being recorded
+func (a *App) IsHookLoggingEnabled(projectPath string) bool {
+	if a.toolsManager == nil {
+		return false
+	}
+	return a.toolsManager.IsHookLoggingEnabled(projectPath)
+}
+
+// SetHookLogging turns recording of the project's hook invocations on or off
+func (a *App) SetHookLogging(projectPath string, enabled bool) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SetHookLogging(projectPath, enabled)
+}
+
+// GetHookLog returns the project's recent hook invocations, newest first
+func (a *App) GetHookLog(projectPath string, limit int) []claude.HookInvocation {
+	if a.toolsManager == nil {
+		return []claude.HookInvocation{}
+	}
+	log, _ := a.toolsManager.GetHookLog(projectPath, limit)
+	return log
+}
+
+// ClearHookLog deletes the project's recorded hook invocations
+func (a *App) ClearHookLog(projectPath string) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.ClearHookLog(projectPath)
+}
+
+// SampleHookPayload returns the JSON Claude would send on stdin for a hook event
+func (a *App) SampleHookPayload(projectPath, eventType, matcher string) string {
+	return claude.SampleHookPayload(projectPath, eventType, matcher)
+}
+
+// TestHook runs a hook's commands with a sample payload (synthesized when empty)
+func (a *App) TestHook(projectPath string, hook claude.HookEntry, samplePayload string) ([]claude.HookTestResult, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.TestHook(projectPath, hook, samplePayload)
+}
+
+// GetStatusLine returns the statusLine setting of the "project", "local" or "user" settings, nil when unset
+func (a *App) GetStatusLine(projectPath, scope string) (*claude.StatusLine, error) {
+	if a.toolsManager == nil {
+		return nil, fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.GetStatusLine(projectPath, scope)
+}
+
+// SetStatusLine writes the statusLine setting; an empty command removes it
+func (a *App) SetStatusLine(projectPath, scope string, statusLine *claude.StatusLine) error {
+	if a.toolsManager == nil {
+		return fmt.Errorf("tools manager not initialized")
+	}
+	return a.toolsManager.SetStatusLine(projectPath, scope, statusLine)
+}
+
+// TestStatusLine runs a statusline command with a sample session and returns its output
+func (a *App) TestStatusLine(projectPath, command string) (*claude.StatusLineTestResult, error) {
 	if a.toolsManager == nil {
-		return fmt.Errorf("tools manager not initialized")
+		return nil, fmt.Errorf("tools manager not initialized")
 	}
-	return a.toolsManager.RemoveHook(projectPath, hookType, matcher)
+	return a.toolsManager.TestStatusLine(projectPath, command)
 }
 
-// GetHookScriptContent reads the content of a hook script file
-func (a *App) GetHookScriptContent(projectPath, scriptPath string) string {
+// ============================================
+// Configuration Profile Methods
+// ============================================
+
+// GetProfiles returns the stored Claude configuration profiles
+func (a *App) GetProfiles() []claude.ClaudeProfile {
 	if a.toolsManager == nil {
-		return ""
+		return []claude.ClaudeProfile{}
 	}
-	content, _ := a.toolsManager.GetHookScriptContent(projectPath, scriptPath)
-	return content
+	profiles, _ := a.toolsManager.GetProfiles()
+	return profiles
 }
 
-// GetProjectHookScripts returns list of hook scripts in .claude/hooks/ folder
-func (a *App) GetProjectHookScripts(projectPath string) []string {
+// CreateProfileFromProject saves a project's Claude configuration as a profile
+func (a *App) CreateProfileFromProject(name, description, projectPath string) (*claude.ClaudeProfile, error) {
 	if a.toolsManager == nil {
-		return []string{}
+		return nil, fmt.Errorf("tools manager not initialized")
 	}
-	scripts, _ := a.toolsManager.GetProjectHookScripts(projectPath)
-	return scripts
+	return a.toolsManager.CreateProfileFromProject(name, description, projectPath)
 }
 
-// CreateHookScript creates a new hook script file in .claude/hooks/
-func (a *App) CreateHookScript(projectPath, scriptName, content string) error {
+// RenameProfile changes a profile's name and description
+func (a *App) RenameProfile(profileID, name, description string) error {
 	if a.toolsManager == nil {
 		return fmt.Errorf("tools manager not initialized")
 	}
-	return a.toolsManager.CreateHookScript(projectPath, scriptName, content)
+	return a.toolsManager.RenameProfile(profileID, name, description)
 }
 
-// DeleteHookScript deletes a hook script file
-func (a *App) DeleteHookScript(projectPath, scriptName string) error {
+// DeleteProfile removes a profile
+func (a *App) DeleteProfile(profileID string) error {
 	if a.toolsManager == nil {
 		return fmt.Errorf("tools manager not initialized")
 	}
-	return a.toolsManager.DeleteHookScript(projectPath, scriptName)
+	return a.toolsManager.DeleteProfile(profileID)
 }
 
-// InstallTemplateHook installs a hook from template repo to project
-func (a *App) InstallTemplateHook(projectPath string, hook claude.HookEntry) error {
+// ApplyProfile installs a profile into the project and reports conflicts
+func (a *App) ApplyProfile(projectPath, profileID string) (*claude.ProfileApplyReport, error) {
 	if a.toolsManager == nil {
-		return fmt.Errorf("tools manager not initialized")
+		return nil, fmt.Errorf("tools manager not initialized")
 	}
-	repoPath := a.toolsManager.GetTemplateRepoPath()
-	return a.toolsManager.InstallTemplateHook(projectPath, hook, repoPath)
+	return a.toolsManager.ApplyProfile(projectPath, profileID)
 }
 
 // ============================================
@@ -2016,6 +4136,87 @@ func (a *App) ResetTestState(terminalID string) {
 	}
 }
 
+// OpenTestFailure opens the file of a test failure at the failing line
+func (a *App) OpenTestFailure(projectPath string, failure testing.TestFailure) error {
+	path := testing.ResolveFailureFile(projectPath, failure.File)
+	if path == "" {
+		return fmt.Errorf("file not found: %s", failure.File)
+	}
+	return openInEditor(path, failure.Line, failure.Column)
+}
+
+// openInEditor opens a file at a line in VS Code, Cursor, Zed or Sublime Text,
+// the first found on the PATH, else in the system's default app for the file
+func openInEditor(path string, line, column int) error {
+	location := path
+	if line > 0 {
+		location = fmt.Sprintf("%s:%d", path, line)
+		if column > 0 {
+			location = fmt.Sprintf("%s:%d", location, column)
+		}
+	}
+	editors := [][]string{
+		{"code", "--goto", location},
+		{"cursor", "--goto", location},
+		{"zed", location},
+		{"subl", location},
+	}
+	var cmd *exec.Cmd
+	for _, editor := range editors {
+		if _, err := exec.LookPath(editor[0]); err == nil {
+			cmd = exec.Command(editor[0], editor[1:]...)
+			break
+		}
+	}
+	if cmd == nil {
+		switch goruntime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", path)
+		case "windows":
+			cmd = exec.Command("cmd", "/c", "start", "", path)
+		default:
+			cmd = exec.Command("xdg-open", path)
+		}
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// GetTestNotifications returns when a project notifies about test runs
+// finishing in the background: "all", "failures" or "off"
+func (a *App) GetTestNotifications(projectID string) string {
+	if a.stateManager == nil {
+		return state.TestNotifyAll
+	}
+	return a.stateManager.GetProjectTestNotifications(projectID)
+}
+
+// SetTestNotifications sets when a project notifies about test runs
+// finishing in the background
+func (a *App) SetTestNotifications(projectID, mode string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	switch mode {
+	case state.TestNotifyAll, state.TestNotifyFailures, state.TestNotifyOff:
+	default:
+		return fmt.Errorf("unknown notification mode: %s", mode)
+	}
+	return a.stateManager.SetProjectTestNotifications(projectID, mode)
+}
+
+// SetWindowFocused records whether the app window has focus, so finished
+// test runs only notify while the user looks elsewhere
+func (a *App) SetWindowFocused(focused bool) {
+	a.mu.Lock()
+	a.windowBlurred = !focused
+	a.mu.Unlock()
+}
+
 // ============================================
 // Coverage Watcher Methods
 // ============================================
@@ -2042,6 +4243,48 @@ func (a *App) GetProjectCoverage(projectPath string) *testing.CoverageSummary {
 	return a.coverageWatcher.GetCoverage(projectPath)
 }
 
+// GetFileCoverage returns the function and line coverage of one file, nil
+// when the project's latest coverage report doesn't include it
+func (a *App) GetFileCoverage(projectPath, file string) *testing.FileCoverage {
+	if a.coverageWatcher == nil {
+		return nil
+	}
+	return a.coverageWatcher.GetFileCoverage(projectPath, file)
+}
+
+// GetChangeCoverage returns how much of the code changed since the current
+// branch left base (the default branch when empty) the latest coverage report
+// covers, uncommitted and untracked changes included
+func (a *App) GetChangeCoverage(projectPath, base string) (*testing.ChangeCoverage, error) {
+	if a.gitManager == nil {
+		return nil, fmt.Errorf("git manager not initialized")
+	}
+	if a.coverageWatcher == nil {
+		return nil, fmt.Errorf("coverage watcher not initialized")
+	}
+	if base == "" {
+		base = a.gitManager.DefaultBaseBranch(projectPath)
+		if base == "" {
+			return nil, fmt.Errorf("no main or master branch to compare against")
+		}
+	}
+	changes, err := a.gitManager.GetBranchChanges(projectPath, base)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[string][]int, len(changes.Files))
+	for _, file := range changes.Files {
+		lines[file.Path] = file.Lines
+	}
+	coverage := a.coverageWatcher.GetChangeCoverage(projectPath, lines)
+	if coverage == nil {
+		return nil, fmt.Errorf("no coverage report found")
+	}
+	coverage.Base = base
+	return coverage, nil
+}
+
 // GetProjectCoverageHistory returns coverage history for trending
 func (a *App) GetProjectCoverageHistory(projectPath string) *testing.CoverageHistory {
 	if a.coverageWatcher == nil {
@@ -2057,16 +4300,293 @@ func (a *App) CheckProjectCoverage(projectPath string) {
 	}
 }
 
+// RunMutationTests runs Stryker (JS/TS) or go-mutesting (Go) on a project in
+// the background; progress and the score are sent as "mutation-update"
+func (a *App) RunMutationTests(projectPath string) error {
+	if a.coverageWatcher == nil {
+		return fmt.Errorf("coverage watcher not initialized")
+	}
+	return a.coverageWatcher.RunMutationTests(projectPath)
+}
+
+// StopMutationTests stops a project's mutation run
+func (a *App) StopMutationTests(projectPath string) {
+	if a.coverageWatcher != nil {
+		a.coverageWatcher.StopMutationTests(projectPath)
+	}
+}
+
+// GetMutationSummary returns the latest mutation testing result of a project,
+// from a run in the app or a report found in the project
+func (a *App) GetMutationSummary(projectPath string) *testing.MutationSummary {
+	if a.coverageWatcher == nil {
+		return nil
+	}
+	return a.coverageWatcher.GetMutation(projectPath)
+}
+
+// GetCoverageThresholds returns a project's coverage thresholds, or nil
+func (a *App) GetCoverageThresholds(projectID string) *state.CoverageThresholds {
+	if a.stateManager == nil {
+		return nil
+	}
+	return a.stateManager.GetProjectCoverageThresholds(projectID)
+}
+
+// SetCoverageThresholds sets a project's coverage thresholds; nil removes them
+func (a *App) SetCoverageThresholds(projectID string, thresholds *state.CoverageThresholds) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	if thresholds != nil {
+		for _, pct := range []float64{thresholds.Lines, thresholds.Statements, thresholds.Functions, thresholds.Branches, thresholds.MaxDrop} {
+			if pct < 0 || pct > 100 {
+				return fmt.Errorf("coverage thresholds must be between 0 and 100")
+			}
+		}
+	}
+	return a.stateManager.SetProjectCoverageThresholds(projectID, thresholds)
+}
+
+// GetCoverageGate compares a project's latest coverage report with its
+// thresholds; nil without thresholds or a report
+func (a *App) GetCoverageGate(projectID string) *testing.CoverageGate {
+	if a.stateManager == nil || a.coverageWatcher == nil {
+		return nil
+	}
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil
+	}
+	return a.coverageGate(projectID, project.Path, a.coverageWatcher.GetCoverage(project.Path))
+}
+
+// checkCoverageThresholds warns with "coverage-threshold-warning" when a new
+// coverage report breaks its project's thresholds
+func (a *App) checkCoverageThresholds(projectPath string, summary *testing.CoverageSummary) {
+	if a.stateManager == nil {
+		return
+	}
+	projectID := a.stateManager.GetProjectIDByPath(projectPath)
+	gate := a.coverageGate(projectID, projectPath, summary)
+	if gate == nil || gate.Passed {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "coverage-threshold-warning", map[string]interface{}{
+		"projectId": projectID,
+		"gate":      gate,
+	})
+}
+
+func (a *App) coverageGate(projectID, projectPath string, summary *testing.CoverageSummary) *testing.CoverageGate {
+	thresholds := a.stateManager.GetProjectCoverageThresholds(projectID)
+	if thresholds == nil || summary == nil {
+		return nil
+	}
+	return testing.CheckCoverageGate(summary, a.coverageWatcher.GetPreviousCoverage(projectPath), testing.CoverageThresholds{
+		Lines:      thresholds.Lines,
+		Statements: thresholds.Statements,
+		Functions:  thresholds.Functions,
+		Branches:   thresholds.Branches,
+		MaxDrop:    thresholds.MaxDrop,
+	})
+}
+
+// ============================================
+// Test Watch Mode Methods
+// ============================================
+
+// StartTestWatch turns on watch mode for a project: when source files change,
+// the tests affected by them are rerun and reported as "test-watch-update"
+func (a *App) StartTestWatch(projectPath string) error {
+	if a.rerunWatcher == nil {
+		return fmt.Errorf("test watch not initialized")
+	}
+	return a.rerunWatcher.WatchProject(projectPath)
+}
+
+// StopTestWatch turns off watch mode for a project
+func (a *App) StopTestWatch(projectPath string) {
+	if a.rerunWatcher != nil {
+		a.rerunWatcher.UnwatchProject(projectPath)
+	}
+}
+
+// IsTestWatchActive returns whether watch mode is on for a project
+func (a *App) IsTestWatchActive(projectPath string) bool {
+	if a.rerunWatcher == nil {
+		return false
+	}
+	return a.rerunWatcher.IsWatching(projectPath)
+}
+
+// GetTestWatchResult returns the latest watch mode rerun of a project
+func (a *App) GetTestWatchResult(projectPath string) *testing.RerunResult {
+	if a.rerunWatcher == nil {
+		return nil
+	}
+	return a.rerunWatcher.GetLastResult(projectPath)
+}
+
+// ============================================
+// Test Sharding Methods
+// ============================================
+
+// StartTestShards splits a project's unit and integration tests across
+// parallel processes, balanced by test count ("file") or by earlier timings
+// ("timing"). Progress and the merged result are sent as "test-shards-update".
+func (a *App) StartTestShards(projectPath string, shards int, strategy string) (*testing.ShardRun, error) {
+	if a.shardRunner == nil {
+		return nil, fmt.Errorf("test sharding not initialized")
+	}
+	return a.shardRunner.Start(projectPath, shards, strategy)
+}
+
+// StopTestShards stops a project's sharded run
+func (a *App) StopTestShards(projectPath string) {
+	if a.shardRunner != nil {
+		a.shardRunner.Stop(projectPath)
+	}
+}
+
+// GetTestShardRun returns the latest sharded run of a project
+func (a *App) GetTestShardRun(projectPath string) *testing.ShardRun {
+	if a.shardRunner == nil {
+		return nil
+	}
+	return a.shardRunner.GetLastRun(projectPath)
+}
+
+// ============================================
+// Snapshot Test Methods
+// ============================================
+
+// CheckTestSnapshots runs a Jest or Vitest project's suite without writing
+// snapshots and lists the obsolete ones and those that no longer match
+func (a *App) CheckTestSnapshots(projectPath string) (*testing.SnapshotReport, error) {
+	return testing.CheckSnapshots(projectPath)
+}
+
+// UpdateTestSnapshots rewrites the given snapshots from their tests' current output
+func (a *App) UpdateTestSnapshots(projectPath string, entries []testing.SnapshotEntry) error {
+	return testing.UpdateSnapshots(projectPath, entries)
+}
+
+// DeleteObsoleteSnapshots removes the given obsolete snapshots and returns
+// how many were removed
+func (a *App) DeleteObsoleteSnapshots(projectPath string, entries []testing.SnapshotEntry) (int, error) {
+	return testing.DeleteObsoleteSnapshots(projectPath, entries)
+}
+
 // ============================================
 // Structure Scanner Methods
 // ============================================
 
-// GetProjectStructure returns the full file tree for a project (JS/TS files only)
+// GetProjectStructure returns the file tree for a project (source files only).
+// The project is watched from then on and changes arrive as "structure-changed" deltas.
+// Huge trees come back a few levels deep; GetNodeChildren loads the rest.
 func (a *App) GetProjectStructure(projectPath string) (*structure.FileNode, error) {
-	if a.structureScanner == nil {
+	if a.structureWatcher == nil {
+		return nil, fmt.Errorf("structure scanner not initialized")
+	}
+	a.applyIgnorePatterns(projectPath)
+	tree, err := a.structureWatcher.WatchProject(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if tree.FileCount > structure.LazyTreeThreshold {
+		tree = tree.Shallow(structure.LazyTreeDepth)
+	}
+	if flags := a.gitFlags(projectPath); flags != nil {
+		tree = tree.WithGitStatus(flags)
+	}
+	return tree, nil
+}
+
+// GetNodeChildren returns the children of a directory left out of a lazily
+// delivered project tree, one level deep
+func (a *App) GetNodeChildren(path string) ([]structure.FileNode, error) {
+	if a.structureWatcher == nil {
 		return nil, fmt.Errorf("structure scanner not initialized")
 	}
-	return a.structureScanner.ScanProject(projectPath)
+	children, err := a.structureWatcher.NodeChildren(path)
+	if err != nil {
+		return nil, err
+	}
+	if flags := a.gitFlags(path); flags != nil {
+		for i := range children {
+			children[i] = *children[i].WithGitStatus(flags)
+		}
+	}
+	return children, nil
+}
+
+// gitFlags returns the git state of the changed files in the repository
+// containing path, or nil outside a repository
+func (a *App) gitFlags(path string) map[string]structure.GitFlags {
+	if a.gitManager == nil {
+		return nil
+	}
+	states, err := a.gitManager.GetFileStates(path)
+	if err != nil {
+		return nil
+	}
+	flags := make(map[string]structure.GitFlags, len(states))
+	for file, state := range states {
+		flags[file] = structure.GitFlags{
+			Staged:    state.Staged,
+			Modified:  state.Modified,
+			Untracked: state.Untracked,
+		}
+	}
+	return flags
+}
+
+// GetIgnorePatterns returns a project's custom ignore patterns
+func (a *App) GetIgnorePatterns(projectID string) []string {
+	if a.stateManager == nil {
+		return []string{}
+	}
+	return a.stateManager.GetProjectIgnorePatterns(projectID)
+}
+
+// SetIgnorePatterns saves a project's custom ignore patterns, in .gitignore
+// syntax. The project's cached tree is dropped so the next load rescans it.
+func (a *App) SetIgnorePatterns(projectID string, patterns []string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	var cleaned []string
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			cleaned = append(cleaned, pattern)
+		}
+	}
+	if err := a.stateManager.SetProjectIgnorePatterns(projectID, cleaned); err != nil {
+		return err
+	}
+	if project := a.stateManager.GetProject(projectID); project != nil && a.structureWatcher != nil {
+		a.structureScanner.SetIgnorePatterns(project.Path, cleaned)
+		a.structureWatcher.UnwatchProject(project.Path)
+	}
+	return nil
+}
+
+// applyIgnorePatterns hands a project's saved ignore patterns to the scanner
+func (a *App) applyIgnorePatterns(projectPath string) {
+	if a.stateManager == nil {
+		return
+	}
+	if projectID := a.stateManager.GetProjectIDByPath(projectPath); projectID != "" {
+		a.structureScanner.SetIgnorePatterns(projectPath, a.stateManager.GetProjectIgnorePatterns(projectID))
+	}
+}
+
+// UnwatchProjectStructure stops watching a project's file tree
+func (a *App) UnwatchProjectStructure(projectPath string) {
+	if a.structureWatcher != nil {
+		a.structureWatcher.UnwatchProject(projectPath)
+	}
 }
 
 // GetProjectFolderHierarchy returns only the folder hierarchy (no files) for graph visualization
@@ -2077,6 +4597,59 @@ func (a *App) GetProjectFolderHierarchy(projectPath string) (*structure.FileNode
 	return a.structureScanner.GetFolderHierarchy(projectPath)
 }
 
+// GetDependencyCycles returns the groups of JS/TS files in a project that
+// import each other in a loop
+func (a *App) GetDependencyCycles(projectPath string) ([]structure.DependencyCycle, error) {
+	if a.structureScanner == nil {
+		return nil, fmt.Errorf("structure scanner not initialized")
+	}
+	a.applyIgnorePatterns(projectPath)
+	return a.structureScanner.DependencyCycles(projectPath)
+}
+
+// GetOrphanFiles returns the JS/TS files in a project that nothing imports
+// and that aren't entry points, as candidates for deletion
+func (a *App) GetOrphanFiles(projectPath string) ([]string, error) {
+	if a.structureScanner == nil {
+		return nil, fmt.Errorf("structure scanner not initialized")
+	}
+	a.applyIgnorePatterns(projectPath)
+	return a.structureScanner.OrphanFiles(projectPath)
+}
+
+// SearchProject searches a project's text files for query. A new search of
+// the same project cancels the one still running, so it can follow typing.
+func (a *App) SearchProject(projectPath, query string, opts structure.SearchOptions) (*structure.SearchResult, error) {
+	if a.structureScanner == nil {
+		return nil, fmt.Errorf("structure scanner not initialized")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a.mu.Lock()
+	if a.searchCancel == nil {
+		a.searchCancel = make(map[string]context.CancelFunc)
+	}
+	if previous, ok := a.searchCancel[projectPath]; ok {
+		previous()
+	}
+	a.searchCancel[projectPath] = cancel
+	a.mu.Unlock()
+
+	a.applyIgnorePatterns(projectPath)
+	return a.structureScanner.Search(ctx, projectPath, query, opts)
+}
+
+// CancelProjectSearch stops a running search of a project
+func (a *App) CancelProjectSearch(projectPath string) {
+	a.mu.Lock()
+	cancel, ok := a.searchCancel[projectPath]
+	a.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
 // ReadFileContent reads and returns the content of a file
 func (a *App) ReadFileContent(filePath string) (string, error) {
 	content, err := os.ReadFile(filePath)
@@ -2122,12 +4695,67 @@ func (a *App) GetTodos(projectID string) []state.TodoItem {
 	return a.stateManager.GetTodos(projectID)
 }
 
-// SaveTodos saves todos for a project
-func (a *App) SaveTodos(projectID string, todos []state.TodoItem) error {
+// SaveTodos saves todos for a project
+func (a *App) SaveTodos(projectID string, todos []state.TodoItem) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	return a.stateManager.SaveTodos(projectID, todos)
+}
+
+// GetCodeTodos returns the TODO, FIXME and HACK comments in a project's
+// source files, with their authors from git blame when the project is a repo
+func (a *App) GetCodeTodos(projectPath string) ([]structure.CodeTodo, error) {
+	if a.structureScanner == nil {
+		return nil, fmt.Errorf("structure scanner not initialized")
+	}
+	a.applyIgnorePatterns(projectPath)
+	todos, err := a.structureScanner.CodeTodos(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.gitManager != nil && a.gitManager.IsGitRepo(projectPath) {
+		lines := make(map[string][]int)
+		for _, todo := range todos {
+			lines[todo.File] = append(lines[todo.File], todo.Line)
+		}
+		authors := make(map[string]map[int]string, len(lines))
+		for file, fileLines := range lines {
+			authors[file] = a.gitManager.BlameAuthors(projectPath, file, fileLines)
+		}
+		for i := range todos {
+			todos[i].Author = authors[todos[i].File][todos[i].Line]
+		}
+	}
+	return todos, nil
+}
+
+// ImportCodeTodos adds a project's TODO, FIXME and HACK comments to its todo
+// list, skipping ones imported before, and returns how many were added
+func (a *App) ImportCodeTodos(projectID string) (int, error) {
 	if a.stateManager == nil {
-		return fmt.Errorf("state manager not initialized")
+		return 0, fmt.Errorf("state manager not initialized")
 	}
-	return a.stateManager.SaveTodos(projectID, todos)
+	project := a.stateManager.GetProject(projectID)
+	if project == nil {
+		return 0, fmt.Errorf("project not found")
+	}
+	todos, err := a.GetCodeTodos(project.Path)
+	if err != nil {
+		return 0, err
+	}
+
+	items := make([]state.TodoItem, len(todos))
+	for i, todo := range todos {
+		items[i] = state.TodoItem{
+			Text:      todo.Marker + ": " + todo.Text,
+			CreatedAt: time.Now(),
+			Source:    fmt.Sprintf("%s:%d", todo.File, todo.Line),
+			Author:    todo.Author,
+		}
+	}
+	return a.stateManager.ImportTodos(projectID, items)
 }
 
 // ============================================
@@ -2177,6 +4805,11 @@ type RemoteAccessStatus struct {
 	Token            string              `json:"token"`
 	ClientCount      int                 `json:"clientCount"`
 	Clients          []remote.ClientInfo `json:"clients"`
+	LanURL           string              `json:"lanUrl"` // Direct URL for devices on the same network
+	TLS              bool                `json:"tls"`
+	Fingerprint      string              `json:"fingerprint"` // SHA-256 of the TLS certificate, for pinning
+	Tunnels          []remote.TunnelInfo `json:"tunnels"`     // Extra ngrok tunnels, e.g. a dev server
+	BindHost         string              `json:"bindHost"`    // Address the server listens on, "" for every interface
 }
 
 // StartRemoteAccess starts the remote access server with optional ngrok tunnel
@@ -2195,22 +4828,65 @@ func (a *App) StartRemoteAccess(config remote.Config) (*RemoteAccessStatus, erro
 		a.remoteServer.SetProjectHandler(&remoteProjectHandler{app: a})
 		a.setupApprovedClientsCallback()
 		a.loadApprovedClients()
+		a.setupPushSubscriptionsCallback()
+		a.loadPushSubscriptions()
+		a.loadBannedIPs()
+		a.remoteServer.SetAuditLog(a.remoteAudit)
+	}
+	a.remoteServer.SetAuthPolicy(config.MaxAuthAttempts, time.Duration(config.AuthLockoutMins)*time.Minute)
+	bindHost := config.ListenHost()
+	a.remoteServer.SetBindHost(bindHost)
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
 	}
+	cert, err := remote.LoadTLSCertificate(config, filepath.Join(homeDir, ".projecthub"))
+	if err != nil {
+		return nil, err
+	}
+	a.remoteServer.SetTLSCertificate(cert)
+	// Push notifications are optional; remote access works without them
+	if keys, err := remote.LoadVAPIDKeys(filepath.Join(homeDir, ".projecthub")); err != nil {
+		logging.Warn("Push notifications unavailable", "error", err)
+	} else {
+		a.remoteServer.SetVAPIDKeys(keys)
+	}
+	// Sessions are recorded only when asked for; old recordings are pruned
+	// either way
+	if a.remoteRecorder != nil {
+		a.remoteRecorder.SetRetention(config.RecordingDays)
+	}
+	if config.RecordSessions && a.remoteRecorder != nil {
+		a.remoteServer.SetSessionRecorder(a.remoteRecorder)
+	} else {
+		a.remoteServer.SetSessionRecorder(nil)
+	}
+	if config.AccessRequests {
+		a.remoteServer.SetAccessRequestCallback(a.onRemoteAccessRequest)
+	} else {
+		a.remoteServer.SetAccessRequestCallback(nil)
+	}
+	whisperModel := config.WhisperModel
+	a.remoteServer.SetTranscriber(func(ctx context.Context, audioPath, lang string) (string, error) {
+		return transcribeAudio(ctx, whisperModel, audioPath, lang)
+	})
 
 	var token string
 	var localURL string
 	var publicURL string
+	tunnels := []remote.TunnelInfo{}
 
 	// Generate token only if not using saved devices only mode
 	if config.SavedDevicesOnly {
 		// No new token - only approved clients can connect
-		// Check if we have any approved clients
+		// Check if we have any approved clients, or a way for one to ask
 		approvedClients := a.GetApprovedClients()
-		if len(approvedClients) == 0 {
+		if len(approvedClients) == 0 && !config.AccessRequests {
 			return nil, fmt.Errorf("no saved devices configured - add a device first")
 		}
 		token = "" // No temporary token
-		localURL = fmt.Sprintf("http://localhost:%d/", config.Port)
+		localURL = remoteAccessURL(cert != nil, remote.LocalHost(bindHost), config.Port, "")
 	} else {
 		// Generate temporary token
 		tokenDuration := time.Duration(config.TokenExpiry) * time.Hour
@@ -2219,7 +4895,7 @@ func (a *App) StartRemoteAccess(config remote.Config) (*RemoteAccessStatus, erro
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate access token: %w", err)
 		}
-		localURL = fmt.Sprintf("http://localhost:%d/?token=%s", config.Port, token)
+		localURL = remoteAccessURL(cert != nil, remote.LocalHost(bindHost), config.Port, token)
 	}
 
 	// Start server in goroutine
@@ -2247,6 +4923,7 @@ func (a *App) StartRemoteAccess(config remote.Config) (*RemoteAccessStatus, erro
 			} else {
 				publicURL = ngrokURL + "/?token=" + token
 			}
+			tunnels = a.ngrokTunnel.GetTunnels()
 		}
 	}
 
@@ -2257,19 +4934,42 @@ func (a *App) StartRemoteAccess(config remote.Config) (*RemoteAccessStatus, erro
 		"publicUrl", publicURL,
 	)
 
+	var lanURL string
+	if host := remote.LANHost(bindHost); host != "" {
+		lanURL = remoteAccessURL(cert != nil, host, config.Port, token)
+	}
+
 	return &RemoteAccessStatus{
-		Enabled:         config.Enabled,
+		Enabled:          config.Enabled,
 		SavedDevicesOnly: config.SavedDevicesOnly,
-		Running:         true,
-		Port:            config.Port,
-		LocalURL:        localURL,
-		PublicURL:       publicURL,
-		Token:           token,
-		ClientCount:     0,
-		Clients:         []remote.ClientInfo{},
+		Running:          true,
+		Port:             config.Port,
+		LocalURL:         localURL,
+		PublicURL:        publicURL,
+		Token:            token,
+		ClientCount:      0,
+		Clients:          []remote.ClientInfo{},
+		LanURL:           lanURL,
+		TLS:              cert != nil,
+		Fingerprint:      remote.CertificateFingerprint(cert),
+		Tunnels:          tunnels,
+		BindHost:         bindHost,
 	}, nil
 }
 
+// remoteAccessURL builds a URL of the remote access web client
+func remoteAccessURL(tls bool, host string, port int, token string) string {
+	scheme := "http"
+	if tls {
+		scheme = "https"
+	}
+	url := fmt.Sprintf("%s://%s/", scheme, net.JoinHostPort(host, strconv.Itoa(port)))
+	if token != "" {
+		url += "?token=" + token
+	}
+	return url
+}
+
 // StopRemoteAccess stops the remote access server and ngrok tunnel
 func (a *App) StopRemoteAccess() error {
 	a.mu.Lock()
@@ -2303,33 +5003,74 @@ func (a *App) GetRemoteAccessStatus() *RemoteAccessStatus {
 	defer a.mu.RUnlock()
 
 	status := &RemoteAccessStatus{
-		Enabled:    false,
-		Running:    false,
-		Port:       9090,
-		LocalURL:   "",
-		PublicURL:  "",
-		Token:      "",
+		Enabled:     false,
+		Running:     false,
+		Port:        9090,
+		LocalURL:    "",
+		PublicURL:   "",
+		Token:       "",
 		ClientCount: 0,
-		Clients:    []remote.ClientInfo{},
+		Clients:     []remote.ClientInfo{},
+		Tunnels:     []remote.TunnelInfo{},
 	}
 
 	if a.remoteServer != nil && a.remoteServer.IsRunning() {
 		status.Running = true
 		status.Port = a.remoteServer.GetPort()
 		status.Token = a.remoteServer.GetToken()
-		status.LocalURL = fmt.Sprintf("http://localhost:%d/?token=%s", status.Port, status.Token)
+		status.TLS = a.remoteServer.IsTLS()
+		status.Fingerprint = a.remoteServer.GetFingerprint()
+		status.BindHost = a.remoteServer.GetBindHost()
+		status.LocalURL = remoteAccessURL(status.TLS, remote.LocalHost(status.BindHost), status.Port, status.Token)
+		if host := remote.LANHost(status.BindHost); host != "" {
+			status.LanURL = remoteAccessURL(status.TLS, host, status.Port, status.Token)
+		}
 		status.Clients = a.remoteServer.GetClients()
 		status.ClientCount = len(status.Clients)
 
 		if a.ngrokTunnel != nil && a.ngrokTunnel.IsRunning() {
 			status.Enabled = true
 			status.PublicURL = a.ngrokTunnel.GetPublicURL() + "/?token=" + status.Token
+			status.Tunnels = a.ngrokTunnel.GetTunnels()
 		}
 	}
 
 	return status
 }
 
+// remoteQRCodeSize is the width of remote access QR codes, in pixels
+const remoteQRCodeSize = 240
+
+// GetRemoteAccessQRCode renders a remote access URL with its token as a QR
+// code PNG data URL. target is "public" (ngrok), "lan" or "local".
+func (a *App) GetRemoteAccessQRCode(target string) (string, error) {
+	status := a.GetRemoteAccessStatus()
+	if !status.Running {
+		return "", fmt.Errorf("remote access is not running")
+	}
+
+	var url string
+	switch target {
+	case "public":
+		url = status.PublicURL
+	case "lan":
+		url = status.LanURL
+	case "local":
+		url = status.LocalURL
+	default:
+		return "", fmt.Errorf("unknown QR code target: %s", target)
+	}
+	if url == "" {
+		return "", fmt.Errorf("no %s URL available", target)
+	}
+
+	png, err := remote.QRCodePNG(url, remoteQRCodeSize)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(png), nil
+}
+
 // GetRemoteAccessClients returns list of connected remote clients
 func (a *App) GetRemoteAccessClients() []remote.ClientInfo {
 	if a.remoteServer == nil {
@@ -2338,6 +5079,53 @@ func (a *App) GetRemoteAccessClients() []remote.ClientInfo {
 	return a.remoteServer.GetClients()
 }
 
+// GetRemoteAuditLog returns the latest actions of remote clients, newest
+// first
+func (a *App) GetRemoteAuditLog(limit int) ([]remote.AuditEntry, error) {
+	if a.remoteAudit == nil {
+		return nil, fmt.Errorf("remote audit log not initialized")
+	}
+	return a.remoteAudit.Entries(limit)
+}
+
+// SaveRemoteAuditLogExport asks where to save the remote audit log as "csv"
+// or "json" and writes it there. Returns the path, or "" when the dialog was
+// cancelled.
+func (a *App) SaveRemoteAuditLogExport(format string) (string, error) {
+	if a.remoteAudit == nil {
+		return "", fmt.Errorf("remote audit log not initialized")
+	}
+	content, err := a.remoteAudit.Export(format)
+	if err != nil {
+		return "", err
+	}
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Remote Audit Log",
+		DefaultFilename: "remote-audit." + format,
+		Filters:         []runtime.FileFilter{{DisplayName: strings.ToUpper(format), Pattern: "*." + format}},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	return path, os.WriteFile(path, content, 0600)
+}
+
+// GetRemoteRecordings returns the recorded remote sessions, newest first
+func (a *App) GetRemoteRecordings() ([]remote.RecordingInfo, error) {
+	if a.remoteRecorder == nil {
+		return nil, fmt.Errorf("remote session recorder not initialized")
+	}
+	return a.remoteRecorder.List()
+}
+
+// DeleteRemoteRecording deletes a recorded remote session
+func (a *App) DeleteRemoteRecording(id string) error {
+	if a.remoteRecorder == nil {
+		return fmt.Errorf("remote session recorder not initialized")
+	}
+	return a.remoteRecorder.Delete(id)
+}
+
 // RefreshNgrokURL refreshes the ngrok public URL
 func (a *App) RefreshNgrokURL() (string, error) {
 	if a.ngrokTunnel == nil || !a.ngrokTunnel.IsRunning() {
@@ -2346,12 +5134,91 @@ func (a *App) RefreshNgrokURL() (string, error) {
 	return a.ngrokTunnel.RefreshURL()
 }
 
+// GetRemoteAuthAttempts returns the addresses with recent failed remote
+// sign-ins, locked out ones first
+func (a *App) GetRemoteAuthAttempts() []remote.AuthAttemptInfo {
+	if a.remoteServer == nil {
+		return []remote.AuthAttemptInfo{}
+	}
+	return a.remoteServer.GetAuthAttempts()
+}
+
+// UnlockRemoteIP lifts the lockout of an address
+func (a *App) UnlockRemoteIP(ip string) error {
+	if a.remoteServer == nil {
+		return fmt.Errorf("remote server not initialized")
+	}
+	a.remoteServer.UnlockIP(ip)
+	return nil
+}
+
+// GetRemoteBannedIPs returns the addresses banned from remote access
+func (a *App) GetRemoteBannedIPs() []remote.BannedIP {
+	if a.stateManager == nil {
+		return []remote.BannedIP{}
+	}
+	stateBans := a.stateManager.GetBannedRemoteIPs()
+	bans := make([]remote.BannedIP, len(stateBans))
+	for i, ban := range stateBans {
+		bans[i] = remote.BannedIP{IP: ban.IP, BannedAt: ban.BannedAt}
+	}
+	return bans
+}
+
+// BanRemoteIP refuses an address remote access until it is unbanned
+func (a *App) BanRemoteIP(ip string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	ip, err := remote.ValidateIP(ip)
+	if err != nil {
+		return err
+	}
+
+	stateBans := a.stateManager.GetBannedRemoteIPs()
+	for _, ban := range stateBans {
+		if ban.IP == ip {
+			return nil // Already banned
+		}
+	}
+	stateBans = append(stateBans, state.BannedRemoteIP{IP: ip, BannedAt: time.Now()})
+	a.stateManager.SetBannedRemoteIPs(stateBans)
+	a.loadBannedIPs()
+
+	logging.Info("Remote access address banned", "ip", ip)
+	return nil
+}
+
+// UnbanRemoteIP lifts the ban of an address
+func (a *App) UnbanRemoteIP(ip string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+
+	stateBans := a.stateManager.GetBannedRemoteIPs()
+	filtered := make([]state.BannedRemoteIP, 0, len(stateBans))
+	for _, ban := range stateBans {
+		if ban.IP != ip {
+			filtered = append(filtered, ban)
+		}
+	}
+	if len(filtered) == len(stateBans) {
+		return fmt.Errorf("address not banned: %s", ip)
+	}
+	a.stateManager.SetBannedRemoteIPs(filtered)
+	a.loadBannedIPs()
+
+	logging.Info("Remote access address unbanned", "ip", ip)
+	return nil
+}
+
 // ============================================
 // Approved Clients (Permanent Tokens)
 // ============================================
 
-// AddApprovedClient creates a new permanent token for an approved client
-func (a *App) AddApprovedClient(name string) (*remote.ApprovedClient, error) {
+// AddApprovedClient creates a new permanent token for an approved client.
+// Viewer tokens are read-only: they can watch terminals but not type in them.
+func (a *App) AddApprovedClient(name string, viewer bool) (*remote.ApprovedClient, error) {
 	// Generate token
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
@@ -2365,6 +5232,7 @@ func (a *App) AddApprovedClient(name string) (*remote.ApprovedClient, error) {
 		Name:      name,
 		CreatedAt: now,
 		LastUsed:  now,
+		Viewer:    viewer,
 	}
 
 	// Save to state (persistent)
@@ -2374,6 +5242,7 @@ func (a *App) AddApprovedClient(name string) (*remote.ApprovedClient, error) {
 		Name:      client.Name,
 		CreatedAt: client.CreatedAt,
 		LastUsed:  client.LastUsed,
+		Viewer:    client.Viewer,
 	})
 	a.stateManager.SetApprovedClients(stateClients)
 
@@ -2382,7 +5251,7 @@ func (a *App) AddApprovedClient(name string) (*remote.ApprovedClient, error) {
 		a.remoteServer.SetApprovedClients(a.getRemoteApprovedClients())
 	}
 
-	logging.Info("Approved client added", "name", name)
+	logging.Info("Approved client added", "name", name, "viewer", viewer)
 	return client, nil
 }
 
@@ -2406,16 +5275,139 @@ func (a *App) RemoveApprovedClient(token string) {
 	logging.Info("Approved client removed")
 }
 
+// SetApprovedClientScope limits an approved client to some projects and
+// capabilities; empty lists allow everything. Connected clients are
+// disconnected and pick up the new scope when they reconnect.
+func (a *App) SetApprovedClientScope(token string, projects []string, capabilities []string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+
+	stateClients := a.stateManager.GetApprovedClients()
+	found := false
+	for _, c := range stateClients {
+		if c.Token == token {
+			c.Projects = projects
+			c.Capabilities = capabilities
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("approved client not found")
+	}
+	a.stateManager.SetApprovedClients(stateClients)
+
+	if a.remoteServer != nil {
+		a.remoteServer.SetApprovedClients(a.getRemoteApprovedClients())
+	}
+	return nil
+}
+
+// BeginApprovedClientTOTP generates a TOTP secret for an approved client, to
+// add to an authenticator app. It takes effect once confirmed with a code.
+func (a *App) BeginApprovedClientTOTP(token string) (*remote.TOTPEnrollment, error) {
+	if a.stateManager == nil {
+		return nil, fmt.Errorf("state manager not initialized")
+	}
+
+	var name string
+	for _, c := range a.stateManager.GetApprovedClients() {
+		if c.Token == token {
+			name = c.Name
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("approved client not found")
+	}
+
+	enrollment, err := remote.NewTOTPEnrollment(name)
+	if err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	if a.pendingTOTP == nil {
+		a.pendingTOTP = make(map[string]string)
+	}
+	a.pendingTOTP[token] = enrollment.Secret
+	a.mu.Unlock()
+	return enrollment, nil
+}
+
+// ConfirmApprovedClientTOTP turns on the second factor of an approved client
+// once a code from the new secret checks out. The device then enters a code
+// the next time it connects from any address.
+func (a *App) ConfirmApprovedClientTOTP(token, code string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+
+	a.mu.RLock()
+	secret := a.pendingTOTP[token]
+	a.mu.RUnlock()
+	if secret == "" {
+		return fmt.Errorf("no pending second factor for this device")
+	}
+	if _, ok := remote.ValidateTOTP(secret, code, time.Now()); !ok {
+		return fmt.Errorf("invalid code")
+	}
+
+	if err := a.updateApprovedClientTOTP(token, secret); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	delete(a.pendingTOTP, token)
+	a.mu.Unlock()
+
+	logging.Info("Remote second factor enabled")
+	return nil
+}
+
+// DisableApprovedClientTOTP turns off the second factor of an approved client
+func (a *App) DisableApprovedClientTOTP(token string) error {
+	if a.stateManager == nil {
+		return fmt.Errorf("state manager not initialized")
+	}
+	return a.updateApprovedClientTOTP(token, "")
+}
+
+// updateApprovedClientTOTP sets an approved client's TOTP secret, forgetting
+// the browsers confirmed with the old one
+func (a *App) updateApprovedClientTOTP(token, secret string) error {
+	stateClients := a.stateManager.GetApprovedClients()
+	found := false
+	for _, c := range stateClients {
+		if c.Token == token {
+			c.TOTPSecret = secret
+			c.TOTPDevices = nil
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("approved client not found")
+	}
+	a.stateManager.SetApprovedClients(stateClients)
+
+	if a.remoteServer != nil {
+		a.remoteServer.SetApprovedClients(a.getRemoteApprovedClients())
+	}
+	return nil
+}
+
 // GetApprovedClients returns all approved clients from persistent state
 func (a *App) GetApprovedClients() []*remote.ApprovedClient {
 	stateClients := a.stateManager.GetApprovedClients()
 	result := make([]*remote.ApprovedClient, len(stateClients))
 	for i, c := range stateClients {
 		result[i] = &remote.ApprovedClient{
-			Token:     c.Token,
-			Name:      c.Name,
-			CreatedAt: c.CreatedAt,
-			LastUsed:  c.LastUsed,
+			Token:        c.Token,
+			Name:         c.Name,
+			CreatedAt:    c.CreatedAt,
+			LastUsed:     c.LastUsed,
+			Viewer:       c.Viewer,
+			Projects:     c.Projects,
+			Capabilities: c.Capabilities,
+			TOTPSecret:   c.TOTPSecret,
+			TOTPDevices:  c.TOTPDevices,
 		}
 	}
 	return result
@@ -2436,10 +5428,15 @@ func (a *App) setupApprovedClientsCallback() {
 			stateClients := make([]*state.ApprovedRemoteClient, len(remoteClients))
 			for i, c := range remoteClients {
 				stateClients[i] = &state.ApprovedRemoteClient{
-					Token:     c.Token,
-					Name:      c.Name,
-					CreatedAt: c.CreatedAt,
-					LastUsed:  c.LastUsed,
+					Token:        c.Token,
+					Name:         c.Name,
+					CreatedAt:    c.CreatedAt,
+					LastUsed:     c.LastUsed,
+					Viewer:       c.Viewer,
+					Projects:     c.Projects,
+					Capabilities: c.Capabilities,
+					TOTPSecret:   c.TOTPSecret,
+					TOTPDevices:  c.TOTPDevices,
 				}
 			}
 			a.stateManager.SetApprovedClients(stateClients)
@@ -2456,6 +5453,125 @@ func (a *App) loadApprovedClients() {
 	a.remoteServer.SetApprovedClients(a.getRemoteApprovedClients())
 }
 
+// onRemoteAccessRequest tells the frontend a device asks for access, and
+// shows a notification when the window isn't in front
+func (a *App) onRemoteAccessRequest(req remote.AccessRequest) {
+	runtime.EventsEmit(a.ctx, "remote-access-request", req)
+
+	a.mu.RLock()
+	focused := !a.windowBlurred
+	a.mu.RUnlock()
+	if focused {
+		return
+	}
+	message := fmt.Sprintf("%s (%s) from %s", req.Name, req.Fingerprint, req.RemoteAddr)
+	err := notify.Send("Device asks for remote access", message, func() {
+		runtime.WindowUnminimise(a.ctx)
+		runtime.WindowShow(a.ctx)
+	})
+	if err != nil {
+		logging.Warn("Access request notification failed", "error", err)
+	}
+}
+
+// GetRemoteAccessRequests returns devices waiting for an answer to their
+// access request
+func (a *App) GetRemoteAccessRequests() []remote.AccessRequest {
+	if a.remoteServer == nil {
+		return []remote.AccessRequest{}
+	}
+	return a.remoteServer.GetAccessRequests()
+}
+
+// ApproveRemoteAccessRequest saves a device that asked for access and hands
+// it its permanent token
+func (a *App) ApproveRemoteAccessRequest(id string, viewer bool) (*remote.ApprovedClient, error) {
+	if a.remoteServer == nil {
+		return nil, fmt.Errorf("remote access not running")
+	}
+	req, ok := a.remoteServer.GetAccessRequest(id)
+	if !ok {
+		return nil, fmt.Errorf("access request not found or expired")
+	}
+
+	client, err := a.AddApprovedClient(req.Name, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.remoteServer.ApproveAccessRequest(id, client.Token); err != nil {
+		// Expired meanwhile; nobody got the token
+		a.RemoveApprovedClient(client.Token)
+		return nil, err
+	}
+	return client, nil
+}
+
+// DenyRemoteAccessRequest turns down a device that asked for access
+func (a *App) DenyRemoteAccessRequest(id string) error {
+	if a.remoteServer == nil {
+		return fmt.Errorf("remote access not running")
+	}
+	return a.remoteServer.DenyAccessRequest(id)
+}
+
+// setupPushSubscriptionsCallback persists push subscriptions when remote
+// clients subscribe or the push service drops them
+func (a *App) setupPushSubscriptionsCallback() {
+	if a.remoteServer != nil {
+		a.remoteServer.SetPushChangeCallback(func() {
+			remoteSubs := a.remoteServer.GetPushSubscriptions()
+			stateSubs := make([]state.RemotePushSubscription, len(remoteSubs))
+			for i, sub := range remoteSubs {
+				stateSubs[i] = state.RemotePushSubscription{
+					Endpoint: sub.Endpoint,
+					P256dh:   sub.Keys.P256dh,
+					Auth:     sub.Keys.Auth,
+					Token:    sub.Token,
+				}
+			}
+			a.stateManager.SetRemotePushSubscriptions(stateSubs)
+		})
+	}
+}
+
+// loadBannedIPs loads banned addresses from state into remote server
+func (a *App) loadBannedIPs() {
+	if a.remoteServer == nil {
+		return
+	}
+	a.remoteServer.SetBannedIPs(a.GetRemoteBannedIPs())
+}
+
+// loadPushSubscriptions loads push subscriptions from state into remote server
+func (a *App) loadPushSubscriptions() {
+	if a.remoteServer == nil || a.stateManager == nil {
+		return
+	}
+	stateSubs := a.stateManager.GetRemotePushSubscriptions()
+	remoteSubs := make([]remote.PushSubscription, len(stateSubs))
+	for i, sub := range stateSubs {
+		remoteSubs[i].Endpoint = sub.Endpoint
+		remoteSubs[i].Keys.P256dh = sub.P256dh
+		remoteSubs[i].Keys.Auth = sub.Auth
+		remoteSubs[i].Token = sub.Token
+	}
+	a.remoteServer.SetPushSubscriptions(remoteSubs)
+}
+
+// notifyRemote sends a push notification to the remote clients' devices
+// while remote access is on
+func (a *App) notifyRemote(projectID, title, body, tag string) {
+	if a.remoteServer == nil || !a.remoteServer.IsRunning() {
+		return
+	}
+	a.remoteServer.Notify(remote.PushMessage{
+		Title:     title,
+		Body:      body,
+		ProjectID: projectID,
+		Tag:       tag,
+	})
+}
+
 // ============================================
 // ProjectHandler Implementation for Remote Access
 // ============================================
@@ -2563,3 +5679,113 @@ func (h *remoteProjectHandler) RenameTerminal(projectID, terminalID, name string
 func (h *remoteProjectHandler) DeleteTerminal(projectID, terminalID string) error {
 	return h.app.RemoteDeleteTerminal(projectID, terminalID)
 }
+
+func (h *remoteProjectHandler) HasTerminal(terminalID string) bool {
+	return h.app.terminalManager != nil && h.app.terminalManager.Get(terminalID) != nil
+}
+
+func (h *remoteProjectHandler) WriteTerminal(terminalID string, data []byte) error {
+	if h.app.terminalManager == nil {
+		return fmt.Errorf("terminal manager not initialized")
+	}
+	return h.app.terminalManager.Write(terminalID, data)
+}
+
+func (h *remoteProjectHandler) ResizeTerminal(terminalID string, rows, cols int) error {
+	if h.app.terminalManager == nil {
+		return fmt.Errorf("terminal manager not initialized")
+	}
+	return h.app.terminalManager.Resize(terminalID, uint16(rows), uint16(cols))
+}
+
+// RunTests implements remote.ProjectHandler.RunTests
+func (h *remoteProjectHandler) RunTests(projectID, terminalID string) (*remote.TestRunInfo, error) {
+	if h.app.stateManager == nil || h.app.terminalManager == nil {
+		return nil, fmt.Errorf("terminal manager not initialized")
+	}
+	project := h.app.stateManager.GetProject(projectID)
+	if project == nil {
+		return nil, fmt.Errorf("project not found: %s", projectID)
+	}
+	argv := testing.TestCommand(project.Path)
+	if argv == nil {
+		return nil, fmt.Errorf("no test command known for %s", project.Name)
+	}
+
+	if terminalID == "" {
+		term, err := h.app.RemoteCreateTerminal(projectID, "Tests")
+		if err != nil {
+			return nil, err
+		}
+		terminalID = term.ID
+	}
+	command := strings.Join(argv, " ")
+	if err := h.app.terminalManager.Write(terminalID, []byte(command+"\r")); err != nil {
+		return nil, err
+	}
+	return &remote.TestRunInfo{TerminalID: terminalID, Command: command}, nil
+}
+
+// GetTestSummary implements remote.ProjectHandler.GetTestSummary
+func (h *remoteProjectHandler) GetTestSummary(terminalID string) *testing.TestSummary {
+	return h.app.GetTestSummary(terminalID)
+}
+
+// GetGitStatus implements remote.ProjectHandler.GetGitStatus
+func (h *remoteProjectHandler) GetGitStatus(projectID string) *remote.GitStatusInfo {
+	if h.app.gitManager == nil || h.app.stateManager == nil {
+		return nil
+	}
+	project := h.app.stateManager.GetProject(projectID)
+	if project == nil || !h.app.gitManager.IsGitRepo(project.Path) {
+		return nil
+	}
+	staged, unstaged, untracked := h.app.gitManager.GetStatus(project.Path)
+	return &remote.GitStatusInfo{
+		Branch:    h.app.gitManager.GetCurrentBranch(project.Path),
+		Staged:    staged,
+		Unstaged:  unstaged,
+		Untracked: untracked,
+	}
+}
+
+// GetClaudeStatus implements remote.ProjectHandler.GetClaudeStatus
+func (h *remoteProjectHandler) GetClaudeStatus(terminalID string) string {
+	if h.app.claudeDetector == nil {
+		return string(claude.StatusNone)
+	}
+	return string(h.app.claudeDetector.GetStatus(terminalID))
+}
+
+// GetContainers implements remote.ProjectHandler.GetContainers with the
+// project's compose service containers
+func (h *remoteProjectHandler) GetContainers(projectID string) ([]remote.ContainerInfo, error) {
+	health, err := h.app.GetProjectServicesHealth(projectID)
+	if err != nil {
+		return nil, err
+	}
+	containers := make([]remote.ContainerInfo, len(health.Services))
+	for i, svc := range health.Services {
+		containers[i] = remote.ContainerInfo{
+			ID:      svc.ContainerID,
+			Service: svc.Service,
+			State:   svc.State,
+			Health:  svc.Health,
+			Status:  svc.Status,
+		}
+	}
+	return containers, nil
+}
+
+// ContainerAction implements remote.ProjectHandler.ContainerAction
+func (h *remoteProjectHandler) ContainerAction(containerID, action string) error {
+	switch action {
+	case remote.ContainerStart:
+		return h.app.StartContainer(containerID)
+	case remote.ContainerStop:
+		return h.app.StopContainer(containerID)
+	case remote.ContainerRestart:
+		return h.app.RestartContainer(containerID)
+	}
+	return fmt.Errorf("unknown container action: %s", action)
+}
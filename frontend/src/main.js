@@ -7,6 +7,8 @@ import { state } from './modules/state.js';
 import { escapeHtml, textToBase64 } from './modules/utils.js';
 import {
   refreshContainers,
+  initDockerEvents,
+  showComposeLogs,
   renderContainers,
   showLogsModal,
   setupDockerWindowFunctions
@@ -25,7 +27,8 @@ import {
   setDiffCallbacks
 } from './modules/diff.js';
 import {
-  updateClaudeStatusUI
+  updateClaudeStatusUI,
+  initClaudeVersionCheck
 } from './modules/claude-status.js';
 import {
   setupToolsPanel,
@@ -117,6 +120,7 @@ import {
   initGitDashboard,
   setGitDashboardCallbacks,
   loadGitHistory,
+  isGitTabActive,
   initGitDashboardHandler
 } from './modules/git-dashboard.js';
 
@@ -162,6 +166,32 @@ import {
   DeleteScreenshot,
   GetTestHistory,
   AddTestRun,
+  GetFlakyTests,
+  GetTestHistoryLimit,
+  SetTestHistoryLimit,
+  SaveTestHistoryExport,
+  GetTestDurationReport,
+  StartTestWatch,
+  StopTestWatch,
+  IsTestWatchActive,
+  GetTestWatchResult,
+  StartTestShards,
+  StopTestShards,
+  GetTestShardRun,
+  GetCoverageThresholds,
+  SetCoverageThresholds,
+  GetCoverageGate,
+  RunMutationTests,
+  StopMutationTests,
+  GetMutationSummary,
+  CheckTestSnapshots,
+  UpdateTestSnapshots,
+  DeleteObsoleteSnapshots,
+  GetChangeCoverage,
+  GetTestNotifications,
+  SetTestNotifications,
+  SetWindowFocused,
+  OpenTestFailure,
   GetTestDiscovery,
   ScanProjectTests,
   WriteTerminal,
@@ -170,6 +200,7 @@ import {
   CheckProjectCoverage,
   GetTodos,
   SaveTodos,
+  ImportCodeTodos,
   GetGitHistory,
   GetPomodoroSettings,
   SavePomodoroSettings
@@ -188,7 +219,10 @@ function insertToTerminal(text) {
 async function init() {
   // Setup module callbacks
   setGitCallbacks({
-    showGitDiff
+    showGitDiff,
+    gitRootChanged: () => {
+      if (isGitTabActive()) loadGitHistory();
+    }
   });
   setDiffCallbacks({
     switchTab
@@ -243,6 +277,31 @@ async function init() {
   setTestDashboardCallbacks({
     getTestHistory: GetTestHistory,
     addTestRun: AddTestRun,
+    getFlakyTests: GetFlakyTests,
+    getTestHistoryLimit: GetTestHistoryLimit,
+    setTestHistoryLimit: SetTestHistoryLimit,
+    saveTestHistoryExport: SaveTestHistoryExport,
+    getTestDurationReport: GetTestDurationReport,
+    startTestWatch: StartTestWatch,
+    stopTestWatch: StopTestWatch,
+    isTestWatchActive: IsTestWatchActive,
+    getTestWatchResult: GetTestWatchResult,
+    startTestShards: StartTestShards,
+    stopTestShards: StopTestShards,
+    getTestShardRun: GetTestShardRun,
+    getCoverageThresholds: GetCoverageThresholds,
+    setCoverageThresholds: SetCoverageThresholds,
+    getCoverageGate: GetCoverageGate,
+    runMutationTests: RunMutationTests,
+    stopMutationTests: StopMutationTests,
+    getMutationSummary: GetMutationSummary,
+    checkTestSnapshots: CheckTestSnapshots,
+    updateTestSnapshots: UpdateTestSnapshots,
+    deleteObsoleteSnapshots: DeleteObsoleteSnapshots,
+    getChangeCoverage: GetChangeCoverage,
+    getTestNotifications: GetTestNotifications,
+    setTestNotifications: SetTestNotifications,
+    openTestFailure: OpenTestFailure,
     getTestDiscovery: GetTestDiscovery,
     scanProjectTests: ScanProjectTests,
     writeTerminal: WriteTerminal,
@@ -254,7 +313,8 @@ async function init() {
   // Setup todo dashboard callbacks
   setTodoDashboardCallbacks({
     getTodos: GetTodos,
-    saveTodos: SaveTodos
+    saveTodos: SaveTodos,
+    importCodeTodos: ImportCodeTodos
   });
 
   // Setup git dashboard callbacks
@@ -292,6 +352,7 @@ async function init() {
 
     if (status === 'none') {
       state.claudeStatus.delete(terminalId);
+      state.claudeContext.delete(terminalId);
     } else {
       state.claudeStatus.set(terminalId, status);
     }
@@ -301,6 +362,28 @@ async function init() {
     }
   });
 
+  // Claude model and permission mode per terminal
+  EventsOn('state:claude:session', (data) => {
+    const { terminalId, model, mode } = data;
+    if (!model && !mode) {
+      state.claudeSessions.delete(terminalId);
+    } else {
+      state.claudeSessions.set(terminalId, { model, mode });
+    }
+    updateClaudeStatusUI(terminalId);
+  });
+
+  // Estimated context window left per Claude terminal
+  EventsOn('claude-context-usage', (data) => {
+    const { terminalId, usage } = data;
+    if (!usage || usage.percentLeft < 0) {
+      state.claudeContext.delete(terminalId);
+    } else {
+      state.claudeContext.set(terminalId, usage);
+    }
+    updateClaudeStatusUI(terminalId);
+  });
+
   // Test status detection from terminal output
   EventsOn('test-status', (data) => {
     if (data && data.terminalId && data.summary) {
@@ -308,6 +391,19 @@ async function init() {
     }
   });
 
+  // Clicked test notification: show the project and terminal the run finished in
+  EventsOn('test-notification-clicked', async (data) => {
+    if (!data?.projectId) return;
+    await selectProject(data.projectId);
+    if (state.activeProject?.id === data.projectId && data.terminalId) {
+      state.activeTerminalId = data.terminalId;
+    }
+  });
+
+  // Finished test runs only notify while the window is in the background
+  window.addEventListener('focus', () => SetWindowFocused(true));
+  window.addEventListener('blur', () => SetWindowFocused(false));
+
   // Render UI
   render();
 
@@ -335,6 +431,9 @@ async function init() {
   // Initialize keyboard shortcuts (Shift+Arrow for project/tab navigation)
   initKeyboardShortcuts();
 
+  // Check for Claude Code CLI updates in the background
+  initClaudeVersionCheck();
+
   // Detect fullscreen and toggle titlebar visibility
   async function updateFullscreenClass() {
     const isFs = await WindowIsFullscreen();
@@ -343,9 +442,10 @@ async function init() {
   updateFullscreenClass();
   window.addEventListener('resize', updateFullscreenClass);
 
-  // Load containers if docker available
+  // Load containers if docker available, then keep them live via Docker events
   if (state.dockerAvailable) {
     refreshContainers();
+    initDockerEvents();
   }
 
   // If we have projects, select the first one or the previously active one
@@ -394,6 +494,8 @@ function render() {
             </div>
           </div>
 
+          <div class="claude-update-notice hidden" id="claudeUpdateNotice"></div>
+
           <div class="sidebar-shortcuts-hint" onclick="window.showShortcutsModal()">
             <span class="shortcuts-hint-key">⌘K</span> Shortcuts
           </div>
@@ -453,6 +555,7 @@ function render() {
                     ${state.dockerAvailable ? `
                       <div class="docker-header">
                         <h3>Containers</h3>
+                        <button id="composeLogsBtn" class="small-btn">📜 Compose logs</button>
                         <button id="refreshContainers" class="small-btn">🔄 Refresh</button>
                       </div>
                       <div id="containerList" class="container-list"></div>
@@ -507,6 +610,12 @@ function render() {
                 <button class="tools-tab" data-tools-tab="mcp">
                   <span class="tools-tab-icon">🔌</span> MCP
                 </button>
+                <button class="tools-tab" data-tools-tab="rules">
+                  <span class="tools-tab-icon">📏</span> Rules
+                </button>
+                <button class="tools-tab" data-tools-tab="profiles">
+                  <span class="tools-tab-icon">🧰</span> Profiles
+                </button>
                 <button class="tools-tab" data-tools-tab="libs">
                   <span class="tools-tab-icon">📦</span> Libs
                 </button>
@@ -540,6 +649,12 @@ function render() {
               <div id="toolsMcpTab" class="tools-tab-content" style="display:none;">
                 <div class="tools-list" id="mcpList"></div>
               </div>
+              <div id="toolsRulesTab" class="tools-tab-content" style="display:none;">
+                <div class="tools-list" id="rulesList"></div>
+              </div>
+              <div id="toolsProfilesTab" class="tools-tab-content" style="display:none;">
+                <div class="tools-list" id="profilesList"></div>
+              </div>
               <div id="toolsLibsTab" class="tools-tab-content" style="display:none;">
                 <div id="libsList"></div>
               </div>
@@ -759,6 +874,7 @@ function setupEventListeners() {
   if (refreshBtn) {
     refreshBtn.addEventListener('click', refreshContainers);
   }
+  document.getElementById('composeLogsBtn')?.addEventListener('click', showComposeLogs);
 
   // Browser panel expand
   document.getElementById('expandBrowserPanel')?.addEventListener('click', expandBrowserPanel);
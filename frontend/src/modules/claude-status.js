@@ -1,4 +1,5 @@
 import { state } from './state.js';
+import { GetClaudeVersion, UpdateClaudeCLI } from '../../wailsjs/go/main/App';
 
 // Claude status UI functions
 export function updateClaudeStatusUI(terminalId) {
@@ -31,6 +32,8 @@ function updateClaudeStatusElement(element, terminalId) {
   if (existingIndicator) {
     existingIndicator.remove();
   }
+  updateClaudeModelBadge(element, terminalId);
+  updateClaudeContextGauge(element, terminalId);
 
   if (!status || status === 'none') return;
 
@@ -46,6 +49,56 @@ function updateClaudeStatusElement(element, terminalId) {
   }
 }
 
+const MODE_LABELS = {
+  plan: 'plan',
+  accept_edits: 'auto-edit',
+  bypass: 'bypass',
+};
+
+// Label a terminal with the Claude model (and non-default mode) it is running
+function updateClaudeModelBadge(element, terminalId) {
+  element.querySelector('.claude-model-badge')?.remove();
+
+  const session = state.claudeSessions.get(terminalId);
+  if (!session?.model && !MODE_LABELS[session?.mode]) return;
+
+  const badge = document.createElement('span');
+  badge.className = `claude-model-badge claude-mode-${session.mode || 'default'}`;
+  badge.textContent = [session.model, MODE_LABELS[session.mode]].filter(Boolean).join(' · ');
+  badge.title = `Claude ${session.model || ''}${session.mode ? ` (${session.mode.replace('_', ' ')} mode)` : ''}`;
+
+  const nameEl = element.querySelector('.name');
+  if (nameEl) {
+    nameEl.after(badge);
+  } else {
+    element.appendChild(badge);
+  }
+}
+
+// Show how much context a Claude session has left before it auto-compacts
+function updateClaudeContextGauge(element, terminalId) {
+  element.querySelector('.claude-context-gauge')?.remove();
+
+  const usage = state.claudeContext.get(terminalId);
+  if (!usage || usage.percentLeft < 0) return;
+
+  const gauge = document.createElement('span');
+  gauge.className = `claude-context-gauge${usage.low ? ' claude-context-low' : ''}`;
+  gauge.textContent = `${usage.percentLeft}%`;
+  const tokens = usage.maxTokens ? ` (${Math.round(usage.usedTokens / 1000)}k/${Math.round(usage.maxTokens / 1000)}k tokens used)` : '';
+  const compactions = usage.compactions ? `, compacted ${usage.compactions}×` : '';
+  gauge.title = usage.low
+    ? `Context nearly full: ${usage.percentLeft}% left before auto-compact${tokens}${compactions}. Run /compact or start a new session.`
+    : `${usage.percentLeft}% context left before auto-compact${tokens}${compactions}`;
+
+  const anchor = element.querySelector('.claude-model-badge') || element.querySelector('.name');
+  if (anchor) {
+    anchor.after(gauge);
+  } else {
+    element.appendChild(gauge);
+  }
+}
+
 export function updateProjectClaudeStatus(projectTab, projectId) {
   // Status classes removed - only active project gets green highlight via CSS
 }
@@ -55,6 +108,10 @@ export function getClaudeStatusTitle(status) {
     case 'working': return 'Claude is working...';
     case 'idle': return 'Claude is ready';
     case 'needs_action': return 'Claude needs your input';
+    case 'waiting_permission': return 'Claude is waiting for tool permission';
+    case 'rate_limited': return 'Claude is rate limited or the API is overloaded';
+    case 'compacting': return 'Claude is compacting the conversation...';
+    case 'plan_mode': return 'Claude is ready (plan mode)';
     default: return '';
   }
 }
@@ -68,3 +125,47 @@ export function updateAllProjectClaudeStatus() {
     }
   }
 }
+
+// Claude Code CLI version check: show a sidebar notice when an update is published
+const VERSION_CHECK_INTERVAL = 6 * 60 * 60 * 1000;
+
+export function initClaudeVersionCheck() {
+  checkClaudeVersion(false);
+  setInterval(() => checkClaudeVersion(false), VERSION_CHECK_INTERVAL);
+}
+
+async function checkClaudeVersion(force) {
+  const notice = document.getElementById('claudeUpdateNotice');
+  if (!notice) return;
+
+  try {
+    const info = await GetClaudeVersion(force);
+    if (!info?.updateAvailable) {
+      notice.classList.add('hidden');
+      return;
+    }
+
+    notice.innerHTML = `
+      <span class="claude-update-text" title="Installed ${info.version} at ${info.path}">
+        Claude Code ${info.latest} available <span class="claude-update-current">(you have ${info.version})</span>
+      </span>
+      <button class="small-btn claude-update-btn" title="${info.updateCommand}">Update</button>
+    `;
+    notice.classList.remove('hidden');
+
+    notice.querySelector('.claude-update-btn')?.addEventListener('click', async () => {
+      if (!state.activeProject) {
+        alert(`Select a project to run the update, or run it manually:\n\n${info.updateCommand}`);
+        return;
+      }
+      try {
+        await UpdateClaudeCLI(state.activeProject.id);
+        notice.classList.add('hidden');
+      } catch (err) {
+        alert('Failed to start Claude update: ' + err);
+      }
+    });
+  } catch (err) {
+    notice.classList.add('hidden');
+  }
+}
@@ -1,7 +1,11 @@
 import hljs from 'highlight.js';
 import { state } from './state.js';
 import { escapeHtml } from './utils.js';
-import { GetGitFileDiff } from '../../wailsjs/go/main/App';
+import { gitRepoPath } from './git.js';
+import { GetGitFileDiff, GetGitFileDiffChunk } from '../../wailsjs/go/main/App';
+
+// Lines fetched per request when a diff is too large to load at once
+const DIFF_CHUNK_LINES = 2000;
 
 // Callback for switching tabs
 let onSwitchTab = null;
@@ -43,11 +47,16 @@ export async function showGitDiff(filePath) {
   `;
 
   try {
-    const diff = await GetGitFileDiff(state.activeProject.path, filePath);
+    const diff = await GetGitFileDiff(gitRepoPath(), filePath);
     const oldContent = document.getElementById('diffOldContent');
     const newContent = document.getElementById('diffNewContent');
 
-    if (diff) {
+    if (diff && diff.isBinary) {
+      oldContent.textContent = '(binary file)';
+      newContent.textContent = '(binary file)';
+    } else if (diff && diff.truncated) {
+      await showChunkedDiff(viewer, filePath);
+    } else if (diff) {
       highlightDiffContent(oldContent, newContent, diff.oldContent || '', diff.newContent || '', filePath);
       setupDiffSyncScroll(oldContent, newContent);
     } else {
@@ -60,6 +69,30 @@ export async function showGitDiff(filePath) {
   }
 }
 
+// Show a large diff as unified text, loading it in chunks on demand
+async function showChunkedDiff(viewer, filePath) {
+  viewer.innerHTML = `
+    <div class="diff-large-notice">Large diff - showing unified view</div>
+    <pre class="diff-content diff-unified" id="diffUnifiedContent"></pre>
+    <button class="diff-load-more hidden" id="diffLoadMore">Load more</button>
+  `;
+  const content = document.getElementById('diffUnifiedContent');
+  const loadMore = document.getElementById('diffLoadMore');
+  let offset = 0;
+
+  const loadChunk = async () => {
+    const chunk = await GetGitFileDiffChunk(gitRepoPath(), filePath, offset, DIFF_CHUNK_LINES);
+    if (!chunk || state.git.currentDiffFile !== filePath) return;
+    content.insertAdjacentText('beforeend', chunk.lines.join('\n') + '\n');
+    offset += chunk.lines.length;
+    loadMore.textContent = `Load more (${offset} / ${chunk.totalLines} lines)`;
+    loadMore.classList.toggle('hidden', !chunk.hasMore);
+  };
+
+  loadMore.addEventListener('click', loadChunk);
+  await loadChunk();
+}
+
 // Get language for highlight.js based on file extension
 function getLanguageFromPath(filePath) {
   const ext = filePath.split('.').pop()?.toLowerCase();
@@ -5,8 +5,27 @@ import {
   StartContainer,
   StopContainer,
   RestartContainer,
-  GetContainerLogs
+  RemoveContainer,
+  InspectContainer,
+  FollowComposeLogs,
+  StopComposeLogs,
+  GetProjectServicesHealth,
+  FollowContainerLogs,
+  StopContainerLogs
 } from '../../wailsjs/go/main/App';
+import { EventsOn, BrowserOpenURL } from '../../wailsjs/runtime/runtime';
+
+// Container whose logs are currently streamed into the logs modal
+let followedContainerId = null;
+let unsubscribeLogs = null;
+
+// Active compose logs stream and the services hidden by the filter
+let composeLogsProjectId = null;
+let unsubscribeComposeLogs = null;
+const hiddenServices = new Set();
+
+// Coalesces bursts of container events (e.g. compose up) into one refresh
+let eventRefreshTimer = null;
 
 export async function refreshContainers() {
   if (!state.dockerAvailable) return;
@@ -19,6 +38,54 @@ export async function refreshContainers() {
   }
 }
 
+// Refresh the container list whenever the daemon reports a container change
+export function initDockerEvents() {
+  EventsOn('docker-container-event', () => {
+    clearTimeout(eventRefreshTimer);
+    eventRefreshTimer = setTimeout(refreshContainers, 250);
+  });
+
+  // Switching projects may switch to another daemon (Docker context)
+  EventsOn('docker-context-changed', () => {
+    refreshContainers();
+  });
+
+  EventsOn('project-services-health', (data) => {
+    if (!data || !data.projectId) return;
+    state.servicesHealth.set(data.projectId, data.health);
+    renderServicesHealth();
+  });
+
+  if (state.activeProject) {
+    GetProjectServicesHealth(state.activeProject.id)
+      .then(health => {
+        state.servicesHealth.set(state.activeProject.id, health);
+        renderServicesHealth();
+      })
+      .catch(() => {});
+  }
+}
+
+// Compose services health dot next to the active project name
+export function renderServicesHealth() {
+  const el = document.getElementById('servicesHealth');
+  if (!el || !state.activeProject) return;
+
+  const health = state.servicesHealth.get(state.activeProject.id);
+  if (!health || health.overall === 'none') {
+    el.classList.add('hidden');
+    return;
+  }
+
+  const total = health.services.length;
+  const details = health.services
+    .map(s => `${s.service}: ${s.health !== 'none' ? s.health : s.state}`)
+    .join('\n');
+  el.className = `services-health ${health.overall}`;
+  el.textContent = `${health.healthy}/${total}`;
+  el.title = `${health.project} services\n${details}`;
+}
+
 export function renderContainers() {
   const container = document.getElementById('containerList');
   if (!container) return;
@@ -35,6 +102,7 @@ export function renderContainers() {
         <div class="details">
           <span class="name">${c.name}</span>
           <span class="image">${c.image}</span>
+          ${renderPortLinks(c)}
         </div>
       </div>
       <div class="container-actions">
@@ -43,14 +111,25 @@ export function renderContainers() {
           <button class="small-btn" onclick="window.restartContainer('${c.id}')">Restart</button>
         ` : `
           <button class="small-btn" onclick="window.startContainer('${c.id}')">Start</button>
+          <button class="small-btn" onclick="window.removeContainer('${c.id}')">Remove</button>
         `}
         <button class="small-btn" onclick="window.showContainerLogs('${c.id}')">Logs</button>
+        <button class="small-btn" onclick="window.showContainerDetails('${c.id}')">Details</button>
       </div>
     </div>
   `).join('');
 }
 
-export function showLogsModal(title, content) {
+// Published TCP ports of running containers, linking to localhost
+function renderPortLinks(c) {
+  if (c.state !== 'running' || !c.portMappings) return '';
+  const links = c.portMappings
+    .filter(p => p.hostPort > 0 && p.protocol === 'tcp')
+    .map(p => `<a class="container-port" href="#" onclick="window.openContainerPort(${p.hostPort}); return false;" title="Open http://localhost:${p.hostPort}">:${p.hostPort}→${p.containerPort}</a>`);
+  return links.length ? `<span class="ports">${links.join('')}</span>` : '';
+}
+
+export function showLogsModal(title, content, onClose) {
   const existingModal = document.getElementById('logsModal');
   if (existingModal) existingModal.remove();
 
@@ -60,26 +139,172 @@ export function showLogsModal(title, content) {
   modal.innerHTML = `
     <div class="modal-content logs-modal">
       <div class="logs-modal-header">
-        <h2>Logs: ${title}</h2>
+        <h2>Logs: ${escapeHtml(title)}</h2>
         <button class="close-modal-btn" id="closeLogsModal">×</button>
       </div>
-      <pre class="logs-content">${escapeHtml(content)}</pre>
+      <pre class="logs-content" id="logsContent">${escapeHtml(content)}</pre>
     </div>
   `;
 
   document.body.appendChild(modal);
 
-  document.getElementById('closeLogsModal').addEventListener('click', () => {
+  const close = () => {
     modal.remove();
-  });
+    if (onClose) onClose();
+  };
+
+  document.getElementById('closeLogsModal').addEventListener('click', close);
 
   modal.addEventListener('click', (e) => {
     if (e.target === modal) {
-      modal.remove();
+      close();
     }
   });
 }
 
+// Details drawer: command, env (secrets masked), mounts, networks, labels
+function showDetailsModal(d) {
+  const existingModal = document.getElementById('containerDetailsModal');
+  if (existingModal) existingModal.remove();
+
+  const rows = (items) => items.length
+    ? items.map(([k, v]) => `<tr><td>${escapeHtml(k)}</td><td>${escapeHtml(v)}</td></tr>`).join('')
+    : '<tr><td colspan="2" class="empty">None</td></tr>';
+
+  const modal = document.createElement('div');
+  modal.id = 'containerDetailsModal';
+  modal.className = 'modal';
+  modal.innerHTML = `
+    <div class="modal-content logs-modal container-details">
+      <div class="logs-modal-header">
+        <h2>${escapeHtml(d.name)}</h2>
+        <button class="close-modal-btn" id="closeContainerDetails">×</button>
+      </div>
+      <div class="details-body">
+        <h3>Overview</h3>
+        <table>${rows([
+          ['Image', d.image],
+          ['State', d.health ? `${d.state} (${d.health})` : d.state],
+          ['Command', d.command],
+          ['Working dir', d.workingDir || ''],
+          ['User', d.user || ''],
+          ['Restart policy', d.restartPolicy]
+        ])}</table>
+        <h3>Environment</h3>
+        <table>${rows(d.env.map(e => [e.name, e.value]))}</table>
+        <h3>Mounts</h3>
+        <table>${rows(d.mounts.map(m => [m.destination, `${m.type}: ${m.name || m.source}${m.readOnly ? ' (ro)' : ''}`]))}</table>
+        <h3>Networks</h3>
+        <table>${rows(d.networks.map(n => [n.name, [n.ipAddress, ...n.aliases].filter(Boolean).join(', ')]))}</table>
+        <h3>Labels</h3>
+        <table>${rows(Object.entries(d.labels).sort())}</table>
+      </div>
+    </div>
+  `;
+
+  document.body.appendChild(modal);
+  document.getElementById('closeContainerDetails').addEventListener('click', () => modal.remove());
+  modal.addEventListener('click', (e) => {
+    if (e.target === modal) modal.remove();
+  });
+}
+
+function stopFollowingLogs() {
+  if (followedContainerId) {
+    StopContainerLogs(followedContainerId);
+    followedContainerId = null;
+  }
+  if (unsubscribeLogs) {
+    unsubscribeLogs();
+    unsubscribeLogs = null;
+  }
+}
+
+function appendLogLine(data) {
+  if (!data || data.containerId !== followedContainerId) return;
+  const el = document.getElementById('logsContent');
+  if (!el) return;
+
+  const atBottom = el.scrollTop + el.clientHeight >= el.scrollHeight - 20;
+  el.appendChild(document.createTextNode(data.line + '\n'));
+  if (atBottom) el.scrollTop = el.scrollHeight;
+}
+
+// Combined logs of the active project's compose services, filterable by service
+export async function showComposeLogs() {
+  if (!state.activeProject) return;
+  stopComposeLogs();
+
+  const projectId = state.activeProject.id;
+  // Open the modal first so the initial tail lines have somewhere to go
+  showLogsModal(`${state.activeProject.name} (compose)`, '', stopComposeLogs);
+  hiddenServices.clear();
+
+  let services;
+  try {
+    composeLogsProjectId = projectId;
+    unsubscribeComposeLogs = EventsOn('docker-compose-log', appendComposeLogLine);
+    services = await FollowComposeLogs(projectId);
+  } catch (err) {
+    stopComposeLogs();
+    showLogsModal('Error', 'Error getting compose logs: ' + err);
+    return;
+  }
+
+  const filters = document.createElement('div');
+  filters.className = 'compose-log-filters';
+  filters.innerHTML = [...new Set(services.map(s => s.service))].map(name => {
+    const color = services.find(s => s.service === name).color;
+    return `<button class="compose-log-filter active" data-service="${escapeHtml(name)}" style="border-color: ${color}; color: ${color}">${escapeHtml(name)}</button>`;
+  }).join('');
+  filters.addEventListener('click', (e) => {
+    const btn = e.target.closest('.compose-log-filter');
+    if (!btn) return;
+    const service = btn.dataset.service;
+    const hidden = !hiddenServices.has(service);
+    if (hidden) hiddenServices.add(service); else hiddenServices.delete(service);
+    btn.classList.toggle('active', !hidden);
+    document.querySelectorAll('#logsContent .compose-log-line').forEach(el => {
+      if (el.dataset.service === service) el.classList.toggle('hidden', hidden);
+    });
+  });
+
+  const content = document.getElementById('logsContent');
+  if (content) content.parentNode.insertBefore(filters, content);
+}
+
+function stopComposeLogs() {
+  if (composeLogsProjectId) {
+    StopComposeLogs(composeLogsProjectId);
+    composeLogsProjectId = null;
+  }
+  if (unsubscribeComposeLogs) {
+    unsubscribeComposeLogs();
+    unsubscribeComposeLogs = null;
+  }
+}
+
+function appendComposeLogLine(data) {
+  if (!data || data.projectId !== composeLogsProjectId) return;
+  const el = document.getElementById('logsContent');
+  if (!el) return;
+
+  const atBottom = el.scrollTop + el.clientHeight >= el.scrollHeight - 20;
+  const line = document.createElement('div');
+  line.className = 'compose-log-line';
+  line.dataset.service = data.service;
+  if (hiddenServices.has(data.service)) line.classList.add('hidden');
+
+  const tag = document.createElement('span');
+  tag.className = 'compose-log-service';
+  tag.style.color = data.color;
+  tag.textContent = `${data.service} | `;
+  line.appendChild(tag);
+  line.appendChild(document.createTextNode(data.line));
+  el.appendChild(line);
+  if (atBottom) el.scrollTop = el.scrollHeight;
+}
+
 // Setup global window functions for onclick handlers
 export function setupDockerWindowFunctions() {
   window.startContainer = async (id) => {
@@ -97,13 +322,41 @@ export function setupDockerWindowFunctions() {
     refreshContainers();
   };
 
+  window.removeContainer = async (id) => {
+    const container = state.containers.find(c => c.id === id);
+    if (!confirm(`Remove container ${container ? container.name : id}?`)) return;
+    try {
+      await RemoveContainer(id, false);
+    } catch (err) {
+      alert('Failed to remove container: ' + err);
+    }
+    refreshContainers();
+  };
+
+  window.showContainerDetails = async (id) => {
+    try {
+      showDetailsModal(await InspectContainer(id));
+    } catch (err) {
+      showLogsModal('Error', 'Error inspecting container: ' + err);
+    }
+  };
+
+  window.openContainerPort = (port) => {
+    BrowserOpenURL(`http://localhost:${port}`);
+  };
+
   window.showContainerLogs = async (id) => {
+    stopFollowingLogs();
+    const container = state.containers.find(c => c.id === id);
+    const containerName = container ? container.name : id;
+    showLogsModal(containerName, '', stopFollowingLogs);
+
     try {
-      const container = state.containers.find(c => c.id === id);
-      const containerName = container ? container.name : id;
-      const logs = await GetContainerLogs(id);
-      showLogsModal(containerName, logs || 'No logs available');
+      followedContainerId = id;
+      unsubscribeLogs = EventsOn('docker-log', appendLogLine);
+      await FollowContainerLogs(id);
     } catch (err) {
+      stopFollowingLogs();
       showLogsModal('Error', 'Error getting logs: ' + err);
     }
   };
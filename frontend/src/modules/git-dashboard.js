@@ -2,6 +2,7 @@ import { state } from './state.js';
 import { registerStateHandler } from './project-switcher.js';
 import { showGitDiff } from './diff.js';
 import { escapeHtml } from './utils.js';
+import { gitRepoPath, renderGitRootSelect, bindGitRootSelect } from './git.js';
 import { GetGitStatus, GetGitCurrentBranch } from '../../wailsjs/go/main/App';
 
 // Special tab ID for Git History
@@ -94,11 +95,12 @@ function getCurrentCommits() {
 export async function loadGitHistory() {
   if (!state.activeProject) return;
 
+  const repoPath = gitRepoPath();
   try {
     const [commits, gitStatus, gitBranch] = await Promise.all([
-      gitDashboardCallbacks.getGitHistory(state.activeProject.path, 50),
-      GetGitStatus(state.activeProject.path).catch(() => null),
-      GetGitCurrentBranch(state.activeProject.path).catch(() => null)
+      gitDashboardCallbacks.getGitHistory(repoPath, 50),
+      GetGitStatus(repoPath).catch(() => null),
+      GetGitCurrentBranch(repoPath).catch(() => null)
     ]);
     if (commits && Array.isArray(commits)) {
       projectCommits.set(state.activeProject.id, commits);
@@ -312,6 +314,7 @@ export function renderGitDashboard() {
       <div class="git-dashboard-content">
         <div class="git-dashboard-header">
           <h2>Git History</h2>
+          ${renderGitRootSelect('gitHistoryRootSelect')}
           <button class="git-refresh-btn" id="refreshGitHistory" title="Refresh">🔄</button>
         </div>
         <div class="git-empty-state">
@@ -329,6 +332,7 @@ export function renderGitDashboard() {
       ${renderActivityCard(commits)}
       <div class="git-dashboard-header">
         <h2>Git History</h2>
+        ${renderGitRootSelect('gitHistoryRootSelect')}
         <button class="git-refresh-btn" id="refreshGitHistory" title="Refresh">🔄</button>
       </div>
       <div class="git-commits-grid">
@@ -418,6 +422,9 @@ function setupGitDashboardListeners() {
     loadGitHistory();
   });
 
+  // Repository picker
+  bindGitRootSelect('gitHistoryRootSelect');
+
   // Expand/collapse buttons
   document.querySelectorAll('.commit-expand-btn').forEach(btn => {
     btn.addEventListener('click', (e) => {
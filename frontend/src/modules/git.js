@@ -2,15 +2,69 @@ import { state } from './state.js';
 import {
   IsGitRepo,
   GetGitChangedFiles,
-  GetGitCurrentBranch
+  GetGitCurrentBranch,
+  GetGitRepoRoots,
+  IgnoreGitFile
 } from '../../wailsjs/go/main/App';
 import { registerStateHandler } from './project-switcher.js';
+import { escapeHtml } from './utils.js';
 
 // Callback for showing diff
 let onShowGitDiff = null;
+// Callback for when the repositories found or the one shown change
+let onGitRootChange = null;
+
+// Repositories found in each project (the project's own and nested ones),
+// and the one the Git views show
+const projectRoots = new Map(); // projectId -> RepoRoot[]
+const selectedRoots = new Map(); // projectId -> repository path
 
 export function setGitCallbacks(callbacks) {
   onShowGitDiff = callbacks.showGitDiff;
+  onGitRootChange = callbacks.gitRootChanged;
+}
+
+// Path of the repository the Git views show: the chosen root, else the project
+export function gitRepoPath() {
+  if (!state.activeProject) return '';
+  return selectedRoots.get(state.activeProject.id) || state.activeProject.path;
+}
+
+// Repositories found in the active project
+export function getGitRoots() {
+  if (!state.activeProject) return [];
+  return projectRoots.get(state.activeProject.id) || [];
+}
+
+// Switch the Git views to another repository of the active project
+export async function selectGitRoot(path) {
+  if (!state.activeProject) return;
+  selectedRoots.set(state.activeProject.id, path);
+  state.git.currentDiffFile = null;
+  await refreshGitStatus();
+  if (onGitRootChange) onGitRootChange();
+}
+
+// Root picker, shown when a project holds more than one repository
+export function renderGitRootSelect(id) {
+  const roots = getGitRoots();
+  if (roots.length < 2) return '';
+
+  const current = gitRepoPath();
+  const options = roots.map(root => {
+    const label = root.relPath === '.' ? `${state.activeProject.name} (project)` : root.relPath;
+    const changes = root.staged + root.unstaged + root.untracked;
+    const details = [root.branch, changes > 0 ? `${changes} changed` : ''].filter(Boolean).join(', ');
+    return `<option value="${escapeHtml(root.path)}" ${root.path === current ? 'selected' : ''}>${escapeHtml(label)}${details ? ` (${escapeHtml(details)})` : ''}</option>`;
+  }).join('');
+  return `<select class="git-root-select" id="${id}" title="Repository">${options}</select>`;
+}
+
+export function bindGitRootSelect(id) {
+  const select = document.getElementById(id);
+  if (!select) return;
+  select.addEventListener('click', (e) => e.stopPropagation());
+  select.addEventListener('change', () => selectGitRoot(select.value));
 }
 
 export function setupGitSection() {
@@ -69,7 +123,28 @@ export async function refreshGitStatus() {
     return;
   }
 
-  const path = state.activeProject.path;
+  const project = state.activeProject;
+  const previousPath = gitRepoPath();
+  const previousRoots = getGitRoots().length;
+
+  // Nested repositories; a root that is gone falls back to the first one
+  try {
+    const roots = await GetGitRepoRoots(project.path) || [];
+    projectRoots.set(project.id, roots);
+    const selected = selectedRoots.get(project.id);
+    if (!selected || !roots.some(r => r.path === selected)) {
+      if (roots.length > 0) {
+        selectedRoots.set(project.id, roots[0].path);
+      } else {
+        selectedRoots.delete(project.id);
+      }
+    }
+  } catch (err) {
+    console.error('Failed to find git repositories:', err);
+    projectRoots.delete(project.id);
+  }
+
+  const path = gitRepoPath();
 
   try {
     state.git.isRepo = await IsGitRepo(path);
@@ -90,6 +165,10 @@ export async function refreshGitStatus() {
 
   renderGitFileList();
   updateGitDisplay();
+
+  if (onGitRootChange && (path !== previousPath || getGitRoots().length !== previousRoots)) {
+    onGitRootChange();
+  }
 }
 
 export function updateGitDisplay() {
@@ -120,11 +199,10 @@ export function updateGitDisplay() {
   }
 
   if (branchBar) {
-    if (state.git.branch) {
-      branchBar.innerHTML = `<span class="git-branch-icon">⎇</span> ${state.git.branch}`;
-    } else {
-      branchBar.innerHTML = '';
-    }
+    const rootSelect = renderGitRootSelect('gitRootSelect');
+    const branch = state.git.branch ? `<span class="git-branch-icon">⎇</span> ${escapeHtml(state.git.branch)}` : '';
+    branchBar.innerHTML = rootSelect + branch;
+    bindGitRootSelect('gitRootSelect');
   }
 }
 
@@ -175,6 +253,24 @@ export function renderGitFileList() {
       }
     });
   });
+
+  container.querySelectorAll('.git-ignore-btn').forEach(btn => {
+    btn.addEventListener('click', async (e) => {
+      e.stopPropagation();
+      await ignoreGitFile(btn.dataset.path);
+    });
+  });
+}
+
+async function ignoreGitFile(filePath) {
+  if (!state.activeProject || !filePath) return;
+
+  try {
+    await IgnoreGitFile(gitRepoPath(), filePath);
+    await refreshGitStatus();
+  } catch (err) {
+    console.error('Failed to add file to .gitignore:', err);
+  }
 }
 
 function renderGitFileItem(file) {
@@ -187,6 +283,7 @@ function renderGitFileItem(file) {
       <span class="git-status ${getStatusClass(file.status)}">${statusIcon}</span>
       <span class="git-file-name">${fileName}</span>
       <span class="git-file-dir">${dirPath}</span>
+      ${file.status === '?' ? `<button class="git-ignore-btn" data-path="${file.path}" title="Add to .gitignore">⊘</button>` : ''}
     </div>
   `;
 }
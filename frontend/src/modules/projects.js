@@ -5,6 +5,7 @@ import { UpdateProject, SelectDirectory } from '../../wailsjs/go/main/App';
 import { switchProject } from './project-switcher.js';
 import { renderITermPanel, focusProjectTab } from './iterm-panel.js';
 import { refreshGitStatus } from './git.js';
+import { renderServicesHealth } from './docker.js';
 
 // Open edit project modal
 export function openEditProjectModal() {
@@ -171,6 +172,7 @@ export function updateWorkspaceInfo() {
         <div class="project-header">
           <span class="icon" style="color: ${state.activeProject.color}">${state.activeProject.icon}</span>
           <span class="name">${state.activeProject.name}</span>
+          <span class="services-health hidden" id="servicesHealth"></span>
         </div>
         <p class="path">${state.activeProject.path}</p>
       </div>
@@ -182,6 +184,7 @@ export function updateWorkspaceInfo() {
       projectInfo.style.cursor = 'pointer';
       projectInfo.addEventListener('dblclick', openEditProjectModal);
     }
+    renderServicesHealth();
   } else {
     container.innerHTML = `<p class="no-project">No project selected</p>`;
   }
@@ -4,11 +4,28 @@ import {
   StartRemoteAccess,
   StopRemoteAccess,
   GetRemoteAccessStatus,
+  GetRemoteAccessQRCode,
   GetRemoteAccessClients,
   AddApprovedClient,
   RemoveApprovedClient,
-  GetApprovedClients
+  GetApprovedClients,
+  SetApprovedClientScope,
+  BeginApprovedClientTOTP,
+  ConfirmApprovedClientTOTP,
+  DisableApprovedClientTOTP,
+  GetRemoteAuditLog,
+  SaveRemoteAuditLogExport,
+  GetRemoteRecordings,
+  DeleteRemoteRecording,
+  GetRemoteAuthAttempts,
+  UnlockRemoteIP,
+  GetRemoteBannedIPs,
+  BanRemoteIP,
+  UnbanRemoteIP,
+  ApproveRemoteAccessRequest,
+  DenyRemoteAccessRequest
 } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 
 // Remote access state
 let remoteStatus = {
@@ -16,22 +33,95 @@ let remoteStatus = {
   savedDevicesOnly: false,
   running: false,
   port: 9090,
+  bindHost: '',
   localUrl: '',
   publicUrl: '',
   token: '',
   clientCount: 0,
-  clients: []
+  clients: [],
+  lanUrl: '',
+  tls: false,
+  fingerprint: '',
+  tunnels: []
 };
 
 // Approved clients list
 let approvedClients = [];
 
+// Capabilities a device can be limited to
+const CAPABILITIES = [
+  { id: 'terminals', label: 'Terminals' },
+  { id: 'files', label: 'Files' },
+  { id: 'git', label: 'Git' },
+  { id: 'docker', label: 'Docker' }
+];
+
 let statusInterval = null;
+
+// How many audit log entries the panel shows
+const AUDIT_LOG_LIMIT = 100;
 let isTabActive = false;
 
+// Access requests expire on the server after this long
+const ACCESS_REQUEST_TTL_MS = 10 * 60 * 1000;
+
 // Initialize remote access panel
 export function initRemoteAccess() {
   // Status polling is now managed by tab visibility
+  EventsOn('remote-access-request', showAccessRequestPrompt);
+}
+
+// Ask whether a device that requested access from the login page may connect
+function showAccessRequestPrompt(req) {
+  let container = document.getElementById('accessRequestPrompts');
+  if (!container) {
+    container = document.createElement('div');
+    container.id = 'accessRequestPrompts';
+    container.className = 'access-request-prompts';
+    document.body.appendChild(container);
+  }
+
+  const prompt = document.createElement('div');
+  prompt.className = 'access-request-prompt';
+  prompt.innerHTML = `
+    <div class="access-request-title">Device asks for remote access</div>
+    <div class="access-request-name">${escapeHtml(req.name)}</div>
+    <div class="access-request-meta">
+      Code <code>${escapeHtml(req.fingerprint)}</code> | ${escapeHtml(req.remoteAddr)}
+    </div>
+    <div class="access-request-meta" title="${escapeHtml(req.userAgent || '')}">${escapeHtml(req.userAgent || '')}</div>
+    <div class="access-request-hint">Approve only if the device shows the same code.</div>
+    <div class="access-request-actions">
+      <button class="small-btn" data-action="approve">Approve</button>
+      <button class="small-btn" data-action="viewer">View Only</button>
+      <button class="small-btn danger" data-action="deny">Deny</button>
+    </div>
+  `;
+  container.appendChild(prompt);
+
+  const expiry = setTimeout(() => prompt.remove(), ACCESS_REQUEST_TTL_MS);
+  prompt.querySelectorAll('button').forEach(btn => {
+    btn.addEventListener('click', async () => {
+      const action = btn.dataset.action;
+      prompt.querySelectorAll('button').forEach(b => b.disabled = true);
+      try {
+        if (action === 'deny') {
+          await DenyRemoteAccessRequest(req.id);
+        } else {
+          await ApproveRemoteAccessRequest(req.id, action === 'viewer');
+          showCopyNotification(`${req.name} approved`);
+          if (isTabActive) {
+            loadApprovedClients();
+          }
+        }
+      } catch (err) {
+        console.error('Failed to answer access request:', err);
+        alert('Failed to answer access request: ' + err);
+      }
+      clearTimeout(expiry);
+      prompt.remove();
+    });
+  });
 }
 
 // Called when switching TO the Remote tab
@@ -40,12 +130,18 @@ export function showRemoteAccessPanel() {
   renderRemoteAccessPanel();
   loadRemoteStatus();
   loadApprovedClients();
+  loadAuditLog();
+  loadRecordings();
+  loadAuthLists();
 
   // Start polling only when tab is active
   if (!statusInterval) {
     statusInterval = setInterval(() => {
       if (isTabActive && remoteStatus.running) {
         loadRemoteStatus();
+        loadAuditLog();
+        loadRecordings();
+        loadAuthLists();
       }
     }, 5000);
   }
@@ -82,19 +178,47 @@ export async function startRemoteAccess() {
 
   // Get config from form
   const port = parseInt(document.getElementById('remotePort')?.value) || 9090;
+  const bindHost = document.getElementById('bindHost')?.value.trim() || '';
   const ngrokPlan = document.getElementById('ngrokPlan')?.value || 'free';
   const subdomain = document.getElementById('ngrokSubdomain')?.value || '';
   const tokenExpiry = parseInt(document.getElementById('tokenExpiry')?.value) || 24;
   const enableNgrok = document.getElementById('enableNgrok')?.checked || false;
   const savedDevicesOnly = document.getElementById('savedDevicesOnly')?.checked || false;
+  const accessRequests = document.getElementById('accessRequests')?.checked || false;
+  const tlsMode = document.getElementById('tlsMode')?.value || '';
+  const certFile = document.getElementById('tlsCertFile')?.value.trim() || '';
+  const keyFile = document.getElementById('tlsKeyFile')?.value.trim() || '';
+  const recordSessions = document.getElementById('recordSessions')?.checked || false;
+  const recordingDays = parseInt(document.getElementById('recordingDays')?.value) || 0;
+  const ngrokRegion = document.getElementById('ngrokRegion')?.value || '';
+  const ngrokDomain = document.getElementById('ngrokDomain')?.value.trim() || '';
+  const ngrokEdge = document.getElementById('ngrokEdge')?.value.trim() || '';
+  const extraTunnels = parseExtraTunnels(document.getElementById('extraTunnels')?.value || '');
+  const maxAuthAttempts = parseInt(document.getElementById('maxAuthAttempts')?.value) || 50;
+  const authLockoutMinutes = parseInt(document.getElementById('authLockoutMinutes')?.value) || 1;
+  const whisperModel = document.getElementById('whisperModel')?.value.trim() || '';
 
   const config = {
     enabled: enableNgrok,
     savedDevicesOnly: savedDevicesOnly,
+    accessRequests: accessRequests,
     port: port,
+    bindHost: bindHost,
     ngrokPlan: ngrokPlan,
     subdomain: subdomain,
-    tokenExpiry: tokenExpiry
+    tokenExpiry: tokenExpiry,
+    tlsMode: tlsMode,
+    certFile: certFile,
+    keyFile: keyFile,
+    recordSessions: recordSessions,
+    recordingDays: recordingDays,
+    ngrokRegion: ngrokRegion,
+    ngrokDomain: ngrokDomain,
+    ngrokEdge: ngrokEdge,
+    extraTunnels: extraTunnels,
+    maxAuthAttempts: maxAuthAttempts,
+    authLockoutMinutes: authLockoutMinutes,
+    whisperModel: whisperModel
   };
 
   try {
@@ -121,6 +245,18 @@ export async function startRemoteAccess() {
   }
 }
 
+// Parse the extra ports to share: "3000, 5173=dev.example.com" gives a
+// tunnel per port, optionally on a reserved domain
+function parseExtraTunnels(text) {
+  return text.split(',')
+    .map(item => item.trim())
+    .filter(item => item)
+    .map(item => {
+      const [port, domain] = item.split('=').map(part => part.trim());
+      return { name: '', port: parseInt(port) || 0, domain: domain || '' };
+    });
+}
+
 // Stop remote access
 export async function stopRemoteAccess() {
   try {
@@ -135,11 +271,16 @@ export async function stopRemoteAccess() {
       enabled: false,
       running: false,
       port: remoteStatus.port,
+      bindHost: '',
       localUrl: '',
       publicUrl: '',
       token: '',
       clientCount: 0,
-      clients: []
+      clients: [],
+      lanUrl: '',
+      tls: false,
+      fingerprint: '',
+      tunnels: []
     };
     updateRemoteAccessUI();
   } catch (err) {
@@ -155,14 +296,16 @@ export async function stopRemoteAccess() {
 
 // Copy URL to clipboard
 export function copyRemoteUrl(type) {
-  const url = type === 'public' ? remoteStatus.publicUrl : remoteStatus.localUrl;
+  const urls = { public: remoteStatus.publicUrl, lan: remoteStatus.lanUrl, local: remoteStatus.localUrl };
+  const url = urls[type] || remoteStatus.localUrl;
   if (url) {
     navigator.clipboard.writeText(url);
     showCopyNotification('URL copied to clipboard!');
   }
 }
 
-// Generate QR code locally using qrcode library
+// Generate QR code locally using qrcode library, used when the backend
+// can't render one
 async function generateQRCode(text, size = 200) {
   try {
     const qrDataUrl = await QRCode.toDataURL(text, {
@@ -181,16 +324,24 @@ async function generateQRCode(text, size = 200) {
   }
 }
 
-// Show QR code modal with locally generated QR code
+// Show QR code modal with locally generated QR code; a phone can't open
+// localhost, so the public or LAN URL is preferred
 export async function showQRCode() {
-  const url = remoteStatus.publicUrl || remoteStatus.localUrl;
+  const target = remoteStatus.publicUrl ? 'public' : remoteStatus.lanUrl ? 'lan' : 'local';
+  const url = remoteStatus.publicUrl || remoteStatus.lanUrl || remoteStatus.localUrl;
   if (!url) return;
 
   const existingModal = document.getElementById('qrModal');
   if (existingModal) existingModal.remove();
 
-  // Generate QR code locally
-  const qrDataUrl = await generateQRCode(url, 200);
+  // Rendered by the backend, with the token in the URL
+  let qrDataUrl = null;
+  try {
+    qrDataUrl = await GetRemoteAccessQRCode(target);
+  } catch (err) {
+    console.error('Failed to get QR code from backend:', err);
+    qrDataUrl = await generateQRCode(url, 200);
+  }
 
   const modal = document.createElement('div');
   modal.id = 'qrModal';
@@ -212,6 +363,9 @@ export async function showQRCode() {
           </div>
         `}
         <p class="qr-url">${escapeHtml(url)}</p>
+        ${remoteStatus.fingerprint && !remoteStatus.publicUrl ? `
+          <p class="qr-fingerprint">Certificate SHA-256: ${escapeHtml(remoteStatus.fingerprint)}</p>
+        ` : ''}
         <button class="copy-btn" id="copyQrUrlBtn">
           Copy URL
         </button>
@@ -226,7 +380,7 @@ export async function showQRCode() {
   });
 
   document.getElementById('copyQrUrlBtn').addEventListener('click', () => {
-    copyRemoteUrl(remoteStatus.publicUrl ? 'public' : 'local');
+    copyRemoteUrl(remoteStatus.publicUrl ? 'public' : remoteStatus.lanUrl ? 'lan' : 'local');
   });
 
   modal.addEventListener('click', (e) => {
@@ -269,11 +423,11 @@ function updateRemoteAccessUI() {
   if (remoteStatus.running) {
     // Show running state
     if (statusSection) {
-      const modeText = remoteStatus.savedDevicesOnly ? ' (Saved Devices Only)' : '';
+      const modeText = (remoteStatus.tls ? ' over HTTPS' : '') + (remoteStatus.savedDevicesOnly ? ' (Saved Devices Only)' : '');
       statusSection.innerHTML = `
         <div class="status-indicator running">
           <span class="status-dot"></span>
-          <span>Running on port ${remoteStatus.port}${modeText}</span>
+          <span>Running on ${remoteStatus.bindHost ? `${escapeHtml(remoteStatus.bindHost)} ` : ''}port ${remoteStatus.port}${modeText}</span>
         </div>
         <div class="remote-urls">
           ${remoteStatus.localUrl ? `
@@ -283,6 +437,13 @@ function updateRemoteAccessUI() {
               <button class="small-btn" id="copyLocalUrlBtn">Copy</button>
             </div>
           ` : ''}
+          ${remoteStatus.lanUrl ? `
+            <div class="url-row">
+              <label>LAN:</label>
+              <input type="text" readonly value="${escapeHtml(remoteStatus.lanUrl)}" class="url-input" />
+              <button class="small-btn" id="copyLanUrlBtn">Copy</button>
+            </div>
+          ` : ''}
           ${remoteStatus.publicUrl ? `
             <div class="url-row public">
               <label>Public:</label>
@@ -290,7 +451,21 @@ function updateRemoteAccessUI() {
               <button class="small-btn" id="copyPublicUrlBtn">Copy</button>
             </div>
           ` : ''}
+          ${(remoteStatus.tunnels || []).map(t => `
+            <div class="url-row">
+              <label>:${t.port}</label>
+              <input type="text" readonly value="${escapeHtml(t.publicUrl || 'Not started')}" class="url-input" />
+              ${t.publicUrl ? `<button class="small-btn copy-tunnel-btn" data-url="${escapeHtml(t.publicUrl)}">Copy</button>` : ''}
+            </div>
+          `).join('')}
         </div>
+        ${remoteStatus.fingerprint ? `
+          <div class="remote-fingerprint">
+            <label>Certificate SHA-256</label>
+            <code>${escapeHtml(remoteStatus.fingerprint)}</code>
+            <span class="checkbox-hint">Check that the browser shows this fingerprint before accepting the certificate</span>
+          </div>
+        ` : ''}
         <button class="qr-btn" id="showQrBtn">
           Show QR Code
         </button>
@@ -298,8 +473,15 @@ function updateRemoteAccessUI() {
 
       // Add event listeners
       document.getElementById('copyLocalUrlBtn')?.addEventListener('click', () => copyRemoteUrl('local'));
+      document.getElementById('copyLanUrlBtn')?.addEventListener('click', () => copyRemoteUrl('lan'));
       document.getElementById('copyPublicUrlBtn')?.addEventListener('click', () => copyRemoteUrl('public'));
       document.getElementById('showQrBtn')?.addEventListener('click', showQRCode);
+      statusSection.querySelectorAll('.copy-tunnel-btn').forEach(btn => {
+        btn.addEventListener('click', () => {
+          navigator.clipboard.writeText(btn.dataset.url);
+          showCopyNotification('URL copied to clipboard!');
+        });
+      });
     }
 
     if (configSection) {
@@ -322,7 +504,7 @@ function updateRemoteAccessUI() {
           <ul class="clients-list">
             ${remoteStatus.clients.map(c => `
               <li>
-                <span class="client-addr">${escapeHtml(c.remoteAddr || '')}</span>
+                <span class="client-addr">${escapeHtml(c.remoteAddr || '')}${c.viewer ? ' <span class="viewer-badge">view only</span>' : ''}</span>
                 <span class="client-term">${escapeHtml(c.terminalId || 'No terminal')}</span>
               </li>
             `).join('')}
@@ -400,6 +582,11 @@ export function renderRemoteAccessPanel() {
             <input type="number" id="remotePort" value="${remoteStatus.port}" min="1024" max="65535" />
           </div>
 
+          <div class="config-row">
+            <label for="bindHost">Bind Address</label>
+            <input type="text" id="bindHost" value="${escapeHtml(remoteStatus.bindHost || '')}" placeholder="Automatic" title="Address to listen on, e.g. 127.0.0.1, ::1, :: (every interface) or 0.0.0.0 (every IPv4 interface); automatic listens on 127.0.0.1 when tunneling through ngrok and on every interface otherwise" />
+          </div>
+
           <div class="config-row" id="tokenExpiryRow">
             <label for="tokenExpiry">Token Expiry</label>
             <select id="tokenExpiry">
@@ -412,6 +599,77 @@ export function renderRemoteAccessPanel() {
           </div>
         </div>
 
+        <div class="config-section">
+          <div class="config-section-title">Security</div>
+
+          <div class="config-row">
+            <label for="tlsMode">HTTPS</label>
+            <select id="tlsMode">
+              <option value="">Off (use ngrok for secure access)</option>
+              <option value="self-signed">Self-signed certificate</option>
+              <option value="custom">Own certificate</option>
+            </select>
+          </div>
+
+          <div class="config-row">
+            <label for="maxAuthAttempts">Failed Sign-ins</label>
+            <input type="number" id="maxAuthAttempts" value="50" min="1" max="1000" title="Failed attempts before an address is locked out" />
+          </div>
+
+          <div class="config-row">
+            <label for="authLockoutMinutes">Lockout</label>
+            <select id="authLockoutMinutes">
+              <option value="1" selected>1 minute</option>
+              <option value="5">5 minutes</option>
+              <option value="15">15 minutes</option>
+              <option value="60">1 hour</option>
+              <option value="1440">1 day</option>
+            </select>
+          </div>
+
+          <div class="tls-custom-config" id="tlsCustomConfig" style="display: none;">
+            <div class="config-row">
+              <label for="tlsCertFile">Certificate</label>
+              <input type="text" id="tlsCertFile" placeholder="/path/to/cert.pem" />
+            </div>
+            <div class="config-row">
+              <label for="tlsKeyFile">Private Key</label>
+              <input type="text" id="tlsKeyFile" placeholder="/path/to/key.pem" />
+            </div>
+          </div>
+        </div>
+
+        <div class="config-section">
+          <div class="config-section-title">Session Recording</div>
+
+          <div class="checkbox-row">
+            <label class="checkbox-label">
+              <input type="checkbox" id="recordSessions" />
+              <span class="checkbox-text">Record remote sessions</span>
+              <span class="checkbox-hint">Save terminal output (asciicast) and input of every connection for later review</span>
+            </label>
+          </div>
+
+          <div class="config-row">
+            <label for="recordingDays">Keep Recordings</label>
+            <select id="recordingDays">
+              <option value="7">1 week</option>
+              <option value="30" selected>30 days</option>
+              <option value="90">90 days</option>
+              <option value="0">Forever</option>
+            </select>
+          </div>
+        </div>
+
+        <div class="config-section">
+          <div class="config-section-title">Voice Input</div>
+
+          <div class="config-row">
+            <label for="whisperModel">Whisper Model</label>
+            <input type="text" id="whisperModel" placeholder="macOS speech recognition" title="whisper.cpp model (ggml-*.bin) used to transcribe voice input from phones; empty uses macOS speech recognition" />
+          </div>
+        </div>
+
         <div class="config-section">
           <div class="config-section-title">Access Mode</div>
 
@@ -423,6 +681,14 @@ export function renderRemoteAccessPanel() {
             </label>
           </div>
 
+          <div class="checkbox-row">
+            <label class="checkbox-label">
+              <input type="checkbox" id="accessRequests" />
+              <span class="checkbox-text">Access Requests</span>
+              <span class="checkbox-hint">New devices can ask for access from the login page; you approve them here</span>
+            </label>
+          </div>
+
           <div class="checkbox-row">
             <label class="checkbox-label">
               <input type="checkbox" id="enableNgrok" checked />
@@ -447,6 +713,35 @@ export function renderRemoteAccessPanel() {
                 <span class="subdomain-suffix">.ngrok.io</span>
               </div>
             </div>
+
+            <div class="config-row">
+              <label for="ngrokRegion">Region</label>
+              <select id="ngrokRegion">
+                <option value="" selected>Closest</option>
+                <option value="us">United States</option>
+                <option value="eu">Europe</option>
+                <option value="ap">Asia/Pacific</option>
+                <option value="au">Australia</option>
+                <option value="sa">South America</option>
+                <option value="jp">Japan</option>
+                <option value="in">India</option>
+              </select>
+            </div>
+
+            <div class="config-row">
+              <label for="ngrokDomain">Reserved Domain</label>
+              <input type="text" id="ngrokDomain" placeholder="e.g., myapp.ngrok.app (replaces the subdomain)" />
+            </div>
+
+            <div class="config-row">
+              <label for="ngrokEdge">Edge</label>
+              <input type="text" id="ngrokEdge" placeholder="edghts_... (optional, uses the reserved domain's edge)" />
+            </div>
+
+            <div class="config-row">
+              <label for="extraTunnels">Also Share Ports</label>
+              <input type="text" id="extraTunnels" placeholder="e.g., 3000, 5173=dev.example.com" />
+            </div>
           </div>
         </div>
       </div>
@@ -470,11 +765,55 @@ export function renderRemoteAccessPanel() {
           </p>
           <div class="add-approved-row">
             <input type="text" id="approvedClientName" placeholder="Device name (e.g., iPhone)" />
+            <label class="approved-viewer-toggle" title="Can watch terminals but not type, create, rename or delete">
+              <input type="checkbox" id="approvedClientViewer" />
+              View only
+            </label>
             <button id="addApprovedBtn" class="small-btn">Add Device</button>
           </div>
           <div id="approvedClientsList" class="approved-list"></div>
         </div>
       </div>
+
+      <div class="audit-log-section">
+        <div class="config-section">
+          <div class="config-section-title audit-log-title">
+            <span>Activity Log</span>
+            <span class="audit-log-actions">
+              <button class="small-btn" data-export="csv">Export CSV</button>
+              <button class="small-btn" data-export="json">Export JSON</button>
+            </span>
+          </div>
+          <p class="approved-description">
+            Everything remote devices did: connections, input, terminal changes, tab switches and file transfers.
+          </p>
+          <div id="remoteAuditList" class="audit-log-list"></div>
+        </div>
+      </div>
+
+      <div class="auth-lists-section">
+        <div class="config-section">
+          <div class="config-section-title">Blocked Addresses</div>
+          <p class="approved-description">
+            Addresses with failed sign-ins are locked out for a while; banned ones are refused until unbanned.
+          </p>
+          <div class="add-approved-row">
+            <input type="text" id="banIpInput" placeholder="IP address to ban" />
+            <button id="banIpBtn" class="small-btn danger">Ban</button>
+          </div>
+          <div id="remoteAuthList" class="approved-list"></div>
+        </div>
+      </div>
+
+      <div class="recordings-section">
+        <div class="config-section">
+          <div class="config-section-title">Recorded Sessions</div>
+          <p class="approved-description">
+            Recordings play with asciinema (<code>asciinema play &lt;file&gt;.cast</code>); input.log holds what the device typed.
+          </p>
+          <div id="remoteRecordingsList" class="approved-list"></div>
+        </div>
+      </div>
     </div>
   `;
 
@@ -494,6 +833,14 @@ export function renderRemoteAccessPanel() {
     ngrokConfig.style.display = 'block';
   }
 
+  const tlsMode = document.getElementById('tlsMode');
+  const tlsCustomConfig = document.getElementById('tlsCustomConfig');
+  if (tlsMode && tlsCustomConfig) {
+    tlsMode.addEventListener('change', () => {
+      tlsCustomConfig.style.display = tlsMode.value === 'custom' ? 'block' : 'none';
+    });
+  }
+
   if (savedDevicesOnly && tokenExpiryRow) {
     savedDevicesOnly.addEventListener('change', () => {
       // Hide token expiry when using saved devices only
@@ -517,6 +864,214 @@ export function renderRemoteAccessPanel() {
   document.getElementById('approvedClientName')?.addEventListener('keypress', (e) => {
     if (e.key === 'Enter') addApprovedClientHandler();
   });
+
+  document.getElementById('banIpBtn')?.addEventListener('click', banIpHandler);
+  document.getElementById('banIpInput')?.addEventListener('keypress', (e) => {
+    if (e.key === 'Enter') banIpHandler();
+  });
+
+  document.querySelectorAll('.audit-log-actions [data-export]').forEach(btn => {
+    btn.addEventListener('click', () => exportAuditLog(btn.dataset.export));
+  });
+}
+
+// ============================================
+// Audit Log
+// ============================================
+
+const AUDIT_ACTION_LABELS = {
+  'connect': 'Connected',
+  'disconnect': 'Disconnected',
+  'input': 'Input',
+  'create-terminal': 'Created terminal',
+  'rename-terminal': 'Renamed terminal',
+  'delete-terminal': 'Deleted terminal',
+  'switch-tab': 'Switched tab',
+  'download': 'Downloaded',
+  'upload': 'Uploaded',
+  'run-tests': 'Ran tests',
+  'second-factor': 'Entered code',
+  'access-request': 'Requested access',
+  'container': 'Container'
+};
+
+// Load the latest remote actions
+async function loadAuditLog() {
+  const container = document.getElementById('remoteAuditList');
+  if (!container) return;
+
+  try {
+    const entries = await GetRemoteAuditLog(AUDIT_LOG_LIMIT) || [];
+    if (entries.length === 0) {
+      container.innerHTML = '<p class="no-approved">No remote activity yet</p>';
+      return;
+    }
+    container.innerHTML = entries.map(entry => {
+      const time = new Date(entry.time).toLocaleString();
+      const who = entry.clientName || entry.remoteAddr;
+      const label = AUDIT_ACTION_LABELS[entry.action] || entry.action;
+      return `
+        <div class="audit-log-entry">
+          <span class="audit-log-time">${escapeHtml(time)}</span>
+          <span class="audit-log-client" title="${escapeHtml(entry.remoteAddr)}">${escapeHtml(who)}</span>
+          <span class="audit-log-action">${escapeHtml(label)}</span>
+          <span class="audit-log-summary" title="${escapeHtml(entry.terminal || '')}">${escapeHtml(entry.summary || '')}</span>
+        </div>
+      `;
+    }).join('');
+  } catch (err) {
+    console.error('Failed to load remote audit log:', err);
+    container.innerHTML = `<p class="no-approved">Failed to load activity: ${escapeHtml(String(err))}</p>`;
+  }
+}
+
+// Save the whole audit log as CSV or JSON
+async function exportAuditLog(format) {
+  try {
+    await SaveRemoteAuditLogExport(format);
+  } catch (err) {
+    console.error('Failed to export remote audit log:', err);
+    alert(`Export failed: ${err}`);
+  }
+}
+
+// ============================================
+// Locked Out and Banned Addresses
+// ============================================
+
+// Load the addresses with failed sign-ins and the banned ones
+async function loadAuthLists() {
+  const container = document.getElementById('remoteAuthList');
+  if (!container) return;
+
+  try {
+    const [attempts, bans] = await Promise.all([GetRemoteAuthAttempts(), GetRemoteBannedIPs()]);
+    const banned = new Set((bans || []).map(b => b.ip));
+    const items = (bans || []).map(ban => `
+      <div class="approved-client-item">
+        <div class="approved-client-info">
+          <span class="approved-client-name">${escapeHtml(ban.ip)} <span class="viewer-badge">banned</span></span>
+          <span class="approved-client-meta">Since ${escapeHtml(new Date(ban.bannedAt).toLocaleString())}</span>
+        </div>
+        <div class="approved-client-actions">
+          <button class="small-btn" data-unban="${escapeHtml(ban.ip)}">Unban</button>
+        </div>
+      </div>
+    `).concat((attempts || []).filter(a => !banned.has(a.ip)).map(attempt => `
+      <div class="approved-client-item">
+        <div class="approved-client-info">
+          <span class="approved-client-name">${escapeHtml(attempt.ip)}${attempt.locked ? ' <span class="viewer-badge">locked</span>' : ''}</span>
+          <span class="approved-client-meta">${attempt.failures} failed sign-in${attempt.failures === 1 ? '' : 's'}${attempt.locked ? ` | Locked until ${escapeHtml(new Date(attempt.lockedUntil).toLocaleTimeString())}` : ''}</span>
+        </div>
+        <div class="approved-client-actions">
+          <button class="small-btn" data-unlock="${escapeHtml(attempt.ip)}">${attempt.locked ? 'Unlock' : 'Reset'}</button>
+          <button class="small-btn danger" data-ban="${escapeHtml(attempt.ip)}">Ban</button>
+        </div>
+      </div>
+    `));
+
+    container.innerHTML = items.length > 0 ? items.join('') : '<p class="no-approved">No failed sign-ins</p>';
+
+    container.querySelectorAll('[data-unban]').forEach(btn => {
+      btn.addEventListener('click', () => runAuthAction(UnbanRemoteIP, btn.dataset.unban));
+    });
+    container.querySelectorAll('[data-unlock]').forEach(btn => {
+      btn.addEventListener('click', () => runAuthAction(UnlockRemoteIP, btn.dataset.unlock));
+    });
+    container.querySelectorAll('[data-ban]').forEach(btn => {
+      btn.addEventListener('click', () => runAuthAction(BanRemoteIP, btn.dataset.ban));
+    });
+  } catch (err) {
+    console.error('Failed to load blocked addresses:', err);
+    container.innerHTML = `<p class="no-approved">Failed to load blocked addresses: ${escapeHtml(String(err))}</p>`;
+  }
+}
+
+// Unlock, ban or unban an address, then refresh the list
+async function runAuthAction(action, ip) {
+  try {
+    await action(ip);
+    loadAuthLists();
+  } catch (err) {
+    console.error('Failed to update blocked addresses:', err);
+    alert(`Failed: ${err}`);
+  }
+}
+
+// Ban the address typed in
+function banIpHandler() {
+  const input = document.getElementById('banIpInput');
+  const ip = input?.value.trim();
+  if (!ip) return;
+  input.value = '';
+  runAuthAction(BanRemoteIP, ip);
+}
+
+// ============================================
+// Session Recordings
+// ============================================
+
+// Format a byte count for the recordings list
+function formatBytes(bytes) {
+  if (bytes < 1024) return `${bytes} B`;
+  if (bytes < 1024 * 1024) return `${(bytes / 1024).toFixed(1)} KB`;
+  return `${(bytes / (1024 * 1024)).toFixed(1)} MB`;
+}
+
+// Load the recorded remote sessions
+async function loadRecordings() {
+  const container = document.getElementById('remoteRecordingsList');
+  if (!container) return;
+
+  try {
+    const recordings = await GetRemoteRecordings() || [];
+    if (recordings.length === 0) {
+      container.innerHTML = '<p class="no-approved">No recorded sessions</p>';
+      return;
+    }
+    container.innerHTML = recordings.map(rec => {
+      const started = new Date(rec.startedAt).toLocaleString();
+      const who = rec.clientName || rec.remoteAddr;
+      const casts = rec.casts.length > 0 ? rec.casts.join(', ') : 'no output';
+      return `
+        <div class="approved-client-item">
+          <div class="approved-client-info">
+            <span class="approved-client-name">${escapeHtml(who)}${rec.active ? ' <span class="viewer-badge">recording</span>' : ''}</span>
+            <span class="approved-client-meta" title="${escapeHtml(rec.dir)}">${escapeHtml(started)} | ${escapeHtml(casts)} | ${formatBytes(rec.size)}</span>
+          </div>
+          <div class="approved-client-actions">
+            <button class="copy-path-btn small-btn" data-dir="${escapeHtml(rec.dir)}">Copy Path</button>
+            <button class="delete-recording-btn small-btn danger" data-id="${escapeHtml(rec.id)}" ${rec.active ? 'disabled' : ''}>Delete</button>
+          </div>
+        </div>
+      `;
+    }).join('');
+
+    container.querySelectorAll('.copy-path-btn').forEach(btn => {
+      btn.addEventListener('click', () => {
+        navigator.clipboard.writeText(btn.dataset.dir);
+        showCopyNotification('Path copied to clipboard!');
+      });
+    });
+    container.querySelectorAll('.delete-recording-btn').forEach(btn => {
+      btn.addEventListener('click', () => deleteRecording(btn.dataset.id));
+    });
+  } catch (err) {
+    console.error('Failed to load remote session recordings:', err);
+    container.innerHTML = `<p class="no-approved">Failed to load recordings: ${escapeHtml(String(err))}</p>`;
+  }
+}
+
+// Delete a recorded session
+async function deleteRecording(id) {
+  if (!confirm('Delete this recorded session?')) return;
+  try {
+    await DeleteRemoteRecording(id);
+    loadRecordings();
+  } catch (err) {
+    console.error('Failed to delete remote session recording:', err);
+    alert(`Failed to delete recording: ${err}`);
+  }
 }
 
 // ============================================
@@ -547,14 +1102,18 @@ function renderApprovedClientsList() {
   container.innerHTML = approvedClients.map(client => {
     const createdDate = new Date(client.createdAt).toLocaleDateString();
     const lastUsedDate = client.lastUsed ? new Date(client.lastUsed).toLocaleDateString() : 'Never';
+    const projectCount = (client.projects || []).length;
+    const scopeText = projectCount > 0 ? `${projectCount} project${projectCount === 1 ? '' : 's'}` : 'All projects';
 
     return `
       <div class="approved-client-item" data-token="${escapeHtml(client.token)}">
         <div class="approved-client-info">
-          <span class="approved-client-name">${escapeHtml(client.name)}</span>
-          <span class="approved-client-meta">Added: ${createdDate} | Last used: ${lastUsedDate}</span>
+          <span class="approved-client-name">${escapeHtml(client.name)}${client.viewer ? ' <span class="viewer-badge">view only</span>' : ''}${client.totpSecret ? ' <span class="viewer-badge">2FA</span>' : ''}</span>
+          <span class="approved-client-meta">Added: ${createdDate} | Last used: ${lastUsedDate} | ${scopeText}</span>
         </div>
         <div class="approved-client-actions">
+          <button class="scope-btn small-btn" data-token="${escapeHtml(client.token)}">Scope</button>
+          <button class="totp-btn small-btn" data-token="${escapeHtml(client.token)}">${client.totpSecret ? 'Disable 2FA' : '2FA'}</button>
           <button class="copy-token-btn small-btn" data-token="${escapeHtml(client.token)}">Copy URL</button>
           <button class="remove-approved-btn small-btn danger" data-token="${escapeHtml(client.token)}">Remove</button>
         </div>
@@ -570,12 +1129,144 @@ function renderApprovedClientsList() {
   container.querySelectorAll('.remove-approved-btn').forEach(btn => {
     btn.addEventListener('click', () => removeApprovedClientHandler(btn.dataset.token));
   });
+
+  container.querySelectorAll('.scope-btn').forEach(btn => {
+    btn.addEventListener('click', () => toggleScopeEditor(btn.closest('.approved-client-item'), btn.dataset.token));
+  });
+
+  container.querySelectorAll('.totp-btn').forEach(btn => {
+    btn.addEventListener('click', () => toggleTOTPEditor(btn.closest('.approved-client-item'), btn.dataset.token));
+  });
+}
+
+// Enroll an approved client in the TOTP second factor: scan the QR code with
+// an authenticator app and confirm with a code. Enrolled clients are asked
+// for a code in every browser they haven't confirmed yet.
+async function toggleTOTPEditor(item, token) {
+  const client = approvedClients.find(c => c.token === token);
+  if (!client) return;
+
+  if (client.totpSecret) {
+    if (!confirm('Turn off the second factor for this device?')) return;
+    try {
+      await DisableApprovedClientTOTP(token);
+      client.totpSecret = '';
+      client.totpDevices = [];
+      renderApprovedClientsList();
+      showCopyNotification('Second factor turned off');
+    } catch (err) {
+      console.error('Failed to turn off second factor:', err);
+      alert('Failed to turn off second factor: ' + err);
+    }
+    return;
+  }
+
+  const existing = item.nextElementSibling;
+  if (existing && existing.classList.contains('approved-totp-editor')) {
+    existing.remove();
+    return;
+  }
+
+  let enrollment;
+  try {
+    enrollment = await BeginApprovedClientTOTP(token);
+  } catch (err) {
+    console.error('Failed to start second factor enrollment:', err);
+    alert('Failed to set up second factor: ' + err);
+    return;
+  }
+  const qrDataUrl = await generateQRCode(enrollment.uri, 180);
+
+  const editor = document.createElement('div');
+  editor.className = 'approved-totp-editor';
+  editor.innerHTML = `
+    <span class="scope-title">Second factor</span>
+    <span class="scope-hint">Scan with an authenticator app, or enter the key by hand, then type the code it shows</span>
+    ${qrDataUrl ? `<img class="totp-qr" src="${qrDataUrl}" alt="TOTP QR code" />` : ''}
+    <code class="totp-secret">${escapeHtml(enrollment.secret)}</code>
+    <div class="scope-actions">
+      <input type="text" class="totp-code" inputmode="numeric" maxlength="6" placeholder="123456" />
+      <button class="small-btn totp-confirm-btn">Confirm</button>
+    </div>
+  `;
+  item.after(editor);
+
+  const codeInput = editor.querySelector('.totp-code');
+  codeInput.focus();
+  editor.querySelector('.totp-confirm-btn').addEventListener('click', async () => {
+    try {
+      await ConfirmApprovedClientTOTP(token, codeInput.value.trim());
+      await loadApprovedClients();
+      showCopyNotification('Second factor turned on');
+    } catch (err) {
+      console.error('Failed to confirm second factor:', err);
+      alert('Failed to confirm second factor: ' + err);
+    }
+  });
+}
+
+// Show or hide the scope editor of an approved client: which projects and
+// capabilities it may use. Nothing checked means no limit.
+function toggleScopeEditor(item, token) {
+  const existing = item.nextElementSibling;
+  if (existing && existing.classList.contains('approved-scope-editor')) {
+    existing.remove();
+    return;
+  }
+  const client = approvedClients.find(c => c.token === token);
+  if (!client) return;
+
+  const projects = new Set(client.projects || []);
+  const capabilities = new Set(client.capabilities || []);
+  const editor = document.createElement('div');
+  editor.className = 'approved-scope-editor';
+  editor.innerHTML = `
+    <div class="scope-group">
+      <span class="scope-title">Projects <span class="scope-hint">(none checked = all)</span></span>
+      ${(state.projects || []).map(p => `
+        <label class="scope-option">
+          <input type="checkbox" data-project="${escapeHtml(p.id)}" ${projects.has(p.id) ? 'checked' : ''} />
+          ${escapeHtml(p.name)}
+        </label>
+      `).join('')}
+    </div>
+    <div class="scope-group">
+      <span class="scope-title">Capabilities <span class="scope-hint">(none checked = all)</span></span>
+      ${CAPABILITIES.map(c => `
+        <label class="scope-option">
+          <input type="checkbox" data-capability="${c.id}" ${capabilities.has(c.id) ? 'checked' : ''} />
+          ${c.label}
+        </label>
+      `).join('')}
+    </div>
+    <div class="scope-actions">
+      <span class="scope-hint">Connected devices get the new scope when they reconnect</span>
+      <button class="small-btn scope-save-btn">Save</button>
+    </div>
+  `;
+  item.after(editor);
+
+  editor.querySelector('.scope-save-btn').addEventListener('click', async () => {
+    const selectedProjects = [...editor.querySelectorAll('[data-project]:checked')].map(el => el.dataset.project);
+    const selectedCapabilities = [...editor.querySelectorAll('[data-capability]:checked')].map(el => el.dataset.capability);
+    try {
+      await SetApprovedClientScope(token, selectedProjects, selectedCapabilities);
+      client.projects = selectedProjects;
+      client.capabilities = selectedCapabilities;
+      renderApprovedClientsList();
+      showCopyNotification('Device scope saved');
+    } catch (err) {
+      console.error('Failed to save device scope:', err);
+      alert('Failed to save device scope: ' + err);
+    }
+  });
 }
 
 // Add a new approved client
 async function addApprovedClientHandler() {
   const nameInput = document.getElementById('approvedClientName');
   const name = nameInput?.value.trim();
+  const viewerInput = document.getElementById('approvedClientViewer');
 
   if (!name) {
     alert('Please enter a device name');
@@ -583,11 +1274,12 @@ async function addApprovedClientHandler() {
   }
 
   try {
-    const client = await AddApprovedClient(name);
+    const client = await AddApprovedClient(name, viewerInput?.checked || false);
     if (client) {
       approvedClients.push(client);
       renderApprovedClientsList();
       nameInput.value = '';
+      if (viewerInput) viewerInput.checked = false;
 
       // Show the URL for this new client
       const baseUrl = remoteStatus.publicUrl || remoteStatus.localUrl || `http://localhost:${remoteStatus.port}`;
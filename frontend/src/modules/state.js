@@ -22,6 +22,7 @@ export const state = {
   activeTab: 'terminal',
   dockerAvailable: false,
   containers: [],
+  servicesHealth: new Map(), // projectId -> aggregated compose service health
   colors: [],
   icons: [],
   browser: {
@@ -52,6 +53,8 @@ export const state = {
     currentDiffFile: null
   },
   claudeStatus: new Map(), // terminalId -> status
+  claudeSessions: new Map(), // terminalId -> { model, mode }
+  claudeContext: new Map(), // terminalId -> { percentLeft, usedTokens, maxTokens, compactions, low }
   testStatus: new Map(), // terminalId -> { runner, status, passed, failed, skipped, total, duration, coveragePercent, failedTests }
   terminalFontSize: 14, // Terminal font size
   terminalTheme: 'dracula', // Terminal color theme
@@ -6,7 +6,8 @@ import hljs from 'highlight.js';
 
 import { state } from './state.js';
 import { registerStateHandler } from './project-switcher.js';
-import { GetProjectStructure, ReadFileContent, SaveFileContent } from '../../wailsjs/go/main/App';
+import { GetProjectStructure, GetNodeChildren, UnwatchProjectStructure, GetDependencyCycles, GetOrphanFiles, GetIgnorePatterns, SetIgnorePatterns, ReadFileContent, SaveFileContent, GetProjectCoverage, GetFileCoverage } from '../../wailsjs/go/main/App';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 
 // Import highlight.js theme
 import 'highlight.js/styles/github-dark.css';
@@ -23,6 +24,9 @@ export const STRUCTURE_TAB_ID = 'tab-structure';
 
 let panelRoot = null;
 let currentStructure = null;
+let currentCoverage = null; // Latest CoverageSummary of the active project
+let currentCycles = []; // Import cycles of the active project
+let currentOrphans = new Set(); // Paths of files nothing imports
 
 // ============================================
 // File Icons & Language Detection
@@ -39,6 +43,10 @@ const FILE_ICONS = {
   '.cts': '💠',
   '.vue': '💚',
   '.svelte': '🔥',
+  '.go': '🐹',
+  '.py': '🐍',
+  '.rs': '🦀',
+  '.java': '☕',
 };
 
 const EXT_TO_LANGUAGE = {
@@ -52,6 +60,10 @@ const EXT_TO_LANGUAGE = {
   '.cts': 'typescript',
   '.vue': 'xml',
   '.svelte': 'xml',
+  '.go': 'go',
+  '.py': 'python',
+  '.rs': 'rust',
+  '.java': 'java',
   '.json': 'json',
   '.css': 'css',
   '.scss': 'scss',
@@ -64,6 +76,66 @@ function getFileIcon(filename) {
   return FILE_ICONS[ext] || '📄';
 }
 
+// Line coverage of a file from the project's latest coverage report
+function getFileCoveragePct(path) {
+  const root = state.activeProject?.path;
+  if (!currentCoverage?.byFile || !root || !path.startsWith(root + '/')) return undefined;
+  return currentCoverage.byFile[path.slice(root.length + 1)]?.lines?.pct;
+}
+
+// Git badge of a node: staged beats modified beats untracked
+function gitBadge(git) {
+  if (!git) return null;
+  if (git.staged) return { letter: 'S', className: 'git-staged', title: 'Staged changes' };
+  if (git.modified) return { letter: 'M', className: 'git-modified', title: 'Modified' };
+  if (git.untracked) return { letter: 'U', className: 'git-untracked', title: 'Untracked' };
+  return null;
+}
+
+function coverageClass(pct) {
+  if (pct >= 80) return 'coverage-high';
+  if (pct >= 50) return 'coverage-medium';
+  return 'coverage-low';
+}
+
+// Per-language file counts of a directory, e.g. "go 12, python 3"
+function languageSummary(languages) {
+  return Object.entries(languages || {})
+    .sort((a, b) => b[1] - a[1])
+    .map(([language, count]) => `${language} ${count}`)
+    .join(', ');
+}
+
+// Heatmap metrics offered in the tree
+const HEATMAP_METRICS = {
+  lines: 'Lines of code',
+  complexity: 'Complexity',
+  size: 'Size',
+};
+
+function formatMetric(metric, value) {
+  if (metric === 'size') {
+    if (value >= 1024 * 1024) return `${(value / (1024 * 1024)).toFixed(1)}M`;
+    if (value >= 1024) return `${Math.round(value / 1024)}K`;
+    return `${value}B`;
+  }
+  return value >= 1000 ? `${(value / 1000).toFixed(1)}k` : `${value}`;
+}
+
+// Largest value of a metric over the files of a tree
+function maxFileMetric(node, metric) {
+  if (!node.isDir) return node.metrics?.[metric] || 0;
+  return Math.max(0, ...(node.children || []).map(child => maxFileMetric(child, metric)));
+}
+
+// Heat of a node: files against the project's largest file, directories by
+// their share of the project total
+function heatRatio(node, heat) {
+  const value = node.metrics?.[heat.metric] || 0;
+  const max = node.isDir ? heat.total : heat.maxFile;
+  return max > 0 ? value / max : 0;
+}
+
 function getLanguage(filename) {
   const ext = filename.substring(filename.lastIndexOf('.')).toLowerCase();
   return EXT_TO_LANGUAGE[ext] || 'plaintext';
@@ -73,14 +145,21 @@ function getLanguage(filename) {
 // Custom File Tree Component
 // ============================================
 
-function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect }) {
+function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect, heat }) {
   const isExpanded = expanded.has(node.path);
   const isSelected = selected === node.path;
-  const hasChildren = node.children && node.children.length > 0;
+  const hasChildren = node.hasMore || (node.children && node.children.length > 0);
+  const coveragePct = node.isDir ? undefined : getFileCoveragePct(node.path);
+  const heatValue = heat ? node.metrics?.[heat.metric] || 0 : 0;
+  const isOrphan = !node.isDir && currentOrphans.has(node.path);
+  const git = gitBadge(node.git);
 
-  const handleClick = (e) => {
+  const handleClick = async (e) => {
     e.stopPropagation();
     onSelect(node.path, node.isDir);
+    if (node.isDir && node.hasMore) {
+      await loadNodeChildren(node.path);
+    }
     if (node.isDir && hasChildren) {
       onToggle(node.path);
     }
@@ -90,7 +169,10 @@ function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect }) {
     <div className="tree-node">
       <div
         className={`tree-node-item ${isSelected ? 'selected' : ''}`}
-        style={{ paddingLeft: `${depth * 16 + 8}px` }}
+        style={{
+          paddingLeft: `${depth * 16 + 8}px`,
+          ...(heat && { background: `rgba(239, 68, 68, ${(0.05 + heatRatio(node, heat) * 0.4).toFixed(2)})` }),
+        }}
         onClick={handleClick}
       >
         {node.isDir ? (
@@ -103,12 +185,32 @@ function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect }) {
         <span className="tree-icon">
           {node.isDir ? (isExpanded ? '📂' : '📁') : getFileIcon(node.name)}
         </span>
-        <span className="tree-name">{node.name}</span>
+        <span className={`tree-name ${isOrphan ? 'orphan' : ''} ${git ? git.className : ''}`}>{node.name}</span>
+        {git && (
+          <span className={`tree-git ${git.className}`} title={node.isDir ? `${git.title} below` : git.title}>
+            {node.isDir ? '●' : git.letter}
+          </span>
+        )}
+        {isOrphan && (
+          <span className="tree-orphan" title="Nothing imports this file and it isn't an entry point; a candidate for deletion">
+            unused
+          </span>
+        )}
         {node.isDir && node.fileCount > 0 && (
-          <span className="tree-count">{node.fileCount}</span>
+          <span className="tree-count" title={languageSummary(node.languages)}>{node.fileCount}</span>
+        )}
+        {heat && (
+          <span className="tree-metric" title={`${HEATMAP_METRICS[heat.metric]}: ${heatValue}`}>
+            {formatMetric(heat.metric, heatValue)}
+          </span>
+        )}
+        {coveragePct !== undefined && (
+          <span className={`tree-coverage ${coverageClass(coveragePct)}`} title={`${coveragePct}% of lines covered`}>
+            {Math.round(coveragePct)}%
+          </span>
         )}
       </div>
-      {node.isDir && isExpanded && hasChildren && (
+      {node.isDir && isExpanded && node.children?.length > 0 && (
         <div className="tree-children">
           {node.children.map(child => (
             <TreeNode
@@ -119,6 +221,7 @@ function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect }) {
               onToggle={onToggle}
               selected={selected}
               onSelect={onSelect}
+              heat={heat}
             />
           ))}
         </div>
@@ -127,9 +230,18 @@ function TreeNode({ node, depth = 0, expanded, onToggle, selected, onSelect }) {
   );
 }
 
-function FileTreeComponent({ structure, selectedPath, onSelect }) {
+function FileTreeComponent({ structure, selectedPath, onSelect, heatmap }) {
   const [expanded, setExpanded] = useState(new Set());
 
+  const heat = useMemo(() => {
+    if (!structure || !heatmap) return null;
+    return {
+      metric: heatmap,
+      maxFile: maxFileMetric(structure, heatmap),
+      total: structure.metrics?.[heatmap] || 0,
+    };
+  }, [structure, heatmap]);
+
   const handleToggle = useCallback((path) => {
     setExpanded(prev => {
       const next = new Set(prev);
@@ -162,6 +274,7 @@ function FileTreeComponent({ structure, selectedPath, onSelect }) {
         onToggle={handleToggle}
         selected={selectedPath}
         onSelect={onSelect}
+        heat={heat}
       />
     </div>
   );
@@ -171,7 +284,7 @@ function FileTreeComponent({ structure, selectedPath, onSelect }) {
 // Code Editor Component
 // ============================================
 
-function CodeEditor({ filePath, content, originalContent, loading, error, onChange, onSave, onDiscard, saving }) {
+function CodeEditor({ filePath, content, originalContent, loading, error, onChange, onSave, onDiscard, saving, coverage }) {
   const textareaRef = useRef(null);
   const highlightRef = useRef(null);
   const [scrollTop, setScrollTop] = useState(0);
@@ -201,6 +314,22 @@ function CodeEditor({ filePath, content, originalContent, loading, error, onChan
     return content.split('\n');
   }, [content]);
 
+  const uncoveredLines = useMemo(() => {
+    const set = new Set();
+    for (const range of coverage?.uncoveredLines || []) {
+      for (let line = range.start; line <= range.end; line++) set.add(line);
+    }
+    return set;
+  }, [coverage]);
+
+  const coverageTitle = useMemo(() => {
+    if (!coverage) return '';
+    const functions = (coverage.functions || [])
+      .map(fn => `${fn.name}: ${fn.lines.pct}%`)
+      .join('\n');
+    return `${coverage.metrics.lines.pct}% of lines covered` + (functions ? `\n\n${functions}` : '');
+  }, [coverage]);
+
   const handleScroll = (e) => {
     setScrollTop(e.target.scrollTop);
     if (highlightRef.current) {
@@ -275,11 +404,16 @@ function CodeEditor({ filePath, content, originalContent, loading, error, onChan
         </span>
         <span className="file-path">{filePath}</span>
         <span className="line-count">{lines.length} lines</span>
+        {coverage && (
+          <span className={`file-coverage ${coverageClass(coverage.metrics.lines.pct)}`} title={coverageTitle}>
+            {coverage.metrics.lines.pct}% covered
+          </span>
+        )}
       </div>
       <div className="code-editor-content">
         <div className="line-numbers">
           {lines.map((_, i) => (
-            <div key={i} className="line-number">{i + 1}</div>
+            <div key={i} className={`line-number${uncoveredLines.has(i + 1) ? ' uncovered' : ''}`}>{i + 1}</div>
           ))}
         </div>
         <div className="editor-wrapper">
@@ -310,13 +444,15 @@ function CodeEditor({ filePath, content, originalContent, loading, error, onChan
 // Main Structure Panel Component
 // ============================================
 
-function StructurePanelComponent({ structure }) {
+function StructurePanelComponent({ structure, cycles }) {
   const [selectedPath, setSelectedPath] = useState(null);
   const [originalContent, setOriginalContent] = useState(null);
   const [editedContent, setEditedContent] = useState(null);
   const [loading, setLoading] = useState(false);
   const [saving, setSaving] = useState(false);
   const [error, setError] = useState(null);
+  const [fileCoverage, setFileCoverage] = useState(null);
+  const [heatmap, setHeatmap] = useState('');
 
   const hasChanges = editedContent !== null && originalContent !== null && editedContent !== originalContent;
 
@@ -340,11 +476,15 @@ function StructurePanelComponent({ structure }) {
     setError(null);
     setOriginalContent(null);
     setEditedContent(null);
+    setFileCoverage(null);
 
     try {
       const content = await ReadFileContent(path);
       setOriginalContent(content);
       setEditedContent(content);
+      if (state.activeProject) {
+        setFileCoverage(await GetFileCoverage(state.activeProject.path, path));
+      }
     } catch (err) {
       setError(`Failed to load file: ${err.message || err}`);
     } finally {
@@ -379,7 +519,7 @@ function StructurePanelComponent({ structure }) {
   if (!structure) {
     return (
       <div className="structure-empty">
-        <p>No project selected or no JS/TS files found.</p>
+        <p>No project selected or no source files found.</p>
       </div>
     );
   }
@@ -393,6 +533,24 @@ function StructurePanelComponent({ structure }) {
             <span>Files</span>
           </div>
           <div className="sidebar-actions">
+            <button
+              className="action-btn ignore-btn"
+              onClick={editIgnorePatterns}
+              title="Ignore patterns (on top of .gitignore)"
+            >
+              🚫
+            </button>
+            <select
+              className="heatmap-select"
+              value={heatmap}
+              onChange={(e) => setHeatmap(e.target.value)}
+              title="Color the tree by a code metric"
+            >
+              <option value="">No heatmap</option>
+              {Object.entries(HEATMAP_METRICS).map(([metric, label]) => (
+                <option key={metric} value={metric}>{label}</option>
+              ))}
+            </select>
             <button
               className={`action-btn save-btn ${hasChanges ? 'enabled' : 'disabled'}`}
               onClick={handleSave}
@@ -411,10 +569,25 @@ function StructurePanelComponent({ structure }) {
             </button>
           </div>
         </div>
+        {cycles.length > 0 && (
+          <div
+            className="structure-cycles"
+            title={cycles.map(cycle => cycle.path.join(' → ')).join('\n')}
+          >
+            ⚠️ {cycles.length} import cycle{cycles.length === 1 ? '' : 's'}
+            {' '}({cycles.reduce((sum, cycle) => sum + cycle.files.length, 0)} files)
+          </div>
+        )}
+        {currentOrphans.size > 0 && (
+          <div className="structure-orphans" title={[...currentOrphans].map(path => relativeToProject(path)).join('\n')}>
+            🗑️ {currentOrphans.size} unused file{currentOrphans.size === 1 ? '' : 's'}
+          </div>
+        )}
         <FileTreeComponent
           structure={structure}
           selectedPath={selectedPath}
           onSelect={handleSelect}
+          heatmap={heatmap}
         />
       </div>
       <div className="structure-resizer" id="structureResizer"></div>
@@ -429,6 +602,7 @@ function StructurePanelComponent({ structure }) {
           onSave={handleSave}
           onDiscard={handleDiscard}
           saving={saving}
+          coverage={fileCoverage}
         />
       </div>
     </div>
@@ -441,6 +615,129 @@ function StructurePanelComponent({ structure }) {
 
 export function initStructurePanel() {
   addStructurePanelStyles();
+
+  // Recolor the tree when a new coverage report is read
+  EventsOn('coverage-update', (data) => {
+    if (data?.projectPath !== state.activeProject?.path) return;
+    currentCoverage = data.summary;
+    if (isStructureTabActive()) {
+      renderStructurePanel();
+    }
+  });
+
+  // Apply file additions and removals to the loaded tree
+  EventsOn('structure-changed', (delta) => {
+    if (!currentStructure || delta?.projectPath !== state.activeProject?.path) return;
+    currentStructure = applyStructureDelta(currentStructure, delta);
+    if (isStructureTabActive()) {
+      renderStructurePanel();
+    }
+    loadStructureAnalysis();
+  });
+}
+
+// ============================================
+// Incremental Tree Updates
+// ============================================
+
+// Load the children of a directory cut from a lazily delivered tree,
+// copying only the nodes on its path
+async function loadNodeChildren(path) {
+  try {
+    const children = await GetNodeChildren(path);
+    const replace = (node) => {
+      if (node.path === path) {
+        return { ...node, children: children || [], hasMore: false };
+      }
+      if (!node.isDir || !node.children || !path.startsWith(node.path + '/')) return node;
+      return { ...node, children: node.children.map(replace) };
+    };
+    if (currentStructure) {
+      currentStructure = replace(currentStructure);
+      renderStructurePanel();
+    }
+  } catch (err) {
+    console.error('[Structure] Failed to load folder contents:', err);
+  }
+}
+
+// Returns a copy of the tree with a structure-changed delta applied
+function applyStructureDelta(structure, delta) {
+  const root = structuredClone(structure);
+  for (const path of delta.removed || []) {
+    removeTreeNode(root, path);
+  }
+  for (const file of [...(delta.added || []), ...(delta.changed || [])]) {
+    addTreeFile(root, file);
+  }
+  recountTree(root);
+  return root;
+}
+
+function removeTreeNode(node, path) {
+  const index = node.children.findIndex(child => child.path === path);
+  if (index >= 0) {
+    node.children.splice(index, 1);
+    return true;
+  }
+  return node.children.some(child => child.isDir && child.children && path.startsWith(child.path + '/') && removeTreeNode(child, path));
+}
+
+function addTreeFile(root, file) {
+  if (!file.path.startsWith(root.path + '/')) return;
+  const dirs = file.path.slice(root.path.length + 1).split('/').slice(0, -1);
+  let node = root;
+  for (const name of dirs) {
+    let child = node.children.find(c => c.isDir && c.name === name);
+    // Folders not loaded yet pick the file up when they are
+    if (child?.hasMore) return;
+    if (!child) {
+      child = { name, path: `${node.path}/${name}`, isDir: true, children: [] };
+      node.children.push(child);
+    }
+    node = child;
+  }
+  const index = node.children.findIndex(c => c.path === file.path);
+  if (index >= 0) {
+    node.children[index] = file;
+  } else {
+    node.children.push(file);
+  }
+}
+
+// Re-sorts children (directories first) and recomputes file and language
+// counts and metrics, dropping directories left without source files
+function recountTree(node) {
+  node.fileCount = 0;
+  node.languages = {};
+  node.metrics = { lines: 0, size: 0, complexity: 0 };
+  const addMetrics = (metrics) => {
+    for (const key of Object.keys(node.metrics)) {
+      node.metrics[key] += metrics?.[key] || 0;
+    }
+  };
+  node.children = node.children.filter(child => {
+    if (!child.isDir) {
+      node.fileCount++;
+      node.languages[child.language] = (node.languages[child.language] || 0) + 1;
+      addMetrics(child.metrics);
+      return true;
+    }
+    if (!child.hasMore) {
+      recountTree(child);
+    }
+    if (child.fileCount === 0) return false;
+    node.fileCount += child.fileCount;
+    for (const [language, count] of Object.entries(child.languages)) {
+      node.languages[language] = (node.languages[language] || 0) + count;
+    }
+    addMetrics(child.metrics);
+    return true;
+  });
+  node.children.sort((a, b) => {
+    if (a.isDir !== b.isDir) return a.isDir ? -1 : 1;
+    return a.name.toLowerCase() < b.name.toLowerCase() ? -1 : 1;
+  });
 }
 
 function renderStructurePanel() {
@@ -453,7 +750,8 @@ function renderStructurePanel() {
 
   panelRoot.render(
     React.createElement(StructurePanelComponent, {
-      structure: currentStructure
+      structure: currentStructure,
+      cycles: currentCycles
     })
   );
 }
@@ -477,6 +775,10 @@ export function showStructurePanel(show) {
     if (qaPanel) qaPanel.style.display = 'none';
     if (gitPanel) gitPanel.style.display = 'none';
     renderStructurePanel();
+    // Refresh git flags; the tree itself is cached by the backend
+    if (currentStructure) {
+      loadProjectStructure();
+    }
   }
 }
 
@@ -495,6 +797,9 @@ export function isStructureTabActive() {
 export async function loadProjectStructure() {
   if (!state.activeProject) {
     currentStructure = null;
+    currentCoverage = null;
+    currentCycles = [];
+    currentOrphans = new Set();
     renderStructurePanel();
     return;
   }
@@ -502,6 +807,8 @@ export async function loadProjectStructure() {
   try {
     const structure = await GetProjectStructure(state.activeProject.path);
     currentStructure = structure;
+    currentCoverage = await GetProjectCoverage(state.activeProject.path);
+    await loadStructureAnalysis();
 
     if (isStructureTabActive()) {
       renderStructurePanel();
@@ -512,6 +819,49 @@ export async function loadProjectStructure() {
   }
 }
 
+// Edit the active project's custom ignore patterns and rescan its tree
+async function editIgnorePatterns() {
+  const project = state.activeProject;
+  if (!project) return;
+  try {
+    const current = await GetIgnorePatterns(project.id) || [];
+    const input = prompt('Ignore patterns, comma separated (.gitignore syntax, e.g. generated/, *.min.js):', current.join(', '));
+    if (input === null) return;
+    await SetIgnorePatterns(project.id, input.split(','));
+    await loadProjectStructure();
+    if (isStructureTabActive()) {
+      renderStructurePanel();
+    }
+  } catch (err) {
+    console.error('[Structure] Failed to save ignore patterns:', err);
+  }
+}
+
+// Import cycles and unused files of the active project
+async function loadStructureAnalysis() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+  try {
+    const [cycles, orphans] = await Promise.all([
+      GetDependencyCycles(projectPath),
+      GetOrphanFiles(projectPath),
+    ]);
+    if (projectPath !== state.activeProject?.path) return;
+    currentCycles = cycles || [];
+    currentOrphans = new Set((orphans || []).map(file => `${projectPath}/${file}`));
+    if (isStructureTabActive()) {
+      renderStructurePanel();
+    }
+  } catch (err) {
+    console.error('[Structure] Failed to analyze imports:', err);
+  }
+}
+
+function relativeToProject(path) {
+  const root = state.activeProject?.path;
+  return root && path.startsWith(root + '/') ? path.slice(root.length + 1) : path;
+}
+
 // ============================================
 // Project Switcher Handler
 // ============================================
@@ -521,7 +871,13 @@ export function initStructureHandler() {
     priority: 85,
 
     onBeforeSwitch: async (ctx) => {
+      if (ctx.previousProject?.path) {
+        UnwatchProjectStructure(ctx.previousProject.path);
+      }
       currentStructure = null;
+      currentCoverage = null;
+      currentCycles = [];
+      currentOrphans = new Set();
     },
 
     onSave: async (ctx) => {},
@@ -700,6 +1056,101 @@ function addStructurePanelStyles() {
       border-radius: 10px;
     }
 
+    .tree-metric {
+      font-size: 10px;
+      color: #fca5a5;
+      margin-left: auto;
+    }
+
+    .tree-metric + .tree-coverage {
+      margin-left: 6px;
+    }
+
+    .structure-cycles {
+      padding: 6px 12px;
+      font-size: 11px;
+      color: #fbbf24;
+      background: #f59e0b15;
+      border-bottom: 1px solid #334155;
+      cursor: help;
+    }
+
+    .ignore-btn {
+      background: #334155;
+      color: #cbd5e1;
+    }
+
+    .structure-orphans {
+      padding: 6px 12px;
+      font-size: 11px;
+      color: #94a3b8;
+      border-bottom: 1px solid #334155;
+      cursor: help;
+    }
+
+    .tree-name.git-modified { color: #fbbf24; }
+    .tree-name.git-untracked { color: #4ade80; }
+    .tree-name.git-staged { color: #60a5fa; }
+
+    .tree-git {
+      font-size: 9px;
+      font-weight: 600;
+    }
+
+    .tree-git.git-modified { color: #fbbf24; }
+    .tree-git.git-untracked { color: #4ade80; }
+    .tree-git.git-staged { color: #60a5fa; }
+
+    .tree-name.orphan {
+      color: #64748b;
+      text-decoration: line-through;
+    }
+
+    .tree-orphan {
+      font-size: 9px;
+      color: #94a3b8;
+      border: 1px solid #475569;
+      padding: 0 4px;
+      border-radius: 8px;
+    }
+
+    .heatmap-select {
+      padding: 3px 4px;
+      font-size: 11px;
+      background: #0f172a;
+      color: #cbd5e1;
+      border: 1px solid #334155;
+      border-radius: 4px;
+    }
+
+    .tree-coverage,
+    .file-coverage {
+      font-size: 10px;
+      padding: 1px 6px;
+      border-radius: 10px;
+      margin-left: auto;
+    }
+
+    .coverage-high {
+      color: #22c55e;
+      background: rgba(34, 197, 94, 0.15);
+    }
+
+    .coverage-medium {
+      color: #eab308;
+      background: rgba(234, 179, 8, 0.15);
+    }
+
+    .coverage-low {
+      color: #ef4444;
+      background: rgba(239, 68, 68, 0.15);
+    }
+
+    .line-numbers .line-number.uncovered {
+      color: #ef4444;
+      background: rgba(239, 68, 68, 0.12);
+    }
+
     .tree-empty {
       padding: 20px;
       color: #64748b;
@@ -1,5 +1,5 @@
 // Teams Dashboard - Monitor Claude Code Agent Teams
-import { GetAllTeams, GetTeamHistory, StartTeamsPolling, StopTeamsPolling } from '../../wailsjs/go/main/App';
+import { GetAllTeams, GetTeamHistory, GetSubagentSessions, StartTeamsPolling, StopTeamsPolling } from '../../wailsjs/go/main/App';
 import { EventsOn } from '../../wailsjs/runtime/runtime';
 
 export const TEAMS_TAB_ID = 'teams';
@@ -7,6 +7,7 @@ export const TEAMS_TAB_ID = 'teams';
 let teamsState = {
   teams: {},
   history: [],
+  subagentSessions: [],
   selectedTeam: null,
   showHistory: false,
 };
@@ -25,6 +26,10 @@ export function initTeamsDashboard() {
       renderTeamsDashboard();
     }
   });
+  EventsOn('subagents-update', (data) => {
+    teamsState.subagentSessions = data || [];
+    renderTeamsDashboard();
+  });
 }
 
 // Called when Teams tab becomes active
@@ -46,12 +51,14 @@ export function stopTeamsTab() {
 
 async function loadTeams() {
   try {
-    const [teams, history] = await Promise.all([
+    const [teams, history, subagentSessions] = await Promise.all([
       GetAllTeams(),
       GetTeamHistory(),
+      GetSubagentSessions(),
     ]);
     teamsState.teams = teams || {};
     teamsState.history = history || [];
+    teamsState.subagentSessions = subagentSessions || [];
     renderTeamsDashboard();
   } catch (e) {
     console.error('Failed to load teams:', e);
@@ -117,6 +124,18 @@ function statusLabel(status) {
   return 'Pending';
 }
 
+function subagentStatusColor(status) {
+  if (status === 'completed') return '#22c55e';
+  if (status === 'failed') return '#ef4444';
+  return '#3b82f6';
+}
+
+function subagentStatusLabel(status) {
+  if (status === 'completed') return 'Done';
+  if (status === 'failed') return 'Failed';
+  return 'Running';
+}
+
 function messageTypeIcon(type) {
   if (type === 'task_assignment') return '📋';
   if (type === 'shutdown_request') return '🛑';
@@ -144,6 +163,8 @@ export function renderTeamsDashboard() {
 function renderTeamList(container) {
   const teamNames = Object.keys(teamsState.teams);
   const hasHistory = teamsState.history && teamsState.history.length > 0;
+  const subagentSessions = teamsState.subagentSessions || [];
+  const runningSubagents = subagentSessions.reduce((n, s) => n + (s.subagents || []).filter(a => a.status === 'running').length, 0);
 
   container.innerHTML = `
     <style>${getStyles()}</style>
@@ -156,11 +177,11 @@ function renderTeamList(container) {
       </div>
     </div>
     <div class="teams-list">
-      ${teamNames.length === 0 ? `
+      ${teamNames.length === 0 && subagentSessions.length === 0 ? `
         <div class="teams-empty">
           <div class="teams-empty-icon">👥</div>
           <div class="teams-empty-text">No active teams</div>
-          <div class="teams-empty-hint">Teams appear here when spawned via Claude Code</div>
+          <div class="teams-empty-hint">Teams and subagents appear here when spawned via Claude Code</div>
         </div>
       ` : teamNames.map(name => {
         const team = teamsState.teams[name];
@@ -192,6 +213,41 @@ function renderTeamList(container) {
         `;
       }).join('')}
     </div>
+    ${subagentSessions.length > 0 ? `
+      <div class="teams-section">
+        <div class="teams-section-title">
+          Session Subagents
+          ${runningSubagents > 0 ? `<span class="teams-badge">${runningSubagents} running</span>` : ''}
+        </div>
+        <div class="teams-list">
+          ${subagentSessions.map(renderSubagentSession).join('')}
+        </div>
+      </div>
+    ` : ''}
+  `;
+}
+
+function renderSubagentSession(session) {
+  const subagents = session.subagents || [];
+  const project = session.projectPath ? session.projectPath.replace(/.*\//, '') : session.sessionId.substring(0, 8);
+  return `
+    <div class="teams-card teams-card-static">
+      <div class="teams-card-header">
+        <span class="teams-card-name" title="${escapeHtml(session.projectPath)}">${escapeHtml(project)}</span>
+        <span class="teams-member-cwd" title="${escapeHtml(session.sessionId)}">${escapeHtml(session.sessionId.substring(0, 8))}</span>
+        <span class="teams-card-time">${timeAgo(session.lastModified)}</span>
+      </div>
+      <div class="teams-tasks-list">
+        ${subagents.slice().reverse().map(a => `
+          <div class="teams-task-row" title="${escapeHtml(a.result || a.prompt)}">
+            <span class="teams-task-status" style="background:${subagentStatusColor(a.status)}">${subagentStatusLabel(a.status)}</span>
+            ${a.type ? `<span class="teams-member-badge">${escapeHtml(a.type)}</span>` : ''}
+            <span class="teams-task-subject">${escapeHtml(a.description || truncate(a.prompt, 80))}</span>
+            <span class="teams-member-time">${timeAgo(a.startedAt)}</span>
+          </div>
+        `).join('')}
+      </div>
+    </div>
   `;
 }
 
@@ -427,6 +483,9 @@ function getStyles() {
     }
     .teams-card:hover { border-color: #475569; }
     .teams-card-archived { opacity: 0.6; cursor: default; }
+    .teams-card-static { cursor: default; }
+    .teams-card-static .teams-tasks-list { padding: 4px 0 0; }
+    .teams-section-title .teams-badge { margin-left: 6px; text-transform: none; letter-spacing: 0; }
     .teams-card-header {
       display: flex;
       align-items: center;
@@ -1,17 +1,55 @@
 import { state, getTerminals } from './state.js';
 import { registerStateHandler } from './project-switcher.js';
-import { textToBase64 } from './utils.js';
+import { textToBase64, escapeHtml } from './utils.js';
 
 // Coverage state
 let projectCoverage = new Map(); // projectPath -> coverage summary
 let coverageHistory = new Map(); // projectPath -> history entries
 let projectTestHistory = new Map(); // projectId -> array of test runs
+let projectHistoryLimit = new Map(); // projectId -> how many runs the project keeps
 let projectTestDiscovery = new Map(); // projectPath -> TestDiscovery
+let projectFlakyTests = new Map(); // projectId -> flaky tests
+let projectDurationReport = new Map(); // projectId -> { runs, slowest }
+let projectTestWatch = new Map(); // projectPath -> { active, result }
+let projectTestShards = new Map(); // projectPath -> latest sharded run
+let projectSnapshots = new Map(); // projectPath -> { busy, report, selected: Set of entry indexes }
+let projectChangeCoverage = new Map(); // projectPath -> coverage of changes vs the base branch
+let projectCoverageGates = new Map(); // projectId -> { thresholds, gate }
+let thresholdsFormOpen = false; // keeps the thresholds form open across re-renders
+let projectMutation = new Map(); // projectPath -> { summary, previousScore }
+let shownMutants = []; // surviving mutants in the rendered Mutation Testing section, by index
+let projectTestNotifications = new Map(); // projectId -> 'all' | 'failures' | 'off'
+let shownFailures = []; // failure locations in the rendered Failures section, by index
 
 // Callbacks for backend operations
 let testDashboardCallbacks = {
   getTestHistory: async () => [],
   addTestRun: async () => {},
+  getFlakyTests: async () => [],
+  getTestHistoryLimit: async () => 20,
+  setTestHistoryLimit: async () => {},
+  saveTestHistoryExport: async () => '',
+  getTestDurationReport: async () => null,
+  startTestWatch: async () => {},
+  stopTestWatch: async () => {},
+  isTestWatchActive: async () => false,
+  getTestWatchResult: async () => null,
+  startTestShards: async () => null,
+  stopTestShards: async () => {},
+  getTestShardRun: async () => null,
+  checkTestSnapshots: async () => null,
+  updateTestSnapshots: async () => {},
+  deleteObsoleteSnapshots: async () => 0,
+  getChangeCoverage: async () => null,
+  getCoverageThresholds: async () => null,
+  setCoverageThresholds: async () => {},
+  getCoverageGate: async () => null,
+  runMutationTests: async () => {},
+  stopMutationTests: async () => {},
+  getMutationSummary: async () => null,
+  getTestNotifications: async () => 'all',
+  setTestNotifications: async () => {},
+  openTestFailure: async () => {},
   getTestDiscovery: async () => null,
   scanProjectTests: async () => null,
   writeTerminal: async () => {},
@@ -24,6 +62,9 @@ export function setTestDashboardCallbacks(callbacks) {
   testDashboardCallbacks = { ...testDashboardCallbacks, ...callbacks };
 }
 
+// Number of slowest tests shown in the duration report
+const SLOWEST_TESTS_LIMIT = 8;
+
 // Test status colors and icons
 const STATUS_CONFIG = {
   none: { icon: '○', color: '#6b7280', label: 'No tests' },
@@ -40,7 +81,10 @@ const RUNNER_ICONS = {
   jest: '🃏',
   mocha: '☕',
   pytest: '🐍',
-  go: '🐹'
+  go: '🐹',
+  cargo: '🦀',
+  tap: '🍺',
+  junit: '☕'
 };
 
 // Special tab ID for QA (tests)
@@ -76,6 +120,25 @@ function getHealthLabel(score) {
   return { label: 'Critical', color: '#ef4444', icon: '✗' };
 }
 
+// Load flaky tests and duration trends derived from the project's test history
+async function loadTestInsights() {
+  if (!state.activeProject) return;
+  const projectId = state.activeProject.id;
+
+  try {
+    const [flaky, report] = await Promise.all([
+      testDashboardCallbacks.getFlakyTests(projectId),
+      testDashboardCallbacks.getTestDurationReport(projectId, SLOWEST_TESTS_LIMIT)
+    ]);
+    projectFlakyTests.set(projectId, Array.isArray(flaky) ? flaky : []);
+    projectDurationReport.set(projectId, report || null);
+  } catch (err) {
+    console.error('Failed to load test insights:', err);
+    projectFlakyTests.set(projectId, []);
+    projectDurationReport.delete(projectId);
+  }
+}
+
 // Load test history from backend for current project
 export async function loadTestHistory() {
   if (!state.activeProject) return;
@@ -96,6 +159,11 @@ export async function loadTestHistory() {
     projectTestHistory.set(state.activeProject.id, []);
   }
 
+  await loadTestInsights();
+  await loadTestWatch();
+  await loadTestShards();
+  await loadTestNotifications();
+
   // Load test discovery
   await loadTestDiscovery();
 
@@ -105,7 +173,11 @@ export async function loadTestHistory() {
   // Start watching coverage for this project
   if (state.activeProject?.path) {
     await testDashboardCallbacks.watchProjectCoverage(state.activeProject.path);
+    await loadChangeCoverage(state.activeProject.path);
   }
+  await loadCoverageGate();
+  await loadMutation();
+  await loadHistoryLimit();
 
   // Always render dashboard content
   renderTestDashboardContent();
@@ -243,6 +315,10 @@ function getTestTypeCommand(type) {
     }
   }
 
+  // Command suggested by test discovery for the framework found (go test, pytest, cargo test...)
+  const suggested = getTestDiscovery()?.commands?.[type];
+  if (suggested) return suggested;
+
   // Fallback commands if no script found
   const fallbacks = {
     unit: 'npm test',
@@ -265,7 +341,7 @@ function hasTestTypeCommand(type) {
   };
 
   const scriptKeys = typeScriptMap[type] || ['test'];
-  return scriptKeys.some(key => scripts[key]);
+  return scriptKeys.some(key => scripts[key]) || !!getTestDiscovery()?.commands?.[type];
 }
 
 // Run tests for a specific type
@@ -307,29 +383,7 @@ export function updateTestStatus(terminalId, summary) {
     });
 
     if (!isDuplicate) {
-      const newRun = {
-        id: Date.now(),
-        terminalId,
-        runner: summary.runner,
-        status: summary.status,
-        passed: summary.passed,
-        failed: summary.failed,
-        skipped: summary.skipped,
-        total: summary.total,
-        duration: summary.duration,
-        timestamp: new Date()
-      };
-
-      history.unshift(newRun);
-
-      if (history.length > 20) {
-        projectTestHistory.set(state.activeProject.id, history.slice(0, 20));
-      }
-
-      testDashboardCallbacks.addTestRun(state.activeProject.id, {
-        ...newRun,
-        timestamp: newRun.timestamp.toISOString()
-      }).catch(err => console.error('Failed to save test run:', err));
+      recordTestRun(terminalId, summary);
     }
   }
 
@@ -337,6 +391,217 @@ export function updateTestStatus(terminalId, summary) {
   updateTestIndicators(terminalId);
 }
 
+// Add a finished run to the active project's history and save it
+function recordTestRun(terminalId, summary) {
+  const history = getTestRunHistory();
+  const newRun = {
+    id: Date.now(),
+    terminalId,
+    runner: summary.runner,
+    status: summary.status,
+    passed: summary.passed,
+    failed: summary.failed,
+    skipped: summary.skipped,
+    total: summary.total,
+    duration: summary.duration,
+    failedTests: (summary.failedTests || []).map(t => t.name),
+    testDurations: Object.fromEntries((summary.testDurations || []).map(t => [t.name, t.duration])),
+    timestamp: new Date()
+  };
+
+  history.unshift(newRun);
+
+  const limit = projectHistoryLimit.get(state.activeProject.id) || 20;
+  if (history.length > limit) {
+    projectTestHistory.set(state.activeProject.id, history.slice(0, limit));
+  }
+
+  testDashboardCallbacks.addTestRun(state.activeProject.id, {
+    ...newRun,
+    timestamp: newRun.timestamp.toISOString()
+  }).then(() => loadTestInsights())
+    .then(() => updateTestDashboard())
+    .catch(err => console.error('Failed to save test run:', err));
+}
+
+// Watch mode: load whether it is on for the active project and its latest rerun
+async function loadTestWatch() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+
+  try {
+    const [active, result] = await Promise.all([
+      testDashboardCallbacks.isTestWatchActive(projectPath),
+      testDashboardCallbacks.getTestWatchResult(projectPath)
+    ]);
+    projectTestWatch.set(projectPath, { active: !!active, result: result || null });
+  } catch (err) {
+    console.error('Failed to load test watch state:', err);
+  }
+}
+
+// Load the active project's latest sharded run
+async function loadTestShards() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+
+  try {
+    const run = await testDashboardCallbacks.getTestShardRun(projectPath);
+    if (run) projectTestShards.set(projectPath, run);
+  } catch (err) {
+    console.error('Failed to load sharded test run:', err);
+  }
+}
+
+// Load when the active project notifies about test runs finishing in the background
+async function loadTestNotifications() {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  try {
+    const mode = await testDashboardCallbacks.getTestNotifications(projectId);
+    projectTestNotifications.set(projectId, mode || 'all');
+  } catch (err) {
+    console.error('Failed to load test notification setting:', err);
+  }
+}
+
+async function setTestNotifications(mode) {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  try {
+    await testDashboardCallbacks.setTestNotifications(projectId, mode);
+    projectTestNotifications.set(projectId, mode);
+  } catch (err) {
+    console.error('Failed to save test notification setting:', err);
+  }
+  updateTestDashboard();
+}
+
+// Turn watch mode on or off for the active project
+async function toggleTestWatch() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+
+  const watch = projectTestWatch.get(projectPath) || { active: false, result: null };
+  try {
+    if (watch.active) {
+      await testDashboardCallbacks.stopTestWatch(projectPath);
+      projectTestWatch.set(projectPath, { active: false, result: null });
+    } else {
+      await testDashboardCallbacks.startTestWatch(projectPath);
+      projectTestWatch.set(projectPath, { active: true, result: null });
+    }
+  } catch (err) {
+    console.error('Failed to toggle test watch:', err);
+    alert(`Watch mode unavailable: ${err}`);
+  }
+  updateTestDashboard();
+}
+
+// Start a sharded run from the header's "count:strategy" choice, or stop the running one
+async function startTestShards(choice) {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath || !choice) return;
+
+  try {
+    if (choice === 'stop') {
+      await testDashboardCallbacks.stopTestShards(projectPath);
+      return;
+    }
+    const [count, strategy] = choice.split(':');
+    const run = await testDashboardCallbacks.startTestShards(projectPath, parseInt(count, 10), strategy);
+    if (run) projectTestShards.set(projectPath, run);
+  } catch (err) {
+    console.error('Failed to start sharded test run:', err);
+    alert(`Sharded run unavailable: ${err}`);
+  }
+  updateTestDashboard();
+}
+
+// Update sharded run progress from backend event
+export function updateTestShards(run) {
+  projectTestShards.set(run.projectPath, run);
+
+  // Finished runs join the project's test history as one run
+  if (!run.running && !run.error && run.summary?.total > 0 && run.projectPath === state.activeProject?.path) {
+    recordTestRun('shards', run.summary);
+  }
+  updateTestDashboard();
+}
+
+// Run the suite to find obsolete and failed Jest/Vitest snapshots
+async function checkSnapshots() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+
+  const snapshots = projectSnapshots.get(projectPath) || { report: null, selected: new Set() };
+  projectSnapshots.set(projectPath, { ...snapshots, busy: 'Checking snapshots...' });
+  updateTestDashboard();
+  try {
+    const report = await testDashboardCallbacks.checkTestSnapshots(projectPath);
+    projectSnapshots.set(projectPath, { busy: null, report, selected: new Set() });
+  } catch (err) {
+    console.error('Failed to check snapshots:', err);
+    projectSnapshots.set(projectPath, { ...snapshots, busy: null });
+    alert(`Snapshot check failed: ${err}`);
+  }
+  updateTestDashboard();
+}
+
+function toggleSnapshot(index) {
+  const snapshots = projectSnapshots.get(state.activeProject?.path);
+  if (!snapshots) return;
+  if (snapshots.selected.has(index)) {
+    snapshots.selected.delete(index);
+  } else {
+    snapshots.selected.add(index);
+  }
+  updateTestDashboard();
+}
+
+// Update the selected failed snapshots, or delete the selected obsolete ones, then check again
+async function applySnapshots(action) {
+  const projectPath = state.activeProject?.path;
+  const snapshots = projectSnapshots.get(projectPath);
+  if (!snapshots?.report) return;
+
+  const status = action === 'update' ? 'failed' : 'obsolete';
+  const entries = [...snapshots.selected]
+    .map(i => snapshots.report.entries[i])
+    .filter(entry => entry && entry.status === status);
+  if (entries.length === 0) return;
+  if (action === 'delete' && !confirm(`Delete ${entries.length} obsolete snapshot${entries.length === 1 ? '' : 's'}?`)) return;
+
+  projectSnapshots.set(projectPath, { ...snapshots, busy: action === 'update' ? 'Updating snapshots...' : 'Deleting snapshots...' });
+  updateTestDashboard();
+  try {
+    if (action === 'update') {
+      await testDashboardCallbacks.updateTestSnapshots(projectPath, entries);
+    } else {
+      await testDashboardCallbacks.deleteObsoleteSnapshots(projectPath, entries);
+    }
+  } catch (err) {
+    console.error(`Failed to ${action} snapshots:`, err);
+    alert(`Could not ${action} snapshots: ${err}`);
+  }
+  await checkSnapshots();
+}
+
+// Update watch mode progress from backend event
+export function updateTestWatch(result) {
+  const watch = projectTestWatch.get(result.projectPath);
+  if (!watch?.active) return;
+  watch.result = result;
+
+  // Finished reruns join the project's test history
+  if (!result.running && result.summary?.total > 0 && result.projectPath === state.activeProject?.path) {
+    recordTestRun('watch', result.summary);
+  }
+  updateTestDashboard();
+}
+
 // Update test indicators on terminal tabs
 function updateTestIndicators(terminalId) {
   const terminalTab = document.querySelector(`.terminal-tab[data-id="${terminalId}"]`);
@@ -434,11 +699,25 @@ function renderTestDashboardContent() {
   const discovery = getTestDiscovery();
   const coverage = getActiveCoverage();
   const history = getTestRunHistory();
+  const flakyTests = state.activeProject ? (projectFlakyTests.get(state.activeProject.id) || []) : [];
+  const durationReport = state.activeProject ? projectDurationReport.get(state.activeProject.id) : null;
   const covHistory = state.activeProject?.path ? coverageHistory.get(state.activeProject.path) : null;
+  const watch = state.activeProject?.path ? projectTestWatch.get(state.activeProject.path) : null;
+  const shardRun = state.activeProject?.path ? projectTestShards.get(state.activeProject.path) : null;
+  const snapshots = state.activeProject?.path ? projectSnapshots.get(state.activeProject.path) : null;
+  const hasSnapshotRunner = (discovery?.testFiles || []).some(f => f.framework === 'jest' || f.framework === 'vitest');
+  const notifyMode = projectTestNotifications.get(state.activeProject?.id) || 'all';
+  const changeCoverage = state.activeProject?.path ? projectChangeCoverage.get(state.activeProject.path) : null;
+  const coverageGate = projectCoverageGates.get(state.activeProject?.id) || { thresholds: null, gate: null };
+  const mutation = state.activeProject?.path ? projectMutation.get(state.activeProject.path) : null;
+  const historyLimit = projectHistoryLimit.get(state.activeProject?.id) || 20;
+  shownMutants = (mutation?.summary?.survivors || []).slice(0, 15);
+  const gateFailed = coverageGate.gate && !coverageGate.gate.passed;
 
   // Aggregate test results from terminal status
   let passedRun = 0, failedRun = 0, skippedRun = 0;
   let isRunning = false;
+  const failures = [];
 
   for (const [terminalId, summary] of state.testStatus) {
     if (!projectTerminalIds.has(terminalId)) continue;
@@ -446,7 +725,15 @@ function renderTestDashboardContent() {
     passedRun += summary.passed || 0;
     failedRun += summary.failed || 0;
     skippedRun += summary.skipped || 0;
+    failures.push(...(summary.failures || []));
+  }
+  if (watch?.active) {
+    failures.push(...(watch.result?.summary?.failures || []));
   }
+  if (shardRun) {
+    failures.push(...(shardRun.summary?.failures || []));
+  }
+  shownFailures = failures.slice(0, 20);
 
   // Coverage data
   const coveragePct = coverage?.total?.lines?.pct || 0;
@@ -578,6 +865,34 @@ function renderTestDashboardContent() {
           <span class="qa-health-badge" style="background: ${health.color}20; color: ${health.color}">
             ${health.icon} ${health.label} (${healthScore}/100)
           </span>
+          ${coverageGate.thresholds?.blockReady ? `
+            <span class="qa-ready-badge ${gateFailed ? 'blocked' : ''}"
+                  title="${gateFailed ? 'Coverage is below the project\'s thresholds' : 'Coverage meets the project\'s thresholds'}">
+              ${gateFailed ? '⛔ Not ready to commit' : '✓ Ready to commit'}
+            </span>
+          ` : ''}
+          <select class="qa-notify-select" onchange="window.__qaSetNotifications?.(this.value)"
+                  title="Notify when tests finish in a terminal you aren't looking at">
+            ${[['all', '🔔 All runs'], ['failures', '🔔 Failures'], ['off', '🔕 Off']].map(([mode, label]) => `
+              <option value="${mode}" ${notifyMode === mode ? 'selected' : ''}>${label}</option>
+            `).join('')}
+          </select>
+          <select class="qa-notify-select qa-shard-select" onchange="window.__qaStartShards?.(this.value); this.value = ''"
+                  title="Split the unit and integration tests across parallel processes">
+            <option value="" selected>⫴ ${shardRun?.running ? 'Sharding...' : 'Shard'}</option>
+            ${shardRun?.running ? `
+              <option value="stop">■ Stop</option>
+            ` : [2, 4, 8].map(count => `
+              <option value="${count}:file">${count} shards by test count</option>
+              <option value="${count}:timing">${count} shards by timing</option>
+            `).join('')}
+          </select>
+          ${hasSnapshotRunner ? `
+            <button class="qa-scan-btn" onclick="window.__qaCheckSnapshots?.()" ${snapshots?.busy ? 'disabled' : ''}
+                    title="Find obsolete and mismatched Jest/Vitest snapshots">📸 Snapshots</button>
+          ` : ''}
+          <button class="qa-scan-btn qa-watch-btn ${watch?.active ? 'active' : ''}" onclick="window.__qaToggleWatch?.()"
+                  title="Rerun the tests affected by each file change">👁 ${watch?.active ? 'Watching' : 'Watch'}</button>
           <button class="qa-scan-btn" onclick="window.__qaScanTests?.()">🔄 Scan</button>
         </div>
       </div>
@@ -598,11 +913,52 @@ function renderTestDashboardContent() {
         </div>
       ` : ''}
 
+      <!-- Watch Mode -->
+      ${watch?.active ? renderWatchStatus(watch.result) : ''}
+
+      <!-- Sharded Run -->
+      ${shardRun ? renderShardRun(shardRun) : ''}
+
+      <!-- Snapshot management -->
+      ${snapshots ? renderSnapshots(snapshots) : ''}
+
+      <!-- Failure locations of the latest runs -->
+      ${shownFailures.length > 0 ? renderFailures(shownFailures) : ''}
+
+      <!-- Coverage thresholds -->
+      ${gateFailed ? `
+        <div class="qa-watch-status status-failed qa-coverage-warning">
+          <span class="qa-watch-icon">⚠</span>
+          <span class="qa-watch-text">${coverageGate.gate.violations.map(v => escapeHtml(v.message)).join(' · ')}</span>
+        </div>
+      ` : ''}
+      ${renderCoverageThresholds(coverageGate.thresholds)}
+
+      <!-- Mutation testing -->
+      ${renderMutation(mutation)}
+
+      <!-- Coverage of the branch's changes -->
+      ${changeCoverage && changeCoverage.files.length > 0 ? renderChangeCoverage(changeCoverage) : ''}
+
       <!-- Recent Runs - Enhanced -->
       <div class="qa-section qa-recent-runs">
         <div class="qa-section-header">
           <span>Recent Runs</span>
           ${history.length > 0 ? `<span class="qa-section-count">${history.length} runs</span>` : ''}
+          <span class="qa-history-actions">
+            <select class="qa-notify-select" onchange="window.__qaSetHistoryLimit?.(parseInt(this.value, 10))"
+                    title="How many test runs and coverage points the project keeps">
+              ${[20, 50, 100, 250, 500, 1000].map(limit => `
+                <option value="${limit}" ${historyLimit === limit ? 'selected' : ''}>Keep ${limit}</option>
+              `).join('')}
+            </select>
+            <select class="qa-notify-select" onchange="window.__qaExportHistory?.(this.value); this.value = ''"
+                    title="Export test runs and coverage history">
+              <option value="" selected>⤓ Export</option>
+              <option value="csv">CSV</option>
+              <option value="json">JSON</option>
+            </select>
+          </span>
         </div>
         <div class="qa-runs-grid">
           ${history.length === 0 ? `
@@ -641,15 +997,172 @@ function renderTestDashboardContent() {
           }).join('')}
         </div>
       </div>
+
+      <!-- Test Durations -->
+      ${durationReport && (durationReport.runs?.length > 1 || durationReport.slowest?.length > 0) ? `
+        <div class="qa-section qa-durations">
+          <div class="qa-section-header">
+            <span>Test Durations</span>
+            <span class="qa-section-count">${durationReport.runs.length} runs</span>
+          </div>
+          ${renderDurationTrend(durationReport.runs)}
+          ${durationReport.slowest?.length > 0 ? `
+            <div class="qa-slow-list">
+              ${durationReport.slowest.map(test => `
+                <div class="qa-slow-item" title="Average ${formatTestDuration(test.average)} over ${test.trend.length} run${test.trend.length === 1 ? '' : 's'}">
+                  <span class="qa-slow-name">${escapeHtml(test.name)}</span>
+                  <span class="qa-slow-duration">${formatTestDuration(test.duration)}</span>
+                  ${Math.abs(test.change) >= 20 ? `
+                    <span class="qa-slow-change ${test.change > 0 ? 'slower' : 'faster'}">${test.change > 0 ? '+' : ''}${Math.round(test.change)}%</span>
+                  ` : '<span class="qa-slow-change"></span>'}
+                </div>
+              `).join('')}
+            </div>
+          ` : ''}
+        </div>
+      ` : ''}
+
+      <!-- Flaky Tests -->
+      ${flakyTests.length > 0 ? `
+        <div class="qa-section qa-flaky-tests">
+          <div class="qa-section-header">
+            <span>Flaky Tests</span>
+            <span class="qa-section-count">${flakyTests.length} flaky</span>
+          </div>
+          <div class="qa-flaky-list">
+            ${flakyTests.slice(0, 10).map(test => `
+              <div class="qa-flaky-item" title="Passed ${test.passes}× and failed ${test.failures}× on unchanged code">
+                <span class="qa-flaky-name">${escapeHtml(test.name)}</span>
+                <span class="qa-flaky-runner">${escapeHtml(test.runner || 'unknown')}</span>
+                <span class="qa-flaky-flips">${test.flips} flip${test.flips === 1 ? '' : 's'}</span>
+                <span class="qa-flaky-counts">✓${test.passes} ✗${test.failures}</span>
+              </div>
+            `).join('')}
+          </div>
+        </div>
+      ` : ''}
     </div>
   `;
 
   // Attach global handlers
   window.__qaScanTests = scanTests;
+  window.__qaToggleWatch = toggleTestWatch;
+  window.__qaStartShards = startTestShards;
+  window.__qaSaveThresholds = saveCoverageThresholds;
+  window.__qaToggleMutation = toggleMutationRun;
+  window.__qaSetHistoryLimit = setHistoryLimit;
+  window.__qaExportHistory = exportHistory;
+  window.__qaOpenMutant = openMutant;
+  window.__qaThresholdsToggled = open => { thresholdsFormOpen = open; };
+  window.__qaCheckSnapshots = checkSnapshots;
+  window.__qaToggleSnapshot = toggleSnapshot;
+  window.__qaApplySnapshots = applySnapshots;
+  window.__qaSetNotifications = setTestNotifications;
+  window.__qaOpenFailure = openFailure;
   window.__qaRunTestType = runTestType;
   window.__qaRunFailed = runFailedTests;
 }
 
+// Render where the failed tests failed, with their assertion diffs
+function renderFailures(failures) {
+  return `
+    <div class="qa-section qa-failures">
+      <div class="qa-section-header">
+        <span>Failures</span>
+        <span class="qa-section-count">${failures.length} failing</span>
+      </div>
+      <div class="qa-failure-list">
+        ${failures.map((failure, i) => `
+          <div class="qa-failure-item" onclick="window.__qaOpenFailure?.(${i})" title="Open ${escapeHtml(failure.file)} at line ${failure.line}">
+            <div class="qa-failure-head">
+              <span class="qa-failure-name">${escapeHtml(failure.test)}</span>
+              <span class="qa-failure-location">${escapeHtml(failure.file)}:${failure.line}</span>
+            </div>
+            ${failure.message ? `<div class="qa-failure-message">${escapeHtml(failure.message)}</div>` : ''}
+            ${failure.expected || failure.actual ? `
+              <div class="qa-failure-diff">
+                <div class="qa-failure-expected">- ${escapeHtml(failure.expected || '')}</div>
+                <div class="qa-failure-actual">+ ${escapeHtml(failure.actual || '')}</div>
+              </div>
+            ` : ''}
+          </div>
+        `).join('')}
+      </div>
+    </div>
+  `;
+}
+
+// Obsolete and failed snapshots with checkboxes, and diffs for the failed ones
+function renderSnapshots({ busy, report, selected }) {
+  if (!report) {
+    return busy ? `
+      <div class="qa-watch-status status-running">
+        <span class="qa-watch-icon">⟳</span>
+        <span class="qa-watch-text">${busy}</span>
+      </div>
+    ` : '';
+  }
+
+  const entries = report.entries || [];
+  const selectedOf = status => [...selected].filter(i => entries[i]?.status === status).length;
+  return `
+    <div class="qa-section qa-snapshots">
+      <div class="qa-section-header">
+        <span>Snapshots</span>
+        <span class="qa-section-count">
+          ${busy || `${report.snapshots} in ${report.snapshotFiles} file${report.snapshotFiles === 1 ? '' : 's'} · checked ${formatRelativeTime(new Date(report.checkedAt))}`}
+        </span>
+      </div>
+      ${entries.length === 0 ? `
+        <div class="qa-empty-text">All snapshots match and are in use</div>
+      ` : `
+        <div class="qa-failure-list">
+          ${entries.map((entry, i) => `
+            <div class="qa-snapshot-item status-${entry.status}">
+              <label class="qa-failure-head">
+                <span class="qa-failure-name">
+                  <input type="checkbox" ${selected.has(i) ? 'checked' : ''} ${busy ? 'disabled' : ''} onchange="window.__qaToggleSnapshot?.(${i})">
+                  ${escapeHtml(entry.key || entry.test || 'Whole snapshot file (test file removed)')}
+                </span>
+                <span class="qa-failure-location">${escapeHtml(entry.snapshotFile || entry.testFile)}</span>
+              </label>
+              ${entry.diff ? `
+                <details class="qa-snapshot-diff">
+                  <summary>Diff</summary>
+                  <div class="qa-failure-diff">${entry.diff.split('\n').map(line => `
+                    <div class="${line.startsWith('- ') ? 'qa-failure-expected' : line.startsWith('+ ') ? 'qa-failure-actual' : ''}">${escapeHtml(line) || '&nbsp;'}</div>
+                  `).join('')}</div>
+                </details>
+              ` : ''}
+            </div>
+          `).join('')}
+        </div>
+        <div class="qa-snapshot-actions">
+          <button class="qa-scan-btn" onclick="window.__qaApplySnapshots?.('update')" ${busy || !selectedOf('failed') ? 'disabled' : ''}>
+            Update ${selectedOf('failed') || ''} failed
+          </button>
+          <button class="qa-scan-btn" onclick="window.__qaApplySnapshots?.('delete')" ${busy || !selectedOf('obsolete') ? 'disabled' : ''}>
+            Delete ${selectedOf('obsolete') || ''} obsolete
+          </button>
+        </div>
+      `}
+    </div>
+  `;
+}
+
+// Open a failing test's file at the failing line in the editor
+async function openFailure(index) {
+  const failure = shownFailures[index];
+  if (!failure || !state.activeProject?.path) return;
+
+  try {
+    await testDashboardCallbacks.openTestFailure(state.activeProject.path, failure);
+  } catch (err) {
+    console.error('Failed to open test failure:', err);
+    alert(`Could not open ${failure.file}: ${err}`);
+  }
+}
+
 function getCoverageColor(percent) {
   if (percent >= 80) return '#22c55e';
   if (percent >= 60) return '#eab308';
@@ -657,6 +1170,212 @@ function getCoverageColor(percent) {
   return '#ef4444';
 }
 
+// Mutation score: how many injected bugs the tests caught, and where they didn't
+function renderMutation(mutation) {
+  const summary = mutation?.summary;
+  const delta = summary && mutation.previousScore !== null ? summary.score - mutation.previousScore : null;
+  return `
+    <div class="qa-section qa-mutation">
+      <div class="qa-section-header">
+        <span>Mutation Testing</span>
+        <button class="qa-scan-btn" onclick="window.__qaToggleMutation?.()"
+                title="Run Stryker (JS/TS) or go-mutesting (Go) and track the mutation score">
+          ${summary?.running ? '■ Stop' : '🧬 Run'}
+        </button>
+      </div>
+      ${!summary ? `
+        <div class="qa-empty-text">No mutation report yet</div>
+      ` : `
+        <div class="qa-watch-status status-${summary.running ? 'running' : summary.error ? 'failed' : 'passed'}">
+          <span class="qa-watch-icon">${summary.running ? '⟳' : '🧬'}</span>
+          <span class="qa-watch-text">
+            ${summary.running ? `Running ${escapeHtml(summary.tool)}...` : ''}
+            ${summary.total > 0 ? `
+              Score <strong style="color: ${getCoverageColor(summary.score)}">${summary.score.toFixed(1)}%</strong>
+              ${delta !== null && Math.abs(delta) >= 0.1 ? `<span class="qa-trend-delta ${delta > 0 ? 'positive' : 'negative'}">${delta > 0 ? '+' : ''}${delta.toFixed(1)}</span>` : ''}
+              · ${summary.killed + summary.timeout} killed, ${summary.survived} survived, ${summary.noCoverage} uncovered
+            ` : ''}
+          </span>
+          ${summary.error ? `<span class="qa-watch-error">${escapeHtml(summary.error)}</span>` : ''}
+        </div>
+        ${shownMutants.length > 0 ? `
+          <div class="qa-failure-list">
+            ${shownMutants.map((mutant, i) => `
+              <div class="qa-failure-item qa-mutant-item" onclick="window.__qaOpenMutant?.(${i})" title="Open ${escapeHtml(mutant.file)} at line ${mutant.line}">
+                <div class="qa-failure-head">
+                  <span class="qa-failure-name">${escapeHtml(mutant.mutator)}${mutant.noCoverage ? ' (no coverage)' : ''}</span>
+                  <span class="qa-failure-location">${escapeHtml(mutant.file)}:${mutant.line}</span>
+                </div>
+                ${mutant.replacement ? `<div class="qa-failure-diff"><div class="qa-failure-actual">+ ${escapeHtml(mutant.replacement)}</div></div>` : ''}
+              </div>
+            `).join('')}
+          </div>
+        ` : ''}
+      `}
+    </div>
+  `;
+}
+
+// Collapsible form for the project's coverage thresholds
+function renderCoverageThresholds(thresholds) {
+  const t = thresholds || {};
+  const field = (id, label, value, hint) => `
+    <label class="qa-threshold-field" title="${hint}">
+      <span>${label}</span>
+      <input id="${id}" type="number" min="0" max="100" step="0.5" value="${value || ''}" placeholder="—">
+    </label>
+  `;
+  return `
+    <details class="qa-section qa-thresholds" ${thresholdsFormOpen ? 'open' : ''} ontoggle="window.__qaThresholdsToggled?.(this.open)">
+      <summary class="qa-section-header">
+        <span>Coverage Thresholds</span>
+        <span class="qa-section-count">${thresholds
+          ? ['lines', 'statements', 'functions', 'branches'].filter(m => t[m] > 0).map(m => `${m} ≥ ${t[m]}%`).join(', ') || 'no minimums'
+          : 'not set'}</span>
+      </summary>
+      <div class="qa-threshold-grid">
+        ${field('qa-threshold-lines', 'Lines %', t.lines, 'Minimum line coverage')}
+        ${field('qa-threshold-statements', 'Statements %', t.statements, 'Minimum statement coverage')}
+        ${field('qa-threshold-functions', 'Functions %', t.functions, 'Minimum function coverage')}
+        ${field('qa-threshold-branches', 'Branches %', t.branches, 'Minimum branch coverage')}
+        ${field('qa-threshold-drop', 'Max drop', t.maxDrop, 'Points coverage may fall from one report to the next')}
+      </div>
+      <div class="qa-snapshot-actions">
+        <label class="qa-threshold-block">
+          <input id="qa-threshold-block" type="checkbox" ${t.blockReady ? 'checked' : ''}>
+          Not ready to commit while below
+        </label>
+        <button class="qa-scan-btn" onclick="window.__qaSaveThresholds?.()">Save</button>
+      </div>
+    </details>
+  `;
+}
+
+// Coverage of the lines changed since the branch left its base
+function renderChangeCoverage(result) {
+  const { lines } = result;
+  const files = result.files.filter(f => f.lines.total > 0 || !f.inReport);
+  const headline = !result.lineData
+    ? 'The coverage report has no line data'
+    : lines.total > 0
+      ? `Your changes are <strong style="color: ${getCoverageColor(lines.pct)}">${Math.round(lines.pct)}%</strong> covered`
+      : 'None of your changes are instrumented';
+
+  return `
+    <div class="qa-section qa-change-coverage">
+      <div class="qa-section-header">
+        <span>Changes vs ${escapeHtml(result.base)}</span>
+        ${lines.total > 0 ? `<span class="qa-section-count">${lines.covered}/${lines.total} lines</span>` : ''}
+      </div>
+      <div class="qa-change-headline">${headline}</div>
+      ${lines.total > 0 ? `
+        <div class="qa-stat-bar">
+          <div class="qa-stat-fill" style="width: ${lines.pct}%; background: ${getCoverageColor(lines.pct)}"></div>
+        </div>
+      ` : ''}
+      <div class="qa-change-files">
+        ${files.slice(0, 12).map(file => `
+          <div class="qa-change-file" title="${file.uncoveredLines.length > 0
+            ? 'Uncovered: ' + file.uncoveredLines.map(r => r.start === r.end ? r.start : `${r.start}-${r.end}`).join(', ')
+            : ''}">
+            <span class="qa-change-path">${escapeHtml(file.path)}</span>
+            ${file.inReport ? `
+              <span class="qa-change-pct" style="color: ${getCoverageColor(file.lines.pct)}">${file.lines.covered}/${file.lines.total}</span>
+            ` : '<span class="qa-change-pct dim">not in report</span>'}
+          </div>
+        `).join('')}
+      </div>
+    </div>
+  `;
+}
+
+// Latest watch mode rerun: what changed, which tests ran, and how they did
+function renderWatchStatus(result) {
+  if (!result) {
+    return `
+      <div class="qa-watch-status">
+        <span class="qa-watch-icon">👁</span>
+        <span class="qa-watch-text">Watching for changes...</span>
+      </div>
+    `;
+  }
+
+  const summary = result.summary;
+  const status = result.running ? 'running' : (result.error ? 'failed' : summary?.status || 'none');
+  const changed = result.changedFiles || [];
+  return `
+    <div class="qa-watch-status status-${status}" title="${escapeHtml(result.command)}">
+      <span class="qa-watch-icon">${result.running ? '⟳' : status === 'passed' ? '✓' : status === 'none' ? '○' : '✗'}</span>
+      <span class="qa-watch-text">
+        ${result.running ? 'Rerunning' : 'Reran'} ${result.testFiles.length} test file${result.testFiles.length === 1 ? '' : 's'}
+        for ${escapeHtml(changed.slice(0, 2).join(', '))}${changed.length > 2 ? ` +${changed.length - 2}` : ''}
+      </span>
+      ${summary ? `<span class="qa-running-counts">✓${summary.passed} ✗${summary.failed}</span>` : ''}
+      ${result.error ? `<span class="qa-watch-error">${escapeHtml(result.error)}</span>` : ''}
+    </div>
+  `;
+}
+
+// Latest sharded run: the merged result and each shard's progress
+function renderShardRun(run) {
+  const summary = run.summary;
+  const status = run.running ? 'running' : (run.error ? 'failed' : summary?.status || 'none');
+  const elapsed = run.running ? '' : formatTestDuration(summary?.duration || 0);
+  return `
+    <div class="qa-section qa-shards">
+      <div class="qa-section-header">
+        <span>Sharded Run</span>
+        <span class="qa-section-count">
+          ${run.shards.length} shards by ${run.strategy === 'timing' ? 'timing' : 'test count'}${elapsed ? ` · ${elapsed}` : ''}
+        </span>
+      </div>
+      <div class="qa-watch-status status-${status}">
+        <span class="qa-watch-icon">${run.running ? '⟳' : status === 'passed' ? '✓' : status === 'none' ? '○' : '✗'}</span>
+        <span class="qa-watch-text">${run.running ? 'Running' : run.error ? 'Stopped' : 'Finished'}</span>
+        ${summary ? `<span class="qa-running-counts">✓${summary.passed} ✗${summary.failed}${summary.skipped ? ` ○${summary.skipped}` : ''}</span>` : ''}
+      </div>
+      <div class="qa-shard-list">
+        ${run.shards.map(shard => {
+          const s = shard.summary;
+          const shardStatus = shard.running ? 'running' : (shard.error ? 'failed' : s?.status || 'none');
+          const done = s ? s.passed + s.failed + s.skipped : 0;
+          const pct = shard.tests > 0 ? Math.min(100, Math.round((done / shard.tests) * 100)) : (shard.running ? 0 : 100);
+          return `
+            <div class="qa-shard-row status-${shardStatus}" title="${escapeHtml(shard.command)}">
+              <span class="qa-shard-index">#${shard.index}</span>
+              <span class="qa-shard-files">${shard.testFiles.length} file${shard.testFiles.length === 1 ? '' : 's'}</span>
+              <div class="qa-shard-bar"><div class="qa-shard-fill" style="width: ${shard.running ? pct : 100}%"></div></div>
+              <span class="qa-running-counts">${s ? `✓${s.passed} ✗${s.failed}` : '…'}</span>
+              ${shard.error ? `<span class="qa-watch-error">${escapeHtml(shard.error)}</span>` : ''}
+            </div>
+          `;
+        }).join('')}
+      </div>
+    </div>
+  `;
+}
+
+// Bar chart of suite durations, oldest run first
+function renderDurationTrend(runs) {
+  if (!runs || runs.length < 2) return '';
+  const max = Math.max(...runs.map(run => run.duration), 1);
+  return `
+    <div class="qa-duration-trend">
+      ${runs.map(run => `
+        <div class="qa-duration-bar" style="height: ${Math.max(4, Math.round((run.duration / max) * 100))}%"
+             title="${escapeHtml(run.runner || 'unknown')} · ${formatTestDuration(run.duration)} · ${run.total} tests · ${formatRelativeTime(run.timestamp)}"></div>
+      `).join('')}
+    </div>
+  `;
+}
+
+function formatTestDuration(ms) {
+  if (!ms || ms < 1) return '<1ms';
+  if (ms < 1000) return `${Math.round(ms)}ms`;
+  if (ms < 60000) return `${(ms / 1000).toFixed(1)}s`;
+  return `${Math.floor(ms / 60000)}m ${Math.round((ms % 60000) / 1000)}s`;
+}
+
 function formatRelativeTime(date) {
   if (!date) return '-';
   const d = new Date(date);
@@ -682,66 +1401,366 @@ export function showQAPanel(show) {
   const structurePanel = document.getElementById('structurePanel');
   const gitPanel = document.getElementById('gitHistoryPanel');
 
-  if (qaPanel) {
-    qaPanel.style.display = show ? 'flex' : 'none';
-  }
+  if (qaPanel) {
+    qaPanel.style.display = show ? 'flex' : 'none';
+  }
+
+  if (show) {
+    if (browserInnerContent) browserInnerContent.style.display = 'none';
+    if (dashboardPanel) dashboardPanel.style.display = 'none';
+    if (structurePanel) structurePanel.style.display = 'none';
+    if (gitPanel) gitPanel.style.display = 'none';
+  }
+}
+
+// Check if tests tab is active
+export function isQATabActive() {
+  return state.browser.activeTabId === QA_TAB_ID;
+}
+
+function addTestDashboardStyles() {
+  if (document.getElementById('test-dashboard-styles')) return;
+
+  const style = document.createElement('style');
+  style.id = 'test-dashboard-styles';
+  style.textContent = `
+    /* QA Dashboard Container */
+    .qa-dashboard {
+      padding: 20px;
+      background: #0f172a;
+      color: #e2e8f0;
+      overflow-y: auto;
+      height: 100%;
+    }
+
+    .qa-header {
+      display: flex;
+      justify-content: space-between;
+      align-items: center;
+      margin-bottom: 20px;
+    }
+
+    .qa-header h2 {
+      margin: 0;
+      font-size: 18px;
+      font-weight: 600;
+      color: #f1f5f9;
+    }
+
+    .qa-scan-btn {
+      padding: 8px 16px;
+      background: #1e293b;
+      border: 1px solid #334155;
+      border-radius: 8px;
+      color: #94a3b8;
+      cursor: pointer;
+      font-size: 13px;
+      transition: all 0.2s;
+    }
+
+    .qa-scan-btn:hover {
+      background: #334155;
+      color: #f1f5f9;
+    }
+
+    .qa-change-headline {
+      font-size: 14px;
+      color: #cbd5e1;
+      margin-bottom: 8px;
+    }
+
+    .qa-change-files {
+      display: flex;
+      flex-direction: column;
+      gap: 4px;
+      margin-top: 10px;
+    }
+
+    .qa-change-file {
+      display: flex;
+      justify-content: space-between;
+      gap: 10px;
+      font-size: 12px;
+    }
+
+    .qa-change-path {
+      min-width: 0;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+      font-family: monospace;
+      color: #94a3b8;
+    }
+
+    .qa-change-pct {
+      font-variant-numeric: tabular-nums;
+      font-weight: 600;
+    }
+
+    .qa-change-pct.dim {
+      color: #64748b;
+      font-weight: normal;
+    }
+
+    .qa-failure-list {
+      display: flex;
+      flex-direction: column;
+      gap: 6px;
+    }
+
+    .qa-failure-item {
+      padding: 8px 12px;
+      background: rgba(239, 68, 68, 0.06);
+      border-left: 3px solid #ef4444;
+      border-radius: 6px;
+      cursor: pointer;
+    }
+
+    .qa-failure-item:hover {
+      background: rgba(239, 68, 68, 0.12);
+    }
+
+    .qa-failure-head {
+      display: flex;
+      justify-content: space-between;
+      gap: 12px;
+      font-size: 13px;
+    }
+
+    .qa-failure-name {
+      color: #e2e8f0;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+    }
+
+    .qa-failure-location {
+      font-family: monospace;
+      color: #94a3b8;
+      white-space: nowrap;
+    }
+
+    .qa-failure-message {
+      margin-top: 4px;
+      font-size: 12px;
+      color: #fca5a5;
+    }
+
+    .qa-failure-diff {
+      margin-top: 4px;
+      font-family: monospace;
+      font-size: 12px;
+      white-space: pre-wrap;
+    }
+
+    .qa-failure-expected {
+      color: #86efac;
+    }
+
+    .qa-failure-actual {
+      color: #fca5a5;
+    }
+
+    .qa-snapshot-item {
+      padding: 8px 12px;
+      background: #1e293b;
+      border-left: 3px solid #64748b;
+      border-radius: 6px;
+    }
+
+    .qa-snapshot-item.status-failed {
+      border-left-color: #ef4444;
+    }
+
+    .qa-snapshot-item .qa-failure-head {
+      cursor: pointer;
+    }
+
+    .qa-snapshot-diff summary {
+      margin-top: 4px;
+      font-size: 12px;
+      color: #94a3b8;
+      cursor: pointer;
+    }
+
+    .qa-snapshot-actions {
+      display: flex;
+      justify-content: flex-end;
+      gap: 8px;
+      margin-top: 10px;
+    }
+
+    .qa-history-actions {
+      display: flex;
+      gap: 6px;
+      margin-left: auto;
+    }
+
+    .qa-history-actions .qa-notify-select {
+      padding: 4px 8px;
+      font-size: 12px;
+    }
+
+    .qa-mutant-item {
+      background: rgba(249, 115, 22, 0.06);
+      border-left-color: #f97316;
+    }
+
+    .qa-mutant-item:hover {
+      background: rgba(249, 115, 22, 0.12);
+    }
+
+    .qa-ready-badge {
+      padding: 6px 10px;
+      border-radius: 8px;
+      background: rgba(34, 197, 94, 0.12);
+      color: #22c55e;
+      font-size: 13px;
+      font-weight: 500;
+    }
+
+    .qa-ready-badge.blocked {
+      background: rgba(239, 68, 68, 0.12);
+      color: #ef4444;
+    }
 
-  if (show) {
-    if (browserInnerContent) browserInnerContent.style.display = 'none';
-    if (dashboardPanel) dashboardPanel.style.display = 'none';
-    if (structurePanel) structurePanel.style.display = 'none';
-    if (gitPanel) gitPanel.style.display = 'none';
-  }
-}
+    .qa-thresholds summary {
+      cursor: pointer;
+      list-style: none;
+    }
 
-// Check if tests tab is active
-export function isQATabActive() {
-  return state.browser.activeTabId === QA_TAB_ID;
-}
+    .qa-threshold-grid {
+      display: grid;
+      grid-template-columns: repeat(5, 1fr);
+      gap: 8px;
+      margin-top: 10px;
+    }
 
-function addTestDashboardStyles() {
-  if (document.getElementById('test-dashboard-styles')) return;
+    .qa-threshold-field {
+      display: flex;
+      flex-direction: column;
+      gap: 4px;
+      font-size: 12px;
+      color: #94a3b8;
+    }
 
-  const style = document.createElement('style');
-  style.id = 'test-dashboard-styles';
-  style.textContent = `
-    /* QA Dashboard Container */
-    .qa-dashboard {
-      padding: 20px;
+    .qa-threshold-field input {
+      padding: 6px 8px;
       background: #0f172a;
+      border: 1px solid #334155;
+      border-radius: 6px;
       color: #e2e8f0;
-      overflow-y: auto;
-      height: 100%;
     }
 
-    .qa-header {
+    .qa-threshold-block {
       display: flex;
-      justify-content: space-between;
       align-items: center;
-      margin-bottom: 20px;
-    }
-
-    .qa-header h2 {
-      margin: 0;
-      font-size: 18px;
-      font-weight: 600;
-      color: #f1f5f9;
+      gap: 6px;
+      margin-right: auto;
+      font-size: 12px;
+      color: #94a3b8;
     }
 
-    .qa-scan-btn {
-      padding: 8px 16px;
+    .qa-notify-select {
+      padding: 8px 10px;
       background: #1e293b;
       border: 1px solid #334155;
       border-radius: 8px;
       color: #94a3b8;
       cursor: pointer;
       font-size: 13px;
-      transition: all 0.2s;
     }
 
-    .qa-scan-btn:hover {
+    .qa-watch-btn.active {
+      background: rgba(99, 102, 241, 0.15);
+      border-color: #6366f1;
+      color: #a5b4fc;
+    }
+
+    .qa-watch-status {
+      display: flex;
+      align-items: center;
+      gap: 10px;
+      padding: 10px 14px;
+      margin-bottom: 16px;
+      border-radius: 8px;
+      background: #1e293b;
+      border-left: 3px solid #6366f1;
+      font-size: 13px;
+    }
+
+    .qa-watch-status.status-running {
+      border-left-color: #eab308;
+    }
+
+    .qa-watch-status.status-passed {
+      border-left-color: #22c55e;
+    }
+
+    .qa-watch-status.status-failed,
+    .qa-watch-status.status-mixed {
+      border-left-color: #ef4444;
+    }
+
+    .qa-watch-text {
+      flex: 1;
+      min-width: 0;
+      color: #cbd5e1;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+    }
+
+    .qa-watch-error {
+      color: #ef4444;
+      font-size: 12px;
+    }
+
+    .qa-shard-list {
+      display: flex;
+      flex-direction: column;
+      gap: 6px;
+    }
+
+    .qa-shard-row {
+      display: flex;
+      align-items: center;
+      gap: 10px;
+      font-size: 12px;
+      color: #94a3b8;
+    }
+
+    .qa-shard-index {
+      width: 28px;
+      color: #cbd5e1;
+      font-weight: 600;
+    }
+
+    .qa-shard-files {
+      width: 64px;
+    }
+
+    .qa-shard-bar {
+      flex: 1;
+      height: 6px;
+      border-radius: 3px;
       background: #334155;
-      color: #f1f5f9;
+      overflow: hidden;
+    }
+
+    .qa-shard-fill {
+      height: 100%;
+      background: #eab308;
+      transition: width 0.3s;
+    }
+
+    .qa-shard-row.status-passed .qa-shard-fill {
+      background: #22c55e;
+    }
+
+    .qa-shard-row.status-failed .qa-shard-fill,
+    .qa-shard-row.status-mixed .qa-shard-fill {
+      background: #ef4444;
     }
 
     .qa-scan-btn:disabled {
@@ -1280,6 +2299,111 @@ function addTestDashboardStyles() {
       font-weight: normal;
     }
 
+    .qa-duration-trend {
+      display: flex;
+      align-items: flex-end;
+      gap: 3px;
+      height: 48px;
+      margin-bottom: 10px;
+    }
+
+    .qa-duration-bar {
+      flex: 1;
+      min-width: 4px;
+      border-radius: 2px 2px 0 0;
+      background: #6366f1;
+      opacity: 0.8;
+    }
+
+    .qa-duration-bar:hover {
+      opacity: 1;
+    }
+
+    .qa-slow-list {
+      display: flex;
+      flex-direction: column;
+      gap: 4px;
+    }
+
+    .qa-slow-item {
+      display: flex;
+      align-items: center;
+      gap: 10px;
+      padding: 6px 10px;
+      border-radius: 6px;
+      background: rgba(255, 255, 255, 0.03);
+      font-size: 12px;
+    }
+
+    .qa-slow-name {
+      flex: 1;
+      min-width: 0;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+      font-family: monospace;
+      color: #e2e8f0;
+    }
+
+    .qa-slow-duration {
+      color: #94a3b8;
+      font-variant-numeric: tabular-nums;
+    }
+
+    .qa-slow-change {
+      min-width: 44px;
+      text-align: right;
+      font-weight: 600;
+    }
+
+    .qa-slow-change.slower {
+      color: #ef4444;
+    }
+
+    .qa-slow-change.faster {
+      color: #22c55e;
+    }
+
+    .qa-flaky-list {
+      display: flex;
+      flex-direction: column;
+      gap: 6px;
+    }
+
+    .qa-flaky-item {
+      display: flex;
+      align-items: center;
+      gap: 10px;
+      padding: 8px 10px;
+      border-radius: 6px;
+      background: rgba(234, 179, 8, 0.08);
+      border-left: 3px solid #eab308;
+      font-size: 12px;
+    }
+
+    .qa-flaky-name {
+      flex: 1;
+      min-width: 0;
+      overflow: hidden;
+      text-overflow: ellipsis;
+      white-space: nowrap;
+      font-family: monospace;
+      color: #e2e8f0;
+    }
+
+    .qa-flaky-runner {
+      color: #64748b;
+    }
+
+    .qa-flaky-flips {
+      color: #eab308;
+      font-weight: 600;
+    }
+
+    .qa-flaky-counts {
+      color: #94a3b8;
+    }
+
     .qa-runs-grid {
       display: grid;
       grid-template-columns: repeat(3, 1fr);
@@ -1463,6 +2587,30 @@ export function initTestDashboard() {
         updateCoverage(data.projectPath, data.summary);
       }
     });
+
+    window.runtime.EventsOn('mutation-update', (data) => {
+      if (data && data.projectPath && data.summary) {
+        updateMutation(data.projectPath, data.summary);
+      }
+    });
+
+    window.runtime.EventsOn('coverage-threshold-warning', (data) => {
+      if (data && data.projectId && data.gate) {
+        updateCoverageWarning(data.projectId, data.gate);
+      }
+    });
+
+    window.runtime.EventsOn('test-watch-update', (result) => {
+      if (result && result.projectPath) {
+        updateTestWatch(result);
+      }
+    });
+
+    window.runtime.EventsOn('test-shards-update', (run) => {
+      if (run && run.projectPath) {
+        updateTestShards(run);
+      }
+    });
   }
 }
 
@@ -1487,6 +2635,168 @@ export function updateCoverage(projectPath, summary) {
   }
 
   updateTestDashboard();
+  loadChangeCoverage(projectPath).then(() => updateTestDashboard());
+  if (projectPath === state.activeProject?.path) {
+    loadCoverageGate().then(() => updateTestDashboard());
+  }
+}
+
+// Load the coverage of the changes on the current branch; errors (no base
+// branch, no report) just hide the section
+async function loadChangeCoverage(projectPath) {
+  try {
+    const result = await testDashboardCallbacks.getChangeCoverage(projectPath, '');
+    projectChangeCoverage.set(projectPath, result || null);
+  } catch (err) {
+    projectChangeCoverage.delete(projectPath);
+  }
+}
+
+// Load the active project's coverage thresholds and how the latest report compares
+async function loadCoverageGate() {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  try {
+    const [thresholds, gate] = await Promise.all([
+      testDashboardCallbacks.getCoverageThresholds(projectId),
+      testDashboardCallbacks.getCoverageGate(projectId)
+    ]);
+    projectCoverageGates.set(projectId, { thresholds: thresholds || null, gate: gate || null });
+  } catch (err) {
+    console.error('Failed to load coverage thresholds:', err);
+  }
+}
+
+// Save the thresholds from the form; all zero removes them
+async function saveCoverageThresholds() {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  const value = id => parseFloat(document.getElementById(id)?.value) || 0;
+  const thresholds = {
+    lines: value('qa-threshold-lines'),
+    statements: value('qa-threshold-statements'),
+    functions: value('qa-threshold-functions'),
+    branches: value('qa-threshold-branches'),
+    maxDrop: value('qa-threshold-drop'),
+    blockReady: !!document.getElementById('qa-threshold-block')?.checked
+  };
+  const empty = !thresholds.lines && !thresholds.statements && !thresholds.functions && !thresholds.branches && !thresholds.maxDrop;
+
+  try {
+    await testDashboardCallbacks.setCoverageThresholds(projectId, empty ? null : thresholds);
+    await loadCoverageGate();
+  } catch (err) {
+    console.error('Failed to save coverage thresholds:', err);
+    alert(`Could not save thresholds: ${err}`);
+  }
+  updateTestDashboard();
+}
+
+// Load how many test runs the active project keeps
+async function loadHistoryLimit() {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  try {
+    projectHistoryLimit.set(projectId, await testDashboardCallbacks.getTestHistoryLimit(projectId) || 20);
+  } catch (err) {
+    console.error('Failed to load test history limit:', err);
+  }
+}
+
+async function setHistoryLimit(limit) {
+  const projectId = state.activeProject?.id;
+  if (!projectId) return;
+
+  try {
+    await testDashboardCallbacks.setTestHistoryLimit(projectId, limit);
+    projectHistoryLimit.set(projectId, limit);
+    const history = getTestRunHistory();
+    if (history.length > limit) {
+      projectTestHistory.set(projectId, history.slice(0, limit));
+    }
+  } catch (err) {
+    console.error('Failed to save test history limit:', err);
+  }
+  updateTestDashboard();
+}
+
+// Save the test runs and coverage history to a CSV or JSON file
+async function exportHistory(format) {
+  const projectId = state.activeProject?.id;
+  if (!projectId || !format) return;
+
+  try {
+    await testDashboardCallbacks.saveTestHistoryExport(projectId, format);
+  } catch (err) {
+    console.error('Failed to export test history:', err);
+    alert(`Export failed: ${err}`);
+  }
+}
+
+// Load the active project's latest mutation score
+async function loadMutation() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath || projectMutation.has(projectPath)) return;
+
+  try {
+    const summary = await testDashboardCallbacks.getMutationSummary(projectPath);
+    if (summary) projectMutation.set(projectPath, { summary, previousScore: null });
+  } catch (err) {
+    console.error('Failed to load mutation score:', err);
+  }
+}
+
+async function toggleMutationRun() {
+  const projectPath = state.activeProject?.path;
+  if (!projectPath) return;
+
+  try {
+    if (projectMutation.get(projectPath)?.summary?.running) {
+      await testDashboardCallbacks.stopMutationTests(projectPath);
+    } else {
+      await testDashboardCallbacks.runMutationTests(projectPath);
+    }
+  } catch (err) {
+    console.error('Failed to run mutation tests:', err);
+    alert(`Mutation testing unavailable: ${err}`);
+  }
+}
+
+// Update mutation run progress and results from backend event
+export function updateMutation(projectPath, summary) {
+  const current = projectMutation.get(projectPath);
+  let previousScore = current?.previousScore ?? null;
+  // A new finished result moves the old score into the comparison
+  if (!summary.running && !summary.error && current?.summary?.total > 0 && current.summary.lastUpdated !== summary.lastUpdated) {
+    previousScore = current.summary.score;
+  }
+  projectMutation.set(projectPath, { summary, previousScore });
+  updateTestDashboard();
+}
+
+// Open a surviving mutant's file at its line in the editor
+async function openMutant(index) {
+  const mutant = shownMutants[index];
+  if (!mutant || !state.activeProject?.path) return;
+
+  try {
+    await testDashboardCallbacks.openTestFailure(state.activeProject.path, { file: mutant.file, line: mutant.line, column: mutant.column || 0 });
+  } catch (err) {
+    console.error('Failed to open mutant:', err);
+    alert(`Could not open ${mutant.file}: ${err}`);
+  }
+}
+
+// Mark a project whose new coverage report broke its thresholds
+export function updateCoverageWarning(projectId, gate) {
+  const current = projectCoverageGates.get(projectId) || { thresholds: null, gate: null };
+  projectCoverageGates.set(projectId, { ...current, gate });
+  if (projectId === state.activeProject?.id) {
+    loadCoverageGate().then(() => updateTestDashboard());
+  }
 }
 
 // Get coverage for the active project
@@ -10,7 +10,8 @@ let projectTodos = new Map(); // projectId -> todos[]
 // Callbacks for backend operations
 let todoDashboardCallbacks = {
   getTodos: async () => [],
-  saveTodos: async () => {}
+  saveTodos: async () => {},
+  importCodeTodos: async () => 0
 };
 
 export function setTodoDashboardCallbacks(callbacks) {
@@ -76,6 +77,23 @@ async function saveTodos() {
   }
 }
 
+// Import TODO/FIXME/HACK comments from the project's code into the list
+async function importCodeTodos() {
+  if (!state.activeProject) return;
+
+  const button = document.getElementById('todoImportCode');
+  if (button) button.disabled = true;
+  try {
+    const added = await todoDashboardCallbacks.importCodeTodos(state.activeProject.id);
+    await loadTodos();
+    const refreshed = document.getElementById('todoImportCode');
+    if (refreshed) refreshed.title = added ? `Imported ${added} code comment${added === 1 ? '' : 's'}` : 'No new code comments';
+  } catch (err) {
+    console.error('Failed to import code todos:', err);
+    if (button) button.disabled = false;
+  }
+}
+
 // Add a new todo
 function addTodo(text) {
   if (!text.trim()) return;
@@ -315,12 +333,14 @@ export function renderTodoDashboard() {
       <span class="sidebar-todo-icon">✅</span>
       <span class="sidebar-todo-title">Todo List</span>
       <span class="sidebar-todo-count">${completedCount}/${totalCount}</span>
+      <button class="sidebar-todo-btn" id="todoImportCode" title="Import TODO/FIXME/HACK comments from code">⤓</button>
     </div>
     <div class="sidebar-todo-list" id="todoList">
       ${todos.map(todo => `
         <div class="sidebar-todo-item ${todo.completed ? 'completed' : ''}" data-id="${todo.id}">
           <input type="checkbox" class="sidebar-todo-checkbox" ${todo.completed ? 'checked' : ''} data-id="${todo.id}">
-          <span class="sidebar-todo-text" data-id="${todo.id}">${escapeHtml(todo.text)}</span>
+          <span class="sidebar-todo-text" data-id="${todo.id}">${escapeHtml(todo.text)}${todo.source ? `
+            <span class="sidebar-todo-source">${escapeHtml(todo.source)}${todo.author ? ` · ${escapeHtml(todo.author)}` : ''}</span>` : ''}</span>
           <div class="sidebar-todo-actions">
             <button class="sidebar-todo-btn todo-copy" data-id="${todo.id}" title="Copy">📋</button>
             <button class="sidebar-todo-btn todo-delete" data-id="${todo.id}" title="Delete">🗑️</button>
@@ -340,6 +360,8 @@ export function renderTodoDashboard() {
 
 // Setup event listeners for todo interactions
 function setupTodoEventListeners() {
+  document.getElementById('todoImportCode')?.addEventListener('click', importCodeTodos);
+
   // Checkbox toggle
   document.querySelectorAll('.sidebar-todo-checkbox:not(.placeholder-checkbox)').forEach(checkbox => {
     checkbox.addEventListener('change', (e) => {
@@ -383,8 +405,8 @@ function setupTodoEventListeners() {
 
   // Make todo text editable
   document.querySelectorAll('.sidebar-todo-text').forEach(span => {
-    span.addEventListener('dblclick', (e) => {
-      const id = e.target.dataset.id;
+    span.addEventListener('dblclick', () => {
+      const id = span.dataset.id;
       const todos = getCurrentTodos();
       const todo = todos.find(t => t.id === id);
       if (!todo) return;
@@ -412,7 +434,7 @@ function setupTodoEventListeners() {
         }
       });
 
-      e.target.replaceWith(input);
+      span.replaceWith(input);
       input.focus();
       input.select();
     });
@@ -486,6 +508,13 @@ function addTodoDashboardStyles() {
       flex: 1;
     }
 
+    .sidebar-todo-source {
+      display: block;
+      font-size: 10px;
+      color: #64748b;
+      font-family: monospace;
+    }
+
     .sidebar-todo-count {
       font-size: 11px;
       color: #64748b;
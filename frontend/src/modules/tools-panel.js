@@ -10,14 +10,37 @@ import {
   GetGlobalAgents,
   GetAgentContent,
   SaveAgentContent,
+  ValidateAgentContent,
+  CreateAgent,
+  GetProjectOutputStyles,
+  GetGlobalOutputStyles,
+  GetOutputStyleContent,
+  SaveOutputStyleContent,
+  GetTemplateOutputStyles,
+  InstallOutputStyle,
+  GetProjectRules,
+  GetGlobalRules,
+  GetRuleContent,
+  SaveRuleContent,
+  CreateRule,
+  DeleteRule,
   GetAvailableSkills,
   GetInstalledSkills,
   InstallSkill,
+  UninstallSkill,
+  UpdateSkill,
+  GetSkillUpgradeDiff,
+  GetMarketplaces,
+  AddMarketplace,
+  RemoveMarketplace,
   GetProjectHooks,
   GetProjectHooksDetailed,
   InstallHook,
   AddHook,
   AddHookEntry,
+  GetProjectPermissions,
+  AddPermissionRule,
+  RemovePermissionRule,
   RemoveHook,
   GetHookScriptContent,
   GetProjectHookScripts,
@@ -25,18 +48,54 @@ import {
   DeleteHookScript,
   InstallTemplateHook,
   CheckLibraryStatus,
-  GetClaudemd,
-  SaveClaudemd,
+  GetClaudemdHierarchy,
+  GetClaudemdFile,
+  SaveClaudemdFile,
+  GetMemoryFiles,
+  GetMemoryContent,
+  SaveMemoryContent,
+  GetMemoryChanges,
+  AcknowledgeMemoryChanges,
+  GetResumableSessions,
+  GetCheckpoints,
+  RestoreCheckpoint,
+  GetBackgroundTasks,
+  KillBackgroundTask,
+  ResumeClaudeSession,
+  IsHookLoggingEnabled,
+  SetHookLogging,
+  GetStatusLine,
+  SetStatusLine,
+  TestStatusLine,
+  GetHookLog,
+  ClearHookLog,
+  SampleHookPayload,
+  TestHook,
   GetProjectCommands,
   GetGlobalCommands,
   GetCommandContent,
   SaveCommandContent,
   CreateCommand,
   DeleteCommand,
+  PreviewCommand,
   GetProjectMCPServers,
   GetUserMCPServers,
   AddMCPServer,
+  AddUserMCPServer,
+  UpdateUserMCPServer,
+  RemoveUserMCPServer,
   RemoveMCPServer,
+  TestMCPServer,
+  GetMCPSecrets,
+  SetMCPSecret,
+  DeleteMCPSecret,
+  ResolveMCPSecrets,
+  SecureMCPEnv,
+  GetProfiles,
+  CreateProfileFromProject,
+  RenameProfile,
+  DeleteProfile,
+  ApplyProfile,
   // Template repo methods
   GetTemplateRepoPath,
   GetTemplateAgents,
@@ -80,10 +139,16 @@ export const toolsState = {
   libs: [],
   skills: [],
   hooks: [],
+  hookLog: [],
   commands: [],
   mcpServers: [],
+  outputStyles: [],
+  rules: [],
+  profiles: [],
   claudemd: '', // CLAUDE.md content
   claudemdDirty: false, // unsaved changes flag
+  claudemdFiles: [], // CLAUDE.md hierarchy (global, project, nested)
+  claudemdPath: null, // file currently open in the editor
   claudemdPreview: false, // preview mode toggle
   // Prompts state
   prompts: [],           // project prompts
@@ -183,6 +248,9 @@ export function setupToolsPanel() {
   renderToolsPanel();
 }
 
+// Matches ${secret:NAME} references to keychain secrets in MCP configs
+const SECRET_PLACEHOLDER = /\$\{secret:[A-Za-z0-9_.-]+\}/;
+
 // Tab icons mapping for status bar
 const TAB_ICONS = {
   prompts: '💬',
@@ -191,6 +259,8 @@ const TAB_ICONS = {
   skills: '⚡',
   hooks: '🪝',
   mcp: '🔌',
+  rules: '📏',
+  profiles: '🧰',
   libs: '📦',
   claudemd: '📄'
 };
@@ -202,6 +272,8 @@ const TAB_LABELS = {
   skills: 'Skills',
   hooks: 'Hooks',
   mcp: 'MCP',
+  rules: 'Rules',
+  profiles: 'Profiles',
   libs: 'Libs',
   claudemd: 'CLAUDE.MD'
 };
@@ -374,6 +446,12 @@ export function renderToolsPanel() {
     case 'mcp':
       renderMcpTab();
       break;
+    case 'rules':
+      renderRulesTab();
+      break;
+    case 'profiles':
+      renderProfilesTab();
+      break;
     case 'claudemd':
       renderClaudemdTab();
       break;
@@ -485,6 +563,9 @@ async function renderPromptsTab() {
           <input type="checkbox" id="promptAutoSubmit" ${toolsState.promptAutoSubmit ? 'checked' : ''} />
           Auto Submit
         </label>
+        <button class="prompt-category-btn resume-session-btn" title="Resume a previous Claude session">↺ Resume</button>
+        <button class="prompt-category-btn checkpoints-btn" title="Roll back files edited by Claude">⏪ Checkpoints</button>
+        <button class="prompt-category-btn bg-tasks-btn" title="Background shells started by Claude">⚙ Background</button>
       </div>
     `;
 
@@ -561,7 +642,214 @@ function renderPromptItem(prompt, maxUsage, totalPrompts) {
   `;
 }
 
+// Show past Claude sessions of the project and resume one in a new terminal
+async function showResumeSessionsModal() {
+  if (!state.activeProject) return;
+  const projectId = state.activeProject.id;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const sessions = await GetResumableSessions(state.activeProject.path);
+    const projectPath = state.activeProject.path;
+
+    title.textContent = 'Resume Claude Session';
+    body.innerHTML = sessions.length === 0 ? `
+      <div class="tools-empty-state">
+        <div class="empty-icon">↺</div>
+        <p>No previous Claude sessions for this project</p>
+      </div>
+    ` : sessions.map(s => `
+      <div class="tools-item">
+        <div class="tools-item-info">
+          <span class="tools-item-status">💬</span>
+          <div class="tools-item-details">
+            <span class="tools-item-name">${escapeHtml(s.summary || s.firstPrompt)}</span>
+            <span class="tools-item-description">
+              ${new Date(s.updatedAt).toLocaleString()} · ${s.messageCount} messages
+              ${s.gitBranch ? ` · ${escapeHtml(s.gitBranch)}` : ''}
+              ${s.workDir !== projectPath ? ` · ${escapeHtml(s.workDir.slice(projectPath.length + 1))}` : ''}
+            </span>
+            ${s.summary ? `<span class="tools-item-description">${escapeHtml(s.firstPrompt)}</span>` : ''}
+          </div>
+        </div>
+        <div class="tools-item-actions">
+          <button class="tools-item-btn primary resume-btn" data-id="${s.id}">Resume</button>
+        </div>
+      </div>
+    `).join('');
+    footer.innerHTML = `
+      <button id="closeResumeBtn" class="secondary-btn">Close</button>
+    `;
+
+    footer.querySelector('#closeResumeBtn')?.addEventListener('click', closeToolsModal);
+    body.querySelectorAll('.resume-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        try {
+          await ResumeClaudeSession(projectId, btn.dataset.id);
+          closeToolsModal();
+        } catch (err) {
+          logger.error('Failed to resume session', { error: err.message || String(err) });
+          alert('Failed to resume session: ' + err);
+        }
+      });
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load sessions', { error: err.message || String(err) });
+    alert('Failed to load sessions: ' + err);
+  }
+}
+
+// Show Claude's checkpoints for the project and restore files to one of them
+async function showCheckpointsModal() {
+  if (!state.activeProject) return;
+  const projectPath = state.activeProject.path;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const checkpoints = await GetCheckpoints(projectPath);
+
+    title.textContent = 'Claude Checkpoints';
+    body.innerHTML = checkpoints.length === 0 ? `
+      <div class="tools-empty-state">
+        <div class="empty-icon">⏪</div>
+        <p>No checkpoints for this project</p>
+      </div>
+    ` : checkpoints.map((cp, i) => `
+      <div class="tools-item">
+        <div class="tools-item-info">
+          <span class="tools-item-status">⏪</span>
+          <div class="tools-item-details">
+            <span class="tools-item-name">${escapeHtml(cp.prompt)}</span>
+            <span class="tools-item-description">
+              ${new Date(cp.timestamp).toLocaleString()} · session ${escapeHtml(cp.sessionId.slice(0, 8))}
+              · ${cp.files.length === 0 ? 'no file changes' : `${cp.files.length} file${cp.files.length === 1 ? '' : 's'}`}
+            </span>
+            ${cp.files.length > 0 ? `
+              <span class="tools-item-description">
+                ${cp.files.map(f => `${f.action === 'delete' ? '−' : '↺'} ${escapeHtml(f.path)}`).join('<br />')}
+              </span>
+            ` : ''}
+          </div>
+        </div>
+        <div class="tools-item-actions">
+          <button class="tools-item-btn restore-checkpoint-btn" data-index="${i}" ${cp.files.length === 0 ? 'disabled' : ''}>Restore</button>
+        </div>
+      </div>
+    `).join('');
+    footer.innerHTML = `
+      <button id="closeCheckpointsBtn" class="secondary-btn">Close</button>
+    `;
+
+    footer.querySelector('#closeCheckpointsBtn')?.addEventListener('click', closeToolsModal);
+    body.querySelectorAll('.restore-checkpoint-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        const cp = checkpoints[parseInt(btn.dataset.index, 10)];
+        const list = cp.files.map(f => `• ${f.path}${f.action === 'delete' ? ' (deleted)' : ''}`).join('\n');
+        if (!confirm(`Restore files to before "${cp.prompt}"?\n\n${list}\n\nThe conversation itself is not rewound.`)) return;
+        try {
+          await RestoreCheckpoint(projectPath, cp.sessionId, cp.id);
+          showCheckpointsModal();
+        } catch (err) {
+          logger.error('Failed to restore checkpoint', { error: err.message || String(err) });
+          alert('Failed to restore checkpoint: ' + err);
+        }
+      });
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load checkpoints', { error: err.message || String(err) });
+    alert('Failed to load checkpoints: ' + err);
+  }
+}
+
+// Show the background shells Claude started and stop runaway ones
+async function showBackgroundTasksModal() {
+  if (!state.activeProject) return;
+  const projectPath = state.activeProject.path;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const tasks = await GetBackgroundTasks(projectPath);
+
+    title.textContent = 'Background Tasks';
+    body.innerHTML = tasks.length === 0 ? `
+      <div class="tools-empty-state">
+        <div class="empty-icon">⚙</div>
+        <p>No background tasks in recent Claude sessions</p>
+      </div>
+    ` : tasks.map((t, i) => `
+      <div class="tools-item bg-task-item">
+        <div class="tools-item-info">
+          <span class="tools-item-status">${t.status === 'running' ? '🟢' : t.status === 'failed' ? '🔴' : '⚪'}</span>
+          <div class="tools-item-details">
+            <span class="tools-item-name">${escapeHtml(t.description || t.command)}</span>
+            <span class="tools-item-description">
+              ${escapeHtml(t.status)}${t.exitCode != null ? ` (exit ${t.exitCode})` : ''}
+              · ${t.startedAt ? new Date(t.startedAt).toLocaleString() : ''} · ${escapeHtml(t.id)} · session ${escapeHtml(t.sessionId.slice(0, 8))}
+            </span>
+            ${t.description ? `<code class="tools-item-description">${escapeHtml(t.command)}</code>` : ''}
+            ${t.outputTail ? `<pre class="hook-invocation-block">${escapeHtml(t.outputTail)}</pre>` : ''}
+          </div>
+        </div>
+        <div class="tools-item-actions">
+          ${t.status === 'running' ? `<button class="tools-item-btn kill-bg-task-btn" data-index="${i}">Kill</button>` : ''}
+        </div>
+      </div>
+    `).join('');
+    footer.innerHTML = `
+      <button id="refreshBgTasksBtn" class="secondary-btn">↻ Refresh</button>
+      <button id="closeBgTasksBtn" class="primary-btn">Close</button>
+    `;
+
+    footer.querySelector('#refreshBgTasksBtn')?.addEventListener('click', showBackgroundTasksModal);
+    footer.querySelector('#closeBgTasksBtn')?.addEventListener('click', closeToolsModal);
+    body.querySelectorAll('.kill-bg-task-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        const task = tasks[parseInt(btn.dataset.index, 10)];
+        if (!confirm(`Kill background task "${task.command}"?`)) return;
+        try {
+          await KillBackgroundTask(projectPath, task.sessionId, task.id);
+          showBackgroundTasksModal();
+        } catch (err) {
+          logger.error('Failed to kill background task', { error: err.message || String(err) });
+          alert('Failed to kill background task: ' + err);
+        }
+      });
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load background tasks', { error: err.message || String(err) });
+    alert('Failed to load background tasks: ' + err);
+  }
+}
+
 function setupPromptEventHandlers(container) {
+  container.querySelector('.resume-session-btn')?.addEventListener('click', showResumeSessionsModal);
+  container.querySelector('.checkpoints-btn')?.addEventListener('click', showCheckpointsModal);
+  container.querySelector('.bg-tasks-btn')?.addEventListener('click', showBackgroundTasksModal);
+
   // Category filter buttons
   container.querySelectorAll('.prompt-category-btn:not(.add-category-btn)').forEach(btn => {
     btn.addEventListener('click', () => {
@@ -978,7 +1266,20 @@ async function renderAgentsTab() {
     const templateAgents = await GetTemplateAgents();
     const availableTemplates = templateAgents.filter(t => !installedNames.has(t.name));
 
-    let html = '';
+    // Load output styles (project, global and templates)
+    const projectStyles = await GetProjectOutputStyles(state.activeProject.path);
+    const globalStyles = await GetGlobalOutputStyles();
+    toolsState.outputStyles = [...projectStyles, ...globalStyles];
+    const projectStyleFiles = new Set(projectStyles.map(s => s.path.split('/').pop()));
+    const templateStyles = (await GetTemplateOutputStyles())
+      .filter(t => !projectStyleFiles.has(t.path.split('/').pop()));
+
+    let html = `
+      <div class="tools-header-row">
+        <span class="tools-header-title">Agents</span>
+        <button class="tools-item-btn create-agent-btn">+ New</button>
+      </div>
+    `;
 
     // Installed agents section
     if (toolsState.agents.length > 0) {
@@ -1026,8 +1327,49 @@ async function renderAgentsTab() {
       `;
     }
 
-    if (!html) {
-      html = `
+    // Output styles section
+    if (toolsState.outputStyles.length > 0 || templateStyles.length > 0) {
+      html += `
+        <div class="tools-section-header">Output Styles</div>
+        ${toolsState.outputStyles.map(style => `
+          <div class="tools-item">
+            <div class="tools-item-info">
+              <span class="tools-item-status">🎨</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${escapeHtml(style.name)}</span>
+                <span class="tools-item-description">${escapeHtml(style.description || 'No description')}</span>
+              </div>
+              ${style.isGlobal ? '<span class="tools-item-badge global">Global</span>' : '<span class="tools-item-badge">Project</span>'}
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn view-style-btn" data-path="${style.path}">View</button>
+              <button class="tools-item-btn edit-style-btn" data-path="${style.path}">Edit</button>
+              ${style.isGlobal && !projectStyleFiles.has(style.path.split('/').pop())
+                ? `<button class="tools-item-btn copy-style-btn" data-path="${style.path}" title="Copy to project">→ Project</button>` : ''}
+            </div>
+          </div>
+        `).join('')}
+        ${templateStyles.map(template => `
+          <div class="tools-item template-item">
+            <div class="tools-item-info">
+              <span class="tools-item-status">📋</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${escapeHtml(template.name)}</span>
+                <span class="tools-item-description">${escapeHtml(template.description || 'No description')}</span>
+              </div>
+              <span class="tools-item-badge template">Template</span>
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn preview-template-btn" data-path="${template.path}" data-name="${escapeHtml(template.name)}" data-type="output-style">Preview</button>
+              <button class="tools-item-btn install-template-btn primary" data-path="${template.path}" data-type="output-style">Install</button>
+            </div>
+          </div>
+        `).join('')}
+      `;
+    }
+
+    if (toolsState.agents.length === 0 && availableTemplates.length === 0 && toolsState.outputStyles.length === 0) {
+      html += `
         <div class="tools-empty-state">
           <div class="empty-icon">🤖</div>
           <p>No agents available</p>
@@ -1040,6 +1382,8 @@ async function renderAgentsTab() {
 
     container.innerHTML = html;
 
+    container.querySelector('.create-agent-btn')?.addEventListener('click', showCreateAgentModal);
+
     // Add click handlers for installed agents
     container.querySelectorAll('.view-agent-btn').forEach(btn => {
       btn.addEventListener('click', () => viewAgent(btn.dataset.path));
@@ -1048,9 +1392,20 @@ async function renderAgentsTab() {
       btn.addEventListener('click', () => editAgent(btn.dataset.path));
     });
 
+    // Add click handlers for output styles
+    container.querySelectorAll('.view-style-btn').forEach(btn => {
+      btn.addEventListener('click', () => showOutputStyle(btn.dataset.path, false));
+    });
+    container.querySelectorAll('.edit-style-btn').forEach(btn => {
+      btn.addEventListener('click', () => showOutputStyle(btn.dataset.path, true));
+    });
+    container.querySelectorAll('.copy-style-btn').forEach(btn => {
+      btn.addEventListener('click', () => installTemplate(btn.dataset.path, 'output-style'));
+    });
+
     // Add click handlers for templates
     container.querySelectorAll('.preview-template-btn').forEach(btn => {
-      btn.addEventListener('click', () => previewTemplate(btn.dataset.path, btn.dataset.name, 'agent'));
+      btn.addEventListener('click', () => previewTemplate(btn.dataset.path, btn.dataset.name, btn.dataset.type || 'agent'));
     });
     container.querySelectorAll('.install-template-btn').forEach(btn => {
       btn.addEventListener('click', () => installTemplate(btn.dataset.path, btn.dataset.type));
@@ -1100,6 +1455,49 @@ async function previewTemplate(path, name, type) {
   }
 }
 
+// View or edit an output style
+async function showOutputStyle(path, editable) {
+  try {
+    const content = await GetOutputStyleContent(path);
+    const style = toolsState.outputStyles.find(s => s.path === path);
+
+    const modal = document.getElementById('toolsModal');
+    const title = document.getElementById('toolsModalTitle');
+    const body = document.getElementById('toolsModalBody');
+    const footer = document.getElementById('toolsModalFooter');
+
+    if (!modal || !title || !body || !footer) return;
+
+    title.textContent = `${editable ? 'Edit' : 'View'}: ${style?.name || 'Output Style'}`;
+    body.innerHTML = `
+      <textarea class="tools-editor" id="outputStyleEditor" ${editable ? '' : 'readonly'}>${escapeHtml(content)}</textarea>
+    `;
+    footer.innerHTML = editable ? `
+      <button id="cancelStyleBtn" class="secondary-btn">Cancel</button>
+      <button id="saveStyleBtn" class="primary-btn">Save</button>
+    ` : `
+      <button id="cancelStyleBtn" class="primary-btn">Close</button>
+    `;
+
+    footer.querySelector('#cancelStyleBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#saveStyleBtn')?.addEventListener('click', async () => {
+      try {
+        await SaveOutputStyleContent(path, document.getElementById('outputStyleEditor').value);
+        closeToolsModal();
+        renderAgentsTab();
+      } catch (err) {
+        logger.error('Failed to save output style', { error: err.message || String(err) });
+        alert('Failed to save output style: ' + err);
+      }
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load output style', { error: err.message || String(err) });
+    alert('Failed to load output style');
+  }
+}
+
 // Install template to project
 async function installTemplate(templatePath, type) {
   if (!state.activeProject) {
@@ -1123,7 +1521,11 @@ async function installTemplate(templatePath, type) {
         break;
       case 'rule':
         await InstallTemplateRule(state.activeProject.path, templatePath);
-        // Rules don't have a dedicated tab, maybe refresh current
+        renderRulesTab();
+        break;
+      case 'output-style':
+        await InstallOutputStyle(state.activeProject.path, templatePath, false);
+        renderAgentsTab();
         break;
     }
   } catch (err) {
@@ -1256,7 +1658,7 @@ async function renderSkillsTab() {
   try {
     // Load skills (directory-based)
     const installedSkills = await GetInstalledSkills(state.activeProject.path);
-    const installedSkillSet = new Set(installedSkills);
+    const installedSkillSet = new Set(installedSkills.map(s => s.name));
 
     // Load commands (file-based, now part of skills)
     const projectCommands = await GetProjectCommands(state.activeProject.path);
@@ -1267,19 +1669,25 @@ async function renderSkillsTab() {
     const templateSkills = await GetTemplateSkills();
     const templateCommands = await GetTemplateCommands();
     const allInstalledNames = new Set([
-      ...installedSkills,
+      ...installedSkillSet,
       ...toolsState.commands.map(c => c.name),
     ]);
     const availableSkillTemplates = templateSkills.filter(t => !allInstalledNames.has(t.name));
     const availableCommandTemplates = templateCommands.filter(t => !allInstalledNames.has(t.name));
 
+    // Load marketplace skills (from every installed marketplace)
+    const marketplaceSkills = (await GetAvailableSkills()).filter(s => !installedSkillSet.has(s.name));
+
     let html = '';
 
     // Header with create button
     html += `
       <div class="tools-header-row">
         <span class="tools-header-title">Skills & Commands</span>
-        <button class="tools-item-btn create-command-btn">+ New</button>
+        <div class="tools-item-actions">
+          <button class="tools-item-btn marketplaces-btn">Marketplaces</button>
+          <button class="tools-item-btn create-command-btn">+ New</button>
+        </div>
       </div>
     `;
 
@@ -1287,16 +1695,22 @@ async function renderSkillsTab() {
     if (installedSkills.length > 0) {
       html += `
         <div class="tools-section-header">Skills</div>
-        ${installedSkills.map(skillName => `
-          <div class="tools-item" data-skill="${skillName}">
+        ${installedSkills.map(skill => `
+          <div class="tools-item" data-skill="${skill.name}">
             <div class="tools-item-info">
               <span class="tools-item-status">⚡</span>
               <div class="tools-item-details">
-                <span class="tools-item-name">/${skillName}</span>
-                <span class="tools-item-description">Installed skill</span>
+                <span class="tools-item-name">/${skill.name}</span>
+                <span class="tools-item-description">${skill.source ? `Installed from ${escapeHtml(skill.marketplace || 'template repository')}` : 'Installed skill'}</span>
               </div>
+              ${skill.updateAvailable ? '<span class="tools-item-badge skill-update-badge">Update available</span>' : ''}
+              ${skill.locallyModified ? '<span class="tools-item-badge skill-modified-badge">Locally modified</span>' : ''}
               <span class="tools-item-badge installed">Skill</span>
             </div>
+            <div class="tools-item-actions">
+              ${skill.source ? `<button class="tools-item-btn update-skill-btn" data-name="${skill.name}">Update</button>` : ''}
+              <button class="tools-item-btn uninstall-skill-btn" data-name="${skill.name}">🗑️</button>
+            </div>
           </div>
         `).join('')}
       `;
@@ -1318,6 +1732,7 @@ async function renderSkillsTab() {
             </div>
             <div class="tools-item-actions">
               <button class="tools-item-btn view-command-btn" data-path="${cmd.path}">View</button>
+              <button class="tools-item-btn preview-command-btn" data-path="${cmd.path}">Preview</button>
               <button class="tools-item-btn edit-command-btn" data-path="${cmd.path}">Edit</button>
               ${!cmd.isGlobal ? `<button class="tools-item-btn delete-command-btn" data-path="${cmd.path}">🗑️</button>` : ''}
             </div>
@@ -1353,7 +1768,29 @@ async function renderSkillsTab() {
       `;
     }
 
-    if (installedSkills.length === 0 && toolsState.commands.length === 0 && allTemplates.length === 0) {
+    // Available marketplace skills section
+    if (marketplaceSkills.length > 0) {
+      html += `
+        <div class="tools-section-header">Available from Marketplaces</div>
+        ${marketplaceSkills.map(skill => `
+          <div class="tools-item">
+            <div class="tools-item-info">
+              <span class="tools-item-status">🧩</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">/${escapeHtml(skill.name)}</span>
+                <span class="tools-item-description">${escapeHtml(skill.description || 'No description')}</span>
+              </div>
+              <span class="tools-item-badge template" title="Marketplace">${escapeHtml(skill.marketplace)}</span>
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn install-skill-btn primary" data-name="${escapeHtml(skill.name)}" data-marketplace="${escapeHtml(skill.marketplace)}">Install</button>
+            </div>
+          </div>
+        `).join('')}
+      `;
+    }
+
+    if (installedSkills.length === 0 && toolsState.commands.length === 0 && allTemplates.length === 0 && marketplaceSkills.length === 0) {
       html = `
         <div class="tools-empty-state">
           <div class="empty-icon">⚡</div>
@@ -1369,12 +1806,25 @@ async function renderSkillsTab() {
     container.querySelectorAll('.create-command-btn').forEach(btn => {
       btn.addEventListener('click', () => showCreateCommandModal());
     });
+    container.querySelector('.marketplaces-btn')?.addEventListener('click', showMarketplacesModal);
+    container.querySelectorAll('.install-skill-btn').forEach(btn => {
+      btn.addEventListener('click', () => doInstallSkill(btn.dataset.name, btn.dataset.marketplace));
+    });
+    container.querySelectorAll('.update-skill-btn').forEach(btn => {
+      btn.addEventListener('click', () => showSkillUpgradeModal(btn.dataset.name));
+    });
+    container.querySelectorAll('.uninstall-skill-btn').forEach(btn => {
+      btn.addEventListener('click', () => doUninstallSkill(btn.dataset.name));
+    });
     container.querySelectorAll('.view-command-btn').forEach(btn => {
       btn.addEventListener('click', () => viewCommand(btn.dataset.path));
     });
     container.querySelectorAll('.edit-command-btn').forEach(btn => {
       btn.addEventListener('click', () => editCommand(btn.dataset.path));
     });
+    container.querySelectorAll('.preview-command-btn').forEach(btn => {
+      btn.addEventListener('click', () => previewCommand(btn.dataset.path));
+    });
     container.querySelectorAll('.delete-command-btn').forEach(btn => {
       btn.addEventListener('click', () => deleteCommandConfirm(btn.dataset.path));
     });
@@ -1397,6 +1847,60 @@ async function renderSkillsTab() {
   }
 }
 
+// Show the payload, output and outcome of one recorded hook invocation
+function showHookInvocation(inv) {
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!inv || !modal || !title || !body || !footer) return;
+
+  let payload = inv.payload;
+  try {
+    payload = JSON.stringify(JSON.parse(inv.payload), null, 2);
+  } catch {
+    // Keep the raw payload
+  }
+
+  const outcome = inv.running ? 'Running'
+    : inv.blocked ? `Blocked${inv.reason ? `: ${inv.reason}` : ''}`
+    : `Exit code ${inv.exitCode}`;
+
+  title.textContent = `Hook: ${inv.event}${inv.matcher ? ` (${inv.matcher})` : ''}`;
+  body.innerHTML = `
+    <div class="hook-invocation">
+      <div class="hook-invocation-meta">
+        <span>${new Date(inv.startedAt).toLocaleString()}</span>
+        ${inv.running ? '' : `<span>${inv.durationMs}ms</span>`}
+        <span class="${inv.blocked ? 'hook-outcome-blocked' : ''}">${escapeHtml(outcome)}</span>
+      </div>
+      <label>Command</label>
+      <pre class="hook-invocation-block">${escapeHtml(inv.command)}</pre>
+      <label>Payload (stdin)</label>
+      <pre class="hook-invocation-block">${escapeHtml(payload)}</pre>
+      <label>Stdout</label>
+      <pre class="hook-invocation-block">${escapeHtml(inv.stdout || '(empty)')}</pre>
+      <label>Stderr</label>
+      <pre class="hook-invocation-block">${escapeHtml(inv.stderr || '(empty)')}</pre>
+    </div>
+  `;
+  footer.innerHTML = `
+    <button id="closeHookInvocationBtn" class="primary-btn">Close</button>
+  `;
+  footer.querySelector('#closeHookInvocationBtn')?.addEventListener('click', closeToolsModal);
+  modal.classList.remove('hidden');
+}
+
+const PERMISSION_ICONS = { allow: '✅', ask: '❓', deny: '⛔' };
+
+// Badge naming the settings file a hook or permission comes from
+function settingsSourceBadge(source) {
+  return source === 'local'
+    ? '<span class="tools-item-badge" title=".claude/settings.local.json (personal, not committed)">local</span>'
+    : '<span class="tools-item-badge" title=".claude/settings.json (shared)">project</span>';
+}
+
 async function renderHooksTab() {
   const container = document.getElementById('hooksList');
   if (!container) return;
@@ -1421,15 +1925,26 @@ async function renderHooksTab() {
     // Load hook scripts from .claude/hooks/ folder
     const hookScripts = await GetProjectHookScripts(state.activeProject.path);
 
+    // Permission rules from settings.json and settings.local.json
+    const permissions = await GetProjectPermissions(state.activeProject.path);
+
     toolsState.hooks = projectHooks;
 
+    // Hook activity log (only recorded while logging is enabled)
+    const hookLogging = projectHooks.length > 0 && await IsHookLoggingEnabled(state.activeProject.path);
+    const hookLog = projectHooks.length > 0 ? await GetHookLog(state.activeProject.path, 20) : [];
+    toolsState.hookLog = hookLog;
+
     let html = '';
 
     // Header with create button
     html += `
       <div class="tools-header-row">
         <span class="tools-header-title">Claude Code Hooks</span>
-        <button class="tools-item-btn create-hook-btn">+ New Hook</button>
+        <div class="tools-item-actions">
+          <button class="tools-item-btn statusline-btn">Statusline</button>
+          <button class="tools-item-btn create-hook-btn">+ New Hook</button>
+        </div>
       </div>
     `;
 
@@ -1466,6 +1981,7 @@ async function renderHooksTab() {
                     <span class="tools-item-name">${escapeHtml(hook.matcher || '*')}</span>
                     <span class="tools-item-description">${escapeHtml(hook.description || 'No description')}</span>
                   </div>
+                  ${settingsSourceBadge(hook.source)}
                 </div>
                 <div class="tools-item-actions">
                   <button class="tools-item-btn preview-hook-btn"
@@ -1475,6 +1991,12 @@ async function renderHooksTab() {
                     data-script-path="${escapeHtml(hook.scriptPath || '')}">
                     Preview
                   </button>
+                  <button class="tools-item-btn test-hook-btn"
+                    data-hook-type="${hook.eventType}"
+                    data-hook-idx="${idx}"
+                    title="Run with a sample payload">
+                    Test
+                  </button>
                   <button class="tools-item-btn delete-hook-btn"
                     data-hook-type="${hook.eventType}"
                     data-hook-matcher="${escapeHtml(hook.matcher)}">
@@ -1488,6 +2010,73 @@ async function renderHooksTab() {
       }
     }
 
+    // Hook activity section
+    if (projectHooks.length > 0) {
+      html += `
+        <div class="tools-section-header hook-log-header">
+          <span>Activity</span>
+          <label class="hook-log-toggle" title="Route the hook commands of settings.local.json through a logger that records each invocation">
+            <input type="checkbox" id="hookLoggingToggle" ${hookLogging ? 'checked' : ''} /> Record
+          </label>
+          <button class="tools-item-btn refresh-hook-log-btn" title="Refresh">↻</button>
+          ${hookLog.length > 0 ? '<button class="tools-item-btn clear-hook-log-btn">Clear</button>' : ''}
+        </div>
+        ${hookLog.length === 0 ? `
+          <div class="hook-log-empty">${hookLogging ? 'No hook invocations recorded yet' : 'Enable recording to see hook invocations'}</div>
+        ` : hookLog.map((inv, idx) => `
+          <div class="tools-item hook-log-item ${inv.blocked ? 'blocked' : ''}" data-log-idx="${idx}">
+            <div class="tools-item-info">
+              <span class="tools-item-status">${inv.running ? '⏳' : inv.blocked ? '⛔' : inv.exitCode === 0 ? '✅' : '⚠️'}</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${escapeHtml(inv.event)}${inv.toolName ? ` · ${escapeHtml(inv.toolName)}` : ''}</span>
+                <span class="tools-item-description">
+                  ${new Date(inv.startedAt).toLocaleTimeString()}
+                  ${inv.running ? ' · running' : ` · exit ${inv.exitCode} · ${inv.durationMs}ms`}
+                  ${inv.reason ? ` · ${escapeHtml(inv.reason)}` : ''}
+                </span>
+              </div>
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn view-hook-log-btn" data-log-idx="${idx}">Details</button>
+            </div>
+          </div>
+        `).join('')}
+      `;
+    }
+
+    // Permissions section
+    html += `
+      <div class="tools-section-header">Permissions</div>
+      ${permissions.map((rule, idx) => `
+        <div class="tools-item">
+          <div class="tools-item-info">
+            <span class="tools-item-status">${PERMISSION_ICONS[rule.kind] || '•'}</span>
+            <div class="tools-item-details">
+              <span class="tools-item-name">${escapeHtml(rule.rule)}</span>
+              <span class="tools-item-description">${escapeHtml(rule.kind)}</span>
+            </div>
+            ${settingsSourceBadge(rule.source)}
+          </div>
+          <div class="tools-item-actions">
+            <button class="tools-item-btn delete-permission-btn" data-idx="${idx}">🗑️</button>
+          </div>
+        </div>
+      `).join('')}
+      <div class="permission-add-row">
+        <select id="permissionKind" class="tools-select">
+          <option value="allow">Allow</option>
+          <option value="ask">Ask</option>
+          <option value="deny">Deny</option>
+        </select>
+        <input type="text" id="permissionRule" class="tools-input" placeholder="Bash(npm run test:*)" />
+        <select id="permissionSource" class="tools-select" title="Settings file">
+          <option value="project">settings.json</option>
+          <option value="local">settings.local.json</option>
+        </select>
+        <button class="tools-item-btn add-permission-btn">Add</button>
+      </div>
+    `;
+
     // Hook scripts section
     if (hookScripts.length > 0) {
       html += `
@@ -1575,10 +2164,30 @@ async function renderHooksTab() {
     container.innerHTML = html;
 
     // Add event handlers
+    container.querySelector('.statusline-btn')?.addEventListener('click', () => showStatusLineModal());
     container.querySelectorAll('.create-hook-btn').forEach(btn => {
       btn.addEventListener('click', () => showCreateHookModal());
     });
 
+    container.querySelector('#hookLoggingToggle')?.addEventListener('change', async (e) => {
+      try {
+        await SetHookLogging(state.activeProject.path, e.target.checked);
+        renderHooksTab();
+      } catch (err) {
+        logger.error('Failed to toggle hook logging', { error: err.message || String(err) });
+        alert('Failed to toggle hook logging: ' + err);
+        e.target.checked = !e.target.checked;
+      }
+    });
+    container.querySelector('.refresh-hook-log-btn')?.addEventListener('click', () => renderHooksTab());
+    container.querySelector('.clear-hook-log-btn')?.addEventListener('click', async () => {
+      await ClearHookLog(state.activeProject.path);
+      renderHooksTab();
+    });
+    container.querySelectorAll('.view-hook-log-btn').forEach(btn => {
+      btn.addEventListener('click', () => showHookInvocation(toolsState.hookLog[parseInt(btn.dataset.logIdx)]));
+    });
+
     container.querySelectorAll('.preview-hook-btn').forEach(btn => {
       btn.addEventListener('click', () => {
         const hookType = btn.dataset.hookType;
@@ -1589,6 +2198,13 @@ async function renderHooksTab() {
       });
     });
 
+    container.querySelectorAll('.test-hook-btn').forEach(btn => {
+      btn.addEventListener('click', () => {
+        const hooks = toolsState.hooks.filter(h => h.eventType === btn.dataset.hookType);
+        showTestHookModal(hooks[parseInt(btn.dataset.hookIdx)]);
+      });
+    });
+
     container.querySelectorAll('.delete-hook-btn').forEach(btn => {
       btn.addEventListener('click', () => {
         const hookType = btn.dataset.hookType;
@@ -1597,13 +2213,46 @@ async function renderHooksTab() {
       });
     });
 
-    container.querySelectorAll('.view-script-btn').forEach(btn => {
-      btn.addEventListener('click', () => viewHookScript(btn.dataset.script));
+    container.querySelectorAll('.delete-permission-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        const rule = permissions[parseInt(btn.dataset.idx)];
+        if (!confirm(`Remove ${rule.kind} rule "${rule.rule}"?`)) return;
+        try {
+          await RemovePermissionRule(state.activeProject.path, rule);
+          renderHooksTab();
+        } catch (err) {
+          logger.error('Failed to remove permission', { error: err.message || String(err) });
+          alert('Failed to remove permission: ' + err);
+        }
+      });
     });
 
-    container.querySelectorAll('.preview-template-hook-btn').forEach(btn => {
-      btn.addEventListener('click', async () => {
-        const hookType = btn.dataset.hookType;
+    container.querySelector('.add-permission-btn')?.addEventListener('click', async () => {
+      const rule = {
+        kind: document.getElementById('permissionKind').value,
+        rule: document.getElementById('permissionRule').value.trim(),
+        source: document.getElementById('permissionSource').value,
+      };
+      if (!rule.rule) {
+        alert('Please enter a permission rule');
+        return;
+      }
+      try {
+        await AddPermissionRule(state.activeProject.path, rule);
+        renderHooksTab();
+      } catch (err) {
+        logger.error('Failed to add permission', { error: err.message || String(err) });
+        alert('Failed to add permission: ' + err);
+      }
+    });
+
+    container.querySelectorAll('.view-script-btn').forEach(btn => {
+      btn.addEventListener('click', () => viewHookScript(btn.dataset.script));
+    });
+
+    container.querySelectorAll('.preview-template-hook-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        const hookType = btn.dataset.hookType;
         const hookIdx = parseInt(btn.dataset.hookIdx);
         const templates = await GetTemplateHooks();
         const grouped = {};
@@ -1643,6 +2292,168 @@ async function renderHooksTab() {
   }
 }
 
+// Run a hook's commands with an editable sample payload, as Claude would
+async function showTestHookModal(hook) {
+  if (!hook || !state.activeProject) return;
+  const projectPath = state.activeProject.path;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  const payload = await SampleHookPayload(projectPath, hook.eventType, hook.matcher);
+
+  title.textContent = `Test Hook: ${hook.eventType}${hook.matcher ? ` (${hook.matcher})` : ''}`;
+  body.innerHTML = `
+    <div class="hook-invocation">
+      <label for="hookTestPayload">Payload (stdin)</label>
+      <textarea id="hookTestPayload" class="tools-editor hook-test-payload">${escapeHtml(payload)}</textarea>
+      <div id="hookTestResults"></div>
+    </div>
+  `;
+  footer.innerHTML = `
+    <button id="closeHookTestBtn" class="secondary-btn">Close</button>
+    <button id="runHookTestBtn" class="primary-btn">▶ Run</button>
+  `;
+
+  footer.querySelector('#closeHookTestBtn')?.addEventListener('click', closeToolsModal);
+  const runBtn = footer.querySelector('#runHookTestBtn');
+  runBtn?.addEventListener('click', async () => {
+    const resultsEl = document.getElementById('hookTestResults');
+    runBtn.disabled = true;
+    runBtn.textContent = 'Running...';
+    try {
+      const results = await TestHook(projectPath, hook, document.getElementById('hookTestPayload').value);
+      resultsEl.innerHTML = results.map(r => {
+        const outcome = r.error ? `Failed to run: ${r.error}`
+          : r.timedOut ? 'Timed out'
+          : r.blocked ? `Blocked${r.reason ? `: ${r.reason}` : ''}`
+          : `Exit code ${r.exitCode}`;
+        return `
+          <div class="hook-invocation-meta">
+            <span>${r.durationMs}ms</span>
+            <span class="${r.blocked || r.timedOut || r.error ? 'hook-outcome-blocked' : ''}">${escapeHtml(outcome)}</span>
+          </div>
+          <label>Command</label>
+          <pre class="hook-invocation-block">${escapeHtml(r.command)}</pre>
+          <label>Stdout</label>
+          <pre class="hook-invocation-block">${escapeHtml(r.stdout || '(empty)')}</pre>
+          <label>Stderr</label>
+          <pre class="hook-invocation-block">${escapeHtml(r.stderr || '(empty)')}</pre>
+        `;
+      }).join('<hr />');
+    } catch (err) {
+      resultsEl.innerHTML = `<div class="hook-outcome-blocked">${escapeHtml(String(err))}</div>`;
+    } finally {
+      runBtn.disabled = false;
+      runBtn.textContent = '▶ Run';
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
+// Edit and test the statusLine setting of the project or user settings
+async function showStatusLineModal(scope = 'project') {
+  if (!state.activeProject) return;
+  const projectPath = state.activeProject.path;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  let statusLine = null;
+  try {
+    statusLine = await GetStatusLine(projectPath, scope);
+  } catch (err) {
+    logger.error('Failed to load statusline', { error: err.message || String(err) });
+    alert('Failed to load statusline: ' + err);
+    return;
+  }
+
+  title.textContent = 'Statusline';
+  body.innerHTML = `
+    <div class="command-create-form">
+      <div class="form-group">
+        <label for="statusLineScope">Settings</label>
+        <select id="statusLineScope" class="tools-select">
+          <option value="project" ${scope === 'project' ? 'selected' : ''}>Project (.claude/settings.json)</option>
+          <option value="local" ${scope === 'local' ? 'selected' : ''}>Local (.claude/settings.local.json)</option>
+          <option value="user" ${scope === 'user' ? 'selected' : ''}>User (~/.claude/settings.json)</option>
+        </select>
+      </div>
+      <div class="form-group">
+        <label for="statusLineCommand">Command</label>
+        <input type="text" id="statusLineCommand" class="tools-input" placeholder="~/.claude/statusline.sh" value="${escapeHtml(statusLine?.command || '')}" />
+        <span class="form-hint">Receives the session as JSON on stdin; the first line it prints is shown below the prompt. Leave empty to remove.</span>
+      </div>
+      <div class="form-group">
+        <label for="statusLinePadding">Padding</label>
+        <input type="number" id="statusLinePadding" class="tools-input" min="0" value="${statusLine?.padding || 0}" />
+      </div>
+      <div id="statusLineTestResult"></div>
+    </div>
+  `;
+  footer.innerHTML = `
+    <button id="cancelStatusLineBtn" class="secondary-btn">Cancel</button>
+    <button id="testStatusLineBtn" class="secondary-btn">▶ Test</button>
+    <button id="saveStatusLineBtn" class="primary-btn">Save</button>
+  `;
+
+  body.querySelector('#statusLineScope')?.addEventListener('change', (e) => showStatusLineModal(e.target.value));
+  footer.querySelector('#cancelStatusLineBtn')?.addEventListener('click', closeToolsModal);
+
+  const testBtn = footer.querySelector('#testStatusLineBtn');
+  testBtn?.addEventListener('click', async () => {
+    const resultEl = document.getElementById('statusLineTestResult');
+    testBtn.disabled = true;
+    testBtn.textContent = 'Running...';
+    try {
+      const r = await TestStatusLine(projectPath, document.getElementById('statusLineCommand').value);
+      resultEl.innerHTML = `
+        <div class="hook-invocation">
+          <div class="hook-invocation-meta">
+            <span>${r.durationMs}ms</span>
+            <span class="${r.problem ? 'hook-outcome-blocked' : ''}">${escapeHtml(r.problem ? `Blank statusline: ${r.problem}` : `Exit code ${r.exitCode}`)}</span>
+          </div>
+          <label>Displayed</label>
+          <pre class="hook-invocation-block">${escapeHtml(r.output || '(empty)')}</pre>
+          ${r.stderr ? `
+            <label>Stderr</label>
+            <pre class="hook-invocation-block">${escapeHtml(r.stderr)}</pre>
+          ` : ''}
+        </div>
+      `;
+    } catch (err) {
+      resultEl.innerHTML = `<div class="hook-outcome-blocked">${escapeHtml(String(err))}</div>`;
+    } finally {
+      testBtn.disabled = false;
+      testBtn.textContent = '▶ Test';
+    }
+  });
+
+  footer.querySelector('#saveStatusLineBtn')?.addEventListener('click', async () => {
+    const command = document.getElementById('statusLineCommand').value.trim();
+    const padding = parseInt(document.getElementById('statusLinePadding').value, 10) || 0;
+    try {
+      await SetStatusLine(projectPath, document.getElementById('statusLineScope').value,
+        command ? { type: 'command', command, padding } : null);
+      closeToolsModal();
+    } catch (err) {
+      logger.error('Failed to save statusline', { error: err.message || String(err) });
+      alert('Failed to save statusline: ' + err);
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
 // Preview installed hook content
 async function previewHook(hookType, hookIdx, isInline, scriptPath) {
   const hooks = toolsState.hooks.filter(h => h.eventType === hookType);
@@ -1821,6 +2632,13 @@ echo \"$INPUT\"
 exit 0"></textarea>
         <span class="form-hint">Inline bash script or path to script file (e.g., bash .claude/hooks/my-hook.sh)</span>
       </div>
+      <div class="form-group">
+        <label for="hookSource">Save To</label>
+        <select id="hookSource" class="tools-select">
+          <option value="project">.claude/settings.json (shared)</option>
+          <option value="local">.claude/settings.local.json (personal)</option>
+        </select>
+      </div>
     </div>
   `;
 
@@ -1848,7 +2666,8 @@ exit 0"></textarea>
         description,
         hooks: [{ type: 'command', command }],
         isInline: command.includes('\n') || command.startsWith('#!'),
-        scriptPath: ''
+        scriptPath: '',
+        source: document.getElementById('hookSource').value
       };
       await AddHookEntry(state.activeProject.path, hook);
       closeToolsModal();
@@ -1917,7 +2736,9 @@ async function editCommand(path) {
     title.textContent = `Edit: /${cmd?.name || 'Command'}`;
     body.innerHTML = `
       <textarea class="tools-editor" id="commandEditor">${escapeHtml(content)}</textarea>
+      <div class="agent-issues" id="commandIssues"></div>
     `;
+    watchCommandIssues(document.getElementById('commandEditor'));
     footer.innerHTML = `
       <button id="cancelCommandEditBtn" class="secondary-btn">Cancel</button>
       <button id="saveCommandBtn" class="primary-btn">Save</button>
@@ -1975,8 +2796,10 @@ Your command instructions here...
 
 Use $ARGUMENTS to include user arguments."></textarea>
       </div>
+      <div class="agent-issues" id="commandIssues"></div>
     </div>
   `;
+  watchCommandIssues(document.getElementById('commandContent'));
   footer.innerHTML = `
     <button id="cancelCreateCommandBtn" class="secondary-btn">Cancel</button>
     <button id="createCommandBtn" class="primary-btn">Create</button>
@@ -2005,6 +2828,75 @@ Use $ARGUMENTS to include user arguments."></textarea>
   modal.classList.remove('hidden');
 }
 
+// Preview a command with sample arguments substituted
+async function previewCommand(path) {
+  try {
+    const content = await GetCommandContent(path);
+    const cmd = toolsState.commands.find(c => c.path === path);
+
+    const modal = document.getElementById('toolsModal');
+    const title = document.getElementById('toolsModalTitle');
+    const body = document.getElementById('toolsModalBody');
+    const footer = document.getElementById('toolsModalFooter');
+
+    if (!modal || !title || !body || !footer) return;
+
+    title.textContent = `Preview: /${cmd?.name || 'Command'}`;
+    body.innerHTML = `
+      <div class="command-create-form">
+        <div class="form-group">
+          <label for="commandArgs">Sample Arguments</label>
+          <input type="text" id="commandArgs" class="tools-input" placeholder='123 "high priority"' />
+          <span class="form-hint">Fills $ARGUMENTS with the whole input and $1, $2, ... with each (quoted) argument</span>
+        </div>
+        <div class="agent-issues" id="commandIssues"></div>
+        <div class="form-group">
+          <label for="commandRendered">Prompt sent to Claude</label>
+          <textarea id="commandRendered" class="tools-editor" readonly></textarea>
+        </div>
+      </div>
+    `;
+    footer.innerHTML = `
+      <button id="closeCommandPreviewBtn" class="secondary-btn">Close</button>
+      <button id="editFromPreviewBtn" class="primary-btn">Edit</button>
+    `;
+
+    const argsInput = document.getElementById('commandArgs');
+    const update = async () => {
+      const preview = await PreviewCommand(content, argsInput.value);
+      const rendered = document.getElementById('commandRendered');
+      const issues = document.getElementById('commandIssues');
+      if (rendered) rendered.value = preview.rendered;
+      if (issues) issues.innerHTML = renderAgentIssues(preview.issues);
+    };
+    argsInput.addEventListener('input', update);
+    await update();
+
+    footer.querySelector('#closeCommandPreviewBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#editFromPreviewBtn')?.addEventListener('click', () => editCommand(path));
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to preview command', { error: err.message || String(err) });
+    alert('Failed to preview command: ' + err);
+  }
+}
+
+// Lint a command editor as the user types
+function watchCommandIssues(editor) {
+  if (!editor) return;
+  let timer = null;
+  const validate = async () => {
+    const { issues } = await PreviewCommand(editor.value, '');
+    const container = document.getElementById('commandIssues');
+    if (container) container.innerHTML = renderAgentIssues(issues);
+  };
+  editor.addEventListener('input', () => {
+    clearTimeout(timer);
+    timer = setTimeout(validate, 300);
+  });
+  if (editor.value) validate();
+}
+
 // Delete command confirmation
 async function deleteCommandConfirm(path) {
   const cmd = toolsState.commands.find(c => c.path === path);
@@ -2020,138 +2912,170 @@ async function deleteCommandConfirm(path) {
 }
 
 // ============================================
-// MCP Tab
+// Rules Tab
 // ============================================
 
-async function renderMcpTab() {
-  const container = document.getElementById('mcpList');
+async function renderRulesTab() {
+  const container = document.getElementById('rulesList');
   if (!container) return;
 
   if (!state.activeProject) {
     container.innerHTML = `
       <div class="tools-empty-state">
-        <div class="empty-icon">🔌</div>
-        <p>Select a project to view MCP servers</p>
+        <div class="empty-icon">📏</div>
+        <p>Select a project to view rules</p>
       </div>
     `;
     return;
   }
 
   try {
-    // Load installed MCP servers
-    const projectServers = await GetProjectMCPServers(state.activeProject.path);
-    const userServers = await GetUserMCPServers();
-    const markedUserServers = userServers.map(s => ({ ...s, scope: 'user' }));
-    toolsState.mcpServers = [...projectServers, ...markedUserServers];
-    const installedNames = new Set(toolsState.mcpServers.map(s => s.name));
-
-    // Load template MCP servers from repo
-    const templateServers = await GetTemplateMCPServers();
-    const availableTemplates = templateServers.filter(t => !installedNames.has(t.name));
+    const projectRules = await GetProjectRules(state.activeProject.path);
+    const globalRules = await GetGlobalRules();
+    toolsState.rules = [...projectRules, ...globalRules];
 
-    let html = '';
+    const installedFiles = new Set(projectRules.map(r => r.path.split('/').pop()));
+    const templateRules = (await GetTemplateRules())
+      .filter(t => !installedFiles.has(t.path.split('/').pop()));
 
-    // Header with add button
-    html += `
+    let html = `
       <div class="tools-header-row">
-        <span class="tools-header-title">MCP Servers</span>
-        <button class="tools-item-btn add-mcp-btn">+ Add</button>
+        <span class="tools-header-title">Rules</span>
+        <button class="tools-item-btn create-rule-btn">+ New</button>
       </div>
     `;
 
-    // Installed servers section
-    if (toolsState.mcpServers.length > 0) {
+    if (toolsState.rules.length > 0) {
       html += `
-        <div class="tools-section-header">Installed Servers</div>
-        ${toolsState.mcpServers.map(server => `
-          <div class="tools-item" data-mcp-name="${server.name}">
+        <div class="tools-section-header">Installed</div>
+        ${toolsState.rules.map(rule => `
+          <div class="tools-item">
             <div class="tools-item-info">
-              <span class="tools-item-status">${server.type === 'stdio' ? '💻' : '🌐'}</span>
+              <span class="tools-item-status">📏</span>
               <div class="tools-item-details">
-                <span class="tools-item-name">${server.name}</span>
-                <span class="tools-item-description">${server.type === 'stdio' ? server.command : server.url || 'No URL'}</span>
+                <span class="tools-item-name">${escapeHtml(rule.name)}</span>
+                <span class="tools-item-description">${escapeHtml(rule.description || 'No description')}</span>
               </div>
-              <span class="tools-item-badge ${server.scope === 'user' ? 'global' : ''}">${server.scope === 'user' ? 'User' : 'Project'}</span>
+              ${rule.isGlobal ? '<span class="tools-item-badge global">Global</span>' : '<span class="tools-item-badge">Project</span>'}
             </div>
             <div class="tools-item-actions">
-              <button class="tools-item-btn view-mcp-btn" data-name="${server.name}">View</button>
-              ${server.scope === 'project' ? `<button class="tools-item-btn delete-mcp-btn" data-name="${server.name}">🗑️</button>` : ''}
+              <button class="tools-item-btn view-rule-btn" data-path="${rule.path}">View</button>
+              <button class="tools-item-btn edit-rule-btn" data-path="${rule.path}">Edit</button>
+              <button class="tools-item-btn delete-rule-btn" data-path="${rule.path}">🗑️</button>
             </div>
           </div>
         `).join('')}
       `;
     }
 
-    // Available templates section
-    if (availableTemplates.length > 0) {
+    if (templateRules.length > 0) {
       html += `
         <div class="tools-section-header">Available from Repository</div>
-        ${availableTemplates.map(server => `
-          <div class="tools-item template-item" data-mcp-name="${server.name}">
+        ${templateRules.map(template => `
+          <div class="tools-item template-item">
             <div class="tools-item-info">
               <span class="tools-item-status">📋</span>
               <div class="tools-item-details">
-                <span class="tools-item-name">${server.name}</span>
-                <span class="tools-item-description">${server.command || server.url || 'No description'}</span>
+                <span class="tools-item-name">${escapeHtml(template.name)}</span>
+                <span class="tools-item-description">${escapeHtml(template.description || 'No description')}</span>
               </div>
               <span class="tools-item-badge template">Template</span>
             </div>
             <div class="tools-item-actions">
-              <button class="tools-item-btn view-mcp-template-btn" data-name="${server.name}">View</button>
-              <button class="tools-item-btn install-mcp-template-btn primary" data-name="${server.name}">Install</button>
+              <button class="tools-item-btn preview-template-btn" data-path="${template.path}" data-name="${escapeHtml(template.name)}">Preview</button>
+              <button class="tools-item-btn install-template-btn primary" data-path="${template.path}">Install</button>
             </div>
           </div>
         `).join('')}
       `;
     }
 
-    if (toolsState.mcpServers.length === 0 && availableTemplates.length === 0) {
+    if (toolsState.rules.length === 0 && templateRules.length === 0) {
       html = `
         <div class="tools-empty-state">
-          <div class="empty-icon">🔌</div>
-          <p>No MCP servers available</p>
-          <button class="tools-item-btn add-mcp-btn" style="margin-top:12px;">+ Add MCP Server</button>
+          <div class="empty-icon">📏</div>
+          <p>No rules in .claude/rules</p>
+          <button class="tools-item-btn create-rule-btn" style="margin-top:12px;">+ Create Rule</button>
         </div>
       `;
     }
 
     container.innerHTML = html;
 
-    // Add click handlers
-    container.querySelectorAll('.add-mcp-btn').forEach(btn => {
-      btn.addEventListener('click', () => showAddMcpModal());
+    container.querySelectorAll('.create-rule-btn').forEach(btn => {
+      btn.addEventListener('click', () => showCreateRuleModal());
     });
-    container.querySelectorAll('.view-mcp-btn').forEach(btn => {
-      btn.addEventListener('click', () => viewMcpServer(btn.dataset.name));
+    container.querySelectorAll('.view-rule-btn').forEach(btn => {
+      btn.addEventListener('click', () => showRule(btn.dataset.path, false));
     });
-    container.querySelectorAll('.delete-mcp-btn').forEach(btn => {
-      btn.addEventListener('click', () => deleteMcpServerConfirm(btn.dataset.name));
+    container.querySelectorAll('.edit-rule-btn').forEach(btn => {
+      btn.addEventListener('click', () => showRule(btn.dataset.path, true));
     });
-
-    // Template handlers
-    container.querySelectorAll('.view-mcp-template-btn').forEach(btn => {
-      btn.addEventListener('click', () => viewMcpTemplate(btn.dataset.name));
+    container.querySelectorAll('.delete-rule-btn').forEach(btn => {
+      btn.addEventListener('click', () => deleteRuleConfirm(btn.dataset.path));
     });
-    container.querySelectorAll('.install-mcp-template-btn').forEach(btn => {
-      btn.addEventListener('click', () => installMcpTemplate(btn.dataset.name));
+    container.querySelectorAll('.preview-template-btn').forEach(btn => {
+      btn.addEventListener('click', () => previewTemplate(btn.dataset.path, btn.dataset.name, 'rule'));
+    });
+    container.querySelectorAll('.install-template-btn').forEach(btn => {
+      btn.addEventListener('click', () => installTemplate(btn.dataset.path, 'rule'));
     });
   } catch (err) {
-    logger.error('Failed to load MCP servers', { error: err.message || String(err) });
+    logger.error('Failed to load rules', { error: err.message || String(err) });
     container.innerHTML = `
       <div class="tools-empty-state">
         <div class="empty-icon">⚠️</div>
-        <p>Error loading MCP servers</p>
+        <p>Error loading rules</p>
       </div>
     `;
   }
 }
 
-// View MCP template details
-async function viewMcpTemplate(name) {
-  const templateServers = await GetTemplateMCPServers();
-  const server = templateServers.find(s => s.name === name);
-  if (!server) return;
+// View or edit a rule
+async function showRule(path, editable) {
+  try {
+    const content = await GetRuleContent(path);
+    const rule = toolsState.rules.find(r => r.path === path);
+
+    const modal = document.getElementById('toolsModal');
+    const title = document.getElementById('toolsModalTitle');
+    const body = document.getElementById('toolsModalBody');
+    const footer = document.getElementById('toolsModalFooter');
+
+    if (!modal || !title || !body || !footer) return;
+
+    title.textContent = `${editable ? 'Edit' : 'View'}: ${rule?.name || 'Rule'}`;
+    body.innerHTML = `
+      <textarea class="tools-editor" id="ruleEditor" ${editable ? '' : 'readonly'}>${escapeHtml(content)}</textarea>
+    `;
+    footer.innerHTML = editable ? `
+      <button id="cancelRuleBtn" class="secondary-btn">Cancel</button>
+      <button id="saveRuleBtn" class="primary-btn">Save</button>
+    ` : `
+      <button id="cancelRuleBtn" class="primary-btn">Close</button>
+    `;
+
+    footer.querySelector('#cancelRuleBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#saveRuleBtn')?.addEventListener('click', async () => {
+      try {
+        await SaveRuleContent(path, document.getElementById('ruleEditor').value);
+        closeToolsModal();
+        renderRulesTab();
+      } catch (err) {
+        logger.error('Failed to save rule', { error: err.message || String(err) });
+        alert('Failed to save rule: ' + err);
+      }
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load rule', { error: err.message || String(err) });
+    alert('Failed to load rule');
+  }
+}
 
+// Show create rule modal
+function showCreateRuleModal() {
   const modal = document.getElementById('toolsModal');
   const title = document.getElementById('toolsModalTitle');
   const body = document.getElementById('toolsModalBody');
@@ -2159,55 +3083,525 @@ async function viewMcpTemplate(name) {
 
   if (!modal || !title || !body || !footer) return;
 
-  const envEntries = server.env ? Object.entries(server.env) : [];
-
-  title.textContent = `MCP Template: ${server.name}`;
+  title.textContent = 'Create New Rule';
   body.innerHTML = `
-    <div class="mcp-info-content">
-      <div class="mcp-info-row">
-        <span class="mcp-info-label">Name:</span>
-        <span class="mcp-info-value">${server.name}</span>
+    <div class="command-create-form">
+      <div class="form-group">
+        <label for="ruleName">Rule Name</label>
+        <input type="text" id="ruleName" placeholder="testing" class="tools-input" />
+        <span class="form-hint">Use / for nested rules, e.g. frontend/react</span>
       </div>
-      <div class="mcp-info-row">
-        <span class="mcp-info-label">Type:</span>
-        <span class="mcp-info-value">${server.type || 'stdio'}</span>
+      <div class="form-group">
+        <label for="ruleScope">Scope</label>
+        <select id="ruleScope" class="tools-input">
+          <option value="project">Project (.claude/rules)</option>
+          <option value="global">Global (~/.claude/rules)</option>
+        </select>
+      </div>
+      <div class="form-group">
+        <label for="ruleContent">Rule</label>
+        <textarea id="ruleContent" class="tools-editor" placeholder="---
+description: Testing conventions
+---
+
+# Testing
+
+- Write table-driven tests..."></textarea>
       </div>
-      ${server.command ? `
-        <div class="mcp-info-row">
-          <span class="mcp-info-label">Command:</span>
-          <span class="mcp-info-value"><code>${server.command}</code></span>
-        </div>
-      ` : ''}
-      ${server.args && server.args.length > 0 ? `
-        <div class="mcp-info-row">
-          <span class="mcp-info-label">Args:</span>
-          <span class="mcp-info-value"><code>${server.args.join(' ')}</code></span>
-        </div>
-      ` : ''}
-      ${envEntries.length > 0 ? `
-        <div class="mcp-info-row">
-          <span class="mcp-info-label">Required Env:</span>
-          <div class="mcp-info-env">
-            ${envEntries.map(([key]) => `<code>${key}</code>`).join(', ')}
-          </div>
-        </div>
-      ` : ''}
     </div>
   `;
-
   footer.innerHTML = `
-    <button id="closeMcpTemplateBtn" class="secondary-btn">Close</button>
-    <button id="installMcpTemplateBtn" class="primary-btn" data-name="${name}">Install to Project</button>
+    <button id="cancelCreateRuleBtn" class="secondary-btn">Cancel</button>
+    <button id="createRuleBtn" class="primary-btn">Create</button>
   `;
 
-  footer.querySelector('#closeMcpTemplateBtn')?.addEventListener('click', closeToolsModal);
-  footer.querySelector('#installMcpTemplateBtn')?.addEventListener('click', async (e) => {
-    await installMcpTemplate(e.target.dataset.name);
-    closeToolsModal();
-  });
-
-  modal.classList.remove('hidden');
-}
+  footer.querySelector('#cancelCreateRuleBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#createRuleBtn')?.addEventListener('click', async () => {
+    const name = document.getElementById('ruleName').value.trim();
+    const content = document.getElementById('ruleContent').value;
+    const global = document.getElementById('ruleScope').value === 'global';
+
+    if (!name) {
+      alert('Please enter a rule name');
+      return;
+    }
+
+    try {
+      await CreateRule(state.activeProject.path, name, content, global);
+      closeToolsModal();
+      renderRulesTab();
+    } catch (err) {
+      logger.error('Failed to create rule', { error: err.message || String(err) });
+      alert('Failed to create rule: ' + err);
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
+// Delete rule confirmation
+async function deleteRuleConfirm(path) {
+  const rule = toolsState.rules.find(r => r.path === path);
+  const scope = rule?.isGlobal ? 'global ' : '';
+  if (confirm(`Delete ${scope}rule "${rule?.name}"?`)) {
+    try {
+      await DeleteRule(path);
+      renderRulesTab();
+    } catch (err) {
+      logger.error('Failed to delete rule', { error: err.message || String(err) });
+      alert('Failed to delete rule: ' + err);
+    }
+  }
+}
+
+// ============================================
+// Profiles Tab
+// ============================================
+
+// Number of items of each kind in a profile, for its summary line
+function profileSummary(profile) {
+  const parts = [
+    [profile.agents?.length, 'agents'],
+    [profile.commands?.length, 'commands'],
+    [profile.hooks?.length, 'hooks'],
+    [profile.mcpServers?.length, 'MCP servers'],
+    [Object.keys(profile.settings || {}).length, 'settings'],
+  ].filter(([count]) => count > 0).map(([count, label]) => `${count} ${label}`);
+  return parts.length > 0 ? parts.join(' · ') : 'Empty';
+}
+
+async function renderProfilesTab() {
+  const container = document.getElementById('profilesList');
+  if (!container) return;
+
+  if (!state.activeProject) {
+    container.innerHTML = `
+      <div class="tools-empty-state">
+        <div class="empty-icon">🧰</div>
+        <p>Select a project to apply profiles</p>
+      </div>
+    `;
+    return;
+  }
+
+  try {
+    toolsState.profiles = await GetProfiles();
+
+    let html = `
+      <div class="tools-header-row">
+        <span class="tools-header-title">Configuration Profiles</span>
+        <button class="tools-item-btn create-profile-btn">+ From Project</button>
+      </div>
+    `;
+
+    if (toolsState.profiles.length === 0) {
+      html += `
+        <div class="tools-empty-state">
+          <div class="empty-icon">🧰</div>
+          <p>No profiles yet. Save this project's agents, commands, hooks, MCP servers and settings as a profile to apply them to other projects.</p>
+        </div>
+      `;
+    } else {
+      html += toolsState.profiles.map(profile => `
+        <div class="tools-item">
+          <div class="tools-item-info">
+            <span class="tools-item-status">🧰</span>
+            <div class="tools-item-details">
+              <span class="tools-item-name">${escapeHtml(profile.name)}</span>
+              <span class="tools-item-description">${escapeHtml(profile.description || profileSummary(profile))}</span>
+            </div>
+          </div>
+          <div class="tools-item-actions">
+            <button class="tools-item-btn view-profile-btn" data-id="${profile.id}">View</button>
+            <button class="tools-item-btn edit-profile-btn" data-id="${profile.id}">Edit</button>
+            <button class="tools-item-btn apply-profile-btn primary" data-id="${profile.id}">Apply</button>
+            <button class="tools-item-btn delete-profile-btn" data-id="${profile.id}">🗑️</button>
+          </div>
+        </div>
+      `).join('');
+    }
+
+    container.innerHTML = html;
+
+    container.querySelector('.create-profile-btn')?.addEventListener('click', () => showProfileModal());
+    container.querySelectorAll('.view-profile-btn').forEach(btn => {
+      btn.addEventListener('click', () => viewProfile(btn.dataset.id));
+    });
+    container.querySelectorAll('.edit-profile-btn').forEach(btn => {
+      btn.addEventListener('click', () => showProfileModal(toolsState.profiles.find(p => p.id === btn.dataset.id)));
+    });
+    container.querySelectorAll('.apply-profile-btn').forEach(btn => {
+      btn.addEventListener('click', () => applyProfile(btn.dataset.id));
+    });
+    container.querySelectorAll('.delete-profile-btn').forEach(btn => {
+      btn.addEventListener('click', () => deleteProfileConfirm(btn.dataset.id));
+    });
+  } catch (err) {
+    logger.error('Failed to load profiles', { error: err.message || String(err) });
+    container.innerHTML = `
+      <div class="tools-empty-state">
+        <div class="empty-icon">⚠️</div>
+        <p>Error loading profiles</p>
+      </div>
+    `;
+  }
+}
+
+// Create a profile from the active project, or rename an existing one
+function showProfileModal(existing = null) {
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  title.textContent = existing ? `Edit Profile: ${existing.name}` : 'Create Profile from Project';
+  body.innerHTML = `
+    <div class="command-create-form">
+      <div class="form-group">
+        <label for="profileName">Profile Name</label>
+        <input type="text" id="profileName" placeholder="web-app" class="tools-input" />
+      </div>
+      <div class="form-group">
+        <label for="profileDescription">Description</label>
+        <input type="text" id="profileDescription" placeholder="Agents, hooks and MCP servers for web apps" class="tools-input" />
+        ${existing ? '' : `<span class="form-hint">Captures the agents, commands, hooks, MCP servers and settings of ${escapeHtml(state.activeProject.name)}</span>`}
+      </div>
+    </div>
+  `;
+  if (existing) {
+    body.querySelector('#profileName').value = existing.name;
+    body.querySelector('#profileDescription').value = existing.description || '';
+  }
+  footer.innerHTML = `
+    <button id="cancelProfileBtn" class="secondary-btn">Cancel</button>
+    <button id="saveProfileBtn" class="primary-btn">${existing ? 'Save' : 'Create'}</button>
+  `;
+
+  footer.querySelector('#cancelProfileBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#saveProfileBtn')?.addEventListener('click', async () => {
+    const name = document.getElementById('profileName').value.trim();
+    const description = document.getElementById('profileDescription').value.trim();
+
+    if (!name) {
+      alert('Please enter a profile name');
+      return;
+    }
+
+    try {
+      if (existing) {
+        await RenameProfile(existing.id, name, description);
+      } else {
+        await CreateProfileFromProject(name, description, state.activeProject.path);
+      }
+      closeToolsModal();
+      renderProfilesTab();
+    } catch (err) {
+      logger.error('Failed to save profile', { error: err.message || String(err) });
+      alert('Failed to save profile: ' + err);
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
+// Show the items a profile installs
+function viewProfile(profileId) {
+  const profile = toolsState.profiles.find(p => p.id === profileId);
+  if (!profile) return;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  const section = (label, items) => items.length === 0 ? '' : `
+    <div class="tools-section-header">${label}</div>
+    <ul class="profile-items">${items.map(item => `<li><code>${escapeHtml(item)}</code></li>`).join('')}</ul>
+  `;
+
+  title.textContent = `Profile: ${profile.name}`;
+  body.innerHTML = `
+    ${profile.description ? `<p>${escapeHtml(profile.description)}</p>` : ''}
+    ${section('Agents', (profile.agents || []).map(f => f.name))}
+    ${section('Commands', (profile.commands || []).map(f => f.name))}
+    ${section('Hooks', (profile.hooks || []).map(h => h.matcher ? `${h.eventType} (${h.matcher})` : h.eventType))}
+    ${section('MCP Servers', (profile.mcpServers || []).map(s => s.name))}
+    ${section('Settings', Object.keys(profile.settings || {}))}
+  `;
+  footer.innerHTML = `
+    <button id="closeProfileViewBtn" class="secondary-btn">Close</button>
+    <button id="applyProfileViewBtn" class="primary-btn">Apply to ${escapeHtml(state.activeProject?.name || 'Project')}</button>
+  `;
+
+  footer.querySelector('#closeProfileViewBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#applyProfileViewBtn')?.addEventListener('click', () => applyProfile(profile.id));
+  modal.classList.remove('hidden');
+}
+
+// Apply a profile to the active project and show what was installed and what conflicted
+async function applyProfile(profileId) {
+  if (!state.activeProject) return;
+  const profile = toolsState.profiles.find(p => p.id === profileId);
+  if (!profile) return;
+
+  try {
+    const report = await ApplyProfile(state.activeProject.path, profileId);
+
+    const modal = document.getElementById('toolsModal');
+    const title = document.getElementById('toolsModalTitle');
+    const body = document.getElementById('toolsModalBody');
+    const footer = document.getElementById('toolsModalFooter');
+
+    if (!modal || !title || !body || !footer) return;
+
+    title.textContent = `Applied Profile: ${profile.name}`;
+    body.innerHTML = `
+      <div class="tools-section-header">Installed (${report.installed.length})</div>
+      ${report.installed.length === 0 ? '<p class="form-hint">Nothing new to install</p>' : `
+        <ul class="profile-items">${report.installed.map(item => `<li>${escapeHtml(item)}</li>`).join('')}</ul>
+      `}
+      ${report.conflicts.length > 0 ? `
+        <div class="tools-section-header">Conflicts (${report.conflicts.length}) · left unchanged</div>
+        <ul class="profile-items profile-conflicts">
+          ${report.conflicts.map(c => `<li><strong>${c.kind} ${escapeHtml(c.name)}</strong>: ${escapeHtml(c.reason)}</li>`).join('')}
+        </ul>
+      ` : ''}
+      ${report.unchanged.length > 0 ? `<p class="form-hint">${report.unchanged.length} items were already present</p>` : ''}
+    `;
+    footer.innerHTML = `
+      <button id="closeProfileReportBtn" class="primary-btn">Close</button>
+    `;
+
+    footer.querySelector('#closeProfileReportBtn')?.addEventListener('click', closeToolsModal);
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to apply profile', { error: err.message || String(err) });
+    alert('Failed to apply profile: ' + err);
+  }
+}
+
+// Delete profile confirmation
+async function deleteProfileConfirm(profileId) {
+  const profile = toolsState.profiles.find(p => p.id === profileId);
+  if (confirm(`Delete profile "${profile?.name}"?`)) {
+    try {
+      await DeleteProfile(profileId);
+      renderProfilesTab();
+    } catch (err) {
+      logger.error('Failed to delete profile', { error: err.message || String(err) });
+      alert('Failed to delete profile: ' + err);
+    }
+  }
+}
+
+// ============================================
+// MCP Tab
+// ============================================
+
+async function renderMcpTab() {
+  const container = document.getElementById('mcpList');
+  if (!container) return;
+
+  if (!state.activeProject) {
+    container.innerHTML = `
+      <div class="tools-empty-state">
+        <div class="empty-icon">🔌</div>
+        <p>Select a project to view MCP servers</p>
+      </div>
+    `;
+    return;
+  }
+
+  try {
+    // Load installed MCP servers
+    const projectServers = await GetProjectMCPServers(state.activeProject.path);
+    const userServers = await GetUserMCPServers();
+    const markedUserServers = userServers.map(s => ({ ...s, scope: 'user' }));
+    toolsState.mcpServers = [...projectServers, ...markedUserServers];
+    const installedNames = new Set(toolsState.mcpServers.map(s => s.name));
+
+    // Load template MCP servers from repo
+    const templateServers = await GetTemplateMCPServers();
+    const availableTemplates = templateServers.filter(t => !installedNames.has(t.name));
+
+    let html = '';
+
+    // Header with add button
+    html += `
+      <div class="tools-header-row">
+        <span class="tools-header-title">MCP Servers</span>
+        <div class="tools-item-actions">
+          <button class="tools-item-btn mcp-secrets-btn">🔑 Secrets</button>
+          <button class="tools-item-btn add-mcp-btn">+ Add</button>
+        </div>
+      </div>
+    `;
+
+    // Installed servers section
+    if (toolsState.mcpServers.length > 0) {
+      html += `
+        <div class="tools-section-header">Installed Servers</div>
+        ${toolsState.mcpServers.map(server => `
+          <div class="tools-item" data-mcp-name="${server.name}">
+            <div class="tools-item-info">
+              <span class="tools-item-status">${server.type === 'stdio' ? '💻' : '🌐'}</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${server.name}</span>
+                <span class="tools-item-description">${server.type === 'stdio' ? server.command : server.url || 'No URL'}</span>
+              </div>
+              <span class="tools-item-badge ${server.scope === 'user' ? 'global' : ''}">${server.scope === 'user' ? 'User' : 'Project'}</span>
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn test-mcp-btn" data-name="${server.name}" data-scope="${server.scope}">Test</button>
+              <button class="tools-item-btn view-mcp-btn" data-name="${server.name}">View</button>
+              ${server.scope === 'user' ? `<button class="tools-item-btn edit-mcp-btn" data-name="${server.name}">Edit</button>` : ''}
+              <button class="tools-item-btn delete-mcp-btn" data-name="${server.name}" data-scope="${server.scope}">🗑️</button>
+            </div>
+          </div>
+        `).join('')}
+      `;
+    }
+
+    // Available templates section
+    if (availableTemplates.length > 0) {
+      html += `
+        <div class="tools-section-header">Available from Repository</div>
+        ${availableTemplates.map(server => `
+          <div class="tools-item template-item" data-mcp-name="${server.name}">
+            <div class="tools-item-info">
+              <span class="tools-item-status">📋</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${server.name}</span>
+                <span class="tools-item-description">${server.command || server.url || 'No description'}</span>
+              </div>
+              <span class="tools-item-badge template">Template</span>
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn view-mcp-template-btn" data-name="${server.name}">View</button>
+              <button class="tools-item-btn install-mcp-template-btn primary" data-name="${server.name}">Install</button>
+            </div>
+          </div>
+        `).join('')}
+      `;
+    }
+
+    if (toolsState.mcpServers.length === 0 && availableTemplates.length === 0) {
+      html = `
+        <div class="tools-empty-state">
+          <div class="empty-icon">🔌</div>
+          <p>No MCP servers available</p>
+          <button class="tools-item-btn add-mcp-btn" style="margin-top:12px;">+ Add MCP Server</button>
+        </div>
+      `;
+    }
+
+    container.innerHTML = html;
+
+    // Add click handlers
+    container.querySelector('.mcp-secrets-btn')?.addEventListener('click', showMcpSecretsModal);
+    container.querySelectorAll('.add-mcp-btn').forEach(btn => {
+      btn.addEventListener('click', () => showAddMcpModal());
+    });
+    container.querySelectorAll('.view-mcp-btn').forEach(btn => {
+      btn.addEventListener('click', () => viewMcpServer(btn.dataset.name));
+    });
+    container.querySelectorAll('.delete-mcp-btn').forEach(btn => {
+      btn.addEventListener('click', () => deleteMcpServerConfirm(btn.dataset.name, btn.dataset.scope));
+    });
+    container.querySelectorAll('.edit-mcp-btn').forEach(btn => {
+      btn.addEventListener('click', () => {
+        const server = toolsState.mcpServers.find(s => s.name === btn.dataset.name && s.scope === 'user');
+        if (server) showAddMcpModal(server);
+      });
+    });
+    container.querySelectorAll('.test-mcp-btn').forEach(btn => {
+      btn.addEventListener('click', () => testMcpServer(btn, btn.dataset.name, btn.dataset.scope));
+    });
+
+    // Template handlers
+    container.querySelectorAll('.view-mcp-template-btn').forEach(btn => {
+      btn.addEventListener('click', () => viewMcpTemplate(btn.dataset.name));
+    });
+    container.querySelectorAll('.install-mcp-template-btn').forEach(btn => {
+      btn.addEventListener('click', () => installMcpTemplate(btn.dataset.name));
+    });
+  } catch (err) {
+    logger.error('Failed to load MCP servers', { error: err.message || String(err) });
+    container.innerHTML = `
+      <div class="tools-empty-state">
+        <div class="empty-icon">⚠️</div>
+        <p>Error loading MCP servers</p>
+      </div>
+    `;
+  }
+}
+
+// View MCP template details
+async function viewMcpTemplate(name) {
+  const templateServers = await GetTemplateMCPServers();
+  const server = templateServers.find(s => s.name === name);
+  if (!server) return;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  const envEntries = server.env ? Object.entries(server.env) : [];
+
+  title.textContent = `MCP Template: ${server.name}`;
+  body.innerHTML = `
+    <div class="mcp-info-content">
+      <div class="mcp-info-row">
+        <span class="mcp-info-label">Name:</span>
+        <span class="mcp-info-value">${server.name}</span>
+      </div>
+      <div class="mcp-info-row">
+        <span class="mcp-info-label">Type:</span>
+        <span class="mcp-info-value">${server.type || 'stdio'}</span>
+      </div>
+      ${server.command ? `
+        <div class="mcp-info-row">
+          <span class="mcp-info-label">Command:</span>
+          <span class="mcp-info-value"><code>${server.command}</code></span>
+        </div>
+      ` : ''}
+      ${server.args && server.args.length > 0 ? `
+        <div class="mcp-info-row">
+          <span class="mcp-info-label">Args:</span>
+          <span class="mcp-info-value"><code>${server.args.join(' ')}</code></span>
+        </div>
+      ` : ''}
+      ${envEntries.length > 0 ? `
+        <div class="mcp-info-row">
+          <span class="mcp-info-label">Required Env:</span>
+          <div class="mcp-info-env">
+            ${envEntries.map(([key]) => `<code>${key}</code>`).join(', ')}
+          </div>
+        </div>
+      ` : ''}
+    </div>
+  `;
+
+  footer.innerHTML = `
+    <button id="closeMcpTemplateBtn" class="secondary-btn">Close</button>
+    <button id="installMcpTemplateBtn" class="primary-btn" data-name="${name}">Install to Project</button>
+  `;
+
+  footer.querySelector('#closeMcpTemplateBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#installMcpTemplateBtn')?.addEventListener('click', async (e) => {
+    await installMcpTemplate(e.target.dataset.name);
+    closeToolsModal();
+  });
+
+  modal.classList.remove('hidden');
+}
 
 // Install MCP template to project
 async function installMcpTemplate(name) {
@@ -2293,7 +3687,8 @@ function viewMcpServer(name) {
           <div class="mcp-info-env">
             ${envEntries.map(([key, val]) => `
               <div class="mcp-env-entry">
-                <code>${key}</code>=<code>${val.startsWith('$') ? val : '***'}</code>
+                <code>${key}</code>=<code class="mcp-env-value" data-key="${escapeHtml(key)}">${val.startsWith('$') ? escapeHtml(val) : '***'}</code>
+                ${SECRET_PLACEHOLDER.test(val) ? '<span class="mcp-secret-badge" title="Stored in the keychain">🔒</span>' : ''}
               </div>
             `).join('')}
           </div>
@@ -2303,15 +3698,29 @@ function viewMcpServer(name) {
   `;
 
   footer.innerHTML = `
+    ${envEntries.length > 0 ? '<button id="revealMcpEnvBtn" class="secondary-btn">Reveal Values</button>' : ''}
     <button id="closeMcpViewBtn" class="primary-btn">Close</button>
   `;
 
   footer.querySelector('#closeMcpViewBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#revealMcpEnvBtn')?.addEventListener('click', async () => {
+    try {
+      const resolved = await ResolveMCPSecrets(server);
+      body.querySelectorAll('.mcp-env-value').forEach(el => {
+        el.textContent = resolved.env[el.dataset.key] ?? '';
+      });
+      footer.querySelector('#revealMcpEnvBtn')?.remove();
+    } catch (err) {
+      logger.error('Failed to resolve MCP secrets', { error: err.message || String(err) });
+      alert('Failed to resolve secrets: ' + err);
+    }
+  });
   modal.classList.remove('hidden');
 }
 
 // Show add MCP server modal
-function showAddMcpModal() {
+// Add a server, or edit an existing user-scope server
+function showAddMcpModal(existing = null) {
   const modal = document.getElementById('toolsModal');
   const title = document.getElementById('toolsModalTitle');
   const body = document.getElementById('toolsModalBody');
@@ -2319,16 +3728,23 @@ function showAddMcpModal() {
 
   if (!modal || !title || !body || !footer) return;
 
-  toolsState.modalMode = 'create';
-  toolsState.modalItem = null;
+  toolsState.modalMode = existing ? 'edit' : 'create';
+  toolsState.modalItem = existing;
 
-  title.textContent = 'Add MCP Server';
+  title.textContent = existing ? `Edit MCP Server: ${existing.name}` : 'Add MCP Server';
   body.innerHTML = `
     <div class="mcp-create-form">
       <div class="form-group">
         <label for="mcpName">Server Name</label>
         <input type="text" id="mcpName" placeholder="my-server" class="tools-input" />
       </div>
+      <div class="form-group">
+        <label for="mcpScope">Scope</label>
+        <select id="mcpScope" class="tools-select" ${existing ? 'disabled' : ''}>
+          <option value="project">Project (.mcp.json)</option>
+          <option value="user">User (~/.claude.json)</option>
+        </select>
+      </div>
       <div class="form-group">
         <label for="mcpType">Type</label>
         <select id="mcpType" class="tools-select">
@@ -2355,6 +3771,13 @@ function showAddMcpModal() {
       <div class="form-group">
         <label for="mcpEnv">Environment Variables (KEY=value, one per line)</label>
         <textarea id="mcpEnv" class="tools-textarea" placeholder="API_KEY=your-key-here"></textarea>
+        <span class="form-hint">Reference keychain secrets with \${secret:NAME}</span>
+      </div>
+      <div class="form-group">
+        <label>
+          <input type="checkbox" id="mcpSecureEnv" />
+          Move plaintext values to the keychain
+        </label>
       </div>
     </div>
   `;
@@ -2364,7 +3787,7 @@ function showAddMcpModal() {
   const stdioFields = body.querySelector('#mcpStdioFields');
   const httpFields = body.querySelector('#mcpHttpFields');
 
-  typeSelect?.addEventListener('change', () => {
+  const toggleFields = () => {
     if (typeSelect.value === 'stdio') {
       stdioFields.style.display = 'block';
       httpFields.style.display = 'none';
@@ -2372,11 +3795,23 @@ function showAddMcpModal() {
       stdioFields.style.display = 'none';
       httpFields.style.display = 'block';
     }
-  });
+  };
+  typeSelect?.addEventListener('change', toggleFields);
+
+  if (existing) {
+    body.querySelector('#mcpName').value = existing.name;
+    body.querySelector('#mcpScope').value = 'user';
+    typeSelect.value = existing.type === 'stdio' || !existing.type ? 'stdio' : 'http';
+    body.querySelector('#mcpCommand').value = existing.command || '';
+    body.querySelector('#mcpArgs').value = (existing.args || []).join(' ');
+    body.querySelector('#mcpUrl').value = existing.url || '';
+    body.querySelector('#mcpEnv').value = Object.entries(existing.env || {}).map(([k, v]) => `${k}=${v}`).join('\n');
+    toggleFields();
+  }
 
   footer.innerHTML = `
     <button id="cancelAddMcpBtn" class="secondary-btn">Cancel</button>
-    <button id="addMcpBtn" class="primary-btn">Add Server</button>
+    <button id="addMcpBtn" class="primary-btn">${existing ? 'Save' : 'Add Server'}</button>
   `;
 
   footer.querySelector('#cancelAddMcpBtn')?.addEventListener('click', closeToolsModal);
@@ -2387,6 +3822,7 @@ function showAddMcpModal() {
     const argsStr = document.getElementById('mcpArgs')?.value?.trim();
     const url = document.getElementById('mcpUrl')?.value?.trim();
     const envStr = document.getElementById('mcpEnv')?.value?.trim();
+    const scope = document.getElementById('mcpScope')?.value || 'project';
 
     if (!name) {
       alert('Please enter a server name');
@@ -2405,36 +3841,157 @@ function showAddMcpModal() {
           env[key.trim()] = valueParts.join('=').trim();
         }
       });
-    }
+    }
+
+    let server = {
+      name,
+      type,
+      command: type === 'stdio' ? command : '',
+      args: type === 'stdio' ? args : [],
+      url: type === 'http' ? url : '',
+      env,
+      scope
+    };
+
+    try {
+      if (document.getElementById('mcpSecureEnv')?.checked) {
+        server = { ...(await SecureMCPEnv(server)), scope };
+      }
+      if (existing) {
+        await UpdateUserMCPServer(existing.name, server);
+      } else if (scope === 'user') {
+        await AddUserMCPServer(server);
+      } else {
+        await AddMCPServer(state.activeProject.path, server);
+      }
+      closeToolsModal();
+      renderMcpTab();
+    } catch (err) {
+      logger.error('Failed to add MCP server', { error: err.message || String(err) });
+      alert('Failed to add MCP server: ' + err);
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
+// Manage the keychain secrets MCP configs reference as ${secret:NAME}
+async function showMcpSecretsModal() {
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const names = await GetMCPSecrets();
+
+    title.textContent = 'MCP Secrets';
+    body.innerHTML = `
+      ${names.length === 0 ? `
+        <div class="tools-empty-state">
+          <div class="empty-icon">🔑</div>
+          <p>No secrets stored</p>
+        </div>
+      ` : names.map(name => `
+        <div class="tools-item">
+          <div class="tools-item-info">
+            <span class="tools-item-status">🔒</span>
+            <div class="tools-item-details">
+              <span class="tools-item-name">${escapeHtml(name)}</span>
+              <span class="tools-item-description"><code>\${secret:${escapeHtml(name)}}</code></span>
+            </div>
+          </div>
+          <div class="tools-item-actions">
+            <button class="tools-item-btn delete-secret-btn" data-name="${escapeHtml(name)}">🗑️</button>
+          </div>
+        </div>
+      `).join('')}
+      <div class="command-create-form">
+        <div class="form-group">
+          <label for="mcpSecretName">Name</label>
+          <input type="text" id="mcpSecretName" placeholder="GITHUB_TOKEN" class="tools-input" />
+        </div>
+        <div class="form-group">
+          <label for="mcpSecretValue">Value</label>
+          <input type="password" id="mcpSecretValue" class="tools-input" />
+          <span class="form-hint">Stored in the system keychain, never in .mcp.json</span>
+        </div>
+      </div>
+    `;
+    footer.innerHTML = `
+      <button id="closeSecretsBtn" class="secondary-btn">Close</button>
+      <button id="saveSecretBtn" class="primary-btn">Save Secret</button>
+    `;
+
+    footer.querySelector('#closeSecretsBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#saveSecretBtn')?.addEventListener('click', async () => {
+      const name = document.getElementById('mcpSecretName')?.value.trim();
+      const value = document.getElementById('mcpSecretValue')?.value;
+      if (!name || !value) {
+        alert('Please enter a name and a value');
+        return;
+      }
+      try {
+        await SetMCPSecret(name, value);
+        showMcpSecretsModal();
+      } catch (err) {
+        logger.error('Failed to save MCP secret', { error: err.message || String(err) });
+        alert('Failed to save secret: ' + err);
+      }
+    });
+    body.querySelectorAll('.delete-secret-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        if (!confirm(`Delete secret "${btn.dataset.name}" from the keychain?`)) return;
+        try {
+          await DeleteMCPSecret(btn.dataset.name);
+          showMcpSecretsModal();
+        } catch (err) {
+          logger.error('Failed to delete MCP secret', { error: err.message || String(err) });
+          alert('Failed to delete secret: ' + err);
+        }
+      });
+    });
 
-    const server = {
-      name,
-      type,
-      command: type === 'stdio' ? command : '',
-      args: type === 'stdio' ? args : [],
-      url: type === 'http' ? url : '',
-      env,
-      scope: 'project'
-    };
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load MCP secrets', { error: err.message || String(err) });
+    alert('Failed to load secrets: ' + err);
+  }
+}
 
-    try {
-      await AddMCPServer(state.activeProject.path, server);
-      closeToolsModal();
-      renderMcpTab();
-    } catch (err) {
-      logger.error('Failed to add MCP server', { error: err.message || String(err) });
-      alert('Failed to add MCP server: ' + err);
-    }
-  });
+// Test MCP server connectivity and show its tools
+async function testMcpServer(btn, name, scope) {
+  const server = toolsState.mcpServers.find(s => s.name === name && s.scope === scope);
+  if (!server) return;
 
-  modal.classList.remove('hidden');
+  btn.disabled = true;
+  btn.textContent = 'Testing...';
+  try {
+    const result = await TestMCPServer(server);
+    const tools = result.tools.map(t => `• ${t.name}`).join('\n');
+    alert(`✅ ${name}: connected to ${result.serverName || 'server'} ${result.serverVersion || ''} in ${result.durationMs}ms\n\n` +
+      `${result.tools.length} tools, ${result.resources.length} resources` + (tools ? `\n\n${tools}` : ''));
+  } catch (err) {
+    logger.error('MCP server test failed', { name, error: err.message || String(err) });
+    alert(`❌ ${name}: ${err}`);
+  } finally {
+    btn.disabled = false;
+    btn.textContent = 'Test';
+  }
 }
 
 // Delete MCP server confirmation
-async function deleteMcpServerConfirm(name) {
-  if (confirm(`Remove MCP server "${name}" from this project?`)) {
+async function deleteMcpServerConfirm(name, scope) {
+  const where = scope === 'user' ? 'your user config (~/.claude.json)' : 'this project';
+  if (confirm(`Remove MCP server "${name}" from ${where}?`)) {
     try {
-      await RemoveMCPServer(state.activeProject.path, name);
+      if (scope === 'user') {
+        await RemoveUserMCPServer(name);
+      } else {
+        await RemoveMCPServer(state.activeProject.path, name);
+      }
       renderMcpTab();
     } catch (err) {
       logger.error('Failed to remove MCP server', { error: err.message || String(err) });
@@ -2547,6 +4104,9 @@ async function editAgent(path) {
     footer.querySelector('#saveAgentBtn')?.addEventListener('click', async () => {
       const editor = document.getElementById('agentEditor');
       if (editor) {
+        if (agent?.format === 'md' && !(await confirmAgentIssues(await ValidateAgentContent(editor.value)))) {
+          return;
+        }
         try {
           await SaveAgentContent(path, editor.value);
           closeToolsModal();
@@ -2565,15 +4125,150 @@ async function editAgent(path) {
   }
 }
 
+// Render agent validation issues
+function renderAgentIssues(issues) {
+  if (!issues || issues.length === 0) return '';
+  return issues.map(issue => `
+    <div class="agent-issue ${issue.severity}">
+      ${issue.severity === 'error' ? '✖' : '⚠'} ${escapeHtml(issue.field)}${issue.line ? ` (line ${issue.line})` : ''}: ${escapeHtml(issue.message)}
+    </div>
+  `).join('');
+}
+
+// Block saving on errors; let the user decide on warnings
+async function confirmAgentIssues(issues) {
+  const errors = issues.filter(i => i.severity === 'error');
+  const warnings = issues.filter(i => i.severity === 'warning');
+  const format = list => list.map(i => `• ${i.field}: ${i.message}`).join('\n');
+
+  if (errors.length > 0) {
+    alert(`Agent has errors and was not saved:\n\n${format(errors)}`);
+    return false;
+  }
+  if (warnings.length > 0) {
+    return confirm(`Agent has warnings:\n\n${format(warnings)}\n\nSave anyway?`);
+  }
+  return true;
+}
+
+// Create agent wizard
+function showCreateAgentModal() {
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer || !state.activeProject) return;
+
+  toolsState.modalMode = 'create';
+  toolsState.modalItem = null;
+
+  title.textContent = 'Create New Agent';
+  body.innerHTML = `
+    <div class="agent-create-form">
+      <div class="form-group">
+        <label for="agentName">Name</label>
+        <input type="text" id="agentName" placeholder="code-reviewer" class="tools-input" />
+        <span class="form-hint">Lowercase letters, digits and hyphens; saved as .claude/agents/&lt;name&gt;.md</span>
+      </div>
+      <div class="form-group">
+        <label for="agentDescription">Description</label>
+        <input type="text" id="agentDescription" placeholder="Reviews code for quality. Use proactively after code changes." class="tools-input" />
+        <span class="form-hint">Claude uses this to decide when to delegate to the agent</span>
+      </div>
+      <div class="form-group">
+        <label for="agentTools">Tools (comma separated, empty = all)</label>
+        <input type="text" id="agentTools" placeholder="Read, Grep, Glob, Bash" class="tools-input" />
+      </div>
+      <div class="form-group">
+        <label for="agentModel">Model</label>
+        <select id="agentModel" class="tools-select">
+          <option value="">Default</option>
+          <option value="inherit">inherit</option>
+          <option value="sonnet">sonnet</option>
+          <option value="opus">opus</option>
+          <option value="haiku">haiku</option>
+        </select>
+      </div>
+      <div class="form-group">
+        <label for="agentColor">Color</label>
+        <select id="agentColor" class="tools-select">
+          <option value="">None</option>
+          ${['red', 'blue', 'green', 'yellow', 'purple', 'orange', 'pink', 'cyan'].map(c => `<option value="${c}">${c}</option>`).join('')}
+        </select>
+      </div>
+      <div class="form-group">
+        <label for="agentBody">System Prompt</label>
+        <textarea id="agentBody" class="tools-editor" placeholder="You are a senior code reviewer..."></textarea>
+      </div>
+      <div class="agent-issues" id="agentIssues"></div>
+    </div>
+  `;
+  footer.innerHTML = `
+    <button id="cancelCreateAgentBtn" class="secondary-btn">Cancel</button>
+    <button id="createAgentBtn" class="primary-btn">Create</button>
+  `;
+
+  const readForm = () => ({
+    name: document.getElementById('agentName').value.trim(),
+    frontmatter: {
+      name: document.getElementById('agentName').value.trim(),
+      description: document.getElementById('agentDescription').value.trim(),
+      tools: document.getElementById('agentTools').value.split(',').map(t => t.trim()).filter(Boolean),
+      model: document.getElementById('agentModel').value,
+      color: document.getElementById('agentColor').value,
+    },
+    body: document.getElementById('agentBody').value,
+  });
+
+  // Live validation of the generated frontmatter
+  let validateTimer = null;
+  const validate = () => {
+    clearTimeout(validateTimer);
+    validateTimer = setTimeout(async () => {
+      const { frontmatter } = readForm();
+      const lines = ['---', `name: ${frontmatter.name}`, `description: ${JSON.stringify(frontmatter.description)}`];
+      if (frontmatter.tools.length) lines.push(`tools: ${frontmatter.tools.join(', ')}`);
+      if (frontmatter.model) lines.push(`model: ${frontmatter.model}`);
+      if (frontmatter.color) lines.push(`color: ${frontmatter.color}`);
+      lines.push('---');
+      const issues = await ValidateAgentContent(lines.join('\n'));
+      const container = document.getElementById('agentIssues');
+      if (container) container.innerHTML = renderAgentIssues(issues);
+    }, 300);
+  };
+  body.querySelectorAll('input, select').forEach(el => el.addEventListener('input', validate));
+
+  footer.querySelector('#cancelCreateAgentBtn')?.addEventListener('click', closeToolsModal);
+  footer.querySelector('#createAgentBtn')?.addEventListener('click', async () => {
+    const { name, frontmatter, body: prompt } = readForm();
+    if (!prompt.trim()) {
+      alert('Please enter a system prompt');
+      return;
+    }
+
+    try {
+      await CreateAgent(state.activeProject.path, name, frontmatter, prompt);
+      closeToolsModal();
+      renderAgentsTab();
+    } catch (err) {
+      logger.error('Failed to create agent', { error: err.message || String(err) });
+      alert('Failed to create agent: ' + err);
+    }
+  });
+
+  modal.classList.remove('hidden');
+}
+
 // Install skill
-async function doInstallSkill(skillName) {
+async function doInstallSkill(skillName, marketplace = '') {
   if (!state.activeProject) {
     alert('No project selected');
     return;
   }
 
   try {
-    await InstallSkill(state.activeProject.path, skillName);
+    await InstallSkill(state.activeProject.path, skillName, marketplace);
     alert(`Skill "${skillName}" installed successfully!`);
     renderSkillsTab();
   } catch (err) {
@@ -2582,6 +4277,177 @@ async function doInstallSkill(skillName) {
   }
 }
 
+// Show what updating a skill from its source would change, then update it
+async function showSkillUpgradeModal(skillName) {
+  if (!state.activeProject) return;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const diffs = await GetSkillUpgradeDiff(state.activeProject.path, skillName);
+
+    title.textContent = `Update Skill: /${skillName}`;
+    body.innerHTML = diffs.length === 0 ? `
+      <div class="tools-empty-state">
+        <div class="empty-icon">✅</div>
+        <p>Skill "${escapeHtml(skillName)}" matches its source</p>
+      </div>
+    ` : diffs.map(d => `
+      <div class="memory-change">
+        <div class="memory-change-header">
+          <span class="memory-change-status ${d.status}">${d.status}</span>
+          <span class="memory-change-name">${escapeHtml(d.path)}</span>
+        </div>
+        ${renderUnifiedDiff(d.diff)}
+      </div>
+    `).join('');
+    footer.innerHTML = `
+      <button id="cancelSkillUpgradeBtn" class="secondary-btn">${diffs.length === 0 ? 'Close' : 'Cancel'}</button>
+      ${diffs.length > 0 ? '<button id="applySkillUpgradeBtn" class="primary-btn">Update</button>' : ''}
+    `;
+
+    footer.querySelector('#cancelSkillUpgradeBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#applySkillUpgradeBtn')?.addEventListener('click', async () => {
+      closeToolsModal();
+      await doUpdateSkill(skillName);
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to diff skill', { error: err.message || String(err) });
+    alert('Failed to compare skill with its source: ' + err);
+  }
+}
+
+// Update skill from the marketplace or template repository
+async function doUpdateSkill(skillName) {
+  if (!state.activeProject) return;
+
+  try {
+    const report = await UpdateSkill(state.activeProject.path, skillName);
+    const lines = [
+      ...report.added.map(f => `+ ${f}`),
+      ...report.modified.map(f => `~ ${f}`),
+      ...report.removed.map(f => `- ${f}`),
+    ];
+    alert(lines.length === 0
+      ? `Skill "${skillName}" is already up to date.`
+      : `Skill "${skillName}" updated (${lines.length} files changed):\n\n${lines.join('\n')}`);
+    renderSkillsTab();
+  } catch (err) {
+    logger.error('Failed to update skill', { error: err.message || String(err) });
+    alert('Failed to update skill: ' + err);
+  }
+}
+
+// Show installed plugin marketplaces and add or remove custom ones
+async function showMarketplacesModal() {
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const marketplaces = await GetMarketplaces();
+
+    title.textContent = 'Plugin Marketplaces';
+    body.innerHTML = `
+      ${marketplaces.length === 0 ? `
+        <div class="tools-empty-state">
+          <div class="empty-icon">🧩</div>
+          <p>No marketplaces installed</p>
+        </div>
+      ` : marketplaces.map(mp => `
+        <div class="tools-item">
+          <div class="tools-item-info">
+            <span class="tools-item-status">🧩</span>
+            <div class="tools-item-details">
+              <span class="tools-item-name">${escapeHtml(mp.name)}</span>
+              <span class="tools-item-description">${escapeHtml(mp.source || mp.path)} · ${mp.skillCount} skills</span>
+            </div>
+            ${mp.official ? '<span class="tools-item-badge global">Official</span>' : ''}
+          </div>
+          <div class="tools-item-actions">
+            ${!mp.official ? `<button class="tools-item-btn remove-marketplace-btn" data-name="${escapeHtml(mp.name)}">🗑️</button>` : ''}
+          </div>
+        </div>
+      `).join('')}
+      <div class="command-create-form">
+        <div class="form-group">
+          <label for="marketplaceSource">Add Marketplace</label>
+          <input type="text" id="marketplaceSource" placeholder="owner/repo" class="tools-input" />
+          <span class="form-hint">GitHub repo, git URL or local path of a Claude plugin marketplace</span>
+        </div>
+      </div>
+    `;
+    footer.innerHTML = `
+      <button id="closeMarketplacesBtn" class="secondary-btn">Close</button>
+      <button id="addMarketplaceBtn" class="primary-btn">Add</button>
+    `;
+
+    footer.querySelector('#closeMarketplacesBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#addMarketplaceBtn')?.addEventListener('click', async () => {
+      const source = document.getElementById('marketplaceSource')?.value.trim();
+      if (!source) {
+        alert('Please enter a marketplace repo');
+        return;
+      }
+      const btn = footer.querySelector('#addMarketplaceBtn');
+      btn.disabled = true;
+      btn.textContent = 'Adding...';
+      try {
+        await AddMarketplace(source);
+        showMarketplacesModal();
+        renderSkillsTab();
+      } catch (err) {
+        logger.error('Failed to add marketplace', { error: err.message || String(err) });
+        alert('Failed to add marketplace: ' + err);
+        btn.disabled = false;
+        btn.textContent = 'Add';
+      }
+    });
+    body.querySelectorAll('.remove-marketplace-btn').forEach(btn => {
+      btn.addEventListener('click', async () => {
+        if (!confirm(`Remove marketplace "${btn.dataset.name}"?`)) return;
+        try {
+          await RemoveMarketplace(btn.dataset.name);
+          showMarketplacesModal();
+          renderSkillsTab();
+        } catch (err) {
+          logger.error('Failed to remove marketplace', { error: err.message || String(err) });
+          alert('Failed to remove marketplace: ' + err);
+        }
+      });
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load marketplaces', { error: err.message || String(err) });
+    alert('Failed to load marketplaces: ' + err);
+  }
+}
+
+// Uninstall skill
+async function doUninstallSkill(skillName) {
+  if (!state.activeProject) return;
+  if (!confirm(`Remove skill "${skillName}" from this project?`)) return;
+
+  try {
+    await UninstallSkill(state.activeProject.path, skillName);
+    renderSkillsTab();
+  } catch (err) {
+    logger.error('Failed to uninstall skill', { error: err.message || String(err) });
+    alert('Failed to uninstall skill: ' + err);
+  }
+}
+
 // Install hook
 async function doInstallHook(hookName) {
   if (!state.activeProject) {
@@ -2623,9 +4489,17 @@ async function renderClaudemdTab() {
   }
 
   try {
+    // Load the hierarchy and default to the project root CLAUDE.md
+    toolsState.claudemdFiles = await GetClaudemdHierarchy(state.activeProject.path);
+    if (!toolsState.claudemdFiles.some(f => f.path === toolsState.claudemdPath)) {
+      const root = toolsState.claudemdFiles.find(f => f.relPath === 'CLAUDE.md');
+      toolsState.claudemdPath = root ? root.path : null;
+      toolsState.claudemdDirty = false;
+    }
+
     // Load content only if not dirty (preserve unsaved changes)
-    if (!toolsState.claudemdDirty) {
-      toolsState.claudemd = await GetClaudemd(state.activeProject.path);
+    if (!toolsState.claudemdDirty && toolsState.claudemdPath) {
+      toolsState.claudemd = await GetClaudemdFile(state.activeProject.path, toolsState.claudemdPath);
     }
 
     // Configure marked for safe rendering
@@ -2641,7 +4515,13 @@ async function renderClaudemdTab() {
       <div class="claudemd-editor">
         <div class="claudemd-toolbar">
           <div class="claudemd-toolbar-left">
-            <span class="claudemd-filepath">CLAUDE.md</span>
+            <select class="claudemd-file-select" id="claudemdFileSelect" title="CLAUDE.md files loaded for this project">
+              ${toolsState.claudemdFiles.map(f => `
+                <option value="${escapeHtml(f.path)}" ${f.path === toolsState.claudemdPath ? 'selected' : ''}>
+                  ${escapeHtml(f.relPath)}${f.exists ? '' : ' (new)'}
+                </option>
+              `).join('')}
+            </select>
             ${toolsState.claudemdDirty ? '<span class="claudemd-dirty">• Unsaved</span>' : ''}
           </div>
           <div class="claudemd-toolbar-right">
@@ -2649,6 +4529,7 @@ async function renderClaudemdTab() {
               <button class="claudemd-mode-btn ${!isPreviewMode ? 'active' : ''}" id="claudemdEditMode">Edit</button>
               <button class="claudemd-mode-btn ${isPreviewMode ? 'active' : ''}" id="claudemdPreviewMode">Preview</button>
             </div>
+            <button class="claudemd-btn" id="claudeMemoryBtn" title="View and edit Claude's memory">🧠 Memory</button>
             <button class="claudemd-btn claudemd-save-btn" id="saveClaudemdBtn" ${!toolsState.claudemdDirty ? 'disabled' : ''}>
               💾 Save
             </button>
@@ -2681,6 +4562,19 @@ Add instructions, context, and guidelines for Claude when working with this proj
     const editModeBtn = document.getElementById('claudemdEditMode');
     const previewModeBtn = document.getElementById('claudemdPreviewMode');
 
+    document.getElementById('claudeMemoryBtn')?.addEventListener('click', showMemoryModal);
+
+    // Switch between files in the hierarchy
+    document.getElementById('claudemdFileSelect')?.addEventListener('change', (e) => {
+      if (toolsState.claudemdDirty && !confirm('Discard unsaved changes?')) {
+        e.target.value = toolsState.claudemdPath;
+        return;
+      }
+      toolsState.claudemdPath = e.target.value;
+      toolsState.claudemdDirty = false;
+      renderClaudemdTab();
+    });
+
     // Mode toggle
     editModeBtn?.addEventListener('click', () => {
       toolsState.claudemdPreview = false;
@@ -2709,7 +4603,7 @@ Add instructions, context, and guidelines for Claude when working with this proj
         // Update dirty indicator
         const dirtyIndicator = container.querySelector('.claudemd-dirty');
         if (!dirtyIndicator) {
-          const filepath = container.querySelector('.claudemd-filepath');
+          const filepath = container.querySelector('.claudemd-file-select');
           if (filepath) {
             filepath.insertAdjacentHTML('afterend', '<span class="claudemd-dirty">• Unsaved</span>');
           }
@@ -2741,14 +4635,151 @@ Add instructions, context, and guidelines for Claude when working with this proj
   }
 }
 
+// Render a unified diff with added/removed lines highlighted
+function renderUnifiedDiff(diff) {
+  const lines = diff.replace(/\n$/, '').split('\n').map(line => {
+    let cls = '';
+    if (line.startsWith('+')) cls = 'diff-added';
+    else if (line.startsWith('-')) cls = 'diff-removed';
+    else if (line.startsWith('@@')) cls = 'diff-hunk';
+    return `<div class="diff-line ${cls}">${escapeHtml(line)}</div>`;
+  });
+  return `<pre class="diff-content diff-unified memory-diff">${lines.join('')}</pre>`;
+}
+
+// Show Claude's memory files and what changed since they were last reviewed
+async function showMemoryModal() {
+  if (!state.activeProject) return;
+  const projectPath = state.activeProject.path;
+
+  const modal = document.getElementById('toolsModal');
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  if (!modal || !title || !body || !footer) return;
+
+  try {
+    const [files, changes] = await Promise.all([
+      GetMemoryFiles(projectPath),
+      GetMemoryChanges(projectPath),
+    ]);
+
+    title.textContent = 'Claude Memory';
+    body.innerHTML = `
+      <div class="memory-viewer">
+        ${files.length === 0 ? `
+          <div class="tools-empty-state">
+            <div class="empty-icon">🧠</div>
+            <p>Claude has no memory for this project yet</p>
+          </div>
+        ` : files.map(f => `
+          <div class="tools-item">
+            <div class="tools-item-info">
+              <span class="tools-item-status">🧠</span>
+              <div class="tools-item-details">
+                <span class="tools-item-name">${escapeHtml(f.name)}</span>
+                <span class="tools-item-description">${(f.size / 1024).toFixed(1)} KB · ${new Date(f.modifiedAt).toLocaleString()}</span>
+              </div>
+              ${f.scope === 'global' ? '<span class="tools-item-badge global">Global</span>' : '<span class="tools-item-badge">Project</span>'}
+              ${f.changed ? '<span class="tools-item-badge template">Changed</span>' : ''}
+            </div>
+            <div class="tools-item-actions">
+              <button class="tools-item-btn edit-memory-btn" data-path="${escapeHtml(f.path)}">Edit</button>
+            </div>
+          </div>
+        `).join('')}
+        ${changes.length > 0 ? `
+          <div class="tools-section-header">Changes since last review</div>
+          ${changes.map(c => `
+            <div class="memory-change">
+              <div class="memory-change-header">
+                <span class="tools-item-name">${escapeHtml(c.name)}</span>
+                <span class="memory-change-status ${c.status}">${c.status}</span>
+              </div>
+              ${renderUnifiedDiff(c.diff)}
+            </div>
+          `).join('')}
+        ` : ''}
+      </div>
+    `;
+    footer.innerHTML = `
+      <button id="closeMemoryBtn" class="secondary-btn">Close</button>
+      ${changes.length > 0 ? '<button id="ackMemoryBtn" class="primary-btn">Mark Reviewed</button>' : ''}
+    `;
+
+    body.querySelectorAll('.edit-memory-btn').forEach(btn => {
+      btn.addEventListener('click', () => editMemoryFile(btn.dataset.path));
+    });
+    footer.querySelector('#closeMemoryBtn')?.addEventListener('click', closeToolsModal);
+    footer.querySelector('#ackMemoryBtn')?.addEventListener('click', async () => {
+      try {
+        await AcknowledgeMemoryChanges(projectPath);
+        showMemoryModal();
+      } catch (err) {
+        logger.error('Failed to mark memory as reviewed', { error: err.message || String(err) });
+        alert('Failed to mark memory as reviewed: ' + err);
+      }
+    });
+
+    modal.classList.remove('hidden');
+  } catch (err) {
+    logger.error('Failed to load Claude memory', { error: err.message || String(err) });
+    alert('Failed to load Claude memory: ' + err);
+  }
+}
+
+// Edit a memory file, returning to the memory list afterwards
+async function editMemoryFile(path) {
+  const projectPath = state.activeProject.path;
+  const title = document.getElementById('toolsModalTitle');
+  const body = document.getElementById('toolsModalBody');
+  const footer = document.getElementById('toolsModalFooter');
+
+  try {
+    const content = await GetMemoryContent(projectPath, path);
+
+    title.textContent = `Edit Memory: ${path.split('/').pop()}`;
+    body.innerHTML = `
+      <textarea class="tools-editor" id="memoryEditor">${escapeHtml(content)}</textarea>
+    `;
+    footer.innerHTML = `
+      <button id="backMemoryBtn" class="secondary-btn">Back</button>
+      <button id="saveMemoryBtn" class="primary-btn">Save</button>
+    `;
+
+    footer.querySelector('#backMemoryBtn')?.addEventListener('click', showMemoryModal);
+    footer.querySelector('#saveMemoryBtn')?.addEventListener('click', async () => {
+      try {
+        await SaveMemoryContent(projectPath, path, document.getElementById('memoryEditor').value);
+        showMemoryModal();
+      } catch (err) {
+        logger.error('Failed to save memory', { error: err.message || String(err) });
+        alert('Failed to save memory: ' + err);
+      }
+    });
+  } catch (err) {
+    logger.error('Failed to load memory file', { error: err.message || String(err) });
+    alert('Failed to load memory file: ' + err);
+  }
+}
+
 // Save CLAUDE.md content
 async function saveClaudemd() {
-  if (!state.activeProject) return;
+  if (!state.activeProject || !toolsState.claudemdPath) return;
 
   try {
-    await SaveClaudemd(state.activeProject.path, toolsState.claudemd);
+    await SaveClaudemdFile(state.activeProject.path, toolsState.claudemdPath, toolsState.claudemd);
     toolsState.claudemdDirty = false;
 
+    // A newly created file no longer needs the "(new)" marker
+    const file = toolsState.claudemdFiles.find(f => f.path === toolsState.claudemdPath);
+    if (file && !file.exists) {
+      file.exists = true;
+      const option = document.querySelector(`#claudemdFileSelect option[value="${CSS.escape(file.path)}"]`);
+      if (option) option.textContent = file.relPath;
+    }
+
     // Update UI
     const dirtyIndicator = document.querySelector('.claudemd-dirty');
     if (dirtyIndicator) {
@@ -2796,6 +4827,7 @@ export function refreshToolsPanel() {
   toolsState.claudemd = '';
   toolsState.claudemdDirty = false;
   toolsState.claudemdPreview = false;
+  toolsState.claudemdPath = null;
 
   // Reset prompts state when project changes
   toolsState.prompts = [];
@@ -3,30 +3,64 @@
 import {remote} from '../models';
 import {state} from '../models';
 import {claude} from '../models';
-import {main} from '../models';
-import {teams} from '../models';
+import {git} from '../models';
 import {testing} from '../models';
 import {docker} from '../models';
-import {git} from '../models';
-import {iterm} from '../models';
+import {main} from '../models';
+import {teams} from '../models';
 import {structure} from '../models';
+import {forge} from '../models';
+import {iterm} from '../models';
+import {k8s} from '../models';
+
+export function AcknowledgeMemoryChanges(arg1:string):Promise<void>;
 
-export function AddApprovedClient(arg1:string):Promise<remote.ApprovedClient>;
+export function AddApprovedClient(arg1:string,arg2:boolean):Promise<remote.ApprovedClient>;
 
 export function AddBookmark(arg1:string,arg2:string,arg3:string):Promise<state.Bookmark>;
 
+export function AddGitignorePatterns(arg1:string,arg2:Array<string>):Promise<Array<string>>;
+
 export function AddHook(arg1:string,arg2:claude.Hook):Promise<void>;
 
 export function AddHookEntry(arg1:string,arg2:claude.HookEntry):Promise<void>;
 
 export function AddMCPServer(arg1:string,arg2:claude.MCPServer):Promise<void>;
 
+export function AddMarketplace(arg1:string):Promise<claude.Marketplace>;
+
+export function AddPermissionRule(arg1:string,arg2:claude.PermissionRule):Promise<void>;
+
 export function AddTestRun(arg1:string,arg2:state.TestRun):Promise<void>;
 
+export function AddUserMCPServer(arg1:claude.MCPServer):Promise<void>;
+
+export function ApplyProfile(arg1:string,arg2:string):Promise<claude.ProfileApplyReport>;
+
+export function ApproveRemoteAccessRequest(arg1:string,arg2:boolean):Promise<remote.ApprovedClient>;
+
+export function BanRemoteIP(arg1:string):Promise<void>;
+
+export function BeginApprovedClientTOTP(arg1:string):Promise<remote.TOTPEnrollment>;
+
+export function BuildImage(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function CancelClaudeJob(arg1:string):Promise<void>;
+
+export function CancelProjectSearch(arg1:string):Promise<void>;
+
+export function CheckGitIgnore(arg1:string,arg2:string):Promise<git.IgnoreCheck>;
+
 export function CheckLibraryStatus(arg1:string,arg2:Array<string>):Promise<Array<claude.LibStatus>>;
 
 export function CheckProjectCoverage(arg1:string):Promise<void>;
 
+export function CheckTestSnapshots(arg1:string):Promise<testing.SnapshotReport>;
+
+export function ClearClaudeJobs():Promise<void>;
+
+export function ClearHookLog(arg1:string):Promise<void>;
+
 export function ClearTestDiscoveryCache(arg1:string):Promise<void>;
 
 export function CloseITermTab(arg1:number,arg2:number):Promise<void>;
@@ -35,6 +69,14 @@ export function CloseITermTabBySessionID(arg1:string):Promise<void>;
 
 export function CloseTerminal(arg1:string):Promise<void>;
 
+export function ConfirmApprovedClientTOTP(arg1:string,arg2:string):Promise<void>;
+
+export function CopyFromContainer(arg1:string,arg2:string,arg3:string):Promise<docker.CopyResult>;
+
+export function CopyToContainer(arg1:string,arg2:string,arg3:string):Promise<docker.CopyResult>;
+
+export function CreateAgent(arg1:string,arg2:string,arg3:claude.AgentFrontmatter,arg4:string):Promise<claude.Agent>;
+
 export function CreateCommand(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function CreateGlobalPrompt(arg1:state.Prompt):Promise<state.Prompt>;
@@ -43,12 +85,16 @@ export function CreateHookScript(arg1:string,arg2:string,arg3:string):Promise<vo
 
 export function CreateITermTab(arg1:string,arg2:string):Promise<void>;
 
+export function CreateProfileFromProject(arg1:string,arg2:string,arg3:string):Promise<claude.ClaudeProfile>;
+
 export function CreateProject(arg1:string,arg2:string):Promise<state.ProjectState>;
 
 export function CreatePrompt(arg1:string,arg2:state.Prompt):Promise<state.Prompt>;
 
 export function CreatePromptCategory(arg1:string,arg2:string,arg3:boolean):Promise<state.PromptCategory>;
 
+export function CreateRule(arg1:string,arg2:string,arg3:string,arg4:boolean):Promise<claude.Rule>;
+
 export function CreateTerminal(arg1:string,arg2:string,arg3:string):Promise<main.TerminalInfo>;
 
 export function DeleteCommand(arg1:string):Promise<void>;
@@ -57,16 +103,46 @@ export function DeleteGlobalPrompt(arg1:string):Promise<void>;
 
 export function DeleteHookScript(arg1:string,arg2:string):Promise<void>;
 
+export function DeleteMCPSecret(arg1:string):Promise<void>;
+
+export function DeleteObsoleteSnapshots(arg1:string,arg2:Array<testing.SnapshotEntry>):Promise<number>;
+
+export function DeleteProfile(arg1:string):Promise<void>;
+
 export function DeleteProject(arg1:string):Promise<void>;
 
 export function DeletePrompt(arg1:string,arg2:string):Promise<void>;
 
 export function DeletePromptCategory(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
+export function DeleteRemoteRecording(arg1:string):Promise<void>;
+
+export function DeleteRule(arg1:string):Promise<void>;
+
 export function DeleteScreenshot(arg1:string,arg2:string):Promise<void>;
 
+export function DenyRemoteAccessRequest(arg1:string):Promise<void>;
+
+export function DetectDockerHosts():Promise<Array<docker.HostCandidate>>;
+
+export function DisableApprovedClientTOTP(arg1:string):Promise<void>;
+
+export function DockerRegistryLogin(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function DockerRegistryLogout(arg1:string):Promise<void>;
+
+export function ExportTestHistory(arg1:string,arg2:string):Promise<string>;
+
 export function FocusITerm():Promise<void>;
 
+export function FollowComposeLogs(arg1:string):Promise<Array<docker.ComposeLogService>>;
+
+export function FollowContainerLogs(arg1:string):Promise<void>;
+
+export function FollowPodLogs(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function GetActiveDockerContext():Promise<string>;
+
 export function GetActiveProject():Promise<string>;
 
 export function GetAgentContent(arg1:string):Promise<string>;
@@ -79,42 +155,108 @@ export function GetApprovedClients():Promise<Array<remote.ApprovedClient>>;
 
 export function GetAvailableSkills():Promise<Array<claude.Skill>>;
 
+export function GetBackgroundTasks(arg1:string):Promise<Array<claude.BackgroundTask>>;
+
 export function GetBookmarks(arg1:string):Promise<Array<state.Bookmark>>;
 
+export function GetChangeCoverage(arg1:string,arg2:string):Promise<testing.ChangeCoverage>;
+
+export function GetCheckpoints(arg1:string):Promise<Array<claude.Checkpoint>>;
+
+export function GetClaudeJob(arg1:string):Promise<claude.Job>;
+
+export function GetClaudeJobs(arg1:string):Promise<Array<claude.Job>>;
+
+export function GetClaudeVersion(arg1:boolean):Promise<claude.VersionInfo>;
+
 export function GetClaudemd(arg1:string):Promise<string>;
 
+export function GetClaudemdFile(arg1:string,arg2:string):Promise<string>;
+
+export function GetClaudemdHierarchy(arg1:string):Promise<Array<claude.ClaudemdFile>>;
+
+export function GetCodeTodos(arg1:string):Promise<Array<structure.CodeTodo>>;
+
 export function GetCommandContent(arg1:string):Promise<string>;
 
 export function GetContainerLogs(arg1:string):Promise<string>;
 
+export function GetContainerResources(arg1:string):Promise<docker.ContainerResources>;
+
 export function GetContainers(arg1:boolean):Promise<Array<docker.Container>>;
 
+export function GetCoverageGate(arg1:string):Promise<testing.CoverageGate>;
+
+export function GetCoverageThresholds(arg1:string):Promise<state.CoverageThresholds>;
+
 export function GetDashboardFullscreen():Promise<boolean>;
 
 export function GetDefaultColors():Promise<Array<string>>;
 
 export function GetDefaultIcons():Promise<Array<string>>;
 
+export function GetDependencyCycles(arg1:string):Promise<Array<structure.DependencyCycle>>;
+
+export function GetDevContainerConfig(arg1:string):Promise<docker.DevContainerConfig>;
+
+export function GetDockerContexts():Promise<Array<docker.DockerContext>>;
+
+export function GetDockerHost():Promise<Record<string, string>>;
+
+export function GetDockerImages():Promise<Array<docker.Image>>;
+
 export function GetDockerProjectContainers(arg1:string):Promise<Array<docker.Container>>;
 
+export function GetDockerRegistries():Promise<Array<state.DockerRegistry>>;
+
+export function GetDockerURLSuggestions(arg1:string):Promise<Array<docker.URLSuggestion>>;
+
+export function GetFileCoverage(arg1:string,arg2:string):Promise<testing.FileCoverage>;
+
+export function GetFlakyTests(arg1:string):Promise<Array<state.FlakyTest>>;
+
+export function GetGitBisectStatus(arg1:string):Promise<git.BisectStatus>;
+
 export function GetGitChangedFiles(arg1:string):Promise<Array<git.ChangedFile>>;
 
+export function GetGitCommitSignatures(arg1:string,arg2:number):Promise<Array<git.CommitSignature>>;
+
 export function GetGitCurrentBranch(arg1:string):Promise<string>;
 
 export function GetGitFileDiff(arg1:string,arg2:string):Promise<git.FileDiff>;
 
+export function GetGitFileDiffChunk(arg1:string,arg2:string,arg3:number,arg4:number):Promise<git.DiffChunk>;
+
 export function GetGitHistory(arg1:string,arg2:number):Promise<Array<git.CommitInfo>>;
 
+export function GetGitHookTemplates():Promise<Array<git.HookTemplate>>;
+
+export function GetGitHooks(arg1:string):Promise<git.HooksInfo>;
+
+export function GetGitIdentity(arg1:string):Promise<git.IdentityStatus>;
+
+export function GetGitRemoteInfo(arg1:string):Promise<forge.RemoteInfo>;
+
+export function GetGitRepoRoots(arg1:string):Promise<Array<git.RepoRoot>>;
+
 export function GetGitStatus(arg1:string):Promise<Record<string, number>>;
 
+export function GetGitignore(arg1:string):Promise<Array<git.GitignoreEntry>>;
+
 export function GetGlobalAgents():Promise<Array<claude.Agent>>;
 
 export function GetGlobalCommands():Promise<Array<claude.Command>>;
 
+export function GetGlobalOutputStyles():Promise<Array<claude.OutputStyle>>;
+
 export function GetGlobalPromptCategories():Promise<Array<state.PromptCategory>>;
 
 export function GetGlobalPrompts():Promise<Array<state.Prompt>>;
 
+export function GetGlobalRules():Promise<Array<claude.Rule>>;
+
+export function GetHookLog(arg1:string,arg2:number):Promise<Array<claude.HookInvocation>>;
+
 export function GetHookScriptContent(arg1:string,arg2:string):Promise<string>;
 
 export function GetITermSessionContents(arg1:number):Promise<string>;
@@ -125,14 +267,46 @@ export function GetITermSessionInfo():Promise<iterm.SessionInfo>;
 
 export function GetITermStatus():Promise<iterm.ITermStatus>;
 
-export function GetInstalledSkills(arg1:string):Promise<Array<string>>;
+export function GetIgnorePatterns(arg1:string):Promise<Array<string>>;
+
+export function GetInstalledSkills(arg1:string):Promise<Array<claude.InstalledSkill>>;
+
+export function GetK8sContexts():Promise<Array<k8s.KubeContext>>;
+
+export function GetK8sDeployments(arg1:string):Promise<Array<k8s.Deployment>>;
+
+export function GetK8sPods(arg1:string):Promise<Array<k8s.Pod>>;
+
+export function GetMCPSecrets():Promise<Array<string>>;
+
+export function GetMarketplaces():Promise<Array<claude.Marketplace>>;
+
+export function GetMemoryChanges(arg1:string):Promise<Array<claude.MemoryChange>>;
+
+export function GetMemoryContent(arg1:string,arg2:string):Promise<string>;
+
+export function GetMemoryFiles(arg1:string):Promise<Array<claude.MemoryFile>>;
+
+export function GetMergeRequests(arg1:string):Promise<Array<forge.MergeRequest>>;
+
+export function GetMutationSummary(arg1:string):Promise<testing.MutationSummary>;
+
+export function GetNodeChildren(arg1:string):Promise<Array<structure.FileNode>>;
 
 export function GetNotes(arg1:string):Promise<string>;
 
+export function GetOrphanFiles(arg1:string):Promise<Array<string>>;
+
+export function GetOutputStyleContent(arg1:string):Promise<string>;
+
 export function GetPackageJSONScripts(arg1:string):Promise<Record<string, string>>;
 
+export function GetPipelineStatus(arg1:string):Promise<forge.PipelineStatus>;
+
 export function GetPomodoroSettings():Promise<state.PomodoroSettings>;
 
+export function GetProfiles():Promise<Array<claude.ClaudeProfile>>;
+
 export function GetProject(arg1:string):Promise<state.ProjectState>;
 
 export function GetProjectAgents(arg1:string):Promise<Array<claude.Agent>>;
@@ -153,10 +327,20 @@ export function GetProjectHooks(arg1:string):Promise<Array<claude.Hook>>;
 
 export function GetProjectHooksDetailed(arg1:string):Promise<Array<claude.HookEntry>>;
 
+export function GetProjectK8sTarget(arg1:string):Promise<k8s.Target>;
+
 export function GetProjectMCPServers(arg1:string):Promise<Array<claude.MCPServer>>;
 
+export function GetProjectOutputStyles(arg1:string):Promise<Array<claude.OutputStyle>>;
+
+export function GetProjectPermissions(arg1:string):Promise<Array<claude.PermissionRule>>;
+
 export function GetProjectPrompts(arg1:string):Promise<Array<state.Prompt>>;
 
+export function GetProjectRules(arg1:string):Promise<Array<claude.Rule>>;
+
+export function GetProjectServicesHealth(arg1:string):Promise<docker.ServicesHealth>;
+
 export function GetProjectStructure(arg1:string):Promise<structure.FileNode>;
 
 export function GetProjectTerminals(arg1:string):Promise<Array<main.TerminalInfo>>;
@@ -167,12 +351,36 @@ export function GetPromptCategories(arg1:string):Promise<Array<state.PromptCateg
 
 export function GetRemoteAccessClients():Promise<Array<remote.ClientInfo>>;
 
+export function GetRemoteAccessQRCode(arg1:string):Promise<string>;
+
+export function GetRemoteAccessRequests():Promise<Array<remote.AccessRequest>>;
+
 export function GetRemoteAccessStatus():Promise<main.RemoteAccessStatus>;
 
+export function GetRemoteAuditLog(arg1:number):Promise<Array<remote.AuditEntry>>;
+
+export function GetRemoteAuthAttempts():Promise<Array<remote.AuthAttemptInfo>>;
+
+export function GetRemoteBannedIPs():Promise<Array<remote.BannedIP>>;
+
+export function GetRemoteRecordings():Promise<Array<remote.RecordingInfo>>;
+
+export function GetRepoStats(arg1:string,arg2:number):Promise<git.RepoStats>;
+
+export function GetResumableSessions(arg1:string):Promise<Array<claude.ResumableSession>>;
+
+export function GetRuleContent(arg1:string):Promise<string>;
+
 export function GetScreenshots(arg1:string):Promise<Array<main.Screenshot>>;
 
+export function GetSkillUpgradeDiff(arg1:string,arg2:string):Promise<Array<claude.SkillFileDiff>>;
+
 export function GetState():Promise<state.AppState>;
 
+export function GetStatusLine(arg1:string,arg2:string):Promise<claude.StatusLine>;
+
+export function GetSubagentSessions():Promise<Array<teams.SessionSubagents>>;
+
 export function GetTeamHistory():Promise<Array<teams.TeamHistoryEntry>>;
 
 export function GetTemplateAgents():Promise<Array<claude.TemplateItem>>;
@@ -185,6 +393,8 @@ export function GetTemplateHooks():Promise<Array<claude.HookEntry>>;
 
 export function GetTemplateMCPServers():Promise<Array<claude.MCPServer>>;
 
+export function GetTemplateOutputStyles():Promise<Array<claude.TemplateItem>>;
+
 export function GetTemplateRepoPath():Promise<string>;
 
 export function GetTemplateRules():Promise<Array<claude.TemplateItem>>;
@@ -199,10 +409,20 @@ export function GetTerminals():Promise<Array<main.TerminalInfo>>;
 
 export function GetTestDiscovery(arg1:string):Promise<testing.TestDiscovery>;
 
+export function GetTestDurationReport(arg1:string,arg2:number):Promise<state.TestDurationReport>;
+
 export function GetTestHistory(arg1:string):Promise<Array<state.TestRun>>;
 
+export function GetTestHistoryLimit(arg1:string):Promise<number>;
+
+export function GetTestNotifications(arg1:string):Promise<string>;
+
+export function GetTestShardRun(arg1:string):Promise<testing.ShardRun>;
+
 export function GetTestSummary(arg1:string):Promise<testing.TestSummary>;
 
+export function GetTestWatchResult(arg1:string):Promise<testing.RerunResult>;
+
 export function GetTodos(arg1:string):Promise<Array<state.TodoItem>>;
 
 export function GetToolsPanelHeight():Promise<number>;
@@ -213,11 +433,29 @@ export function GetVoiceAutoSubmit():Promise<boolean>;
 
 export function GetVoiceLang():Promise<string>;
 
+export function GitAbortCherryPick(arg1:string):Promise<void>;
+
+export function GitAbortRevert(arg1:string):Promise<void>;
+
+export function GitCherryPick(arg1:string,arg2:string):Promise<git.PickResult>;
+
+export function GitRevert(arg1:string,arg2:string):Promise<git.PickResult>;
+
+export function IgnoreGitFile(arg1:string,arg2:string):Promise<void>;
+
+export function ImportCodeTodos(arg1:string):Promise<number>;
+
 export function IncrementPromptUsage(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
+export function InspectContainer(arg1:string):Promise<docker.ContainerDetails>;
+
+export function InstallGitHookTemplate(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
 export function InstallHook(arg1:string,arg2:string):Promise<void>;
 
-export function InstallSkill(arg1:string,arg2:string):Promise<void>;
+export function InstallOutputStyle(arg1:string,arg2:string,arg3:boolean):Promise<void>;
+
+export function InstallSkill(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function InstallTemplateAgent(arg1:string,arg2:string):Promise<void>;
 
@@ -237,14 +475,44 @@ export function IsDockerAvailable():Promise<boolean>;
 
 export function IsGitRepo(arg1:string):Promise<boolean>;
 
+export function IsHookLoggingEnabled(arg1:string):Promise<boolean>;
+
+export function IsK8sAvailable():Promise<boolean>;
+
 export function IsTestRunning():Promise<boolean>;
 
+export function IsTestWatchActive(arg1:string):Promise<boolean>;
+
+export function KillBackgroundTask(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function LaunchITerm():Promise<void>;
 
+export function ListContainerFiles(arg1:string,arg2:string):Promise<Array<docker.ContainerFile>>;
+
 export function Log(arg1:string,arg2:string,arg3:string,arg4:Record<string, any>):Promise<void>;
 
+export function MarkGitBisect(arg1:string,arg2:string):Promise<git.BisectStatus>;
+
+export function OpenContainerShell(arg1:string):Promise<main.TerminalInfo>;
+
+export function OpenDevContainerShell(arg1:string):Promise<main.TerminalInfo>;
+
+export function OpenPodShell(arg1:string,arg2:string,arg3:string):Promise<main.TerminalInfo>;
+
+export function OpenTestFailure(arg1:string,arg2:testing.TestFailure):Promise<void>;
+
 export function PauseTerminal(arg1:string):Promise<void>;
 
+export function PreviewCommand(arg1:string,arg2:string):Promise<claude.CommandPreview>;
+
+export function PruneDockerImages(arg1:boolean):Promise<docker.PruneResult>;
+
+export function PruneDockerSystem(arg1:boolean,arg2:boolean,arg3:boolean):Promise<docker.SystemPruneReport>;
+
+export function PullDockerImage(arg1:string):Promise<void>;
+
+export function PushImage(arg1:string):Promise<void>;
+
 export function ReadFileContent(arg1:string):Promise<string>;
 
 export function RefreshNgrokURL():Promise<string>;
@@ -261,44 +529,90 @@ export function RemoveApprovedClient(arg1:string):Promise<void>;
 
 export function RemoveBookmark(arg1:string,arg2:string):Promise<void>;
 
+export function RemoveContainer(arg1:string,arg2:boolean):Promise<void>;
+
+export function RemoveDockerImage(arg1:string,arg2:boolean):Promise<Array<string>>;
+
+export function RemoveGitHook(arg1:string,arg2:string):Promise<void>;
+
 export function RemoveHook(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function RemoveMCPServer(arg1:string,arg2:string):Promise<void>;
 
+export function RemoveMarketplace(arg1:string):Promise<void>;
+
+export function RemovePermissionRule(arg1:string,arg2:claude.PermissionRule):Promise<void>;
+
+export function RemoveUserMCPServer(arg1:string):Promise<void>;
+
 export function RenameITermTab(arg1:number,arg2:number,arg3:string):Promise<void>;
 
 export function RenameITermTabBySessionID(arg1:string,arg2:string):Promise<void>;
 
+export function RenameProfile(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function RequestStyledHistory(arg1:string):Promise<void>;
 
+export function ResetGitBisect(arg1:string):Promise<void>;
+
 export function ResetTestState(arg1:string):Promise<void>;
 
 export function ResizeTerminal(arg1:string,arg2:number,arg3:number):Promise<void>;
 
+export function ResolveMCPSecrets(arg1:claude.MCPServer):Promise<claude.MCPServer>;
+
 export function RestartContainer(arg1:string):Promise<void>;
 
+export function RestoreCheckpoint(arg1:string,arg2:string,arg3:string):Promise<Array<claude.CheckpointFile>>;
+
+export function ResumeClaudeSession(arg1:string,arg2:string):Promise<main.TerminalInfo>;
+
 export function ResumeTerminal(arg1:string):Promise<void>;
 
+export function RunClaudeJob(arg1:string,arg2:string,arg3:string,arg4:Array<string>):Promise<claude.Job>;
+
+export function RunGitBisect(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function RunMutationTests(arg1:string):Promise<void>;
+
+export function SampleHookPayload(arg1:string,arg2:string,arg3:string):Promise<string>;
+
 export function SaveAgentContent(arg1:string,arg2:string):Promise<void>;
 
 export function SaveClaudemd(arg1:string,arg2:string):Promise<void>;
 
+export function SaveClaudemdFile(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function SaveCommandContent(arg1:string,arg2:string):Promise<void>;
 
 export function SaveFileContent(arg1:string,arg2:string):Promise<void>;
 
+export function SaveMemoryContent(arg1:string,arg2:string,arg3:string):Promise<void>;
+
 export function SaveNotes(arg1:string,arg2:string):Promise<void>;
 
+export function SaveOutputStyleContent(arg1:string,arg2:string):Promise<void>;
+
 export function SavePomodoroSettings(arg1:number,arg2:number):Promise<void>;
 
+export function SaveRemoteAuditLogExport(arg1:string):Promise<string>;
+
+export function SaveRuleContent(arg1:string,arg2:string):Promise<void>;
+
 export function SaveScreenshot(arg1:string,arg2:string,arg3:string):Promise<string>;
 
 export function SaveTestHistory(arg1:string,arg2:Array<state.TestRun>):Promise<void>;
 
+export function SaveTestHistoryExport(arg1:string,arg2:string):Promise<string>;
+
 export function SaveTodos(arg1:string,arg2:Array<state.TodoItem>):Promise<void>;
 
 export function ScanProjectTests(arg1:string):Promise<testing.TestDiscovery>;
 
+export function SearchProject(arg1:string,arg2:string,arg3:structure.SearchOptions):Promise<structure.SearchResult>;
+
+export function SecureMCPEnv(arg1:claude.MCPServer):Promise<claude.MCPServer>;
+
 export function SelectDirectory():Promise<string>;
 
 export function SendITermSpecialKey(arg1:string,arg2:string):Promise<void>;
@@ -307,56 +621,132 @@ export function SetActiveProject(arg1:string):Promise<void>;
 
 export function SetActiveTerminal(arg1:string,arg2:string):Promise<void>;
 
+export function SetApprovedClientScope(arg1:string,arg2:Array<string>,arg3:Array<string>):Promise<void>;
+
+export function SetCoverageThresholds(arg1:string,arg2:state.CoverageThresholds):Promise<void>;
+
 export function SetDashboardFullscreen(arg1:boolean):Promise<void>;
 
+export function SetDockerHost(arg1:string):Promise<void>;
+
+export function SetGitIdentity(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetHookLogging(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetIgnorePatterns(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function SetMCPSecret(arg1:string,arg2:string):Promise<void>;
+
+export function SetProjectDockerContext(arg1:string,arg2:string):Promise<void>;
+
+export function SetProjectK8sTarget(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetStatusLine(arg1:string,arg2:string,arg3:claude.StatusLine):Promise<void>;
+
 export function SetTerminalFontSize(arg1:number):Promise<void>;
 
 export function SetTerminalTheme(arg1:string):Promise<void>;
 
+export function SetTestHistoryLimit(arg1:string,arg2:number):Promise<void>;
+
+export function SetTestNotifications(arg1:string,arg2:string):Promise<void>;
+
 export function SetToolsPanelHeight(arg1:number):Promise<void>;
 
 export function SetVoiceAutoSubmit(arg1:boolean):Promise<void>;
 
 export function SetVoiceLang(arg1:string):Promise<void>;
 
+export function SetWindowFocused(arg1:boolean):Promise<void>;
+
 export function StartContainer(arg1:string):Promise<void>;
 
+export function StartDevContainer(arg1:string):Promise<void>;
+
+export function StartGitBisect(arg1:string,arg2:string,arg3:string):Promise<git.BisectStatus>;
+
 export function StartRemoteAccess(arg1:remote.Config):Promise<main.RemoteAccessStatus>;
 
 export function StartTeamsPolling():Promise<void>;
 
+export function StartTestShards(arg1:string,arg2:number,arg3:string):Promise<testing.ShardRun>;
+
+export function StartTestWatch(arg1:string):Promise<void>;
+
 export function StartVoiceRecognition(arg1:string):Promise<string>;
 
+export function StopComposeLogs(arg1:string):Promise<void>;
+
 export function StopContainer(arg1:string):Promise<void>;
 
+export function StopContainerLogs(arg1:string):Promise<void>;
+
+export function StopGitBisectRun(arg1:string):Promise<void>;
+
+export function StopMutationTests(arg1:string):Promise<void>;
+
+export function StopPodLogs(arg1:string,arg2:string):Promise<void>;
+
 export function StopRemoteAccess():Promise<void>;
 
 export function StopTeamsPolling():Promise<void>;
 
+export function StopTestShards(arg1:string):Promise<void>;
+
+export function StopTestWatch(arg1:string):Promise<void>;
+
 export function StopVoiceRecognition():Promise<void>;
 
 export function SwitchITermTab(arg1:number,arg2:number):Promise<void>;
 
 export function SwitchITermTabBySessionID(arg1:string):Promise<void>;
 
+export function TestHook(arg1:string,arg2:claude.HookEntry,arg3:string):Promise<Array<claude.HookTestResult>>;
+
+export function TestMCPServer(arg1:claude.MCPServer):Promise<claude.MCPTestResult>;
+
+export function TestStatusLine(arg1:string,arg2:string):Promise<claude.StatusLineTestResult>;
+
 export function TogglePromptPinned(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
+export function UnbanRemoteIP(arg1:string):Promise<void>;
+
+export function UninstallSkill(arg1:string,arg2:string):Promise<void>;
+
+export function UnlockRemoteIP(arg1:string):Promise<void>;
+
 export function UnwatchITermSession():Promise<void>;
 
 export function UnwatchProjectCoverage(arg1:string):Promise<void>;
 
+export function UnwatchProjectStructure(arg1:string):Promise<void>;
+
 export function UpdateBrowserState(arg1:string,arg2:string,arg3:number,arg4:boolean,arg5:number):Promise<void>;
 
 export function UpdateBrowserTabs(arg1:string,arg2:Array<state.BrowserTab>,arg3:string):Promise<void>;
 
+export function UpdateClaudeCLI(arg1:string):Promise<main.TerminalInfo>;
+
+export function UpdateContainerResources(arg1:string,arg2:docker.ContainerResources):Promise<Array<string>>;
+
 export function UpdateGlobalPrompt(arg1:string,arg2:state.Prompt):Promise<void>;
 
 export function UpdateProject(arg1:state.ProjectState):Promise<void>;
 
 export function UpdatePrompt(arg1:string,arg2:string,arg3:state.Prompt):Promise<void>;
 
+export function UpdateSkill(arg1:string,arg2:string):Promise<claude.SkillUpdateReport>;
+
+export function UpdateTestSnapshots(arg1:string,arg2:Array<testing.SnapshotEntry>):Promise<void>;
+
 export function UpdateUIState(arg1:string,arg2:string,arg3:boolean,arg4:number):Promise<void>;
 
+export function UpdateUserMCPServer(arg1:string,arg2:claude.MCPServer):Promise<void>;
+
+export function ValidateAgentContent(arg1:string):Promise<Array<claude.AgentIssue>>;
+
+export function ValidateGitignorePattern(arg1:string):Promise<string>;
+
 export function WatchITermSession(arg1:string):Promise<string>;
 
 export function WatchProjectCoverage(arg1:string):Promise<void>;
@@ -2,14 +2,22 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function AddApprovedClient(arg1) {
-  return window['go']['main']['App']['AddApprovedClient'](arg1);
+export function AcknowledgeMemoryChanges(arg1) {
+  return window['go']['main']['App']['AcknowledgeMemoryChanges'](arg1);
+}
+
+export function AddApprovedClient(arg1, arg2) {
+  return window['go']['main']['App']['AddApprovedClient'](arg1, arg2);
 }
 
 export function AddBookmark(arg1, arg2, arg3) {
   return window['go']['main']['App']['AddBookmark'](arg1, arg2, arg3);
 }
 
+export function AddGitignorePatterns(arg1, arg2) {
+  return window['go']['main']['App']['AddGitignorePatterns'](arg1, arg2);
+}
+
 export function AddHook(arg1, arg2) {
   return window['go']['main']['App']['AddHook'](arg1, arg2);
 }
@@ -22,10 +30,54 @@ export function AddMCPServer(arg1, arg2) {
   return window['go']['main']['App']['AddMCPServer'](arg1, arg2);
 }
 
+export function AddMarketplace(arg1) {
+  return window['go']['main']['App']['AddMarketplace'](arg1);
+}
+
+export function AddPermissionRule(arg1, arg2) {
+  return window['go']['main']['App']['AddPermissionRule'](arg1, arg2);
+}
+
 export function AddTestRun(arg1, arg2) {
   return window['go']['main']['App']['AddTestRun'](arg1, arg2);
 }
 
+export function AddUserMCPServer(arg1) {
+  return window['go']['main']['App']['AddUserMCPServer'](arg1);
+}
+
+export function ApplyProfile(arg1, arg2) {
+  return window['go']['main']['App']['ApplyProfile'](arg1, arg2);
+}
+
+export function ApproveRemoteAccessRequest(arg1, arg2) {
+  return window['go']['main']['App']['ApproveRemoteAccessRequest'](arg1, arg2);
+}
+
+export function BanRemoteIP(arg1) {
+  return window['go']['main']['App']['BanRemoteIP'](arg1);
+}
+
+export function BeginApprovedClientTOTP(arg1) {
+  return window['go']['main']['App']['BeginApprovedClientTOTP'](arg1);
+}
+
+export function BuildImage(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BuildImage'](arg1, arg2, arg3);
+}
+
+export function CancelClaudeJob(arg1) {
+  return window['go']['main']['App']['CancelClaudeJob'](arg1);
+}
+
+export function CancelProjectSearch(arg1) {
+  return window['go']['main']['App']['CancelProjectSearch'](arg1);
+}
+
+export function CheckGitIgnore(arg1, arg2) {
+  return window['go']['main']['App']['CheckGitIgnore'](arg1, arg2);
+}
+
 export function CheckLibraryStatus(arg1, arg2) {
   return window['go']['main']['App']['CheckLibraryStatus'](arg1, arg2);
 }
@@ -34,6 +86,18 @@ export function CheckProjectCoverage(arg1) {
   return window['go']['main']['App']['CheckProjectCoverage'](arg1);
 }
 
+export function CheckTestSnapshots(arg1) {
+  return window['go']['main']['App']['CheckTestSnapshots'](arg1);
+}
+
+export function ClearClaudeJobs() {
+  return window['go']['main']['App']['ClearClaudeJobs']();
+}
+
+export function ClearHookLog(arg1) {
+  return window['go']['main']['App']['ClearHookLog'](arg1);
+}
+
 export function ClearTestDiscoveryCache(arg1) {
   return window['go']['main']['App']['ClearTestDiscoveryCache'](arg1);
 }
@@ -50,6 +114,22 @@ export function CloseTerminal(arg1) {
   return window['go']['main']['App']['CloseTerminal'](arg1);
 }
 
+export function ConfirmApprovedClientTOTP(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmApprovedClientTOTP'](arg1, arg2);
+}
+
+export function CopyFromContainer(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CopyFromContainer'](arg1, arg2, arg3);
+}
+
+export function CopyToContainer(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CopyToContainer'](arg1, arg2, arg3);
+}
+
+export function CreateAgent(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateAgent'](arg1, arg2, arg3, arg4);
+}
+
 export function CreateCommand(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreateCommand'](arg1, arg2, arg3);
 }
@@ -66,6 +146,10 @@ export function CreateITermTab(arg1, arg2) {
   return window['go']['main']['App']['CreateITermTab'](arg1, arg2);
 }
 
+export function CreateProfileFromProject(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateProfileFromProject'](arg1, arg2, arg3);
+}
+
 export function CreateProject(arg1, arg2) {
   return window['go']['main']['App']['CreateProject'](arg1, arg2);
 }
@@ -78,6 +162,10 @@ export function CreatePromptCategory(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreatePromptCategory'](arg1, arg2, arg3);
 }
 
+export function CreateRule(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateRule'](arg1, arg2, arg3, arg4);
+}
+
 export function CreateTerminal(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreateTerminal'](arg1, arg2, arg3);
 }
@@ -94,6 +182,18 @@ export function DeleteHookScript(arg1, arg2) {
   return window['go']['main']['App']['DeleteHookScript'](arg1, arg2);
 }
 
+export function DeleteMCPSecret(arg1) {
+  return window['go']['main']['App']['DeleteMCPSecret'](arg1);
+}
+
+export function DeleteObsoleteSnapshots(arg1, arg2) {
+  return window['go']['main']['App']['DeleteObsoleteSnapshots'](arg1, arg2);
+}
+
+export function DeleteProfile(arg1) {
+  return window['go']['main']['App']['DeleteProfile'](arg1);
+}
+
 export function DeleteProject(arg1) {
   return window['go']['main']['App']['DeleteProject'](arg1);
 }
@@ -106,14 +206,62 @@ export function DeletePromptCategory(arg1, arg2, arg3) {
   return window['go']['main']['App']['DeletePromptCategory'](arg1, arg2, arg3);
 }
 
+export function DeleteRemoteRecording(arg1) {
+  return window['go']['main']['App']['DeleteRemoteRecording'](arg1);
+}
+
+export function DeleteRule(arg1) {
+  return window['go']['main']['App']['DeleteRule'](arg1);
+}
+
 export function DeleteScreenshot(arg1, arg2) {
   return window['go']['main']['App']['DeleteScreenshot'](arg1, arg2);
 }
 
+export function DenyRemoteAccessRequest(arg1) {
+  return window['go']['main']['App']['DenyRemoteAccessRequest'](arg1);
+}
+
+export function DetectDockerHosts() {
+  return window['go']['main']['App']['DetectDockerHosts']();
+}
+
+export function DisableApprovedClientTOTP(arg1) {
+  return window['go']['main']['App']['DisableApprovedClientTOTP'](arg1);
+}
+
+export function DockerRegistryLogin(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DockerRegistryLogin'](arg1, arg2, arg3);
+}
+
+export function DockerRegistryLogout(arg1) {
+  return window['go']['main']['App']['DockerRegistryLogout'](arg1);
+}
+
+export function ExportTestHistory(arg1, arg2) {
+  return window['go']['main']['App']['ExportTestHistory'](arg1, arg2);
+}
+
 export function FocusITerm() {
   return window['go']['main']['App']['FocusITerm']();
 }
 
+export function FollowComposeLogs(arg1) {
+  return window['go']['main']['App']['FollowComposeLogs'](arg1);
+}
+
+export function FollowContainerLogs(arg1) {
+  return window['go']['main']['App']['FollowContainerLogs'](arg1);
+}
+
+export function FollowPodLogs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['FollowPodLogs'](arg1, arg2, arg3);
+}
+
+export function GetActiveDockerContext() {
+  return window['go']['main']['App']['GetActiveDockerContext']();
+}
+
 export function GetActiveProject() {
   return window['go']['main']['App']['GetActiveProject']();
 }
@@ -138,14 +286,50 @@ export function GetAvailableSkills() {
   return window['go']['main']['App']['GetAvailableSkills']();
 }
 
+export function GetBackgroundTasks(arg1) {
+  return window['go']['main']['App']['GetBackgroundTasks'](arg1);
+}
+
 export function GetBookmarks(arg1) {
   return window['go']['main']['App']['GetBookmarks'](arg1);
 }
 
+export function GetChangeCoverage(arg1, arg2) {
+  return window['go']['main']['App']['GetChangeCoverage'](arg1, arg2);
+}
+
+export function GetCheckpoints(arg1) {
+  return window['go']['main']['App']['GetCheckpoints'](arg1);
+}
+
+export function GetClaudeJob(arg1) {
+  return window['go']['main']['App']['GetClaudeJob'](arg1);
+}
+
+export function GetClaudeJobs(arg1) {
+  return window['go']['main']['App']['GetClaudeJobs'](arg1);
+}
+
+export function GetClaudeVersion(arg1) {
+  return window['go']['main']['App']['GetClaudeVersion'](arg1);
+}
+
 export function GetClaudemd(arg1) {
   return window['go']['main']['App']['GetClaudemd'](arg1);
 }
 
+export function GetClaudemdFile(arg1, arg2) {
+  return window['go']['main']['App']['GetClaudemdFile'](arg1, arg2);
+}
+
+export function GetClaudemdHierarchy(arg1) {
+  return window['go']['main']['App']['GetClaudemdHierarchy'](arg1);
+}
+
+export function GetCodeTodos(arg1) {
+  return window['go']['main']['App']['GetCodeTodos'](arg1);
+}
+
 export function GetCommandContent(arg1) {
   return window['go']['main']['App']['GetCommandContent'](arg1);
 }
@@ -154,10 +338,22 @@ export function GetContainerLogs(arg1) {
   return window['go']['main']['App']['GetContainerLogs'](arg1);
 }
 
+export function GetContainerResources(arg1) {
+  return window['go']['main']['App']['GetContainerResources'](arg1);
+}
+
 export function GetContainers(arg1) {
   return window['go']['main']['App']['GetContainers'](arg1);
 }
 
+export function GetCoverageGate(arg1) {
+  return window['go']['main']['App']['GetCoverageGate'](arg1);
+}
+
+export function GetCoverageThresholds(arg1) {
+  return window['go']['main']['App']['GetCoverageThresholds'](arg1);
+}
+
 export function GetDashboardFullscreen() {
   return window['go']['main']['App']['GetDashboardFullscreen']();
 }
@@ -170,14 +366,58 @@ export function GetDefaultIcons() {
   return window['go']['main']['App']['GetDefaultIcons']();
 }
 
+export function GetDependencyCycles(arg1) {
+  return window['go']['main']['App']['GetDependencyCycles'](arg1);
+}
+
+export function GetDevContainerConfig(arg1) {
+  return window['go']['main']['App']['GetDevContainerConfig'](arg1);
+}
+
+export function GetDockerContexts() {
+  return window['go']['main']['App']['GetDockerContexts']();
+}
+
+export function GetDockerHost() {
+  return window['go']['main']['App']['GetDockerHost']();
+}
+
+export function GetDockerImages() {
+  return window['go']['main']['App']['GetDockerImages']();
+}
+
 export function GetDockerProjectContainers(arg1) {
   return window['go']['main']['App']['GetDockerProjectContainers'](arg1);
 }
 
+export function GetDockerRegistries() {
+  return window['go']['main']['App']['GetDockerRegistries']();
+}
+
+export function GetDockerURLSuggestions(arg1) {
+  return window['go']['main']['App']['GetDockerURLSuggestions'](arg1);
+}
+
+export function GetFileCoverage(arg1, arg2) {
+  return window['go']['main']['App']['GetFileCoverage'](arg1, arg2);
+}
+
+export function GetFlakyTests(arg1) {
+  return window['go']['main']['App']['GetFlakyTests'](arg1);
+}
+
+export function GetGitBisectStatus(arg1) {
+  return window['go']['main']['App']['GetGitBisectStatus'](arg1);
+}
+
 export function GetGitChangedFiles(arg1) {
   return window['go']['main']['App']['GetGitChangedFiles'](arg1);
 }
 
+export function GetGitCommitSignatures(arg1, arg2) {
+  return window['go']['main']['App']['GetGitCommitSignatures'](arg1, arg2);
+}
+
 export function GetGitCurrentBranch(arg1) {
   return window['go']['main']['App']['GetGitCurrentBranch'](arg1);
 }
@@ -186,14 +426,42 @@ export function GetGitFileDiff(arg1, arg2) {
   return window['go']['main']['App']['GetGitFileDiff'](arg1, arg2);
 }
 
+export function GetGitFileDiffChunk(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetGitFileDiffChunk'](arg1, arg2, arg3, arg4);
+}
+
 export function GetGitHistory(arg1, arg2) {
   return window['go']['main']['App']['GetGitHistory'](arg1, arg2);
 }
 
+export function GetGitHookTemplates() {
+  return window['go']['main']['App']['GetGitHookTemplates']();
+}
+
+export function GetGitHooks(arg1) {
+  return window['go']['main']['App']['GetGitHooks'](arg1);
+}
+
+export function GetGitIdentity(arg1) {
+  return window['go']['main']['App']['GetGitIdentity'](arg1);
+}
+
+export function GetGitRemoteInfo(arg1) {
+  return window['go']['main']['App']['GetGitRemoteInfo'](arg1);
+}
+
+export function GetGitRepoRoots(arg1) {
+  return window['go']['main']['App']['GetGitRepoRoots'](arg1);
+}
+
 export function GetGitStatus(arg1) {
   return window['go']['main']['App']['GetGitStatus'](arg1);
 }
 
+export function GetGitignore(arg1) {
+  return window['go']['main']['App']['GetGitignore'](arg1);
+}
+
 export function GetGlobalAgents() {
   return window['go']['main']['App']['GetGlobalAgents']();
 }
@@ -202,6 +470,10 @@ export function GetGlobalCommands() {
   return window['go']['main']['App']['GetGlobalCommands']();
 }
 
+export function GetGlobalOutputStyles() {
+  return window['go']['main']['App']['GetGlobalOutputStyles']();
+}
+
 export function GetGlobalPromptCategories() {
   return window['go']['main']['App']['GetGlobalPromptCategories']();
 }
@@ -210,6 +482,14 @@ export function GetGlobalPrompts() {
   return window['go']['main']['App']['GetGlobalPrompts']();
 }
 
+export function GetGlobalRules() {
+  return window['go']['main']['App']['GetGlobalRules']();
+}
+
+export function GetHookLog(arg1, arg2) {
+  return window['go']['main']['App']['GetHookLog'](arg1, arg2);
+}
+
 export function GetHookScriptContent(arg1, arg2) {
   return window['go']['main']['App']['GetHookScriptContent'](arg1, arg2);
 }
@@ -230,22 +510,86 @@ export function GetITermStatus() {
   return window['go']['main']['App']['GetITermStatus']();
 }
 
+export function GetIgnorePatterns(arg1) {
+  return window['go']['main']['App']['GetIgnorePatterns'](arg1);
+}
+
 export function GetInstalledSkills(arg1) {
   return window['go']['main']['App']['GetInstalledSkills'](arg1);
 }
 
+export function GetK8sContexts() {
+  return window['go']['main']['App']['GetK8sContexts']();
+}
+
+export function GetK8sDeployments(arg1) {
+  return window['go']['main']['App']['GetK8sDeployments'](arg1);
+}
+
+export function GetK8sPods(arg1) {
+  return window['go']['main']['App']['GetK8sPods'](arg1);
+}
+
+export function GetMCPSecrets() {
+  return window['go']['main']['App']['GetMCPSecrets']();
+}
+
+export function GetMarketplaces() {
+  return window['go']['main']['App']['GetMarketplaces']();
+}
+
+export function GetMemoryChanges(arg1) {
+  return window['go']['main']['App']['GetMemoryChanges'](arg1);
+}
+
+export function GetMemoryContent(arg1, arg2) {
+  return window['go']['main']['App']['GetMemoryContent'](arg1, arg2);
+}
+
+export function GetMemoryFiles(arg1) {
+  return window['go']['main']['App']['GetMemoryFiles'](arg1);
+}
+
+export function GetMergeRequests(arg1) {
+  return window['go']['main']['App']['GetMergeRequests'](arg1);
+}
+
+export function GetMutationSummary(arg1) {
+  return window['go']['main']['App']['GetMutationSummary'](arg1);
+}
+
+export function GetNodeChildren(arg1) {
+  return window['go']['main']['App']['GetNodeChildren'](arg1);
+}
+
 export function GetNotes(arg1) {
   return window['go']['main']['App']['GetNotes'](arg1);
 }
 
+export function GetOrphanFiles(arg1) {
+  return window['go']['main']['App']['GetOrphanFiles'](arg1);
+}
+
+export function GetOutputStyleContent(arg1) {
+  return window['go']['main']['App']['GetOutputStyleContent'](arg1);
+}
+
 export function GetPackageJSONScripts(arg1) {
   return window['go']['main']['App']['GetPackageJSONScripts'](arg1);
 }
 
+export function GetPipelineStatus(arg1) {
+  return window['go']['main']['App']['GetPipelineStatus'](arg1);
+}
+
 export function GetPomodoroSettings() {
   return window['go']['main']['App']['GetPomodoroSettings']();
 }
 
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
+}
+
 export function GetProject(arg1) {
   return window['go']['main']['App']['GetProject'](arg1);
 }
@@ -286,14 +630,34 @@ export function GetProjectHooksDetailed(arg1) {
   return window['go']['main']['App']['GetProjectHooksDetailed'](arg1);
 }
 
+export function GetProjectK8sTarget(arg1) {
+  return window['go']['main']['App']['GetProjectK8sTarget'](arg1);
+}
+
 export function GetProjectMCPServers(arg1) {
   return window['go']['main']['App']['GetProjectMCPServers'](arg1);
 }
 
+export function GetProjectOutputStyles(arg1) {
+  return window['go']['main']['App']['GetProjectOutputStyles'](arg1);
+}
+
+export function GetProjectPermissions(arg1) {
+  return window['go']['main']['App']['GetProjectPermissions'](arg1);
+}
+
 export function GetProjectPrompts(arg1) {
   return window['go']['main']['App']['GetProjectPrompts'](arg1);
 }
 
+export function GetProjectRules(arg1) {
+  return window['go']['main']['App']['GetProjectRules'](arg1);
+}
+
+export function GetProjectServicesHealth(arg1) {
+  return window['go']['main']['App']['GetProjectServicesHealth'](arg1);
+}
+
 export function GetProjectStructure(arg1) {
   return window['go']['main']['App']['GetProjectStructure'](arg1);
 }
@@ -314,18 +678,66 @@ export function GetRemoteAccessClients() {
   return window['go']['main']['App']['GetRemoteAccessClients']();
 }
 
+export function GetRemoteAccessQRCode(arg1) {
+  return window['go']['main']['App']['GetRemoteAccessQRCode'](arg1);
+}
+
+export function GetRemoteAccessRequests() {
+  return window['go']['main']['App']['GetRemoteAccessRequests']();
+}
+
 export function GetRemoteAccessStatus() {
   return window['go']['main']['App']['GetRemoteAccessStatus']();
 }
 
+export function GetRemoteAuditLog(arg1) {
+  return window['go']['main']['App']['GetRemoteAuditLog'](arg1);
+}
+
+export function GetRemoteAuthAttempts() {
+  return window['go']['main']['App']['GetRemoteAuthAttempts']();
+}
+
+export function GetRemoteBannedIPs() {
+  return window['go']['main']['App']['GetRemoteBannedIPs']();
+}
+
+export function GetRemoteRecordings() {
+  return window['go']['main']['App']['GetRemoteRecordings']();
+}
+
+export function GetRepoStats(arg1, arg2) {
+  return window['go']['main']['App']['GetRepoStats'](arg1, arg2);
+}
+
+export function GetResumableSessions(arg1) {
+  return window['go']['main']['App']['GetResumableSessions'](arg1);
+}
+
+export function GetRuleContent(arg1) {
+  return window['go']['main']['App']['GetRuleContent'](arg1);
+}
+
 export function GetScreenshots(arg1) {
   return window['go']['main']['App']['GetScreenshots'](arg1);
 }
 
+export function GetSkillUpgradeDiff(arg1, arg2) {
+  return window['go']['main']['App']['GetSkillUpgradeDiff'](arg1, arg2);
+}
+
 export function GetState() {
   return window['go']['main']['App']['GetState']();
 }
 
+export function GetStatusLine(arg1, arg2) {
+  return window['go']['main']['App']['GetStatusLine'](arg1, arg2);
+}
+
+export function GetSubagentSessions() {
+  return window['go']['main']['App']['GetSubagentSessions']();
+}
+
 export function GetTeamHistory() {
   return window['go']['main']['App']['GetTeamHistory']();
 }
@@ -350,6 +762,10 @@ export function GetTemplateMCPServers() {
   return window['go']['main']['App']['GetTemplateMCPServers']();
 }
 
+export function GetTemplateOutputStyles() {
+  return window['go']['main']['App']['GetTemplateOutputStyles']();
+}
+
 export function GetTemplateRepoPath() {
   return window['go']['main']['App']['GetTemplateRepoPath']();
 }
@@ -378,14 +794,34 @@ export function GetTestDiscovery(arg1) {
   return window['go']['main']['App']['GetTestDiscovery'](arg1);
 }
 
+export function GetTestDurationReport(arg1, arg2) {
+  return window['go']['main']['App']['GetTestDurationReport'](arg1, arg2);
+}
+
 export function GetTestHistory(arg1) {
   return window['go']['main']['App']['GetTestHistory'](arg1);
 }
 
+export function GetTestHistoryLimit(arg1) {
+  return window['go']['main']['App']['GetTestHistoryLimit'](arg1);
+}
+
+export function GetTestNotifications(arg1) {
+  return window['go']['main']['App']['GetTestNotifications'](arg1);
+}
+
+export function GetTestShardRun(arg1) {
+  return window['go']['main']['App']['GetTestShardRun'](arg1);
+}
+
 export function GetTestSummary(arg1) {
   return window['go']['main']['App']['GetTestSummary'](arg1);
 }
 
+export function GetTestWatchResult(arg1) {
+  return window['go']['main']['App']['GetTestWatchResult'](arg1);
+}
+
 export function GetTodos(arg1) {
   return window['go']['main']['App']['GetTodos'](arg1);
 }
@@ -406,16 +842,52 @@ export function GetVoiceLang() {
   return window['go']['main']['App']['GetVoiceLang']();
 }
 
+export function GitAbortCherryPick(arg1) {
+  return window['go']['main']['App']['GitAbortCherryPick'](arg1);
+}
+
+export function GitAbortRevert(arg1) {
+  return window['go']['main']['App']['GitAbortRevert'](arg1);
+}
+
+export function GitCherryPick(arg1, arg2) {
+  return window['go']['main']['App']['GitCherryPick'](arg1, arg2);
+}
+
+export function GitRevert(arg1, arg2) {
+  return window['go']['main']['App']['GitRevert'](arg1, arg2);
+}
+
+export function IgnoreGitFile(arg1, arg2) {
+  return window['go']['main']['App']['IgnoreGitFile'](arg1, arg2);
+}
+
+export function ImportCodeTodos(arg1) {
+  return window['go']['main']['App']['ImportCodeTodos'](arg1);
+}
+
 export function IncrementPromptUsage(arg1, arg2, arg3) {
   return window['go']['main']['App']['IncrementPromptUsage'](arg1, arg2, arg3);
 }
 
+export function InspectContainer(arg1) {
+  return window['go']['main']['App']['InspectContainer'](arg1);
+}
+
+export function InstallGitHookTemplate(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallGitHookTemplate'](arg1, arg2, arg3);
+}
+
 export function InstallHook(arg1, arg2) {
   return window['go']['main']['App']['InstallHook'](arg1, arg2);
 }
 
-export function InstallSkill(arg1, arg2) {
-  return window['go']['main']['App']['InstallSkill'](arg1, arg2);
+export function InstallOutputStyle(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallOutputStyle'](arg1, arg2, arg3);
+}
+
+export function InstallSkill(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InstallSkill'](arg1, arg2, arg3);
 }
 
 export function InstallTemplateAgent(arg1, arg2) {
@@ -454,22 +926,82 @@ export function IsGitRepo(arg1) {
   return window['go']['main']['App']['IsGitRepo'](arg1);
 }
 
+export function IsHookLoggingEnabled(arg1) {
+  return window['go']['main']['App']['IsHookLoggingEnabled'](arg1);
+}
+
+export function IsK8sAvailable() {
+  return window['go']['main']['App']['IsK8sAvailable']();
+}
+
 export function IsTestRunning() {
   return window['go']['main']['App']['IsTestRunning']();
 }
 
+export function IsTestWatchActive(arg1) {
+  return window['go']['main']['App']['IsTestWatchActive'](arg1);
+}
+
+export function KillBackgroundTask(arg1, arg2, arg3) {
+  return window['go']['main']['App']['KillBackgroundTask'](arg1, arg2, arg3);
+}
+
 export function LaunchITerm() {
   return window['go']['main']['App']['LaunchITerm']();
 }
 
+export function ListContainerFiles(arg1, arg2) {
+  return window['go']['main']['App']['ListContainerFiles'](arg1, arg2);
+}
+
 export function Log(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['Log'](arg1, arg2, arg3, arg4);
 }
 
+export function MarkGitBisect(arg1, arg2) {
+  return window['go']['main']['App']['MarkGitBisect'](arg1, arg2);
+}
+
+export function OpenContainerShell(arg1) {
+  return window['go']['main']['App']['OpenContainerShell'](arg1);
+}
+
+export function OpenDevContainerShell(arg1) {
+  return window['go']['main']['App']['OpenDevContainerShell'](arg1);
+}
+
+export function OpenPodShell(arg1, arg2, arg3) {
+  return window['go']['main']['App']['OpenPodShell'](arg1, arg2, arg3);
+}
+
+export function OpenTestFailure(arg1, arg2) {
+  return window['go']['main']['App']['OpenTestFailure'](arg1, arg2);
+}
+
 export function PauseTerminal(arg1) {
   return window['go']['main']['App']['PauseTerminal'](arg1);
 }
 
+export function PreviewCommand(arg1, arg2) {
+  return window['go']['main']['App']['PreviewCommand'](arg1, arg2);
+}
+
+export function PruneDockerImages(arg1) {
+  return window['go']['main']['App']['PruneDockerImages'](arg1);
+}
+
+export function PruneDockerSystem(arg1, arg2, arg3) {
+  return window['go']['main']['App']['PruneDockerSystem'](arg1, arg2, arg3);
+}
+
+export function PullDockerImage(arg1) {
+  return window['go']['main']['App']['PullDockerImage'](arg1);
+}
+
+export function PushImage(arg1) {
+  return window['go']['main']['App']['PushImage'](arg1);
+}
+
 export function ReadFileContent(arg1) {
   return window['go']['main']['App']['ReadFileContent'](arg1);
 }
@@ -502,6 +1034,18 @@ export function RemoveBookmark(arg1, arg2) {
   return window['go']['main']['App']['RemoveBookmark'](arg1, arg2);
 }
 
+export function RemoveContainer(arg1, arg2) {
+  return window['go']['main']['App']['RemoveContainer'](arg1, arg2);
+}
+
+export function RemoveDockerImage(arg1, arg2) {
+  return window['go']['main']['App']['RemoveDockerImage'](arg1, arg2);
+}
+
+export function RemoveGitHook(arg1, arg2) {
+  return window['go']['main']['App']['RemoveGitHook'](arg1, arg2);
+}
+
 export function RemoveHook(arg1, arg2, arg3) {
   return window['go']['main']['App']['RemoveHook'](arg1, arg2, arg3);
 }
@@ -510,6 +1054,18 @@ export function RemoveMCPServer(arg1, arg2) {
   return window['go']['main']['App']['RemoveMCPServer'](arg1, arg2);
 }
 
+export function RemoveMarketplace(arg1) {
+  return window['go']['main']['App']['RemoveMarketplace'](arg1);
+}
+
+export function RemovePermissionRule(arg1, arg2) {
+  return window['go']['main']['App']['RemovePermissionRule'](arg1, arg2);
+}
+
+export function RemoveUserMCPServer(arg1) {
+  return window['go']['main']['App']['RemoveUserMCPServer'](arg1);
+}
+
 export function RenameITermTab(arg1, arg2, arg3) {
   return window['go']['main']['App']['RenameITermTab'](arg1, arg2, arg3);
 }
@@ -518,10 +1074,18 @@ export function RenameITermTabBySessionID(arg1, arg2) {
   return window['go']['main']['App']['RenameITermTabBySessionID'](arg1, arg2);
 }
 
+export function RenameProfile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RenameProfile'](arg1, arg2, arg3);
+}
+
 export function RequestStyledHistory(arg1) {
   return window['go']['main']['App']['RequestStyledHistory'](arg1);
 }
 
+export function ResetGitBisect(arg1) {
+  return window['go']['main']['App']['ResetGitBisect'](arg1);
+}
+
 export function ResetTestState(arg1) {
   return window['go']['main']['App']['ResetTestState'](arg1);
 }
@@ -530,14 +1094,42 @@ export function ResizeTerminal(arg1, arg2, arg3) {
   return window['go']['main']['App']['ResizeTerminal'](arg1, arg2, arg3);
 }
 
+export function ResolveMCPSecrets(arg1) {
+  return window['go']['main']['App']['ResolveMCPSecrets'](arg1);
+}
+
 export function RestartContainer(arg1) {
   return window['go']['main']['App']['RestartContainer'](arg1);
 }
 
+export function RestoreCheckpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestoreCheckpoint'](arg1, arg2, arg3);
+}
+
+export function ResumeClaudeSession(arg1, arg2) {
+  return window['go']['main']['App']['ResumeClaudeSession'](arg1, arg2);
+}
+
 export function ResumeTerminal(arg1) {
   return window['go']['main']['App']['ResumeTerminal'](arg1);
 }
 
+export function RunClaudeJob(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['RunClaudeJob'](arg1, arg2, arg3, arg4);
+}
+
+export function RunGitBisect(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RunGitBisect'](arg1, arg2, arg3);
+}
+
+export function RunMutationTests(arg1) {
+  return window['go']['main']['App']['RunMutationTests'](arg1);
+}
+
+export function SampleHookPayload(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SampleHookPayload'](arg1, arg2, arg3);
+}
+
 export function SaveAgentContent(arg1, arg2) {
   return window['go']['main']['App']['SaveAgentContent'](arg1, arg2);
 }
@@ -546,6 +1138,10 @@ export function SaveClaudemd(arg1, arg2) {
   return window['go']['main']['App']['SaveClaudemd'](arg1, arg2);
 }
 
+export function SaveClaudemdFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveClaudemdFile'](arg1, arg2, arg3);
+}
+
 export function SaveCommandContent(arg1, arg2) {
   return window['go']['main']['App']['SaveCommandContent'](arg1, arg2);
 }
@@ -554,14 +1150,30 @@ export function SaveFileContent(arg1, arg2) {
   return window['go']['main']['App']['SaveFileContent'](arg1, arg2);
 }
 
+export function SaveMemoryContent(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveMemoryContent'](arg1, arg2, arg3);
+}
+
 export function SaveNotes(arg1, arg2) {
   return window['go']['main']['App']['SaveNotes'](arg1, arg2);
 }
 
+export function SaveOutputStyleContent(arg1, arg2) {
+  return window['go']['main']['App']['SaveOutputStyleContent'](arg1, arg2);
+}
+
 export function SavePomodoroSettings(arg1, arg2) {
   return window['go']['main']['App']['SavePomodoroSettings'](arg1, arg2);
 }
 
+export function SaveRemoteAuditLogExport(arg1) {
+  return window['go']['main']['App']['SaveRemoteAuditLogExport'](arg1);
+}
+
+export function SaveRuleContent(arg1, arg2) {
+  return window['go']['main']['App']['SaveRuleContent'](arg1, arg2);
+}
+
 export function SaveScreenshot(arg1, arg2, arg3) {
   return window['go']['main']['App']['SaveScreenshot'](arg1, arg2, arg3);
 }
@@ -570,6 +1182,10 @@ export function SaveTestHistory(arg1, arg2) {
   return window['go']['main']['App']['SaveTestHistory'](arg1, arg2);
 }
 
+export function SaveTestHistoryExport(arg1, arg2) {
+  return window['go']['main']['App']['SaveTestHistoryExport'](arg1, arg2);
+}
+
 export function SaveTodos(arg1, arg2) {
   return window['go']['main']['App']['SaveTodos'](arg1, arg2);
 }
@@ -578,6 +1194,14 @@ export function ScanProjectTests(arg1) {
   return window['go']['main']['App']['ScanProjectTests'](arg1);
 }
 
+export function SearchProject(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SearchProject'](arg1, arg2, arg3);
+}
+
+export function SecureMCPEnv(arg1) {
+  return window['go']['main']['App']['SecureMCPEnv'](arg1);
+}
+
 export function SelectDirectory() {
   return window['go']['main']['App']['SelectDirectory']();
 }
@@ -594,10 +1218,50 @@ export function SetActiveTerminal(arg1, arg2) {
   return window['go']['main']['App']['SetActiveTerminal'](arg1, arg2);
 }
 
+export function SetApprovedClientScope(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetApprovedClientScope'](arg1, arg2, arg3);
+}
+
+export function SetCoverageThresholds(arg1, arg2) {
+  return window['go']['main']['App']['SetCoverageThresholds'](arg1, arg2);
+}
+
 export function SetDashboardFullscreen(arg1) {
   return window['go']['main']['App']['SetDashboardFullscreen'](arg1);
 }
 
+export function SetDockerHost(arg1) {
+  return window['go']['main']['App']['SetDockerHost'](arg1);
+}
+
+export function SetGitIdentity(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetGitIdentity'](arg1, arg2, arg3);
+}
+
+export function SetHookLogging(arg1, arg2) {
+  return window['go']['main']['App']['SetHookLogging'](arg1, arg2);
+}
+
+export function SetIgnorePatterns(arg1, arg2) {
+  return window['go']['main']['App']['SetIgnorePatterns'](arg1, arg2);
+}
+
+export function SetMCPSecret(arg1, arg2) {
+  return window['go']['main']['App']['SetMCPSecret'](arg1, arg2);
+}
+
+export function SetProjectDockerContext(arg1, arg2) {
+  return window['go']['main']['App']['SetProjectDockerContext'](arg1, arg2);
+}
+
+export function SetProjectK8sTarget(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetProjectK8sTarget'](arg1, arg2, arg3);
+}
+
+export function SetStatusLine(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetStatusLine'](arg1, arg2, arg3);
+}
+
 export function SetTerminalFontSize(arg1) {
   return window['go']['main']['App']['SetTerminalFontSize'](arg1);
 }
@@ -606,6 +1270,14 @@ export function SetTerminalTheme(arg1) {
   return window['go']['main']['App']['SetTerminalTheme'](arg1);
 }
 
+export function SetTestHistoryLimit(arg1, arg2) {
+  return window['go']['main']['App']['SetTestHistoryLimit'](arg1, arg2);
+}
+
+export function SetTestNotifications(arg1, arg2) {
+  return window['go']['main']['App']['SetTestNotifications'](arg1, arg2);
+}
+
 export function SetToolsPanelHeight(arg1) {
   return window['go']['main']['App']['SetToolsPanelHeight'](arg1);
 }
@@ -618,10 +1290,22 @@ export function SetVoiceLang(arg1) {
   return window['go']['main']['App']['SetVoiceLang'](arg1);
 }
 
+export function SetWindowFocused(arg1) {
+  return window['go']['main']['App']['SetWindowFocused'](arg1);
+}
+
 export function StartContainer(arg1) {
   return window['go']['main']['App']['StartContainer'](arg1);
 }
 
+export function StartDevContainer(arg1) {
+  return window['go']['main']['App']['StartDevContainer'](arg1);
+}
+
+export function StartGitBisect(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartGitBisect'](arg1, arg2, arg3);
+}
+
 export function StartRemoteAccess(arg1) {
   return window['go']['main']['App']['StartRemoteAccess'](arg1);
 }
@@ -630,14 +1314,42 @@ export function StartTeamsPolling() {
   return window['go']['main']['App']['StartTeamsPolling']();
 }
 
+export function StartTestShards(arg1, arg2, arg3) {
+  return window['go']['main']['App']['StartTestShards'](arg1, arg2, arg3);
+}
+
+export function StartTestWatch(arg1) {
+  return window['go']['main']['App']['StartTestWatch'](arg1);
+}
+
 export function StartVoiceRecognition(arg1) {
   return window['go']['main']['App']['StartVoiceRecognition'](arg1);
 }
 
+export function StopComposeLogs(arg1) {
+  return window['go']['main']['App']['StopComposeLogs'](arg1);
+}
+
 export function StopContainer(arg1) {
   return window['go']['main']['App']['StopContainer'](arg1);
 }
 
+export function StopContainerLogs(arg1) {
+  return window['go']['main']['App']['StopContainerLogs'](arg1);
+}
+
+export function StopGitBisectRun(arg1) {
+  return window['go']['main']['App']['StopGitBisectRun'](arg1);
+}
+
+export function StopMutationTests(arg1) {
+  return window['go']['main']['App']['StopMutationTests'](arg1);
+}
+
+export function StopPodLogs(arg1, arg2) {
+  return window['go']['main']['App']['StopPodLogs'](arg1, arg2);
+}
+
 export function StopRemoteAccess() {
   return window['go']['main']['App']['StopRemoteAccess']();
 }
@@ -646,6 +1358,14 @@ export function StopTeamsPolling() {
   return window['go']['main']['App']['StopTeamsPolling']();
 }
 
+export function StopTestShards(arg1) {
+  return window['go']['main']['App']['StopTestShards'](arg1);
+}
+
+export function StopTestWatch(arg1) {
+  return window['go']['main']['App']['StopTestWatch'](arg1);
+}
+
 export function StopVoiceRecognition() {
   return window['go']['main']['App']['StopVoiceRecognition']();
 }
@@ -658,10 +1378,34 @@ export function SwitchITermTabBySessionID(arg1) {
   return window['go']['main']['App']['SwitchITermTabBySessionID'](arg1);
 }
 
+export function TestHook(arg1, arg2, arg3) {
+  return window['go']['main']['App']['TestHook'](arg1, arg2, arg3);
+}
+
+export function TestMCPServer(arg1) {
+  return window['go']['main']['App']['TestMCPServer'](arg1);
+}
+
+export function TestStatusLine(arg1, arg2) {
+  return window['go']['main']['App']['TestStatusLine'](arg1, arg2);
+}
+
 export function TogglePromptPinned(arg1, arg2, arg3) {
   return window['go']['main']['App']['TogglePromptPinned'](arg1, arg2, arg3);
 }
 
+export function UnbanRemoteIP(arg1) {
+  return window['go']['main']['App']['UnbanRemoteIP'](arg1);
+}
+
+export function UninstallSkill(arg1, arg2) {
+  return window['go']['main']['App']['UninstallSkill'](arg1, arg2);
+}
+
+export function UnlockRemoteIP(arg1) {
+  return window['go']['main']['App']['UnlockRemoteIP'](arg1);
+}
+
 export function UnwatchITermSession() {
   return window['go']['main']['App']['UnwatchITermSession']();
 }
@@ -670,6 +1414,10 @@ export function UnwatchProjectCoverage(arg1) {
   return window['go']['main']['App']['UnwatchProjectCoverage'](arg1);
 }
 
+export function UnwatchProjectStructure(arg1) {
+  return window['go']['main']['App']['UnwatchProjectStructure'](arg1);
+}
+
 export function UpdateBrowserState(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['UpdateBrowserState'](arg1, arg2, arg3, arg4, arg5);
 }
@@ -678,6 +1426,14 @@ export function UpdateBrowserTabs(arg1, arg2, arg3) {
   return window['go']['main']['App']['UpdateBrowserTabs'](arg1, arg2, arg3);
 }
 
+export function UpdateClaudeCLI(arg1) {
+  return window['go']['main']['App']['UpdateClaudeCLI'](arg1);
+}
+
+export function UpdateContainerResources(arg1, arg2) {
+  return window['go']['main']['App']['UpdateContainerResources'](arg1, arg2);
+}
+
 export function UpdateGlobalPrompt(arg1, arg2) {
   return window['go']['main']['App']['UpdateGlobalPrompt'](arg1, arg2);
 }
@@ -690,10 +1446,30 @@ export function UpdatePrompt(arg1, arg2, arg3) {
   return window['go']['main']['App']['UpdatePrompt'](arg1, arg2, arg3);
 }
 
+export function UpdateSkill(arg1, arg2) {
+  return window['go']['main']['App']['UpdateSkill'](arg1, arg2);
+}
+
+export function UpdateTestSnapshots(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTestSnapshots'](arg1, arg2);
+}
+
 export function UpdateUIState(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['UpdateUIState'](arg1, arg2, arg3, arg4);
 }
 
+export function UpdateUserMCPServer(arg1, arg2) {
+  return window['go']['main']['App']['UpdateUserMCPServer'](arg1, arg2);
+}
+
+export function ValidateAgentContent(arg1) {
+  return window['go']['main']['App']['ValidateAgentContent'](arg1);
+}
+
+export function ValidateGitignorePattern(arg1) {
+  return window['go']['main']['App']['ValidateGitignorePattern'](arg1);
+}
+
 export function WatchITermSession(arg1) {
   return window['go']['main']['App']['WatchITermSession'](arg1);
 }
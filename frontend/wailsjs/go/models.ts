@@ -18,86 +18,104 @@ export namespace claude {
 	        this.format = source["format"];
 	    }
 	}
-	export class Command {
+	export class AgentFrontmatter {
 	    name: string;
-	    path: string;
 	    description: string;
-	    isGlobal: boolean;
-	    content?: string;
+	    tools: string[];
+	    model: string;
+	    color: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Command(source);
+	        return new AgentFrontmatter(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.path = source["path"];
 	        this.description = source["description"];
-	        this.isGlobal = source["isGlobal"];
-	        this.content = source["content"];
+	        this.tools = source["tools"];
+	        this.model = source["model"];
+	        this.color = source["color"];
 	    }
 	}
-	export class Hook {
-	    name: string;
-	    path: string;
-	    type: string;
-	    description: string;
-	    active: boolean;
-	    matcher?: string;
-	    command?: string;
+	export class AgentIssue {
+	    field: string;
+	    message: string;
+	    severity: string;
+	    line: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Hook(source);
+	        return new AgentIssue(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.type = source["type"];
-	        this.description = source["description"];
-	        this.active = source["active"];
-	        this.matcher = source["matcher"];
-	        this.command = source["command"];
+	        this.field = source["field"];
+	        this.message = source["message"];
+	        this.severity = source["severity"];
+	        this.line = source["line"];
 	    }
 	}
-	export class HookAction {
-	    type: string;
+	export class BackgroundTask {
+	    id: string;
+	    sessionId: string;
 	    command: string;
-	    timeout?: number;
+	    description?: string;
+	    status: string;
+	    exitCode?: number;
+	    startedAt: number;
+	    outputTail?: string;
+	    pids?: number[];
 	
 	    static createFrom(source: any = {}) {
-	        return new HookAction(source);
+	        return new BackgroundTask(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.type = source["type"];
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
 	        this.command = source["command"];
-	        this.timeout = source["timeout"];
+	        this.description = source["description"];
+	        this.status = source["status"];
+	        this.exitCode = source["exitCode"];
+	        this.startedAt = source["startedAt"];
+	        this.outputTail = source["outputTail"];
+	        this.pids = source["pids"];
 	    }
 	}
-	export class HookEntry {
-	    eventType: string;
-	    matcher: string;
-	    description: string;
-	    hooks: HookAction[];
-	    isInline: boolean;
-	    scriptPath: string;
+	export class CheckpointFile {
+	    path: string;
+	    action: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new HookEntry(source);
+	        return new CheckpointFile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.eventType = source["eventType"];
-	        this.matcher = source["matcher"];
-	        this.description = source["description"];
-	        this.hooks = this.convertValues(source["hooks"], HookAction);
-	        this.isInline = source["isInline"];
-	        this.scriptPath = source["scriptPath"];
+	        this.path = source["path"];
+	        this.action = source["action"];
+	    }
+	}
+	export class Checkpoint {
+	    id: string;
+	    sessionId: string;
+	    prompt: string;
+	    timestamp: number;
+	    files: CheckpointFile[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Checkpoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.sessionId = source["sessionId"];
+	        this.prompt = source["prompt"];
+	        this.timestamp = source["timestamp"];
+	        this.files = this.convertValues(source["files"], CheckpointFile);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -118,24 +136,7 @@ export namespace claude {
 		    return a;
 		}
 	}
-	export class LibStatus {
-	    name: string;
-	    installed: boolean;
-	    version?: string;
-	    apps?: string[];
-	
-	    static createFrom(source: any = {}) {
-	        return new LibStatus(source);
-	    }
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.installed = source["installed"];
-	        this.version = source["version"];
-	        this.apps = source["apps"];
-	    }
-	}
 	export class MCPServer {
 	    name: string;
 	    type: string;
@@ -162,152 +163,176 @@ export namespace claude {
 	        this.disabled = source["disabled"];
 	    }
 	}
-	export class Skill {
-	    name: string;
-	    path: string;
-	    description: string;
-	    installed: boolean;
+	export class HookAction {
+	    type: string;
+	    command: string;
+	    timeout?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Skill(source);
+	        return new HookAction(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.description = source["description"];
-	        this.installed = source["installed"];
+	        this.type = source["type"];
+	        this.command = source["command"];
+	        this.timeout = source["timeout"];
 	    }
 	}
-	export class TemplateItem {
-	    name: string;
-	    path: string;
+	export class HookEntry {
+	    eventType: string;
+	    matcher: string;
 	    description: string;
-	    category: string;
-	    content?: string;
+	    hooks: HookAction[];
+	    isInline: boolean;
+	    scriptPath: string;
+	    source: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TemplateItem(source);
+	        return new HookEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
+	        this.eventType = source["eventType"];
+	        this.matcher = source["matcher"];
 	        this.description = source["description"];
-	        this.category = source["category"];
-	        this.content = source["content"];
+	        this.hooks = this.convertValues(source["hooks"], HookAction);
+	        this.isInline = source["isInline"];
+	        this.scriptPath = source["scriptPath"];
+	        this.source = source["source"];
 	    }
-	}
-
-}
-
-export namespace docker {
 	
-	export class Container {
-	    id: string;
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProfileFile {
 	    name: string;
-	    image: string;
-	    state: string;
-	    status: string;
-	    ports: string[];
-	    created: number;
+	    content: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Container(source);
+	        return new ProfileFile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.image = source["image"];
-	        this.state = source["state"];
-	        this.status = source["status"];
-	        this.ports = source["ports"];
-	        this.created = source["created"];
+	        this.content = source["content"];
 	    }
 	}
-
-}
-
-export namespace git {
-	
-	export class ChangedFile {
-	    path: string;
-	    status: string;
-	    staged: boolean;
+	export class ClaudeProfile {
+	    id: string;
+	    name: string;
+	    description: string;
+	    agents: ProfileFile[];
+	    commands: ProfileFile[];
+	    hooks: HookEntry[];
+	    mcpServers: MCPServer[];
+	    settings: Record<string, Array<number>>;
+	    createdAt: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ChangedFile(source);
+	        return new ClaudeProfile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.status = source["status"];
-	        this.staged = source["staged"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.agents = this.convertValues(source["agents"], ProfileFile);
+	        this.commands = this.convertValues(source["commands"], ProfileFile);
+	        this.hooks = this.convertValues(source["hooks"], HookEntry);
+	        this.mcpServers = this.convertValues(source["mcpServers"], MCPServer);
+	        this.settings = source["settings"];
+	        this.createdAt = source["createdAt"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class CommitFile {
+	export class ClaudemdFile {
 	    path: string;
-	    status: string;
+	    relPath: string;
+	    scope: string;
+	    exists: boolean;
+	    size: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitFile(source);
+	        return new ClaudemdFile(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.path = source["path"];
-	        this.status = source["status"];
+	        this.relPath = source["relPath"];
+	        this.scope = source["scope"];
+	        this.exists = source["exists"];
+	        this.size = source["size"];
 	    }
 	}
-	export class CommitStats {
-	    filesChanged: number;
-	    insertions: number;
-	    deletions: number;
+	export class Command {
+	    name: string;
+	    path: string;
+	    description: string;
+	    isGlobal: boolean;
+	    content?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitStats(source);
+	        return new Command(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.filesChanged = source["filesChanged"];
-	        this.insertions = source["insertions"];
-	        this.deletions = source["deletions"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.isGlobal = source["isGlobal"];
+	        this.content = source["content"];
 	    }
 	}
-	export class CommitInfo {
-	    hash: string;
-	    shortHash: string;
-	    subject: string;
-	    body: string;
-	    author: string;
-	    authorEmail: string;
-	    date: string;
-	    relativeDate: string;
-	    files: CommitFile[];
-	    stats: CommitStats;
+	export class CommandPreview {
+	    rendered: string;
+	    issues: AgentIssue[];
 	
 	    static createFrom(source: any = {}) {
-	        return new CommitInfo(source);
+	        return new CommandPreview(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hash = source["hash"];
-	        this.shortHash = source["shortHash"];
-	        this.subject = source["subject"];
-	        this.body = source["body"];
-	        this.author = source["author"];
-	        this.authorEmail = source["authorEmail"];
-	        this.date = source["date"];
-	        this.relativeDate = source["relativeDate"];
-	        this.files = this.convertValues(source["files"], CommitFile);
-	        this.stats = this.convertValues(source["stats"], CommitStats);
+	        this.rendered = source["rendered"];
+	        this.issues = this.convertValues(source["issues"], AgentIssue);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -328,64 +353,3079 @@ export namespace git {
 		    return a;
 		}
 	}
-	
-	export class FileDiff {
+	export class Hook {
+	    name: string;
+	    path: string;
+	    type: string;
+	    description: string;
+	    active: boolean;
+	    matcher?: string;
+	    command?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Hook(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.type = source["type"];
+	        this.description = source["description"];
+	        this.active = source["active"];
+	        this.matcher = source["matcher"];
+	        this.command = source["command"];
+	    }
+	}
+	
+	
+	export class HookInvocation {
+	    id: string;
+	    event: string;
+	    matcher: string;
+	    command: string;
+	    toolName?: string;
+	    payload: string;
+	    stdout: string;
+	    stderr: string;
+	    exitCode: number;
+	    running: boolean;
+	    blocked: boolean;
+	    reason?: string;
+	    startedAt: number;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookInvocation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.event = source["event"];
+	        this.matcher = source["matcher"];
+	        this.command = source["command"];
+	        this.toolName = source["toolName"];
+	        this.payload = source["payload"];
+	        this.stdout = source["stdout"];
+	        this.stderr = source["stderr"];
+	        this.exitCode = source["exitCode"];
+	        this.running = source["running"];
+	        this.blocked = source["blocked"];
+	        this.reason = source["reason"];
+	        this.startedAt = source["startedAt"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class HookTestResult {
+	    command: string;
+	    exitCode: number;
+	    stdout: string;
+	    stderr: string;
+	    durationMs: number;
+	    blocked: boolean;
+	    reason?: string;
+	    timedOut: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookTestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.command = source["command"];
+	        this.exitCode = source["exitCode"];
+	        this.stdout = source["stdout"];
+	        this.stderr = source["stderr"];
+	        this.durationMs = source["durationMs"];
+	        this.blocked = source["blocked"];
+	        this.reason = source["reason"];
+	        this.timedOut = source["timedOut"];
+	        this.error = source["error"];
+	    }
+	}
+	export class InstalledSkill {
+	    name: string;
+	    source: string;
+	    marketplace?: string;
+	    updateAvailable: boolean;
+	    locallyModified: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new InstalledSkill(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.source = source["source"];
+	        this.marketplace = source["marketplace"];
+	        this.updateAvailable = source["updateAvailable"];
+	        this.locallyModified = source["locallyModified"];
+	    }
+	}
+	export class Job {
+	    projectId: string;
+	    prompt: string;
+	    workDir: string;
+	    model: string;
+	    allowedTools: string[];
+	    id: string;
+	    status: string;
+	    output: string;
+	    result: string;
+	    error?: string;
+	    sessionId?: string;
+	    costUsd: number;
+	    numTurns: number;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    startedAt?: any;
+	    // Go type: time
+	    finishedAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Job(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.projectId = source["projectId"];
+	        this.prompt = source["prompt"];
+	        this.workDir = source["workDir"];
+	        this.model = source["model"];
+	        this.allowedTools = source["allowedTools"];
+	        this.id = source["id"];
+	        this.status = source["status"];
+	        this.output = source["output"];
+	        this.result = source["result"];
+	        this.error = source["error"];
+	        this.sessionId = source["sessionId"];
+	        this.costUsd = source["costUsd"];
+	        this.numTurns = source["numTurns"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.finishedAt = this.convertValues(source["finishedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class LibStatus {
+	    name: string;
+	    installed: boolean;
+	    version?: string;
+	    apps?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LibStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.installed = source["installed"];
+	        this.version = source["version"];
+	        this.apps = source["apps"];
+	    }
+	}
+	export class MCPResource {
+	    uri: string;
+	    name: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPResource(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.uri = source["uri"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	    }
+	}
+	
+	export class MCPTool {
+	    name: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPTool(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	    }
+	}
+	export class MCPTestResult {
+	    serverName: string;
+	    serverVersion: string;
+	    protocolVersion: string;
+	    tools: MCPTool[];
+	    resources: MCPResource[];
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MCPTestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.serverName = source["serverName"];
+	        this.serverVersion = source["serverVersion"];
+	        this.protocolVersion = source["protocolVersion"];
+	        this.tools = this.convertValues(source["tools"], MCPTool);
+	        this.resources = this.convertValues(source["resources"], MCPResource);
+	        this.durationMs = source["durationMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class Marketplace {
+	    name: string;
+	    path: string;
+	    source?: string;
+	    skillCount: number;
+	    official: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Marketplace(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.source = source["source"];
+	        this.skillCount = source["skillCount"];
+	        this.official = source["official"];
+	    }
+	}
+	export class MemoryChange {
+	    name: string;
+	    path: string;
+	    scope: string;
+	    status: string;
+	    diff: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MemoryChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.scope = source["scope"];
+	        this.status = source["status"];
+	        this.diff = source["diff"];
+	    }
+	}
+	export class MemoryFile {
+	    name: string;
+	    path: string;
+	    scope: string;
+	    size: number;
+	    modifiedAt: number;
+	    changed: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MemoryFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.scope = source["scope"];
+	        this.size = source["size"];
+	        this.modifiedAt = source["modifiedAt"];
+	        this.changed = source["changed"];
+	    }
+	}
+	export class OutputStyle {
+	    name: string;
+	    path: string;
+	    description: string;
+	    isGlobal: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new OutputStyle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.isGlobal = source["isGlobal"];
+	    }
+	}
+	export class PermissionRule {
+	    rule: string;
+	    kind: string;
+	    source: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PermissionRule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rule = source["rule"];
+	        this.kind = source["kind"];
+	        this.source = source["source"];
+	    }
+	}
+	export class ProfileConflict {
+	    kind: string;
+	    name: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileConflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.name = source["name"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class ProfileApplyReport {
+	    installed: string[];
+	    unchanged: string[];
+	    conflicts: ProfileConflict[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileApplyReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.installed = source["installed"];
+	        this.unchanged = source["unchanged"];
+	        this.conflicts = this.convertValues(source["conflicts"], ProfileConflict);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class ResumableSession {
+	    id: string;
+	    summary?: string;
+	    firstPrompt: string;
+	    workDir: string;
+	    gitBranch?: string;
+	    messageCount: number;
+	    startedAt: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResumableSession(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.summary = source["summary"];
+	        this.firstPrompt = source["firstPrompt"];
+	        this.workDir = source["workDir"];
+	        this.gitBranch = source["gitBranch"];
+	        this.messageCount = source["messageCount"];
+	        this.startedAt = source["startedAt"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class Rule {
+	    name: string;
+	    path: string;
+	    description: string;
+	    isGlobal: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Rule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.isGlobal = source["isGlobal"];
+	    }
+	}
+	export class Skill {
+	    name: string;
+	    path: string;
+	    description: string;
+	    installed: boolean;
+	    marketplace: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Skill(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.installed = source["installed"];
+	        this.marketplace = source["marketplace"];
+	    }
+	}
+	export class SkillFileDiff {
+	    path: string;
+	    status: string;
+	    diff: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SkillFileDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.diff = source["diff"];
+	    }
+	}
+	export class SkillUpdateReport {
+	    name: string;
+	    added: string[];
+	    modified: string[];
+	    removed: string[];
+	    unchanged: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SkillUpdateReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.added = source["added"];
+	        this.modified = source["modified"];
+	        this.removed = source["removed"];
+	        this.unchanged = source["unchanged"];
+	    }
+	}
+	export class StatusLine {
+	    type: string;
+	    command: string;
+	    padding?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.command = source["command"];
+	        this.padding = source["padding"];
+	    }
+	}
+	export class StatusLineTestResult {
+	    output: string;
+	    stdout: string;
+	    stderr: string;
+	    exitCode: number;
+	    durationMs: number;
+	    timedOut: boolean;
+	    problem?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusLineTestResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.output = source["output"];
+	        this.stdout = source["stdout"];
+	        this.stderr = source["stderr"];
+	        this.exitCode = source["exitCode"];
+	        this.durationMs = source["durationMs"];
+	        this.timedOut = source["timedOut"];
+	        this.problem = source["problem"];
+	    }
+	}
+	export class TemplateItem {
+	    name: string;
+	    path: string;
+	    description: string;
+	    category: string;
+	    content?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TemplateItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.description = source["description"];
+	        this.category = source["category"];
+	        this.content = source["content"];
+	    }
+	}
+	export class VersionInfo {
+	    installed: boolean;
+	    version: string;
+	    path: string;
+	    latest: string;
+	    updateAvailable: boolean;
+	    updateCommand: string;
+	    // Go type: time
+	    checkedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new VersionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.installed = source["installed"];
+	        this.version = source["version"];
+	        this.path = source["path"];
+	        this.latest = source["latest"];
+	        this.updateAvailable = source["updateAvailable"];
+	        this.updateCommand = source["updateCommand"];
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace docker {
+	
+	export class ComposeLogService {
+	    service: string;
+	    containerId: string;
+	    color: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ComposeLogService(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.containerId = source["containerId"];
+	        this.color = source["color"];
+	    }
+	}
+	export class PortMapping {
+	    hostIp?: string;
+	    hostPort?: number;
+	    containerPort: number;
+	    protocol: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PortMapping(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostIp = source["hostIp"];
+	        this.hostPort = source["hostPort"];
+	        this.containerPort = source["containerPort"];
+	        this.protocol = source["protocol"];
+	    }
+	}
+	export class Container {
+	    id: string;
+	    name: string;
+	    image: string;
+	    state: string;
+	    status: string;
+	    ports: string[];
+	    portMappings: PortMapping[];
+	    created: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Container(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.state = source["state"];
+	        this.status = source["status"];
+	        this.ports = source["ports"];
+	        this.portMappings = this.convertValues(source["portMappings"], PortMapping);
+	        this.created = source["created"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NetworkInfo {
+	    name: string;
+	    ipAddress: string;
+	    gateway: string;
+	    aliases: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NetworkInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.ipAddress = source["ipAddress"];
+	        this.gateway = source["gateway"];
+	        this.aliases = source["aliases"];
+	    }
+	}
+	export class MountInfo {
+	    type: string;
+	    source: string;
+	    destination: string;
+	    name?: string;
+	    readOnly: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MountInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.source = source["source"];
+	        this.destination = source["destination"];
+	        this.name = source["name"];
+	        this.readOnly = source["readOnly"];
+	    }
+	}
+	export class EnvVar {
+	    name: string;
+	    value: string;
+	    masked: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnvVar(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.value = source["value"];
+	        this.masked = source["masked"];
+	    }
+	}
+	export class ContainerDetails {
+	    id: string;
+	    name: string;
+	    image: string;
+	    imageId: string;
+	    state: string;
+	    health?: string;
+	    startedAt?: string;
+	    command: string;
+	    workingDir?: string;
+	    user?: string;
+	    restartPolicy: string;
+	    env: EnvVar[];
+	    mounts: MountInfo[];
+	    labels: Record<string, string>;
+	    networks: NetworkInfo[];
+	    ports: PortMapping[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerDetails(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.imageId = source["imageId"];
+	        this.state = source["state"];
+	        this.health = source["health"];
+	        this.startedAt = source["startedAt"];
+	        this.command = source["command"];
+	        this.workingDir = source["workingDir"];
+	        this.user = source["user"];
+	        this.restartPolicy = source["restartPolicy"];
+	        this.env = this.convertValues(source["env"], EnvVar);
+	        this.mounts = this.convertValues(source["mounts"], MountInfo);
+	        this.labels = source["labels"];
+	        this.networks = this.convertValues(source["networks"], NetworkInfo);
+	        this.ports = this.convertValues(source["ports"], PortMapping);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ContainerFile {
+	    name: string;
+	    path: string;
+	    type: string;
+	    size: number;
+	    modTime: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.type = source["type"];
+	        this.size = source["size"];
+	        this.modTime = source["modTime"];
+	    }
+	}
+	export class ContainerResources {
+	    restartPolicy: string;
+	    maxRetries: number;
+	    cpus: number;
+	    memoryMb: number;
+	    hostCpus: number;
+	    hostMemoryMb: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ContainerResources(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.restartPolicy = source["restartPolicy"];
+	        this.maxRetries = source["maxRetries"];
+	        this.cpus = source["cpus"];
+	        this.memoryMb = source["memoryMb"];
+	        this.hostCpus = source["hostCpus"];
+	        this.hostMemoryMb = source["hostMemoryMb"];
+	    }
+	}
+	export class CopyResult {
+	    destination: string;
+	    files: number;
+	    bytes: number;
+	    skipped: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CopyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.destination = source["destination"];
+	        this.files = source["files"];
+	        this.bytes = source["bytes"];
+	        this.skipped = source["skipped"];
+	    }
+	}
+	export class DevContainerConfig {
+	    path: string;
+	    name: string;
+	    image?: string;
+	    dockerfile?: string;
+	    composeFiles?: string[];
+	    service?: string;
+	    features: string[];
+	    forwardPorts: string[];
+	    postCreateCommand?: string;
+	    remoteUser?: string;
+	    workspaceFolder: string;
+	    cliAvailable: boolean;
+	    containerId?: string;
+	    running: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DevContainerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.image = source["image"];
+	        this.dockerfile = source["dockerfile"];
+	        this.composeFiles = source["composeFiles"];
+	        this.service = source["service"];
+	        this.features = source["features"];
+	        this.forwardPorts = source["forwardPorts"];
+	        this.postCreateCommand = source["postCreateCommand"];
+	        this.remoteUser = source["remoteUser"];
+	        this.workspaceFolder = source["workspaceFolder"];
+	        this.cliAvailable = source["cliAvailable"];
+	        this.containerId = source["containerId"];
+	        this.running = source["running"];
+	    }
+	}
+	export class DockerContext {
+	    name: string;
+	    description: string;
+	    host: string;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DockerContext(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.host = source["host"];
+	        this.current = source["current"];
+	    }
+	}
+	
+	export class HostCandidate {
+	    name: string;
+	    host: string;
+	    exists: boolean;
+	    reachable: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new HostCandidate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.host = source["host"];
+	        this.exists = source["exists"];
+	        this.reachable = source["reachable"];
+	    }
+	}
+	export class Image {
+	    id: string;
+	    tags: string[];
+	    size: number;
+	    created: number;
+	    containers: number;
+	    dangling: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Image(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tags = source["tags"];
+	        this.size = source["size"];
+	        this.created = source["created"];
+	        this.containers = source["containers"];
+	        this.dangling = source["dangling"];
+	    }
+	}
+	
+	
+	
+	export class PruneResult {
+	    deleted: string[];
+	    spaceReclaimed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PruneResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deleted = source["deleted"];
+	        this.spaceReclaimed = source["spaceReclaimed"];
+	    }
+	}
+	export class ServiceHealth {
+	    service: string;
+	    containerId: string;
+	    state: string;
+	    health: string;
+	    status: string;
+	    level: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServiceHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.service = source["service"];
+	        this.containerId = source["containerId"];
+	        this.state = source["state"];
+	        this.health = source["health"];
+	        this.status = source["status"];
+	        this.level = source["level"];
+	    }
+	}
+	export class ServicesHealth {
+	    project: string;
+	    overall: string;
+	    services: ServiceHealth[];
+	    healthy: number;
+	    unhealthy: number;
+	    pending: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServicesHealth(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.project = source["project"];
+	        this.overall = source["overall"];
+	        this.services = this.convertValues(source["services"], ServiceHealth);
+	        this.healthy = source["healthy"];
+	        this.unhealthy = source["unhealthy"];
+	        this.pending = source["pending"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SystemPruneReport {
+	    dryRun: boolean;
+	    containers: string[];
+	    images: string[];
+	    volumes: string[];
+	    networks: string[];
+	    buildCacheCount: number;
+	    spaceReclaimed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SystemPruneReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dryRun = source["dryRun"];
+	        this.containers = source["containers"];
+	        this.images = source["images"];
+	        this.volumes = source["volumes"];
+	        this.networks = source["networks"];
+	        this.buildCacheCount = source["buildCacheCount"];
+	        this.spaceReclaimed = source["spaceReclaimed"];
+	    }
+	}
+	export class URLSuggestion {
+	    containerId: string;
+	    containerName: string;
+	    port: number;
+	    url: string;
+	    label: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new URLSuggestion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.containerId = source["containerId"];
+	        this.containerName = source["containerName"];
+	        this.port = source["port"];
+	        this.url = source["url"];
+	        this.label = source["label"];
+	    }
+	}
+
+}
+
+export namespace forge {
+	
+	export class MergeRequest {
+	    id: number;
+	    title: string;
+	    author: string;
+	    sourceBranch: string;
+	    targetBranch: string;
+	    state: string;
+	    draft: boolean;
+	    url: string;
+	    updatedAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MergeRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.sourceBranch = source["sourceBranch"];
+	        this.targetBranch = source["targetBranch"];
+	        this.state = source["state"];
+	        this.draft = source["draft"];
+	        this.url = source["url"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	export class PipelineStatus {
+	    ref: string;
+	    status: string;
+	    url: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PipelineStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ref = source["ref"];
+	        this.status = source["status"];
+	        this.url = source["url"];
+	    }
+	}
+	export class RemoteInfo {
+	    provider: string;
+	    host: string;
+	    owner: string;
+	    repo: string;
+	    webUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.host = source["host"];
+	        this.owner = source["owner"];
+	        this.repo = source["repo"];
+	        this.webUrl = source["webUrl"];
+	    }
+	}
+
+}
+
+export namespace git {
+	
+	export class BisectStatus {
+	    active: boolean;
+	    current: string;
+	    currentSubject: string;
+	    remaining: number;
+	    stepsLeft: number;
+	    culprit: string;
+	    culpritSubject: string;
+	    log: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BisectStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.active = source["active"];
+	        this.current = source["current"];
+	        this.currentSubject = source["currentSubject"];
+	        this.remaining = source["remaining"];
+	        this.stepsLeft = source["stepsLeft"];
+	        this.culprit = source["culprit"];
+	        this.culpritSubject = source["culpritSubject"];
+	        this.log = source["log"];
+	    }
+	}
+	export class ChangedFile {
+	    path: string;
+	    status: string;
+	    staged: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChangedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	        this.staged = source["staged"];
+	    }
+	}
+	export class CommitFile {
+	    path: string;
+	    status: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.status = source["status"];
+	    }
+	}
+	export class CommitStats {
+	    filesChanged: number;
+	    insertions: number;
+	    deletions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filesChanged = source["filesChanged"];
+	        this.insertions = source["insertions"];
+	        this.deletions = source["deletions"];
+	    }
+	}
+	export class CommitInfo {
+	    hash: string;
+	    shortHash: string;
+	    subject: string;
+	    body: string;
+	    author: string;
+	    authorEmail: string;
+	    date: string;
+	    relativeDate: string;
+	    files: CommitFile[];
+	    stats: CommitStats;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.shortHash = source["shortHash"];
+	        this.subject = source["subject"];
+	        this.body = source["body"];
+	        this.author = source["author"];
+	        this.authorEmail = source["authorEmail"];
+	        this.date = source["date"];
+	        this.relativeDate = source["relativeDate"];
+	        this.files = this.convertValues(source["files"], CommitFile);
+	        this.stats = this.convertValues(source["stats"], CommitStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CommitSignature {
+	    hash: string;
+	    signed: boolean;
+	    status: string;
+	    signer: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommitSignature(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hash = source["hash"];
+	        this.signed = source["signed"];
+	        this.status = source["status"];
+	        this.signer = source["signer"];
+	    }
+	}
+	
+	export class Contributor {
+	    name: string;
+	    email: string;
+	    commits: number;
+	    insertions: number;
+	    deletions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Contributor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.commits = source["commits"];
+	        this.insertions = source["insertions"];
+	        this.deletions = source["deletions"];
+	    }
+	}
+	export class DayCount {
+	    date: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.count = source["count"];
+	    }
+	}
+	export class DiffChunk {
+	    path: string;
+	    offset: number;
+	    lines: string[];
+	    totalLines: number;
+	    hasMore: boolean;
+	    isBinary: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiffChunk(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.offset = source["offset"];
+	        this.lines = source["lines"];
+	        this.totalLines = source["totalLines"];
+	        this.hasMore = source["hasMore"];
+	        this.isBinary = source["isBinary"];
+	    }
+	}
+	export class FileChurn {
+	    path: string;
+	    commits: number;
+	    insertions: number;
+	    deletions: number;
+	    churn: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileChurn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.commits = source["commits"];
+	        this.insertions = source["insertions"];
+	        this.deletions = source["deletions"];
+	        this.churn = source["churn"];
+	    }
+	}
+	export class FileDiff {
 	    path: string;
 	    oldContent: string;
 	    newContent: string;
 	    diffContent: string;
+	    isBinary: boolean;
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileDiff(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.oldContent = source["oldContent"];
+	        this.newContent = source["newContent"];
+	        this.diffContent = source["diffContent"];
+	        this.isBinary = source["isBinary"];
+	        this.truncated = source["truncated"];
+	    }
+	}
+	export class GitHook {
+	    name: string;
+	    path: string;
+	    source: string;
+	    enabled: boolean;
+	    commands: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GitHook(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.source = source["source"];
+	        this.enabled = source["enabled"];
+	        this.commands = source["commands"];
+	    }
+	}
+	export class GitignoreEntry {
+	    line: number;
+	    pattern: string;
+	    negated: boolean;
+	    comment: boolean;
+	    blank: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new GitignoreEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.line = source["line"];
+	        this.pattern = source["pattern"];
+	        this.negated = source["negated"];
+	        this.comment = source["comment"];
+	        this.blank = source["blank"];
+	    }
+	}
+	export class HookTemplate {
+	    id: string;
+	    hook: string;
+	    name: string;
+	    description: string;
+	    script: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new HookTemplate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.hook = source["hook"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.script = source["script"];
+	    }
+	}
+	export class HooksInfo {
+	    hooksPath: string;
+	    managers: string[];
+	    hooks: GitHook[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HooksInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hooksPath = source["hooksPath"];
+	        this.managers = source["managers"];
+	        this.hooks = this.convertValues(source["hooks"], GitHook);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class IdentityStatus {
+	    userName: string;
+	    userEmail: string;
+	    nameScope: string;
+	    emailScope: string;
+	    signingEnabled: boolean;
+	    signingFormat: string;
+	    signingKey: string;
+	    configured: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new IdentityStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.userName = source["userName"];
+	        this.userEmail = source["userEmail"];
+	        this.nameScope = source["nameScope"];
+	        this.emailScope = source["emailScope"];
+	        this.signingEnabled = source["signingEnabled"];
+	        this.signingFormat = source["signingFormat"];
+	        this.signingKey = source["signingKey"];
+	        this.configured = source["configured"];
+	    }
+	}
+	export class IgnoreCheck {
+	    path: string;
+	    ignored: boolean;
+	    source?: string;
+	    line?: number;
+	    pattern?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IgnoreCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.ignored = source["ignored"];
+	        this.source = source["source"];
+	        this.line = source["line"];
+	        this.pattern = source["pattern"];
+	    }
+	}
+	export class PickResult {
+	    success: boolean;
+	    conflicts: string[];
+	    head: string;
+	    output: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PickResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.conflicts = source["conflicts"];
+	        this.head = source["head"];
+	        this.output = source["output"];
+	    }
+	}
+	export class RepoRoot {
+	    path: string;
+	    relPath: string;
+	    branch: string;
+	    isSubmodule: boolean;
+	    staged: number;
+	    unstaged: number;
+	    untracked: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RepoRoot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.relPath = source["relPath"];
+	        this.branch = source["branch"];
+	        this.isSubmodule = source["isSubmodule"];
+	        this.staged = source["staged"];
+	        this.unstaged = source["unstaged"];
+	        this.untracked = source["untracked"];
+	    }
+	}
+	export class RepoStats {
+	    windowDays: number;
+	    since: string;
+	    totalCommits: number;
+	    contributors: Contributor[];
+	    commitsPerDay: DayCount[];
+	    commitsPerWeekday: number[];
+	    hotspots: FileChurn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RepoStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.windowDays = source["windowDays"];
+	        this.since = source["since"];
+	        this.totalCommits = source["totalCommits"];
+	        this.contributors = this.convertValues(source["contributors"], Contributor);
+	        this.commitsPerDay = this.convertValues(source["commitsPerDay"], DayCount);
+	        this.commitsPerWeekday = source["commitsPerWeekday"];
+	        this.hotspots = this.convertValues(source["hotspots"], FileChurn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace iterm {
+	
+	export class ITermTab {
+	    windowId: number;
+	    tabIndex: number;
+	    sessionId: string;
+	    name: string;
+	    path: string;
+	    isActive: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ITermTab(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.windowId = source["windowId"];
+	        this.tabIndex = source["tabIndex"];
+	        this.sessionId = source["sessionId"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.isActive = source["isActive"];
+	    }
+	}
+	export class ITermStatus {
+	    running: boolean;
+	    tabs: ITermTab[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ITermStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.tabs = this.convertValues(source["tabs"], ITermTab);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SessionInfo {
+	    name: string;
+	    profileName: string;
+	    columns: number;
+	    rows: number;
+	    currentCommand: string;
+	    jobPid: number;
+	    isProcessing: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.profileName = source["profileName"];
+	        this.columns = source["columns"];
+	        this.rows = source["rows"];
+	        this.currentCommand = source["currentCommand"];
+	        this.jobPid = source["jobPid"];
+	        this.isProcessing = source["isProcessing"];
+	    }
+	}
+
+}
+
+export namespace k8s {
+	
+	export class Deployment {
+	    name: string;
+	    namespace: string;
+	    ready: string;
+	    upToDate: number;
+	    available: number;
+	    images: string[];
+	    createdAt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Deployment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.namespace = source["namespace"];
+	        this.ready = source["ready"];
+	        this.upToDate = source["upToDate"];
+	        this.available = source["available"];
+	        this.images = source["images"];
+	        this.createdAt = source["createdAt"];
+	    }
+	}
+	export class KubeContext {
+	    name: string;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new KubeContext(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.current = source["current"];
+	    }
+	}
+	export class Pod {
+	    name: string;
+	    namespace: string;
+	    phase: string;
+	    status: string;
+	    ready: string;
+	    restarts: number;
+	    node: string;
+	    startedAt: string;
+	    containers: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Pod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.namespace = source["namespace"];
+	        this.phase = source["phase"];
+	        this.status = source["status"];
+	        this.ready = source["ready"];
+	        this.restarts = source["restarts"];
+	        this.node = source["node"];
+	        this.startedAt = source["startedAt"];
+	        this.containers = source["containers"];
+	    }
+	}
+	export class Target {
+	    context: string;
+	    namespace: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Target(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.context = source["context"];
+	        this.namespace = source["namespace"];
+	    }
+	}
+
+}
+
+export namespace main {
+	
+	export class RemoteAccessStatus {
+	    enabled: boolean;
+	    savedDevicesOnly: boolean;
+	    running: boolean;
+	    port: number;
+	    localUrl: string;
+	    publicUrl: string;
+	    token: string;
+	    clientCount: number;
+	    clients: remote.ClientInfo[];
+	    lanUrl: string;
+	    tls: boolean;
+	    fingerprint: string;
+	    tunnels: remote.TunnelInfo[];
+	    bindHost: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemoteAccessStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.savedDevicesOnly = source["savedDevicesOnly"];
+	        this.running = source["running"];
+	        this.port = source["port"];
+	        this.localUrl = source["localUrl"];
+	        this.publicUrl = source["publicUrl"];
+	        this.token = source["token"];
+	        this.clientCount = source["clientCount"];
+	        this.clients = this.convertValues(source["clients"], remote.ClientInfo);
+	        this.lanUrl = source["lanUrl"];
+	        this.tls = source["tls"];
+	        this.fingerprint = source["fingerprint"];
+	        this.tunnels = this.convertValues(source["tunnels"], remote.TunnelInfo);
+	        this.bindHost = source["bindHost"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Screenshot {
+	    id: string;
+	    filename: string;
+	    path: string;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Screenshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.filename = source["filename"];
+	        this.path = source["path"];
+	        this.timestamp = source["timestamp"];
+	    }
+	}
+	export class TerminalInfo {
+	    id: string;
+	    projectId: string;
+	    name: string;
+	    workDir: string;
+	    running: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.projectId = source["projectId"];
+	        this.name = source["name"];
+	        this.workDir = source["workDir"];
+	        this.running = source["running"];
+	    }
+	}
+
+}
+
+export namespace remote {
+	
+	export class AccessRequest {
+	    id: string;
+	    name: string;
+	    fingerprint: string;
+	    remoteAddr: string;
+	    userAgent: string;
+	    // Go type: time
+	    createdAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccessRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.fingerprint = source["fingerprint"];
+	        this.remoteAddr = source["remoteAddr"];
+	        this.userAgent = source["userAgent"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ApprovedClient {
+	    token: string;
+	    name: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    lastUsed: any;
+	    viewer: boolean;
+	    projects?: string[];
+	    capabilities?: string[];
+	    totpSecret?: string;
+	    totpDevices?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ApprovedClient(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.name = source["name"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	        this.viewer = source["viewer"];
+	        this.projects = source["projects"];
+	        this.capabilities = source["capabilities"];
+	        this.totpSecret = source["totpSecret"];
+	        this.totpDevices = source["totpDevices"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AuditEntry {
+	    // Go type: time
+	    time: any;
+	    clientId?: string;
+	    clientName?: string;
+	    remoteAddr: string;
+	    action: string;
+	    terminal?: string;
+	    summary: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = this.convertValues(source["time"], null);
+	        this.clientId = source["clientId"];
+	        this.clientName = source["clientName"];
+	        this.remoteAddr = source["remoteAddr"];
+	        this.action = source["action"];
+	        this.terminal = source["terminal"];
+	        this.summary = source["summary"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AuthAttemptInfo {
+	    ip: string;
+	    failures: number;
+	    // Go type: time
+	    lastAttempt: any;
+	    locked: boolean;
+	    // Go type: time
+	    lockedUntil: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuthAttemptInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ip = source["ip"];
+	        this.failures = source["failures"];
+	        this.lastAttempt = this.convertValues(source["lastAttempt"], null);
+	        this.locked = source["locked"];
+	        this.lockedUntil = this.convertValues(source["lockedUntil"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BannedIP {
+	    ip: string;
+	    // Go type: time
+	    bannedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new BannedIP(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ip = source["ip"];
+	        this.bannedAt = this.convertValues(source["bannedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ClientInfo {
+	    id: string;
+	    // Go type: time
+	    connectedAt: any;
+	    terminalId: string;
+	    userAgent: string;
+	    remoteAddr: string;
+	    viewer: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClientInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.connectedAt = this.convertValues(source["connectedAt"], null);
+	        this.terminalId = source["terminalId"];
+	        this.userAgent = source["userAgent"];
+	        this.remoteAddr = source["remoteAddr"];
+	        this.viewer = source["viewer"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TunnelConfig {
+	    name: string;
+	    port: number;
+	    domain: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TunnelConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.port = source["port"];
+	        this.domain = source["domain"];
+	    }
+	}
+	export class Config {
+	    enabled: boolean;
+	    savedDevicesOnly: boolean;
+	    accessRequests: boolean;
+	    port: number;
+	    bindHost: string;
+	    ngrokPlan: string;
+	    subdomain: string;
+	    tokenExpiry: number;
+	    ngrokApiPort: number;
+	    tlsMode: string;
+	    certFile: string;
+	    keyFile: string;
+	    recordSessions: boolean;
+	    recordingDays: number;
+	    ngrokRegion: string;
+	    ngrokDomain: string;
+	    ngrokEdge: string;
+	    extraTunnels: TunnelConfig[];
+	    maxAuthAttempts: number;
+	    authLockoutMinutes: number;
+	    whisperModel: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Config(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.savedDevicesOnly = source["savedDevicesOnly"];
+	        this.accessRequests = source["accessRequests"];
+	        this.port = source["port"];
+	        this.bindHost = source["bindHost"];
+	        this.ngrokPlan = source["ngrokPlan"];
+	        this.subdomain = source["subdomain"];
+	        this.tokenExpiry = source["tokenExpiry"];
+	        this.ngrokApiPort = source["ngrokApiPort"];
+	        this.tlsMode = source["tlsMode"];
+	        this.certFile = source["certFile"];
+	        this.keyFile = source["keyFile"];
+	        this.recordSessions = source["recordSessions"];
+	        this.recordingDays = source["recordingDays"];
+	        this.ngrokRegion = source["ngrokRegion"];
+	        this.ngrokDomain = source["ngrokDomain"];
+	        this.ngrokEdge = source["ngrokEdge"];
+	        this.extraTunnels = this.convertValues(source["extraTunnels"], TunnelConfig);
+	        this.maxAuthAttempts = source["maxAuthAttempts"];
+	        this.authLockoutMinutes = source["authLockoutMinutes"];
+	        this.whisperModel = source["whisperModel"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TerminalInfo {
+	    id: string;
+	    projectId: string;
+	    name: string;
+	    workDir: string;
+	    running: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.projectId = source["projectId"];
+	        this.name = source["name"];
+	        this.workDir = source["workDir"];
+	        this.running = source["running"];
+	    }
+	}
+	export class ProjectInfo {
+	    id: string;
+	    name: string;
+	    path: string;
+	    color: string;
+	    icon: string;
+	    terminals: TerminalInfo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ProjectInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.color = source["color"];
+	        this.icon = source["icon"];
+	        this.terminals = this.convertValues(source["terminals"], TerminalInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecordingInfo {
+	    id: string;
+	    clientName?: string;
+	    remoteAddr: string;
+	    userAgent: string;
+	    // Go type: time
+	    startedAt: any;
+	    // Go type: time
+	    endedAt: any;
+	    dir: string;
+	    casts: string[];
+	    size: number;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecordingInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.clientName = source["clientName"];
+	        this.remoteAddr = source["remoteAddr"];
+	        this.userAgent = source["userAgent"];
+	        this.startedAt = this.convertValues(source["startedAt"], null);
+	        this.endedAt = this.convertValues(source["endedAt"], null);
+	        this.dir = source["dir"];
+	        this.casts = source["casts"];
+	        this.size = source["size"];
+	        this.active = source["active"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TOTPEnrollment {
+	    secret: string;
+	    uri: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TOTPEnrollment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.secret = source["secret"];
+	        this.uri = source["uri"];
+	    }
+	}
+	
+	
+	export class TunnelInfo {
+	    name: string;
+	    port: number;
+	    publicUrl: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TunnelInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.port = source["port"];
+	        this.publicUrl = source["publicUrl"];
+	    }
+	}
+
+}
+
+export namespace state {
+	
+	export class DockerRegistry {
+	    server: string;
+	    username: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DockerRegistry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.server = source["server"];
+	        this.username = source["username"];
+	    }
+	}
+	export class PomodoroSettings {
+	    sessionMinutes: number;
+	    breakMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PomodoroSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionMinutes = source["sessionMinutes"];
+	        this.breakMinutes = source["breakMinutes"];
+	    }
+	}
+	export class WindowState {
+	    x: number;
+	    y: number;
+	    width: number;
+	    height: number;
+	    maximized: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.x = source["x"];
+	        this.y = source["y"];
+	        this.width = source["width"];
+	        this.height = source["height"];
+	        this.maximized = source["maximized"];
+	    }
+	}
+	export class BannedRemoteIP {
+	    ip: string;
+	    // Go type: time
+	    bannedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new BannedRemoteIP(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ip = source["ip"];
+	        this.bannedAt = this.convertValues(source["bannedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RemotePushSubscription {
+	    endpoint: string;
+	    p256dh: string;
+	    auth: string;
+	    token: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RemotePushSubscription(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.endpoint = source["endpoint"];
+	        this.p256dh = source["p256dh"];
+	        this.auth = source["auth"];
+	        this.token = source["token"];
+	    }
+	}
+	export class ApprovedRemoteClient {
+	    token: string;
+	    name: string;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    lastUsed: any;
+	    viewer?: boolean;
+	    projects?: string[];
+	    capabilities?: string[];
+	    totpSecret?: string;
+	    totpDevices?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ApprovedRemoteClient(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.name = source["name"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	        this.viewer = source["viewer"];
+	        this.projects = source["projects"];
+	        this.capabilities = source["capabilities"];
+	        this.totpSecret = source["totpSecret"];
+	        this.totpDevices = source["totpDevices"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TodoItem {
+	    id: string;
+	    text: string;
+	    completed: boolean;
+	    // Go type: time
+	    createdAt: any;
+	    source?: string;
+	    author?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TodoItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.text = source["text"];
+	        this.completed = source["completed"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.source = source["source"];
+	        this.author = source["author"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PromptCategory {
+	    id: string;
+	    name: string;
+	    order: number;
+	    isGlobal: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptCategory(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.order = source["order"];
+	        this.isGlobal = source["isGlobal"];
+	    }
+	}
+	export class Prompt {
+	    id: string;
+	    title: string;
+	    content: string;
+	    category: string;
+	    usageCount: number;
+	    pinned: boolean;
+	    isGlobal: boolean;
+	    // Go type: time
+	    createdAt: any;
+	    // Go type: time
+	    updatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Prompt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.content = source["content"];
+	        this.category = source["category"];
+	        this.usageCount = source["usageCount"];
+	        this.pinned = source["pinned"];
+	        this.isGlobal = source["isGlobal"];
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CoverageThresholds {
+	    lines: number;
+	    statements: number;
+	    functions: number;
+	    branches: number;
+	    maxDrop: number;
+	    blockReady: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoverageThresholds(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lines = source["lines"];
+	        this.statements = source["statements"];
+	        this.functions = source["functions"];
+	        this.branches = source["branches"];
+	        this.maxDrop = source["maxDrop"];
+	        this.blockReady = source["blockReady"];
+	    }
+	}
+	export class CoverageRecord {
+	    // Go type: time
+	    timestamp: any;
+	    lines: number;
+	    functions: number;
+	    branches: number;
+	    mutationScore?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CoverageRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.lines = source["lines"];
+	        this.functions = source["functions"];
+	        this.branches = source["branches"];
+	        this.mutationScore = source["mutationScore"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TestRun {
+	    id: number;
+	    terminalId: string;
+	    runner: string;
+	    status: string;
+	    passed: number;
+	    failed: number;
+	    skipped: number;
+	    total: number;
+	    duration: number;
+	    // Go type: time
+	    timestamp: any;
+	    failedTests?: string[];
+	    codeVersion?: string;
+	    testDurations?: Record<string, number>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TestRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.terminalId = source["terminalId"];
+	        this.runner = source["runner"];
+	        this.status = source["status"];
+	        this.passed = source["passed"];
+	        this.failed = source["failed"];
+	        this.skipped = source["skipped"];
+	        this.total = source["total"];
+	        this.duration = source["duration"];
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.failedTests = source["failedTests"];
+	        this.codeVersion = source["codeVersion"];
+	        this.testDurations = source["testDurations"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BrowserTab {
+	    id: string;
+	    url: string;
+	    title: string;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BrowserTab(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.url = source["url"];
+	        this.title = source["title"];
+	        this.active = source["active"];
+	    }
+	}
+	export class Bookmark {
+	    id: string;
+	    name: string;
+	    url: string;
+	    order: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Bookmark(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.url = source["url"];
+	        this.order = source["order"];
+	    }
+	}
+	export class BrowserState {
+	    url: string;
+	    deviceIndex: number;
+	    rotated: boolean;
+	    scale: number;
+	    bookmarks: Bookmark[];
+	    tabs: BrowserTab[];
+	    activeTabId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BrowserState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.deviceIndex = source["deviceIndex"];
+	        this.rotated = source["rotated"];
+	        this.scale = source["scale"];
+	        this.bookmarks = this.convertValues(source["bookmarks"], Bookmark);
+	        this.tabs = this.convertValues(source["tabs"], BrowserTab);
+	        this.activeTabId = source["activeTabId"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TerminalState {
+	    id: string;
+	    projectId: string;
+	    name: string;
+	    workDir: string;
+	    running: boolean;
+	    claudeModel?: string;
+	    claudeMode?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.projectId = source["projectId"];
+	        this.name = source["name"];
+	        this.workDir = source["workDir"];
+	        this.running = source["running"];
+	        this.claudeModel = source["claudeModel"];
+	        this.claudeMode = source["claudeMode"];
+	    }
+	}
+	export class ProjectState {
+	    id: string;
+	    name: string;
+	    path: string;
+	    color: string;
+	    icon: string;
+	    terminals: Record<string, TerminalState>;
+	    activeTerminalId: string;
+	    browser?: BrowserState;
+	    activeTab: string;
+	    splitView: boolean;
+	    splitRatio: number;
+	    notes: string;
+	    testHistory: TestRun[];
+	    coverageHistory?: CoverageRecord[];
+	    testHistoryLimit?: number;
+	    testNotifications?: string;
+	    coverageThresholds?: CoverageThresholds;
+	    ignorePatterns?: string[];
+	    prompts: Prompt[];
+	    promptCategories: PromptCategory[];
+	    todos: TodoItem[];
+	    dockerContext?: string;
+	    k8sContext?: string;
+	    k8sNamespace?: string;
+	    browserTabs: string[];
+	    envVars: Record<string, string>;
+	    // Go type: time
+	    lastOpened: any;
+	    // Go type: time
+	    createdAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileDiff(source);
+	        return new ProjectState(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
 	        this.path = source["path"];
-	        this.oldContent = source["oldContent"];
-	        this.newContent = source["newContent"];
-	        this.diffContent = source["diffContent"];
+	        this.color = source["color"];
+	        this.icon = source["icon"];
+	        this.terminals = this.convertValues(source["terminals"], TerminalState, true);
+	        this.activeTerminalId = source["activeTerminalId"];
+	        this.browser = this.convertValues(source["browser"], BrowserState);
+	        this.activeTab = source["activeTab"];
+	        this.splitView = source["splitView"];
+	        this.splitRatio = source["splitRatio"];
+	        this.notes = source["notes"];
+	        this.testHistory = this.convertValues(source["testHistory"], TestRun);
+	        this.coverageHistory = this.convertValues(source["coverageHistory"], CoverageRecord);
+	        this.testHistoryLimit = source["testHistoryLimit"];
+	        this.testNotifications = source["testNotifications"];
+	        this.coverageThresholds = this.convertValues(source["coverageThresholds"], CoverageThresholds);
+	        this.ignorePatterns = source["ignorePatterns"];
+	        this.prompts = this.convertValues(source["prompts"], Prompt);
+	        this.promptCategories = this.convertValues(source["promptCategories"], PromptCategory);
+	        this.todos = this.convertValues(source["todos"], TodoItem);
+	        this.dockerContext = source["dockerContext"];
+	        this.k8sContext = source["k8sContext"];
+	        this.k8sNamespace = source["k8sNamespace"];
+	        this.browserTabs = source["browserTabs"];
+	        this.envVars = source["envVars"];
+	        this.lastOpened = this.convertValues(source["lastOpened"], null);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-
-}
-
-export namespace iterm {
+	export class AppState {
+	    version: number;
+	    activeProjectId: string;
+	    projects: Record<string, ProjectState>;
+	    globalPrompts: Prompt[];
+	    globalPromptCategories: PromptCategory[];
+	    approvedRemoteClients: ApprovedRemoteClient[];
+	    remotePushSubscriptions?: RemotePushSubscription[];
+	    bannedRemoteIps?: BannedRemoteIP[];
+	    terminalTheme: string;
+	    terminalFontSize: number;
+	    toolsPanelHeight: number;
+	    voiceLang: string;
+	    voiceAutoSubmit?: boolean;
+	    dashboardFullscreen: boolean;
+	    window?: WindowState;
+	    pomodoro?: PomodoroSettings;
+	    dockerHost?: string;
+	    dockerRegistries?: DockerRegistry[];
 	
-	export class ITermTab {
-	    windowId: number;
-	    tabIndex: number;
-	    sessionId: string;
+	    static createFrom(source: any = {}) {
+	        return new AppState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.activeProjectId = source["activeProjectId"];
+	        this.projects = this.convertValues(source["projects"], ProjectState, true);
+	        this.globalPrompts = this.convertValues(source["globalPrompts"], Prompt);
+	        this.globalPromptCategories = this.convertValues(source["globalPromptCategories"], PromptCategory);
+	        this.approvedRemoteClients = this.convertValues(source["approvedRemoteClients"], ApprovedRemoteClient);
+	        this.remotePushSubscriptions = this.convertValues(source["remotePushSubscriptions"], RemotePushSubscription);
+	        this.bannedRemoteIps = this.convertValues(source["bannedRemoteIps"], BannedRemoteIP);
+	        this.terminalTheme = source["terminalTheme"];
+	        this.terminalFontSize = source["terminalFontSize"];
+	        this.toolsPanelHeight = source["toolsPanelHeight"];
+	        this.voiceLang = source["voiceLang"];
+	        this.voiceAutoSubmit = source["voiceAutoSubmit"];
+	        this.dashboardFullscreen = source["dashboardFullscreen"];
+	        this.window = this.convertValues(source["window"], WindowState);
+	        this.pomodoro = this.convertValues(source["pomodoro"], PomodoroSettings);
+	        this.dockerHost = source["dockerHost"];
+	        this.dockerRegistries = this.convertValues(source["dockerRegistries"], DockerRegistry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	
+	
+	
+	
+	export class FlakyTest {
 	    name: string;
-	    path: string;
-	    isActive: boolean;
+	    runner: string;
+	    passes: number;
+	    failures: number;
+	    flips: number;
+	    // Go type: time
+	    lastFailed: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new ITermTab(source);
+	        return new FlakyTest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.windowId = source["windowId"];
-	        this.tabIndex = source["tabIndex"];
-	        this.sessionId = source["sessionId"];
 	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.isActive = source["isActive"];
+	        this.runner = source["runner"];
+	        this.passes = source["passes"];
+	        this.failures = source["failures"];
+	        this.flips = source["flips"];
+	        this.lastFailed = this.convertValues(source["lastFailed"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class ITermStatus {
-	    running: boolean;
-	    tabs: ITermTab[];
+	
+	
+	
+	
+	
+	export class RunDuration {
+	    // Go type: time
+	    timestamp: any;
+	    runner: string;
+	    duration: number;
+	    total: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ITermStatus(source);
+	        return new RunDuration(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.running = source["running"];
-	        this.tabs = this.convertValues(source["tabs"], ITermTab);
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.runner = source["runner"];
+	        this.duration = source["duration"];
+	        this.total = source["total"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -406,62 +3446,162 @@ export namespace iterm {
 		    return a;
 		}
 	}
-	
-	export class SessionInfo {
+	export class SlowTest {
 	    name: string;
-	    profileName: string;
-	    columns: number;
-	    rows: number;
-	    currentCommand: string;
-	    jobPid: number;
-	    isProcessing: boolean;
+	    runner: string;
+	    duration: number;
+	    average: number;
+	    change: number;
+	    trend: number[];
 	
 	    static createFrom(source: any = {}) {
-	        return new SessionInfo(source);
+	        return new SlowTest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
-	        this.profileName = source["profileName"];
-	        this.columns = source["columns"];
-	        this.rows = source["rows"];
-	        this.currentCommand = source["currentCommand"];
-	        this.jobPid = source["jobPid"];
-	        this.isProcessing = source["isProcessing"];
+	        this.runner = source["runner"];
+	        this.duration = source["duration"];
+	        this.average = source["average"];
+	        this.change = source["change"];
+	        this.trend = source["trend"];
+	    }
+	}
+	
+	export class TestDurationReport {
+	    runs: RunDuration[];
+	    slowest: SlowTest[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TestDurationReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.runs = this.convertValues(source["runs"], RunDuration);
+	        this.slowest = this.convertValues(source["slowest"], SlowTest);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
+	
 
 }
 
-export namespace main {
+export namespace structure {
 	
-	export class RemoteAccessStatus {
-	    enabled: boolean;
-	    savedDevicesOnly: boolean;
-	    running: boolean;
-	    port: number;
-	    localUrl: string;
-	    publicUrl: string;
-	    token: string;
-	    clientCount: number;
-	    clients: remote.ClientInfo[];
+	export class CodeTodo {
+	    file: string;
+	    line: number;
+	    marker: string;
+	    text: string;
+	    author?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CodeTodo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.marker = source["marker"];
+	        this.text = source["text"];
+	        this.author = source["author"];
+	    }
+	}
+	export class DependencyCycle {
+	    files: string[];
+	    path: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DependencyCycle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.files = source["files"];
+	        this.path = source["path"];
+	    }
+	}
+	export class FileMetrics {
+	    lines: number;
+	    size: number;
+	    complexity: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lines = source["lines"];
+	        this.size = source["size"];
+	        this.complexity = source["complexity"];
+	    }
+	}
+	export class GitFlags {
+	    staged?: boolean;
+	    modified?: boolean;
+	    untracked?: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new RemoteAccessStatus(source);
+	        return new GitFlags(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.enabled = source["enabled"];
-	        this.savedDevicesOnly = source["savedDevicesOnly"];
-	        this.running = source["running"];
-	        this.port = source["port"];
-	        this.localUrl = source["localUrl"];
-	        this.publicUrl = source["publicUrl"];
-	        this.token = source["token"];
-	        this.clientCount = source["clientCount"];
-	        this.clients = this.convertValues(source["clients"], remote.ClientInfo);
+	        this.staged = source["staged"];
+	        this.modified = source["modified"];
+	        this.untracked = source["untracked"];
+	    }
+	}
+	export class FileNode {
+	    name: string;
+	    path: string;
+	    isDir: boolean;
+	    children?: FileNode[];
+	    fileCount?: number;
+	    language?: string;
+	    languages?: Record<string, number>;
+	    metrics?: FileMetrics;
+	    hasMore?: boolean;
+	    git?: GitFlags;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileNode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.isDir = source["isDir"];
+	        this.children = this.convertValues(source["children"], FileNode);
+	        this.fileCount = source["fileCount"];
+	        this.language = source["language"];
+	        this.languages = source["languages"];
+	        this.metrics = this.convertValues(source["metrics"], FileMetrics);
+	        this.hasMore = source["hasMore"];
+	        this.git = this.convertValues(source["git"], GitFlags);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -482,67 +3622,71 @@ export namespace main {
 		    return a;
 		}
 	}
-	export class Screenshot {
-	    id: string;
-	    filename: string;
-	    path: string;
-	    timestamp: number;
+	
+	export class SearchMatch {
+	    file: string;
+	    line: number;
+	    column: number;
+	    length: number;
+	    text: string;
+	    before?: string[];
+	    after?: string[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Screenshot(source);
+	        return new SearchMatch(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.filename = source["filename"];
-	        this.path = source["path"];
-	        this.timestamp = source["timestamp"];
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.length = source["length"];
+	        this.text = source["text"];
+	        this.before = source["before"];
+	        this.after = source["after"];
 	    }
 	}
-	export class TerminalInfo {
-	    id: string;
-	    projectId: string;
-	    name: string;
-	    workDir: string;
-	    running: boolean;
+	export class SearchOptions {
+	    caseSensitive: boolean;
+	    regex: boolean;
+	    wholeWord: boolean;
+	    include: string;
+	    maxResults: number;
+	    contextLines: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new TerminalInfo(source);
+	        return new SearchOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.projectId = source["projectId"];
-	        this.name = source["name"];
-	        this.workDir = source["workDir"];
-	        this.running = source["running"];
+	        this.caseSensitive = source["caseSensitive"];
+	        this.regex = source["regex"];
+	        this.wholeWord = source["wholeWord"];
+	        this.include = source["include"];
+	        this.maxResults = source["maxResults"];
+	        this.contextLines = source["contextLines"];
 	    }
 	}
-
-}
-
-export namespace remote {
-	
-	export class ApprovedClient {
-	    token: string;
-	    name: string;
-	    // Go type: time
-	    createdAt: any;
-	    // Go type: time
-	    lastUsed: any;
+	export class SearchResult {
+	    query: string;
+	    matches: SearchMatch[];
+	    filesSearched: number;
+	    filesMatched: number;
+	    truncated: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ApprovedClient(source);
+	        return new SearchResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.token = source["token"];
-	        this.name = source["name"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	        this.query = source["query"];
+	        this.matches = this.convertValues(source["matches"], SearchMatch);
+	        this.filesSearched = source["filesSearched"];
+	        this.filesMatched = source["filesMatched"];
+	        this.truncated = source["truncated"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -563,25 +3707,53 @@ export namespace remote {
 		    return a;
 		}
 	}
-	export class ClientInfo {
+
+}
+
+export namespace teams {
+	
+	export class Subagent {
 	    id: string;
-	    // Go type: time
-	    connectedAt: any;
-	    terminalId: string;
-	    userAgent: string;
-	    remoteAddr: string;
+	    type: string;
+	    description: string;
+	    prompt: string;
+	    status: string;
+	    startedAt: number;
+	    endedAt?: number;
+	    result?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ClientInfo(source);
+	        return new Subagent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.connectedAt = this.convertValues(source["connectedAt"], null);
-	        this.terminalId = source["terminalId"];
-	        this.userAgent = source["userAgent"];
-	        this.remoteAddr = source["remoteAddr"];
+	        this.type = source["type"];
+	        this.description = source["description"];
+	        this.prompt = source["prompt"];
+	        this.status = source["status"];
+	        this.startedAt = source["startedAt"];
+	        this.endedAt = source["endedAt"];
+	        this.result = source["result"];
+	    }
+	}
+	export class SessionSubagents {
+	    sessionId: string;
+	    projectPath: string;
+	    subagents: Subagent[];
+	    lastModified: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionSubagents(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.projectPath = source["projectPath"];
+	        this.subagents = this.convertValues(source["subagents"], Subagent);
+	        this.lastModified = source["lastModified"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -602,70 +3774,68 @@ export namespace remote {
 		    return a;
 		}
 	}
-	export class Config {
-	    enabled: boolean;
-	    savedDevicesOnly: boolean;
-	    port: number;
-	    ngrokPlan: string;
-	    subdomain: string;
-	    tokenExpiry: number;
-	    ngrokApiPort: number;
+	
+	export class TeamHistoryEntry {
+	    name: string;
+	    description: string;
+	    createdAt: number;
+	    archivedAt: number;
+	    memberCount: number;
+	    taskCount: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Config(source);
+	        return new TeamHistoryEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.enabled = source["enabled"];
-	        this.savedDevicesOnly = source["savedDevicesOnly"];
-	        this.port = source["port"];
-	        this.ngrokPlan = source["ngrokPlan"];
-	        this.subdomain = source["subdomain"];
-	        this.tokenExpiry = source["tokenExpiry"];
-	        this.ngrokApiPort = source["ngrokApiPort"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.createdAt = source["createdAt"];
+	        this.archivedAt = source["archivedAt"];
+	        this.memberCount = source["memberCount"];
+	        this.taskCount = source["taskCount"];
 	    }
 	}
-	export class TerminalInfo {
-	    id: string;
-	    projectId: string;
-	    name: string;
-	    workDir: string;
-	    running: boolean;
+
+}
+
+export namespace testing {
+	
+	export class LineRange {
+	    start: number;
+	    end: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new TerminalInfo(source);
+	        return new LineRange(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.projectId = source["projectId"];
-	        this.name = source["name"];
-	        this.workDir = source["workDir"];
-	        this.running = source["running"];
+	        this.start = source["start"];
+	        this.end = source["end"];
 	    }
 	}
-	export class ProjectInfo {
-	    id: string;
-	    name: string;
+	export class FileChangeCoverage {
 	    path: string;
-	    color: string;
-	    icon: string;
-	    terminals: TerminalInfo[];
+	    changedLines: number;
+	    lines: CoverageDetail;
+	    uncoveredLines: LineRange[];
+	    fileLines: CoverageDetail;
+	    inReport: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProjectInfo(source);
+	        return new FileChangeCoverage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
 	        this.path = source["path"];
-	        this.color = source["color"];
-	        this.icon = source["icon"];
-	        this.terminals = this.convertValues(source["terminals"], TerminalInfo);
+	        this.changedLines = source["changedLines"];
+	        this.lines = this.convertValues(source["lines"], CoverageDetail);
+	        this.uncoveredLines = this.convertValues(source["uncoveredLines"], LineRange);
+	        this.fileLines = this.convertValues(source["fileLines"], CoverageDetail);
+	        this.inReport = source["inReport"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -686,63 +3856,100 @@ export namespace remote {
 		    return a;
 		}
 	}
-
-}
-
-export namespace state {
+	export class CoverageDetail {
+	    total: number;
+	    covered: number;
+	    skipped: number;
+	    pct: number;
 	
-	export class PomodoroSettings {
-	    sessionMinutes: number;
-	    breakMinutes: number;
+	    static createFrom(source: any = {}) {
+	        return new CoverageDetail(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.total = source["total"];
+	        this.covered = source["covered"];
+	        this.skipped = source["skipped"];
+	        this.pct = source["pct"];
+	    }
+	}
+	export class ChangeCoverage {
+	    base?: string;
+	    lines: CoverageDetail;
+	    files: FileChangeCoverage[];
+	    lineData: boolean;
+	    format?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new PomodoroSettings(source);
+	        return new ChangeCoverage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.sessionMinutes = source["sessionMinutes"];
-	        this.breakMinutes = source["breakMinutes"];
+	        this.base = source["base"];
+	        this.lines = this.convertValues(source["lines"], CoverageDetail);
+	        this.files = this.convertValues(source["files"], FileChangeCoverage);
+	        this.lineData = source["lineData"];
+	        this.format = source["format"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class WindowState {
-	    x: number;
-	    y: number;
-	    width: number;
-	    height: number;
-	    maximized: boolean;
+	
+	export class CoverageViolation {
+	    metric: string;
+	    actual: number;
+	    threshold?: number;
+	    previous?: number;
+	    message: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new WindowState(source);
+	        return new CoverageViolation(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.x = source["x"];
-	        this.y = source["y"];
-	        this.width = source["width"];
-	        this.height = source["height"];
-	        this.maximized = source["maximized"];
+	        this.metric = source["metric"];
+	        this.actual = source["actual"];
+	        this.threshold = source["threshold"];
+	        this.previous = source["previous"];
+	        this.message = source["message"];
 	    }
 	}
-	export class ApprovedRemoteClient {
-	    token: string;
-	    name: string;
-	    // Go type: time
-	    createdAt: any;
+	export class CoverageGate {
+	    projectPath: string;
+	    passed: boolean;
+	    violations: CoverageViolation[];
 	    // Go type: time
-	    lastUsed: any;
+	    checkedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new ApprovedRemoteClient(source);
+	        return new CoverageGate(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.token = source["token"];
-	        this.name = source["name"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	        this.projectPath = source["projectPath"];
+	        this.passed = source["passed"];
+	        this.violations = this.convertValues(source["violations"], CoverageViolation);
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -763,23 +3970,25 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class TodoItem {
-	    id: string;
-	    text: string;
-	    completed: boolean;
+	export class CoverageHistoryEntry {
 	    // Go type: time
-	    createdAt: any;
+	    timestamp: any;
+	    lines: number;
+	    functions: number;
+	    branches: number;
+	    mutationScore?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new TodoItem(source);
+	        return new CoverageHistoryEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.text = source["text"];
-	        this.completed = source["completed"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.lines = source["lines"];
+	        this.functions = source["functions"];
+	        this.branches = source["branches"];
+	        this.mutationScore = source["mutationScore"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -800,52 +4009,16 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class PromptCategory {
-	    id: string;
-	    name: string;
-	    order: number;
-	    isGlobal: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new PromptCategory(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.order = source["order"];
-	        this.isGlobal = source["isGlobal"];
-	    }
-	}
-	export class Prompt {
-	    id: string;
-	    title: string;
-	    content: string;
-	    category: string;
-	    usageCount: number;
-	    pinned: boolean;
-	    isGlobal: boolean;
-	    // Go type: time
-	    createdAt: any;
-	    // Go type: time
-	    updatedAt: any;
+	export class CoverageHistory {
+	    entries: CoverageHistoryEntry[];
 	
 	    static createFrom(source: any = {}) {
-	        return new Prompt(source);
+	        return new CoverageHistory(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.title = source["title"];
-	        this.content = source["content"];
-	        this.category = source["category"];
-	        this.usageCount = source["usageCount"];
-	        this.pinned = source["pinned"];
-	        this.isGlobal = source["isGlobal"];
-	        this.createdAt = this.convertValues(source["createdAt"], null);
-	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.entries = this.convertValues(source["entries"], CoverageHistoryEntry);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -866,35 +4039,23 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class TestRun {
-	    id: number;
-	    terminalId: string;
-	    runner: string;
-	    status: string;
-	    passed: number;
-	    failed: number;
-	    skipped: number;
-	    total: number;
-	    duration: number;
-	    // Go type: time
-	    timestamp: any;
+	
+	export class CoverageMetrics {
+	    lines: CoverageDetail;
+	    statements: CoverageDetail;
+	    functions: CoverageDetail;
+	    branches: CoverageDetail;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestRun(source);
+	        return new CoverageMetrics(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.terminalId = source["terminalId"];
-	        this.runner = source["runner"];
-	        this.status = source["status"];
-	        this.passed = source["passed"];
-	        this.failed = source["failed"];
-	        this.skipped = source["skipped"];
-	        this.total = source["total"];
-	        this.duration = source["duration"];
-	        this.timestamp = this.convertValues(source["timestamp"], null);
+	        this.lines = this.convertValues(source["lines"], CoverageDetail);
+	        this.statements = this.convertValues(source["statements"], CoverageDetail);
+	        this.functions = this.convertValues(source["functions"], CoverageDetail);
+	        this.branches = this.convertValues(source["branches"], CoverageDetail);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -915,64 +4076,25 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class BrowserTab {
-	    id: string;
-	    url: string;
-	    title: string;
-	    active: boolean;
-	
-	    static createFrom(source: any = {}) {
-	        return new BrowserTab(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.url = source["url"];
-	        this.title = source["title"];
-	        this.active = source["active"];
-	    }
-	}
-	export class Bookmark {
-	    id: string;
-	    name: string;
-	    url: string;
-	    order: number;
-	
-	    static createFrom(source: any = {}) {
-	        return new Bookmark(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.name = source["name"];
-	        this.url = source["url"];
-	        this.order = source["order"];
-	    }
-	}
-	export class BrowserState {
-	    url: string;
-	    deviceIndex: number;
-	    rotated: boolean;
-	    scale: number;
-	    bookmarks: Bookmark[];
-	    tabs: BrowserTab[];
-	    activeTabId: string;
+	export class CoverageSummary {
+	    total: CoverageMetrics;
+	    byFile?: Record<string, CoverageMetrics>;
+	    // Go type: time
+	    lastUpdated: any;
+	    projectPath: string;
+	    format?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new BrowserState(source);
+	        return new CoverageSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.url = source["url"];
-	        this.deviceIndex = source["deviceIndex"];
-	        this.rotated = source["rotated"];
-	        this.scale = source["scale"];
-	        this.bookmarks = this.convertValues(source["bookmarks"], Bookmark);
-	        this.tabs = this.convertValues(source["tabs"], BrowserTab);
-	        this.activeTabId = source["activeTabId"];
+	        this.total = this.convertValues(source["total"], CoverageMetrics);
+	        this.byFile = this.convertValues(source["byFile"], CoverageMetrics, true);
+	        this.lastUpdated = this.convertValues(source["lastUpdated"], null);
+	        this.projectPath = source["projectPath"];
+	        this.format = source["format"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -993,76 +4115,26 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class TerminalState {
-	    id: string;
-	    projectId: string;
-	    name: string;
-	    workDir: string;
-	    running: boolean;
 	
-	    static createFrom(source: any = {}) {
-	        return new TerminalState(source);
-	    }
 	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
-	        this.projectId = source["projectId"];
-	        this.name = source["name"];
-	        this.workDir = source["workDir"];
-	        this.running = source["running"];
-	    }
-	}
-	export class ProjectState {
-	    id: string;
+	export class FunctionCoverage {
 	    name: string;
-	    path: string;
-	    color: string;
-	    icon: string;
-	    terminals: Record<string, TerminalState>;
-	    activeTerminalId: string;
-	    browser?: BrowserState;
-	    activeTab: string;
-	    splitView: boolean;
-	    splitRatio: number;
-	    notes: string;
-	    testHistory: TestRun[];
-	    prompts: Prompt[];
-	    promptCategories: PromptCategory[];
-	    todos: TodoItem[];
-	    browserTabs: string[];
-	    envVars: Record<string, string>;
-	    // Go type: time
-	    lastOpened: any;
-	    // Go type: time
-	    createdAt: any;
+	    startLine: number;
+	    endLine?: number;
+	    hits: number;
+	    lines: CoverageDetail;
 	
 	    static createFrom(source: any = {}) {
-	        return new ProjectState(source);
+	        return new FunctionCoverage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.color = source["color"];
-	        this.icon = source["icon"];
-	        this.terminals = this.convertValues(source["terminals"], TerminalState, true);
-	        this.activeTerminalId = source["activeTerminalId"];
-	        this.browser = this.convertValues(source["browser"], BrowserState);
-	        this.activeTab = source["activeTab"];
-	        this.splitView = source["splitView"];
-	        this.splitRatio = source["splitRatio"];
-	        this.notes = source["notes"];
-	        this.testHistory = this.convertValues(source["testHistory"], TestRun);
-	        this.prompts = this.convertValues(source["prompts"], Prompt);
-	        this.promptCategories = this.convertValues(source["promptCategories"], PromptCategory);
-	        this.todos = this.convertValues(source["todos"], TodoItem);
-	        this.browserTabs = source["browserTabs"];
-	        this.envVars = source["envVars"];
-	        this.lastOpened = this.convertValues(source["lastOpened"], null);
-	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.startLine = source["startLine"];
+	        this.endLine = source["endLine"];
+	        this.hits = source["hits"];
+	        this.lines = this.convertValues(source["lines"], CoverageDetail);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1083,42 +4155,22 @@ export namespace state {
 		    return a;
 		}
 	}
-	export class AppState {
-	    version: number;
-	    activeProjectId: string;
-	    projects: Record<string, ProjectState>;
-	    globalPrompts: Prompt[];
-	    globalPromptCategories: PromptCategory[];
-	    approvedRemoteClients: ApprovedRemoteClient[];
-	    terminalTheme: string;
-	    terminalFontSize: number;
-	    toolsPanelHeight: number;
-	    voiceLang: string;
-	    voiceAutoSubmit?: boolean;
-	    dashboardFullscreen: boolean;
-	    window?: WindowState;
-	    pomodoro?: PomodoroSettings;
+	export class FileCoverage {
+	    path: string;
+	    metrics: CoverageMetrics;
+	    functions: FunctionCoverage[];
+	    uncoveredLines: LineRange[];
 	
 	    static createFrom(source: any = {}) {
-	        return new AppState(source);
+	        return new FileCoverage(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.version = source["version"];
-	        this.activeProjectId = source["activeProjectId"];
-	        this.projects = this.convertValues(source["projects"], ProjectState, true);
-	        this.globalPrompts = this.convertValues(source["globalPrompts"], Prompt);
-	        this.globalPromptCategories = this.convertValues(source["globalPromptCategories"], PromptCategory);
-	        this.approvedRemoteClients = this.convertValues(source["approvedRemoteClients"], ApprovedRemoteClient);
-	        this.terminalTheme = source["terminalTheme"];
-	        this.terminalFontSize = source["terminalFontSize"];
-	        this.toolsPanelHeight = source["toolsPanelHeight"];
-	        this.voiceLang = source["voiceLang"];
-	        this.voiceAutoSubmit = source["voiceAutoSubmit"];
-	        this.dashboardFullscreen = source["dashboardFullscreen"];
-	        this.window = this.convertValues(source["window"], WindowState);
-	        this.pomodoro = this.convertValues(source["pomodoro"], PomodoroSettings);
+	        this.path = source["path"];
+	        this.metrics = this.convertValues(source["metrics"], CoverageMetrics);
+	        this.functions = this.convertValues(source["functions"], FunctionCoverage);
+	        this.uncoveredLines = this.convertValues(source["uncoveredLines"], LineRange);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1141,38 +4193,63 @@ export namespace state {
 	}
 	
 	
+	export class Mutant {
+	    file: string;
+	    line: number;
+	    column?: number;
+	    mutator: string;
+	    replacement?: string;
+	    noCoverage?: boolean;
 	
+	    static createFrom(source: any = {}) {
+	        return new Mutant(source);
+	    }
 	
-	
-	
-	
-	
-	
-	
-	
-
-}
-
-export namespace structure {
-	
-	export class FileNode {
-	    name: string;
-	    path: string;
-	    isDir: boolean;
-	    children?: FileNode[];
-	    fileCount?: number;
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.mutator = source["mutator"];
+	        this.replacement = source["replacement"];
+	        this.noCoverage = source["noCoverage"];
+	    }
+	}
+	export class MutationSummary {
+	    projectPath: string;
+	    tool: string;
+	    score: number;
+	    killed: number;
+	    timeout: number;
+	    survived: number;
+	    noCoverage: number;
+	    errors: number;
+	    total: number;
+	    survivors: Mutant[];
+	    // Go type: time
+	    lastUpdated: any;
+	    running?: boolean;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileNode(source);
+	        return new MutationSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.isDir = source["isDir"];
-	        this.children = this.convertValues(source["children"], FileNode);
-	        this.fileCount = source["fileCount"];
+	        this.projectPath = source["projectPath"];
+	        this.tool = source["tool"];
+	        this.score = source["score"];
+	        this.killed = source["killed"];
+	        this.timeout = source["timeout"];
+	        this.survived = source["survived"];
+	        this.noCoverage = source["noCoverage"];
+	        this.errors = source["errors"];
+	        this.total = source["total"];
+	        this.survivors = this.convertValues(source["survivors"], Mutant);
+	        this.lastUpdated = this.convertValues(source["lastUpdated"], null);
+	        this.running = source["running"];
+	        this.error = source["error"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1193,73 +4270,84 @@ export namespace structure {
 		    return a;
 		}
 	}
-
-}
-
-export namespace teams {
-	
-	export class TeamHistoryEntry {
-	    name: string;
-	    description: string;
-	    createdAt: number;
-	    archivedAt: number;
-	    memberCount: number;
-	    taskCount: number;
+	export class TestFailure {
+	    test: string;
+	    file: string;
+	    line: number;
+	    column?: number;
+	    message?: string;
+	    expected?: string;
+	    actual?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TeamHistoryEntry(source);
+	        return new TestFailure(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.description = source["description"];
-	        this.createdAt = source["createdAt"];
-	        this.archivedAt = source["archivedAt"];
-	        this.memberCount = source["memberCount"];
-	        this.taskCount = source["taskCount"];
+	        this.test = source["test"];
+	        this.file = source["file"];
+	        this.line = source["line"];
+	        this.column = source["column"];
+	        this.message = source["message"];
+	        this.expected = source["expected"];
+	        this.actual = source["actual"];
 	    }
 	}
-
-}
-
-export namespace testing {
-	
-	export class CoverageDetail {
-	    total: number;
-	    covered: number;
-	    skipped: number;
-	    pct: number;
+	export class TestResult {
+	    name: string;
+	    status: string;
+	    duration: number;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CoverageDetail(source);
+	        return new TestResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.total = source["total"];
-	        this.covered = source["covered"];
-	        this.skipped = source["skipped"];
-	        this.pct = source["pct"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.duration = source["duration"];
+	        this.error = source["error"];
 	    }
 	}
-	export class CoverageHistoryEntry {
+	export class TestSummary {
+	    runner: string;
+	    status: string;
+	    passed: number;
+	    failed: number;
+	    skipped: number;
+	    total: number;
+	    duration: number;
+	    failedTests?: TestResult[];
+	    testDurations?: TestResult[];
+	    failures?: TestFailure[];
 	    // Go type: time
-	    timestamp: any;
-	    lines: number;
-	    functions: number;
-	    branches: number;
+	    startTime: any;
+	    // Go type: time
+	    endTime?: any;
+	    coveragePercent?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CoverageHistoryEntry(source);
+	        return new TestSummary(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.timestamp = this.convertValues(source["timestamp"], null);
-	        this.lines = source["lines"];
-	        this.functions = source["functions"];
-	        this.branches = source["branches"];
+	        this.runner = source["runner"];
+	        this.status = source["status"];
+	        this.passed = source["passed"];
+	        this.failed = source["failed"];
+	        this.skipped = source["skipped"];
+	        this.total = source["total"];
+	        this.duration = source["duration"];
+	        this.failedTests = this.convertValues(source["failedTests"], TestResult);
+	        this.testDurations = this.convertValues(source["testDurations"], TestResult);
+	        this.failures = this.convertValues(source["failures"], TestFailure);
+	        this.startTime = this.convertValues(source["startTime"], null);
+	        this.endTime = this.convertValues(source["endTime"], null);
+	        this.coveragePercent = source["coveragePercent"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1280,16 +4368,36 @@ export namespace testing {
 		    return a;
 		}
 	}
-	export class CoverageHistory {
-	    entries: CoverageHistoryEntry[];
+	export class RerunResult {
+	    projectPath: string;
+	    changedFiles: string[];
+	    testFiles: string[];
+	    command: string;
+	    summary?: TestSummary;
+	    running: boolean;
+	    exitCode: number;
+	    error?: string;
+	    // Go type: time
+	    startTime: any;
+	    // Go type: time
+	    endTime?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CoverageHistory(source);
+	        return new RerunResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.entries = this.convertValues(source["entries"], CoverageHistoryEntry);
+	        this.projectPath = source["projectPath"];
+	        this.changedFiles = source["changedFiles"];
+	        this.testFiles = source["testFiles"];
+	        this.command = source["command"];
+	        this.summary = this.convertValues(source["summary"], TestSummary);
+	        this.running = source["running"];
+	        this.exitCode = source["exitCode"];
+	        this.error = source["error"];
+	        this.startTime = this.convertValues(source["startTime"], null);
+	        this.endTime = this.convertValues(source["endTime"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1310,23 +4418,33 @@ export namespace testing {
 		    return a;
 		}
 	}
-	
-	export class CoverageMetrics {
-	    lines: CoverageDetail;
-	    statements: CoverageDetail;
-	    functions: CoverageDetail;
-	    branches: CoverageDetail;
+	export class Shard {
+	    index: number;
+	    testFiles: string[];
+	    tests: number;
+	    command: string;
+	    summary?: TestSummary;
+	    running: boolean;
+	    exitCode: number;
+	    error?: string;
+	    // Go type: time
+	    endTime?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CoverageMetrics(source);
+	        return new Shard(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.lines = this.convertValues(source["lines"], CoverageDetail);
-	        this.statements = this.convertValues(source["statements"], CoverageDetail);
-	        this.functions = this.convertValues(source["functions"], CoverageDetail);
-	        this.branches = this.convertValues(source["branches"], CoverageDetail);
+	        this.index = source["index"];
+	        this.testFiles = source["testFiles"];
+	        this.tests = source["tests"];
+	        this.command = source["command"];
+	        this.summary = this.convertValues(source["summary"], TestSummary);
+	        this.running = source["running"];
+	        this.exitCode = source["exitCode"];
+	        this.error = source["error"];
+	        this.endTime = this.convertValues(source["endTime"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1347,23 +4465,32 @@ export namespace testing {
 		    return a;
 		}
 	}
-	export class CoverageSummary {
-	    total: CoverageMetrics;
-	    byFile?: Record<string, CoverageMetrics>;
-	    // Go type: time
-	    lastUpdated: any;
+	export class ShardRun {
 	    projectPath: string;
+	    strategy: string;
+	    shards: Shard[];
+	    summary?: TestSummary;
+	    running: boolean;
+	    error?: string;
+	    // Go type: time
+	    startTime: any;
+	    // Go type: time
+	    endTime?: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new CoverageSummary(source);
+	        return new ShardRun(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.total = this.convertValues(source["total"], CoverageMetrics);
-	        this.byFile = this.convertValues(source["byFile"], CoverageMetrics, true);
-	        this.lastUpdated = this.convertValues(source["lastUpdated"], null);
 	        this.projectPath = source["projectPath"];
+	        this.strategy = source["strategy"];
+	        this.shards = this.convertValues(source["shards"], Shard);
+	        this.summary = this.convertValues(source["summary"], TestSummary);
+	        this.running = source["running"];
+	        this.error = source["error"];
+	        this.startTime = this.convertValues(source["startTime"], null);
+	        this.endTime = this.convertValues(source["endTime"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1384,45 +4511,49 @@ export namespace testing {
 		    return a;
 		}
 	}
-	export class TestFileInfo {
-	    path: string;
-	    testCount: number;
-	    type: string;
+	export class SnapshotEntry {
+	    status: string;
+	    snapshotFile?: string;
+	    testFile: string;
+	    key?: string;
+	    test?: string;
+	    diff?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestFileInfo(source);
+	        return new SnapshotEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.path = source["path"];
-	        this.testCount = source["testCount"];
-	        this.type = source["type"];
+	        this.status = source["status"];
+	        this.snapshotFile = source["snapshotFile"];
+	        this.testFile = source["testFile"];
+	        this.key = source["key"];
+	        this.test = source["test"];
+	        this.diff = source["diff"];
 	    }
 	}
-	export class TestDiscovery {
-	    totalTests: number;
-	    unitTests: number;
-	    e2eTests: number;
-	    integrationTests: number;
-	    testFiles: TestFileInfo[];
-	    // Go type: time
-	    scannedAt: any;
+	export class SnapshotReport {
 	    projectPath: string;
+	    runner: string;
+	    snapshotFiles: number;
+	    snapshots: number;
+	    entries: SnapshotEntry[];
+	    // Go type: time
+	    checkedAt: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestDiscovery(source);
+	        return new SnapshotReport(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.totalTests = source["totalTests"];
-	        this.unitTests = source["unitTests"];
-	        this.e2eTests = source["e2eTests"];
-	        this.integrationTests = source["integrationTests"];
-	        this.testFiles = this.convertValues(source["testFiles"], TestFileInfo);
-	        this.scannedAt = this.convertValues(source["scannedAt"], null);
 	        this.projectPath = source["projectPath"];
+	        this.runner = source["runner"];
+	        this.snapshotFiles = source["snapshotFiles"];
+	        this.snapshots = source["snapshots"];
+	        this.entries = this.convertValues(source["entries"], SnapshotEntry);
+	        this.checkedAt = this.convertValues(source["checkedAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1443,57 +4574,49 @@ export namespace testing {
 		    return a;
 		}
 	}
-	
-	export class TestResult {
-	    name: string;
-	    status: string;
-	    duration: number;
-	    error?: string;
+	export class TestFileInfo {
+	    path: string;
+	    testCount: number;
+	    type: string;
+	    framework?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestResult(source);
+	        return new TestFileInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.status = source["status"];
-	        this.duration = source["duration"];
-	        this.error = source["error"];
+	        this.path = source["path"];
+	        this.testCount = source["testCount"];
+	        this.type = source["type"];
+	        this.framework = source["framework"];
 	    }
 	}
-	export class TestSummary {
-	    runner: string;
-	    status: string;
-	    passed: number;
-	    failed: number;
-	    skipped: number;
-	    total: number;
-	    duration: number;
-	    failedTests?: TestResult[];
-	    // Go type: time
-	    startTime: any;
+	export class TestDiscovery {
+	    totalTests: number;
+	    unitTests: number;
+	    e2eTests: number;
+	    integrationTests: number;
+	    testFiles: TestFileInfo[];
 	    // Go type: time
-	    endTime?: any;
-	    coveragePercent?: number;
+	    scannedAt: any;
+	    projectPath: string;
+	    commands: Record<string, string>;
 	
 	    static createFrom(source: any = {}) {
-	        return new TestSummary(source);
+	        return new TestDiscovery(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.runner = source["runner"];
-	        this.status = source["status"];
-	        this.passed = source["passed"];
-	        this.failed = source["failed"];
-	        this.skipped = source["skipped"];
-	        this.total = source["total"];
-	        this.duration = source["duration"];
-	        this.failedTests = this.convertValues(source["failedTests"], TestResult);
-	        this.startTime = this.convertValues(source["startTime"], null);
-	        this.endTime = this.convertValues(source["endTime"], null);
-	        this.coveragePercent = source["coveragePercent"];
+	        this.totalTests = source["totalTests"];
+	        this.unitTests = source["unitTests"];
+	        this.e2eTests = source["e2eTests"];
+	        this.integrationTests = source["integrationTests"];
+	        this.testFiles = this.convertValues(source["testFiles"], TestFileInfo);
+	        this.scannedAt = this.convertValues(source["scannedAt"], null);
+	        this.projectPath = source["projectPath"];
+	        this.commands = source["commands"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1514,6 +4637,9 @@ export namespace testing {
 		    return a;
 		}
 	}
+	
+	
+	
 
 }
 
@@ -0,0 +1,278 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AgentFrontmatter holds the YAML frontmatter fields of a subagent file
+type AgentFrontmatter struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tools       []string `json:"tools"` // Empty = inherit all tools
+	Model       string   `json:"model"` // sonnet, opus, haiku, inherit or a model ID
+	Color       string   `json:"color"`
+}
+
+// AgentIssue is a problem found while validating an agent definition
+type AgentIssue struct {
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // error or warning
+	Line     int    `json:"line"`     // 1-based line in the file, 0 if unknown
+}
+
+// AgentValidationError is returned when an agent has error-level issues
+type AgentValidationError struct {
+	Issues []AgentIssue
+}
+
+func (e *AgentValidationError) Error() string {
+	messages := []string{}
+	for _, issue := range e.Issues {
+		if issue.Severity == "error" {
+			messages = append(messages, issue.Field+": "+issue.Message)
+		}
+	}
+	return "invalid agent: " + strings.Join(messages, "; ")
+}
+
+var (
+	agentNameRe  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	agentToolRe  = regexp.MustCompile(`^([A-Za-z]+)(\(.*\))?$`)
+	agentModels  = []string{"sonnet", "opus", "haiku", "inherit"}
+	agentColors  = []string{"red", "blue", "green", "yellow", "purple", "orange", "pink", "cyan"}
+	agentFields  = []string{"name", "description", "tools", "model", "color", "permissionMode", "skills"}
+	builtinTools = []string{
+		"Bash", "BashOutput", "Edit", "ExitPlanMode", "Glob", "Grep", "KillShell", "LS",
+		"MultiEdit", "NotebookEdit", "NotebookRead", "Read", "SlashCommand", "Skill",
+		"Task", "TodoWrite", "WebFetch", "WebSearch", "Write",
+	}
+)
+
+// ValidateAgentContent checks an agent markdown file's frontmatter against the
+// fields Claude Code accepts. Unknown fields and tools are warnings, since the
+// CLI adds new ones over time; missing or malformed required fields are errors.
+func ValidateAgentContent(content string) []AgentIssue {
+	issues := []AgentIssue{}
+	fields, lines, ok := parseFrontmatter(content)
+	if !ok {
+		return append(issues, AgentIssue{
+			Field:    "frontmatter",
+			Message:  "file must start with a --- delimited YAML frontmatter block",
+			Severity: "error",
+			Line:     1,
+		})
+	}
+
+	addIssue := func(field, severity, format string, args ...interface{}) {
+		issues = append(issues, AgentIssue{
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: severity,
+			Line:     lines[field],
+		})
+	}
+
+	name := fields["name"]
+	switch {
+	case name == "":
+		addIssue("name", "error", "name is required")
+	case !agentNameRe.MatchString(name):
+		addIssue("name", "error", "name must be lowercase letters, digits and hyphens")
+	}
+
+	if strings.TrimSpace(fields["description"]) == "" {
+		addIssue("description", "error", "description is required; Claude uses it to decide when to delegate")
+	}
+
+	if tools, ok := fields["tools"]; ok {
+		for _, tool := range splitAgentTools(tools) {
+			match := agentToolRe.FindStringSubmatch(tool)
+			switch {
+			case strings.HasPrefix(tool, "mcp__"):
+				// MCP tools are named mcp__<server>__<tool>
+			case match == nil:
+				addIssue("tools", "error", "invalid tool name %q", tool)
+			case !containsString(builtinTools, match[1]):
+				addIssue("tools", "warning", "unknown tool %q", tool)
+			}
+		}
+	}
+
+	if model, ok := fields["model"]; ok && model != "" {
+		if !containsString(agentModels, model) && !strings.HasPrefix(model, "claude-") {
+			addIssue("model", "error", "model must be one of %s, or a claude-* model ID", strings.Join(agentModels, ", "))
+		}
+	}
+
+	if color, ok := fields["color"]; ok && color != "" && !containsString(agentColors, color) {
+		addIssue("color", "warning", "unsupported color %q (use %s)", color, strings.Join(agentColors, ", "))
+	}
+
+	for field := range fields {
+		if !containsString(agentFields, field) {
+			addIssue(field, "warning", "unknown field %q is ignored by Claude Code", field)
+		}
+	}
+
+	return issues
+}
+
+// CreateAgent validates and writes a new agent to .claude/agents/<name>.md
+func (m *ToolsManager) CreateAgent(projectPath, name string, frontmatter AgentFrontmatter, body string) (*Agent, error) {
+	frontmatter.Name = strings.TrimSpace(name)
+	content := BuildAgentContent(frontmatter, body)
+
+	issues := ValidateAgentContent(content)
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			return nil, &AgentValidationError{Issues: issues}
+		}
+	}
+
+	dir := filepath.Join(projectPath, ".claude", "agents")
+	path := filepath.Join(dir, frontmatter.Name+".md")
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("agent %s already exists", frontmatter.Name)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+
+	return &Agent{
+		Name:   frontmatter.Name,
+		Path:   path,
+		Format: "md",
+	}, nil
+}
+
+// BuildAgentContent renders frontmatter and the system prompt body as an agent file
+func BuildAgentContent(frontmatter AgentFrontmatter, body string) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.WriteString("name: " + frontmatter.Name + "\n")
+	b.WriteString("description: " + yamlScalar(frontmatter.Description) + "\n")
+	if len(frontmatter.Tools) > 0 {
+		b.WriteString("tools: " + strings.Join(frontmatter.Tools, ", ") + "\n")
+	}
+	if frontmatter.Model != "" {
+		b.WriteString("model: " + frontmatter.Model + "\n")
+	}
+	if frontmatter.Color != "" {
+		b.WriteString("color: " + frontmatter.Color + "\n")
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimSpace(body) + "\n")
+	return b.String()
+}
+
+// parseFrontmatter reads the simple key: value frontmatter used by agents,
+// returning the fields and the line each one is on. Indented lines continue
+// the previous field (block scalars and "- item" lists).
+func parseFrontmatter(content string) (fields map[string]string, lines map[string]int, ok bool) {
+	all := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(all) == 0 || strings.TrimSpace(all[0]) != "---" {
+		return nil, nil, false
+	}
+
+	fields = make(map[string]string)
+	lines = make(map[string]int)
+	current := ""
+	for i := 1; i < len(all); i++ {
+		line := all[i]
+		if strings.TrimSpace(line) == "---" {
+			return fields, lines, true
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && current != "" {
+			item := strings.TrimSpace(line)
+			if rest, isItem := strings.CutPrefix(item, "- "); isItem {
+				item = rest
+				if fields[current] != "" {
+					fields[current] += ", "
+				}
+			} else if fields[current] != "" {
+				fields[current] += " "
+			}
+			fields[current] += unquoteYAML(item)
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		current = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if value == "|" || value == ">" || value == "|-" || value == ">-" {
+			value = ""
+		}
+		fields[current] = unquoteYAML(value)
+		lines[current] = i + 1
+	}
+
+	// No closing delimiter
+	return nil, nil, false
+}
+
+// splitAgentTools splits a comma separated tools value, keeping commas inside
+// parentheses such as Bash(git diff:*, git log:*)
+func splitAgentTools(value string) []string {
+	tools := []string{}
+	depth, start := 0, 0
+	for i, c := range value {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				if tool := strings.TrimSpace(value[start:i]); tool != "" {
+					tools = append(tools, tool)
+				}
+				start = i + 1
+			}
+		}
+	}
+	if tool := strings.TrimSpace(value[start:]); tool != "" {
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// unquoteYAML strips matching single or double quotes
+func unquoteYAML(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// yamlScalar quotes a value when it would not survive as a plain YAML scalar
+func yamlScalar(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" || strings.ContainsAny(value[:1], "\"'&*!|>%@`[]{},#?-") || strings.Contains(value, ": ") || strings.Contains(value, " #") {
+		return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `\"`) + `"`
+	}
+	return value
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
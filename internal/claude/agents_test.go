@@ -0,0 +1,70 @@
+package claude
+
+import "testing"
+
+func TestValidateAgentContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errors   []string // Fields expected with error severity
+		warnings []string // Fields expected with warning severity
+	}{
+		{
+			name: "valid agent",
+			content: "---\nname: code-reviewer\ndescription: Reviews code. Use proactively.\n" +
+				"tools: Read, Grep, Bash(git diff:*, git log:*), mcp__github__get_pr\nmodel: sonnet\ncolor: blue\n---\n\nYou review code.\n",
+		},
+		{
+			name:    "missing frontmatter",
+			content: "You review code.\n",
+			errors:  []string{"frontmatter"},
+		},
+		{
+			name:    "unterminated frontmatter",
+			content: "---\nname: reviewer\ndescription: x\n",
+			errors:  []string{"frontmatter"},
+		},
+		{
+			name:    "missing required fields",
+			content: "---\ntools: Read\n---\n",
+			errors:  []string{"name", "description"},
+		},
+		{
+			name:     "bad values",
+			content:  "---\nname: Code_Reviewer\ndescription: >\n  Multi line\n  description\ntools:\n  - Read\n  - Frobnicate\nmodel: gpt-4\ncolor: teal\nextra: 1\n---\n",
+			errors:   []string{"name", "model"},
+			warnings: []string{"tools", "color", "extra"},
+		},
+		{
+			name:    "full model id",
+			content: "---\nname: reviewer\ndescription: \"Reviews: code\"\nmodel: claude-sonnet-4-5\n---\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string][]string{}
+			for _, issue := range ValidateAgentContent(tt.content) {
+				got[issue.Severity] = append(got[issue.Severity], issue.Field)
+			}
+			if !sameFields(got["error"], tt.errors) {
+				t.Errorf("errors = %v, want %v", got["error"], tt.errors)
+			}
+			if !sameFields(got["warning"], tt.warnings) {
+				t.Errorf("warnings = %v, want %v", got["warning"], tt.warnings)
+			}
+		})
+	}
+}
+
+func sameFields(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for _, field := range want {
+		if !containsString(got, field) {
+			return false
+		}
+	}
+	return true
+}
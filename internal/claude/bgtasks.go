@@ -0,0 +1,334 @@
+package claude
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// BackgroundTask is a shell Claude started with run_in_background
+type BackgroundTask struct {
+	ID          string `json:"id"` // Shell id Claude assigned, e.g. bash_1
+	SessionID   string `json:"sessionId"`
+	Command     string `json:"command"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status"` // running, completed, failed, killed or exited (no longer running, outcome unknown)
+	ExitCode    *int   `json:"exitCode,omitempty"`
+	StartedAt   int64  `json:"startedAt"` // Unix ms
+	OutputTail  string `json:"outputTail,omitempty"`
+	PIDs        []int  `json:"pids,omitempty"` // Live processes running the command
+}
+
+const (
+	// maxBackgroundTaskSessions limits how many recent sessions are read
+	maxBackgroundTaskSessions = 10
+	// backgroundOutputTailLines is how much of the last output is kept
+	backgroundOutputTailLines = 20
+)
+
+var (
+	backgroundIDRe     = regexp.MustCompile(`running in background with ID: ([A-Za-z0-9_-]+)`)
+	backgroundStatusRe = regexp.MustCompile(`<status>(\w+)</status>`)
+	backgroundExitRe   = regexp.MustCompile(`<exit_code>(-?\d+)</exit_code>`)
+	backgroundStdoutRe = regexp.MustCompile(`(?s)<stdout>\n?(.*?)\n?</stdout>`)
+)
+
+// bgTaskLine is the subset of a transcript line used for background tasks
+type bgTaskLine struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Cwd       string `json:"cwd"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// bgTaskBlock is a tool_use or tool_result content block
+type bgTaskBlock struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Input struct {
+		Command         string `json:"command"`
+		Description     string `json:"description"`
+		RunInBackground bool   `json:"run_in_background"`
+		BashID          string `json:"bash_id"`
+		ShellID         string `json:"shell_id"`
+	} `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	IsError   bool            `json:"is_error"`
+	Content   json.RawMessage `json:"content"`
+}
+
+// GetBackgroundTasks lists the background shells Claude started in the
+// project's recent sessions, newest first. Tasks the transcript still shows
+// as running are checked against the live processes.
+func (m *ToolsManager) GetBackgroundTasks(projectPath string) ([]BackgroundTask, error) {
+	tasks := []BackgroundTask{}
+	if m.homeDir == "" {
+		return tasks, nil
+	}
+
+	projectPath = filepath.Clean(projectPath)
+	transcripts, err := m.projectTranscripts(projectPath)
+	if err != nil {
+		return tasks, err
+	}
+	if len(transcripts) > maxBackgroundTaskSessions {
+		transcripts = transcripts[:maxBackgroundTaskSessions]
+	}
+	for _, t := range transcripts {
+		cwd, sessionTasks := readBackgroundTasks(t.path)
+		if cwd != "" && !isWithin(projectPath, cwd) {
+			continue
+		}
+		tasks = append(tasks, sessionTasks...)
+	}
+
+	procs, _ := listProcesses()
+	for i := range tasks {
+		if tasks[i].Status != "running" {
+			continue
+		}
+		tasks[i].PIDs = commandProcesses(procs, tasks[i].Command)
+		if len(tasks[i].PIDs) == 0 {
+			tasks[i].Status = "exited"
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].StartedAt > tasks[j].StartedAt })
+	return tasks, nil
+}
+
+// KillBackgroundTask stops the processes of a background task
+func (m *ToolsManager) KillBackgroundTask(projectPath, sessionID, taskID string) error {
+	tasks, err := m.GetBackgroundTasks(projectPath)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if task.SessionID != sessionID || task.ID != taskID {
+			continue
+		}
+		if len(task.PIDs) == 0 {
+			return fmt.Errorf("background task %s is not running", taskID)
+		}
+		for _, pid := range task.PIDs {
+			if p, err := os.FindProcess(pid); err == nil {
+				p.Signal(syscall.SIGTERM)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("background task %s not found", taskID)
+}
+
+// readBackgroundTasks follows a transcript's background Bash calls through
+// their BashOutput and KillShell calls
+func readBackgroundTasks(path string) (string, []BackgroundTask) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil
+	}
+	defer f.Close()
+
+	sessionID := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	cwd := ""
+	var tasks []BackgroundTask
+	byID := make(map[string]int)            // Shell id -> task index
+	started := make(map[string]bgTaskBlock) // Bash tool_use id -> call
+	startedAt := make(map[string]int64)
+	polls := make(map[string]string) // BashOutput tool_use id -> shell id
+	kills := make(map[string]string) // KillShell tool_use id -> shell id
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line bgTaskLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil || (line.Type != "assistant" && line.Type != "user") {
+			continue
+		}
+		if cwd == "" && line.Cwd != "" {
+			cwd = filepath.Clean(line.Cwd)
+		}
+		var blocks []bgTaskBlock
+		if json.Unmarshal(line.Message.Content, &blocks) != nil {
+			continue
+		}
+
+		for _, b := range blocks {
+			if b.Type == "tool_use" {
+				switch b.Name {
+				case "Bash":
+					if b.Input.RunInBackground {
+						started[b.ID] = b
+						if ts, err := time.Parse(time.RFC3339, line.Timestamp); err == nil {
+							startedAt[b.ID] = ts.UnixMilli()
+						}
+					}
+				case "BashOutput":
+					polls[b.ID] = b.Input.BashID
+				case "KillShell", "KillBash":
+					kills[b.ID] = b.Input.ShellID
+				}
+				continue
+			}
+			if b.Type != "tool_result" {
+				continue
+			}
+
+			text := toolResultText(b.Content)
+			if call, ok := started[b.ToolUseID]; ok {
+				match := backgroundIDRe.FindStringSubmatch(text)
+				if b.IsError || match == nil {
+					continue
+				}
+				byID[match[1]] = len(tasks)
+				tasks = append(tasks, BackgroundTask{
+					ID:          match[1],
+					SessionID:   sessionID,
+					Command:     call.Input.Command,
+					Description: call.Input.Description,
+					Status:      "running",
+					StartedAt:   startedAt[b.ToolUseID],
+				})
+			} else if shellID, ok := polls[b.ToolUseID]; ok {
+				i, known := byID[shellID]
+				if !known || b.IsError {
+					continue
+				}
+				if match := backgroundStatusRe.FindStringSubmatch(text); match != nil {
+					tasks[i].Status = match[1]
+				}
+				if match := backgroundExitRe.FindStringSubmatch(text); match != nil {
+					code, _ := strconv.Atoi(match[1])
+					tasks[i].ExitCode = &code
+				}
+				if match := backgroundStdoutRe.FindStringSubmatch(text); match != nil && match[1] != "" {
+					tasks[i].OutputTail = tailLines(match[1], backgroundOutputTailLines)
+				}
+			} else if shellID, ok := kills[b.ToolUseID]; ok {
+				if i, known := byID[shellID]; known && !b.IsError {
+					tasks[i].Status = "killed"
+				}
+			}
+		}
+	}
+	return cwd, tasks
+}
+
+// toolResultText extracts the text of a tool result, which is either a string
+// or a list of text blocks
+func toolResultText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	json.Unmarshal(content, &blocks)
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// tailLines returns the last n lines of s
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// process is one entry of the process table
+type process struct {
+	pid  int
+	ppid int
+	args string
+}
+
+// listProcesses reads the process table with ps
+func listProcesses() ([]process, error) {
+	if runtime.GOOS == "windows" {
+		return nil, fmt.Errorf("process listing is not supported on Windows")
+	}
+	output, err := exec.Command("ps", "-axo", "pid=,ppid=,args=").Output()
+	if err != nil {
+		return nil, err
+	}
+	var procs []process
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		procs = append(procs, process{pid: pid, ppid: ppid, args: strings.Join(fields[2:], " ")})
+	}
+	return procs, nil
+}
+
+// commandProcesses finds the shells running a command (Claude wraps it in
+// `eval '<command>'`) and their descendants
+func commandProcesses(procs []process, command string) []int {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil
+	}
+	quoted := strings.ReplaceAll(command, "'", `'\''`)
+	normalize := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	wantRaw, wantQuoted := normalize(command), normalize(quoted)
+
+	self := os.Getpid()
+	matched := make(map[int]bool)
+	for _, p := range procs {
+		if p.pid == self {
+			continue
+		}
+		args := normalize(p.args)
+		if strings.Contains(args, "eval '"+wantQuoted+"'") || strings.Contains(args, "eval "+wantRaw) {
+			matched[p.pid] = true
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	// Add descendants until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, p := range procs {
+			if matched[p.ppid] && !matched[p.pid] {
+				matched[p.pid] = true
+				changed = true
+			}
+		}
+	}
+	pids := make([]int, 0, len(matched))
+	for pid := range matched {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	return pids
+}
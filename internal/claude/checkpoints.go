@@ -0,0 +1,293 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Checkpoint is the state of the files a session edited, as it was before
+// one of its prompts. Claude records these for the /rewind command.
+type Checkpoint struct {
+	ID        string           `json:"id"` // Message id of the prompt
+	SessionID string           `json:"sessionId"`
+	Prompt    string           `json:"prompt"`
+	Timestamp int64            `json:"timestamp"` // Unix ms
+	Files     []CheckpointFile `json:"files"`     // Files a restore would change
+}
+
+// CheckpointFile is a file a checkpoint restore changes
+type CheckpointFile struct {
+	Path   string `json:"path"`   // Relative to the project
+	Action string `json:"action"` // restore (saved version written back) or delete (created after the checkpoint)
+}
+
+// maxCheckpointSessions limits how many recent sessions are read for checkpoints
+const maxCheckpointSessions = 10
+
+// checkpointLine is the subset of a transcript line used for checkpoints
+type checkpointLine struct {
+	Type      string `json:"type"`
+	UUID      string `json:"uuid"`
+	Timestamp string `json:"timestamp"`
+	Cwd       string `json:"cwd"`
+	IsMeta    bool   `json:"isMeta"`
+	MessageID string `json:"messageId"` // file-history-snapshot
+	Snapshot  struct {
+		TrackedFileBackups map[string]struct {
+			BackupFileName *string `json:"backupFileName"` // nil when the file didn't exist yet
+		} `json:"trackedFileBackups"`
+	} `json:"snapshot"`
+	Message struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// sessionCheckpoints is a transcript's prompts and file snapshots, in order
+type sessionCheckpoints struct {
+	id          string
+	cwd         string
+	checkpoints []Checkpoint
+	backups     map[string]map[string]*string // Message id -> file -> backup file name
+}
+
+// GetCheckpoints lists the checkpoints of the project's recent sessions,
+// newest first, with the files restoring each one would change
+func (m *ToolsManager) GetCheckpoints(projectPath string) ([]Checkpoint, error) {
+	checkpoints := []Checkpoint{}
+	if m.homeDir == "" {
+		return checkpoints, nil
+	}
+
+	projectPath = filepath.Clean(projectPath)
+	transcripts, err := m.projectTranscripts(projectPath)
+	if err != nil {
+		return checkpoints, err
+	}
+
+	sessions := 0
+	for _, t := range transcripts {
+		if sessions >= maxCheckpointSessions {
+			break
+		}
+		session, err := readSessionCheckpoints(t.path)
+		if err != nil || len(session.checkpoints) == 0 || !isWithin(projectPath, session.cwd) {
+			continue
+		}
+		sessions++
+		for i := range session.checkpoints {
+			cp := session.checkpoints[i]
+			cp.Files = []CheckpointFile{}
+			for _, change := range m.checkpointChanges(projectPath, session, i) {
+				cp.Files = append(cp.Files, change.file)
+			}
+			checkpoints = append(checkpoints, cp)
+		}
+	}
+
+	sort.SliceStable(checkpoints, func(i, j int) bool { return checkpoints[i].Timestamp > checkpoints[j].Timestamp })
+	return checkpoints, nil
+}
+
+// RestoreCheckpoint rolls the files a session edited back to how they were
+// before the checkpoint's prompt, and returns the files it changed. The
+// conversation itself is not rewound.
+func (m *ToolsManager) RestoreCheckpoint(projectPath, sessionID, checkpointID string) ([]CheckpointFile, error) {
+	if !sessionIDPattern.MatchString(sessionID) {
+		return nil, fmt.Errorf("invalid session id: %s", sessionID)
+	}
+	projectPath = filepath.Clean(projectPath)
+	transcripts, err := m.projectTranscripts(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range transcripts {
+		if strings.TrimSuffix(filepath.Base(t.path), ".jsonl") != sessionID {
+			continue
+		}
+		session, err := readSessionCheckpoints(t.path)
+		if err != nil {
+			return nil, err
+		}
+		if !isWithin(projectPath, session.cwd) {
+			return nil, fmt.Errorf("session %s does not belong to this project", sessionID)
+		}
+		for i, cp := range session.checkpoints {
+			if cp.ID != checkpointID {
+				continue
+			}
+			restored := []CheckpointFile{}
+			for _, change := range m.checkpointChanges(projectPath, session, i) {
+				if err := change.apply(); err != nil {
+					return restored, fmt.Errorf("failed to restore %s: %w", change.file.Path, err)
+				}
+				restored = append(restored, change.file)
+			}
+			return restored, nil
+		}
+		return nil, fmt.Errorf("checkpoint %s not found", checkpointID)
+	}
+	return nil, fmt.Errorf("session %s not found", sessionID)
+}
+
+// readSessionCheckpoints reads the prompts and file snapshots of a transcript.
+// Snapshot updates for the same message add the files first edited during it.
+func readSessionCheckpoints(path string) (*sessionCheckpoints, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	session := &sessionCheckpoints{
+		id:      strings.TrimSuffix(filepath.Base(path), ".jsonl"),
+		backups: make(map[string]map[string]*string),
+	}
+	prompts := make(map[string]Checkpoint)
+	var order []string
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line checkpointLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+		if session.cwd == "" && line.Cwd != "" {
+			session.cwd = filepath.Clean(line.Cwd)
+		}
+
+		switch line.Type {
+		case "file-history-snapshot":
+			if line.MessageID == "" {
+				continue
+			}
+			files, ok := session.backups[line.MessageID]
+			if !ok {
+				files = make(map[string]*string)
+				session.backups[line.MessageID] = files
+				order = append(order, line.MessageID)
+			}
+			for file, backup := range line.Snapshot.TrackedFileBackups {
+				files[file] = backup.BackupFileName
+			}
+		case "user":
+			if line.UUID == "" || line.IsMeta {
+				continue
+			}
+			prompt := promptText(line.Message.Content)
+			if prompt == "" {
+				continue
+			}
+			cp := Checkpoint{ID: line.UUID, SessionID: session.id, Prompt: prompt}
+			if ts, err := time.Parse(time.RFC3339, line.Timestamp); err == nil {
+				cp.Timestamp = ts.UnixMilli()
+			}
+			prompts[line.UUID] = cp
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range order {
+		if cp, ok := prompts[id]; ok {
+			session.checkpoints = append(session.checkpoints, cp)
+		}
+	}
+	return session, nil
+}
+
+// checkpointChange is one file write or removal restoring a checkpoint
+type checkpointChange struct {
+	file   CheckpointFile
+	target string
+	backup string // Saved version; empty to delete
+}
+
+func (c checkpointChange) apply() error {
+	if c.backup == "" {
+		err := os.Remove(c.target)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	content, err := os.ReadFile(c.backup)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(c.target); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(c.target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.target, content, mode)
+}
+
+// checkpointChanges works out what restoring the i-th checkpoint changes. Files
+// tracked at the checkpoint go back to their saved version; files first edited
+// later go back to the version saved before that first edit.
+func (m *ToolsManager) checkpointChanges(projectPath string, session *sessionCheckpoints, i int) []checkpointChange {
+	targets := make(map[string]*string)
+	for file, backup := range session.backups[session.checkpoints[i].ID] {
+		targets[file] = backup
+	}
+	for _, later := range session.checkpoints[i+1:] {
+		for file, backup := range session.backups[later.ID] {
+			if _, ok := targets[file]; !ok {
+				targets[file] = backup
+			}
+		}
+	}
+
+	historyDir := filepath.Join(m.homeDir, ".claude", "file-history", session.id)
+	changes := []checkpointChange{}
+	for file, backup := range targets {
+		target := file
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(session.cwd, target)
+		}
+		target = filepath.Clean(target)
+		if !isWithin(projectPath, target) {
+			continue
+		}
+		rel, _ := filepath.Rel(projectPath, target)
+		current, currentErr := os.ReadFile(target)
+
+		if backup == nil {
+			if currentErr == nil {
+				changes = append(changes, checkpointChange{
+					file:   CheckpointFile{Path: filepath.ToSlash(rel), Action: "delete"},
+					target: target,
+				})
+			}
+			continue
+		}
+		if *backup == "" || strings.ContainsAny(*backup, `/\`) {
+			continue
+		}
+		backupPath := filepath.Join(historyDir, *backup)
+		saved, err := os.ReadFile(backupPath)
+		if err != nil || (currentErr == nil && bytes.Equal(saved, current)) {
+			continue
+		}
+		changes = append(changes, checkpointChange{
+			file:   CheckpointFile{Path: filepath.ToSlash(rel), Action: "restore"},
+			target: target,
+			backup: backupPath,
+		})
+	}
+
+	sort.Slice(changes, func(a, b int) bool { return changes[a].file.Path < changes[b].file.Path })
+	return changes
+}
@@ -0,0 +1,143 @@
+package claude
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ClaudemdFile is one memory file in the CLAUDE.md hierarchy Claude loads
+type ClaudemdFile struct {
+	Path    string `json:"path"`
+	RelPath string `json:"relPath"` // Display path: relative to the project, or ~/.claude/CLAUDE.md
+	Scope   string `json:"scope"`   // global, project, local or nested
+	Exists  bool   `json:"exists"`
+	Size    int64  `json:"size"`
+}
+
+// claudemdScanDepth limits how deep nested CLAUDE.md files are searched for
+const claudemdScanDepth = 6
+
+// claudemdSkipDirs are directories never descended into when looking for nested files
+var claudemdSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"venv":         true,
+	"__pycache__":  true,
+}
+
+// GetClaudemdHierarchy returns the global ~/.claude/CLAUDE.md, the project's
+// CLAUDE.md (root, .claude/ and CLAUDE.local.md) and every nested CLAUDE.md
+// below the project root, in the order Claude loads them. The global and root
+// files are always included so they can be created from the app.
+func (m *ToolsManager) GetClaudemdHierarchy(projectPath string) ([]ClaudemdFile, error) {
+	projectPath = filepath.Clean(projectPath)
+	files := []ClaudemdFile{}
+
+	if m.homeDir != "" {
+		files = append(files, claudemdFile(filepath.Join(m.homeDir, ".claude", "CLAUDE.md"), "~/.claude/CLAUDE.md", "global"))
+	}
+	files = append(files, claudemdFile(filepath.Join(projectPath, "CLAUDE.md"), "CLAUDE.md", "project"))
+	if f := claudemdFile(filepath.Join(projectPath, ".claude", "CLAUDE.md"), ".claude/CLAUDE.md", "project"); f.Exists {
+		files = append(files, f)
+	}
+	if f := claudemdFile(filepath.Join(projectPath, "CLAUDE.local.md"), "CLAUDE.local.md", "local"); f.Exists {
+		files = append(files, f)
+	}
+
+	var nested []ClaudemdFile
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && path != projectPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(projectPath, path)
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") || claudemdSkipDirs[d.Name()] ||
+				strings.Count(rel, string(filepath.Separator))+1 > claudemdScanDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Dir(rel) == "." {
+			return nil // Root files are handled above
+		}
+		switch d.Name() {
+		case "CLAUDE.md", "CLAUDE.local.md":
+			nested = append(nested, claudemdFile(path, filepath.ToSlash(rel), "nested"))
+		}
+		return nil
+	})
+	if err != nil {
+		return files, err
+	}
+
+	sort.Slice(nested, func(i, j int) bool { return nested[i].RelPath < nested[j].RelPath })
+	return append(files, nested...), nil
+}
+
+// claudemdFile stats a memory file
+func claudemdFile(path, relPath, scope string) ClaudemdFile {
+	f := ClaudemdFile{Path: path, RelPath: relPath, Scope: scope}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		f.Exists = true
+		f.Size = info.Size()
+	}
+	return f
+}
+
+// GetClaudemdFile reads a file from the project's CLAUDE.md hierarchy.
+// Missing files read as empty.
+func (m *ToolsManager) GetClaudemdFile(projectPath, path string) (string, error) {
+	if err := m.checkClaudemdPath(projectPath, path); err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// SaveClaudemdFile writes a file in the project's CLAUDE.md hierarchy
+func (m *ToolsManager) SaveClaudemdFile(projectPath, path, content string) error {
+	if err := m.checkClaudemdPath(projectPath, path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// checkClaudemdPath ensures a path is a CLAUDE.md inside the project or the global one
+func (m *ToolsManager) checkClaudemdPath(projectPath, path string) error {
+	path = filepath.Clean(path)
+	if m.homeDir != "" && path == filepath.Join(m.homeDir, ".claude", "CLAUDE.md") {
+		return nil
+	}
+	switch filepath.Base(path) {
+	case "CLAUDE.md", "CLAUDE.local.md":
+	default:
+		return fmt.Errorf("not a CLAUDE.md file: %s", path)
+	}
+	rel, err := filepath.Rel(filepath.Clean(projectPath), path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s is outside the project", path)
+	}
+	return nil
+}
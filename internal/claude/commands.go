@@ -0,0 +1,203 @@
+package claude
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CommandPreview is a slash command rendered with sample arguments, plus the
+// problems found in its frontmatter and body
+type CommandPreview struct {
+	Rendered string       `json:"rendered"`
+	Issues   []AgentIssue `json:"issues"`
+}
+
+var (
+	commandFields   = []string{"allowed-tools", "argument-hint", "description", "model", "disable-model-invocation"}
+	commandArgRe    = regexp.MustCompile(`\$(ARGUMENTS|[1-9][0-9]*)`)
+	commandBashRe   = regexp.MustCompile("!`[^`]+`")
+	commandBoolVals = []string{"true", "false"}
+)
+
+// PreviewCommand renders a command with sample arguments and validates it
+func PreviewCommand(content, arguments string) CommandPreview {
+	return CommandPreview{
+		Rendered: RenderCommand(content, arguments),
+		Issues:   ValidateCommandContent(content),
+	}
+}
+
+// RenderCommand returns the prompt Claude receives for a command: the body
+// without frontmatter, with $ARGUMENTS replaced by the whole argument string
+// and $1, $2, ... by the individual (optionally quoted) arguments
+func RenderCommand(content, arguments string) string {
+	_, body := splitFrontmatter(content)
+	arguments = strings.TrimSpace(arguments)
+	positional := splitCommandArguments(arguments)
+
+	return commandArgRe.ReplaceAllStringFunc(body, func(match string) string {
+		if match == "$ARGUMENTS" {
+			return arguments
+		}
+		var n int
+		fmt.Sscanf(match[1:], "%d", &n)
+		if n <= len(positional) {
+			return positional[n-1]
+		}
+		return ""
+	})
+}
+
+// ValidateCommandContent checks a command's optional frontmatter against the
+// fields Claude Code accepts and its body against what the frontmatter allows.
+// As with agents, unknown fields and tools are warnings.
+func ValidateCommandContent(content string) []AgentIssue {
+	issues := []AgentIssue{}
+	fields := map[string]string{}
+	lines := map[string]int{}
+	bodyStart := 1
+
+	frontmatter, body := splitFrontmatter(content)
+	if frontmatter != "" {
+		var ok bool
+		fields, lines, ok = parseFrontmatter(content)
+		if !ok {
+			return append(issues, AgentIssue{
+				Field:    "frontmatter",
+				Message:  "frontmatter block is missing its closing ---",
+				Severity: "error",
+				Line:     1,
+			})
+		}
+		bodyStart = strings.Count(frontmatter, "\n") + 1
+	}
+
+	addIssue := func(field, severity string, line int, format string, args ...interface{}) {
+		if line == 0 {
+			line = lines[field]
+		}
+		issues = append(issues, AgentIssue{
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: severity,
+			Line:     line,
+		})
+	}
+	bodyLine := func(index int) int {
+		return bodyStart + strings.Count(body[:index], "\n")
+	}
+
+	if strings.TrimSpace(body) == "" {
+		addIssue("body", "error", bodyStart, "command prompt is empty")
+	}
+
+	if strings.TrimSpace(fields["description"]) == "" {
+		addIssue("description", "warning", 0, "no description; the first line of the prompt is shown instead")
+	}
+
+	allowsBash := false
+	if tools, ok := fields["allowed-tools"]; ok {
+		for _, tool := range splitAgentTools(tools) {
+			match := agentToolRe.FindStringSubmatch(tool)
+			switch {
+			case strings.HasPrefix(tool, "mcp__"):
+			case match == nil:
+				addIssue("allowed-tools", "error", 0, "invalid tool name %q", tool)
+			case !containsString(builtinTools, match[1]):
+				addIssue("allowed-tools", "warning", 0, "unknown tool %q", tool)
+			case match[1] == "Bash":
+				allowsBash = true
+			}
+		}
+	}
+
+	if model, ok := fields["model"]; ok && model != "" {
+		if !containsString(agentModels, model) && !strings.HasPrefix(model, "claude-") {
+			addIssue("model", "error", 0, "model must be one of %s, or a claude-* model ID", strings.Join(agentModels, ", "))
+		}
+	}
+
+	if value, ok := fields["disable-model-invocation"]; ok && !containsString(commandBoolVals, value) {
+		addIssue("disable-model-invocation", "error", 0, "must be true or false")
+	}
+
+	for field := range fields {
+		if !containsString(commandFields, field) {
+			addIssue(field, "warning", 0, "unknown field %q is ignored by Claude Code", field)
+		}
+	}
+
+	if loc := commandBashRe.FindStringIndex(body); loc != nil && !allowsBash {
+		addIssue("allowed-tools", "warning", bodyLine(loc[0]), "!`command` runs bash before the prompt; allow it with allowed-tools: Bash(...)")
+	}
+
+	for _, loc := range commandArgRe.FindAllStringIndex(body, -1) {
+		if body[loc[0]:loc[1]] != "$ARGUMENTS" {
+			if strings.TrimSpace(fields["argument-hint"]) == "" {
+				addIssue("argument-hint", "warning", bodyLine(loc[0]), "positional arguments are used; add an argument-hint such as [file] [message]")
+			}
+			break
+		}
+	}
+
+	return issues
+}
+
+// splitFrontmatter separates a leading --- delimited block (delimiters
+// included) from the rest of the file. Without a closing delimiter the whole
+// file is returned as frontmatter.
+func splitFrontmatter(content string) (frontmatter, body string) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return "", content
+	}
+	offset := len("---\n")
+	for offset < len(content) {
+		end := strings.IndexByte(content[offset:], '\n')
+		line := content[offset:]
+		next := len(content)
+		if end >= 0 {
+			line = content[offset : offset+end]
+			next = offset + end + 1
+		}
+		if strings.TrimSpace(line) == "---" {
+			return content[:next], content[next:]
+		}
+		offset = next
+	}
+	return content, ""
+}
+
+// splitCommandArguments splits arguments on whitespace, keeping quoted
+// strings together
+func splitCommandArguments(arguments string) []string {
+	args := []string{}
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	for _, c := range arguments {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
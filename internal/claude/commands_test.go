@@ -0,0 +1,90 @@
+package claude
+
+import "testing"
+
+func TestRenderCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		arguments string
+		want      string
+	}{
+		{
+			name:      "all arguments",
+			content:   "---\ndescription: Fix an issue\n---\nFix issue $ARGUMENTS now.\n",
+			arguments: " 123 high ",
+			want:      "Fix issue 123 high now.\n",
+		},
+		{
+			name:      "positional arguments",
+			content:   "Review PR #$1 with priority $2 and assign to $3.",
+			arguments: `456 "very high"`,
+			want:      "Review PR #456 with priority very high and assign to .",
+		},
+		{
+			name:      "substituted values are not expanded again",
+			content:   "$1 then $2",
+			arguments: `'$2' x`,
+			want:      "$2 then x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderCommand(tt.content, tt.arguments); got != tt.want {
+				t.Errorf("RenderCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCommandContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid command",
+			content: "---\ndescription: Create a commit\nargument-hint: [message]\n" +
+				"allowed-tools: Bash(git add:*), Bash(git commit:*)\n---\n\nStatus: !`git status`\nCommit with $1.\n",
+		},
+		{
+			name:     "no frontmatter",
+			content:  "Explain $ARGUMENTS\n",
+			warnings: []string{"description"},
+		},
+		{
+			name:    "unterminated frontmatter",
+			content: "---\ndescription: x\n",
+			errors:  []string{"frontmatter"},
+		},
+		{
+			name:     "bad values",
+			content:  "---\ndescription: x\nallowed-tools: Read, Frobnicate, Bad Tool\nmodel: gpt-4\ndisable-model-invocation: yes\nextra: 1\n---\nRun !`ls` on $1\n",
+			errors:   []string{"allowed-tools", "model", "disable-model-invocation"},
+			warnings: []string{"allowed-tools", "extra", "allowed-tools", "argument-hint"},
+		},
+		{
+			name:    "empty body",
+			content: "---\ndescription: x\n---\n\n",
+			errors:  []string{"body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string][]string{}
+			for _, issue := range ValidateCommandContent(tt.content) {
+				got[issue.Severity] = append(got[issue.Severity], issue.Field)
+			}
+			if !sameFields(got["error"], tt.errors) {
+				t.Errorf("errors = %v, want %v", got["error"], tt.errors)
+			}
+			if !sameFields(got["warning"], tt.warnings) {
+				t.Errorf("warnings = %v, want %v", got["warning"], tt.warnings)
+			}
+		})
+	}
+}
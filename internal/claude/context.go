@@ -0,0 +1,98 @@
+package claude
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// ContextUsage is the estimated context window usage of a Claude session in a terminal
+type ContextUsage struct {
+	PercentLeft int  `json:"percentLeft"`          // Context left before auto-compact; -1 until seen
+	UsedTokens  int  `json:"usedTokens,omitempty"` // From /context, when shown
+	MaxTokens   int  `json:"maxTokens,omitempty"`
+	Compactions int  `json:"compactions"` // Compactions seen in this terminal
+	Low         bool `json:"low"`         // Close enough to auto-compact to warn
+}
+
+// contextLowPercent is the context left at which a session counts as low
+const contextLowPercent = 15
+
+var (
+	// Footer: "Context left until auto-compact: 12%"
+	contextLeftRe = regexp.MustCompile(`context left until auto-compact: (\d+)%`)
+	// Footer once nearly full: "Context low (3% remaining) · Run /compact to compact & continue"
+	contextLowRe = regexp.MustCompile(`context low \((\d+)% remaining\)`)
+	// /context summary: "claude-sonnet-4-5 · 58k/200k tokens (29%)"
+	contextTokensRe = regexp.MustCompile(`(\d+(?:\.\d+)?)(k|m)?/(\d+(?:\.\d+)?)(k|m)? tokens \((\d+)%\)`)
+	// Finished compaction: "Conversation compacted · ctrl+o for history"
+	contextCompactedRe = regexp.MustCompile(`conversation compacted|compacted \(ctrl\+o`)
+)
+
+// updateContext records context indicators in lowercased, ANSI-free output
+func (d *Detector) updateContext(state *TerminalState, text string) {
+	usage := state.Context
+
+	if contextCompactedRe.MatchString(text) {
+		usage.Compactions++
+		usage.PercentLeft = 100
+		usage.UsedTokens = 0
+	}
+	if match := contextTokensRe.FindStringSubmatch(text); match != nil {
+		usage.UsedTokens = parseTokenCount(match[1], match[2])
+		usage.MaxTokens = parseTokenCount(match[3], match[4])
+		used, _ := strconv.Atoi(match[5])
+		usage.PercentLeft = 100 - used
+	}
+	if match := contextLeftRe.FindStringSubmatch(text); match != nil {
+		usage.PercentLeft, _ = strconv.Atoi(match[1])
+	} else if match := contextLowRe.FindStringSubmatch(text); match != nil {
+		usage.PercentLeft, _ = strconv.Atoi(match[1])
+	}
+	usage.Low = usage.PercentLeft >= 0 && usage.PercentLeft <= contextLowPercent
+
+	if usage != state.Context {
+		state.Context = usage
+		state.contextChanged = true
+	}
+}
+
+// parseTokenCount turns "58" with suffix "k" into 58000
+func parseTokenCount(number, suffix string) int {
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0
+	}
+	switch suffix {
+	case "k":
+		n *= 1000
+	case "m":
+		n *= 1000000
+	}
+	return int(n)
+}
+
+// ConsumeContextChange returns the terminal's context usage and whether it
+// changed since the previous call
+func (d *Detector) ConsumeContextChange(termID string) (ContextUsage, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, exists := d.terminalStates[termID]
+	if !exists {
+		return ContextUsage{PercentLeft: -1}, false
+	}
+	changed := state.contextChanged
+	state.contextChanged = false
+	return state.Context, changed
+}
+
+// GetContextUsage returns the estimated context usage of a terminal
+func (d *Detector) GetContextUsage(termID string) ContextUsage {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if state, exists := d.terminalStates[termID]; exists {
+		return state.Context
+	}
+	return ContextUsage{PercentLeft: -1}
+}
@@ -16,8 +16,22 @@ const (
 	StatusWorking     Status = "working"
 	StatusIdle        Status = "idle"
 	StatusNeedsAction Status = "needs_action"
+
+	// StatusWaitingPermission is a tool approval prompt blocking Claude
+	StatusWaitingPermission Status = "waiting_permission"
+	// StatusRateLimited is a usage limit, rate limit or overloaded API error
+	StatusRateLimited Status = "rate_limited"
+	// StatusCompacting is shown while Claude compacts the conversation context
+	StatusCompacting Status = "compacting"
+	// StatusPlanMode is idle at the prompt with plan mode on
+	StatusPlanMode Status = "plan_mode"
 )
 
+// IsBlocked reports whether Claude cannot continue without the user
+func (s Status) IsBlocked() bool {
+	return s == StatusNeedsAction || s == StatusWaitingPermission || s == StatusRateLimited
+}
+
 // TerminalState tracks the Claude CLI state for a terminal
 type TerminalState struct {
 	Status         Status
@@ -26,6 +40,13 @@ type TerminalState struct {
 	HasPrompt      bool
 	HasQuestion    bool
 	ConsecutiveIdle int
+	PlanMode       bool // Footer showed "plan mode on"
+	Compacting     bool // "Compacting conversation" seen, not finished yet
+	Model          string // e.g. "Opus 4.1", from the banner, /model or status line
+	Mode           string // default, plan, accept_edits or bypass
+	sessionChanged bool   // Model or Mode changed since ConsumeSessionChange
+	Context        ContextUsage // Estimated from footer, /context and compaction output
+	contextChanged bool         // Context changed since ConsumeContextChange
 }
 
 // Detector analyzes terminal output to detect Claude CLI status
@@ -34,7 +55,13 @@ type Detector struct {
 	mu             sync.RWMutex
 
 	// Patterns for detection
-	questionPatterns []*regexp.Regexp
+	questionPatterns   []*regexp.Regexp
+	permissionPatterns []*regexp.Regexp
+	rateLimitPatterns  []*regexp.Regexp
+
+	// Latest published CLI version, cached by CheckVersion
+	latest          string
+	latestFetchedAt time.Time
 }
 
 // NewDetector creates a new Claude CLI detector
@@ -61,6 +88,22 @@ func NewDetector() *Detector {
 		regexp.MustCompile(`(?i)approve|approving`),            // Approve prompts
 	}
 
+	// Tool approval prompts ("Do you want to proceed?" with numbered choices)
+	d.permissionPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)do you want to (proceed|make this edit|create|delete|run|allow|fetch)`),
+		regexp.MustCompile(`(?i)yes, (and )?don't ask again`),
+		regexp.MustCompile(`(?i)yes, allow all edits`),
+	}
+
+	// Errors Claude waits out or cannot recover from without the user
+	d.rateLimitPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)usage limit reached`),
+		regexp.MustCompile(`(?i)\b\d+-hour limit reached`),
+		regexp.MustCompile(`(?i)API Error: (429|529)`),
+		regexp.MustCompile(`(?i)(rate_limit|overloaded)_error`),
+		regexp.MustCompile(`(?i)retrying in \d+ seconds?.*attempt \d+`),
+	}
+
 	return d
 }
 
@@ -75,6 +118,7 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 		state = &TerminalState{
 			Status:       StatusNone,
 			LastActivity: time.Now(),
+			Context:      ContextUsage{PercentLeft: -1},
 		}
 		d.terminalStates[termID] = state
 	}
@@ -82,10 +126,35 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 	oldStatus := state.Status
 	state.LastActivity = time.Now()
 
+	text := strings.ToLower(d.stripANSI(string(data)))
+	d.updateModes(state, text)
+	d.updateModel(state, text)
+	d.updateContext(state, text)
+
+	// Usage limits and API errors block Claude until the user acts or the limit resets
+	if d.matchesAny(d.rateLimitPatterns, text) {
+		state.Status = StatusRateLimited
+		state.HasPrompt = false
+		state.ConsecutiveIdle = 0
+		return state.Status, state.Status != oldStatus
+	}
+
+	// Tool approval prompts are more specific than general questions
+	if d.matchesAny(d.permissionPatterns, text) {
+		state.Status = StatusWaitingPermission
+		state.HasQuestion = true
+		state.HasPrompt = false
+		state.ConsecutiveIdle = 0
+		return state.Status, state.Status != oldStatus
+	}
+
 	// Check for braille spinner characters (Claude CLI spinner)
 	if d.hasSpinner(data) {
 		state.LastSpinner = time.Now()
 		state.Status = StatusWorking
+		if state.Compacting {
+			state.Status = StatusCompacting
+		}
 		state.HasPrompt = false
 		state.HasQuestion = false
 		state.ConsecutiveIdle = 0
@@ -111,7 +180,7 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 		// No spinner for 2+ seconds while receiving output = Claude finished
 		// Check if output looks like it could contain a prompt
 		if d.hasPrompt(data) || d.looksLikeCompletion(data) {
-			state.Status = StatusIdle
+			state.Status = d.idleStatus(state)
 			state.HasPrompt = true
 			state.HasQuestion = false
 			state.ConsecutiveIdle++
@@ -122,7 +191,7 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 	// Check for prompt character ">" at end of visible line
 	if d.hasPrompt(data) {
 		// Prompt detected - Claude is idle and ready for input
-		state.Status = StatusIdle
+		state.Status = d.idleStatus(state)
 		state.HasPrompt = true
 		state.HasQuestion = false
 		state.ConsecutiveIdle++
@@ -132,6 +201,9 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 	// If we saw a spinner recently (within 1.5 seconds), keep working status
 	if !state.LastSpinner.IsZero() && timeSinceSpinner < 1500*time.Millisecond {
 		state.Status = StatusWorking
+		if state.Compacting {
+			state.Status = StatusCompacting
+		}
 		return state.Status, state.Status != oldStatus
 	}
 
@@ -141,6 +213,52 @@ func (d *Detector) Analyze(termID string, data []byte) (Status, bool) {
 	return state.Status, state.Status != oldStatus
 }
 
+// updateModes tracks the permission mode and compaction from the CLI's footer and status lines
+func (d *Detector) updateModes(state *TerminalState, text string) {
+	mode := state.Mode
+	switch {
+	case strings.Contains(text, "plan mode on"):
+		mode = ModePlan
+	case strings.Contains(text, "accept edits on"):
+		mode = ModeAcceptEdits
+	case strings.Contains(text, "bypass permissions on"):
+		mode = ModeBypass
+	case strings.Contains(text, "? for shortcuts"):
+		mode = ModeDefault
+	}
+	if mode != state.Mode {
+		state.Mode = mode
+		state.sessionChanged = true
+	}
+	state.PlanMode = mode == ModePlan
+
+	switch {
+	case strings.Contains(text, "compacting conversation"):
+		state.Compacting = true
+	case strings.Contains(text, "compacted"):
+		state.Compacting = false
+	}
+}
+
+// idleStatus is the status shown when Claude is back at the prompt
+func (d *Detector) idleStatus(state *TerminalState) Status {
+	state.Compacting = false
+	if state.PlanMode {
+		return StatusPlanMode
+	}
+	return StatusIdle
+}
+
+// matchesAny reports whether text matches any of the patterns
+func (d *Detector) matchesAny(patterns []*regexp.Regexp, text string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
 // looksLikeCompletion checks if output looks like Claude finished (cost summary, time summary, etc.)
 func (d *Detector) looksLikeCompletion(data []byte) bool {
 	text := strings.ToLower(string(data))
@@ -305,5 +423,12 @@ func (d *Detector) ResetTerminal(termID string) {
 		state.HasQuestion = false
 		state.LastSpinner = time.Time{}
 		state.ConsecutiveIdle = 0
+		state.PlanMode = false
+		state.Compacting = false
+		state.Model = ""
+		state.Mode = ""
+		state.sessionChanged = true
+		state.Context = ContextUsage{PercentLeft: -1}
+		state.contextChanged = true
 	}
 }
@@ -0,0 +1,352 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"projecthub/internal/shell"
+)
+
+// HookInvocation is one recorded run of a hook command
+type HookInvocation struct {
+	ID         string `json:"id"`
+	Event      string `json:"event"`
+	Matcher    string `json:"matcher"`
+	Command    string `json:"command"`
+	ToolName   string `json:"toolName,omitempty"`
+	Payload    string `json:"payload"` // JSON Claude sent on stdin
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	ExitCode   int    `json:"exitCode"`
+	Running    bool   `json:"running"` // No result recorded yet
+	Blocked    bool   `json:"blocked"` // Exit code 2 or a block/deny decision
+	Reason     string `json:"reason,omitempty"`
+	StartedAt  int64  `json:"startedAt"` // Unix ms
+	DurationMs int64  `json:"durationMs"`
+}
+
+// hookLoggedSource is the only settings source whose hooks are logged: the
+// wrapped commands point at the logger in this user's home, so they must not
+// end up in the committed settings.json
+const hookLoggedSource = "local"
+
+// maxHookInvocations is how many invocations are kept per project
+const maxHookInvocations = 200
+
+// maxHookOutput caps stdout/stderr/payload returned per invocation
+const maxHookOutput = 64 * 1024
+
+// hookLoggerScript runs a hook command like Claude would and records the
+// payload, output, exit code and timing in its own directory under the log dir.
+// Output is passed through unchanged so Claude sees the same result.
+const hookLoggerScript = `#!/bin/sh
+# Written by ProjectHub: records Claude hook invocations for the hook activity log.
+# Usage: hook-logger.sh <log dir> <event> <matcher> <command>
+log_dir=$1; event=$2; matcher=$3; command=$4
+now_ms() { perl -MTime::HiRes=time -e 'printf "%d", time*1000' 2>/dev/null || echo "$(date +%s)000"; }
+dir="$log_dir/$(now_ms)-$$"
+mkdir -p "$dir"
+cat > "$dir/payload.json"
+printf '%s' "$event" > "$dir/event"
+printf '%s' "$matcher" > "$dir/matcher"
+printf '%s' "$command" > "$dir/command"
+start=$(now_ms)
+bash -c "$command" < "$dir/payload.json" > "$dir/stdout" 2> "$dir/stderr"
+code=$?
+end=$(now_ms)
+printf '%s %s %s' "$code" "$start" "$end" > "$dir/result"
+cat "$dir/stdout"
+cat "$dir/stderr" >&2
+exit $code
+`
+
+// hookLoggerPath returns where the logger script is installed
+func (m *ToolsManager) hookLoggerPath() string {
+	return filepath.Join(m.homeDir, ".projecthub", "hooks", "hook-logger.sh")
+}
+
+// hookLogDir returns the directory holding a project's hook invocations
+func (m *ToolsManager) hookLogDir(projectPath string) string {
+	return filepath.Join(m.homeDir, ".projecthub", "hook-logs", encodeProjectPath(projectPath))
+}
+
+// hookLoggerPrefix is the start of every wrapped hook command
+func (m *ToolsManager) hookLoggerPrefix() string {
+	return "sh " + shell.Quote(m.hookLoggerPath()) + " "
+}
+
+// wrapHookCommand routes a hook command through the logger script
+func (m *ToolsManager) wrapHookCommand(projectPath, event, matcher, command string) string {
+	if strings.HasPrefix(command, m.hookLoggerPrefix()) {
+		return command
+	}
+	return m.hookLoggerPrefix() + strings.Join([]string{
+		shell.Quote(m.hookLogDir(projectPath)),
+		shell.Quote(event),
+		shell.Quote(matcher),
+		shell.Quote(command),
+	}, " ")
+}
+
+// unwrapHookCommand returns the original command of a wrapped hook command
+func (m *ToolsManager) unwrapHookCommand(command string) string {
+	if m.homeDir == "" || !strings.HasPrefix(command, m.hookLoggerPrefix()) {
+		return command
+	}
+	args := shellUnquoteArgs(strings.TrimPrefix(command, m.hookLoggerPrefix()))
+	if len(args) != 4 {
+		return command
+	}
+	return args[3]
+}
+
+// IsHookLoggingEnabled reports whether the project's hooks run through the logger
+func (m *ToolsManager) IsHookLoggingEnabled(projectPath string) bool {
+	if m.homeDir == "" {
+		return false
+	}
+	for _, source := range settingsSources {
+		path, _ := projectSettingsPath(projectPath, source)
+		if m.hasLoggedHooks(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLoggedHooks reports whether a settings file has hook commands wrapped
+// with the logger
+func (m *ToolsManager) hasLoggedHooks(settingsPath string) bool {
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return false
+	}
+	var settings SettingsConfig
+	if json.Unmarshal(content, &settings) != nil {
+		return false
+	}
+	for _, configs := range settings.Hooks {
+		for _, hc := range configs {
+			for _, action := range hc.Hooks {
+				if strings.HasPrefix(action.Command, m.hookLoggerPrefix()) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// SetHookLogging wraps (or unwraps) the hook commands in the project's
+// settings.local.json so their invocations are recorded in the hook activity
+// log. The committed settings.json is left as it is, unless it still has
+// wrapped commands to unwrap.
+func (m *ToolsManager) SetHookLogging(projectPath string, enabled bool) error {
+	if m.homeDir == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	localPath, _ := projectSettingsPath(projectPath, hookLoggedSource)
+	localHooks, err := m.readHookEntries(localPath, hookLoggedSource)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		if len(localHooks) == 0 {
+			return fmt.Errorf("only hooks in .claude/settings.local.json can be recorded; settings.json is shared and left unchanged")
+		}
+		if err := os.MkdirAll(filepath.Dir(m.hookLoggerPath()), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(m.hookLoggerPath(), []byte(hookLoggerScript), 0755); err != nil {
+			return err
+		}
+	}
+
+	for _, source := range settingsSources {
+		settingsPath, _ := projectSettingsPath(projectPath, source)
+		if source != hookLoggedSource && !m.hasLoggedHooks(settingsPath) {
+			continue
+		}
+		hooks, err := m.readHookEntries(settingsPath, source)
+		if err != nil {
+			return err
+		}
+		if len(hooks) == 0 {
+			continue
+		}
+		if err := m.writeHookEntries(projectPath, settingsPath, hooks, enabled && source == hookLoggedSource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetHookLog returns the project's recorded hook invocations, newest first
+func (m *ToolsManager) GetHookLog(projectPath string, limit int) ([]HookInvocation, error) {
+	invocations := []HookInvocation{}
+	if m.homeDir == "" {
+		return invocations, nil
+	}
+	if limit <= 0 || limit > maxHookInvocations {
+		limit = maxHookInvocations
+	}
+
+	logDir := m.hookLogDir(projectPath)
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return invocations, nil
+		}
+		return invocations, err
+	}
+
+	// Directory names start with the start time in ms, so newest sorts last
+	names := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return hookLogOrder(names[i]) > hookLogOrder(names[j]) })
+
+	// Prune old invocations
+	if len(names) > maxHookInvocations {
+		for _, name := range names[maxHookInvocations:] {
+			os.RemoveAll(filepath.Join(logDir, name))
+		}
+		names = names[:maxHookInvocations]
+	}
+
+	for _, name := range names {
+		if len(invocations) >= limit {
+			break
+		}
+		invocations = append(invocations, readHookInvocation(filepath.Join(logDir, name)))
+	}
+	return invocations, nil
+}
+
+// ClearHookLog deletes the project's recorded hook invocations
+func (m *ToolsManager) ClearHookLog(projectPath string) error {
+	if m.homeDir == "" {
+		return nil
+	}
+	return os.RemoveAll(m.hookLogDir(projectPath))
+}
+
+// hookLogOrder extracts the start time from an invocation directory name
+func hookLogOrder(name string) int64 {
+	ms, _ := strconv.ParseInt(strings.SplitN(name, "-", 2)[0], 10, 64)
+	return ms
+}
+
+// readHookInvocation parses the files the logger script wrote for one invocation
+func readHookInvocation(dir string) HookInvocation {
+	read := func(name string) string {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		if len(data) > maxHookOutput {
+			data = append(data[:maxHookOutput], "\n... (truncated)"...)
+		}
+		return string(data)
+	}
+
+	inv := HookInvocation{
+		ID:        filepath.Base(dir),
+		Event:     read("event"),
+		Matcher:   read("matcher"),
+		Command:   read("command"),
+		Payload:   read("payload.json"),
+		Stdout:    read("stdout"),
+		Stderr:    read("stderr"),
+		StartedAt: hookLogOrder(filepath.Base(dir)),
+	}
+
+	var payload struct {
+		ToolName string `json:"tool_name"`
+	}
+	if json.Unmarshal([]byte(inv.Payload), &payload) == nil {
+		inv.ToolName = payload.ToolName
+	}
+
+	fields := strings.Fields(read("result"))
+	if len(fields) != 3 {
+		inv.Running = true
+		return inv
+	}
+	inv.ExitCode, _ = strconv.Atoi(fields[0])
+	start, _ := strconv.ParseInt(fields[1], 10, 64)
+	end, _ := strconv.ParseInt(fields[2], 10, 64)
+	inv.DurationMs = end - start
+
+	inv.Blocked, inv.Reason = hookDecision(inv.ExitCode, inv.Stdout, inv.Stderr)
+	return inv
+}
+
+// hookDecision reports whether a hook blocked the action and why: exit code 2
+// blocks with stderr as the reason, and JSON output can block or deny explicitly
+func hookDecision(exitCode int, stdout, stderr string) (bool, string) {
+	if exitCode == 2 {
+		return true, strings.TrimSpace(stderr)
+	}
+
+	var output struct {
+		Decision           string `json:"decision"`
+		Reason             string `json:"reason"`
+		HookSpecificOutput struct {
+			PermissionDecision       string `json:"permissionDecision"`
+			PermissionDecisionReason string `json:"permissionDecisionReason"`
+		} `json:"hookSpecificOutput"`
+	}
+	if exitCode != 0 || json.Unmarshal([]byte(strings.TrimSpace(stdout)), &output) != nil {
+		return false, ""
+	}
+	if output.Decision == "block" {
+		return true, output.Reason
+	}
+	if output.HookSpecificOutput.PermissionDecision == "deny" {
+		return true, output.HookSpecificOutput.PermissionDecisionReason
+	}
+	return false, ""
+}
+
+// shellUnquoteArgs splits a command line produced by shell.Quote-joined arguments
+func shellUnquoteArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+	inArg, quoted := false, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted:
+			if c == '\'' {
+				quoted = false
+			} else {
+				current.WriteByte(c)
+			}
+		case c == '\'':
+			quoted, inArg = true, true
+		case c == '\\' && i+1 < len(s):
+			i++
+			current.WriteByte(s[i])
+			inArg = true
+		case c == ' ':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
@@ -0,0 +1,77 @@
+package claude
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHookLoggingLeavesSharedSettingsAlone(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	m := &ToolsManager{homeDir: home}
+
+	shared := []byte(`{
+  "hooks": {
+    "PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "./lint.sh"}]}]
+  }
+}
+`)
+	sharedPath := filepath.Join(project, ".claude", "settings.json")
+	localPath := filepath.Join(project, ".claude", "settings.local.json")
+	if err := os.MkdirAll(filepath.Dir(sharedPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sharedPath, shared, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only shared hooks: nothing can be recorded
+	if err := m.SetHookLogging(project, true); err == nil {
+		t.Error("SetHookLogging enabled logging without local hooks")
+	}
+
+	local := `{"hooks": {"Stop": [{"matcher": "", "hooks": [{"type": "command", "command": "say done"}]}]}}`
+	if err := os.WriteFile(localPath, []byte(local), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetHookLogging(project, true); err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsHookLoggingEnabled(project) {
+		t.Error("logging is not enabled")
+	}
+	if content, _ := os.ReadFile(localPath); !strings.Contains(string(content), m.hookLoggerPath()) {
+		t.Errorf("local hook was not wrapped: %s", content)
+	}
+	if content, _ := os.ReadFile(sharedPath); !bytes.Equal(content, shared) {
+		t.Errorf("enabling logging changed settings.json:\n%s", content)
+	}
+
+	// Saving the hooks while logging keeps the shared ones unwrapped
+	hooks, err := m.GetProjectHooksDetailed(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SaveProjectHooksEntries(project, hooks); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(sharedPath); strings.Contains(string(content), m.hookLoggerPath()) {
+		t.Errorf("shared hook was wrapped: %s", content)
+	}
+
+	if err := os.WriteFile(sharedPath, shared, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SetHookLogging(project, false); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(sharedPath); !bytes.Equal(content, shared) {
+		t.Errorf("settings.json changed:\n%s", content)
+	}
+	if content, _ := os.ReadFile(localPath); strings.Contains(string(content), m.hookLoggerPath()) {
+		t.Errorf("local hook was not unwrapped: %s", content)
+	}
+}
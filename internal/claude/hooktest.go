@@ -0,0 +1,155 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// HookTestResult is the outcome of running one hook command with a sample payload
+type HookTestResult struct {
+	Command    string `json:"command"`
+	ExitCode   int    `json:"exitCode"`
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	DurationMs int64  `json:"durationMs"`
+	Blocked    bool   `json:"blocked"` // Claude would block the action
+	Reason     string `json:"reason,omitempty"`
+	TimedOut   bool   `json:"timedOut"`
+	Error      string `json:"error,omitempty"` // The command could not be started
+}
+
+// defaultHookTimeout matches Claude's default hook timeout
+const defaultHookTimeout = 60 * time.Second
+
+// plainToolName matches matchers naming a single tool, e.g. "Bash" or "mcp__github__list"
+var plainToolName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// SampleHookPayload synthesizes the JSON Claude would send on stdin for an event
+func SampleHookPayload(projectPath, eventType, matcher string) string {
+	payload := map[string]interface{}{
+		"session_id":      "00000000-0000-0000-0000-000000000000",
+		"transcript_path": "",
+		"cwd":             projectPath,
+		"hook_event_name": eventType,
+	}
+
+	toolName := "Bash"
+	if plainToolName.MatchString(matcher) {
+		toolName = matcher
+	}
+	toolInput := map[string]interface{}{"command": "echo hello"}
+	switch toolName {
+	case "Bash":
+	case "Write":
+		toolInput = map[string]interface{}{"file_path": projectPath + "/example.txt", "content": "hello\n"}
+	case "Edit", "MultiEdit":
+		toolInput = map[string]interface{}{"file_path": projectPath + "/example.txt", "old_string": "hello", "new_string": "world"}
+	case "Read":
+		toolInput = map[string]interface{}{"file_path": projectPath + "/example.txt"}
+	default:
+		toolInput = map[string]interface{}{}
+	}
+
+	switch eventType {
+	case "PreToolUse":
+		payload["tool_name"] = toolName
+		payload["tool_input"] = toolInput
+	case "PostToolUse":
+		payload["tool_name"] = toolName
+		payload["tool_input"] = toolInput
+		payload["tool_response"] = map[string]interface{}{"success": true}
+	case "UserPromptSubmit":
+		payload["prompt"] = "Write a function that adds two numbers"
+	case "Notification":
+		payload["message"] = "Claude needs your permission to use Bash"
+	case "Stop", "SubagentStop":
+		payload["stop_hook_active"] = false
+	case "PreCompact":
+		payload["trigger"] = "manual"
+		payload["custom_instructions"] = ""
+	case "SessionStart":
+		payload["source"] = "startup"
+	}
+
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	return string(data)
+}
+
+// TestHook runs each command of a hook entry in the project directory with the
+// payload on stdin (a synthesized one when empty), like Claude would, and
+// reports output, exit code and whether Claude would block the action
+func (m *ToolsManager) TestHook(projectPath string, hook HookEntry, samplePayload string) ([]HookTestResult, error) {
+	if strings.TrimSpace(samplePayload) == "" {
+		samplePayload = SampleHookPayload(projectPath, hook.EventType, hook.Matcher)
+	}
+	if !json.Valid([]byte(samplePayload)) {
+		return nil, fmt.Errorf("sample payload is not valid JSON")
+	}
+
+	results := []HookTestResult{}
+	for _, action := range hook.Hooks {
+		if action.Type != "command" || strings.TrimSpace(action.Command) == "" {
+			continue
+		}
+		timeout := defaultHookTimeout
+		if action.Timeout > 0 {
+			timeout = time.Duration(action.Timeout) * time.Second
+		}
+		command := m.unwrapHookCommand(action.Command)
+		results = append(results, runHookCommand(projectPath, command, samplePayload, timeout))
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("hook has no commands to run")
+	}
+	return results, nil
+}
+
+// runHookCommand runs a hook command through the login shell so PATH matches
+// the user's terminal
+func runHookCommand(projectPath, command, payload string, timeout time.Duration) HookTestResult {
+	result := HookTestResult{Command: command}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, defaultShell(), "-l", "-c", `exec bash -c "$1"`, "projecthub", command)
+	cmd.Dir = projectPath
+	cmd.Env = append(os.Environ(), "CLAUDE_PROJECT_DIR="+projectPath)
+	cmd.Stdin = strings.NewReader(payload)
+	// Don't wait on pipes held open by background children after a timeout
+	cmd.WaitDelay = 2 * time.Second
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	result.DurationMs = time.Since(start).Milliseconds()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		result.TimedOut = true
+		result.ExitCode = -1
+	case err != nil:
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.Error = err.Error()
+			result.ExitCode = -1
+		}
+	}
+
+	if !result.TimedOut && result.Error == "" {
+		result.Blocked, result.Reason = hookDecision(result.ExitCode, result.Stdout, result.Stderr)
+	}
+	return result
+}
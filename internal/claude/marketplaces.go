@@ -0,0 +1,242 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Marketplace is a plugin marketplace cloned under ~/.claude/plugins/marketplaces
+type Marketplace struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Source     string `json:"source,omitempty"` // GitHub repo, git URL or local path it was added from
+	SkillCount int    `json:"skillCount"`
+	Official   bool   `json:"official"`
+}
+
+// officialMarketplace is the marketplace Claude ships with
+const officialMarketplace = "claude-plugins-official"
+
+// marketplaceTimeout bounds adding or removing a marketplace, which clones or deletes a repo
+const marketplaceTimeout = 2 * time.Minute
+
+// marketplaceManifest is the subset of .claude-plugin/marketplace.json used to find plugins
+type marketplaceManifest struct {
+	Plugins []struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Source      json.RawMessage `json:"source"` // Relative path, or an object for remote plugins
+	} `json:"plugins"`
+}
+
+// knownMarketplace is an entry of ~/.claude/plugins/known_marketplaces.json
+type knownMarketplace struct {
+	Source struct {
+		Source string `json:"source"`
+		Repo   string `json:"repo"`
+		URL    string `json:"url"`
+		Path   string `json:"path"`
+	} `json:"source"`
+	InstallLocation string `json:"installLocation"`
+}
+
+// marketplacesDir returns where Claude clones plugin marketplaces
+func (m *ToolsManager) marketplacesDir() string {
+	return filepath.Join(m.homeDir, ".claude", "plugins", "marketplaces")
+}
+
+// GetMarketplaces lists the installed plugin marketplaces, the official one first
+func (m *ToolsManager) GetMarketplaces() ([]Marketplace, error) {
+	marketplaces := []Marketplace{}
+	if m.homeDir == "" {
+		return marketplaces, nil
+	}
+
+	entries, err := os.ReadDir(m.marketplacesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return marketplaces, nil
+		}
+		return marketplaces, err
+	}
+
+	known := m.knownMarketplaces()
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		mp := Marketplace{
+			Name:     entry.Name(),
+			Path:     filepath.Join(m.marketplacesDir(), entry.Name()),
+			Official: entry.Name() == officialMarketplace,
+		}
+		if k, ok := known[entry.Name()]; ok {
+			switch {
+			case k.Source.Repo != "":
+				mp.Source = k.Source.Repo
+			case k.Source.URL != "":
+				mp.Source = k.Source.URL
+			default:
+				mp.Source = k.Source.Path
+			}
+		}
+		mp.SkillCount = len(m.marketplaceSkills(mp.Name, mp.Path))
+		marketplaces = append(marketplaces, mp)
+	}
+
+	sort.Slice(marketplaces, func(i, j int) bool {
+		if marketplaces[i].Official != marketplaces[j].Official {
+			return marketplaces[i].Official
+		}
+		return marketplaces[i].Name < marketplaces[j].Name
+	})
+	return marketplaces, nil
+}
+
+// knownMarketplaces reads the sources Claude recorded for its marketplaces
+func (m *ToolsManager) knownMarketplaces() map[string]knownMarketplace {
+	known := make(map[string]knownMarketplace)
+	content, err := os.ReadFile(filepath.Join(m.homeDir, ".claude", "plugins", "known_marketplaces.json"))
+	if err == nil {
+		json.Unmarshal(content, &known)
+	}
+	return known
+}
+
+// marketplaceSkills returns the plugins of one marketplace. Plugins listed in
+// its marketplace.json with a local source are used; marketplaces without one
+// fall back to the plugins/ directory layout.
+func (m *ToolsManager) marketplaceSkills(name, dir string) []Skill {
+	skills := []Skill{}
+
+	var manifest marketplaceManifest
+	if content, err := os.ReadFile(filepath.Join(dir, ".claude-plugin", "marketplace.json")); err == nil &&
+		json.Unmarshal(content, &manifest) == nil {
+		for _, p := range manifest.Plugins {
+			var source string
+			if json.Unmarshal(p.Source, &source) != nil || p.Name == "" {
+				continue // Remote plugins aren't cloned with the marketplace
+			}
+			skillPath := filepath.Join(dir, filepath.FromSlash(source))
+			if !isWithin(dir, skillPath) {
+				continue
+			}
+			if info, err := os.Stat(skillPath); err != nil || !info.IsDir() {
+				continue
+			}
+			description := p.Description
+			if description == "" {
+				description = m.getSkillDescription(skillPath)
+			}
+			skills = append(skills, Skill{
+				Name:        p.Name,
+				Path:        skillPath,
+				Description: description,
+				Marketplace: name,
+			})
+		}
+		if len(skills) > 0 {
+			return skills
+		}
+	}
+
+	pluginsDir := filepath.Join(dir, "plugins")
+	entries, err := os.ReadDir(pluginsDir)
+	if err != nil {
+		return skills
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		skillPath := filepath.Join(pluginsDir, entry.Name())
+		skills = append(skills, Skill{
+			Name:        entry.Name(),
+			Path:        skillPath,
+			Description: m.getSkillDescription(skillPath),
+			Marketplace: name,
+		})
+	}
+	return skills
+}
+
+// findMarketplaceSkill returns the source directory of a skill. With no
+// marketplace given, the first marketplace providing it wins (official first).
+func (m *ToolsManager) findMarketplaceSkill(skillName, marketplace string) (string, error) {
+	marketplaces, err := m.GetMarketplaces()
+	if err != nil {
+		return "", err
+	}
+	for _, mp := range marketplaces {
+		if marketplace != "" && mp.Name != marketplace {
+			continue
+		}
+		for _, skill := range m.marketplaceSkills(mp.Name, mp.Path) {
+			if skill.Name == skillName {
+				return skill.Path, nil
+			}
+		}
+	}
+	if marketplace != "" {
+		return "", fmt.Errorf("skill %s not found in marketplace %s", skillName, marketplace)
+	}
+	return "", fmt.Errorf("skill %s not found in marketplace", skillName)
+}
+
+// AddMarketplace adds a custom marketplace (GitHub owner/repo, git URL or local
+// path) with the claude CLI, which clones it next to the official one
+func (m *ToolsManager) AddMarketplace(source string) (*Marketplace, error) {
+	source = strings.TrimSpace(source)
+	if source == "" || strings.HasPrefix(source, "-") {
+		return nil, fmt.Errorf("invalid marketplace source: %s", source)
+	}
+
+	before := make(map[string]bool)
+	if existing, err := m.GetMarketplaces(); err == nil {
+		for _, mp := range existing {
+			before[mp.Name] = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), marketplaceTimeout)
+	defer cancel()
+	output, err := claudeCommand(ctx, "plugin", "marketplace", "add", source).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add marketplace: %s", strings.TrimSpace(string(output)))
+	}
+
+	marketplaces, err := m.GetMarketplaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, mp := range marketplaces {
+		if !before[mp.Name] {
+			return &mp, nil
+		}
+	}
+	return nil, fmt.Errorf("marketplace %s was not added: %s", source, strings.TrimSpace(string(output)))
+}
+
+// RemoveMarketplace removes a custom marketplace with the claude CLI
+func (m *ToolsManager) RemoveMarketplace(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid marketplace name: %s", name)
+	}
+	if name == officialMarketplace {
+		return fmt.Errorf("the official marketplace cannot be removed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), marketplaceTimeout)
+	defer cancel()
+	output, err := claudeCommand(ctx, "plugin", "marketplace", "remove", name).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to remove marketplace: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
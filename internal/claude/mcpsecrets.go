@@ -0,0 +1,153 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"projecthub/internal/keychain"
+)
+
+// mcpSecretKeychainService is the keychain service MCP secrets are stored under
+const mcpSecretKeychainService = "projecthub-mcp"
+
+// secretPlaceholder matches ${secret:NAME} references in MCP server configs
+var secretPlaceholder = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.-]+)\}`)
+
+// secretName matches valid secret names
+var secretName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// nonSecretChars matches characters not allowed in secret names
+var nonSecretChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// mcpSecretsIndexPath lists the names of stored secrets; the keychain can't enumerate them
+func (m *ToolsManager) mcpSecretsIndexPath() string {
+	return filepath.Join(m.homeDir, ".projecthub", "mcp-secrets.json")
+}
+
+// GetMCPSecrets returns the names of the secrets stored in the keychain
+func (m *ToolsManager) GetMCPSecrets() ([]string, error) {
+	names := []string{}
+	if m.homeDir == "" {
+		return names, nil
+	}
+	content, err := os.ReadFile(m.mcpSecretsIndexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return names, nil
+		}
+		return names, err
+	}
+	if err := json.Unmarshal(content, &names); err != nil {
+		return []string{}, fmt.Errorf("invalid secrets index: %w", err)
+	}
+	return names, nil
+}
+
+// SetMCPSecret stores (or replaces) a secret in the keychain so configs can
+// reference it as ${secret:NAME}
+func (m *ToolsManager) SetMCPSecret(name, value string) error {
+	if !secretName.MatchString(name) {
+		return fmt.Errorf("invalid secret name: %s", name)
+	}
+	if value == "" {
+		return fmt.Errorf("secret value is empty")
+	}
+	if err := keychain.Set(mcpSecretKeychainService, name, value); err != nil {
+		return err
+	}
+	return m.updateMCPSecretsIndex(name, true)
+}
+
+// DeleteMCPSecret removes a secret from the keychain
+func (m *ToolsManager) DeleteMCPSecret(name string) error {
+	if !secretName.MatchString(name) {
+		return fmt.Errorf("invalid secret name: %s", name)
+	}
+	if err := keychain.Delete(mcpSecretKeychainService, name); err != nil {
+		return err
+	}
+	return m.updateMCPSecretsIndex(name, false)
+}
+
+// updateMCPSecretsIndex adds or removes a name from the secrets index
+func (m *ToolsManager) updateMCPSecretsIndex(name string, present bool) error {
+	if m.homeDir == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	names, err := m.GetMCPSecrets()
+	if err != nil {
+		return err
+	}
+	updated := []string{}
+	for _, n := range names {
+		if n != name {
+			updated = append(updated, n)
+		}
+	}
+	if present {
+		updated = append(updated, name)
+	}
+	sort.Strings(updated)
+	if err := os.MkdirAll(filepath.Dir(m.mcpSecretsIndexPath()), 0755); err != nil {
+		return err
+	}
+	return writeJSONAtomic(m.mcpSecretsIndexPath(), updated, 0600)
+}
+
+// ResolveMCPSecrets returns a copy of the server with every ${secret:NAME} in
+// its env, args and URL replaced by the stored value
+func (m *ToolsManager) ResolveMCPSecrets(server MCPServer) (MCPServer, error) {
+	var missing []string
+	resolve := func(s string) string {
+		return secretPlaceholder.ReplaceAllStringFunc(s, func(match string) string {
+			name := secretPlaceholder.FindStringSubmatch(match)[1]
+			value, err := keychain.Get(mcpSecretKeychainService, name)
+			if err != nil {
+				missing = append(missing, name)
+				return match
+			}
+			return value
+		})
+	}
+
+	resolved := server
+	resolved.URL = resolve(server.URL)
+	resolved.Args = make([]string, len(server.Args))
+	for i, arg := range server.Args {
+		resolved.Args[i] = resolve(arg)
+	}
+	resolved.Env = make(map[string]string, len(server.Env))
+	for k, v := range server.Env {
+		resolved.Env[k] = resolve(v)
+	}
+
+	if len(missing) > 0 {
+		return server, fmt.Errorf("secrets not found in keychain: %s", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// SecureMCPEnv moves the server's plaintext env values into the keychain as
+// <SERVER>_<KEY> secrets and replaces them with ${secret:NAME} placeholders.
+// Values that already reference a variable or secret are left alone.
+func (m *ToolsManager) SecureMCPEnv(server MCPServer) (MCPServer, error) {
+	secured := server
+	secured.Env = make(map[string]string, len(server.Env))
+	for k, v := range server.Env {
+		if v == "" || strings.Contains(v, "${") {
+			secured.Env[k] = v
+			continue
+		}
+		name := nonSecretChars.ReplaceAllString(strings.ToUpper(server.Name+"_"+k), "_")
+		if err := m.SetMCPSecret(name, v); err != nil {
+			return server, err
+		}
+		secured.Env[k] = "${secret:" + name + "}"
+	}
+	return secured, nil
+}
@@ -0,0 +1,388 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MCPTestResult is the outcome of an MCP handshake with a server
+type MCPTestResult struct {
+	ServerName      string        `json:"serverName"`
+	ServerVersion   string        `json:"serverVersion"`
+	ProtocolVersion string        `json:"protocolVersion"`
+	Tools           []MCPTool     `json:"tools"`
+	Resources       []MCPResource `json:"resources"`
+	DurationMs      int64         `json:"durationMs"`
+}
+
+// MCPTool is a tool advertised by an MCP server
+type MCPTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// MCPResource is a resource advertised by an MCP server
+type MCPResource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+const (
+	mcpProtocolVersion = "2025-03-26"
+	mcpTestTimeout     = 30 * time.Second // npx/uvx may download the server first
+)
+
+// jsonRPCResponse is a JSON-RPC 2.0 response
+type jsonRPCResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// mcpTransport sends a JSON-RPC request (or notification when id is 0) and returns the result
+type mcpTransport interface {
+	call(ctx context.Context, id int, method string, params interface{}) (json.RawMessage, error)
+	close()
+}
+
+// TestMCPServer starts (stdio) or connects to (http) an MCP server, performs the
+// initialize handshake and lists its tools and resources. ${secret:NAME}
+// placeholders are resolved from the keychain first.
+func (m *ToolsManager) TestMCPServer(server MCPServer) (*MCPTestResult, error) {
+	server, err := m.ResolveMCPSecrets(server)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), mcpTestTimeout)
+	defer cancel()
+	start := time.Now()
+
+	var transport mcpTransport
+	switch {
+	case server.Type == "http" || server.Type == "sse" || (server.Type == "" && server.URL != ""):
+		if server.URL == "" {
+			return nil, fmt.Errorf("server %s has no URL", server.Name)
+		}
+		transport = &httpMCPTransport{url: server.URL, client: &http.Client{}}
+	default:
+		if server.Command == "" {
+			return nil, fmt.Errorf("server %s has no command", server.Name)
+		}
+		transport, err = startStdioMCPTransport(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer transport.close()
+
+	raw, err := transport.call(ctx, 1, "initialize", map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "projecthub", "version": "1.0.0"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("initialize failed: %w", err)
+	}
+
+	var init struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		Capabilities    struct {
+			Tools     json.RawMessage `json:"tools"`
+			Resources json.RawMessage `json:"resources"`
+		} `json:"capabilities"`
+		ServerInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(raw, &init); err != nil {
+		return nil, fmt.Errorf("invalid initialize result: %w", err)
+	}
+
+	result := &MCPTestResult{
+		ServerName:      init.ServerInfo.Name,
+		ServerVersion:   init.ServerInfo.Version,
+		ProtocolVersion: init.ProtocolVersion,
+		Tools:           []MCPTool{},
+		Resources:       []MCPResource{},
+	}
+
+	if _, err := transport.call(ctx, 0, "notifications/initialized", nil); err != nil {
+		return nil, fmt.Errorf("initialized notification failed: %w", err)
+	}
+
+	if init.Capabilities.Tools != nil {
+		raw, err := transport.call(ctx, 2, "tools/list", map[string]interface{}{})
+		if err != nil {
+			return nil, fmt.Errorf("tools/list failed: %w", err)
+		}
+		var list struct {
+			Tools []MCPTool `json:"tools"`
+		}
+		if err := json.Unmarshal(raw, &list); err == nil && list.Tools != nil {
+			result.Tools = list.Tools
+		}
+	}
+
+	if init.Capabilities.Resources != nil {
+		// Resources are optional; a failing list does not fail the test
+		if raw, err := transport.call(ctx, 3, "resources/list", map[string]interface{}{}); err == nil {
+			var list struct {
+				Resources []MCPResource `json:"resources"`
+			}
+			if err := json.Unmarshal(raw, &list); err == nil && list.Resources != nil {
+				result.Resources = list.Resources
+			}
+		}
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// newJSONRPCMessage builds a request, or a notification when id is 0
+func newJSONRPCMessage(id int, method string, params interface{}) ([]byte, error) {
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method}
+	if id != 0 {
+		msg["id"] = id
+	}
+	if params != nil {
+		msg["params"] = params
+	}
+	return json.Marshal(msg)
+}
+
+// rpcResult extracts the result of a response or its error
+func rpcResult(resp *jsonRPCResponse) (json.RawMessage, error) {
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s (code %d)", resp.Error.Message, resp.Error.Code)
+	}
+	return resp.Result, nil
+}
+
+// stdioMCPTransport talks newline-delimited JSON-RPC to a server process
+type stdioMCPTransport struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan jsonRPCResponse
+	done      chan struct{}
+	stderr    *tailBuffer
+	waitOnce  sync.Once
+}
+
+// startStdioMCPTransport starts the server through the login shell so tools
+// like npx and uvx resolve as in the user's terminal
+func startStdioMCPTransport(ctx context.Context, server MCPServer) (*stdioMCPTransport, error) {
+	shell := defaultShell()
+	args := append([]string{"-l", "-c", `exec "$@"`, "projecthub", server.Command}, server.Args...)
+	cmd := exec.CommandContext(ctx, shell, args...)
+	cmd.Env = os.Environ()
+	for k, v := range server.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	t := &stdioMCPTransport{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan jsonRPCResponse, 8),
+		done:      make(chan struct{}),
+		stderr:    &tailBuffer{max: 4096},
+	}
+	cmd.Stderr = t.stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", server.Command, err)
+	}
+
+	go func() {
+		defer close(t.done)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 8*1024*1024)
+		for scanner.Scan() {
+			var resp jsonRPCResponse
+			// Servers may log to stdout or send requests/notifications; only responses matter
+			if json.Unmarshal(scanner.Bytes(), &resp) != nil || resp.ID == nil {
+				continue
+			}
+			t.responses <- resp
+		}
+	}()
+
+	return t, nil
+}
+
+func (t *stdioMCPTransport) call(ctx context.Context, id int, method string, params interface{}) (json.RawMessage, error) {
+	msg, err := newJSONRPCMessage(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.stdin.Write(append(msg, '\n')); err != nil {
+		return nil, t.exitError(fmt.Errorf("server closed its input"))
+	}
+	if id == 0 {
+		return nil, nil
+	}
+
+	for {
+		select {
+		case resp := <-t.responses:
+			if *resp.ID != id {
+				continue
+			}
+			return rpcResult(&resp)
+		case <-t.done:
+			t.wait() // Flush stderr before reporting it
+			return nil, t.exitError(fmt.Errorf("server exited before responding"))
+		case <-ctx.Done():
+			return nil, t.exitError(fmt.Errorf("timed out waiting for %s", method))
+		}
+	}
+}
+
+// exitError appends the server's stderr, which usually explains the failure
+func (t *stdioMCPTransport) exitError(err error) error {
+	if msg := strings.TrimSpace(t.stderr.String()); msg != "" {
+		return fmt.Errorf("%v: %s", err, msg)
+	}
+	return err
+}
+
+func (t *stdioMCPTransport) close() {
+	t.stdin.Close()
+	if t.cmd.Process != nil {
+		t.cmd.Process.Kill()
+	}
+	t.wait()
+}
+
+func (t *stdioMCPTransport) wait() {
+	t.waitOnce.Do(func() { t.cmd.Wait() })
+}
+
+// httpMCPTransport talks to a Streamable HTTP MCP endpoint
+type httpMCPTransport struct {
+	url       string
+	client    *http.Client
+	sessionID string
+}
+
+func (t *httpMCPTransport) call(ctx context.Context, id int, method string, params interface{}) (json.RawMessage, error) {
+	msg, err := newJSONRPCMessage(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if t.sessionID != "" {
+		req.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if sid := resp.Header.Get("Mcp-Session-Id"); sid != "" {
+		t.sessionID = sid
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("server requires authentication (HTTP %d)", resp.StatusCode)
+	}
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if id == 0 {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		// Take the first SSE data event carrying our response
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 8*1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var rpc jsonRPCResponse
+			if json.Unmarshal([]byte(strings.TrimSpace(data)), &rpc) == nil && rpc.ID != nil && *rpc.ID == id {
+				return rpcResult(&rpc)
+			}
+		}
+		return nil, fmt.Errorf("no response to %s in event stream", method)
+	}
+
+	var rpc jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpc); err != nil {
+		return nil, fmt.Errorf("invalid response to %s: %w", method, err)
+	}
+	return rpcResult(&rpc)
+}
+
+func (t *httpMCPTransport) close() {
+	if t.sessionID == "" {
+		return
+	}
+	// End the session; servers that don't support it just reject the request
+	req, err := http.NewRequest(http.MethodDelete, t.url, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Mcp-Session-Id", t.sessionID)
+	if resp, err := t.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+	max int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
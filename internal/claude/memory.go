@@ -0,0 +1,248 @@
+package claude
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MemoryFile is one of Claude's memory files for a project
+type MemoryFile struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Scope      string `json:"scope"` // project (auto-memory) or global (~/.claude/CLAUDE.md)
+	Size       int64  `json:"size"`
+	ModifiedAt int64  `json:"modifiedAt"` // Unix ms
+	Changed    bool   `json:"changed"`    // Differs from the last reviewed version
+}
+
+// MemoryChange is a memory file that changed since it was last reviewed
+type MemoryChange struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Scope  string `json:"scope"`
+	Status string `json:"status"` // added, modified or removed
+	Diff   string `json:"diff"`   // Unified diff against the reviewed version
+}
+
+// nonAlphanumeric matches the characters Claude replaces when naming project dirs
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+// encodeProjectPath names a project the way Claude does under ~/.claude/projects
+func encodeProjectPath(projectPath string) string {
+	return nonAlphanumeric.ReplaceAllString(filepath.Clean(projectPath), "-")
+}
+
+// claudeProjectDir returns Claude's per-project data directory
+func (m *ToolsManager) claudeProjectDir(projectPath string) string {
+	return filepath.Join(m.homeDir, ".claude", "projects", encodeProjectPath(projectPath))
+}
+
+// memorySnapshotDir holds the last reviewed copy of each memory file for a project
+func (m *ToolsManager) memorySnapshotDir(projectPath string) string {
+	return filepath.Join(m.homeDir, ".projecthub", "memory-snapshots", encodeProjectPath(projectPath))
+}
+
+// GetMemoryFiles returns the project's auto-memory files
+// (~/.claude/projects/<project>/memory/*.md) and the global ~/.claude/CLAUDE.md
+func (m *ToolsManager) GetMemoryFiles(projectPath string) ([]MemoryFile, error) {
+	files := []MemoryFile{}
+	if m.homeDir == "" {
+		return files, nil
+	}
+
+	memoryDir := filepath.Join(m.claudeProjectDir(projectPath), "memory")
+	entries, err := os.ReadDir(memoryDir)
+	if err != nil && !os.IsNotExist(err) {
+		return files, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".md" {
+			continue
+		}
+		if f, ok := m.memoryFile(projectPath, filepath.Join(memoryDir, entry.Name()), "project"); ok {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		// MEMORY.md is the index Claude loads first
+		if (files[i].Name == "MEMORY.md") != (files[j].Name == "MEMORY.md") {
+			return files[i].Name == "MEMORY.md"
+		}
+		return files[i].Name < files[j].Name
+	})
+
+	if f, ok := m.memoryFile(projectPath, filepath.Join(m.homeDir, ".claude", "CLAUDE.md"), "global"); ok {
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// memoryFile stats a memory file and compares it with its reviewed snapshot
+func (m *ToolsManager) memoryFile(projectPath, path, scope string) (MemoryFile, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return MemoryFile{}, false
+	}
+	f := MemoryFile{
+		Name:       filepath.Base(path),
+		Path:       path,
+		Scope:      scope,
+		Size:       info.Size(),
+		ModifiedAt: info.ModTime().UnixMilli(),
+	}
+	current, _ := os.ReadFile(path)
+	reviewed, err := os.ReadFile(m.memorySnapshotPath(projectPath, scope, f.Name))
+	f.Changed = err != nil || string(current) != string(reviewed)
+	return f, true
+}
+
+// memorySnapshotPath returns where the reviewed copy of a memory file is kept
+func (m *ToolsManager) memorySnapshotPath(projectPath, scope, name string) string {
+	return filepath.Join(m.memorySnapshotDir(projectPath), scope, name)
+}
+
+// checkMemoryPath ensures a path is one of the project's memory files
+func (m *ToolsManager) checkMemoryPath(projectPath, path string) (scope string, err error) {
+	if m.homeDir == "" {
+		return "", fmt.Errorf("cannot determine home directory")
+	}
+	path = filepath.Clean(path)
+	if path == filepath.Join(m.homeDir, ".claude", "CLAUDE.md") {
+		return "global", nil
+	}
+	if filepath.Dir(path) == filepath.Join(m.claudeProjectDir(projectPath), "memory") &&
+		strings.ToLower(filepath.Ext(path)) == ".md" {
+		return "project", nil
+	}
+	return "", fmt.Errorf("not a memory file: %s", path)
+}
+
+// GetMemoryContent reads a memory file
+func (m *ToolsManager) GetMemoryContent(projectPath, path string) (string, error) {
+	if _, err := m.checkMemoryPath(projectPath, path); err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// SaveMemoryContent writes a memory file. Edits made here count as reviewed,
+// so they don't show up as changes made by the agent.
+func (m *ToolsManager) SaveMemoryContent(projectPath, path, content string) error {
+	scope, err := m.checkMemoryPath(projectPath, path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return err
+	}
+	return m.saveMemorySnapshot(projectPath, scope, filepath.Base(path), []byte(content))
+}
+
+// GetMemoryChanges diffs every memory file against the version last marked as reviewed
+func (m *ToolsManager) GetMemoryChanges(projectPath string) ([]MemoryChange, error) {
+	changes := []MemoryChange{}
+	files, err := m.GetMemoryFiles(projectPath)
+	if err != nil {
+		return changes, err
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range files {
+		seen[f.Scope+"/"+f.Name] = true
+		if !f.Changed {
+			continue
+		}
+		snapshot := m.memorySnapshotPath(projectPath, f.Scope, f.Name)
+		status := "modified"
+		if _, err := os.Stat(snapshot); os.IsNotExist(err) {
+			status = "added"
+			snapshot = os.DevNull
+		}
+		diff, err := diffFiles(snapshot, f.Path)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, MemoryChange{Name: f.Name, Path: f.Path, Scope: f.Scope, Status: status, Diff: diff})
+	}
+
+	// Reviewed files that no longer exist were removed
+	for _, scope := range []string{"project", "global"} {
+		entries, _ := os.ReadDir(filepath.Join(m.memorySnapshotDir(projectPath), scope))
+		for _, entry := range entries {
+			if entry.IsDir() || seen[scope+"/"+entry.Name()] {
+				continue
+			}
+			diff, err := diffFiles(m.memorySnapshotPath(projectPath, scope, entry.Name()), os.DevNull)
+			if err != nil {
+				return changes, err
+			}
+			changes = append(changes, MemoryChange{Name: entry.Name(), Scope: scope, Status: "removed", Diff: diff})
+		}
+	}
+
+	return changes, nil
+}
+
+// AcknowledgeMemoryChanges marks the current memory files as reviewed
+func (m *ToolsManager) AcknowledgeMemoryChanges(projectPath string) error {
+	if m.homeDir == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	files, err := m.GetMemoryFiles(projectPath)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(m.memorySnapshotDir(projectPath)); err != nil {
+		return err
+	}
+	for _, f := range files {
+		content, err := os.ReadFile(f.Path)
+		if err != nil {
+			return err
+		}
+		if err := m.saveMemorySnapshot(projectPath, f.Scope, f.Name, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveMemorySnapshot records the reviewed content of a memory file
+func (m *ToolsManager) saveMemorySnapshot(projectPath, scope, name string, content []byte) error {
+	path := m.memorySnapshotPath(projectPath, scope, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// diffFiles returns the unified diff hunks between two files using git diff --no-index
+func diffFiles(oldPath, newPath string) (string, error) {
+	output, err := exec.Command("git", "diff", "--no-index", "--no-color", "--no-prefix", oldPath, newPath).Output()
+	if err != nil {
+		// Exit code 1 just means the files differ
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return "", fmt.Errorf("git diff failed: %w", err)
+		}
+	}
+	// Drop the file headers, which only show the temp/snapshot paths
+	diff := string(output)
+	if i := strings.Index(diff, "\n@@"); i >= 0 {
+		diff = diff[i+1:]
+	}
+	return diff, nil
+}
@@ -0,0 +1,123 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OutputStyle represents a Claude Code output style (.claude/output-styles/*.md)
+type OutputStyle struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	IsGlobal    bool   `json:"isGlobal"`
+}
+
+// GetProjectOutputStyles returns output styles from the project's .claude/output-styles/ directory
+func (m *ToolsManager) GetProjectOutputStyles(projectPath string) ([]OutputStyle, error) {
+	stylesDir := filepath.Join(projectPath, ".claude", "output-styles")
+	return m.getOutputStylesFromDir(stylesDir, false)
+}
+
+// GetGlobalOutputStyles returns output styles from ~/.claude/output-styles/
+func (m *ToolsManager) GetGlobalOutputStyles() ([]OutputStyle, error) {
+	if m.homeDir == "" {
+		return []OutputStyle{}, nil
+	}
+	stylesDir := filepath.Join(m.homeDir, ".claude", "output-styles")
+	return m.getOutputStylesFromDir(stylesDir, true)
+}
+
+// getOutputStylesFromDir reads output styles from a directory
+func (m *ToolsManager) getOutputStylesFromDir(dir string, isGlobal bool) ([]OutputStyle, error) {
+	styles := []OutputStyle{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return styles, nil
+		}
+		return styles, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.ToLower(filepath.Ext(entry.Name())) != ".md" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		description := ""
+		if content, err := os.ReadFile(path); err == nil {
+			// The frontmatter name is what /output-style shows; fall back to the file name
+			if fields, _, ok := parseFrontmatter(string(content)); ok && fields["name"] != "" {
+				name = fields["name"]
+			}
+			description = m.extractCommandDescription(string(content))
+		}
+
+		styles = append(styles, OutputStyle{
+			Name:        name,
+			Path:        path,
+			Description: description,
+			IsGlobal:    isGlobal,
+		})
+	}
+
+	return styles, nil
+}
+
+// GetOutputStyleContent reads the content of an output style file
+func (m *ToolsManager) GetOutputStyleContent(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// SaveOutputStyleContent saves content to an output style file
+func (m *ToolsManager) SaveOutputStyleContent(path, content string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// GetTemplateOutputStyles returns output styles from the template repo
+func (m *ToolsManager) GetTemplateOutputStyles(repoPath string) ([]TemplateItem, error) {
+	stylesDir := filepath.Join(repoPath, "output-styles")
+	return m.getTemplatesFromDir(stylesDir, "output-styles")
+}
+
+// InstallOutputStyle copies an output style (from the template repo or the
+// global scope) into the project, or into ~/.claude when global is set
+func (m *ToolsManager) InstallOutputStyle(projectPath, sourcePath string, global bool) error {
+	if strings.ToLower(filepath.Ext(sourcePath)) != ".md" {
+		return fmt.Errorf("output styles must be markdown files")
+	}
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	destDir := filepath.Join(projectPath, ".claude", "output-styles")
+	if global {
+		if m.homeDir == "" {
+			return fmt.Errorf("cannot determine home directory")
+		}
+		destDir = filepath.Join(m.homeDir, ".claude", "output-styles")
+	}
+	destPath := filepath.Join(destDir, filepath.Base(sourcePath))
+	if destPath == sourcePath {
+		return fmt.Errorf("output style is already installed there")
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(destPath, content, 0644)
+}
@@ -0,0 +1,404 @@
+package claude
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClaudeProfile is a named set of Claude configuration that can be applied to any project
+type ClaudeProfile struct {
+	ID          string                     `json:"id"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Agents      []ProfileFile              `json:"agents"`
+	Commands    []ProfileFile              `json:"commands"`
+	Hooks       []HookEntry                `json:"hooks"`
+	MCPServers  []MCPServer                `json:"mcpServers"`
+	Settings    map[string]json.RawMessage `json:"settings"`  // .claude/settings.json keys other than hooks
+	CreatedAt   int64                      `json:"createdAt"` // Unix ms
+}
+
+// ProfileFile is an agent or command file of a profile
+type ProfileFile struct {
+	Name    string `json:"name"` // Path relative to .claude/agents or .claude/commands
+	Content string `json:"content"`
+}
+
+// ProfileConflict is an item of a profile that differs from what the project already has
+type ProfileConflict struct {
+	Kind   string `json:"kind"` // agent, command, mcp or setting
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ProfileApplyReport lists what applying a profile did
+type ProfileApplyReport struct {
+	Installed []string          `json:"installed"`
+	Unchanged []string          `json:"unchanged"`
+	Conflicts []ProfileConflict `json:"conflicts"` // Left as they are in the project
+}
+
+// profilesPath returns where profiles are stored
+func (m *ToolsManager) profilesPath() string {
+	return filepath.Join(m.homeDir, ".projecthub", "claude-profiles.json")
+}
+
+// GetProfiles returns the stored profiles sorted by name
+func (m *ToolsManager) GetProfiles() ([]ClaudeProfile, error) {
+	profiles := []ClaudeProfile{}
+	if m.homeDir == "" {
+		return profiles, nil
+	}
+	content, err := os.ReadFile(m.profilesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return profiles, nil
+		}
+		return profiles, err
+	}
+	if err := json.Unmarshal(content, &profiles); err != nil {
+		return []ClaudeProfile{}, fmt.Errorf("invalid profiles file: %w", err)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return strings.ToLower(profiles[i].Name) < strings.ToLower(profiles[j].Name) })
+	return profiles, nil
+}
+
+// saveProfiles writes all profiles
+func (m *ToolsManager) saveProfiles(profiles []ClaudeProfile) error {
+	if m.homeDir == "" {
+		return fmt.Errorf("cannot determine home directory")
+	}
+	if err := os.MkdirAll(filepath.Dir(m.profilesPath()), 0755); err != nil {
+		return err
+	}
+	return writeJSONAtomic(m.profilesPath(), profiles, 0644)
+}
+
+// getProfile returns a stored profile by ID
+func (m *ToolsManager) getProfile(profileID string) (*ClaudeProfile, error) {
+	profiles, err := m.GetProfiles()
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles {
+		if profiles[i].ID == profileID {
+			return &profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("profile not found")
+}
+
+// CreateProfileFromProject captures a project's agents, commands, hooks, MCP
+// servers and settings as a new profile
+func (m *ToolsManager) CreateProfileFromProject(name, description, projectPath string) (*ClaudeProfile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+
+	profile := ClaudeProfile{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: description,
+		Settings:    map[string]json.RawMessage{},
+		CreatedAt:   time.Now().UnixMilli(),
+	}
+
+	var err error
+	if profile.Agents, err = readProfileFiles(filepath.Join(projectPath, ".claude", "agents")); err != nil {
+		return nil, err
+	}
+	if profile.Commands, err = readProfileFiles(filepath.Join(projectPath, ".claude", "commands")); err != nil {
+		return nil, err
+	}
+	if profile.Hooks, err = m.GetProjectHooksDetailed(projectPath); err != nil {
+		return nil, err
+	}
+	if profile.MCPServers, err = m.GetProjectMCPServers(projectPath); err != nil {
+		return nil, err
+	}
+
+	settings, err := readSettingsKeys(filepath.Join(projectPath, ".claude", "settings.json"))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range settings {
+		if key != "hooks" {
+			profile.Settings[key] = value
+		}
+	}
+
+	profiles, err := m.GetProfiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.saveProfiles(append(profiles, profile)); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// RenameProfile changes a profile's name and description
+func (m *ToolsManager) RenameProfile(profileID, name, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	profiles, err := m.GetProfiles()
+	if err != nil {
+		return err
+	}
+	for i := range profiles {
+		if profiles[i].ID == profileID {
+			profiles[i].Name = name
+			profiles[i].Description = description
+			return m.saveProfiles(profiles)
+		}
+	}
+	return fmt.Errorf("profile not found")
+}
+
+// DeleteProfile removes a stored profile
+func (m *ToolsManager) DeleteProfile(profileID string) error {
+	profiles, err := m.GetProfiles()
+	if err != nil {
+		return err
+	}
+	kept := []ClaudeProfile{}
+	for _, p := range profiles {
+		if p.ID != profileID {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(profiles) {
+		return fmt.Errorf("profile not found")
+	}
+	return m.saveProfiles(kept)
+}
+
+// ApplyProfile installs everything in a profile into the project. Items the
+// project already has with different content are left alone and reported as
+// conflicts.
+func (m *ToolsManager) ApplyProfile(projectPath, profileID string) (*ProfileApplyReport, error) {
+	profile, err := m.getProfile(profileID)
+	if err != nil {
+		return nil, err
+	}
+	report := &ProfileApplyReport{Installed: []string{}, Unchanged: []string{}, Conflicts: []ProfileConflict{}}
+
+	if err := applyProfileFiles(report, "agent", filepath.Join(projectPath, ".claude", "agents"), profile.Agents); err != nil {
+		return nil, err
+	}
+	if err := applyProfileFiles(report, "command", filepath.Join(projectPath, ".claude", "commands"), profile.Commands); err != nil {
+		return nil, err
+	}
+
+	// MCP servers
+	existingServers, err := m.GetProjectMCPServers(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	servers := make(map[string]MCPServer)
+	for _, s := range existingServers {
+		servers[s.Name] = s
+	}
+	for _, server := range profile.MCPServers {
+		existing, ok := servers[server.Name]
+		switch {
+		case !ok:
+			if err := m.AddMCPServer(projectPath, server); err != nil {
+				return nil, err
+			}
+			report.Installed = append(report.Installed, "mcp "+server.Name)
+		case sameMCPServer(existing, server):
+			report.Unchanged = append(report.Unchanged, "mcp "+server.Name)
+		default:
+			report.Conflicts = append(report.Conflicts, ProfileConflict{Kind: "mcp", Name: server.Name, Reason: "a server with this name is configured differently"})
+		}
+	}
+
+	// Settings, written before hooks since saving hooks rewrites the file
+	settingsPath := filepath.Join(projectPath, ".claude", "settings.json")
+	settings, err := readSettingsKeys(settingsPath)
+	if err != nil {
+		return nil, err
+	}
+	settingsChanged := false
+	keys := make([]string, 0, len(profile.Settings))
+	for key := range profile.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := profile.Settings[key]
+		existing, ok := settings[key]
+		switch {
+		case !ok:
+			settings[key] = value
+			settingsChanged = true
+			report.Installed = append(report.Installed, "setting "+key)
+		case sameJSON(existing, value):
+			report.Unchanged = append(report.Unchanged, "setting "+key)
+		default:
+			report.Conflicts = append(report.Conflicts, ProfileConflict{Kind: "setting", Name: key, Reason: "the project sets a different value"})
+		}
+	}
+	if settingsChanged {
+		if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+			return nil, err
+		}
+		if err := writeJSONAtomic(settingsPath, settings, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	// Hooks are added unless the same hook is already configured
+	hooks, err := m.GetProjectHooksDetailed(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	hooksChanged := false
+	for _, hook := range profile.Hooks {
+		label := "hook " + hook.EventType
+		if hook.Matcher != "" {
+			label += " (" + hook.Matcher + ")"
+		}
+		if containsHook(hooks, hook) {
+			report.Unchanged = append(report.Unchanged, label)
+			continue
+		}
+		hooks = append(hooks, hook)
+		hooksChanged = true
+		report.Installed = append(report.Installed, label)
+	}
+	if hooksChanged {
+		if err := m.saveHookEntries(projectPath, hooks, m.IsHookLoggingEnabled(projectPath)); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// readProfileFiles reads every agent/command file below a directory
+func readProfileFiles(dir string) ([]ProfileFile, error) {
+	files := []ProfileFile{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files = append(files, ProfileFile{Name: filepath.ToSlash(rel), Content: string(content)})
+		return nil
+	})
+	return files, err
+}
+
+// applyProfileFiles writes profile files that the project doesn't have yet
+func applyProfileFiles(report *ProfileApplyReport, kind, dir string, files []ProfileFile) error {
+	for _, f := range files {
+		target := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if !isWithin(dir, target) {
+			return fmt.Errorf("invalid %s file name: %s", kind, f.Name)
+		}
+		existing, err := os.ReadFile(target)
+		switch {
+		case err == nil && string(existing) == f.Content:
+			report.Unchanged = append(report.Unchanged, kind+" "+f.Name)
+		case err == nil:
+			report.Conflicts = append(report.Conflicts, ProfileConflict{Kind: kind, Name: f.Name, Reason: "the project has a different file with this name"})
+		case os.IsNotExist(err):
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, []byte(f.Content), 0644); err != nil {
+				return err
+			}
+			report.Installed = append(report.Installed, kind+" "+f.Name)
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// readSettingsKeys reads a settings file as raw top-level keys; a missing file is empty
+func readSettingsKeys(path string) (map[string]json.RawMessage, error) {
+	settings := make(map[string]json.RawMessage)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// sameJSON compares two JSON values ignoring formatting
+func sameJSON(a, b json.RawMessage) bool {
+	var ca, cb bytes.Buffer
+	if json.Compact(&ca, a) != nil || json.Compact(&cb, b) != nil {
+		return bytes.Equal(a, b)
+	}
+	return bytes.Equal(ca.Bytes(), cb.Bytes())
+}
+
+// sameMCPServer compares the launch configuration of two servers
+func sameMCPServer(a, b MCPServer) bool {
+	return a.Type == b.Type && a.Command == b.Command && a.URL == b.URL &&
+		reflect.DeepEqual(nonNilArgs(a.Args), nonNilArgs(b.Args)) &&
+		reflect.DeepEqual(nonNilEnv(a.Env), nonNilEnv(b.Env))
+}
+
+func nonNilArgs(args []string) []string {
+	if args == nil {
+		return []string{}
+	}
+	return args
+}
+
+func nonNilEnv(env map[string]string) map[string]string {
+	if env == nil {
+		return map[string]string{}
+	}
+	return env
+}
+
+// containsHook reports whether an equivalent hook is already configured
+func containsHook(hooks []HookEntry, hook HookEntry) bool {
+	for _, h := range hooks {
+		if h.EventType == hook.EventType && h.Matcher == hook.Matcher && reflect.DeepEqual(h.Hooks, hook.Hooks) {
+			return true
+		}
+	}
+	return false
+}
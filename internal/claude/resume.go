@@ -0,0 +1,220 @@
+package claude
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResumableSession is a past Claude session that can be continued with --resume
+type ResumableSession struct {
+	ID           string `json:"id"`
+	Summary      string `json:"summary,omitempty"` // Title Claude generated for the session
+	FirstPrompt  string `json:"firstPrompt"`
+	WorkDir      string `json:"workDir"`
+	GitBranch    string `json:"gitBranch,omitempty"`
+	MessageCount int    `json:"messageCount"`
+	StartedAt    int64  `json:"startedAt"` // Unix ms
+	UpdatedAt    int64  `json:"updatedAt"` // Unix ms
+}
+
+// maxResumableSessions limits how many sessions are read per project
+const maxResumableSessions = 50
+
+// maxPromptPreview limits the length of the first prompt preview
+const maxPromptPreview = 200
+
+// sessionIDPattern matches Claude session IDs (UUIDs)
+var sessionIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// sessionLine is the subset of a transcript line needed for the session list
+type sessionLine struct {
+	Type      string `json:"type"`
+	Summary   string `json:"summary"`
+	Timestamp string `json:"timestamp"`
+	Cwd       string `json:"cwd"`
+	GitBranch string `json:"gitBranch"`
+	IsMeta    bool   `json:"isMeta"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// GetResumableSessions lists the project's past sessions from the transcripts
+// in ~/.claude/projects, newest first. Sessions started in subdirectories of
+// the project are included.
+func (m *ToolsManager) GetResumableSessions(projectPath string) ([]ResumableSession, error) {
+	sessions := []ResumableSession{}
+	if m.homeDir == "" {
+		return sessions, nil
+	}
+
+	projectPath = filepath.Clean(projectPath)
+	transcripts, err := m.projectTranscripts(projectPath)
+	if err != nil {
+		return sessions, err
+	}
+
+	for _, t := range transcripts {
+		if len(sessions) >= maxResumableSessions {
+			break
+		}
+		session, ok := readResumableSession(t.path)
+		if !ok {
+			continue
+		}
+		// The encoded dir name is ambiguous; the recorded cwd is not
+		if session.WorkDir != "" && !isWithin(projectPath, session.WorkDir) {
+			continue
+		}
+		if session.WorkDir == "" {
+			session.WorkDir = projectPath
+		}
+		if session.UpdatedAt == 0 {
+			session.UpdatedAt = t.modTime.UnixMilli()
+		}
+		sessions = append(sessions, session)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].UpdatedAt > sessions[j].UpdatedAt })
+
+	return sessions, nil
+}
+
+// readResumableSession summarizes one transcript file. Transcripts without any
+// user prompt (e.g. aborted starts) are skipped.
+func readResumableSession(path string) (ResumableSession, bool) {
+	session := ResumableSession{ID: strings.TrimSuffix(filepath.Base(path), ".jsonl")}
+	if !sessionIDPattern.MatchString(session.ID) {
+		return session, false
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return session, false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line sessionLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil {
+			continue
+		}
+
+		if line.Type == "summary" {
+			session.Summary = line.Summary
+			continue
+		}
+		if line.Type != "user" && line.Type != "assistant" {
+			continue
+		}
+
+		session.MessageCount++
+		if ts, err := time.Parse(time.RFC3339, line.Timestamp); err == nil {
+			if session.StartedAt == 0 {
+				session.StartedAt = ts.UnixMilli()
+			}
+			session.UpdatedAt = ts.UnixMilli()
+		}
+		if session.WorkDir == "" && line.Cwd != "" {
+			session.WorkDir = filepath.Clean(line.Cwd)
+		}
+		if line.GitBranch != "" {
+			session.GitBranch = line.GitBranch
+		}
+		if session.FirstPrompt == "" && line.Type == "user" && !line.IsMeta {
+			session.FirstPrompt = promptText(line.Message.Content)
+		}
+	}
+
+	return session, session.FirstPrompt != ""
+}
+
+// projectTranscript is a session transcript file in ~/.claude/projects
+type projectTranscript struct {
+	path    string
+	modTime time.Time
+}
+
+// projectTranscripts returns the transcripts of sessions started in the
+// project or its subdirectories, newest first. The encoded directory names are
+// ambiguous, so callers should check the recorded cwd.
+func (m *ToolsManager) projectTranscripts(projectPath string) ([]projectTranscript, error) {
+	projectsDir := filepath.Join(m.homeDir, ".claude", "projects")
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var transcripts []projectTranscript
+	encoded := encodeProjectPath(projectPath)
+	for _, entry := range entries {
+		if !entry.IsDir() || (entry.Name() != encoded && !strings.HasPrefix(entry.Name(), encoded+"-")) {
+			continue
+		}
+		files, _ := filepath.Glob(filepath.Join(projectsDir, entry.Name(), "*.jsonl"))
+		for _, f := range files {
+			if info, err := os.Stat(f); err == nil {
+				transcripts = append(transcripts, projectTranscript{path: f, modTime: info.ModTime()})
+			}
+		}
+	}
+	sort.Slice(transcripts, func(i, j int) bool { return transcripts[i].modTime.After(transcripts[j].modTime) })
+	return transcripts, nil
+}
+
+// promptText extracts a typed prompt from message content, which is either a
+// string or a list of content blocks. Tool results and command wrappers
+// (<command-name>, <local-command-stdout>, ...) are not prompts.
+func promptText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) != nil {
+		var blocks []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(content, &blocks) != nil {
+			return ""
+		}
+		for _, b := range blocks {
+			if b.Type == "text" {
+				text = b.Text
+				break
+			}
+		}
+	}
+
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "<") {
+		return ""
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxPromptPreview {
+		text = text[:maxPromptPreview] + "..."
+	}
+	return text
+}
+
+// ResumeCommand returns the command line resuming a session
+func ResumeCommand(sessionID string) (string, error) {
+	if !sessionIDPattern.MatchString(sessionID) {
+		return "", fmt.Errorf("invalid session id: %s", sessionID)
+	}
+	return "claude --resume " + sessionID, nil
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
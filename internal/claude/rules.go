@@ -0,0 +1,156 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Rule represents a Claude Code rule file (.claude/rules/*.md)
+type Rule struct {
+	Name        string `json:"name"` // Path relative to the rules dir, without .md
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	IsGlobal    bool   `json:"isGlobal"`
+}
+
+// projectRulesDir returns the project's .claude/rules directory
+func projectRulesDir(projectPath string) string {
+	return filepath.Join(projectPath, ".claude", "rules")
+}
+
+// globalRulesDir returns ~/.claude/rules
+func (m *ToolsManager) globalRulesDir() (string, error) {
+	if m.homeDir == "" {
+		return "", fmt.Errorf("cannot determine home directory")
+	}
+	return filepath.Join(m.homeDir, ".claude", "rules"), nil
+}
+
+// GetProjectRules returns rules from the project's .claude/rules/ directory
+func (m *ToolsManager) GetProjectRules(projectPath string) ([]Rule, error) {
+	return m.getRulesFromDir(projectRulesDir(projectPath), false)
+}
+
+// GetGlobalRules returns rules from ~/.claude/rules/
+func (m *ToolsManager) GetGlobalRules() ([]Rule, error) {
+	dir, err := m.globalRulesDir()
+	if err != nil {
+		return []Rule{}, nil
+	}
+	return m.getRulesFromDir(dir, true)
+}
+
+// getRulesFromDir reads rules from a directory (supports nested directories)
+func (m *ToolsManager) getRulesFromDir(dir string, isGlobal bool) ([]Rule, error) {
+	rules := []Rule{}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return rules, nil
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors
+		}
+		if info.IsDir() || strings.ToLower(filepath.Ext(info.Name())) != ".md" {
+			return nil
+		}
+
+		relPath, _ := filepath.Rel(dir, path)
+		name := strings.TrimSuffix(filepath.ToSlash(relPath), filepath.Ext(relPath))
+
+		content, _ := os.ReadFile(path)
+		rules = append(rules, Rule{
+			Name:        name,
+			Path:        path,
+			Description: m.extractCommandDescription(string(content)),
+			IsGlobal:    isGlobal,
+		})
+		return nil
+	})
+
+	return rules, err
+}
+
+// GetRuleContent reads the content of a rule file
+func (m *ToolsManager) GetRuleContent(path string) (string, error) {
+	if err := m.checkRulePath(path); err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// SaveRuleContent saves content to an existing or new rule file
+func (m *ToolsManager) SaveRuleContent(path, content string) error {
+	if err := m.checkRulePath(path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+// CreateRule creates a new rule in the project, or in ~/.claude when global
+// is set. Nested rules use "/" in the name (e.g. "frontend/react").
+func (m *ToolsManager) CreateRule(projectPath, name, content string, global bool) (*Rule, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".md")
+	for _, part := range strings.Split(name, "/") {
+		if part == "" || part == "." || part == ".." || strings.Contains(part, `\`) {
+			return nil, fmt.Errorf("invalid rule name: %s", name)
+		}
+	}
+
+	dir := projectRulesDir(projectPath)
+	if global {
+		var err error
+		if dir, err = m.globalRulesDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(name)+".md")
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("rule %s already exists", name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+
+	return &Rule{
+		Name:        name,
+		Path:        path,
+		Description: m.extractCommandDescription(content),
+		IsGlobal:    global,
+	}, nil
+}
+
+// DeleteRule deletes a rule file
+func (m *ToolsManager) DeleteRule(path string) error {
+	if err := m.checkRulePath(path); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// checkRulePath ensures a path points at a markdown file inside a rules directory
+func (m *ToolsManager) checkRulePath(path string) error {
+	if strings.ToLower(filepath.Ext(path)) != ".md" {
+		return fmt.Errorf("rules must be markdown files")
+	}
+	for dir := filepath.Dir(filepath.Clean(path)); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if filepath.Base(dir) == "rules" && filepath.Base(filepath.Dir(dir)) == ".claude" {
+			return nil
+		}
+	}
+	return fmt.Errorf("not a rule file: %s", path)
+}
@@ -0,0 +1,436 @@
+package claude
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus is the lifecycle state of a headless Claude job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// JobRequest describes a `claude -p` job to run
+type JobRequest struct {
+	ProjectID    string   `json:"projectId"`
+	Prompt       string   `json:"prompt"`
+	WorkDir      string   `json:"workDir"`
+	Model        string   `json:"model"`        // "" = CLI default
+	AllowedTools []string `json:"allowedTools"` // e.g. "Read", "Bash(git:*)"
+}
+
+// Job is a queued, running or finished headless Claude job
+type Job struct {
+	JobRequest
+	ID         string    `json:"id"`
+	Status     JobStatus `json:"status"`
+	Output     string    `json:"output"` // Assistant text streamed so far (capped)
+	Result     string    `json:"result"` // Final answer
+	Error      string    `json:"error,omitempty"`
+	SessionID  string    `json:"sessionId,omitempty"`
+	CostUSD    float64   `json:"costUsd"`
+	NumTurns   int       `json:"numTurns"`
+	CreatedAt  time.Time `json:"createdAt"`
+	StartedAt  time.Time `json:"startedAt,omitempty"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// JobEvent is emitted for job status changes ("status") and streamed text ("output")
+type JobEvent struct {
+	Type string `json:"type"` // status or output
+	Job  Job    `json:"job"`
+	Text string `json:"text,omitempty"` // New output for "output" events
+}
+
+const (
+	maxJobOutput    = 256 * 1024 // Output kept per job
+	maxFinishedJobs = 50         // Finished jobs kept in history
+)
+
+// Runner executes `claude -p` jobs as background processes, at most
+// maxConcurrent at a time, queueing the rest
+type Runner struct {
+	mu            sync.Mutex
+	jobs          map[string]*Job
+	queue         []string
+	cancels       map[string]context.CancelFunc
+	running       int
+	maxConcurrent int
+	onEvent       func(JobEvent)
+}
+
+// NewRunner creates a job runner
+func NewRunner(maxConcurrent int) *Runner {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 2
+	}
+	return &Runner{
+		jobs:          make(map[string]*Job),
+		cancels:       make(map[string]context.CancelFunc),
+		maxConcurrent: maxConcurrent,
+	}
+}
+
+// SetEventHandler sets the callback for job status and output events
+func (r *Runner) SetEventHandler(handler func(JobEvent)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onEvent = handler
+}
+
+// Submit queues a job and starts it when a slot is free
+func (r *Runner) Submit(req JobRequest) (*Job, error) {
+	req.Prompt = strings.TrimSpace(req.Prompt)
+	if req.Prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+	if req.WorkDir == "" {
+		return nil, fmt.Errorf("working directory is required")
+	}
+	if info, err := os.Stat(req.WorkDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("working directory not found: %s", req.WorkDir)
+	}
+	if req.AllowedTools == nil {
+		req.AllowedTools = []string{}
+	}
+
+	job := &Job{
+		JobRequest: req,
+		ID:         uuid.New().String(),
+		Status:     JobQueued,
+		CreatedAt:  time.Now(),
+	}
+
+	r.mu.Lock()
+	r.jobs[job.ID] = job
+	r.queue = append(r.queue, job.ID)
+	r.pruneLocked()
+	snapshot := *job
+	r.mu.Unlock()
+
+	r.emit(JobEvent{Type: "status", Job: snapshot})
+	r.schedule()
+	return &snapshot, nil
+}
+
+// Cancel stops a running job or removes a queued one
+func (r *Runner) Cancel(id string) error {
+	r.mu.Lock()
+	job, ok := r.jobs[id]
+	if !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("job not found")
+	}
+
+	switch job.Status {
+	case JobQueued:
+		for i, queued := range r.queue {
+			if queued == id {
+				r.queue = append(r.queue[:i], r.queue[i+1:]...)
+				break
+			}
+		}
+		job.Status = JobCanceled
+		job.FinishedAt = time.Now()
+		snapshot := *job
+		r.mu.Unlock()
+		r.emit(JobEvent{Type: "status", Job: snapshot})
+		return nil
+	case JobRunning:
+		if cancel, ok := r.cancels[id]; ok {
+			cancel()
+		}
+		r.mu.Unlock()
+		return nil
+	}
+	r.mu.Unlock()
+	return fmt.Errorf("job already finished")
+}
+
+// Jobs returns all jobs, newest first, optionally for one project only
+func (r *Runner) Jobs(projectID string) []Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := []Job{}
+	for _, job := range r.jobs {
+		if projectID == "" || job.ProjectID == projectID {
+			jobs = append(jobs, *job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs
+}
+
+// Job returns a single job
+func (r *Runner) Job(id string) (*Job, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
+}
+
+// ClearFinished removes finished jobs from the history
+func (r *Runner) ClearFinished() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, job := range r.jobs {
+		if isFinished(job.Status) {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// StopAll cancels queued and running jobs
+func (r *Runner) StopAll() {
+	r.mu.Lock()
+	r.queue = nil
+	for _, cancel := range r.cancels {
+		cancel()
+	}
+	r.mu.Unlock()
+}
+
+// schedule starts queued jobs while slots are free
+func (r *Runner) schedule() {
+	for {
+		r.mu.Lock()
+		if r.running >= r.maxConcurrent || len(r.queue) == 0 {
+			r.mu.Unlock()
+			return
+		}
+		id := r.queue[0]
+		r.queue = r.queue[1:]
+		job := r.jobs[id]
+		ctx, cancel := context.WithCancel(context.Background())
+		r.cancels[id] = cancel
+		r.running++
+		job.Status = JobRunning
+		job.StartedAt = time.Now()
+		snapshot := *job
+		r.mu.Unlock()
+
+		r.emit(JobEvent{Type: "status", Job: snapshot})
+		go r.run(ctx, id, snapshot.JobRequest)
+	}
+}
+
+// run executes one job and records its result
+func (r *Runner) run(ctx context.Context, id string, req JobRequest) {
+	err := r.execute(ctx, id, req)
+
+	r.mu.Lock()
+	job := r.jobs[id]
+	delete(r.cancels, id)
+	r.running--
+	job.FinishedAt = time.Now()
+	switch {
+	case ctx.Err() != nil:
+		job.Status = JobCanceled
+	case err != nil:
+		job.Status = JobFailed
+		if job.Error == "" {
+			job.Error = err.Error()
+		}
+	case job.Error != "":
+		job.Status = JobFailed
+	default:
+		job.Status = JobSucceeded
+	}
+	snapshot := *job
+	r.mu.Unlock()
+
+	r.emit(JobEvent{Type: "status", Job: snapshot})
+	r.schedule()
+}
+
+// execute runs `claude -p` with stream-json output and folds the stream into the job
+func (r *Runner) execute(ctx context.Context, id string, req JobRequest) error {
+	args := []string{"-p", req.Prompt, "--output-format", "stream-json", "--verbose"}
+	if req.Model != "" {
+		args = append(args, "--model", req.Model)
+	}
+	if len(req.AllowedTools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(req.AllowedTools, ","))
+	}
+
+	cmd := claudeCommand(ctx, args...)
+	cmd.Dir = req.WorkDir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start claude: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		r.handleStreamLine(id, scanner.Bytes())
+	}
+	if err := scanner.Err(); err != nil {
+		// Nothing reads the rest of the stream (e.g. a line over the 4 MB
+		// limit), so stop claude rather than wait on it writing to a full pipe
+		cmd.Process.Kill()
+		cmd.Wait()
+		return fmt.Errorf("failed to read claude output: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// streamMessage is the subset of `--output-format stream-json` messages used
+type streamMessage struct {
+	Type      string  `json:"type"` // system, assistant, user, result
+	Subtype   string  `json:"subtype"`
+	SessionID string  `json:"session_id"`
+	Result    string  `json:"result"`
+	IsError   bool    `json:"is_error"`
+	CostUSD   float64 `json:"total_cost_usd"`
+	NumTurns  int     `json:"num_turns"`
+	Message   struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+			Name string `json:"name"` // tool_use
+		} `json:"content"`
+	} `json:"message"`
+}
+
+// handleStreamLine applies one stream-json line to the job
+func (r *Runner) handleStreamLine(id string, line []byte) {
+	var msg streamMessage
+	text := ""
+	if err := json.Unmarshal(line, &msg); err != nil {
+		// Not JSON (older CLI or a warning); keep it as plain output
+		text = string(line) + "\n"
+	} else {
+		switch msg.Type {
+		case "assistant":
+			for _, c := range msg.Message.Content {
+				switch c.Type {
+				case "text":
+					text += c.Text + "\n"
+				case "tool_use":
+					text += fmt.Sprintf("[tool: %s]\n", c.Name)
+				}
+			}
+		case "result":
+			r.mu.Lock()
+			job := r.jobs[id]
+			job.Result = msg.Result
+			job.CostUSD = msg.CostUSD
+			job.NumTurns = msg.NumTurns
+			if msg.SessionID != "" {
+				job.SessionID = msg.SessionID
+			}
+			if msg.IsError {
+				job.Error = msg.Result
+				if job.Error == "" {
+					job.Error = msg.Subtype
+				}
+			}
+			r.mu.Unlock()
+			return
+		case "system":
+			if msg.SessionID != "" {
+				r.mu.Lock()
+				r.jobs[id].SessionID = msg.SessionID
+				r.mu.Unlock()
+			}
+			return
+		}
+	}
+	if text == "" {
+		return
+	}
+
+	r.mu.Lock()
+	job := r.jobs[id]
+	if len(job.Output)+len(text) <= maxJobOutput {
+		job.Output += text
+	}
+	snapshot := *job
+	r.mu.Unlock()
+
+	r.emit(JobEvent{Type: "output", Job: snapshot, Text: text})
+}
+
+// pruneLocked drops the oldest finished jobs beyond the history limit
+func (r *Runner) pruneLocked() {
+	finished := []*Job{}
+	for _, job := range r.jobs {
+		if isFinished(job.Status) {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].FinishedAt.Before(finished[j].FinishedAt) })
+	for _, job := range finished[:len(finished)-maxFinishedJobs] {
+		delete(r.jobs, job.ID)
+	}
+}
+
+func (r *Runner) emit(event JobEvent) {
+	r.mu.Lock()
+	handler := r.onEvent
+	r.mu.Unlock()
+	if handler != nil {
+		// Output events do not need the full output repeated
+		if event.Type == "output" {
+			event.Job.Output = ""
+		}
+		handler(event)
+	}
+}
+
+func isFinished(status JobStatus) bool {
+	return status == JobSucceeded || status == JobFailed || status == JobCanceled
+}
+
+// defaultShell returns the user's shell
+func defaultShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "/bin/zsh"
+}
+
+// claudeCommand runs the claude CLI through the user's login shell so PATH
+// matches their terminal
+func claudeCommand(ctx context.Context, args ...string) *exec.Cmd {
+	shell := defaultShell()
+	cmdArgs := append([]string{"-l", "-c", `exec claude "$@"`, "projecthub"}, args...)
+	return exec.CommandContext(ctx, shell, cmdArgs...)
+}
@@ -0,0 +1,78 @@
+package claude
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Permission modes shown in the Claude CLI footer
+const (
+	ModeDefault     = "default"
+	ModePlan        = "plan"
+	ModeAcceptEdits = "accept_edits"
+	ModeBypass      = "bypass"
+)
+
+// SessionInfo is the model and permission mode of a Claude session in a terminal
+type SessionInfo struct {
+	Model string `json:"model"` // e.g. "Opus 4.1"; "" until detected
+	Mode  string `json:"mode"`  // default, plan, accept_edits or bypass; "" until detected
+}
+
+// modelPatterns match where the CLI names the active model. Plain mentions in
+// Claude's answers are ignored; only the banner, /model and status lines count.
+var modelPatterns = []*regexp.Regexp{
+	// /model confirmation: "Set model to opus (claude-opus-4-1-20250805)"
+	regexp.MustCompile(`set model to .*?\b(opus|sonnet|haiku)\b(?:[ -](\d+(?:[.-]\d+)?))?`),
+	// Welcome banner: "Sonnet 4.5 · Claude Max" / "Opus 4.1 · API Usage Billing"
+	regexp.MustCompile(`\b(opus|sonnet|haiku) (\d+(?:\.\d+)?) · `),
+	// Status lines: "[Opus 4.1]" or "Model: Sonnet 4.5"
+	regexp.MustCompile(`\[(opus|sonnet|haiku)(?: (\d+(?:\.\d+)?))?[^\]]*\]`),
+	regexp.MustCompile(`model: (opus|sonnet|haiku)(?: (\d+(?:\.\d+)?))?`),
+}
+
+// updateModel records the model named in lowercased, ANSI-free output
+func (d *Detector) updateModel(state *TerminalState, text string) {
+	for _, pattern := range modelPatterns {
+		match := pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		model := strings.ToUpper(match[1][:1]) + match[1][1:]
+		if match[2] != "" {
+			// Model IDs use dashes: claude-opus-4-1 -> 4.1
+			model += " " + strings.ReplaceAll(match[2], "-", ".")
+		}
+		if model != state.Model {
+			state.Model = model
+			state.sessionChanged = true
+		}
+		return
+	}
+}
+
+// ConsumeSessionChange returns the terminal's session info and whether it
+// changed since the previous call
+func (d *Detector) ConsumeSessionChange(termID string) (SessionInfo, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, exists := d.terminalStates[termID]
+	if !exists {
+		return SessionInfo{}, false
+	}
+	changed := state.sessionChanged
+	state.sessionChanged = false
+	return SessionInfo{Model: state.Model, Mode: state.Mode}, changed
+}
+
+// GetSessionInfo returns the detected model and mode of a terminal
+func (d *Detector) GetSessionInfo(termID string) SessionInfo {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if state, exists := d.terminalStates[termID]; exists {
+		return SessionInfo{Model: state.Model, Mode: state.Mode}
+	}
+	return SessionInfo{}
+}
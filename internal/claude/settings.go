@@ -0,0 +1,138 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Project settings sources: the shared .claude/settings.json and the personal,
+// git-ignored .claude/settings.local.json, which takes precedence
+var settingsSources = []string{"project", "local"}
+
+// permissionKinds are the rule lists of the permissions setting
+var permissionKinds = []string{"allow", "ask", "deny"}
+
+// PermissionRule is one rule of a project's permissions setting
+type PermissionRule struct {
+	Rule   string `json:"rule"`   // e.g. Bash(npm run test:*) or Read(./.env)
+	Kind   string `json:"kind"`   // allow, ask or deny
+	Source string `json:"source"` // project (settings.json) or local (settings.local.json)
+}
+
+// projectSettingsPath returns the settings file of a project settings source
+func projectSettingsPath(projectPath, source string) (string, error) {
+	switch source {
+	case "project", "":
+		return filepath.Join(projectPath, ".claude", "settings.json"), nil
+	case "local":
+		return filepath.Join(projectPath, ".claude", "settings.local.json"), nil
+	}
+	return "", fmt.Errorf("invalid settings source: %s", source)
+}
+
+// GetProjectPermissions returns the permission rules of both project settings
+// files, each tagged with the file it comes from
+func (m *ToolsManager) GetProjectPermissions(projectPath string) ([]PermissionRule, error) {
+	rules := []PermissionRule{}
+	for _, source := range settingsSources {
+		path, _ := projectSettingsPath(projectPath, source)
+		permissions, err := readPermissions(path)
+		if err != nil {
+			return rules, err
+		}
+		for _, kind := range permissionKinds {
+			var list []string
+			json.Unmarshal(permissions[kind], &list)
+			for _, rule := range list {
+				rules = append(rules, PermissionRule{Rule: rule, Kind: kind, Source: source})
+			}
+		}
+	}
+	return rules, nil
+}
+
+// AddPermissionRule adds a rule to the settings file of its source
+func (m *ToolsManager) AddPermissionRule(projectPath string, rule PermissionRule) error {
+	rule.Rule = strings.TrimSpace(rule.Rule)
+	if rule.Rule == "" {
+		return fmt.Errorf("permission rule is empty")
+	}
+	return updatePermissionRules(projectPath, rule, func(list []string) []string {
+		if containsString(list, rule.Rule) {
+			return list
+		}
+		return append(list, rule.Rule)
+	})
+}
+
+// RemovePermissionRule removes a rule from the settings file of its source
+func (m *ToolsManager) RemovePermissionRule(projectPath string, rule PermissionRule) error {
+	return updatePermissionRules(projectPath, rule, func(list []string) []string {
+		kept := []string{}
+		for _, r := range list {
+			if r != rule.Rule {
+				kept = append(kept, r)
+			}
+		}
+		return kept
+	})
+}
+
+// updatePermissionRules rewrites one rule list, keeping the other settings
+// and permission keys (defaultMode, additionalDirectories, ...)
+func updatePermissionRules(projectPath string, rule PermissionRule, update func([]string) []string) error {
+	if !containsString(permissionKinds, rule.Kind) {
+		return fmt.Errorf("invalid permission kind: %s", rule.Kind)
+	}
+	path, err := projectSettingsPath(projectPath, rule.Source)
+	if err != nil {
+		return err
+	}
+	settings, err := readSettingsKeys(path)
+	if err != nil {
+		return err
+	}
+	permissions, err := readPermissions(path)
+	if err != nil {
+		return err
+	}
+
+	var list []string
+	json.Unmarshal(permissions[rule.Kind], &list)
+	list = update(list)
+	if len(list) == 0 {
+		delete(permissions, rule.Kind)
+	} else {
+		raw, _ := json.Marshal(list)
+		permissions[rule.Kind] = raw
+	}
+	if len(permissions) == 0 {
+		delete(settings, "permissions")
+	} else {
+		raw, _ := json.Marshal(permissions)
+		settings["permissions"] = raw
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeJSONAtomic(path, settings, 0644)
+}
+
+// readPermissions returns the keys of a settings file's permissions object
+func readPermissions(path string) (map[string]json.RawMessage, error) {
+	settings, err := readSettingsKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(map[string]json.RawMessage)
+	if raw, ok := settings["permissions"]; ok {
+		if err := json.Unmarshal(raw, &permissions); err != nil {
+			return nil, fmt.Errorf("invalid permissions in %s: %w", path, err)
+		}
+	}
+	return permissions, nil
+}
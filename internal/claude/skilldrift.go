@@ -0,0 +1,273 @@
+package claude
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// InstalledSkill is a project skill compared against the source it was installed from
+type InstalledSkill struct {
+	Name            string `json:"name"`
+	Source          string `json:"source"` // marketplace or template; empty when no source is found
+	Marketplace     string `json:"marketplace,omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable"` // The source changed since the skill was installed
+	LocallyModified bool   `json:"locallyModified"` // The project copy was edited since it was installed
+}
+
+// SkillFileDiff is one file an upgrade would change
+type SkillFileDiff struct {
+	Path   string `json:"path"`
+	Status string `json:"status"` // added, modified or removed
+	Diff   string `json:"diff"`   // Unified diff from the project copy to the source
+}
+
+// skillBaseline records what was installed for a skill, to tell source updates from local edits
+type skillBaseline struct {
+	Source      string `json:"source"`
+	Marketplace string `json:"marketplace,omitempty"`
+	Hash        string `json:"hash"`
+}
+
+// skillSource is where an installed skill can be updated from
+type skillSource struct {
+	Kind        string // marketplace or template
+	Marketplace string
+	Whole       bool              // The source is a directory that replaces the project copy
+	Files       map[string]string // Relative path -> source file
+}
+
+// skillBaselinesPath returns where a project's skill baselines are kept
+func (m *ToolsManager) skillBaselinesPath(projectPath string) string {
+	return filepath.Join(m.homeDir, ".projecthub", "skill-baselines", encodeProjectPath(projectPath)+".json")
+}
+
+// loadSkillBaselines reads a project's skill baselines
+func (m *ToolsManager) loadSkillBaselines(projectPath string) map[string]skillBaseline {
+	baselines := make(map[string]skillBaseline)
+	if m.homeDir == "" {
+		return baselines
+	}
+	if content, err := os.ReadFile(m.skillBaselinesPath(projectPath)); err == nil {
+		json.Unmarshal(content, &baselines)
+	}
+	return baselines
+}
+
+// saveSkillBaseline records the source content just installed for a skill
+func (m *ToolsManager) saveSkillBaseline(projectPath, skillName string, src *skillSource) error {
+	if m.homeDir == "" {
+		return nil
+	}
+	hash, err := hashSkillFiles(src.Files)
+	if err != nil {
+		return err
+	}
+	baselines := m.loadSkillBaselines(projectPath)
+	baselines[skillName] = skillBaseline{Source: src.Kind, Marketplace: src.Marketplace, Hash: hash}
+	path := m.skillBaselinesPath(projectPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeJSONAtomic(path, baselines, 0644)
+}
+
+// dirSkillSource describes a skill directory as a source
+func dirSkillSource(kind, marketplace, dir string) (*skillSource, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	src := &skillSource{Kind: kind, Marketplace: marketplace, Whole: true, Files: make(map[string]string)}
+	for rel := range files {
+		src.Files[rel] = filepath.Join(dir, rel)
+	}
+	return src, nil
+}
+
+// templateSkillSource describes a template repo skill: a directory, or a
+// single file installed as SKILL.md
+func templateSkillSource(templatePath string) (*skillSource, error) {
+	info, err := os.Stat(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return dirSkillSource("template", "", templatePath)
+	}
+	return &skillSource{Kind: "template", Files: map[string]string{"SKILL.md": templatePath}}, nil
+}
+
+// findTemplateSkill returns the template repo path of a skill, if any
+func (m *ToolsManager) findTemplateSkill(skillName string) string {
+	repoPath := m.GetTemplateRepoPath()
+	if repoPath == "" {
+		return ""
+	}
+	for _, p := range []string{
+		filepath.Join(repoPath, "skills", skillName, "SKILL.md"),
+		filepath.Join(repoPath, "skills", skillName+".md"),
+	} {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+// resolveSkillSource finds where an installed skill came from: the source
+// recorded at install time, otherwise the marketplaces, then the template repo
+func (m *ToolsManager) resolveSkillSource(projectPath, skillName string) (*skillSource, error) {
+	baseline, known := m.loadSkillBaselines(projectPath)[skillName]
+
+	if !known || baseline.Source == "marketplace" {
+		if dir, err := m.findMarketplaceSkill(skillName, baseline.Marketplace); err == nil {
+			return dirSkillSource("marketplace", m.marketplaceOf(dir), dir)
+		}
+	}
+	if !known || baseline.Source == "template" {
+		if path := m.findTemplateSkill(skillName); path != "" {
+			return templateSkillSource(path)
+		}
+	}
+	return nil, fmt.Errorf("no source found for skill %s", skillName)
+}
+
+// marketplaceOf returns the name of the marketplace containing a path
+func (m *ToolsManager) marketplaceOf(path string) string {
+	rel, err := filepath.Rel(m.marketplacesDir(), path)
+	if err != nil {
+		return ""
+	}
+	return strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+}
+
+// projectSkillFiles returns the project copy's files to compare with a source
+func projectSkillFiles(dstPath string, src *skillSource) (map[string]string, error) {
+	files := make(map[string]string)
+	if !src.Whole {
+		for rel := range src.Files {
+			files[rel] = filepath.Join(dstPath, rel)
+		}
+		return files, nil
+	}
+	existing, err := listFiles(dstPath)
+	if err != nil {
+		return nil, err
+	}
+	for rel := range existing {
+		files[rel] = filepath.Join(dstPath, rel)
+	}
+	return files, nil
+}
+
+// hashSkillFiles hashes relative paths and contents; missing files hash as absent
+func hashSkillFiles(files map[string]string) (string, error) {
+	rels := make([]string, 0, len(files))
+	for rel := range files {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	h := sha256.New()
+	for _, rel := range rels {
+		content, err := os.ReadFile(files[rel])
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(rel), len(content))
+		h.Write(content)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// skillDrift compares an installed skill with its source
+func (m *ToolsManager) skillDrift(projectPath, skillName string) InstalledSkill {
+	skill := InstalledSkill{Name: skillName}
+	src, err := m.resolveSkillSource(projectPath, skillName)
+	if err != nil {
+		return skill
+	}
+	skill.Source = src.Kind
+	skill.Marketplace = src.Marketplace
+
+	local, err := projectSkillFiles(filepath.Join(projectPath, ".claude", "skills", skillName), src)
+	if err != nil {
+		return skill
+	}
+	localHash, err1 := hashSkillFiles(local)
+	sourceHash, err2 := hashSkillFiles(src.Files)
+	if err1 != nil || err2 != nil || localHash == sourceHash {
+		return skill
+	}
+
+	baseline, known := m.loadSkillBaselines(projectPath)[skillName]
+	if !known {
+		// Installed before baselines were recorded: any difference counts as an update
+		skill.UpdateAvailable = true
+		return skill
+	}
+	skill.UpdateAvailable = sourceHash != baseline.Hash
+	skill.LocallyModified = localHash != baseline.Hash
+	return skill
+}
+
+// GetSkillUpgradeDiff shows what updating an installed skill from its source
+// would change in the project copy
+func (m *ToolsManager) GetSkillUpgradeDiff(projectPath, skillName string) ([]SkillFileDiff, error) {
+	dstPath, err := projectSkillPath(projectPath, skillName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dstPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("skill %s is not installed", skillName)
+	}
+	src, err := m.resolveSkillSource(projectPath, skillName)
+	if err != nil {
+		return nil, err
+	}
+	local, err := projectSkillFiles(dstPath, src)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []SkillFileDiff{}
+	seen := make(map[string]bool)
+	for rel, srcFile := range src.Files {
+		seen[rel] = true
+		status := "modified"
+		dstFile := local[rel]
+		if _, err := os.Stat(dstFile); err != nil {
+			status = "added"
+			dstFile = os.DevNull
+		}
+		diff, err := diffFiles(dstFile, srcFile)
+		if err != nil {
+			return nil, err
+		}
+		if diff != "" {
+			diffs = append(diffs, SkillFileDiff{Path: filepath.ToSlash(rel), Status: status, Diff: diff})
+		}
+	}
+	for rel, dstFile := range local {
+		if seen[rel] {
+			continue
+		}
+		diff, err := diffFiles(dstFile, os.DevNull)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, SkillFileDiff{Path: filepath.ToSlash(rel), Status: "removed", Diff: diff})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
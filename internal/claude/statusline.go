@@ -0,0 +1,164 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// StatusLine is the statusLine setting: a command whose first line of output
+// Claude shows below the prompt
+type StatusLine struct {
+	Type    string `json:"type"` // Always "command"
+	Command string `json:"command"`
+	Padding int    `json:"padding,omitempty"` // Extra horizontal padding; 0 reaches the edge
+}
+
+// StatusLineTestResult is the outcome of running a statusline command once
+type StatusLineTestResult struct {
+	Output     string `json:"output"` // The line Claude would display
+	Stdout     string `json:"stdout"`
+	Stderr     string `json:"stderr"`
+	ExitCode   int    `json:"exitCode"`
+	DurationMs int64  `json:"durationMs"`
+	TimedOut   bool   `json:"timedOut"`
+	Problem    string `json:"problem,omitempty"` // Why the statusline would be blank, if it would
+}
+
+// statusLineTimeout bounds a statusline test run; Claude reruns the command on
+// every message, so anything slow is a problem anyway
+const statusLineTimeout = 5 * time.Second
+
+// statusLineSettingsPath returns the settings file for a scope: "user" for
+// ~/.claude/settings.json, otherwise a project settings source
+func (m *ToolsManager) statusLineSettingsPath(projectPath, scope string) (string, error) {
+	if scope == "user" {
+		if m.homeDir == "" {
+			return "", fmt.Errorf("cannot determine home directory")
+		}
+		return filepath.Join(m.homeDir, ".claude", "settings.json"), nil
+	}
+	return projectSettingsPath(projectPath, scope)
+}
+
+// GetStatusLine returns the statusLine setting of a scope, nil when unset
+func (m *ToolsManager) GetStatusLine(projectPath, scope string) (*StatusLine, error) {
+	path, err := m.statusLineSettingsPath(projectPath, scope)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := readSettingsKeys(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := settings["statusLine"]
+	if !ok {
+		return nil, nil
+	}
+	var statusLine StatusLine
+	if err := json.Unmarshal(raw, &statusLine); err != nil {
+		return nil, fmt.Errorf("invalid statusLine setting: %w", err)
+	}
+	return &statusLine, nil
+}
+
+// SetStatusLine writes the statusLine setting of a scope, keeping the other
+// settings. A nil statusLine or an empty command removes the setting.
+func (m *ToolsManager) SetStatusLine(projectPath, scope string, statusLine *StatusLine) error {
+	path, err := m.statusLineSettingsPath(projectPath, scope)
+	if err != nil {
+		return err
+	}
+	settings, err := readSettingsKeys(path)
+	if err != nil {
+		return err
+	}
+
+	if statusLine == nil || strings.TrimSpace(statusLine.Command) == "" {
+		if _, ok := settings["statusLine"]; !ok {
+			return nil
+		}
+		delete(settings, "statusLine")
+	} else {
+		if statusLine.Padding < 0 {
+			return fmt.Errorf("padding must not be negative")
+		}
+		statusLine.Type = "command"
+		statusLine.Command = strings.TrimSpace(statusLine.Command)
+		raw, err := json.Marshal(statusLine)
+		if err != nil {
+			return err
+		}
+		settings["statusLine"] = raw
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeJSONAtomic(path, settings, 0644)
+}
+
+// sampleStatusLinePayload synthesizes the session JSON Claude sends a
+// statusline command on stdin
+func sampleStatusLinePayload(projectPath string) string {
+	payload := map[string]interface{}{
+		"hook_event_name": "Status",
+		"session_id":      "00000000-0000-0000-0000-000000000000",
+		"transcript_path": "",
+		"cwd":             projectPath,
+		"model": map[string]string{
+			"id":           "claude-sonnet-4-5",
+			"display_name": "Sonnet 4.5",
+		},
+		"workspace": map[string]string{
+			"current_dir": projectPath,
+			"project_dir": projectPath,
+		},
+		"version":      "1.0.0",
+		"output_style": map[string]string{"name": "default"},
+		"cost": map[string]interface{}{
+			"total_cost_usd":        0.01,
+			"total_duration_ms":     45000,
+			"total_api_duration_ms": 2300,
+			"total_lines_added":     156,
+			"total_lines_removed":   23,
+		},
+	}
+	data, _ := json.MarshalIndent(payload, "", "  ")
+	return string(data)
+}
+
+// TestStatusLine runs a statusline command in the project directory with a
+// sample session on stdin, and explains why the statusline would be blank
+func (m *ToolsManager) TestStatusLine(projectPath, command string) (*StatusLineTestResult, error) {
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil, fmt.Errorf("statusline command is empty")
+	}
+
+	run := runHookCommand(projectPath, command, sampleStatusLinePayload(projectPath), statusLineTimeout)
+	result := &StatusLineTestResult{
+		Stdout:     run.Stdout,
+		Stderr:     run.Stderr,
+		ExitCode:   run.ExitCode,
+		DurationMs: run.DurationMs,
+		TimedOut:   run.TimedOut,
+	}
+	result.Output, _, _ = strings.Cut(strings.TrimLeft(run.Stdout, "\r\n"), "\n")
+	result.Output = strings.TrimRight(result.Output, "\r")
+
+	switch {
+	case run.Error != "":
+		result.Problem = "the command could not be started: " + run.Error
+	case run.TimedOut:
+		result.Problem = fmt.Sprintf("the command did not finish within %s", statusLineTimeout)
+	case run.ExitCode != 0:
+		result.Problem = fmt.Sprintf("the command exited with code %d", run.ExitCode)
+	case strings.TrimSpace(result.Output) == "":
+		result.Problem = "the command printed nothing to stdout"
+	}
+	return result, nil
+}
@@ -1,10 +1,12 @@
 package claude
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -22,6 +24,7 @@ type Skill struct {
 	Path        string `json:"path"`
 	Description string `json:"description"`
 	Installed   bool   `json:"installed"`
+	Marketplace string `json:"marketplace"` // Marketplace the skill comes from
 }
 
 // Hook represents a Claude Code hook
@@ -43,6 +46,7 @@ type HookEntry struct {
 	Hooks       []HookAction `json:"hooks"`       // Array of hook actions
 	IsInline    bool         `json:"isInline"`    // Whether command is inline script or file path
 	ScriptPath  string       `json:"scriptPath"`  // Path to script file if not inline
+	Source      string       `json:"source"`      // "project" (settings.json) or "local" (settings.local.json)
 }
 
 // Command represents a Claude Code slash command
@@ -198,40 +202,17 @@ func (m *ToolsManager) SaveAgentContent(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
-// GetAvailableSkills returns skills from the Claude plugins marketplace
+// GetAvailableSkills returns skills from every installed plugin marketplace
 func (m *ToolsManager) GetAvailableSkills() ([]Skill, error) {
 	skills := []Skill{}
 
-	if m.homeDir == "" {
-		return skills, nil
-	}
-
-	// Check marketplace directory
-	marketplaceDir := filepath.Join(m.homeDir, ".claude", "plugins", "marketplaces", "claude-plugins-official", "plugins")
-
-	if _, err := os.Stat(marketplaceDir); os.IsNotExist(err) {
-		return skills, nil
-	}
-
-	entries, err := os.ReadDir(marketplaceDir)
+	marketplaces, err := m.GetMarketplaces()
 	if err != nil {
 		return skills, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		skillPath := filepath.Join(marketplaceDir, entry.Name())
-		description := m.getSkillDescription(skillPath)
-
-		skills = append(skills, Skill{
-			Name:        entry.Name(),
-			Path:        skillPath,
-			Description: description,
-			Installed:   false, // Will be updated when checking installed skills
-		})
+	for _, mp := range marketplaces {
+		skills = append(skills, m.marketplaceSkills(mp.Name, mp.Path)...)
 	}
 
 	return skills, nil
@@ -281,9 +262,10 @@ func (m *ToolsManager) getSkillDescription(skillPath string) string {
 	return ""
 }
 
-// GetInstalledSkills returns the names of skills installed in the project
-func (m *ToolsManager) GetInstalledSkills(projectPath string) ([]string, error) {
-	installed := []string{}
+// GetInstalledSkills returns the skills installed in the project, compared
+// against the marketplace or template they were installed from
+func (m *ToolsManager) GetInstalledSkills(projectPath string) ([]InstalledSkill, error) {
+	installed := []InstalledSkill{}
 
 	skillsDir := filepath.Join(projectPath, ".claude", "skills")
 	if _, err := os.Stat(skillsDir); os.IsNotExist(err) {
@@ -297,25 +279,27 @@ func (m *ToolsManager) GetInstalledSkills(projectPath string) ([]string, error)
 
 	for _, entry := range entries {
 		if entry.IsDir() {
-			installed = append(installed, entry.Name())
+			installed = append(installed, m.skillDrift(projectPath, entry.Name()))
 		}
 	}
 
 	return installed, nil
 }
 
-// InstallSkill copies a skill from the marketplace to the project
-func (m *ToolsManager) InstallSkill(projectPath, skillName string) error {
+// InstallSkill copies a skill from a marketplace to the project. With no
+// marketplace given, the first one providing the skill is used.
+func (m *ToolsManager) InstallSkill(projectPath, skillName, marketplace string) error {
 	if m.homeDir == "" {
 		return fmt.Errorf("cannot determine home directory")
 	}
 
-	srcPath := filepath.Join(m.homeDir, ".claude", "plugins", "marketplaces", "claude-plugins-official", "plugins", skillName)
-	dstPath := filepath.Join(projectPath, ".claude", "skills", skillName)
-
-	// Check source exists
-	if _, err := os.Stat(srcPath); os.IsNotExist(err) {
-		return fmt.Errorf("skill %s not found in marketplace", skillName)
+	dstPath, err := projectSkillPath(projectPath, skillName)
+	if err != nil {
+		return err
+	}
+	srcPath, err := m.findMarketplaceSkill(skillName, marketplace)
+	if err != nil {
+		return err
 	}
 
 	// Create destination directory
@@ -324,7 +308,136 @@ func (m *ToolsManager) InstallSkill(projectPath, skillName string) error {
 	}
 
 	// Copy all files from source to destination
-	return copyDir(srcPath, dstPath)
+	if err := copyDir(srcPath, dstPath); err != nil {
+		return err
+	}
+
+	src, err := dirSkillSource("marketplace", m.marketplaceOf(srcPath), srcPath)
+	if err != nil {
+		return err
+	}
+	return m.saveSkillBaseline(projectPath, skillName, src)
+}
+
+// SkillUpdateReport lists the files changed by re-copying a skill from the marketplace
+type SkillUpdateReport struct {
+	Name      string   `json:"name"`
+	Added     []string `json:"added"`
+	Modified  []string `json:"modified"`
+	Removed   []string `json:"removed"`
+	Unchanged int      `json:"unchanged"`
+}
+
+// projectSkillPath validates a skill name and returns its directory in the project
+func projectSkillPath(projectPath, skillName string) (string, error) {
+	if skillName == "" || skillName == "." || skillName == ".." || strings.ContainsAny(skillName, `/\`) {
+		return "", fmt.Errorf("invalid skill name: %s", skillName)
+	}
+	return filepath.Join(projectPath, ".claude", "skills", skillName), nil
+}
+
+// UninstallSkill removes a skill from the project
+func (m *ToolsManager) UninstallSkill(projectPath, skillName string) error {
+	dstPath, err := projectSkillPath(projectPath, skillName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dstPath); os.IsNotExist(err) {
+		return fmt.Errorf("skill %s is not installed", skillName)
+	}
+	return os.RemoveAll(dstPath)
+}
+
+// UpdateSkill re-copies an installed skill from the marketplace or template it
+// came from, making the project copy match it, and reports which files changed
+func (m *ToolsManager) UpdateSkill(projectPath, skillName string) (*SkillUpdateReport, error) {
+	if m.homeDir == "" {
+		return nil, fmt.Errorf("cannot determine home directory")
+	}
+	dstPath, err := projectSkillPath(projectPath, skillName)
+	if err != nil {
+		return nil, err
+	}
+	src, err := m.resolveSkillSource(projectPath, skillName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(dstPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("skill %s is not installed", skillName)
+	}
+
+	dstFiles, err := projectSkillFiles(dstPath, src)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SkillUpdateReport{
+		Name:     skillName,
+		Added:    []string{},
+		Modified: []string{},
+		Removed:  []string{},
+	}
+
+	for rel, srcFile := range src.Files {
+		content, err := os.ReadFile(srcFile)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(dstPath, rel)
+		current, err := os.ReadFile(target)
+		if err == nil {
+			if bytes.Equal(current, content) {
+				report.Unchanged++
+				continue
+			}
+			report.Modified = append(report.Modified, rel)
+		} else {
+			report.Added = append(report.Added, rel)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	for rel := range dstFiles {
+		if _, exists := src.Files[rel]; !exists {
+			if err := os.Remove(filepath.Join(dstPath, rel)); err != nil {
+				return nil, err
+			}
+			report.Removed = append(report.Removed, rel)
+		}
+	}
+
+	if err := m.saveSkillBaseline(projectPath, skillName, src); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Modified)
+	sort.Strings(report.Removed)
+	return report, nil
+}
+
+// listFiles returns the relative paths of all regular files under dir
+func listFiles(dir string) (map[string]struct{}, error) {
+	files := make(map[string]struct{})
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files[rel] = struct{}{}
+		}
+		return nil
+	})
+	return files, err
 }
 
 // copyDir recursively copies a directory
@@ -912,16 +1025,208 @@ func (m *ToolsManager) RemoveMCPServer(projectPath, name string) error {
 	return m.SaveProjectMCPConfig(projectPath, filtered)
 }
 
+// userConfigPath returns the path of ~/.claude.json
+func (m *ToolsManager) userConfigPath() (string, error) {
+	if m.homeDir == "" {
+		return "", fmt.Errorf("home directory not found")
+	}
+	return filepath.Join(m.homeDir, ".claude.json"), nil
+}
+
+// AddUserMCPServer adds a user-scope MCP server to ~/.claude.json
+func (m *ToolsManager) AddUserMCPServer(server MCPServer) error {
+	return m.updateUserMCPServers(func(servers map[string]json.RawMessage) error {
+		if _, exists := servers[server.Name]; exists {
+			return fmt.Errorf("MCP server %s already exists", server.Name)
+		}
+		entry, err := mergeMCPServerConfig(nil, server)
+		if err != nil {
+			return err
+		}
+		servers[server.Name] = entry
+		return nil
+	}, server)
+}
+
+// UpdateUserMCPServer replaces a user-scope MCP server, renaming it if the name changed.
+// Fields the app doesn't know about (e.g. headers) are kept.
+func (m *ToolsManager) UpdateUserMCPServer(name string, server MCPServer) error {
+	return m.updateUserMCPServers(func(servers map[string]json.RawMessage) error {
+		existing, ok := servers[name]
+		if !ok {
+			return fmt.Errorf("MCP server %s not found", name)
+		}
+		if server.Name != name {
+			if _, exists := servers[server.Name]; exists {
+				return fmt.Errorf("MCP server %s already exists", server.Name)
+			}
+			delete(servers, name)
+		}
+		entry, err := mergeMCPServerConfig(existing, server)
+		if err != nil {
+			return err
+		}
+		servers[server.Name] = entry
+		return nil
+	}, server)
+}
+
+// RemoveUserMCPServer removes a user-scope MCP server from ~/.claude.json
+func (m *ToolsManager) RemoveUserMCPServer(name string) error {
+	return m.updateUserMCPServers(func(servers map[string]json.RawMessage) error {
+		if _, ok := servers[name]; !ok {
+			return fmt.Errorf("MCP server %s not found", name)
+		}
+		delete(servers, name)
+		return nil
+	}, MCPServer{Name: name})
+}
+
+// updateUserMCPServers applies a change to the mcpServers object of ~/.claude.json,
+// leaving every other key of the file untouched
+func (m *ToolsManager) updateUserMCPServers(change func(map[string]json.RawMessage) error, server MCPServer) error {
+	if strings.TrimSpace(server.Name) == "" {
+		return fmt.Errorf("server name is required")
+	}
+
+	path, err := m.userConfigPath()
+	if err != nil {
+		return err
+	}
+
+	config := map[string]json.RawMessage{}
+	mode := os.FileMode(0600)
+	content, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(content, &config); err != nil {
+			// Never overwrite a file we can't parse
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode().Perm()
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	servers := map[string]json.RawMessage{}
+	if raw, ok := config["mcpServers"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return fmt.Errorf("invalid mcpServers in %s: %w", path, err)
+		}
+	}
+
+	if err := change(servers); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(servers)
+	if err != nil {
+		return err
+	}
+	config["mcpServers"] = raw
+
+	return writeJSONAtomic(path, config, mode)
+}
+
+// mergeMCPServerConfig writes the server's fields over an existing config entry
+func mergeMCPServerConfig(existing json.RawMessage, server MCPServer) (json.RawMessage, error) {
+	entry := map[string]interface{}{}
+	if existing != nil {
+		if err := json.Unmarshal(existing, &entry); err != nil {
+			entry = map[string]interface{}{}
+		}
+	}
+
+	for _, key := range []string{"type", "command", "args", "url", "env"} {
+		delete(entry, key)
+	}
+	if server.Type != "" {
+		entry["type"] = server.Type
+	}
+	if server.Type == "http" || server.Type == "sse" {
+		if server.URL == "" {
+			return nil, fmt.Errorf("URL is required for %s servers", server.Type)
+		}
+		entry["url"] = server.URL
+	} else {
+		if server.Command == "" {
+			return nil, fmt.Errorf("command is required for stdio servers")
+		}
+		entry["command"] = server.Command
+		entry["args"] = server.Args
+		if server.Args == nil {
+			entry["args"] = []string{}
+		}
+	}
+	if len(server.Env) > 0 {
+		entry["env"] = server.Env
+	}
+
+	return json.Marshal(entry)
+}
+
+// writeJSONAtomic writes indented JSON through a temp file and rename, so a
+// concurrent reader (the Claude CLI) never sees a partial file. A symlinked
+// path stays a symlink.
+func writeJSONAtomic(path string, value interface{}, mode os.FileMode) error {
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+
+	// Write through a symlink (e.g. a ~/.claude.json kept in a dotfiles repo)
+	// so the rename replaces its target instead of the link
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(buf.String()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // ============================================
 // Enhanced Hooks Methods
 // ============================================
 
-// GetProjectHooksDetailed returns hooks with full configuration
+// GetProjectHooksDetailed returns hooks with full configuration from
+// .claude/settings.json and .claude/settings.local.json
 func (m *ToolsManager) GetProjectHooksDetailed(projectPath string) ([]HookEntry, error) {
 	hooks := []HookEntry{}
+	for _, source := range settingsSources {
+		settingsPath, _ := projectSettingsPath(projectPath, source)
+		entries, err := m.readHookEntries(settingsPath, source)
+		if err != nil {
+			return hooks, err
+		}
+		hooks = append(hooks, entries...)
+	}
+	return hooks, nil
+}
 
-	// Read from .claude/settings.json
-	settingsPath := filepath.Join(projectPath, ".claude", "settings.json")
+// readHookEntries reads the hooks of one settings file
+func (m *ToolsManager) readHookEntries(settingsPath, source string) ([]HookEntry, error) {
+	hooks := []HookEntry{}
 	content, err := os.ReadFile(settingsPath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -945,6 +1250,11 @@ func (m *ToolsManager) GetProjectHooksDetailed(projectPath string) ([]HookEntry,
 				isInline := true
 				scriptPath := ""
 
+				// Show the original commands when hook logging wraps them
+				for i := range hc.Hooks {
+					hc.Hooks[i].Command = m.unwrapHookCommand(hc.Hooks[i].Command)
+				}
+
 				if len(hc.Hooks) > 0 {
 					command = hc.Hooks[0].Command
 					// Check if command is a script path vs inline
@@ -970,6 +1280,7 @@ func (m *ToolsManager) GetProjectHooksDetailed(projectPath string) ([]HookEntry,
 					Hooks:       hc.Hooks,
 					IsInline:    isInline,
 					ScriptPath:  scriptPath,
+					Source:      source,
 				})
 			}
 		}
@@ -978,10 +1289,46 @@ func (m *ToolsManager) GetProjectHooksDetailed(projectPath string) ([]HookEntry,
 	return hooks, nil
 }
 
-// SaveProjectHooksEntries saves hooks to the project's settings.json
+// SaveProjectHooksEntries saves hooks to the project's settings.json,
+// keeping hook logging on if it was enabled
 func (m *ToolsManager) SaveProjectHooksEntries(projectPath string, hooks []HookEntry) error {
-	settingsPath := filepath.Join(projectPath, ".claude", "settings.json")
+	return m.saveHookEntries(projectPath, hooks, m.IsHookLoggingEnabled(projectPath))
+}
 
+// saveHookEntries writes hooks to the settings file of their source, wrapping
+// the commands of settings.local.json with the hook logger when logged is set
+func (m *ToolsManager) saveHookEntries(projectPath string, hooks []HookEntry, logged bool) error {
+	bySource := make(map[string][]HookEntry)
+	for _, hook := range hooks {
+		source := hook.Source
+		if source == "" {
+			source = "project"
+		}
+		bySource[source] = append(bySource[source], hook)
+	}
+	for source := range bySource {
+		if !containsString(settingsSources, source) {
+			return fmt.Errorf("invalid settings source: %s", source)
+		}
+	}
+
+	for _, source := range settingsSources {
+		settingsPath, _ := projectSettingsPath(projectPath, source)
+		if source == "local" && len(bySource[source]) == 0 {
+			// Leave settings.local.json alone unless it has hooks to remove
+			if existing, err := m.readHookEntries(settingsPath, source); err != nil || len(existing) == 0 {
+				continue
+			}
+		}
+		if err := m.writeHookEntries(projectPath, settingsPath, bySource[source], logged && source == hookLoggedSource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHookEntries replaces the hooks of one settings file
+func (m *ToolsManager) writeHookEntries(projectPath, settingsPath string, hooks []HookEntry, logged bool) error {
 	// Read existing settings
 	var settings map[string]interface{}
 	content, err := os.ReadFile(settingsPath)
@@ -1002,9 +1349,13 @@ func (m *ToolsManager) SaveProjectHooksEntries(projectPath string, hooks []HookE
 	for _, hook := range hooks {
 		hookActions := []map[string]interface{}{}
 		for _, action := range hook.Hooks {
+			command := m.unwrapHookCommand(action.Command)
+			if logged && action.Type == "command" {
+				command = m.wrapHookCommand(projectPath, hook.EventType, hook.Matcher, command)
+			}
 			actionMap := map[string]interface{}{
 				"type":    action.Type,
-				"command": action.Command,
+				"command": command,
 			}
 			if action.Timeout > 0 {
 				actionMap["timeout"] = action.Timeout
@@ -1492,7 +1843,10 @@ func (m *ToolsManager) InstallTemplateSkill(projectPath, templatePath string) er
 	if info.IsDir() {
 		// Copy entire directory
 		skillName := filepath.Base(templatePath)
-		return copyDir(templatePath, filepath.Join(destDir, skillName))
+		if err := copyDir(templatePath, filepath.Join(destDir, skillName)); err != nil {
+			return err
+		}
+		return m.saveTemplateSkillBaseline(projectPath, skillName, templatePath)
 	}
 
 	// Single file - get parent directory name as skill name
@@ -1514,7 +1868,19 @@ func (m *ToolsManager) InstallTemplateSkill(projectPath, templatePath string) er
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(destSkillDir, "SKILL.md"), content, 0644)
+	if err := os.WriteFile(filepath.Join(destSkillDir, "SKILL.md"), content, 0644); err != nil {
+		return err
+	}
+	return m.saveTemplateSkillBaseline(projectPath, skillName, templatePath)
+}
+
+// saveTemplateSkillBaseline records a template skill as installed, for drift detection
+func (m *ToolsManager) saveTemplateSkillBaseline(projectPath, skillName, templatePath string) error {
+	src, err := templateSkillSource(templatePath)
+	if err != nil {
+		return err
+	}
+	return m.saveSkillBaseline(projectPath, skillName, src)
 }
 
 // InstallTemplateRule copies a rule from template repo to project
@@ -0,0 +1,121 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUserMCPServersPreserveConfig(t *testing.T) {
+	home := t.TempDir()
+	path := filepath.Join(home, ".claude.json")
+	initial := `{
+  "numStartups": 42,
+  "projects": {"/src/app": {"allowedTools": ["Bash"]}},
+  "mcpServers": {
+    "remote": {"type": "http", "url": "https://mcp.example.com/mcp", "headers": {"X-Team": "a&b"}}
+  }
+}`
+	if err := os.WriteFile(path, []byte(initial), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &ToolsManager{homeDir: home}
+	if err := m.AddUserMCPServer(MCPServer{Name: "fs", Command: "npx", Args: []string{"-y", "server-fs"}}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := m.AddUserMCPServer(MCPServer{Name: "fs", Command: "npx"}); err == nil {
+		t.Fatal("adding a duplicate server should fail")
+	}
+	if err := m.UpdateUserMCPServer("remote", MCPServer{Name: "remote2", Type: "http", URL: "https://mcp.example.com/v2"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := m.RemoveUserMCPServer("fs"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		NumStartups int                        `json:"numStartups"`
+		Projects    map[string]json.RawMessage `json:"projects"`
+		McpServers  map[string]struct {
+			URL     string            `json:"url"`
+			Headers map[string]string `json:"headers"`
+		} `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil {
+		t.Fatalf("result is not valid JSON: %v", err)
+	}
+
+	if config.NumStartups != 42 || config.Projects["/src/app"] == nil {
+		t.Errorf("unrelated keys were not preserved: %s", content)
+	}
+	if len(config.McpServers) != 1 {
+		t.Fatalf("expected 1 server, got %d: %s", len(config.McpServers), content)
+	}
+	renamed, ok := config.McpServers["remote2"]
+	if !ok || renamed.URL != "https://mcp.example.com/v2" {
+		t.Errorf("server was not renamed and updated: %s", content)
+	}
+	if renamed.Headers["X-Team"] != "a&b" {
+		t.Errorf("unknown server fields were not preserved: %s", content)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode changed to %v", info.Mode().Perm())
+	}
+}
+
+func TestUserMCPServersRejectInvalidFile(t *testing.T) {
+	home := t.TempDir()
+	path := filepath.Join(home, ".claude.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &ToolsManager{homeDir: home}
+	if err := m.AddUserMCPServer(MCPServer{Name: "fs", Command: "npx"}); err == nil {
+		t.Fatal("expected an error for an unparsable config")
+	}
+	if content, _ := os.ReadFile(path); string(content) != "{not json" {
+		t.Errorf("unparsable config was overwritten: %s", content)
+	}
+}
+
+func TestUserMCPServersKeepSymlinkedConfig(t *testing.T) {
+	home := t.TempDir()
+	dotfiles := t.TempDir()
+	target := filepath.Join(dotfiles, "claude.json")
+	if err := os.WriteFile(target, []byte(`{"numStartups": 1}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(home, ".claude.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	m := &ToolsManager{homeDir: home}
+	if err := m.AddUserMCPServer(MCPServer{Name: "fs", Command: "npx"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("~/.claude.json is no longer a symlink: %v", err)
+	}
+	content, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		McpServers map[string]json.RawMessage `json:"mcpServers"`
+	}
+	if err := json.Unmarshal(content, &config); err != nil || config.McpServers["fs"] == nil {
+		t.Errorf("server was not written to the link target: %s", content)
+	}
+	if entries, _ := os.ReadDir(home); len(entries) != 1 {
+		t.Errorf("temp files left next to the link: %v", entries)
+	}
+}
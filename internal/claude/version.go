@@ -0,0 +1,148 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionInfo describes the installed Claude Code CLI and the latest release
+type VersionInfo struct {
+	Installed       bool      `json:"installed"`
+	Version         string    `json:"version"` // e.g. 2.0.14
+	Path            string    `json:"path"`
+	Latest          string    `json:"latest"` // "" when the registry could not be reached
+	UpdateAvailable bool      `json:"updateAvailable"`
+	UpdateCommand   string    `json:"updateCommand"`
+	CheckedAt       time.Time `json:"checkedAt"`
+}
+
+const (
+	claudeLatestURL    = "https://registry.npmjs.org/@anthropic-ai/claude-code/latest"
+	latestVersionTTL   = time.Hour
+	versionCommandWait = 10 * time.Second
+)
+
+var semverRe = regexp.MustCompile(`\d+\.\d+\.\d+`)
+
+// CheckVersion reports the installed Claude Code version and whether a newer
+// release is published. The latest version is cached for an hour unless force is set.
+func (d *Detector) CheckVersion(force bool) (*VersionInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionCommandWait)
+	defer cancel()
+
+	info := &VersionInfo{CheckedAt: time.Now()}
+
+	output, err := claudeCommand(ctx, "--version").Output()
+	if err != nil {
+		return info, nil
+	}
+	info.Version = semverRe.FindString(string(output))
+	info.Installed = info.Version != ""
+
+	if path, err := loginShellOutput(ctx, "command -v claude"); err == nil {
+		info.Path = path
+	}
+	info.UpdateCommand = updateCommandFor(info.Path)
+
+	latest, err := d.latestVersion(force)
+	if err != nil {
+		// Offline is not an error; the dashboard just can't say whether to update
+		return info, nil
+	}
+	info.Latest = latest
+	info.UpdateAvailable = info.Installed && compareVersions(latest, info.Version) > 0
+	return info, nil
+}
+
+// latestVersion returns the latest published version, cached for latestVersionTTL
+func (d *Detector) latestVersion(force bool) (string, error) {
+	d.mu.RLock()
+	cached, fetchedAt := d.latest, d.latestFetchedAt
+	d.mu.RUnlock()
+	if !force && cached != "" && time.Since(fetchedAt) < latestVersionTTL {
+		return cached, nil
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(claudeLatestURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned HTTP %d", resp.StatusCode)
+	}
+
+	var pkg struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		return "", err
+	}
+	if pkg.Version == "" {
+		return "", fmt.Errorf("registry response has no version")
+	}
+
+	d.mu.Lock()
+	d.latest = pkg.Version
+	d.latestFetchedAt = time.Now()
+	d.mu.Unlock()
+	return pkg.Version, nil
+}
+
+// updateCommandFor picks the update command matching how the CLI was installed
+func updateCommandFor(path string) string {
+	resolved := path
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		resolved = target
+	}
+	switch {
+	case strings.Contains(resolved, "/Caskroom/") || strings.Contains(resolved, "/Cellar/"):
+		return "brew upgrade --cask claude-code"
+	case strings.Contains(resolved, "node_modules"):
+		return "npm install -g @anthropic-ai/claude-code@latest"
+	default:
+		// Native installs update themselves
+		return "claude update"
+	}
+}
+
+// compareVersions compares dotted numeric versions, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na > nb {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// loginShellOutput runs a shell snippet in the user's login shell
+func loginShellOutput(ctx context.Context, script string) (string, error) {
+	shell := defaultShell()
+	output, err := exec.CommandContext(ctx, shell, "-l", "-c", script).Output()
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
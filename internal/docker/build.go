@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BuildResult reports the outcome of an image build
+type BuildResult struct {
+	Tag      string  `json:"tag"`
+	Success  bool    `json:"success"`
+	ImageID  string  `json:"imageId,omitempty"`
+	Size     int64   `json:"size,omitempty"` // Final image size in bytes
+	Duration float64 `json:"duration"`       // in milliseconds
+	Error    string  `json:"error,omitempty"`
+}
+
+// imageTagPattern matches [registry/]name[:tag] references
+var imageTagPattern = regexp.MustCompile(`^[a-z0-9]+([._\-/:][a-zA-Z0-9]+)*(:[\w][\w.-]{0,127})?$`)
+
+// BuildImage builds an image from a Dockerfile in the project using the docker
+// CLI (BuildKit, .dockerignore and credential helpers behave exactly as in a
+// terminal). Each output line is passed to onLine.
+func (m *Manager) BuildImage(ctx context.Context, projectPath, dockerfile, tag string, onLine func(string)) (*BuildResult, error) {
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	if !imageTagPattern.MatchString(tag) {
+		return nil, fmt.Errorf("invalid image tag: %q", tag)
+	}
+
+	dockerfilePath := dockerfile
+	if !filepath.IsAbs(dockerfilePath) {
+		dockerfilePath = filepath.Join(projectPath, dockerfile)
+	}
+	if _, err := os.Stat(dockerfilePath); err != nil {
+		return nil, fmt.Errorf("dockerfile not found: %s", dockerfile)
+	}
+
+	// Arguments are passed positionally so nothing is interpolated by the shell
+	cmd := loginShellCommand(ctx, `exec docker build "$@"`,
+		"--progress=plain",
+		"-f", dockerfilePath,
+		"-t", tag,
+		projectPath)
+	cmd.Dir = projectPath
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start docker build: %w", err)
+	}
+
+	done := make(chan struct{})
+	var lastLine string
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.TrimSpace(line) != "" {
+				lastLine = line
+			}
+			if onLine != nil {
+				onLine(line)
+			}
+		}
+		// Drain in case the scanner stopped on an oversized line
+		io.Copy(io.Discard, pr)
+	}()
+
+	waitErr := cmd.Wait()
+	pw.Close()
+	<-done
+
+	result := &BuildResult{
+		Tag:      tag,
+		Duration: float64(time.Since(start).Milliseconds()),
+	}
+
+	if waitErr != nil {
+		result.Error = lastLine
+		if result.Error == "" || ctx.Err() != nil {
+			result.Error = waitErr.Error()
+		}
+		return result, nil
+	}
+
+	result.Success = true
+	if info, err := m.client.ImageInspect(context.Background(), tag); err == nil {
+		result.ImageID = shortImageID(info.ID)
+		result.Size = info.Size
+	}
+	return result, nil
+}
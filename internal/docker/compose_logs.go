@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// ComposeLogLine is a log line of a compose service, tagged for filtering
+type ComposeLogLine struct {
+	Project     string `json:"project"`
+	Service     string `json:"service"`
+	ContainerID string `json:"containerId"`
+	Color       string `json:"color"` // Stable per service within the stream
+	Stream      string `json:"stream"`
+	Line        string `json:"line"`
+}
+
+// ComposeLogService is a service included in a compose logs stream
+type ComposeLogService struct {
+	Service     string `json:"service"`
+	ContainerID string `json:"containerId"`
+	Color       string `json:"color"`
+}
+
+// composeLogsKeyPrefix namespaces compose streams among the log followers
+const composeLogsKeyPrefix = "compose:"
+
+// serviceColors is the palette services are colored with, like `docker compose logs`
+var serviceColors = []string{
+	"#89b4fa", "#a6e3a1", "#f9e2af", "#f5c2e7", "#94e2d5", "#fab387", "#cba6f7", "#f38ba8",
+}
+
+// FollowComposeLogs streams the logs of every service container of a compose
+// project (matched like GetServicesHealth) as one combined stream. Returns the
+// services included with their colors. Stop it with StopComposeLogs.
+func (m *Manager) FollowComposeLogs(projectPath string, tail int, onLine func(ComposeLogLine)) ([]ComposeLogService, error) {
+	name := ComposeProjectName(projectPath)
+	containers, err := m.client.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	services := []ComposeLogService{}
+	for _, c := range containers {
+		if c.Labels[composeWorkingDirLabel] != projectPath && c.Labels[composeProjectLabel] != name {
+			continue
+		}
+		services = append(services, ComposeLogService{
+			Service:     c.Labels[composeServiceLabel],
+			ContainerID: shortImageID(c.ID),
+		})
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no compose services found for %s", name)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Service != services[j].Service {
+			return services[i].Service < services[j].Service
+		}
+		return services[i].ContainerID < services[j].ContainerID
+	})
+
+	m.StopComposeLogs(projectPath)
+
+	for i := range services {
+		svc := &services[i]
+		svc.Color = serviceColors[i%len(serviceColors)]
+		tagged := *svc
+		key := composeLogsKey(projectPath, svc.ContainerID)
+		err := m.followLogs(key, svc.ContainerID, tail, func(l LogLine) {
+			onLine(ComposeLogLine{
+				Project:     name,
+				Service:     tagged.Service,
+				ContainerID: tagged.ContainerID,
+				Color:       tagged.Color,
+				Stream:      l.Stream,
+				Line:        l.Line,
+			})
+		})
+		if err != nil {
+			m.StopComposeLogs(projectPath)
+			return nil, fmt.Errorf("failed to follow %s logs: %w", svc.Service, err)
+		}
+	}
+
+	return services, nil
+}
+
+// StopComposeLogs stops the combined logs stream of a compose project
+func (m *Manager) StopComposeLogs(projectPath string) {
+	prefix := composeLogsKey(projectPath, "")
+	m.followMu.Lock()
+	defer m.followMu.Unlock()
+	for key, cancel := range m.followers {
+		if strings.HasPrefix(key, prefix) {
+			cancel()
+			delete(m.followers, key)
+		}
+	}
+}
+
+func composeLogsKey(projectPath, containerID string) string {
+	return composeLogsKeyPrefix + projectPath + "\x00" + containerID
+}
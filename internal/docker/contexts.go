@@ -0,0 +1,252 @@
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// DefaultContext is the built-in context: the local (or configured) daemon
+const DefaultContext = "default"
+
+// DockerContext is a Docker CLI context (`docker context ls`)
+type DockerContext struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Host        string `json:"host"`    // unix://, tcp:// or ssh:// endpoint
+	Current     bool   `json:"current"` // Selected in the Docker CLI
+}
+
+// contextMeta is the on-disk format of ~/.docker/contexts/meta/<hash>/meta.json
+type contextMeta struct {
+	Name     string `json:"Name"`
+	Metadata struct {
+		Description string `json:"Description"`
+	} `json:"Metadata"`
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// dockerConfigDir returns the Docker CLI config directory
+func dockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".docker")
+}
+
+// contextDirName is the directory name the CLI uses for a context's files
+func contextDirName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+// currentContextName returns the context selected in the Docker CLI
+func currentContextName() string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+	data, err := os.ReadFile(filepath.Join(dockerConfigDir(), "config.json"))
+	if err != nil {
+		return DefaultContext
+	}
+	var cfg struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if json.Unmarshal(data, &cfg) != nil || cfg.CurrentContext == "" {
+		return DefaultContext
+	}
+	return cfg.CurrentContext
+}
+
+// ListContexts returns the default context and all contexts created with
+// `docker context create`
+func ListContexts() ([]DockerContext, error) {
+	current := currentContextName()
+	contexts := []DockerContext{{
+		Name:        DefaultContext,
+		Description: "Local daemon",
+		Current:     current == DefaultContext,
+	}}
+
+	dirs, err := os.ReadDir(filepath.Join(dockerConfigDir(), "contexts", "meta"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return contexts, nil
+		}
+		return nil, err
+	}
+
+	others := []DockerContext{}
+	for _, dir := range dirs {
+		meta, err := readContextMeta(filepath.Join(dockerConfigDir(), "contexts", "meta", dir.Name(), "meta.json"))
+		if err != nil || meta.Name == "" {
+			continue
+		}
+		others = append(others, DockerContext{
+			Name:        meta.Name,
+			Description: meta.Metadata.Description,
+			Host:        meta.Endpoints["docker"].Host,
+			Current:     meta.Name == current,
+		})
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].Name < others[j].Name })
+
+	return append(contexts, others...), nil
+}
+
+func readContextMeta(path string) (*contextMeta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var meta contextMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// NewManagerForContext creates a manager for a Docker context. The default
+// context (or "") connects to defaultHost, or autodetects when it is empty.
+func NewManagerForContext(name, defaultHost string) (*Manager, error) {
+	if name == "" || name == DefaultContext {
+		m, err := NewManagerWithHost(defaultHost)
+		if err != nil {
+			return nil, err
+		}
+		m.contextName = DefaultContext
+		return m, nil
+	}
+
+	meta, err := readContextMeta(filepath.Join(dockerConfigDir(), "contexts", "meta", contextDirName(name), "meta.json"))
+	if err != nil {
+		return nil, fmt.Errorf("docker context %q not found", name)
+	}
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok || endpoint.Host == "" {
+		return nil, fmt.Errorf("docker context %q has no docker endpoint", name)
+	}
+
+	opts := []client.Opt{client.WithAPIVersionNegotiation()}
+	u, err := url.Parse(endpoint.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host in docker context %q: %w", name, err)
+	}
+
+	if u.Scheme == "ssh" {
+		// Same approach as the Docker CLI: tunnel the API through `docker system dial-stdio`
+		opts = append(opts,
+			client.WithHost("http://docker.example.com"),
+			client.WithDialContext(sshDialer(u)),
+		)
+	} else {
+		opts = append(opts, client.WithHost(endpoint.Host))
+		tlsDir := filepath.Join(dockerConfigDir(), "contexts", "tls", contextDirName(name), "docker")
+		ca, cert, key := filepath.Join(tlsDir, "ca.pem"), filepath.Join(tlsDir, "cert.pem"), filepath.Join(tlsDir, "key.pem")
+		if fileExists(ca) && fileExists(cert) && fileExists(key) {
+			opts = append(opts, client.WithTLSClientConfig(ca, cert, key))
+		}
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{client: cli, contextName: name, host: endpoint.Host}, nil
+}
+
+// Context returns the name of the Docker context the manager is connected to
+func (m *Manager) Context() string {
+	if m.contextName == "" {
+		return DefaultContext
+	}
+	return m.contextName
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// sshDialer returns a dialer that runs `docker system dial-stdio` on the remote
+// host over ssh, so the user's ssh config, agent and known_hosts apply
+func sshDialer(u *url.URL) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		args := []string{"-o", "ConnectTimeout=10"}
+		if u.User != nil {
+			args = append(args, "-l", u.User.Username())
+		}
+		if port := u.Port(); port != "" {
+			args = append(args, "-p", port)
+		}
+		args = append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+
+		// The connection outlives the dial context, so it must not kill the process
+		cmd := exec.Command("ssh", args...)
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start ssh: %w", err)
+		}
+		return &commandConn{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+	}
+}
+
+// commandConn is a net.Conn over the stdin/stdout of a process
+type commandConn struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    io.ReadCloser
+	closeOnce sync.Once
+}
+
+func (c *commandConn) Read(p []byte) (int, error)  { return c.stdout.Read(p) }
+func (c *commandConn) Write(p []byte) (int, error) { return c.stdin.Write(p) }
+
+// CloseWrite half-closes the connection, as used by hijacked API streams
+func (c *commandConn) CloseWrite() error { return c.stdin.Close() }
+
+func (c *commandConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.stdin.Close()
+		if c.cmd.Process != nil {
+			c.cmd.Process.Kill()
+		}
+		c.cmd.Wait()
+	})
+	return nil
+}
+
+func (c *commandConn) LocalAddr() net.Addr                { return dummyAddr{} }
+func (c *commandConn) RemoteAddr() net.Addr               { return dummyAddr{} }
+func (c *commandConn) SetDeadline(t time.Time) error      { return nil }
+func (c *commandConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *commandConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type dummyAddr struct{}
+
+func (dummyAddr) Network() string { return "dummy" }
+func (dummyAddr) String() string  { return "dummy" }
@@ -0,0 +1,308 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// DevContainerConfig is the subset of devcontainer.json the app cares about
+type DevContainerConfig struct {
+	Path              string   `json:"path"` // devcontainer.json location
+	Name              string   `json:"name"`
+	Image             string   `json:"image,omitempty"`
+	Dockerfile        string   `json:"dockerfile,omitempty"`
+	ComposeFiles      []string `json:"composeFiles,omitempty"`
+	Service           string   `json:"service,omitempty"`
+	Features          []string `json:"features"`
+	ForwardPorts      []string `json:"forwardPorts"`
+	PostCreateCommand string   `json:"postCreateCommand,omitempty"`
+	RemoteUser        string   `json:"remoteUser,omitempty"`
+	WorkspaceFolder   string   `json:"workspaceFolder"`
+	CLIAvailable      bool     `json:"cliAvailable"` // devcontainer CLI found on PATH
+	ContainerID       string   `json:"containerId,omitempty"`
+	Running           bool     `json:"running"`
+}
+
+// DevContainerUpResult is the JSON printed by `devcontainer up`
+type DevContainerUpResult struct {
+	Outcome               string `json:"outcome"`
+	ContainerID           string `json:"containerId"`
+	RemoteUser            string `json:"remoteUser"`
+	RemoteWorkspaceFolder string `json:"remoteWorkspaceFolder"`
+	Message               string `json:"message,omitempty"`
+}
+
+// devContainerFolderLabel is set on dev containers by VS Code and the devcontainer CLI
+const devContainerFolderLabel = "devcontainer.local_folder"
+
+// FindDevContainerConfig locates and parses the project's devcontainer.json.
+// Returns nil without error when the project has none.
+func FindDevContainerConfig(projectPath string) (*DevContainerConfig, error) {
+	candidates := []string{
+		filepath.Join(projectPath, ".devcontainer", "devcontainer.json"),
+		filepath.Join(projectPath, ".devcontainer.json"),
+	}
+	// Named configurations: .devcontainer/<name>/devcontainer.json
+	if nested, _ := filepath.Glob(filepath.Join(projectPath, ".devcontainer", "*", "devcontainer.json")); len(nested) > 0 {
+		sort.Strings(nested)
+		candidates = append(candidates, nested...)
+	}
+
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		return parseDevContainerConfig(path, projectPath, data)
+	}
+	return nil, nil
+}
+
+// parseDevContainerConfig parses a devcontainer.json (JSON with comments)
+func parseDevContainerConfig(path, projectPath string, data []byte) (*DevContainerConfig, error) {
+	var raw struct {
+		Name       string `json:"name"`
+		Image      string `json:"image"`
+		Dockerfile string `json:"dockerFile"`
+		Build      struct {
+			Dockerfile string `json:"dockerfile"`
+		} `json:"build"`
+		DockerComposeFile interface{}            `json:"dockerComposeFile"`
+		Service           string                 `json:"service"`
+		Features          map[string]interface{} `json:"features"`
+		ForwardPorts      []interface{}          `json:"forwardPorts"`
+		PostCreateCommand interface{}            `json:"postCreateCommand"`
+		RemoteUser        string                 `json:"remoteUser"`
+		ContainerUser     string                 `json:"containerUser"`
+		WorkspaceFolder   string                 `json:"workspaceFolder"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &raw); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Base(path), err)
+	}
+
+	cfg := &DevContainerConfig{
+		Path:            path,
+		Name:            raw.Name,
+		Image:           raw.Image,
+		Dockerfile:      raw.Build.Dockerfile,
+		Service:         raw.Service,
+		Features:        []string{},
+		ForwardPorts:    []string{},
+		RemoteUser:      raw.RemoteUser,
+		WorkspaceFolder: raw.WorkspaceFolder,
+	}
+	if cfg.Name == "" {
+		cfg.Name = filepath.Base(projectPath)
+	}
+	if cfg.Dockerfile == "" {
+		cfg.Dockerfile = raw.Dockerfile
+	}
+	if cfg.RemoteUser == "" {
+		cfg.RemoteUser = raw.ContainerUser
+	}
+	if cfg.WorkspaceFolder == "" {
+		cfg.WorkspaceFolder = "/workspaces/" + filepath.Base(projectPath)
+	}
+
+	switch v := raw.DockerComposeFile.(type) {
+	case string:
+		cfg.ComposeFiles = []string{v}
+	case []interface{}:
+		for _, f := range v {
+			if s, ok := f.(string); ok {
+				cfg.ComposeFiles = append(cfg.ComposeFiles, s)
+			}
+		}
+	}
+
+	for feature := range raw.Features {
+		cfg.Features = append(cfg.Features, feature)
+	}
+	sort.Strings(cfg.Features)
+
+	for _, p := range raw.ForwardPorts {
+		cfg.ForwardPorts = append(cfg.ForwardPorts, fmt.Sprint(p))
+	}
+
+	switch v := raw.PostCreateCommand.(type) {
+	case string:
+		cfg.PostCreateCommand = v
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, p := range v {
+			parts = append(parts, fmt.Sprint(p))
+		}
+		cfg.PostCreateCommand = strings.Join(parts, " ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		cfg.PostCreateCommand = strings.Join(keys, ", ")
+	}
+
+	return cfg, nil
+}
+
+// stripJSONC removes // and /* */ comments and trailing commas outside strings
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == ']' || c == '}':
+			// Drop a trailing comma before the closing bracket
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// IsDevContainerCLIAvailable reports whether the devcontainer CLI is installed
+func IsDevContainerCLIAvailable() bool {
+	return loginShellCommand(context.Background(), "command -v devcontainer >/dev/null 2>&1").Run() == nil
+}
+
+// FindDevContainer returns the ID of the dev container for a project, if one exists
+func (m *Manager) FindDevContainer(projectPath string) (id string, running bool, err error) {
+	args := filters.NewArgs()
+	args.Add("label", devContainerFolderLabel+"="+projectPath)
+
+	containers, err := m.client.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: args,
+	})
+	if err != nil {
+		return "", false, err
+	}
+	for _, c := range containers {
+		if c.State == "running" {
+			return c.ID, true, nil
+		}
+	}
+	if len(containers) > 0 {
+		return containers[0].ID, false, nil
+	}
+	return "", false, nil
+}
+
+// DevContainerUp builds (if needed) and starts the project's dev container
+// with `devcontainer up`, passing each progress line to onLine.
+func (m *Manager) DevContainerUp(ctx context.Context, projectPath string, onLine func(string)) (*DevContainerUpResult, error) {
+	if !IsDevContainerCLIAvailable() {
+		return nil, fmt.Errorf("devcontainer CLI not found (install with: npm install -g @devcontainers/cli)")
+	}
+
+	cmd := loginShellCommand(ctx, `exec devcontainer up --workspace-folder "$1"`, projectPath)
+	cmd.Dir = projectPath
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start devcontainer CLI: %w", err)
+	}
+
+	// Progress goes to stderr; the result is a single JSON line on stdout
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(stderr)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if onLine != nil {
+				onLine(scanner.Text())
+			}
+		}
+		io.Copy(io.Discard, stderr)
+	}()
+
+	output, _ := io.ReadAll(stdout)
+	<-done
+	waitErr := cmd.Wait()
+
+	var result DevContainerUpResult
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &result); err != nil {
+		if waitErr != nil {
+			return nil, fmt.Errorf("devcontainer up failed: %w", waitErr)
+		}
+		return nil, fmt.Errorf("unexpected devcontainer output: %s", strings.TrimSpace(string(output)))
+	}
+	if result.Outcome != "success" {
+		return &result, fmt.Errorf("devcontainer up failed: %s", result.Message)
+	}
+	return &result, nil
+}
+
+// DevContainerShellCommand returns a command line opening a shell in the
+// project's running dev container as the configured remote user
+func (m *Manager) DevContainerShellCommand(projectPath string) (command string, name string, err error) {
+	id, running, err := m.FindDevContainer(projectPath)
+	if err != nil {
+		return "", "", err
+	}
+	if id == "" || !running {
+		return "", "", fmt.Errorf("dev container is not running")
+	}
+
+	user, workDir := "", ""
+	if cfg, _ := FindDevContainerConfig(projectPath); cfg != nil {
+		user = cfg.RemoteUser
+		workDir = cfg.WorkspaceFolder
+	}
+	return m.ExecShellCommand(id, user, workDir)
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// ContainerEvent is a container lifecycle or health change reported by the daemon
+type ContainerEvent struct {
+	ContainerID string `json:"containerId"`
+	Name        string `json:"name"`
+	Image       string `json:"image"`
+	Action      string `json:"action"`           // create, start, stop, die, destroy, pause, unpause, rename, health_status
+	Health      string `json:"health,omitempty"` // healthy, unhealthy, starting (health_status only)
+	ExitCode    string `json:"exitCode,omitempty"`
+	Project     string `json:"project,omitempty"` // Compose project label
+	Time        int64  `json:"time"`
+}
+
+// watchedActions are the container events forwarded to the app
+var watchedActions = []string{
+	"create", "start", "stop", "die", "destroy", "pause", "unpause", "rename", "health_status",
+}
+
+// eventsRetryDelay is how long to wait before resubscribing after the stream drops
+const eventsRetryDelay = 5 * time.Second
+
+// WatchEvents subscribes to container events and calls onEvent for each one
+// until StopWatchingEvents is called. The subscription is re-established if the
+// daemon restarts. Calling it again replaces the previous subscription.
+func (m *Manager) WatchEvents(onEvent func(ContainerEvent)) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.followMu.Lock()
+	if m.eventsCancel != nil {
+		m.eventsCancel()
+	}
+	m.eventsCancel = cancel
+	m.followMu.Unlock()
+
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for _, action := range watchedActions {
+		args.Add("event", action)
+	}
+
+	go func() {
+		for {
+			messages, errs := m.client.Events(ctx, events.ListOptions{Filters: args})
+		stream:
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg := <-messages:
+					onEvent(containerEventFromMessage(msg))
+				case err := <-errs:
+					if ctx.Err() != nil {
+						return
+					}
+					logging.Warn("Docker event stream interrupted", "error", err)
+					break stream
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(eventsRetryDelay):
+			}
+		}
+	}()
+}
+
+// StopWatchingEvents ends the event subscription started by WatchEvents
+func (m *Manager) StopWatchingEvents() {
+	m.followMu.Lock()
+	defer m.followMu.Unlock()
+	if m.eventsCancel != nil {
+		m.eventsCancel()
+		m.eventsCancel = nil
+	}
+}
+
+// containerEventFromMessage converts a daemon event message
+func containerEventFromMessage(msg events.Message) ContainerEvent {
+	attrs := msg.Actor.Attributes
+	event := ContainerEvent{
+		ContainerID: shortImageID(msg.Actor.ID),
+		Name:        attrs["name"],
+		Image:       attrs["image"],
+		Action:      string(msg.Action),
+		ExitCode:    attrs["exitCode"],
+		Project:     attrs["com.docker.compose.project"],
+		Time:        msg.Time,
+	}
+	// Health changes arrive as "health_status: healthy"
+	if action, health, ok := strings.Cut(event.Action, ":"); ok {
+		event.Action = action
+		event.Health = strings.TrimSpace(health)
+	}
+	return event
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"projecthub/internal/shell"
+)
+
+// ShellCommand returns a `docker exec -it` command line that opens an
+// interactive shell in a running container (bash when present, else sh),
+// along with the container's name for display.
+func (m *Manager) ShellCommand(id string) (command string, name string, err error) {
+	return m.ExecShellCommand(id, "", "")
+}
+
+// ExecShellCommand is like ShellCommand but runs the shell as the given user
+// and in the given working directory (either may be empty for the defaults)
+func (m *Manager) ExecShellCommand(id, user, workDir string) (command string, name string, err error) {
+	ctx := context.Background()
+
+	info, err := m.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+	if info.State == nil || !info.State.Running {
+		return "", "", fmt.Errorf("container %s is not running", strings.TrimPrefix(info.Name, "/"))
+	}
+
+	// Use the full ID from inspect so the command never contains user input
+	args := []string{"docker", "exec", "-it"}
+	if user != "" {
+		args = append(args, "-u", shell.Quote(user))
+	}
+	if workDir != "" {
+		args = append(args, "-w", shell.Quote(workDir))
+	}
+	args = append(args, info.ID, "sh", "-c", `'if command -v bash >/dev/null 2>&1; then exec bash; else exec sh; fi'`)
+
+	return strings.Join(args, " "), strings.TrimPrefix(info.Name, "/"), nil
+}
+
+// loginShellCommand runs a script through the user's login shell so PATH
+// matches their terminal. Extra args are available to the script as "$@".
+func loginShellCommand(ctx context.Context, script string, args ...string) *exec.Cmd {
+	userShell := os.Getenv("SHELL")
+	if userShell == "" {
+		userShell = "/bin/zsh"
+	}
+	cmdArgs := append([]string{"-l", "-c", script, "projecthub"}, args...)
+	return exec.CommandContext(ctx, userShell, cmdArgs...)
+}
@@ -0,0 +1,274 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ContainerFile is an entry of a directory inside a container
+type ContainerFile struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Type    string `json:"type"` // file, dir or symlink
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modTime"` // Unix seconds
+}
+
+// CopyResult reports what a copy between host and container transferred
+type CopyResult struct {
+	Destination string `json:"destination"`
+	Files       int    `json:"files"`
+	Bytes       int64  `json:"bytes"`
+	Skipped     int    `json:"skipped"` // Symlinks and special files are not copied
+}
+
+// maxListedFiles caps directory listings of huge directories
+const maxListedFiles = 1000
+
+// listDirScript prints "type/size/mtime/name" for each entry of the directory in $1.
+// It only relies on POSIX sh and `stat -c`, available in busybox and coreutils.
+const listDirScript = `cd "$1" || exit 2
+for f in * .[!.]* ..?*; do
+  [ -e "$f" ] || [ -L "$f" ] || continue
+  if [ -L "$f" ]; then t=l; elif [ -d "$f" ]; then t=d; else t=f; fi
+  printf '%s/%s/%s\n' "$t" "$(stat -c '%s/%Y' -- "$f" 2>/dev/null || echo 0/0)" "$f"
+done`
+
+// ListContainerFiles lists a directory inside a running container.
+// The container needs a POSIX shell.
+func (m *Manager) ListContainerFiles(id, dir string) ([]ContainerFile, error) {
+	if dir == "" {
+		dir = "/"
+	}
+	dir = path.Clean("/" + dir)
+
+	output, exitCode, err := m.execOutput(context.Background(), id, []string{"sh", "-c", listDirScript, "sh", dir})
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("cannot list %s: %s", dir, strings.TrimSpace(output))
+	}
+
+	files := []ContainerFile{}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.SplitN(line, "/", 4)
+		if len(parts) < 4 || parts[3] == "" {
+			continue
+		}
+		f := ContainerFile{Name: parts[3], Path: path.Join(dir, parts[3]), Type: "file"}
+		switch parts[0] {
+		case "d":
+			f.Type = "dir"
+		case "l":
+			f.Type = "symlink"
+		}
+		f.Size, _ = strconv.ParseInt(parts[1], 10, 64)
+		f.ModTime, _ = strconv.ParseInt(parts[2], 10, 64)
+		files = append(files, f)
+		if len(files) >= maxListedFiles {
+			break
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if (files[i].Type == "dir") != (files[j].Type == "dir") {
+			return files[i].Type == "dir"
+		}
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
+
+// CopyFromContainer copies a file or directory out of a container into hostDir,
+// like `docker cp container:src hostDir`. Works on stopped containers too.
+func (m *Manager) CopyFromContainer(id, srcPath, hostDir string) (*CopyResult, error) {
+	info, err := os.Stat(hostDir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("destination is not a directory: %s", hostDir)
+	}
+
+	reader, stat, err := m.client.CopyFromContainer(context.Background(), id, srcPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	result := &CopyResult{Destination: filepath.Join(hostDir, stat.Name)}
+	root := filepath.Clean(hostDir) + string(os.PathSeparator)
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		// Never write outside the destination directory
+		target := filepath.Join(hostDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target+string(os.PathSeparator), root) || target == filepath.Clean(hostDir) {
+			result.Skipped++
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return result, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return result, err
+			}
+			n, err := writeFile(target, tr, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return result, err
+			}
+			result.Files++
+			result.Bytes += n
+		default:
+			result.Skipped++
+		}
+	}
+
+	return result, nil
+}
+
+// CopyToContainer copies a host file or directory into containerDir,
+// like `docker cp hostPath container:containerDir`
+func (m *Manager) CopyToContainer(id, hostPath, containerDir string) (*CopyResult, error) {
+	if _, err := os.Stat(hostPath); err != nil {
+		return nil, err
+	}
+	containerDir = path.Clean("/" + containerDir)
+
+	stat, err := m.client.ContainerStatPath(context.Background(), id, containerDir)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode.IsDir() {
+		return nil, fmt.Errorf("destination is not a directory: %s", containerDir)
+	}
+
+	result := &CopyResult{Destination: path.Join(containerDir, filepath.Base(hostPath))}
+	var buf bytes.Buffer
+	if err := tarPath(&buf, hostPath, result); err != nil {
+		return nil, err
+	}
+
+	err = m.client.CopyToContainer(context.Background(), id, containerDir, &buf, container.CopyToContainerOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// tarPath writes hostPath (file or directory tree) to a tar archive rooted at its base name
+func tarPath(w io.Writer, hostPath string, result *CopyResult) error {
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(filepath.Clean(hostPath))
+
+	err := filepath.Walk(hostPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			result.Skipped++
+			return nil
+		}
+
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := io.Copy(tw, f)
+		if err != nil {
+			return err
+		}
+		result.Files++
+		result.Bytes += n
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// writeFile writes r to path with the given permissions
+func writeFile(path string, r io.Reader, perm os.FileMode) (int64, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0200)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
+
+// execOutput runs a command in a running container and returns its combined
+// output and exit code
+func (m *Manager) execOutput(ctx context.Context, id string, cmd []string) (string, int, error) {
+	exec, err := m.client.ContainerExecCreate(ctx, id, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	resp, err := m.client.ContainerExecAttach(ctx, exec.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	defer resp.Close()
+
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, resp.Reader); err != nil {
+		return "", 0, err
+	}
+
+	inspect, err := m.client.ContainerExecInspect(ctx, exec.ID)
+	if err != nil {
+		return "", 0, err
+	}
+	return output.String(), inspect.ExitCode, nil
+}
@@ -0,0 +1,150 @@
+package docker
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// Overall health levels shown on the project dashboard
+const (
+	HealthGreen  = "green"
+	HealthYellow = "yellow"
+	HealthRed    = "red"
+	HealthNone   = "none" // No compose services
+)
+
+// Compose labels set on every service container
+const (
+	composeProjectLabel    = "com.docker.compose.project"
+	composeServiceLabel    = "com.docker.compose.service"
+	composeWorkingDirLabel = "com.docker.compose.project.working_dir"
+)
+
+// ServiceHealth is the state of one compose service container
+type ServiceHealth struct {
+	Service     string `json:"service"`
+	ContainerID string `json:"containerId"`
+	State       string `json:"state"`  // running, exited, restarting, ...
+	Health      string `json:"health"` // healthy, unhealthy, starting or none (no healthcheck)
+	Status      string `json:"status"` // Docker's human readable status
+	Level       string `json:"level"`  // green, yellow or red
+}
+
+// ServicesHealth aggregates the health of all compose services of a project
+type ServicesHealth struct {
+	Project   string          `json:"project"` // Compose project name
+	Overall   string          `json:"overall"` // green, yellow, red or none
+	Services  []ServiceHealth `json:"services"`
+	Healthy   int             `json:"healthy"`
+	Unhealthy int             `json:"unhealthy"`
+	Pending   int             `json:"pending"`
+}
+
+// ComposeProjectName returns the default compose project name for a directory
+func ComposeProjectName(projectPath string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(filepath.Base(projectPath)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(b.String(), "-_")
+}
+
+// GetServicesHealth aggregates the health checks of the compose services that
+// belong to a project directory (matched by working dir or project name)
+func (m *Manager) GetServicesHealth(projectPath string) (*ServicesHealth, error) {
+	name := ComposeProjectName(projectPath)
+	result := &ServicesHealth{Project: name, Overall: HealthNone, Services: []ServiceHealth{}}
+
+	containers, err := m.client.ContainerList(context.Background(), container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", composeProjectLabel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range containers {
+		if c.Labels[composeWorkingDirLabel] != projectPath && c.Labels[composeProjectLabel] != name {
+			continue
+		}
+		result.Project = c.Labels[composeProjectLabel]
+
+		svc := ServiceHealth{
+			Service:     c.Labels[composeServiceLabel],
+			ContainerID: shortImageID(c.ID),
+			State:       string(c.State),
+			Health:      healthFromStatus(c.Status),
+			Status:      c.Status,
+		}
+		svc.Level = serviceLevel(svc)
+		switch svc.Level {
+		case HealthGreen:
+			result.Healthy++
+		case HealthYellow:
+			result.Pending++
+		case HealthRed:
+			result.Unhealthy++
+		}
+		result.Services = append(result.Services, svc)
+	}
+
+	sort.Slice(result.Services, func(i, j int) bool {
+		return result.Services[i].Service < result.Services[j].Service
+	})
+
+	switch {
+	case len(result.Services) == 0:
+		result.Overall = HealthNone
+	case result.Unhealthy > 0:
+		result.Overall = HealthRed
+	case result.Pending > 0:
+		result.Overall = HealthYellow
+	default:
+		result.Overall = HealthGreen
+	}
+	return result, nil
+}
+
+// healthFromStatus reads the healthcheck result from a status like "Up 2 minutes (healthy)"
+func healthFromStatus(status string) string {
+	switch {
+	case strings.Contains(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(status, "(health: starting)"):
+		return "starting"
+	}
+	return "none"
+}
+
+// serviceLevel rates a single service container
+func serviceLevel(svc ServiceHealth) string {
+	switch svc.State {
+	case "running":
+		switch svc.Health {
+		case "unhealthy":
+			return HealthRed
+		case "starting":
+			return HealthYellow
+		}
+		return HealthGreen
+	case "exited":
+		// One-shot services (migrations, seeders) finish with exit code 0
+		if strings.HasPrefix(svc.Status, "Exited (0)") {
+			return HealthGreen
+		}
+		return HealthRed
+	case "dead":
+		return HealthRed
+	}
+	// created, restarting, paused, removing
+	return HealthYellow
+}
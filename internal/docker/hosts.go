@@ -0,0 +1,147 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+)
+
+// HostCandidate is a Docker-compatible daemon endpoint the app can connect to
+type HostCandidate struct {
+	Name      string `json:"name"` // Docker Desktop, Podman, Colima, ...
+	Host      string `json:"host"` // DOCKER_HOST style address, e.g. unix:///var/run/docker.sock
+	Exists    bool   `json:"exists"`
+	Reachable bool   `json:"reachable"`
+}
+
+// pingTimeout bounds how long a candidate socket may take to answer
+const pingTimeout = 2 * time.Second
+
+// knownSockets lists common daemon sockets for Docker and its alternatives,
+// in order of preference
+func knownSockets() []HostCandidate {
+	home, _ := os.UserHomeDir()
+	sockets := []HostCandidate{
+		{Name: "Docker", Host: "/var/run/docker.sock"},
+		{Name: "Docker Desktop", Host: filepath.Join(home, ".docker", "run", "docker.sock")},
+		{Name: "OrbStack", Host: filepath.Join(home, ".orbstack", "run", "docker.sock")},
+		{Name: "Colima", Host: filepath.Join(home, ".colima", "default", "docker.sock")},
+		{Name: "Colima", Host: filepath.Join(home, ".colima", "docker.sock")},
+		{Name: "Rancher Desktop", Host: filepath.Join(home, ".rd", "docker.sock")},
+	}
+
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		sockets = append(sockets, HostCandidate{Name: "Podman", Host: filepath.Join(runtimeDir, "podman", "podman.sock")})
+	}
+	sockets = append(sockets,
+		HostCandidate{Name: "Podman", Host: filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock")},
+		HostCandidate{Name: "Podman", Host: filepath.Join(home, ".local", "share", "containers", "podman", "machine", "qemu", "podman.sock")},
+		HostCandidate{Name: "Podman", Host: "/run/podman/podman.sock"},
+	)
+
+	for i := range sockets {
+		sockets[i].Host = "unix://" + sockets[i].Host
+	}
+	return sockets
+}
+
+// NewManagerWithHost creates a Docker manager connected to the given host.
+// With an empty host, DOCKER_HOST from the environment is used when set;
+// otherwise the known sockets are probed and the first one that answers wins.
+func NewManagerWithHost(host string) (*Manager, error) {
+	if host != "" {
+		return newManagerForHost(host)
+	}
+	if os.Getenv("DOCKER_HOST") != "" {
+		return newManagerForHost("")
+	}
+
+	for _, candidate := range knownSockets() {
+		if !socketExists(candidate.Host) {
+			continue
+		}
+		m, err := newManagerForHost(candidate.Host)
+		if err != nil {
+			continue
+		}
+		if m.ping() {
+			return m, nil
+		}
+		m.Close()
+	}
+
+	// Nothing answered; fall back to the client defaults so the error is familiar
+	return newManagerForHost("")
+}
+
+// newManagerForHost creates a client for a host, or from the environment when host is empty
+func newManagerForHost(host string) (*Manager, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if host != "" {
+		if !strings.Contains(host, "://") {
+			host = "unix://" + host
+		}
+		opts = append(opts, client.WithHost(host))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %w", host, err)
+	}
+	return &Manager{client: cli}, nil
+}
+
+// DetectHosts reports which of the known daemon sockets exist and respond
+func DetectHosts() []HostCandidate {
+	candidates := knownSockets()
+	if envHost := os.Getenv("DOCKER_HOST"); envHost != "" {
+		candidates = append([]HostCandidate{{Name: "DOCKER_HOST", Host: envHost}}, candidates...)
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		if strings.HasPrefix(c.Host, "unix://") {
+			c.Exists = socketExists(c.Host)
+			if !c.Exists {
+				continue
+			}
+		} else {
+			c.Exists = true
+		}
+		if m, err := newManagerForHost(c.Host); err == nil {
+			c.Reachable = m.ping()
+			m.Close()
+		}
+	}
+	return candidates
+}
+
+// Host returns the daemon address the manager is connected to
+func (m *Manager) Host() string {
+	if m.host != "" {
+		return m.host
+	}
+	if m.client == nil {
+		return ""
+	}
+	return m.client.DaemonHost()
+}
+
+// ping checks the daemon with a short timeout
+func (m *Manager) ping() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	_, err := m.client.Ping(ctx)
+	return err == nil
+}
+
+// socketExists reports whether a unix:// host points at an existing socket
+func socketExists(host string) bool {
+	info, err := os.Stat(strings.TrimPrefix(host, "unix://"))
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
@@ -0,0 +1,177 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// Image represents a local Docker image
+type Image struct {
+	ID         string   `json:"id"`
+	Tags       []string `json:"tags"`
+	Size       int64    `json:"size"`
+	Created    int64    `json:"created"`
+	Containers int64    `json:"containers"` // Containers using the image (-1 if unknown)
+	Dangling   bool     `json:"dangling"`
+}
+
+// Progress is a single update from a streamed image operation (pull, build, push)
+type Progress struct {
+	Ref     string `json:"ref"`
+	ID      string `json:"id,omitempty"` // Layer ID for pull/push
+	Status  string `json:"status"`
+	Stream  string `json:"stream,omitempty"` // Build output
+	Current int64  `json:"current,omitempty"`
+	Total   int64  `json:"total,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PruneResult reports what a prune removed
+type PruneResult struct {
+	Deleted        []string `json:"deleted"`
+	SpaceReclaimed uint64   `json:"spaceReclaimed"`
+}
+
+// ListImages lists local images
+func (m *Manager) ListImages() ([]Image, error) {
+	ctx := context.Background()
+
+	images, err := m.client.ImageList(ctx, image.ListOptions{SharedSize: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Image, 0, len(images))
+	for _, img := range images {
+		tags := []string{}
+		for _, t := range img.RepoTags {
+			if t != "<none>:<none>" {
+				tags = append(tags, t)
+			}
+		}
+		result = append(result, Image{
+			ID:         shortImageID(img.ID),
+			Tags:       tags,
+			Size:       img.Size,
+			Created:    img.Created,
+			Containers: img.Containers,
+			Dangling:   len(tags) == 0,
+		})
+	}
+
+	return result, nil
+}
+
+// PullImage pulls an image, reporting progress until the pull completes
+func (m *Manager) PullImage(ref string, onProgress func(Progress)) error {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return fmt.Errorf("image reference is required")
+	}
+
+	// Private registries need the credentials stored by RegistryLogin;
+	// public images pull anonymously even if the keychain is unavailable
+	auth, _ := registryAuth(RegistryForImage(ref))
+
+	reader, err := m.client.ImagePull(context.Background(), ref, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readProgress(reader, ref, onProgress)
+}
+
+// RemoveImage removes an image, returning the IDs that were untagged or deleted
+func (m *Manager) RemoveImage(id string, force bool) ([]string, error) {
+	responses, err := m.client.ImageRemove(context.Background(), id, image.RemoveOptions{
+		Force:         force,
+		PruneChildren: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, r := range responses {
+		if r.Deleted != "" {
+			removed = append(removed, shortImageID(r.Deleted))
+		} else if r.Untagged != "" {
+			removed = append(removed, r.Untagged)
+		}
+	}
+	return removed, nil
+}
+
+// PruneImages removes dangling images, or all unused images when all is set
+func (m *Manager) PruneImages(all bool) (*PruneResult, error) {
+	args := filters.NewArgs()
+	if all {
+		args.Add("dangling", "false")
+	}
+
+	report, err := m.client.ImagesPrune(context.Background(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{Deleted: []string{}, SpaceReclaimed: report.SpaceReclaimed}
+	for _, d := range report.ImagesDeleted {
+		if d.Deleted != "" {
+			result.Deleted = append(result.Deleted, shortImageID(d.Deleted))
+		}
+	}
+	return result, nil
+}
+
+// readProgress decodes a JSON message stream, forwarding each message.
+// Returns the first error reported in the stream.
+func readProgress(r io.Reader, ref string, onProgress func(Progress)) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		p := Progress{
+			Ref:    ref,
+			ID:     msg.ID,
+			Status: msg.Status,
+			Stream: msg.Stream,
+		}
+		if msg.Progress != nil {
+			p.Current = msg.Progress.Current
+			p.Total = msg.Progress.Total
+		}
+		if msg.Error != nil {
+			p.Error = msg.Error.Message
+		}
+
+		if onProgress != nil {
+			onProgress(p)
+		}
+		if p.Error != "" {
+			return fmt.Errorf("%s", p.Error)
+		}
+	}
+}
+
+// shortImageID trims the digest algorithm prefix and shortens the ID
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
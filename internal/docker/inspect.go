@@ -0,0 +1,191 @@
+package docker
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"projecthub/internal/shell"
+)
+
+// ContainerDetails is the inspect view shown in the Docker tab's details drawer
+type ContainerDetails struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Image         string            `json:"image"`
+	ImageID       string            `json:"imageId"`
+	State         string            `json:"state"`
+	Health        string            `json:"health,omitempty"`
+	StartedAt     string            `json:"startedAt,omitempty"`
+	Command       string            `json:"command"` // Entrypoint and args as run
+	WorkingDir    string            `json:"workingDir,omitempty"`
+	User          string            `json:"user,omitempty"`
+	RestartPolicy string            `json:"restartPolicy"`
+	Env           []EnvVar          `json:"env"`
+	Mounts        []MountInfo       `json:"mounts"`
+	Labels        map[string]string `json:"labels"`
+	Networks      []NetworkInfo     `json:"networks"`
+	Ports         []PortMapping     `json:"ports"`
+}
+
+// EnvVar is an environment variable; Masked is set when the value was hidden
+type EnvVar struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Masked bool   `json:"masked"`
+}
+
+// MountInfo describes a bind mount, volume or tmpfs
+type MountInfo struct {
+	Type        string `json:"type"` // bind, volume, tmpfs
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Name        string `json:"name,omitempty"` // Volume name
+	ReadOnly    bool   `json:"readOnly"`
+}
+
+// NetworkInfo is a network the container is attached to
+type NetworkInfo struct {
+	Name      string   `json:"name"`
+	IPAddress string   `json:"ipAddress"`
+	Gateway   string   `json:"gateway"`
+	Aliases   []string `json:"aliases"`
+}
+
+// maskedValue replaces secret values
+const maskedValue = "********"
+
+// secretEnvRe matches variable names that usually hold secrets
+var secretEnvRe = regexp.MustCompile(`(?i)(PASSWORD|PASSWD|PASS$|SECRET|TOKEN|API_?KEY|PRIVATE|CREDENTIAL|AUTH|ACCESS_KEY|_KEY$|^KEY$|DSN|SALT)`)
+
+// InspectContainer returns a container's configuration with secret env values masked
+func (m *Manager) InspectContainer(id string) (*ContainerDetails, error) {
+	info, err := m.client.ContainerInspect(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &ContainerDetails{
+		ID:            shortImageID(info.ID),
+		Name:          strings.TrimPrefix(info.Name, "/"),
+		ImageID:       shortImageID(info.Image),
+		RestartPolicy: "no",
+		Env:           []EnvVar{},
+		Mounts:        []MountInfo{},
+		Labels:        map[string]string{},
+		Networks:      []NetworkInfo{},
+		Ports:         []PortMapping{},
+	}
+
+	if info.State != nil {
+		details.State = info.State.Status
+		details.StartedAt = info.State.StartedAt
+		if info.State.Health != nil {
+			details.Health = info.State.Health.Status
+		}
+	}
+	if info.HostConfig != nil && info.HostConfig.RestartPolicy.Name != "" {
+		details.RestartPolicy = string(info.HostConfig.RestartPolicy.Name)
+	}
+
+	if cfg := info.Config; cfg != nil {
+		details.Image = cfg.Image
+		details.WorkingDir = cfg.WorkingDir
+		details.User = cfg.User
+		details.Command = formatCommand(append(append([]string{}, cfg.Entrypoint...), cfg.Cmd...))
+		if cfg.Labels != nil {
+			details.Labels = cfg.Labels
+		}
+		for _, kv := range cfg.Env {
+			name, value, _ := strings.Cut(kv, "=")
+			details.Env = append(details.Env, maskEnv(name, value))
+		}
+		sort.Slice(details.Env, func(i, j int) bool { return details.Env[i].Name < details.Env[j].Name })
+	}
+
+	for _, mnt := range info.Mounts {
+		details.Mounts = append(details.Mounts, MountInfo{
+			Type:        string(mnt.Type),
+			Source:      mnt.Source,
+			Destination: mnt.Destination,
+			Name:        mnt.Name,
+			ReadOnly:    !mnt.RW,
+		})
+	}
+
+	if info.NetworkSettings != nil {
+		for name, ep := range info.NetworkSettings.Networks {
+			if ep == nil {
+				continue
+			}
+			aliases := ep.Aliases
+			if aliases == nil {
+				aliases = []string{}
+			}
+			details.Networks = append(details.Networks, NetworkInfo{
+				Name:      name,
+				IPAddress: ep.IPAddress,
+				Gateway:   ep.Gateway,
+				Aliases:   aliases,
+			})
+		}
+		sort.Slice(details.Networks, func(i, j int) bool { return details.Networks[i].Name < details.Networks[j].Name })
+
+		for port, bindings := range info.NetworkSettings.Ports {
+			mapping := PortMapping{ContainerPort: port.Int(), Protocol: port.Proto()}
+			if len(bindings) == 0 {
+				details.Ports = append(details.Ports, mapping)
+				continue
+			}
+			for _, b := range bindings {
+				m := mapping
+				m.HostIP = b.HostIP
+				m.HostPort, _ = strconv.Atoi(b.HostPort)
+				details.Ports = append(details.Ports, m)
+			}
+		}
+		sort.Slice(details.Ports, func(i, j int) bool {
+			if details.Ports[i].ContainerPort != details.Ports[j].ContainerPort {
+				return details.Ports[i].ContainerPort < details.Ports[j].ContainerPort
+			}
+			return details.Ports[i].HostIP < details.Ports[j].HostIP
+		})
+	}
+
+	return details, nil
+}
+
+// maskEnv hides values of secret-looking variables and passwords embedded in URLs
+func maskEnv(name, value string) EnvVar {
+	if value != "" && secretEnvRe.MatchString(name) {
+		return EnvVar{Name: name, Value: maskedValue, Masked: true}
+	}
+	// e.g. DATABASE_URL=postgres://user:secret@db/app
+	if strings.Contains(value, "://") && strings.Contains(value, "@") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				// Substitute after encoding so the mask is not percent-escaped
+				u.User = url.UserPassword(u.User.Username(), "MASKED")
+				masked := strings.Replace(u.String(), ":MASKED@", ":"+maskedValue+"@", 1)
+				return EnvVar{Name: name, Value: masked, Masked: true}
+			}
+		}
+	}
+	return EnvVar{Name: name, Value: value}
+}
+
+// formatCommand joins a command line, quoting arguments that need it
+func formatCommand(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"$`\\|&;<>()*?[]{}~#") {
+			parts[i] = shell.Quote(arg)
+		} else {
+			parts[i] = arg
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,162 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"projecthub/internal/logging"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// LogLine is a single line of container output
+type LogLine struct {
+	ContainerID string `json:"containerId"`
+	Stream      string `json:"stream"` // stdout or stderr
+	Line        string `json:"line"`
+}
+
+// FollowLogs streams a container's logs, starting with the last `tail` lines,
+// calling onLine for every line until StopFollowingLogs is called or the
+// container stops. Following an already followed container restarts the stream.
+func (m *Manager) FollowLogs(id string, tail int, onLine func(LogLine)) error {
+	return m.followLogs(id, id, tail, onLine)
+}
+
+// followLogs streams a container's logs, registering the stream under key so
+// several streams (e.g. a compose stream and a single container view) can
+// follow the same container independently
+func (m *Manager) followLogs(key, id string, tail int, onLine func(LogLine)) error {
+	info, err := m.client.ContainerInspect(context.Background(), id)
+	if err != nil {
+		return err
+	}
+
+	if tail <= 0 {
+		tail = 100
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, err := m.client.ContainerLogs(ctx, id, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Tail:       strconv.Itoa(tail),
+		Timestamps: true,
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	m.followMu.Lock()
+	if m.followers == nil {
+		m.followers = make(map[string]context.CancelFunc)
+	}
+	if prev, ok := m.followers[key]; ok {
+		prev()
+	}
+	m.followers[key] = cancel
+	m.followMu.Unlock()
+
+	go func() {
+		defer reader.Close()
+		defer func() {
+			m.followMu.Lock()
+			// Only remove our own entry; a newer follow may have replaced it
+			if current, ok := m.followers[key]; ok && ctx.Err() == nil {
+				current()
+				delete(m.followers, key)
+			}
+			m.followMu.Unlock()
+		}()
+
+		stdout := &lineWriter{onLine: func(line string) { onLine(LogLine{ContainerID: id, Stream: "stdout", Line: line}) }}
+		stderr := &lineWriter{onLine: func(line string) { onLine(LogLine{ContainerID: id, Stream: "stderr", Line: line}) }}
+
+		var err error
+		if info.Config != nil && info.Config.Tty {
+			// TTY containers send a raw stream without multiplexing headers
+			_, err = io.Copy(stdout, reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, reader)
+		}
+		stdout.Flush()
+		stderr.Flush()
+
+		if err != nil && ctx.Err() == nil {
+			logging.Warn("Container log stream ended", "container", id, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// StopFollowingLogs stops streaming logs for a container
+func (m *Manager) StopFollowingLogs(id string) {
+	m.followMu.Lock()
+	defer m.followMu.Unlock()
+	if cancel, ok := m.followers[id]; ok {
+		cancel()
+		delete(m.followers, id)
+	}
+}
+
+// StopAllLogFollowers stops every active log stream
+func (m *Manager) StopAllLogFollowers() {
+	m.followMu.Lock()
+	defer m.followMu.Unlock()
+	for id, cancel := range m.followers {
+		cancel()
+		delete(m.followers, id)
+	}
+}
+
+// FollowedContainers returns the IDs of containers whose logs are being streamed
+func (m *Manager) FollowedContainers() []string {
+	m.followMu.Lock()
+	defer m.followMu.Unlock()
+	ids := make([]string, 0, len(m.followers))
+	for id := range m.followers {
+		if !strings.HasPrefix(id, composeLogsKeyPrefix) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// lineWriter splits written data into lines
+type lineWriter struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	onLine func(string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		idx := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if idx < 0 {
+			break
+		}
+		line := string(bytes.TrimRight(w.buf.Next(idx+1), "\r\n"))
+		w.onLine(line)
+	}
+	return len(p), nil
+}
+
+// Flush emits any buffered partial line
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.onLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
@@ -2,8 +2,12 @@ package docker
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
@@ -13,28 +17,44 @@ import (
 
 // Container represents a Docker container
 type Container struct {
-	ID      string   `json:"id"`
-	Name    string   `json:"name"`
-	Image   string   `json:"image"`
-	State   string   `json:"state"`
-	Status  string   `json:"status"`
-	Ports   []string `json:"ports"`
-	Created int64    `json:"created"`
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Image        string        `json:"image"`
+	State        string        `json:"state"`
+	Status       string        `json:"status"`
+	Ports        []string      `json:"ports"`
+	PortMappings []PortMapping `json:"portMappings"`
+	Created      int64         `json:"created"`
+}
+
+// PortMapping represents a container port and where it is published on the host
+type PortMapping struct {
+	HostIP        string `json:"hostIp,omitempty"`
+	HostPort      int    `json:"hostPort,omitempty"` // 0 when not published
+	ContainerPort int    `json:"containerPort"`
+	Protocol      string `json:"protocol"`
 }
 
 // Manager manages Docker containers
 type Manager struct {
 	client *client.Client
+
+	// Docker context name and endpoint shown to the user
+	contextName string
+	host        string
+
+	// Active log streams, keyed by container ID
+	followers map[string]context.CancelFunc
+	followMu  sync.Mutex
+
+	// Cancels the container event subscription
+	eventsCancel context.CancelFunc
 }
 
-// NewManager creates a new Docker manager
+// NewManager creates a new Docker manager, autodetecting the daemon socket
+// (Docker Desktop, Podman, Colima, OrbStack, Rancher Desktop) when DOCKER_HOST is unset
 func NewManager() (*Manager, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
-	if err != nil {
-		return nil, err
-	}
-
-	return &Manager{client: cli}, nil
+	return NewManagerWithHost("")
 }
 
 // IsAvailable checks if Docker is available
@@ -75,13 +95,14 @@ func (m *Manager) ListContainers(all bool) ([]Container, error) {
 		}
 
 		result[i] = Container{
-			ID:      c.ID[:12],
-			Name:    name,
-			Image:   c.Image,
-			State:   c.State,
-			Status:  c.Status,
-			Ports:   ports,
-			Created: c.Created,
+			ID:           c.ID[:12],
+			Name:         name,
+			Image:        c.Image,
+			State:        c.State,
+			Status:       c.Status,
+			Ports:        ports,
+			PortMappings: portMappings(c.Ports),
+			Created:      c.Created,
 		}
 	}
 
@@ -118,13 +139,14 @@ func (m *Manager) ListContainersForProject(projectName string) ([]Container, err
 		}
 
 		result[i] = Container{
-			ID:      c.ID[:12],
-			Name:    name,
-			Image:   c.Image,
-			State:   c.State,
-			Status:  c.Status,
-			Ports:   ports,
-			Created: c.Created,
+			ID:           c.ID[:12],
+			Name:         name,
+			Image:        c.Image,
+			State:        c.State,
+			Status:       c.Status,
+			Ports:        ports,
+			PortMappings: portMappings(c.Ports),
+			Created:      c.Created,
 		}
 	}
 
@@ -169,7 +191,7 @@ func (m *Manager) GetContainerLogs(id string, tail int) (string, error) {
 	}
 
 	if tail > 0 {
-		options.Tail = string(rune(tail))
+		options.Tail = strconv.Itoa(tail)
 	}
 
 	reader, err := m.client.ContainerLogs(ctx, id, options)
@@ -213,11 +235,38 @@ func (m *Manager) Close() error {
 
 func formatPort(p types.Port) string {
 	if p.PublicPort > 0 {
-		return strings.ToLower(string(p.Type)) + ":" + string(rune(p.PublicPort)) + "->" + string(rune(p.PrivatePort))
+		return strings.ToLower(p.Type) + ":" + strconv.Itoa(int(p.PublicPort)) + "->" + strconv.Itoa(int(p.PrivatePort))
 	}
 	return formatPortPrivate(p)
 }
 
 func formatPortPrivate(p types.Port) string {
-	return strings.ToLower(string(p.Type)) + ":" + string(rune(p.PrivatePort))
+	return strings.ToLower(p.Type) + ":" + strconv.Itoa(int(p.PrivatePort))
+}
+
+// portMappings converts API ports, dropping the duplicate IPv6 entries
+// Docker reports for ports published on both 0.0.0.0 and ::
+func portMappings(ports []types.Port) []PortMapping {
+	mappings := []PortMapping{}
+	seen := make(map[string]bool)
+	for _, p := range ports {
+		key := fmt.Sprintf("%d/%d/%s", p.PublicPort, p.PrivatePort, p.Type)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mappings = append(mappings, PortMapping{
+			HostIP:        p.IP,
+			HostPort:      int(p.PublicPort),
+			ContainerPort: int(p.PrivatePort),
+			Protocol:      strings.ToLower(p.Type),
+		})
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].HostPort != mappings[j].HostPort {
+			return mappings[i].HostPort < mappings[j].HostPort
+		}
+		return mappings[i].ContainerPort < mappings[j].ContainerPort
+	})
+	return mappings
 }
@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+)
+
+// SystemPruneReport lists what a system prune removed, or would remove in a dry run
+type SystemPruneReport struct {
+	DryRun          bool     `json:"dryRun"`
+	Containers      []string `json:"containers"`
+	Images          []string `json:"images"`
+	Volumes         []string `json:"volumes"`
+	Networks        []string `json:"networks"`
+	BuildCacheCount int      `json:"buildCacheCount"`
+	SpaceReclaimed  uint64   `json:"spaceReclaimed"` // Estimated for dry runs
+}
+
+// anonymousVolumeLabel marks volumes created without a name
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+// SystemPrune removes stopped containers, unused networks, dangling (or, with
+// allImages, all unused) images and build cache, and optionally anonymous
+// volumes, like `docker system prune`. With dryRun nothing is removed and the
+// report estimates what would be.
+func (m *Manager) SystemPrune(dryRun, allImages, volumes bool) (*SystemPruneReport, error) {
+	if dryRun {
+		return m.systemPruneDryRun(allImages, volumes)
+	}
+
+	ctx := context.Background()
+	report := newSystemPruneReport(false)
+
+	containers, err := m.client.ContainersPrune(ctx, filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range containers.ContainersDeleted {
+		report.Containers = append(report.Containers, shortImageID(id))
+	}
+	report.SpaceReclaimed += containers.SpaceReclaimed
+
+	networks, err := m.client.NetworksPrune(ctx, filters.NewArgs())
+	if err != nil {
+		return nil, err
+	}
+	report.Networks = append(report.Networks, networks.NetworksDeleted...)
+
+	if volumes {
+		vols, err := m.client.VolumesPrune(ctx, filters.NewArgs())
+		if err != nil {
+			return nil, err
+		}
+		report.Volumes = append(report.Volumes, vols.VolumesDeleted...)
+		report.SpaceReclaimed += vols.SpaceReclaimed
+	}
+
+	images, err := m.PruneImages(allImages)
+	if err != nil {
+		return nil, err
+	}
+	report.Images = append(report.Images, images.Deleted...)
+	report.SpaceReclaimed += images.SpaceReclaimed
+
+	cache, err := m.client.BuildCachePrune(ctx, build.CachePruneOptions{All: allImages})
+	if err != nil {
+		// Build cache pruning needs API 1.31+; the rest already succeeded
+		return report, nil
+	}
+	report.BuildCacheCount = len(cache.CachesDeleted)
+	report.SpaceReclaimed += cache.SpaceReclaimed
+
+	return report, nil
+}
+
+// systemPruneDryRun estimates a system prune from the daemon's disk usage
+func (m *Manager) systemPruneDryRun(allImages, volumes bool) (*SystemPruneReport, error) {
+	ctx := context.Background()
+	usage, err := m.client.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return nil, err
+	}
+	report := newSystemPruneReport(true)
+
+	// Images and volumes stay in use only through containers that survive the prune
+	keptImages := make(map[string]bool)
+	keptVolumes := make(map[string]bool)
+	for _, c := range usage.Containers {
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			keptImages[c.ImageID] = true
+			for _, mount := range c.Mounts {
+				if mount.Name != "" {
+					keptVolumes[mount.Name] = true
+				}
+			}
+			continue
+		}
+		name := shortImageID(c.ID)
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		report.Containers = append(report.Containers, name)
+		if c.SizeRw > 0 {
+			report.SpaceReclaimed += uint64(c.SizeRw)
+		}
+	}
+
+	for _, img := range usage.Images {
+		if keptImages[img.ID] {
+			continue
+		}
+		tags := []string{}
+		for _, t := range img.RepoTags {
+			if t != "<none>:<none>" {
+				tags = append(tags, t)
+			}
+		}
+		if len(tags) > 0 && !allImages {
+			continue
+		}
+		label := shortImageID(img.ID)
+		if len(tags) > 0 {
+			label = tags[0]
+		}
+		report.Images = append(report.Images, label)
+
+		// Layers shared with kept images are not freed
+		size := img.Size
+		if img.SharedSize > 0 {
+			size -= img.SharedSize
+		}
+		if size > 0 {
+			report.SpaceReclaimed += uint64(size)
+		}
+	}
+
+	if volumes {
+		for _, v := range usage.Volumes {
+			if keptVolumes[v.Name] {
+				continue
+			}
+			if _, anonymous := v.Labels[anonymousVolumeLabel]; !anonymous {
+				continue
+			}
+			report.Volumes = append(report.Volumes, v.Name)
+			if v.UsageData != nil && v.UsageData.Size > 0 {
+				report.SpaceReclaimed += uint64(v.UsageData.Size)
+			}
+		}
+	}
+
+	for _, record := range usage.BuildCache {
+		if record.InUse {
+			continue
+		}
+		report.BuildCacheCount++
+		if !record.Shared && record.Size > 0 {
+			report.SpaceReclaimed += uint64(record.Size)
+		}
+	}
+
+	// Predefined networks (bridge, host, none) are never dangling
+	networks, err := m.client.NetworkList(ctx, network.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("dangling", "true")),
+	})
+	if err == nil {
+		for _, n := range networks {
+			report.Networks = append(report.Networks, n.Name)
+		}
+	}
+
+	return report, nil
+}
+
+func newSystemPruneReport(dryRun bool) *SystemPruneReport {
+	return &SystemPruneReport{
+		DryRun:     dryRun,
+		Containers: []string{},
+		Images:     []string{},
+		Volumes:    []string{},
+		Networks:   []string{},
+	}
+}
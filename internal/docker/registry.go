@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"projecthub/internal/keychain"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// registryKeychainService is the keychain service registry credentials are stored under
+const registryKeychainService = "projecthub-docker-registry"
+
+// Docker Hub is addressed differently by the login endpoint and in image names
+const (
+	dockerHubDomain = "docker.io"
+	dockerHubServer = "https://index.docker.io/v1/"
+)
+
+// registryCredentials is the keychain payload for a registry
+type registryCredentials struct {
+	Username      string `json:"username"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identityToken,omitempty"`
+}
+
+// NormalizeRegistry returns the canonical host for a registry address
+// ("" and Docker Hub aliases become docker.io)
+func NormalizeRegistry(server string) string {
+	server = strings.TrimSpace(server)
+	server = strings.TrimPrefix(server, "https://")
+	server = strings.TrimPrefix(server, "http://")
+	server = strings.TrimSuffix(server, "/")
+	server = strings.TrimSuffix(server, "/v1")
+	switch server {
+	case "", "docker.io", "index.docker.io", "registry-1.docker.io":
+		return dockerHubDomain
+	}
+	return server
+}
+
+// RegistryForImage returns the registry host an image reference points at.
+// Like Docker, the first path component is a registry only if it looks like
+// a host (contains "." or ":" or is localhost).
+func RegistryForImage(ref string) string {
+	first, _, found := strings.Cut(ref, "/")
+	if !found {
+		return dockerHubDomain
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return NormalizeRegistry(first)
+	}
+	return dockerHubDomain
+}
+
+// RegistryLogin verifies credentials with the daemon and stores them in the
+// keychain. Returns the normalized registry host.
+func (m *Manager) RegistryLogin(server, username, password string) (string, error) {
+	host := NormalizeRegistry(server)
+	if username == "" || password == "" {
+		return "", fmt.Errorf("username and password are required")
+	}
+
+	auth := registry.AuthConfig{Username: username, Password: password, ServerAddress: authServer(host)}
+	resp, err := m.client.RegistryLogin(context.Background(), auth)
+	if err != nil {
+		return "", fmt.Errorf("login to %s failed: %w", host, err)
+	}
+
+	creds := registryCredentials{Username: username, Password: password}
+	if resp.IdentityToken != "" {
+		// Prefer the token so the password is not needed again
+		creds = registryCredentials{Username: username, IdentityToken: resp.IdentityToken}
+	}
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return "", err
+	}
+	if err := keychain.Set(registryKeychainService, host, string(data)); err != nil {
+		return "", err
+	}
+	return host, nil
+}
+
+// RegistryLogout removes the stored credentials for a registry
+func RegistryLogout(server string) error {
+	return keychain.Delete(registryKeychainService, NormalizeRegistry(server))
+}
+
+// registryAuth returns the encoded X-Registry-Auth value for a registry host,
+// or "" when no credentials are stored
+func registryAuth(host string) (string, error) {
+	secret, err := keychain.Get(registryKeychainService, host)
+	if errors.Is(err, keychain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var creds registryCredentials
+	if err := json.Unmarshal([]byte(secret), &creds); err != nil {
+		return "", fmt.Errorf("invalid stored credentials for %s", host)
+	}
+	return registry.EncodeAuthConfig(registry.AuthConfig{
+		Username:      creds.Username,
+		Password:      creds.Password,
+		IdentityToken: creds.IdentityToken,
+		ServerAddress: authServer(host),
+	})
+}
+
+// authServer is the server address the daemon expects for a registry host
+func authServer(host string) string {
+	if host == dockerHubDomain {
+		return dockerHubServer
+	}
+	return host
+}
+
+// PushImage pushes a tagged image to its registry using stored credentials,
+// reporting progress until the push completes
+func (m *Manager) PushImage(tag string, onProgress func(Progress)) error {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return fmt.Errorf("image tag is required")
+	}
+
+	auth, err := registryAuth(RegistryForImage(tag))
+	if err != nil {
+		return err
+	}
+
+	reader, err := m.client.ImagePush(context.Background(), tag, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	return readProgress(reader, tag, onProgress)
+}
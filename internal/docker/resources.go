@@ -0,0 +1,131 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ContainerResources is a container's restart policy and CPU/memory limits
+type ContainerResources struct {
+	RestartPolicy string  `json:"restartPolicy"` // no, always, on-failure, unless-stopped
+	MaxRetries    int     `json:"maxRetries"`    // on-failure only
+	CPUs          float64 `json:"cpus"`          // 0 = unlimited
+	MemoryMB      int64   `json:"memoryMb"`      // 0 = unlimited
+	HostCPUs      int     `json:"hostCpus"`      // Capacity of the daemon host
+	HostMemoryMB  int64   `json:"hostMemoryMb"`
+}
+
+const (
+	cpuPeriod   = 100000 // Default CFS period (microseconds)
+	minMemoryMB = 6      // Docker rejects memory limits below 6MB
+)
+
+// GetContainerResources reads a container's restart policy and limits
+func (m *Manager) GetContainerResources(id string) (*ContainerResources, error) {
+	ctx := context.Background()
+	info, err := m.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ContainerResources{RestartPolicy: "no"}
+	if hc := info.HostConfig; hc != nil {
+		if hc.RestartPolicy.Name != "" {
+			res.RestartPolicy = string(hc.RestartPolicy.Name)
+		}
+		res.MaxRetries = hc.RestartPolicy.MaximumRetryCount
+		switch {
+		case hc.NanoCPUs > 0:
+			res.CPUs = float64(hc.NanoCPUs) / 1e9
+		case hc.CPUQuota > 0:
+			period := hc.CPUPeriod
+			if period == 0 {
+				period = cpuPeriod
+			}
+			res.CPUs = float64(hc.CPUQuota) / float64(period)
+		}
+		res.MemoryMB = hc.Memory / (1024 * 1024)
+	}
+
+	if host, err := m.client.Info(ctx); err == nil {
+		res.HostCPUs = host.NCPU
+		res.HostMemoryMB = host.MemTotal / (1024 * 1024)
+		// A limit at (or above) host capacity is effectively no limit
+		if res.CPUs >= float64(res.HostCPUs) && res.HostCPUs > 0 {
+			res.CPUs = 0
+		}
+		if res.MemoryMB >= res.HostMemoryMB && res.HostMemoryMB > 0 {
+			res.MemoryMB = 0
+		}
+	}
+	return res, nil
+}
+
+// UpdateContainerResources changes the restart policy and limits of a container
+// in place, like `docker update`. The daemon cannot clear a limit, so
+// "unlimited" (0) is applied as the host's full capacity instead, which avoids
+// recreating the container (and losing its anonymous volumes).
+func (m *Manager) UpdateContainerResources(id string, res ContainerResources) ([]string, error) {
+	ctx := context.Background()
+
+	policy := container.RestartPolicyMode(res.RestartPolicy)
+	switch policy {
+	case container.RestartPolicyDisabled, container.RestartPolicyAlways, container.RestartPolicyUnlessStopped:
+		res.MaxRetries = 0
+	case container.RestartPolicyOnFailure:
+		if res.MaxRetries < 0 {
+			return nil, fmt.Errorf("max retries cannot be negative")
+		}
+	default:
+		return nil, fmt.Errorf("invalid restart policy: %s", res.RestartPolicy)
+	}
+	if res.CPUs < 0 || res.MemoryMB < 0 {
+		return nil, fmt.Errorf("limits cannot be negative")
+	}
+	if res.MemoryMB > 0 && res.MemoryMB < minMemoryMB {
+		return nil, fmt.Errorf("memory limit must be at least %dMB", minMemoryMB)
+	}
+
+	info, err := m.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	host, err := m.client.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cpus := res.CPUs
+	if cpus == 0 || cpus > float64(host.NCPU) {
+		cpus = float64(host.NCPU)
+	}
+	memory := res.MemoryMB * 1024 * 1024
+	if memory == 0 || memory > host.MemTotal {
+		memory = host.MemTotal
+	}
+
+	update := container.UpdateConfig{
+		RestartPolicy: container.RestartPolicy{Name: policy, MaximumRetryCount: res.MaxRetries},
+	}
+	update.Memory = memory
+	update.MemorySwap = -1 // Keep swap from capping the new memory limit
+
+	// NanoCPUs and CPU quota cannot be mixed; keep whichever the container uses
+	if hc := info.HostConfig; hc != nil && hc.CPUQuota > 0 && hc.NanoCPUs == 0 {
+		update.CPUPeriod = cpuPeriod
+		update.CPUQuota = int64(cpus * cpuPeriod)
+	} else {
+		update.NanoCPUs = int64(cpus * 1e9)
+	}
+
+	resp, err := m.client.ContainerUpdate(ctx, id, update)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Warnings == nil {
+		return []string{}, nil
+	}
+	return resp.Warnings, nil
+}
@@ -0,0 +1,53 @@
+package docker
+
+import "fmt"
+
+// URLSuggestion is a browser URL for a port published by a running container
+type URLSuggestion struct {
+	ContainerID   string `json:"containerId"`
+	ContainerName string `json:"containerName"`
+	Port          int    `json:"port"`
+	URL           string `json:"url"`
+	Label         string `json:"label"`
+}
+
+// nonHTTPPorts are well-known ports of services that don't speak HTTP
+var nonHTTPPorts = map[int]bool{
+	22:    true, // ssh
+	1433:  true, // sql server
+	2181:  true, // zookeeper
+	3306:  true, // mysql
+	5432:  true, // postgres
+	5672:  true, // rabbitmq
+	6379:  true, // redis
+	9092:  true, // kafka
+	11211: true, // memcached
+	27017: true, // mongodb
+}
+
+// SuggestURLs returns http://localhost URLs for TCP ports published by running containers
+func SuggestURLs(containers []Container) []URLSuggestion {
+	suggestions := []URLSuggestion{}
+	for _, c := range containers {
+		if c.State != "running" {
+			continue
+		}
+		for _, p := range c.PortMappings {
+			if p.HostPort == 0 || p.Protocol != "tcp" || nonHTTPPorts[p.ContainerPort] {
+				continue
+			}
+			scheme := "http"
+			if p.ContainerPort == 443 || p.ContainerPort == 8443 {
+				scheme = "https"
+			}
+			suggestions = append(suggestions, URLSuggestion{
+				ContainerID:   c.ID,
+				ContainerName: c.Name,
+				Port:          p.HostPort,
+				URL:           fmt.Sprintf("%s://localhost:%d", scheme, p.HostPort),
+				Label:         fmt.Sprintf("%s :%d", c.Name, p.HostPort),
+			})
+		}
+	}
+	return suggestions
+}
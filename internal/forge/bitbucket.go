@@ -0,0 +1,118 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// bitbucket implements Forge using the Bitbucket Cloud 2.0 API
+type bitbucket struct {
+	remote *RemoteInfo
+	token  string
+	client *http.Client
+}
+
+func (b *bitbucket) Provider() string { return ProviderBitbucket }
+
+func (b *bitbucket) repoURL() string {
+	return fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s", b.remote.FullName())
+}
+
+func (b *bitbucket) headers() map[string]string {
+	h := map[string]string{}
+	if b.token != "" {
+		h["Authorization"] = "Bearer " + b.token
+	}
+	return h
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+}
+
+func (b *bitbucket) ListMergeRequests(ctx context.Context) ([]MergeRequest, error) {
+	var page struct {
+		Values []struct {
+			ID        int    `json:"id"`
+			Title     string `json:"title"`
+			State     string `json:"state"`
+			UpdatedOn string `json:"updated_on"`
+			Author    struct {
+				DisplayName string `json:"display_name"`
+			} `json:"author"`
+			Source struct {
+				Branch struct {
+					Name string `json:"name"`
+				} `json:"branch"`
+			} `json:"source"`
+			Destination struct {
+				Branch struct {
+					Name string `json:"name"`
+				} `json:"branch"`
+			} `json:"destination"`
+			Links struct {
+				HTML bitbucketLink `json:"html"`
+			} `json:"links"`
+		} `json:"values"`
+	}
+
+	endpoint := b.repoURL() + "/pullrequests?state=OPEN&pagelen=50"
+	if err := getJSON(ctx, b.client, endpoint, b.headers(), &page); err != nil {
+		return nil, err
+	}
+
+	result := make([]MergeRequest, 0, len(page.Values))
+	for _, pr := range page.Values {
+		result = append(result, MergeRequest{
+			ID:           pr.ID,
+			Title:        pr.Title,
+			Author:       pr.Author.DisplayName,
+			SourceBranch: pr.Source.Branch.Name,
+			TargetBranch: pr.Destination.Branch.Name,
+			State:        pr.State,
+			URL:          pr.Links.HTML.Href,
+			UpdatedAt:    pr.UpdatedOn,
+		})
+	}
+	return result, nil
+}
+
+// GetPipelineStatus reads build statuses reported on the commit
+func (b *bitbucket) GetPipelineStatus(ctx context.Context, ref string) (*PipelineStatus, error) {
+	var page struct {
+		Values []struct {
+			State string `json:"state"`
+			URL   string `json:"url"`
+		} `json:"values"`
+	}
+
+	endpoint := fmt.Sprintf("%s/commit/%s/statuses?pagelen=20", b.repoURL(), url.PathEscape(ref))
+	if err := getJSON(ctx, b.client, endpoint, b.headers(), &page); err != nil {
+		return nil, err
+	}
+
+	status := &PipelineStatus{Ref: ref, Status: "unknown"}
+	if len(page.Values) == 0 {
+		return status, nil
+	}
+
+	status.Status = "success"
+	status.URL = page.Values[0].URL
+	for _, v := range page.Values {
+		switch v.State {
+		case "FAILED":
+			status.Status = "failed"
+			status.URL = v.URL
+			return status, nil
+		case "INPROGRESS":
+			status.Status = "running"
+		case "STOPPED":
+			if status.Status == "success" {
+				status.Status = "canceled"
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,98 @@
+// Package forge provides read-only access to code hosting providers
+// (GitHub, GitLab, Bitbucket) for merge request and CI pipeline data.
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// MergeRequest is a provider-neutral pull/merge request
+type MergeRequest struct {
+	ID           int    `json:"id"`
+	Title        string `json:"title"`
+	Author       string `json:"author"`
+	SourceBranch string `json:"sourceBranch"`
+	TargetBranch string `json:"targetBranch"`
+	State        string `json:"state"`
+	Draft        bool   `json:"draft"`
+	URL          string `json:"url"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+// PipelineStatus is the aggregated CI status for a commit
+type PipelineStatus struct {
+	Ref    string `json:"ref"`    // Commit SHA
+	Status string `json:"status"` // success, failed, running, pending, canceled, unknown
+	URL    string `json:"url"`
+}
+
+// Forge is implemented by each supported hosting provider
+type Forge interface {
+	Provider() string
+	ListMergeRequests(ctx context.Context) ([]MergeRequest, error)
+	// GetPipelineStatus returns the CI status for a commit SHA
+	GetPipelineStatus(ctx context.Context, ref string) (*PipelineStatus, error)
+}
+
+// tokenEnvVars lists environment variables consulted for API tokens, per provider
+var tokenEnvVars = map[string][]string{
+	ProviderGitHub:    {"GITHUB_TOKEN", "GH_TOKEN"},
+	ProviderGitLab:    {"GITLAB_TOKEN"},
+	ProviderBitbucket: {"BITBUCKET_TOKEN"},
+}
+
+// TokenFromEnv returns the API token for a provider from the environment, if set
+func TokenFromEnv(provider string) string {
+	for _, name := range tokenEnvVars[provider] {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// New returns the Forge implementation for a remote
+func New(info *RemoteInfo, token string) (Forge, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	switch info.Provider {
+	case ProviderGitHub:
+		return &gitHub{remote: info, token: token, client: client}, nil
+	case ProviderGitLab:
+		return &gitLab{remote: info, token: token, client: client}, nil
+	case ProviderBitbucket:
+		return &bitbucket{remote: info, token: token, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for host %s", info.Host)
+	}
+}
+
+// getJSON performs an authenticated GET and decodes the JSON response
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %d: %s", url, resp.StatusCode, string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,111 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// gitHub implements Forge using the GitHub REST API
+type gitHub struct {
+	remote *RemoteInfo
+	token  string
+	client *http.Client
+}
+
+func (g *gitHub) Provider() string { return ProviderGitHub }
+
+func (g *gitHub) apiBase() string {
+	if g.remote.Host == "github.com" {
+		return "https://api.github.com"
+	}
+	// GitHub Enterprise Server
+	return "https://" + g.remote.Host + "/api/v3"
+}
+
+func (g *gitHub) headers() map[string]string {
+	h := map[string]string{"Accept": "application/vnd.github+json"}
+	if g.token != "" {
+		h["Authorization"] = "Bearer " + g.token
+	}
+	return h
+}
+
+func (g *gitHub) ListMergeRequests(ctx context.Context) ([]MergeRequest, error) {
+	var pulls []struct {
+		Number    int    `json:"number"`
+		Title     string `json:"title"`
+		State     string `json:"state"`
+		Draft     bool   `json:"draft"`
+		HTMLURL   string `json:"html_url"`
+		UpdatedAt string `json:"updated_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls?state=open&per_page=50", g.apiBase(), g.remote.FullName())
+	if err := getJSON(ctx, g.client, endpoint, g.headers(), &pulls); err != nil {
+		return nil, err
+	}
+
+	result := make([]MergeRequest, 0, len(pulls))
+	for _, p := range pulls {
+		result = append(result, MergeRequest{
+			ID:           p.Number,
+			Title:        p.Title,
+			Author:       p.User.Login,
+			SourceBranch: p.Head.Ref,
+			TargetBranch: p.Base.Ref,
+			State:        p.State,
+			Draft:        p.Draft,
+			URL:          p.HTMLURL,
+			UpdatedAt:    p.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (g *gitHub) GetPipelineStatus(ctx context.Context, ref string) (*PipelineStatus, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s/check-runs", g.apiBase(), g.remote.FullName(), url.PathEscape(ref))
+	if err := getJSON(ctx, g.client, endpoint, g.headers(), &runs); err != nil {
+		return nil, err
+	}
+
+	status := &PipelineStatus{Ref: ref, Status: "unknown"}
+	if len(runs.CheckRuns) == 0 {
+		return status, nil
+	}
+
+	// Aggregate: any failure wins, then anything still running, else success
+	status.Status = "success"
+	status.URL = runs.CheckRuns[0].HTMLURL
+	for _, r := range runs.CheckRuns {
+		switch {
+		case r.Conclusion == "failure" || r.Conclusion == "timed_out":
+			status.Status = "failed"
+			status.URL = r.HTMLURL
+			return status, nil
+		case r.Status != "completed":
+			status.Status = "running"
+		case r.Conclusion == "cancelled" && status.Status == "success":
+			status.Status = "canceled"
+		}
+	}
+	return status, nil
+}
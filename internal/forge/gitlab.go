@@ -0,0 +1,99 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// gitLab implements Forge using the GitLab REST API (gitlab.com or self-hosted)
+type gitLab struct {
+	remote *RemoteInfo
+	token  string
+	client *http.Client
+}
+
+func (g *gitLab) Provider() string { return ProviderGitLab }
+
+func (g *gitLab) projectURL() string {
+	return fmt.Sprintf("https://%s/api/v4/projects/%s", g.remote.Host, url.PathEscape(g.remote.FullName()))
+}
+
+func (g *gitLab) headers() map[string]string {
+	h := map[string]string{}
+	if g.token != "" {
+		h["PRIVATE-TOKEN"] = g.token
+	}
+	return h
+}
+
+func (g *gitLab) ListMergeRequests(ctx context.Context) ([]MergeRequest, error) {
+	var mrs []struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		State        string `json:"state"`
+		Draft        bool   `json:"draft"`
+		WebURL       string `json:"web_url"`
+		UpdatedAt    string `json:"updated_at"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+		Author       struct {
+			Username string `json:"username"`
+		} `json:"author"`
+	}
+
+	endpoint := g.projectURL() + "/merge_requests?state=opened&per_page=50"
+	if err := getJSON(ctx, g.client, endpoint, g.headers(), &mrs); err != nil {
+		return nil, err
+	}
+
+	result := make([]MergeRequest, 0, len(mrs))
+	for _, mr := range mrs {
+		result = append(result, MergeRequest{
+			ID:           mr.IID,
+			Title:        mr.Title,
+			Author:       mr.Author.Username,
+			SourceBranch: mr.SourceBranch,
+			TargetBranch: mr.TargetBranch,
+			State:        mr.State,
+			Draft:        mr.Draft,
+			URL:          mr.WebURL,
+			UpdatedAt:    mr.UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (g *gitLab) GetPipelineStatus(ctx context.Context, ref string) (*PipelineStatus, error) {
+	var pipelines []struct {
+		Status string `json:"status"`
+		WebURL string `json:"web_url"`
+	}
+
+	endpoint := g.projectURL() + "/pipelines?per_page=1&sha=" + url.QueryEscape(ref)
+	if err := getJSON(ctx, g.client, endpoint, g.headers(), &pipelines); err != nil {
+		return nil, err
+	}
+
+	status := &PipelineStatus{Ref: ref, Status: "unknown"}
+	if len(pipelines) == 0 {
+		return status, nil
+	}
+
+	status.URL = pipelines[0].WebURL
+	switch pipelines[0].Status {
+	case "success":
+		status.Status = "success"
+	case "failed":
+		status.Status = "failed"
+	case "running":
+		status.Status = "running"
+	case "canceled", "skipped":
+		status.Status = "canceled"
+	default:
+		// created, pending, preparing, scheduled, manual, waiting_for_resource
+		status.Status = "pending"
+	}
+	return status, nil
+}
@@ -0,0 +1,88 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Provider identifiers
+const (
+	ProviderGitHub    = "github"
+	ProviderGitLab    = "gitlab"
+	ProviderBitbucket = "bitbucket"
+	ProviderUnknown   = "unknown"
+)
+
+// RemoteInfo describes a parsed git remote URL
+type RemoteInfo struct {
+	Provider string `json:"provider"`
+	Host     string `json:"host"`
+	Owner    string `json:"owner"` // User, organization, workspace or GitLab group path (may contain "/")
+	Repo     string `json:"repo"`
+	WebURL   string `json:"webUrl"`
+}
+
+// FullName returns "owner/repo"
+func (r *RemoteInfo) FullName() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// ParseRemoteURL parses SSH, scp-like and HTTPS remote URLs.
+// Self-hosted GitLab instances are recognized when the host contains "gitlab".
+func ParseRemoteURL(raw string) (*RemoteInfo, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("empty remote URL")
+	}
+
+	var host, path string
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remote URL: %w", err)
+		}
+		host = u.Hostname()
+		path = u.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		at := strings.Index(raw, "@")
+		colon := strings.Index(raw, ":")
+		if colon < 0 || colon < at {
+			return nil, fmt.Errorf("unsupported remote URL: %s", raw)
+		}
+		host = raw[at+1 : colon]
+		path = raw[colon+1:]
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	idx := strings.LastIndex(path, "/")
+	if host == "" || idx <= 0 || idx == len(path)-1 {
+		return nil, fmt.Errorf("unsupported remote URL: %s", raw)
+	}
+
+	info := &RemoteInfo{
+		Provider: detectProvider(host),
+		Host:     strings.ToLower(host),
+		Owner:    path[:idx],
+		Repo:     path[idx+1:],
+	}
+	info.WebURL = "https://" + info.Host + "/" + info.FullName()
+	return info, nil
+}
+
+// detectProvider maps a remote host to a provider identifier
+func detectProvider(host string) string {
+	host = strings.ToLower(host)
+	switch {
+	case host == "github.com" || strings.HasSuffix(host, ".github.com"):
+		return ProviderGitHub
+	case host == "bitbucket.org":
+		return ProviderBitbucket
+	case strings.Contains(host, "gitlab"):
+		return ProviderGitLab
+	default:
+		return ProviderUnknown
+	}
+}
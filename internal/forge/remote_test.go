@@ -0,0 +1,98 @@
+package forge
+
+import "testing"
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		provider string
+		host     string
+		owner    string
+		repo     string
+		wantErr  bool
+	}{
+		{
+			name:     "github scp-like",
+			url:      "git@github.com:kmxsoftware/claudilandia.git",
+			provider: ProviderGitHub,
+			host:     "github.com",
+			owner:    "kmxsoftware",
+			repo:     "claudilandia",
+		},
+		{
+			name:     "github https without suffix",
+			url:      "https://github.com/kmxsoftware/claudilandia",
+			provider: ProviderGitHub,
+			host:     "github.com",
+			owner:    "kmxsoftware",
+			repo:     "claudilandia",
+		},
+		{
+			name:     "gitlab subgroup over ssh scheme",
+			url:      "ssh://git@gitlab.com:22/group/sub/project.git",
+			provider: ProviderGitLab,
+			host:     "gitlab.com",
+			owner:    "group/sub",
+			repo:     "project",
+		},
+		{
+			name:     "self-hosted gitlab",
+			url:      "https://gitlab.example.com/team/app.git",
+			provider: ProviderGitLab,
+			host:     "gitlab.example.com",
+			owner:    "team",
+			repo:     "app",
+		},
+		{
+			name:     "bitbucket https with user",
+			url:      "https://user@bitbucket.org/workspace/repo.git",
+			provider: ProviderBitbucket,
+			host:     "bitbucket.org",
+			owner:    "workspace",
+			repo:     "repo",
+		},
+		{
+			name:     "unknown host",
+			url:      "git@git.example.com:team/app.git",
+			provider: ProviderUnknown,
+			host:     "git.example.com",
+			owner:    "team",
+			repo:     "app",
+		},
+		{
+			name:    "missing owner",
+			url:     "https://github.com/claudilandia",
+			wantErr: true,
+		},
+		{
+			name:    "local path",
+			url:     "/srv/git/repo.git",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			url:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRemoteURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRemoteURL(%q) expected error, got %+v", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRemoteURL(%q) unexpected error: %v", tt.url, err)
+			}
+			if got.Provider != tt.provider || got.Host != tt.host || got.Owner != tt.owner || got.Repo != tt.repo {
+				t.Errorf("ParseRemoteURL(%q) = %+v, want provider=%s host=%s owner=%s repo=%s",
+					tt.url, got, tt.provider, tt.host, tt.owner, tt.repo)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BisectStatus describes the state of a bisect session
+type BisectStatus struct {
+	Active         bool     `json:"active"`
+	Current        string   `json:"current"`        // Commit currently checked out for testing
+	CurrentSubject string   `json:"currentSubject"` // Subject of the current commit
+	Remaining      int      `json:"remaining"`      // Revisions left to test
+	StepsLeft      int      `json:"stepsLeft"`      // Rough number of steps left
+	Culprit        string   `json:"culprit"`        // First bad commit, once found
+	CulpritSubject string   `json:"culpritSubject"`
+	Log            []string `json:"log"` // git bisect log
+}
+
+// BisectStep reports one automated bisect iteration
+type BisectStep struct {
+	Commit   string        `json:"commit"`
+	Verdict  string        `json:"verdict"` // good, bad or skip
+	ExitCode int           `json:"exitCode"`
+	Output   string        `json:"output"`
+	Status   *BisectStatus `json:"status"`
+}
+
+var (
+	bisectRemainingRe = regexp.MustCompile(`Bisecting: (\d+) revisions? left to test after this \(roughly (\d+) steps?\)`)
+	bisectCurrentRe   = regexp.MustCompile(`(?m)^\[([0-9a-f]{7,40})\] (.*)$`)
+	bisectCulpritRe   = regexp.MustCompile(`(?m)^([0-9a-f]{7,40}) is the first bad commit`)
+)
+
+// StartBisect begins a bisect session between a bad and a good revision
+func (m *Manager) StartBisect(repoPath, bad, good string) (*BisectStatus, error) {
+	if bad == "" {
+		bad = "HEAD"
+	}
+	if good == "" {
+		return nil, fmt.Errorf("a known good revision is required")
+	}
+	for _, rev := range []string{bad, good} {
+		if m.revParse(repoPath, rev+"^{commit}") == "" {
+			return nil, fmt.Errorf("unknown revision: %s", rev)
+		}
+	}
+
+	output, err := exec.Command("git", "-C", repoPath, "bisect", "start", bad, good).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git bisect start failed: %s", strings.TrimSpace(string(output)))
+	}
+	return m.bisectStatusFromOutput(repoPath, string(output)), nil
+}
+
+// MarkBisect marks the current commit as good, bad or skip
+func (m *Manager) MarkBisect(repoPath, verdict string) (*BisectStatus, error) {
+	switch verdict {
+	case "good", "bad", "skip":
+	default:
+		return nil, fmt.Errorf("invalid bisect verdict: %s", verdict)
+	}
+	if !m.IsBisecting(repoPath) {
+		return nil, fmt.Errorf("no bisect in progress")
+	}
+
+	output, err := exec.Command("git", "-C", repoPath, "bisect", verdict).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git bisect %s failed: %s", verdict, strings.TrimSpace(string(output)))
+	}
+	return m.bisectStatusFromOutput(repoPath, string(output)), nil
+}
+
+// ResetBisect ends the bisect session and returns to the original branch
+func (m *Manager) ResetBisect(repoPath string) error {
+	output, err := exec.Command("git", "-C", repoPath, "bisect", "reset").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git bisect reset failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// IsBisecting reports whether a bisect session is in progress
+func (m *Manager) IsBisecting(repoPath string) bool {
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "--git-path", "BISECT_LOG").Output()
+	if err != nil {
+		return false
+	}
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// GetBisectStatus returns the state of the current bisect session
+func (m *Manager) GetBisectStatus(repoPath string) *BisectStatus {
+	status := &BisectStatus{Log: []string{}}
+	if !m.IsBisecting(repoPath) {
+		return status
+	}
+
+	status.Active = true
+	status.Current = m.revParse(repoPath, "HEAD")
+	status.CurrentSubject = m.commitSubject(repoPath, status.Current)
+	status.Log = m.bisectLog(repoPath)
+
+	// Culprit is recorded in the log once found
+	for _, line := range status.Log {
+		if strings.HasPrefix(line, "# first bad commit: [") {
+			rest := strings.TrimPrefix(line, "# first bad commit: [")
+			hash, subject, _ := strings.Cut(rest, "] ")
+			status.Culprit = hash
+			status.CulpritSubject = subject
+		}
+	}
+
+	return status
+}
+
+// RunBisect automates the session: the command is run on each candidate and its
+// exit code decides the verdict (0 good, 125 skip, anything else bad), like
+// `git bisect run`. onStep is called after every iteration.
+func (m *Manager) RunBisect(ctx context.Context, repoPath, command string, onStep func(BisectStep)) (*BisectStatus, error) {
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("test command is required")
+	}
+	if !m.IsBisecting(repoPath) {
+		return nil, fmt.Errorf("no bisect in progress")
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return m.GetBisectStatus(repoPath), err
+		}
+
+		commit := m.revParse(repoPath, "HEAD")
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Dir = repoPath
+		output, err := cmd.CombinedOutput()
+		if ctx.Err() != nil {
+			return m.GetBisectStatus(repoPath), ctx.Err()
+		}
+
+		exitCode := 0
+		if err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				return nil, fmt.Errorf("failed to run test command: %w", err)
+			}
+			exitCode = exitErr.ExitCode()
+		}
+
+		verdict := "bad"
+		switch {
+		case exitCode == 0:
+			verdict = "good"
+		case exitCode == 125:
+			verdict = "skip"
+		case exitCode >= 128 || exitCode < 0:
+			// Killed by a signal: treat like `git bisect run` and stop
+			return m.GetBisectStatus(repoPath), fmt.Errorf("test command aborted with exit code %d", exitCode)
+		}
+
+		status, err := m.MarkBisect(repoPath, verdict)
+		if err != nil {
+			return nil, err
+		}
+
+		if onStep != nil {
+			onStep(BisectStep{
+				Commit:   commit,
+				Verdict:  verdict,
+				ExitCode: exitCode,
+				Output:   string(output),
+				Status:   status,
+			})
+		}
+
+		if status.Culprit != "" {
+			return status, nil
+		}
+		if status.Current == "" || status.Current == commit {
+			// Only skipped commits left; git cannot narrow it down further
+			return status, fmt.Errorf("bisect cannot continue: only skipped commits remain")
+		}
+	}
+}
+
+// bisectStatusFromOutput builds a status from the output of a bisect command
+func (m *Manager) bisectStatusFromOutput(repoPath, output string) *BisectStatus {
+	status := &BisectStatus{Active: true, Log: m.bisectLog(repoPath)}
+
+	if match := bisectCulpritRe.FindStringSubmatch(output); match != nil {
+		status.Culprit = match[1]
+		status.CulpritSubject = m.commitSubject(repoPath, match[1])
+		return status
+	}
+	if match := bisectRemainingRe.FindStringSubmatch(output); match != nil {
+		status.Remaining, _ = strconv.Atoi(match[1])
+		status.StepsLeft, _ = strconv.Atoi(match[2])
+	}
+	if match := bisectCurrentRe.FindStringSubmatch(output); match != nil {
+		status.Current = match[1]
+		status.CurrentSubject = match[2]
+	} else {
+		status.Current = m.revParse(repoPath, "HEAD")
+		status.CurrentSubject = m.commitSubject(repoPath, status.Current)
+	}
+	return status
+}
+
+// bisectLog returns the lines of `git bisect log`
+func (m *Manager) bisectLog(repoPath string) []string {
+	lines := []string{}
+	output, err := exec.Command("git", "-C", repoPath, "bisect", "log").Output()
+	if err != nil {
+		return lines
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// commitSubject returns the subject line of a commit
+func (m *Manager) commitSubject(repoPath, hash string) string {
+	output, err := exec.Command("git", "-C", repoPath, "log", "-1", "--format=%s", hash).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
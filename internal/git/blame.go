@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// BlameAuthors returns the author of each of the given lines of a file, by
+// line number. Lines not committed yet, and files git doesn't track, are left
+// out.
+func (m *Manager) BlameAuthors(repoPath, filePath string, lines []int) map[int]string {
+	authors := make(map[int]string)
+	if len(lines) == 0 {
+		return authors
+	}
+
+	args := []string{"-C", repoPath, "blame", "--line-porcelain"}
+	for _, line := range lines {
+		args = append(args, "-L", fmt.Sprintf("%d,%d", line, line))
+	}
+	args = append(args, "--", filePath)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return authors
+	}
+
+	// Each line starts with "<hash> <original line> <final line> [<count>]",
+	// followed by "key value" headers
+	current, uncommitted := 0, false
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && len(fields[0]) == 40 && isHex(fields[0]) {
+			current, _ = strconv.Atoi(fields[2])
+			uncommitted = strings.Trim(fields[0], "0") == ""
+			continue
+		}
+		if author, ok := strings.CutPrefix(line, "author "); ok && current > 0 && !uncommitted {
+			authors[current] = author
+		}
+	}
+	return authors
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BranchChanges are the lines added or modified since the current branch
+// forked from a base branch, including uncommitted and untracked files
+type BranchChanges struct {
+	Base      string             `json:"base"`
+	MergeBase string             `json:"mergeBase"`
+	Files     []BranchFileChange `json:"files"`
+}
+
+// BranchFileChange lists the changed lines of one file
+type BranchFileChange struct {
+	Path  string `json:"path"`  // Relative to the path the changes were asked for
+	Lines []int  `json:"lines"` // Line numbers in the current version of the file
+}
+
+// hunkHeaderPattern matches "@@ -12,3 +14,5 @@"; the counts default to 1
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// DefaultBaseBranch guesses the branch work is merged into: the remote's
+// HEAD, else main or master, locally or on origin. Empty when none exists.
+func (m *Manager) DefaultBaseBranch(repoPath string) string {
+	if output, err := exec.Command("git", "-C", repoPath, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output(); err == nil {
+		if ref := strings.TrimSpace(string(output)); ref != "" {
+			return ref
+		}
+	}
+	for _, candidate := range []string{"main", "master", "origin/main", "origin/master"} {
+		if m.revParse(repoPath, candidate) != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// GetBranchChanges returns the lines changed in path (a repository or a
+// directory in one) since the merge base of HEAD and base. Paths are relative
+// to path and only files below it are included.
+func (m *Manager) GetBranchChanges(path, base string) (*BranchChanges, error) {
+	if base == "" {
+		return nil, fmt.Errorf("base branch is required")
+	}
+	output, err := exec.Command("git", "-C", path, "merge-base", "HEAD", base).Output()
+	if err != nil {
+		return nil, fmt.Errorf("no common ancestor with %s", base)
+	}
+	changes := &BranchChanges{
+		Base:      base,
+		MergeBase: strings.TrimSpace(string(output)),
+		Files:     []BranchFileChange{},
+	}
+
+	// The working tree against the merge base covers commits and uncommitted edits alike
+	diff, err := exec.Command("git", "-C", path, "diff", "--unified=0", "--no-color", "--no-ext-diff",
+		"--relative", changes.MergeBase, "--").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+	changes.Files = append(changes.Files, parseChangedLines(diff)...)
+
+	untracked, _ := exec.Command("git", "-C", path, "ls-files", "--others", "--exclude-standard", "-z").Output()
+	for _, name := range strings.Split(string(untracked), "\x00") {
+		if name == "" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(path, name))
+		if err != nil || isBinaryContent(content) {
+			continue
+		}
+		lines := bytes.Count(content, []byte("\n"))
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			lines++
+		}
+		change := BranchFileChange{Path: filepath.ToSlash(name), Lines: make([]int, lines)}
+		for i := range change.Lines {
+			change.Lines[i] = i + 1
+		}
+		changes.Files = append(changes.Files, change)
+	}
+	return changes, nil
+}
+
+// parseChangedLines reads the added line numbers of each file from a
+// zero-context unified diff
+func parseChangedLines(diff []byte) []BranchFileChange {
+	var files []BranchFileChange
+	current := -1 // Index of the file the hunks belong to
+
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			current = -1
+		case strings.HasPrefix(line, "+++ ") && current < 0:
+			// File header; inside hunks the same prefix is an added "++" line
+			name := strings.TrimPrefix(line, "+++ ")
+			if name == "/dev/null" {
+				continue // Deleted file
+			}
+			files = append(files, BranchFileChange{Path: strings.TrimPrefix(name, "b/"), Lines: []int{}})
+			current = len(files) - 1
+		default:
+			match := hunkHeaderPattern.FindStringSubmatch(line)
+			if match == nil || current < 0 {
+				continue
+			}
+			start, _ := strconv.Atoi(match[1])
+			count := 1
+			if match[2] != "" {
+				count, _ = strconv.Atoi(match[2])
+			}
+			for i := 0; i < count; i++ {
+				files[current].Lines = append(files[current].Lines, start+i)
+			}
+		}
+	}
+	return files
+}
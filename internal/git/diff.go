@@ -0,0 +1,166 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// MaxDiffBytes is the largest diff or file content returned in one piece by GetFileDiff
+const MaxDiffBytes = 1 << 20 // 1 MB
+
+// binarySniffLen is how many leading bytes are inspected for NUL characters
+const binarySniffLen = 8000
+
+// DiffChunk is a window of lines from a file's unified diff
+type DiffChunk struct {
+	Path       string   `json:"path"`
+	Offset     int      `json:"offset"`
+	Lines      []string `json:"lines"`
+	TotalLines int      `json:"totalLines"`
+	HasMore    bool     `json:"hasMore"`
+	IsBinary   bool     `json:"isBinary"`
+}
+
+// GetFileDiffChunk returns up to limit lines of the file's diff starting at offset.
+// The diff is streamed from git so only the requested window is kept in memory.
+func (m *Manager) GetFileDiffChunk(repoPath, filePath string, offset, limit int) (*DiffChunk, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	chunk := &DiffChunk{
+		Path:   filePath,
+		Offset: offset,
+		Lines:  []string{},
+	}
+
+	if m.isBinaryChange(repoPath, filePath) {
+		chunk.IsBinary = true
+		return chunk, nil
+	}
+
+	// Prefer unstaged changes, fall back to staged (same order as GetFileDiff).
+	// An untracked file has no index entry to diff against, so it is shown
+	// as added in full.
+	untracked := m.isUntracked(repoPath, filePath)
+	args := []string{"-C", repoPath, "diff", "--", filePath}
+	switch {
+	case untracked:
+		args = []string{"-C", repoPath, "diff", "--no-index", "--", "/dev/null", filePath}
+	case !m.hasDiff(repoPath, false, filePath):
+		args = []string{"-C", repoPath, "diff", "--cached", "--", filePath}
+	}
+
+	cmd := exec.Command("git", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git diff: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		if line >= offset && line < offset+limit {
+			chunk.Lines = append(chunk.Lines, scanner.Text())
+		}
+		line++
+	}
+	scanErr := scanner.Err()
+	if err := cmd.Wait(); err != nil && scanErr == nil {
+		// --no-index exits with 1 when the files differ
+		var exitErr *exec.ExitError
+		if !untracked || !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, fmt.Errorf("git diff failed: %w", err)
+		}
+	}
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	chunk.TotalLines = line
+	chunk.HasMore = offset+len(chunk.Lines) < line
+	return chunk, nil
+}
+
+// limitedOutput runs git and returns its output, or reports it truncated
+// once there is more than limit bytes of it. git is killed then, so a huge
+// diff or blob is never held in memory. Like Output, whatever was written
+// is returned when git fails.
+func limitedOutput(limit int, args ...string) ([]byte, bool) {
+	cmd := exec.Command("git", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, false
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, false
+	}
+
+	output, _ := io.ReadAll(io.LimitReader(stdout, int64(limit)+1))
+	if len(output) > limit {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, true
+	}
+	cmd.Wait()
+	return output, false
+}
+
+// hasDiff reports whether the file has unstaged (or staged) changes
+func (m *Manager) hasDiff(repoPath string, cached bool, filePath string) bool {
+	args := []string{"-C", repoPath, "diff", "--quiet"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	args = append(args, "--", filePath)
+	// --quiet exits with 1 when there are differences
+	return exec.Command("git", args...).Run() != nil
+}
+
+// isUntracked reports whether the file is new and not yet added to the index
+func (m *Manager) isUntracked(repoPath, filePath string) bool {
+	output, err := exec.Command("git", "-C", repoPath, "ls-files", "--others", "--exclude-standard", "--", filePath).Output()
+	return err == nil && len(bytes.TrimSpace(output)) > 0
+}
+
+// isBinaryChange uses git's own binary detection via --numstat ("-\t-\tpath")
+func (m *Manager) isBinaryChange(repoPath, filePath string) bool {
+	if m.isUntracked(repoPath, filePath) {
+		// --no-index exits with 1 when the files differ, with the numstat printed
+		output, _ := exec.Command("git", "-C", repoPath, "diff", "--no-index", "--numstat", "--", "/dev/null", filePath).Output()
+		return strings.HasPrefix(string(output), "-\t-\t")
+	}
+	for _, cached := range []bool{false, true} {
+		args := []string{"-C", repoPath, "diff", "--numstat"}
+		if cached {
+			args = append(args, "--cached")
+		}
+		args = append(args, "--", filePath)
+		output, err := exec.Command("git", args...).Output()
+		if err != nil || len(output) == 0 {
+			continue
+		}
+		return strings.HasPrefix(string(output), "-\t-\t")
+	}
+	return false
+}
+
+// isBinaryContent reports whether data looks binary (contains a NUL byte near the start)
+func isBinaryContent(data []byte) bool {
+	if len(data) > binarySniffLen {
+		data = data[:binarySniffLen]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
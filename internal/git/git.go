@@ -1,7 +1,10 @@
 package git
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -20,6 +23,8 @@ type FileDiff struct {
 	OldContent  string `json:"oldContent"`
 	NewContent  string `json:"newContent"`
 	DiffContent string `json:"diffContent"`
+	IsBinary    bool   `json:"isBinary"`
+	Truncated   bool   `json:"truncated"` // Content exceeded the size guard; use GetFileDiffChunk
 }
 
 // Manager handles git operations
@@ -108,34 +113,58 @@ func (m *Manager) GetChangedFiles(path string) ([]ChangedFile, error) {
 	return files, nil
 }
 
-// GetFileDiff returns the diff for a specific file
+// GetFileDiff returns the diff for a specific file.
+// Binary files are reported without content and anything larger than
+// MaxDiffBytes is dropped so huge generated files don't block the UI.
 func (m *Manager) GetFileDiff(repoPath, filePath string) (*FileDiff, error) {
 	diff := &FileDiff{
 		Path: filePath,
 	}
 
-	// Get the diff content
-	diffCmd := exec.Command("git", "-C", repoPath, "diff", "--", filePath)
-	diffOutput, _ := diffCmd.Output()
+	if m.isBinaryChange(repoPath, filePath) {
+		diff.IsBinary = true
+		return diff, nil
+	}
+
+	// Get the diff content, read no further than MaxDiffBytes
+	diffOutput, truncated := limitedOutput(MaxDiffBytes, "-C", repoPath, "diff", "--", filePath)
 
 	// If no unstaged diff, check staged
-	if len(diffOutput) == 0 {
-		diffCmd = exec.Command("git", "-C", repoPath, "diff", "--cached", "--", filePath)
-		diffOutput, _ = diffCmd.Output()
+	if len(diffOutput) == 0 && !truncated {
+		diffOutput, truncated = limitedOutput(MaxDiffBytes, "-C", repoPath, "diff", "--cached", "--", filePath)
 	}
 
+	if truncated {
+		diff.Truncated = true
+		return diff, nil
+	}
 	diff.DiffContent = string(diffOutput)
 
 	// Get old content (HEAD version)
-	oldCmd := exec.Command("git", "-C", repoPath, "show", "HEAD:"+filePath)
-	oldOutput, _ := oldCmd.Output()
-	diff.OldContent = string(oldOutput)
+	oldOutput, oldTruncated := limitedOutput(MaxDiffBytes, "-C", repoPath, "show", "HEAD:"+filePath)
 
 	// Get new content (working directory)
 	fullPath := filepath.Join(repoPath, filePath)
-	newCmd := exec.Command("cat", fullPath)
-	newOutput, _ := newCmd.Output()
-	diff.NewContent = string(newOutput)
+	var newOutput []byte
+	if info, err := os.Stat(fullPath); err == nil && info.Size() <= MaxDiffBytes {
+		newOutput, _ = os.ReadFile(fullPath)
+	} else if err == nil {
+		diff.Truncated = true
+	}
+
+	if oldTruncated {
+		diff.Truncated = true
+	}
+	if isBinaryContent(oldOutput) || isBinaryContent(newOutput) {
+		diff.IsBinary = true
+		diff.DiffContent = ""
+		return diff, nil
+	}
+
+	if !diff.Truncated {
+		diff.OldContent = string(oldOutput)
+		diff.NewContent = string(newOutput)
+	}
 
 	return diff, nil
 }
@@ -314,3 +343,51 @@ func (m *Manager) getCommitDetails(repoPath, hash string) ([]CommitFile, CommitS
 
 	return files, stats
 }
+
+// GetRemoteURL returns the URL of the named remote ("origin" when empty)
+func (m *Manager) GetRemoteURL(repoPath, remote string) (string, error) {
+	if remote == "" {
+		remote = "origin"
+	}
+	output, err := exec.Command("git", "-C", repoPath, "remote", "get-url", remote).Output()
+	if err != nil {
+		return "", fmt.Errorf("remote %q not found", remote)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetHeadHash returns the full hash of HEAD
+func (m *Manager) GetHeadHash(repoPath string) string {
+	return m.revParse(repoPath, "HEAD")
+}
+
+// GetWorkTreeVersion identifies the code in the working tree: HEAD plus a
+// hash of the uncommitted changes and untracked files. Two calls return the
+// same value only if no code changed in between. Empty outside a repository.
+func (m *Manager) GetWorkTreeVersion(repoPath string) string {
+	head := m.GetHeadHash(repoPath)
+	if head == "" {
+		return ""
+	}
+	diff, err := exec.Command("git", "-C", repoPath, "diff", "HEAD", "--binary").Output()
+	if err != nil {
+		return head
+	}
+	untracked, _ := exec.Command("git", "-C", repoPath, "ls-files", "--others", "--exclude-standard", "-z").Output()
+	if len(diff) == 0 && len(untracked) == 0 {
+		return head
+	}
+
+	h := sha256.New()
+	h.Write(diff)
+	for _, name := range strings.Split(string(untracked), "\x00") {
+		if name == "" {
+			continue
+		}
+		h.Write([]byte(name))
+		if content, err := os.ReadFile(filepath.Join(repoPath, name)); err == nil {
+			h.Write(content)
+		}
+	}
+	return head + "+" + hex.EncodeToString(h.Sum(nil))[:12]
+}
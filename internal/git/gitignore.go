@@ -0,0 +1,206 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GitignoreEntry represents a single line of a .gitignore file
+type GitignoreEntry struct {
+	Line    int    `json:"line"`
+	Pattern string `json:"pattern"`
+	Negated bool   `json:"negated"`
+	Comment bool   `json:"comment"`
+	Blank   bool   `json:"blank"`
+}
+
+// IgnoreCheck represents the result of `git check-ignore` for a path
+type IgnoreCheck struct {
+	Path    string `json:"path"`
+	Ignored bool   `json:"ignored"`
+	Source  string `json:"source,omitempty"`  // File containing the matching rule
+	Line    int    `json:"line,omitempty"`    // Line number of the matching rule
+	Pattern string `json:"pattern,omitempty"` // The matching rule itself
+}
+
+// GetGitignore returns the parsed entries of the repository's root .gitignore.
+// A missing file yields an empty list.
+func (m *Manager) GetGitignore(repoPath string) ([]GitignoreEntry, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, ".gitignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []GitignoreEntry{}, nil
+		}
+		return nil, err
+	}
+
+	entries := []GitignoreEntry{}
+	content := strings.TrimSuffix(string(data), "\n")
+	if content == "" {
+		return entries, nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		entry := GitignoreEntry{Line: i + 1, Pattern: line}
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			entry.Blank = true
+		case strings.HasPrefix(trimmed, "#"):
+			entry.Comment = true
+		case strings.HasPrefix(trimmed, "!"):
+			entry.Negated = true
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// escapedChar matches a backslash and the character it escapes
+var escapedChar = regexp.MustCompile(`\\.`)
+
+// ValidateGitignorePattern checks that a pattern can be safely written to .gitignore
+func ValidateGitignorePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("pattern is empty")
+	}
+	if strings.ContainsAny(pattern, "\r\n") {
+		return errors.New("pattern must be a single line")
+	}
+	if strings.HasPrefix(pattern, "#") {
+		return errors.New("pattern starts with '#' and would be treated as a comment (escape it as '\\#')")
+	}
+	// Escaped characters are literal, so the checks below look past them
+	plain := escapedChar.ReplaceAllString(pattern, "_")
+	if strings.HasSuffix(plain, "\\") {
+		return errors.New("pattern ends with a lone '\\' and matches nothing")
+	}
+	if strings.HasSuffix(plain, " ") {
+		return errors.New("trailing spaces are ignored by git unless escaped with '\\'")
+	}
+	if pattern == "!" || pattern == "/" {
+		return fmt.Errorf("pattern %q matches nothing", pattern)
+	}
+	for _, part := range strings.Split(plain, "/") {
+		if strings.Contains(part, "**") && part != "**" {
+			return errors.New("'**' must be a whole path component (e.g. 'a/**/b')")
+		}
+	}
+	if strings.Count(plain, "[") != strings.Count(plain, "]") {
+		return errors.New("unbalanced brackets in pattern")
+	}
+	return nil
+}
+
+// AppendGitignore appends patterns to the repository's root .gitignore,
+// creating the file if needed. Patterns already present are skipped.
+// Returns the patterns that were actually added.
+func (m *Manager) AppendGitignore(repoPath string, patterns []string) ([]string, error) {
+	for _, p := range patterns {
+		if err := ValidateGitignorePattern(p); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+
+	existing, err := m.GetGitignore(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, e := range existing {
+		seen[strings.TrimSpace(e.Pattern)] = true
+	}
+
+	added := []string{}
+	for _, p := range patterns {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		added = append(added, p)
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	path := filepath.Join(repoPath, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		sb.WriteString("\n")
+	}
+	for _, p := range added {
+		sb.WriteString(p)
+		sb.WriteString("\n")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(sb.String()); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// IgnoreFile adds an anchored rule for a single repository-relative file
+func (m *Manager) IgnoreFile(repoPath, filePath string) error {
+	rel := filepath.ToSlash(filepath.Clean(filePath))
+	if rel == "." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("path %q is outside the repository", filePath)
+	}
+	pattern := "/" + strings.TrimPrefix(rel, "/")
+	// Escape glob characters, and the escape character itself, so only this
+	// exact file matches
+	pattern = strings.NewReplacer("\\", "\\\\", "*", "\\*", "?", "\\?", "[", "\\[").Replace(pattern)
+	if strings.HasSuffix(pattern, " ") {
+		pattern = strings.TrimSuffix(pattern, " ") + "\\ "
+	}
+	_, err := m.AppendGitignore(repoPath, []string{pattern})
+	return err
+}
+
+// CheckIgnore reports whether a path is ignored and which rule matches it
+func (m *Manager) CheckIgnore(repoPath, path string) (*IgnoreCheck, error) {
+	result := &IgnoreCheck{Path: path}
+
+	cmd := exec.Command("git", "-C", repoPath, "check-ignore", "-v", "--", path)
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			// Exit code 1 means the path is not ignored
+			return result, nil
+		}
+		return nil, fmt.Errorf("git check-ignore failed: %w", err)
+	}
+
+	// Format: <source>:<line>:<pattern><TAB><path>
+	line := strings.TrimRight(string(output), "\n")
+	info, _, _ := strings.Cut(line, "\t")
+	parts := strings.SplitN(info, ":", 3)
+	if len(parts) == 3 {
+		result.Source = parts[0]
+		result.Line, _ = strconv.Atoi(parts[1])
+		result.Pattern = parts[2]
+	}
+	// A matching negated rule means the path is explicitly re-included
+	result.Ignored = !strings.HasPrefix(result.Pattern, "!")
+
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateGitignorePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{"*.log", true},
+		{"/build/", true},
+		{"a/**/b", true},
+		{"[abc].txt", true},
+		{`/a\[.txt`, true},
+		{`/a\].txt`, true},
+		{`/a\\\[b.txt`, true},
+		{`\#notes`, true},
+		{`/a\ `, true},
+		{`/a\**`, true},
+		{"", false},
+		{"#notes", false},
+		{"/a ", false},
+		{`/a\\ `, false},
+		{`/a\`, false},
+		{"a**", false},
+		{"/a[.txt", false},
+		{`/a[\].txt`, false},
+		{"a\nb", false},
+	}
+	for _, tt := range tests {
+		err := ValidateGitignorePattern(tt.pattern)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateGitignorePattern(%q) = %v, want valid %v", tt.pattern, err, tt.valid)
+		}
+	}
+}
+
+func TestIgnoreFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	tests := []struct {
+		file    string
+		pattern string
+		other   string // a file the rule must not match
+	}{
+		{"plain.txt", "/plain.txt", "plain.txt.bak"},
+		{"a[.txt", `/a\[.txt`, "a.txt"},
+		{"star*.txt", `/star\*.txt`, "starry.txt"},
+		{"what?.txt", `/what\?.txt`, "whats.txt"},
+		{`back\slash.txt`, `/back\\slash.txt`, "backslash.txt"},
+		{`back\*.txt`, `/back\\\*.txt`, `back\x.txt`},
+		{"dir/trailing ", `/dir/trailing\ `, "dir/trailing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			repo := t.TempDir()
+			if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+				t.Fatalf("git init: %v: %s", err, out)
+			}
+
+			m := NewManager()
+			if err := m.IgnoreFile(repo, tt.file); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(filepath.Join(repo, ".gitignore"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSuffix(string(data), "\n"); got != tt.pattern {
+				t.Errorf("rule = %q, want %q", got, tt.pattern)
+			}
+
+			if check, err := m.CheckIgnore(repo, tt.file); err != nil || !check.Ignored {
+				t.Errorf("%q is not ignored: %+v, %v", tt.file, check, err)
+			}
+			if check, err := m.CheckIgnore(repo, tt.other); err != nil || check.Ignored {
+				t.Errorf("%q is ignored too: %+v, %v", tt.other, check, err)
+			}
+		})
+	}
+}
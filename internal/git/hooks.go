@@ -0,0 +1,342 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GitHook represents a hook that runs on a git event
+type GitHook struct {
+	Name     string   `json:"name"`     // Git event, e.g. "pre-commit"
+	Path     string   `json:"path"`     // File defining the hook
+	Source   string   `json:"source"`   // "git", "husky", "lefthook" or "pre-commit"
+	Enabled  bool     `json:"enabled"`  // False for samples and non-executable scripts
+	Commands []string `json:"commands"` // What will run, as far as can be determined
+}
+
+// HooksInfo summarizes the hook setup of a repository
+type HooksInfo struct {
+	HooksPath string    `json:"hooksPath"` // Effective hooks directory (respects core.hooksPath)
+	Managers  []string  `json:"managers"`  // Detected hook managers
+	Hooks     []GitHook `json:"hooks"`
+}
+
+// HookTemplate is a predefined hook script that can be installed
+type HookTemplate struct {
+	ID          string `json:"id"`
+	Hook        string `json:"hook"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Script      string `json:"script"`
+}
+
+// hookTemplateMarker identifies hooks installed from templates so they can be removed safely
+const hookTemplateMarker = "# Installed by Claudilandia"
+
+// hookTemplates is the standard hook set offered for installation
+var hookTemplates = []HookTemplate{
+	{
+		ID:          "go-fmt-vet",
+		Hook:        "pre-commit",
+		Name:        "Go fmt + vet",
+		Description: "Reject commits with unformatted Go files or go vet findings",
+		Script: `files=$(git diff --cached --name-only --diff-filter=ACM -- '*.go')
+[ -z "$files" ] && exit 0
+unformatted=$(gofmt -l $files)
+if [ -n "$unformatted" ]; then
+  echo "gofmt needed on:"; echo "$unformatted"; exit 1
+fi
+go vet ./...`,
+	},
+	{
+		ID:          "npm-lint",
+		Hook:        "pre-commit",
+		Name:        "npm lint",
+		Description: "Run the package.json lint script before committing",
+		Script:      `npm run --if-present lint`,
+	},
+	{
+		ID:          "no-large-files",
+		Hook:        "pre-commit",
+		Name:        "Block large files",
+		Description: "Reject staged files larger than 5 MB",
+		Script: `limit=5242880
+for f in $(git diff --cached --name-only --diff-filter=ACM); do
+  [ -f "$f" ] || continue
+  size=$(wc -c < "$f")
+  if [ "$size" -gt "$limit" ]; then
+    echo "$f is larger than 5 MB"; exit 1
+  fi
+done`,
+	},
+	{
+		ID:          "conventional-commit",
+		Hook:        "commit-msg",
+		Name:        "Conventional commits",
+		Description: "Require commit messages like 'feat: ...' or 'fix(scope): ...'",
+		Script: `pattern='^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\(.+\))?!?: .+'
+if ! head -1 "$1" | grep -qE "$pattern"; then
+  echo "Commit message must follow Conventional Commits"; exit 1
+fi`,
+	},
+	{
+		ID:          "npm-test",
+		Hook:        "pre-push",
+		Name:        "npm test",
+		Description: "Run the test suite before pushing",
+		Script:      `npm test`,
+	},
+	{
+		ID:          "go-test",
+		Hook:        "pre-push",
+		Name:        "go test",
+		Description: "Run go test ./... before pushing",
+		Script:      `go test ./...`,
+	},
+}
+
+// GetHookTemplates returns the hook templates available for installation
+func GetHookTemplates() []HookTemplate {
+	templates := make([]HookTemplate, len(hookTemplates))
+	copy(templates, hookTemplates)
+	return templates
+}
+
+// hooksDir returns the effective hooks directory for a repository
+func (m *Manager) hooksDir(repoPath string) (string, error) {
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	dir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoPath, dir)
+	}
+	return dir, nil
+}
+
+// GetHooks lists git hooks and hooks configured through hook managers
+func (m *Manager) GetHooks(repoPath string) (*HooksInfo, error) {
+	dir, err := m.hooksDir(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &HooksInfo{
+		HooksPath: dir,
+		Managers:  []string{},
+		Hooks:     []GitHook{},
+	}
+
+	info.Hooks = append(info.Hooks, readHookScripts(dir, "git")...)
+
+	// husky (v5+ keeps hook scripts in .husky/, internals in .husky/_)
+	huskyDir := filepath.Join(repoPath, ".husky")
+	if st, err := os.Stat(huskyDir); err == nil && st.IsDir() {
+		info.Managers = append(info.Managers, "husky")
+		for _, hook := range readHookScripts(huskyDir, "husky") {
+			// husky scripts are sourced by its runner, so they don't need +x
+			hook.Enabled = true
+			info.Hooks = append(info.Hooks, hook)
+		}
+	}
+
+	// lefthook
+	for _, name := range []string{"lefthook.yml", "lefthook.yaml", ".lefthook.yml", ".lefthook.yaml"} {
+		path := filepath.Join(repoPath, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		info.Managers = append(info.Managers, "lefthook")
+		info.Hooks = append(info.Hooks, parseLefthookConfig(path, string(data))...)
+		break
+	}
+
+	// pre-commit framework
+	preCommitConfig := filepath.Join(repoPath, ".pre-commit-config.yaml")
+	if _, err := os.Stat(preCommitConfig); err == nil {
+		info.Managers = append(info.Managers, "pre-commit")
+		info.Hooks = append(info.Hooks, GitHook{
+			Name:     "pre-commit",
+			Path:     preCommitConfig,
+			Source:   "pre-commit",
+			Enabled:  true,
+			Commands: []string{"pre-commit run"},
+		})
+	}
+
+	return info, nil
+}
+
+// readHookScripts reads hook scripts from a directory
+func readHookScripts(dir, source string) []GitHook {
+	hooks := []GitHook{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return hooks
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		name := entry.Name()
+		enabled := true
+		if strings.HasSuffix(name, ".sample") {
+			name = strings.TrimSuffix(name, ".sample")
+			enabled = false
+		}
+		if fi, err := entry.Info(); err == nil && fi.Mode()&0111 == 0 {
+			enabled = false
+		}
+
+		hook := GitHook{
+			Name:     name,
+			Path:     path,
+			Source:   source,
+			Enabled:  enabled,
+			Commands: []string{},
+		}
+		// Samples are long boilerplate; only summarize real hooks
+		if !strings.HasSuffix(entry.Name(), ".sample") {
+			if data, err := os.ReadFile(path); err == nil {
+				hook.Commands = scriptCommands(string(data))
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+	return hooks
+}
+
+// scriptCommands returns the meaningful lines of a shell script
+func scriptCommands(script string) []string {
+	commands := []string{}
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// husky v8 bootstrap line
+		if strings.Contains(line, "husky.sh") {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands
+}
+
+// parseLefthookConfig extracts `run:` commands per hook from a lefthook config.
+// This is a line-based reader covering the common layout:
+//
+//	pre-commit:
+//	  commands:
+//	    lint:
+//	      run: npm run lint
+func parseLefthookConfig(path, content string) []GitHook {
+	var hooks []GitHook
+	var current *GitHook
+
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimRight(raw, " \r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Top-level key starts a new hook
+		if line[0] != ' ' && line[0] != '\t' {
+			current = nil
+			key, _, ok := strings.Cut(trimmed, ":")
+			if ok && strings.Contains(key, "-") {
+				hooks = append(hooks, GitHook{
+					Name:     key,
+					Path:     path,
+					Source:   "lefthook",
+					Enabled:  true,
+					Commands: []string{},
+				})
+				current = &hooks[len(hooks)-1]
+			}
+			continue
+		}
+
+		if current != nil && strings.HasPrefix(trimmed, "run:") {
+			cmd := strings.TrimSpace(strings.TrimPrefix(trimmed, "run:"))
+			cmd = strings.Trim(cmd, `"'`)
+			if cmd != "" {
+				current.Commands = append(current.Commands, cmd)
+			}
+		}
+	}
+
+	if hooks == nil {
+		return []GitHook{}
+	}
+	return hooks
+}
+
+// InstallHookTemplate writes a template hook into the repository's hooks directory.
+// An existing hook is only replaced when overwrite is set.
+func (m *Manager) InstallHookTemplate(repoPath, templateID string, overwrite bool) error {
+	var tmpl *HookTemplate
+	for i := range hookTemplates {
+		if hookTemplates[i].ID == templateID {
+			tmpl = &hookTemplates[i]
+			break
+		}
+	}
+	if tmpl == nil {
+		return fmt.Errorf("unknown hook template: %s", templateID)
+	}
+
+	dir, err := m.hooksDir(repoPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, tmpl.Hook)
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("%s hook already exists", tmpl.Hook)
+	}
+
+	content := fmt.Sprintf("#!/bin/sh\n%s (%s)\nset -e\n\n%s\n", hookTemplateMarker, tmpl.ID, tmpl.Script)
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of a hook it overwrites, and git skips hooks
+	// that aren't executable
+	return os.Chmod(path, 0755)
+}
+
+// RemoveHook deletes a hook previously installed from a template.
+// Hooks not created by InstallHookTemplate are left untouched.
+func (m *Manager) RemoveHook(repoPath, hookName string) error {
+	dir, err := m.hooksDir(repoPath)
+	if err != nil {
+		return err
+	}
+	if hookName == "" || strings.ContainsAny(hookName, `/\`) {
+		return fmt.Errorf("invalid hook name: %s", hookName)
+	}
+
+	path := filepath.Join(dir, hookName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(data), hookTemplateMarker) {
+		return fmt.Errorf("%s hook was not installed from a template", hookName)
+	}
+	return os.Remove(path)
+}
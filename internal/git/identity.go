@@ -0,0 +1,129 @@
+package git
+
+import (
+	"fmt"
+	"net/mail"
+	"os/exec"
+	"strings"
+)
+
+// IdentityStatus describes the author identity and signing setup of a repository
+type IdentityStatus struct {
+	UserName       string `json:"userName"`
+	UserEmail      string `json:"userEmail"`
+	NameScope      string `json:"nameScope"`  // local, global, system or "" when unset
+	EmailScope     string `json:"emailScope"` // local, global, system or "" when unset
+	SigningEnabled bool   `json:"signingEnabled"`
+	SigningFormat  string `json:"signingFormat"` // openpgp, ssh or x509
+	SigningKey     string `json:"signingKey"`
+	Configured     bool   `json:"configured"` // Both user.name and user.email are set
+}
+
+// CommitSignature is the signature state of a single commit
+type CommitSignature struct {
+	Hash   string `json:"hash"`
+	Signed bool   `json:"signed"`
+	Status string `json:"status"` // good, bad, untrusted, expired, revoked, unverifiable, none
+	Signer string `json:"signer"`
+}
+
+// signatureStatuses maps git's %G? codes to readable statuses
+var signatureStatuses = map[string]string{
+	"G": "good",
+	"B": "bad",
+	"U": "untrusted",
+	"X": "expired",
+	"Y": "expired",
+	"R": "revoked",
+	"E": "unverifiable",
+	"N": "none",
+}
+
+// getConfig returns a config value and the scope it was read from
+func (m *Manager) getConfig(repoPath, key string) (value, scope string) {
+	output, err := exec.Command("git", "-C", repoPath, "config", "--show-scope", "--get", key).Output()
+	if err != nil {
+		return "", ""
+	}
+	scope, value, _ = strings.Cut(strings.TrimSpace(string(output)), "\t")
+	return value, scope
+}
+
+// getBoolConfig reads a boolean config value, letting git interpret its
+// spellings (yes/on/1/true, or a key with no value)
+func (m *Manager) getBoolConfig(repoPath, key string) bool {
+	output, err := exec.Command("git", "-C", repoPath, "config", "--type=bool", "--get", key).Output()
+	return err == nil && strings.TrimSpace(string(output)) == "true"
+}
+
+// GetIdentity reports the effective user identity and commit signing configuration
+func (m *Manager) GetIdentity(repoPath string) *IdentityStatus {
+	status := &IdentityStatus{}
+	status.UserName, status.NameScope = m.getConfig(repoPath, "user.name")
+	status.UserEmail, status.EmailScope = m.getConfig(repoPath, "user.email")
+	status.Configured = status.UserName != "" && status.UserEmail != ""
+
+	status.SigningEnabled = m.getBoolConfig(repoPath, "commit.gpgsign")
+	status.SigningKey, _ = m.getConfig(repoPath, "user.signingkey")
+	status.SigningFormat, _ = m.getConfig(repoPath, "gpg.format")
+	if status.SigningFormat == "" {
+		status.SigningFormat = "openpgp"
+	}
+
+	return status
+}
+
+// SetIdentity sets user.name and user.email in the repository's local config
+func (m *Manager) SetIdentity(repoPath, name, email string) error {
+	name = strings.TrimSpace(name)
+	email = strings.TrimSpace(email)
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return fmt.Errorf("invalid email address: %s", email)
+	}
+
+	for key, value := range map[string]string{"user.name": name, "user.email": email} {
+		output, err := exec.Command("git", "-C", repoPath, "config", "--local", key, value).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("failed to set %s: %s", key, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// GetCommitSignatures returns the signature state of the most recent commits
+func (m *Manager) GetCommitSignatures(repoPath string, limit int) ([]CommitSignature, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	cmd := exec.Command("git", "-C", repoPath, "log",
+		"--format=%H%x1E%G?%x1E%GS",
+		"-n", fmt.Sprintf("%d", limit))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := []CommitSignature{}
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Split(line, "\x1E")
+		if len(parts) < 3 {
+			continue
+		}
+		status, ok := signatureStatuses[parts[1]]
+		if !ok {
+			status = "unverifiable"
+		}
+		signatures = append(signatures, CommitSignature{
+			Hash:   parts[0],
+			Signed: parts[1] != "N",
+			Status: status,
+			Signer: parts[2],
+		})
+	}
+
+	return signatures, nil
+}
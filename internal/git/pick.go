@@ -0,0 +1,99 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PickResult represents the outcome of a cherry-pick or revert
+type PickResult struct {
+	Success   bool     `json:"success"`
+	Conflicts []string `json:"conflicts"` // Files left with merge conflicts
+	Head      string   `json:"head"`      // New HEAD hash when successful
+	Output    string   `json:"output"`    // Combined git output
+}
+
+// CherryPick applies the given commit on top of the current branch
+func (m *Manager) CherryPick(repoPath, hash string) (*PickResult, error) {
+	return m.applyCommit(repoPath, "cherry-pick", hash)
+}
+
+// Revert creates a new commit undoing the given commit
+func (m *Manager) Revert(repoPath, hash string) (*PickResult, error) {
+	return m.applyCommit(repoPath, "revert", hash, "--no-edit")
+}
+
+// AbortCherryPick aborts an in-progress cherry-pick after conflicts
+func (m *Manager) AbortCherryPick(repoPath string) error {
+	return m.abortSequence(repoPath, "cherry-pick")
+}
+
+// AbortRevert aborts an in-progress revert after conflicts
+func (m *Manager) AbortRevert(repoPath string) error {
+	return m.abortSequence(repoPath, "revert")
+}
+
+// applyCommit runs cherry-pick or revert and reports conflicts instead of failing
+func (m *Manager) applyCommit(repoPath, op, hash string, extra ...string) (*PickResult, error) {
+	hash = strings.TrimSpace(hash)
+	if hash == "" || strings.HasPrefix(hash, "-") {
+		return nil, fmt.Errorf("invalid commit %q", hash)
+	}
+
+	args := append([]string{"-C", repoPath, op}, extra...)
+	args = append(args, hash)
+	output, err := exec.Command("git", args...).CombinedOutput()
+
+	result := &PickResult{
+		Conflicts: []string{},
+		Output:    strings.TrimSpace(string(output)),
+	}
+
+	if err == nil {
+		result.Success = true
+		result.Head = m.revParse(repoPath, "HEAD")
+		return result, nil
+	}
+
+	result.Conflicts = m.getConflictedFiles(repoPath)
+	if len(result.Conflicts) == 0 {
+		// Failed for a reason other than conflicts (dirty tree, bad hash, empty pick...)
+		return nil, fmt.Errorf("git %s failed: %s", op, result.Output)
+	}
+
+	return result, nil
+}
+
+// abortSequence aborts an in-progress cherry-pick or revert
+func (m *Manager) abortSequence(repoPath, op string) error {
+	output, err := exec.Command("git", "-C", repoPath, op, "--abort").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s --abort failed: %s", op, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// getConflictedFiles returns files with unresolved merge conflicts
+func (m *Manager) getConflictedFiles(repoPath string) []string {
+	files := []string{}
+	output, err := exec.Command("git", "-C", repoPath, "diff", "--name-only", "--diff-filter=U").Output()
+	if err != nil {
+		return files
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files
+}
+
+// revParse resolves a revision to a full hash, returning "" on failure
+func (m *Manager) revParse(repoPath, rev string) string {
+	output, err := exec.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", rev).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,98 @@
+package git
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RepoRoot represents a git repository found inside a project directory
+type RepoRoot struct {
+	Path        string `json:"path"`    // Absolute path to the repository root
+	RelPath     string `json:"relPath"` // Path relative to the project ("." for the project itself)
+	Branch      string `json:"branch"`
+	IsSubmodule bool   `json:"isSubmodule"` // .git is a file (submodule or linked worktree)
+	Staged      int    `json:"staged"`
+	Unstaged    int    `json:"unstaged"`
+	Untracked   int    `json:"untracked"`
+}
+
+// defaultRootScanDepth limits how deep nested repositories are searched for
+const defaultRootScanDepth = 4
+
+// rootScanSkipDirs are directories never descended into when looking for nested repos
+var rootScanSkipDirs = map[string]bool{
+	"node_modules": true,
+	".git":         true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	".venv":        true,
+	"venv":         true,
+	"__pycache__":  true,
+	".next":        true,
+	".cache":       true,
+}
+
+// FindRepoRoots returns the project's own repository (if any) followed by
+// every nested repository up to maxDepth levels below projectPath.
+func (m *Manager) FindRepoRoots(projectPath string, maxDepth int) ([]RepoRoot, error) {
+	if maxDepth <= 0 {
+		maxDepth = defaultRootScanDepth
+	}
+
+	projectPath = filepath.Clean(projectPath)
+	var paths []string
+
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Skip unreadable directories instead of aborting the scan
+			if d != nil && d.IsDir() && path != projectPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(projectPath, path)
+		if rel != "." {
+			if rootScanSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			if strings.Count(rel, string(filepath.Separator))+1 > maxDepth {
+				return filepath.SkipDir
+			}
+		}
+
+		if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(paths)
+
+	roots := []RepoRoot{}
+	for _, path := range paths {
+		rel, _ := filepath.Rel(projectPath, path)
+		root := RepoRoot{
+			Path:    path,
+			RelPath: filepath.ToSlash(rel),
+			Branch:  m.GetCurrentBranch(path),
+		}
+		if info, err := os.Lstat(filepath.Join(path, ".git")); err == nil && !info.IsDir() {
+			root.IsSubmodule = true
+		}
+		root.Staged, root.Unstaged, root.Untracked = m.GetStatus(path)
+		roots = append(roots, root)
+	}
+
+	return roots, nil
+}
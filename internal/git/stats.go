@@ -0,0 +1,186 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RepoStats summarizes repository activity over a time window
+type RepoStats struct {
+	WindowDays        int           `json:"windowDays"`
+	Since             string        `json:"since"` // ISO date
+	TotalCommits      int           `json:"totalCommits"`
+	Contributors      []Contributor `json:"contributors"`
+	CommitsPerDay     []DayCount    `json:"commitsPerDay"`     // Oldest first, every day in the window
+	CommitsPerWeekday [7]int        `json:"commitsPerWeekday"` // Sunday = 0
+	Hotspots          []FileChurn   `json:"hotspots"`
+}
+
+// Contributor aggregates commits by author
+type Contributor struct {
+	Name       string `json:"name"`
+	Email      string `json:"email"`
+	Commits    int    `json:"commits"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+}
+
+// DayCount is the number of commits on a day
+type DayCount struct {
+	Date  string `json:"date"` // YYYY-MM-DD
+	Count int    `json:"count"`
+}
+
+// FileChurn measures how often and how much a file changed
+type FileChurn struct {
+	Path       string `json:"path"`
+	Commits    int    `json:"commits"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Churn      int    `json:"churn"` // Insertions + deletions
+}
+
+// maxHotspots limits the number of files reported as hotspots
+const maxHotspots = 20
+
+// GetRepoStats computes contributor, commit frequency and churn statistics
+// for the last `days` days (default 90)
+func (m *Manager) GetRepoStats(repoPath string, days int) (*RepoStats, error) {
+	if days <= 0 {
+		days = 90
+	}
+	now := time.Now()
+	since := now.AddDate(0, 0, -(days - 1))
+	since = time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, since.Location())
+
+	// Each commit starts with 0x1E, header fields are separated by 0x1F,
+	// followed by --numstat lines
+	cmd := exec.Command("git", "-C", repoPath, "log",
+		"--since="+since.Format(time.RFC3339),
+		"--no-merges",
+		"--format=%x1E%an%x1F%ae%x1F%aI",
+		"--numstat")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	stats := &RepoStats{
+		WindowDays:   days,
+		Since:        since.Format("2006-01-02"),
+		Contributors: []Contributor{},
+		Hotspots:     []FileChurn{},
+	}
+
+	contributors := make(map[string]*Contributor)
+	perDay := make(map[string]int)
+	files := make(map[string]*FileChurn)
+
+	for _, entry := range strings.Split(string(output), "\x1E") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		lines := strings.Split(entry, "\n")
+		header := strings.Split(lines[0], "\x1F")
+		if len(header) < 3 {
+			continue
+		}
+
+		stats.TotalCommits++
+
+		key := strings.ToLower(header[1])
+		c, ok := contributors[key]
+		if !ok {
+			c = &Contributor{Name: header[0], Email: header[1]}
+			contributors[key] = c
+		}
+		c.Commits++
+
+		if date, err := time.Parse(time.RFC3339, header[2]); err == nil {
+			local := date.In(now.Location())
+			perDay[local.Format("2006-01-02")]++
+			stats.CommitsPerWeekday[local.Weekday()]++
+		}
+
+		for _, line := range lines[1:] {
+			parts := strings.SplitN(line, "\t", 3)
+			if len(parts) < 3 {
+				continue
+			}
+			// Binary files report "-" for both counts
+			ins, _ := strconv.Atoi(parts[0])
+			del, _ := strconv.Atoi(parts[1])
+			path := renamedPath(parts[2])
+
+			c.Insertions += ins
+			c.Deletions += del
+
+			f, ok := files[path]
+			if !ok {
+				f = &FileChurn{Path: path}
+				files[path] = f
+			}
+			f.Commits++
+			f.Insertions += ins
+			f.Deletions += del
+			f.Churn += ins + del
+		}
+	}
+
+	for _, c := range contributors {
+		stats.Contributors = append(stats.Contributors, *c)
+	}
+	sort.Slice(stats.Contributors, func(i, j int) bool {
+		if stats.Contributors[i].Commits != stats.Contributors[j].Commits {
+			return stats.Contributors[i].Commits > stats.Contributors[j].Commits
+		}
+		return stats.Contributors[i].Name < stats.Contributors[j].Name
+	})
+
+	for d := since; !d.After(now); d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		stats.CommitsPerDay = append(stats.CommitsPerDay, DayCount{Date: day, Count: perDay[day]})
+	}
+
+	for _, f := range files {
+		stats.Hotspots = append(stats.Hotspots, *f)
+	}
+	sort.Slice(stats.Hotspots, func(i, j int) bool {
+		a, b := stats.Hotspots[i], stats.Hotspots[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		if a.Churn != b.Churn {
+			return a.Churn > b.Churn
+		}
+		return a.Path < b.Path
+	})
+	if len(stats.Hotspots) > maxHotspots {
+		stats.Hotspots = stats.Hotspots[:maxHotspots]
+	}
+
+	return stats, nil
+}
+
+// renamedPath resolves numstat rename notation to the new path:
+// "old => new" and "dir/{old => new}/file"
+func renamedPath(path string) string {
+	if !strings.Contains(path, " => ") {
+		return path
+	}
+	if start := strings.Index(path, "{"); start >= 0 {
+		if end := strings.Index(path[start:], "}"); end >= 0 {
+			inner := path[start+1 : start+end]
+			_, newPart, _ := strings.Cut(inner, " => ")
+			result := path[:start] + newPart + path[start+end+1:]
+			return strings.ReplaceAll(result, "//", "/")
+		}
+	}
+	_, newPath, _ := strings.Cut(path, " => ")
+	return newPath
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// FileState is how a changed file differs from HEAD
+type FileState struct {
+	Staged    bool `json:"staged"`    // The index has changes to commit
+	Modified  bool `json:"modified"`  // The working tree has changes not staged
+	Untracked bool `json:"untracked"` // Not tracked and not ignored
+}
+
+// GetFileStates returns the state of every changed file in the repository
+// containing path, keyed by absolute path. Untracked directories are listed
+// file by file.
+func (m *Manager) GetFileStates(path string) (map[string]FileState, error) {
+	top, err := exec.Command("git", "-C", path, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(string(top))
+
+	output, err := exec.Command("git", "-C", path, "status", "--porcelain=v1", "-z", "--untracked-files=all").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]FileState)
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x, y, file := entry[0], entry[1], entry[3:]
+		if x == 'R' || x == 'C' {
+			i++ // The source path of a rename or copy follows
+		}
+
+		var state FileState
+		if x == '?' {
+			state.Untracked = true
+		} else {
+			state.Staged = x != ' '
+			state.Modified = y != ' '
+		}
+		states[filepath.Join(root, filepath.FromSlash(file))] = state
+	}
+	return states, nil
+}
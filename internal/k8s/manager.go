@@ -0,0 +1,411 @@
+// Package k8s provides basic Kubernetes integration through kubectl and the
+// user's kubeconfig: listing pods and deployments, streaming pod logs and
+// building `kubectl exec` shell commands.
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"projecthub/internal/logging"
+	"projecthub/internal/shell"
+)
+
+// Pod is a summary of a pod, like a row of `kubectl get pods`
+type Pod struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Phase      string   `json:"phase"`  // Pending, Running, Succeeded, Failed, Unknown
+	Status     string   `json:"status"` // Phase or a container waiting reason like CrashLoopBackOff
+	Ready      string   `json:"ready"`  // e.g. "1/2"
+	Restarts   int      `json:"restarts"`
+	Node       string   `json:"node"`
+	StartedAt  string   `json:"startedAt"`
+	Containers []string `json:"containers"`
+}
+
+// Deployment is a summary of a deployment, like a row of `kubectl get deployments`
+type Deployment struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Ready     string   `json:"ready"` // e.g. "2/3"
+	UpToDate  int      `json:"upToDate"`
+	Available int      `json:"available"`
+	Images    []string `json:"images"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+// KubeContext is a context from the kubeconfig
+type KubeContext struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+// LogLine is a single line of pod output
+type LogLine struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	Line      string `json:"line"`
+}
+
+// Target selects the cluster and namespace commands run against
+type Target struct {
+	Context   string `json:"context"`   // "" = current kubeconfig context
+	Namespace string `json:"namespace"` // "" = default
+}
+
+// Manager runs kubectl against the user's kubeconfig
+type Manager struct {
+	// Active pod log streams
+	followers map[string]context.CancelFunc
+	mu        sync.Mutex
+}
+
+// commandTimeout bounds list commands so an unreachable cluster does not hang the UI
+const commandTimeout = 15 * time.Second
+
+// NewManager creates a new Kubernetes manager
+func NewManager() *Manager {
+	return &Manager{followers: make(map[string]context.CancelFunc)}
+}
+
+// IsAvailable reports whether kubectl is installed and a kubeconfig exists
+func (m *Manager) IsAvailable() bool {
+	if kubectl(context.Background(), "version", "--client").Run() != nil {
+		return false
+	}
+	for _, path := range kubeconfigPaths() {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Contexts lists the contexts of the kubeconfig
+func (m *Manager) Contexts() ([]KubeContext, error) {
+	output, err := m.run(Target{}, "config", "get-contexts", "-o", "name")
+	if err != nil {
+		return nil, err
+	}
+	current, _ := m.run(Target{}, "config", "current-context")
+	current = strings.TrimSpace(current)
+
+	contexts := []KubeContext{}
+	for _, name := range strings.Split(output, "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			contexts = append(contexts, KubeContext{Name: name, Current: name == current})
+		}
+	}
+	return contexts, nil
+}
+
+// ListPods lists the pods of the target namespace
+func (m *Manager) ListPods(target Target) ([]Pod, error) {
+	output, err := m.run(target, "get", "pods", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				NodeName   string `json:"nodeName"`
+				Containers []struct {
+					Name string `json:"name"`
+				} `json:"containers"`
+			} `json:"spec"`
+			Status struct {
+				Phase             string `json:"phase"`
+				StartTime         string `json:"startTime"`
+				ContainerStatuses []struct {
+					Ready        bool `json:"ready"`
+					RestartCount int  `json:"restartCount"`
+					State        struct {
+						Waiting *struct {
+							Reason string `json:"reason"`
+						} `json:"waiting"`
+						Terminated *struct {
+							Reason string `json:"reason"`
+						} `json:"terminated"`
+					} `json:"state"`
+				} `json:"containerStatuses"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("unexpected kubectl output: %w", err)
+	}
+
+	pods := make([]Pod, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := Pod{
+			Name:       item.Metadata.Name,
+			Namespace:  item.Metadata.Namespace,
+			Phase:      item.Status.Phase,
+			Status:     item.Status.Phase,
+			Node:       item.Spec.NodeName,
+			StartedAt:  item.Status.StartTime,
+			Containers: []string{},
+		}
+		for _, c := range item.Spec.Containers {
+			pod.Containers = append(pod.Containers, c.Name)
+		}
+
+		ready := 0
+		for _, cs := range item.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+			pod.Restarts += cs.RestartCount
+			// Surface reasons like CrashLoopBackOff or ImagePullBackOff
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+				pod.Status = cs.State.Waiting.Reason
+			} else if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" && pod.Phase != "Succeeded" {
+				pod.Status = cs.State.Terminated.Reason
+			}
+		}
+		pod.Ready = fmt.Sprintf("%d/%d", ready, len(item.Spec.Containers))
+		pods = append(pods, pod)
+	}
+
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	return pods, nil
+}
+
+// ListDeployments lists the deployments of the target namespace
+func (m *Manager) ListDeployments(target Target) ([]Deployment, error) {
+	output, err := m.run(target, "get", "deployments", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name              string `json:"name"`
+				Namespace         string `json:"namespace"`
+				CreationTimestamp string `json:"creationTimestamp"`
+			} `json:"metadata"`
+			Spec struct {
+				Replicas *int `json:"replicas"`
+				Template struct {
+					Spec struct {
+						Containers []struct {
+							Image string `json:"image"`
+						} `json:"containers"`
+					} `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+			Status struct {
+				ReadyReplicas     int `json:"readyReplicas"`
+				UpdatedReplicas   int `json:"updatedReplicas"`
+				AvailableReplicas int `json:"availableReplicas"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("unexpected kubectl output: %w", err)
+	}
+
+	deployments := make([]Deployment, 0, len(list.Items))
+	for _, item := range list.Items {
+		replicas := 1
+		if item.Spec.Replicas != nil {
+			replicas = *item.Spec.Replicas
+		}
+		d := Deployment{
+			Name:      item.Metadata.Name,
+			Namespace: item.Metadata.Namespace,
+			Ready:     fmt.Sprintf("%d/%d", item.Status.ReadyReplicas, replicas),
+			UpToDate:  item.Status.UpdatedReplicas,
+			Available: item.Status.AvailableReplicas,
+			Images:    []string{},
+			CreatedAt: item.Metadata.CreationTimestamp,
+		}
+		for _, c := range item.Spec.Template.Spec.Containers {
+			d.Images = append(d.Images, c.Image)
+		}
+		deployments = append(deployments, d)
+	}
+
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+	return deployments, nil
+}
+
+// FollowPodLogs streams a pod's logs (all containers when container is empty),
+// starting with the last `tail` lines, until StopPodLogs is called or the pod exits.
+// Following an already followed pod restarts the stream.
+func (m *Manager) FollowPodLogs(target Target, pod, container string, tail int, onLine func(LogLine)) error {
+	if tail <= 0 {
+		tail = 100
+	}
+	args := []string{"logs", "-f", "--tail=" + strconv.Itoa(tail)}
+	if container != "" {
+		args = append(args, "-c", container)
+	} else {
+		args = append(args, "--all-containers", "--prefix")
+	}
+	args = append(args, pod)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := kubectl(ctx, append(targetArgs(target), args...)...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return fmt.Errorf("failed to start kubectl: %w", err)
+	}
+
+	key := logKey(target, pod)
+	m.mu.Lock()
+	if prev, ok := m.followers[key]; ok {
+		prev()
+	}
+	m.followers[key] = cancel
+	m.mu.Unlock()
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := LogLine{Pod: pod, Container: container, Line: scanner.Text()}
+			// --prefix lines look like "[pod/name/container] message"
+			if container == "" && strings.HasPrefix(line.Line, "[pod/") {
+				if prefix, rest, ok := strings.Cut(line.Line, "] "); ok {
+					parts := strings.Split(strings.TrimPrefix(prefix, "["), "/")
+					line.Container = parts[len(parts)-1]
+					line.Line = rest
+				}
+			}
+			onLine(line)
+		}
+		err := cmd.Wait()
+
+		m.mu.Lock()
+		// Only remove our own entry; a newer follow may have replaced it
+		if ctx.Err() == nil {
+			if current, ok := m.followers[key]; ok {
+				current()
+				delete(m.followers, key)
+			}
+		}
+		m.mu.Unlock()
+
+		if err != nil && ctx.Err() == nil {
+			logging.Warn("Pod log stream ended", "pod", pod, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// StopPodLogs stops streaming logs for a pod
+func (m *Manager) StopPodLogs(target Target, pod string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := logKey(target, pod)
+	if cancel, ok := m.followers[key]; ok {
+		cancel()
+		delete(m.followers, key)
+	}
+}
+
+// StopAllPodLogs stops every active pod log stream
+func (m *Manager) StopAllPodLogs() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, cancel := range m.followers {
+		cancel()
+		delete(m.followers, key)
+	}
+}
+
+// ExecShellCommand returns a `kubectl exec -it` command line that opens an
+// interactive shell in a pod (bash when present, else sh)
+func (m *Manager) ExecShellCommand(target Target, pod, container string) string {
+	args := []string{"kubectl"}
+	for _, arg := range targetArgs(target) {
+		args = append(args, shell.Quote(arg))
+	}
+	args = append(args, "exec", "-it", shell.Quote(pod))
+	if container != "" {
+		args = append(args, "-c", shell.Quote(container))
+	}
+	args = append(args, "--", "sh", "-c", `'if command -v bash >/dev/null 2>&1; then exec bash; else exec sh; fi'`)
+	return strings.Join(args, " ")
+}
+
+// run executes kubectl against the target and returns stdout
+func (m *Manager) run(target Target, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := kubectl(ctx, append(targetArgs(target), args...)...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("kubectl timed out (is the cluster running?)")
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("kubectl failed: %s", msg)
+		}
+		return "", fmt.Errorf("kubectl failed: %w", err)
+	}
+	return string(output), nil
+}
+
+// targetArgs returns the --context and --namespace flags for a target
+func targetArgs(target Target) []string {
+	args := []string{}
+	if target.Context != "" {
+		args = append(args, "--context", target.Context)
+	}
+	if target.Namespace != "" {
+		args = append(args, "--namespace", target.Namespace)
+	}
+	return args
+}
+
+func logKey(target Target, pod string) string {
+	return target.Context + "/" + target.Namespace + "/" + pod
+}
+
+// kubeconfigPaths returns the kubeconfig files kubectl will read
+func kubeconfigPaths() []string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)
+	}
+	home, _ := os.UserHomeDir()
+	return []string{filepath.Join(home, ".kube", "config")}
+}
+
+// kubectl runs kubectl through the user's login shell so PATH matches their
+// terminal (Homebrew, asdf, ...)
+func kubectl(ctx context.Context, args ...string) *exec.Cmd {
+	userShell := os.Getenv("SHELL")
+	if userShell == "" {
+		userShell = "/bin/zsh"
+	}
+	cmdArgs := append([]string{"-l", "-c", `exec kubectl "$@"`, "projecthub"}, args...)
+	return exec.CommandContext(ctx, userShell, cmdArgs...)
+}
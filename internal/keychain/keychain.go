@@ -0,0 +1,115 @@
+// Package keychain stores secrets in the operating system's credential store:
+// the login keychain on macOS (via the security tool), the Secret Service
+// on Linux (via secret-tool) and the Credential Manager on Windows.
+package keychain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrNotFound is returned when no secret is stored for the service and account
+var ErrNotFound = errors.New("secret not found in keychain")
+
+// Set stores (or replaces) the secret for a service and account
+func Set(service, account, secret string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		// Commands are fed through stdin so the secret never shows up in the process list
+		script := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+			quote(service), quote(account), quote(secret))
+		return runSecurity(script)
+	case "linux":
+		cmd := exec.Command("secret-tool", "store", "--label="+service+" ("+account+")",
+			"service", service, "account", account)
+		cmd.Stdin = strings.NewReader(secret)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("secret-tool store failed: %s", strings.TrimSpace(string(output)))
+		}
+		return nil
+	case "windows":
+		return credSet(service, account, secret)
+	}
+	return fmt.Errorf("keychain not supported on %s", runtime.GOOS)
+}
+
+// Get returns the secret for a service and account
+func Get(service, account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("/usr/bin/security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, "account", account)
+	case "windows":
+		return credGet(service, account)
+	default:
+		return "", fmt.Errorf("keychain not supported on %s", runtime.GOOS)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	secret := strings.TrimSuffix(string(output), "\n")
+	if secret == "" && runtime.GOOS == "linux" {
+		// secret-tool exits 0 with no output when nothing matches
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+// Delete removes the secret for a service and account. Deleting a missing
+// secret is not an error.
+func Delete(service, account string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("/usr/bin/security", "delete-generic-password", "-s", service, "-a", account)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", service, "account", account)
+	case "windows":
+		return credDelete(service, account)
+	default:
+		return fmt.Errorf("keychain not supported on %s", runtime.GOOS)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if _, getErr := Get(service, account); errors.Is(getErr, ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete secret: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runSecurity runs commands through `security -i`
+func runSecurity(script string) error {
+	cmd := exec.Command("/usr/bin/security", "-i")
+	cmd.Stdin = strings.NewReader(script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("security failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		// security -i reports command errors on stderr but exits 0
+		return fmt.Errorf("security failed: %s", msg)
+	}
+	return nil
+}
+
+// quote wraps a value in double quotes for the security interactive parser
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package keychain
+
+import "errors"
+
+// errNoCredentialManager is returned by the Credential Manager stubs off Windows
+var errNoCredentialManager = errors.New("credential manager is only available on Windows")
+
+func credSet(service, account, secret string) error { return errNoCredentialManager }
+
+func credGet(service, account string) (string, error) { return "", errNoCredentialManager }
+
+func credDelete(service, account string) error { return errNoCredentialManager }
@@ -0,0 +1,104 @@
+// Package notify shows native desktop notifications: Notification Center on
+// macOS (via alerter when installed, else osascript), the freedesktop
+// notification service on Linux (via notify-send) and toasts on Windows
+// (via PowerShell).
+package notify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// appName is shown as the sender where the platform allows choosing one
+const appName = "Claudilandia"
+
+// Send shows a notification and returns once it is on screen. When onClick
+// is set and the platform reports clicks (alerter on macOS, notify-send 0.7.9+
+// on Linux), it is called from a background goroutine if the user clicks it.
+func Send(title, message string, onClick func()) error {
+	switch runtime.GOOS {
+	case "darwin":
+		if path, err := exec.LookPath("alerter"); err == nil && onClick != nil {
+			cmd := exec.Command(path, "-title", appName, "-subtitle", title, "-message", message,
+				"-group", appName, "-timeout", "60")
+			return startWaiting(cmd, "@CONTENTCLICKED", onClick)
+		}
+		// Arguments go through argv, so no AppleScript quoting is needed
+		cmd := exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, message)
+		return run(cmd, "osascript")
+	case "linux":
+		if onClick != nil && notifySendHasActions() {
+			cmd := exec.Command("notify-send", "--app-name="+appName, "--action=default=Open", "--wait", title, message)
+			return startWaiting(cmd, "default", onClick)
+		}
+		return run(exec.Command("notify-send", "--app-name="+appName, title, message), "notify-send")
+	case "windows":
+		// Text is passed through the environment to keep it out of the script
+		cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-WindowStyle", "Hidden", "-Command", toastScript)
+		cmd.Env = append(os.Environ(), "NOTIFY_TITLE="+title, "NOTIFY_MESSAGE="+message)
+		return run(cmd, "powershell")
+	}
+	return fmt.Errorf("notifications not supported on %s", runtime.GOOS)
+}
+
+// toastScript shows a toast through the WinRT API, attributed to PowerShell
+// since the app has no registered AppUserModelID
+const toastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:NOTIFY_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:NOTIFY_MESSAGE)) | Out-Null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+$appId = '{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe'
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($appId).Show($toast)
+`
+
+var (
+	actionsOnce sync.Once
+	hasActions  bool
+)
+
+// notifySendHasActions reports whether notify-send supports --action, which
+// arrived in libnotify 0.7.9
+func notifySendHasActions() bool {
+	actionsOnce.Do(func() {
+		output, _ := exec.Command("notify-send", "--help").Output()
+		hasActions = strings.Contains(string(output), "--action")
+	})
+	return hasActions
+}
+
+func run(cmd *exec.Cmd, tool string) error {
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) == 0 {
+			return fmt.Errorf("%s failed: %w", tool, err)
+		}
+		return fmt.Errorf("%s failed: %s", tool, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// startWaiting starts a notifier that blocks until the notification is
+// dismissed and prints clicked when it was clicked
+func startWaiting(cmd *exec.Cmd, clicked string, onClick func()) error {
+	var output strings.Builder
+	cmd.Stdout = &output
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err == nil && strings.TrimSpace(output.String()) == clicked {
+			onClick()
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,285 @@
+package remote
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"projecthub/internal/logging"
+)
+
+// Access requests let a device without a token ask for one from the login
+// page. The desktop app approves or denies it; an approved device gets a
+// permanent token, the same as a saved device added by hand. Requests are
+// kept per connecting address, so one client can't take every slot, and a
+// pending request the device stopped polling for is dropped.
+const (
+	accessRequestTTL    = 10 * time.Minute
+	accessRequestIdle   = 2 * time.Minute // without a poll; background tabs poll slowly
+	maxAccessRequests   = 5               // pending at once
+	maxDeviceNameLength = 64              // runes
+	minDeviceKeyLength  = 32              // hex chars the client generates, 128 bits at least
+	maxDeviceKeyLength  = 128
+)
+
+// Access request states reported to the requesting device
+const (
+	AccessRequestPending  = "pending"
+	AccessRequestApproved = "approved"
+	AccessRequestDenied   = "denied"
+)
+
+// AccessRequest is a device asking for access
+type AccessRequest struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Fingerprint string    `json:"fingerprint"` // shown on both screens, to tell requests apart
+	RemoteAddr  string    `json:"remoteAddr"`
+	UserAgent   string    `json:"userAgent"`
+	CreatedAt   time.Time `json:"createdAt"`
+	status      string
+	keyHash     [sha256.Size]byte // of the device key, which proves the poller made the request
+	token       string            // minted on approval, handed over once
+	lastPoll    time.Time
+}
+
+// SetAccessRequestCallback sets what happens when a device asks for access;
+// nil turns access requests off and drops pending ones
+func (s *Server) SetAccessRequestCallback(cb func(AccessRequest)) {
+	s.mu.Lock()
+	s.onAccessRequest = cb
+	if cb == nil {
+		s.accessRequests = make(map[string]*AccessRequest)
+	}
+	s.mu.Unlock()
+}
+
+// GetAccessRequests returns the pending access requests, oldest first
+func (s *Server) GetAccessRequests() []AccessRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneAccessRequests()
+
+	requests := make([]AccessRequest, 0, len(s.accessRequests))
+	for _, req := range s.accessRequests {
+		if req.status == AccessRequestPending {
+			requests = append(requests, *req)
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.Before(requests[j].CreatedAt)
+	})
+	return requests
+}
+
+// GetAccessRequest returns a pending access request
+func (s *Server) GetAccessRequest(id string) (AccessRequest, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneAccessRequests()
+
+	req, exists := s.accessRequests[id]
+	if !exists || req.status != AccessRequestPending {
+		return AccessRequest{}, false
+	}
+	return *req, true
+}
+
+// ApproveAccessRequest hands a token to a pending request. The token must
+// already be an approved client's.
+func (s *Server) ApproveAccessRequest(id, token string) error {
+	return s.resolveAccessRequest(id, AccessRequestApproved, token)
+}
+
+// DenyAccessRequest turns a pending request down
+func (s *Server) DenyAccessRequest(id string) error {
+	return s.resolveAccessRequest(id, AccessRequestDenied, "")
+}
+
+func (s *Server) resolveAccessRequest(id, status, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneAccessRequests()
+
+	req, exists := s.accessRequests[id]
+	if !exists || req.status != AccessRequestPending {
+		return fmt.Errorf("access request not found or expired")
+	}
+	req.status = status
+	req.token = token
+	logging.Info("Remote access request resolved", "name", req.Name, "ip", req.RemoteAddr, "status", status)
+	return nil
+}
+
+// pruneAccessRequests drops expired requests and pending ones nobody polls
+// for any more. Callers hold s.mu.
+func (s *Server) pruneAccessRequests() {
+	for id, req := range s.accessRequests {
+		abandoned := req.status == AccessRequestPending && time.Since(req.lastPoll) > accessRequestIdle
+		if abandoned || time.Since(req.CreatedAt) > accessRequestTTL {
+			delete(s.accessRequests, id)
+		}
+	}
+}
+
+// deviceFingerprint derives the short code shown for a device key, e.g.
+// "3F2A-91C0"
+func deviceFingerprint(keyHash [sha256.Size]byte) string {
+	code := strings.ToUpper(hex.EncodeToString(keyHash[:4]))
+	return code[:4] + "-" + code[4:]
+}
+
+// cleanDeviceName trims a requested device name and drops control characters
+func cleanDeviceName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if runes := []rune(name); len(runes) > maxDeviceNameLength {
+		name = string(runes[:maxDeviceNameLength])
+	}
+	return strings.TrimSpace(name)
+}
+
+// validDeviceKey reports whether a device key is hex of a sensible length
+func validDeviceKey(key string) bool {
+	if len(key) < minDeviceKeyLength || len(key) > maxDeviceKeyLength {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// handleCreateAccessRequest lets a device without a token ask for access
+// (POST /api/access-requests with {"name": "...", "key": "..."}). The key is
+// a random secret the device keeps to poll for the answer. One request per
+// address is kept; a new one replaces it.
+func (s *Server) handleCreateAccessRequest(w http.ResponseWriter, r *http.Request) {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	var body struct {
+		Name string `json:"name"`
+		Key  string `json:"key"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&body); err != nil {
+		http.Error(w, "Expected {\"name\": \"...\", \"key\": \"...\"}", http.StatusBadRequest)
+		return
+	}
+	name := cleanDeviceName(body.Name)
+	if name == "" {
+		http.Error(w, "Device name is required", http.StatusBadRequest)
+		return
+	}
+	if !validDeviceKey(body.Key) {
+		http.Error(w, "Invalid device key", http.StatusBadRequest)
+		return
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		http.Error(w, "Failed to create request", http.StatusInternalServerError)
+		return
+	}
+	keyHash := sha256.Sum256([]byte(body.Key))
+	req := &AccessRequest{
+		ID:          hex.EncodeToString(idBytes),
+		Name:        name,
+		Fingerprint: deviceFingerprint(keyHash),
+		RemoteAddr:  clientIP,
+		UserAgent:   r.UserAgent(),
+		CreatedAt:   time.Now(),
+		status:      AccessRequestPending,
+		keyHash:     keyHash,
+		lastPoll:    time.Now(),
+	}
+
+	s.mu.Lock()
+	cb := s.onAccessRequest
+	if cb == nil {
+		s.mu.Unlock()
+		http.Error(w, "Access requests are turned off", http.StatusForbidden)
+		return
+	}
+	s.pruneAccessRequests()
+	pending := 0
+	for id, other := range s.accessRequests {
+		if other.status != AccessRequestPending {
+			continue
+		}
+		if other.RemoteAddr == clientIP {
+			delete(s.accessRequests, id)
+			continue
+		}
+		pending++
+	}
+	if pending >= maxAccessRequests {
+		s.mu.Unlock()
+		http.Error(w, "Too many pending requests, try again later", http.StatusTooManyRequests)
+		return
+	}
+	s.accessRequests[req.ID] = req
+	snapshot := *req
+	s.mu.Unlock()
+
+	logging.Info("Remote access requested", "name", name, "ip", clientIP, "fingerprint", req.Fingerprint)
+	s.auditRequest(r, "", AuditAccessRequest, "", fmt.Sprintf("%s (%s)", name, req.Fingerprint))
+	cb(snapshot)
+
+	writeJSON(w, http.StatusAccepted, map[string]string{
+		"id":          req.ID,
+		"fingerprint": req.Fingerprint,
+		"status":      AccessRequestPending,
+	})
+}
+
+// handleAccessRequestStatus answers a device polling for its request
+// (GET /api/access-requests/{id} with the device key in X-Device-Key). An
+// approved request returns the token once and is then forgotten.
+func (s *Server) handleAccessRequestStatus(w http.ResponseWriter, r *http.Request) {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	keyHash := sha256.Sum256([]byte(r.Header.Get("X-Device-Key")))
+
+	s.mu.Lock()
+	s.pruneAccessRequests()
+	req, exists := s.accessRequests[r.PathValue("id")]
+	if !exists || subtle.ConstantTimeCompare(req.keyHash[:], keyHash[:]) != 1 {
+		s.mu.Unlock()
+		if exists {
+			s.recordFailedAuth(clientIP)
+		}
+		http.Error(w, "Request not found or expired", http.StatusNotFound)
+		return
+	}
+	req.lastPoll = time.Now()
+	resp := map[string]string{"status": req.status}
+	if req.status != AccessRequestPending {
+		if req.token != "" {
+			resp["token"] = req.token
+		}
+		delete(s.accessRequests, req.ID)
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// requestAccess posts an access request and returns the response status
+func requestAccess(s *Server, remoteAddr, forwardedFor string) int {
+	body := `{"name": "phone", "key": "` + strings.Repeat("ab", 16) + `"}`
+	r := httptest.NewRequest(http.MethodPost, "/api/access-requests", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	s.handleCreateAccessRequest(w, r)
+	return w.Code
+}
+
+func TestAccessRequestsKeyedOnPeerAddress(t *testing.T) {
+	s := NewServer(nil)
+	s.SetAccessRequestCallback(func(AccessRequest) {})
+
+	// Rotating X-Forwarded-For keeps replacing the one request of the address
+	for i := 0; i < maxAccessRequests+1; i++ {
+		if code := requestAccess(s, "203.0.113.9:51000", fmt.Sprintf("198.51.100.%d", i+1)); code != http.StatusAccepted {
+			t.Fatalf("request %d status = %d, want %d", i, code, http.StatusAccepted)
+		}
+	}
+	if pending := s.GetAccessRequests(); len(pending) != 1 || pending[0].RemoteAddr != "203.0.113.9" {
+		t.Fatalf("pending = %+v, want one request from 203.0.113.9", pending)
+	}
+
+	// Devices behind the tunnel count separately, up to the limit
+	for i := 1; i < maxAccessRequests; i++ {
+		if code := requestAccess(s, "127.0.0.1:51000", fmt.Sprintf("10.0.0.1, 198.51.100.%d", i)); code != http.StatusAccepted {
+			t.Fatalf("tunnel request %d status = %d, want %d", i, code, http.StatusAccepted)
+		}
+	}
+	if code := requestAccess(s, "127.0.0.1:51000", "198.51.100.99"); code != http.StatusTooManyRequests {
+		t.Fatalf("request past the limit status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+
+	// A request its device stopped polling for frees its slot
+	s.mu.Lock()
+	for _, req := range s.accessRequests {
+		if req.RemoteAddr == "203.0.113.9" {
+			req.lastPoll = time.Now().Add(-2 * accessRequestIdle)
+		}
+	}
+	s.mu.Unlock()
+	if code := requestAccess(s, "127.0.0.1:51000", "198.51.100.99"); code != http.StatusAccepted {
+		t.Fatalf("request after a stale one status = %d, want %d", code, http.StatusAccepted)
+	}
+	if pending := s.GetAccessRequests(); len(pending) != maxAccessRequests {
+		t.Errorf("pending = %d, want %d", len(pending), maxAccessRequests)
+	}
+}
@@ -0,0 +1,233 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"projecthub/internal/logging"
+)
+
+// maxAPIBody limits the JSON bodies of REST requests
+const maxAPIBody = 64 << 10
+
+// TestRunInfo describes a test run started over the REST API
+type TestRunInfo struct {
+	TerminalID string `json:"terminalId"`
+	Command    string `json:"command"`
+}
+
+// authorizeAPI checks a REST request's token, from the Authorization header
+// or the token query parameter, and returns it. On failure the response is
+// written and "" returned.
+func (s *Server) authorizeAPI(w http.ResponseWriter, r *http.Request) string {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return ""
+	}
+
+	token := r.Header.Get("Authorization")
+	if strings.HasPrefix(token, "Bearer ") {
+		token = strings.TrimPrefix(token, "Bearer ")
+	} else {
+		token = r.URL.Query().Get("token")
+	}
+
+	if !s.validateToken(token) {
+		s.recordFailedAuth(clientIP)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return ""
+	}
+
+	s.resetAuthAttempts(clientIP)
+	if !s.checkSecondFactor(w, r, token) {
+		return ""
+	}
+	return token
+}
+
+// writeJSON sends a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Error("Failed to encode API response", "error", err)
+	}
+}
+
+// handleAPIProjects lists the projects the client may see, with their
+// terminals (GET /api/projects)
+func (s *Server) handleAPIProjects(w http.ResponseWriter, r *http.Request) {
+	token := s.authorizeAPI(w, r)
+	if token == "" {
+		return
+	}
+
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	projects := []ProjectInfo{}
+	if handler != nil {
+		projects = scopedProjects(handler.GetProjects(), s.approvedScope(token))
+	}
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// handleAPITerminalInput types into a terminal (POST
+// /api/terminals/{id}/input with {"data": "..."}). A trailing "\r" or "\n"
+// presses Enter. iTerm2 tabs are switched to first, since iTerm2 takes input
+// in its active session only.
+func (s *Server) handleAPITerminalInput(w http.ResponseWriter, r *http.Request) {
+	token := s.authorizeAPI(w, r)
+	if token == "" {
+		return
+	}
+	if s.IsViewerToken(token) {
+		http.Error(w, "View-only device", http.StatusForbidden)
+		return
+	}
+
+	var body struct {
+		Data string `json:"data"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&body); err != nil || body.Data == "" {
+		http.Error(w, "Expected {\"data\": \"...\"}", http.StatusBadRequest)
+		return
+	}
+
+	termID := r.PathValue("id")
+	scope := s.approvedScope(token)
+
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	var windowID, tabIndex int
+	switch {
+	case handler != nil && handler.HasTerminal(termID):
+		if !scope.allowsProjectTerminals(s.terminalProject(termID)) {
+			http.Error(w, "Access to this terminal is not allowed for this device", http.StatusForbidden)
+			return
+		}
+		if err := handler.WriteTerminal(termID, []byte(body.Data)); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to write to terminal: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+	case s.itermController != nil && parseITermID(termID, &windowID, &tabIndex):
+		if !scope.allowsITerm() {
+			http.Error(w, "Access to iTerm2 is not allowed for this device", http.StatusForbidden)
+			return
+		}
+		if err := s.itermController.SwitchTab(windowID, tabIndex); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to switch tab: %v", err), http.StatusInternalServerError)
+			return
+		}
+		input, pressEnter := splitITermInput(body.Data)
+		if err := s.itermController.WriteText(input, pressEnter); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to write to iTerm2: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+	default:
+		http.Error(w, "Terminal not found", http.StatusNotFound)
+		return
+	}
+
+	s.auditRequest(r, token, AuditInput, termID, describeInput(body.Data))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAPIRunTests runs a project's test suite in a terminal (POST
+// /api/tests/run with {"projectId": "...", "terminalId": "..."}; without a
+// terminal a new one is created). The results are read back with GET
+// /api/tests/{terminalId}.
+func (s *Server) handleAPIRunTests(w http.ResponseWriter, r *http.Request) {
+	token := s.authorizeAPI(w, r)
+	if token == "" {
+		return
+	}
+	if s.IsViewerToken(token) {
+		http.Error(w, "View-only device", http.StatusForbidden)
+		return
+	}
+
+	var body struct {
+		ProjectID  string `json:"projectId"`
+		TerminalID string `json:"terminalId"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&body); err != nil || body.ProjectID == "" {
+		http.Error(w, "Expected {\"projectId\": \"...\"}", http.StatusBadRequest)
+		return
+	}
+	if !s.approvedScope(token).allowsProjectTerminals(body.ProjectID) {
+		http.Error(w, "Access to this project is not allowed for this device", http.StatusForbidden)
+		return
+	}
+	if body.TerminalID != "" && s.terminalProject(body.TerminalID) != body.ProjectID {
+		http.Error(w, "Terminal not found in project", http.StatusNotFound)
+		return
+	}
+
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		http.Error(w, "Project handler not configured", http.StatusServiceUnavailable)
+		return
+	}
+	run, err := handler.RunTests(body.ProjectID, body.TerminalID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to run tests: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	s.auditRequest(r, token, AuditRunTests, run.TerminalID, run.Command)
+	if body.TerminalID == "" {
+		s.BroadcastProjectsList() // The new terminal
+	}
+	writeJSON(w, http.StatusAccepted, run)
+}
+
+// handleAPITestStatus returns the latest test run seen in a terminal (GET
+// /api/tests/{terminalId}): running, passed or failed, with the counts and
+// failed tests
+func (s *Server) handleAPITestStatus(w http.ResponseWriter, r *http.Request) {
+	token := s.authorizeAPI(w, r)
+	if token == "" {
+		return
+	}
+
+	termID := r.PathValue("terminalId")
+	projectID := s.terminalProject(termID)
+	if projectID == "" {
+		http.Error(w, "Terminal not found", http.StatusNotFound)
+		return
+	}
+	if !s.approvedScope(token).allowsProjectTerminals(projectID) {
+		http.Error(w, "Access to this terminal is not allowed for this device", http.StatusForbidden)
+		return
+	}
+
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	summary := handler.GetTestSummary(termID)
+	if summary == nil {
+		http.Error(w, "No test run in this terminal yet", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// parseITermID reads an iTerm2 terminal ID: "iterm-{windowId}-{tabIndex}"
+func parseITermID(termID string, windowID, tabIndex *int) bool {
+	_, err := fmt.Sscanf(termID, "iterm-%d-%d", windowID, tabIndex)
+	return err == nil
+}
@@ -0,0 +1,261 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"projecthub/internal/logging"
+)
+
+// Audit log settings
+const (
+	AuditLogFile      = "remote-audit.log"
+	maxAuditLogSize   = 5 << 20 // Rotate to AuditLogFile.1 past this size
+	maxAuditInputText = 80      // Longest input text kept in a summary
+)
+
+// Remote actions recorded in the audit log
+const (
+	AuditConnect        = "connect"
+	AuditDisconnect     = "disconnect"
+	AuditInput          = "input"
+	AuditCreateTerminal = "create-terminal"
+	AuditRenameTerminal = "rename-terminal"
+	AuditDeleteTerminal = "delete-terminal"
+	AuditSwitchTab      = "switch-tab"
+	AuditDownload       = "download"
+	AuditUpload         = "upload"
+	AuditRunTests       = "run-tests"
+	AuditSecondFactor   = "second-factor"
+	AuditAccessRequest  = "access-request"
+	AuditContainer      = "container"
+)
+
+// AuditEntry is one action of a remote client
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	ClientID   string    `json:"clientId,omitempty"`   // WebSocket connection; empty for HTTP requests
+	ClientName string    `json:"clientName,omitempty"` // Saved device name; empty for temporary tokens
+	RemoteAddr string    `json:"remoteAddr"`
+	Action     string    `json:"action"`
+	Terminal   string    `json:"terminal,omitempty"`
+	Summary    string    `json:"summary"`
+}
+
+// AuditLog keeps remote client actions in a JSON lines file, so they can be
+// reviewed after the fact
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewAuditLog creates an audit log writing to path
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Record appends an entry. Failures are logged rather than returned: an
+// action isn't refused because it couldn't be recorded.
+func (l *AuditLog) Record(entry AuditEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		logging.Warn("Failed to write remote audit log", "error", err)
+		return
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		logging.Warn("Failed to write remote audit log", "error", err)
+		return
+	}
+	_, err = f.Write(append(line, '\n'))
+	info, statErr := f.Stat()
+	f.Close()
+	if err != nil {
+		logging.Warn("Failed to write remote audit log", "error", err)
+		return
+	}
+	if statErr == nil && info.Size() > maxAuditLogSize {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			logging.Warn("Failed to rotate remote audit log", "error", err)
+		}
+	}
+}
+
+// Entries returns up to limit entries, newest first; limit <= 0 returns all
+// of them. The rotated file is read too.
+func (l *AuditLog) Entries(limit int) ([]AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entries []AuditEntry
+	for _, path := range []string{l.path + ".1", l.path} {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var entry AuditEntry
+			if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+				entries = append(entries, entry)
+			}
+		}
+		f.Close()
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	if entries == nil {
+		entries = []AuditEntry{}
+	}
+	return entries, nil
+}
+
+// Export returns all entries, oldest first, as "csv" or "json"
+func (l *AuditLog) Export(format string) ([]byte, error) {
+	entries, err := l.Entries(0)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	switch format {
+	case "json":
+		return json.MarshalIndent(entries, "", "  ")
+	case "csv":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"time", "action", "client", "client_id", "remote_addr", "terminal", "summary"})
+		for _, e := range entries {
+			w.Write([]string{
+				e.Time.Format(time.RFC3339), e.Action, e.ClientName, e.ClientID,
+				e.RemoteAddr, e.Terminal, e.Summary,
+			})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+	return nil, fmt.Errorf("unknown export format: %s", format)
+}
+
+// SetAuditLog sets where remote client actions are recorded
+func (s *Server) SetAuditLog(log *AuditLog) {
+	s.mu.Lock()
+	s.auditLog = log
+	s.mu.Unlock()
+}
+
+// audit records an action of a WebSocket client
+func (s *Server) audit(client *ClientInfo, action, terminal, summary string) {
+	s.mu.RLock()
+	log := s.auditLog
+	s.mu.RUnlock()
+
+	if log == nil {
+		return
+	}
+	entry := AuditEntry{
+		ClientID:   client.ID,
+		RemoteAddr: client.RemoteAddr,
+		Action:     action,
+		Terminal:   terminal,
+		Summary:    summary,
+	}
+	if client.scope != nil {
+		entry.ClientName = client.scope.Name
+	}
+	log.Record(entry)
+}
+
+// auditRequest records an action made over HTTP
+func (s *Server) auditRequest(r *http.Request, token, action, terminal, summary string) {
+	s.mu.RLock()
+	log := s.auditLog
+	s.mu.RUnlock()
+
+	if log == nil {
+		return
+	}
+	entry := AuditEntry{
+		RemoteAddr: getClientIP(r),
+		Action:     action,
+		Terminal:   terminal,
+		Summary:    summary,
+	}
+	if scope := s.approvedScope(token); scope != nil {
+		entry.ClientName = scope.Name
+	}
+	log.Record(entry)
+}
+
+// inputKeyNames names the keys the web client sends on their own
+var inputKeyNames = map[string]string{
+	"\r":     "Enter",
+	"\n":     "Enter",
+	"\x1b":   "Esc",
+	"\x1b[Z": "Shift+Tab",
+	"\t":     "Tab",
+	"\x03":   "Ctrl+C",
+	"\x04":   "Ctrl+D",
+	"\x0f":   "Ctrl+O",
+	"\x7f":   "Backspace",
+}
+
+// describeInput summarizes terminal input for the audit log: named keys,
+// or the text with control characters escaped, shortened when long
+func describeInput(data string) string {
+	if name, ok := inputKeyNames[data]; ok {
+		return name
+	}
+	text, enter := strings.CutSuffix(data, "\r")
+	if !enter {
+		text, enter = strings.CutSuffix(data, "\n")
+	}
+	var b strings.Builder
+	for _, r := range text {
+		if unicode.IsPrint(r) {
+			b.WriteRune(r)
+		} else {
+			quoted := strconv.QuoteRune(r)
+			b.WriteString(quoted[1 : len(quoted)-1])
+		}
+	}
+	summary := b.String()
+	if runes := []rune(summary); len(runes) > maxAuditInputText {
+		summary = string(runes[:maxAuditInputText]) + "…"
+	}
+	if enter {
+		summary += " + Enter"
+	}
+	return summary
+}
@@ -0,0 +1,96 @@
+package remote
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// Default rate-limit policy for failed authentication
+const (
+	DefaultMaxAuthAttempts    = 50 // Failed attempts before a lockout
+	DefaultAuthLockoutMinutes = 1  // Lockout duration
+)
+
+// BannedIP is an address refused for good, until it is unbanned
+type BannedIP struct {
+	IP       string    `json:"ip"`
+	BannedAt time.Time `json:"bannedAt"`
+}
+
+// AuthAttemptInfo describes an address with failed authentication attempts
+type AuthAttemptInfo struct {
+	IP          string    `json:"ip"`
+	Failures    int       `json:"failures"`
+	LastAttempt time.Time `json:"lastAttempt"`
+	Locked      bool      `json:"locked"`
+	LockedUntil time.Time `json:"lockedUntil"` // Zero unless locked
+}
+
+// SetAuthPolicy sets how many failed attempts lock an address out, and for
+// how long
+func (s *Server) SetAuthPolicy(maxAttempts int, lockout time.Duration) {
+	s.authMu.Lock()
+	s.maxAuthAttempts = maxAttempts
+	s.authLockout = lockout
+	s.authMu.Unlock()
+}
+
+// GetAuthAttempts returns the addresses with recent failed attempts,
+// locked ones first
+func (s *Server) GetAuthAttempts() []AuthAttemptInfo {
+	s.authMu.RLock()
+	defer s.authMu.RUnlock()
+
+	attempts := make([]AuthAttemptInfo, 0, len(s.authAttempts))
+	for ip, attempt := range s.authAttempts {
+		until := attempt.lastTime.Add(s.authLockout)
+		if time.Now().After(until) {
+			continue // Expired; forgotten on the next request
+		}
+		info := AuthAttemptInfo{IP: ip, Failures: attempt.count, LastAttempt: attempt.lastTime}
+		if attempt.count >= s.maxAuthAttempts {
+			info.Locked = true
+			info.LockedUntil = until
+		}
+		attempts = append(attempts, info)
+	}
+	sort.Slice(attempts, func(i, j int) bool {
+		if attempts[i].Locked != attempts[j].Locked {
+			return attempts[i].Locked
+		}
+		return attempts[i].LastAttempt.After(attempts[j].LastAttempt)
+	})
+	return attempts
+}
+
+// UnlockIP forgets an address's failed attempts, lifting its lockout
+func (s *Server) UnlockIP(ip string) {
+	s.resetAuthAttempts(ip)
+	logging.Info("Remote access address unlocked", "ip", ip)
+}
+
+// SetBannedIPs replaces the banned addresses (loading from persistence)
+func (s *Server) SetBannedIPs(bans []BannedIP) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	s.bannedIPs = make(map[string]BannedIP, len(bans))
+	for _, ban := range bans {
+		s.bannedIPs[ban.IP] = ban
+	}
+}
+
+// ValidateIP checks that a ban is for an IP address and returns it in its
+// canonical form
+func ValidateIP(ip string) (string, error) {
+	parsed := net.ParseIP(strings.TrimSpace(ip))
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address: %s", ip)
+	}
+	return parsed.String(), nil
+}
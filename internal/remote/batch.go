@@ -0,0 +1,82 @@
+package remote
+
+import "time"
+
+// Output batching: chatty builds print many small chunks, and a WebSocket
+// frame each costs mobile clients bandwidth and battery. Output is held for
+// a short while and sent as one frame.
+const (
+	outputFlushInterval = 50 * time.Millisecond
+	maxOutputBatch      = 64 << 10 // Sent right away past this size
+)
+
+// outputBatch is the output of a terminal waiting to be sent
+type outputBatch struct {
+	data  []byte
+	seq   int64 // replay offset data ends at; 0 for iTerm2
+	timer *time.Timer
+}
+
+// queueOutput adds output to its terminal's batch. App terminals stream, so
+// their chunks are joined and kept for replay; iTerm2's output (termID "")
+// is the whole screen, so only the latest is kept.
+func (s *Server) queueOutput(termID string, data []byte) {
+	s.batchMu.Lock()
+	batch, exists := s.outputBatches[termID]
+	if !exists {
+		batch = &outputBatch{}
+		s.outputBatches[termID] = batch
+	}
+	if termID == "" {
+		batch.data = data
+	} else {
+		batch.data = append(batch.data, data...)
+		batch.seq = s.appendReplay(termID, data)
+	}
+	full := len(batch.data) >= maxOutputBatch
+	if !full && batch.timer == nil {
+		batch.timer = time.AfterFunc(outputFlushInterval, func() {
+			s.flushOutput(termID)
+		})
+	}
+	s.batchMu.Unlock()
+
+	if full {
+		s.flushOutput(termID)
+	}
+}
+
+// flushOutput sends a terminal's batched output. Flushes are serialized so
+// a terminal's batches reach clients in order.
+func (s *Server) flushOutput(termID string) {
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	s.batchMu.Lock()
+	batch, exists := s.outputBatches[termID]
+	if exists {
+		delete(s.outputBatches, termID)
+		if batch.timer != nil {
+			batch.timer.Stop()
+		}
+	}
+	s.batchMu.Unlock()
+
+	if exists && len(batch.data) > 0 {
+		s.sendOutput(termID, batch.data, batch.seq)
+	}
+}
+
+// dropOutputBatches forgets output not sent yet and the output kept for
+// replay
+func (s *Server) dropOutputBatches() {
+	s.batchMu.Lock()
+	s.replays = make(map[string]*outputReplay)
+	for termID, batch := range s.outputBatches {
+		if batch.timer != nil {
+			batch.timer.Stop()
+		}
+		delete(s.outputBatches, termID)
+	}
+	s.batchMu.Unlock()
+}
@@ -0,0 +1,75 @@
+package remote
+
+import (
+	"net"
+	"strings"
+)
+
+// Bind addresses of the remote access server. Any IPv4 or IPv6 address of
+// the machine can be given as well.
+const (
+	BindAuto         = ""          // loopback when tunneling through ngrok, every interface otherwise
+	BindAll          = "::"        // every interface, IPv4 and IPv6
+	BindAllIPv4      = "0.0.0.0"   // every IPv4 interface
+	BindLoopback     = "127.0.0.1" // this machine only
+	BindLoopbackIPv6 = "::1"
+)
+
+// ListenHost returns the address the server binds to. With BindAuto it is
+// loopback when ngrok forwards to the server, so the plain server isn't
+// exposed to the LAN as well, and every interface when devices connect
+// directly ("").
+func (c Config) ListenHost() string {
+	if c.BindHost != BindAuto {
+		return c.BindHost
+	}
+	if c.Enabled {
+		return BindLoopback
+	}
+	return ""
+}
+
+// normalizeBindHost accepts an IP address, bracketed or not, or "localhost".
+// It returns "" for anything else.
+func normalizeBindHost(host string) string {
+	host = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(host), "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return BindLoopback
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// isWildcardHost reports whether a bind host listens on every interface
+func isWildcardHost(host string) bool {
+	return host == "" || host == BindAll || host == BindAllIPv4
+}
+
+// LocalHost returns the host this machine reaches the server at
+func LocalHost(bindHost string) string {
+	if isWildcardHost(bindHost) || bindHost == BindLoopback {
+		return "localhost"
+	}
+	return bindHost
+}
+
+// LANHost returns the host other devices on the network reach the server
+// at, or "" when the server only listens on loopback
+func LANHost(bindHost string) string {
+	if !isWildcardHost(bindHost) {
+		if ip := net.ParseIP(bindHost); ip == nil || ip.IsLoopback() {
+			return ""
+		}
+		return bindHost
+	}
+	for _, addr := range LANAddresses() {
+		if bindHost == BindAllIPv4 && net.ParseIP(addr).To4() == nil {
+			continue
+		}
+		return addr
+	}
+	return ""
+}
@@ -0,0 +1,1340 @@
+const STORAGE_KEY = 'claudilandia_remote_token';
+
+// Get token from URL or localStorage
+const params = new URLSearchParams(window.location.search);
+let token = params.get('token');
+
+if (!token) {
+    token = localStorage.getItem(STORAGE_KEY);
+}
+
+if (!token) {
+    showRequestPrompt('Claudilandia needs to approve this device before it can connect.');
+}
+
+// Check if token is approved and save to localStorage. Returns the token
+// info, false when the token was rejected, or null when the check failed.
+async function checkAndSaveToken() {
+    if (!token) return false;
+    try {
+        const response = await fetch('/api/token-info?token=' + encodeURIComponent(token));
+        if (response.ok) {
+            const data = await response.json();
+            if (data.approved) {
+                localStorage.setItem(STORAGE_KEY, token);
+                initPush();
+            }
+            if (data.viewer) {
+                enterViewerMode();
+            }
+            if (data.transcribe) {
+                enableServerSpeech();
+            }
+            document.getElementById('dockerBtn').style.display = data.docker ? '' : 'none';
+            return data;
+        } else if (response.status === 401) {
+            localStorage.removeItem(STORAGE_KEY);
+            token = null;
+            showRequestPrompt('Your saved token is no longer valid. Ask Claudilandia for access again.');
+            return false;
+        }
+    } catch (err) {
+        console.error('Failed to check token:', err);
+    }
+    return null;
+}
+
+// State
+let ws = null;
+let itermTabs = []; // iTerm2 tabs
+let appTerminals = []; // Claudilandia terminals, streamed per terminal
+let terminals = [];
+let currentTerminalId = null;
+let currentIsApp = false;
+let streamText = ''; // Output of the subscribed app terminal
+let streamSeq = 0; // Offset its output was shown up to, to resume after a reconnect
+let projectList = []; // { id, name } of the projects, in order
+let gitStatuses = {}; // projectId -> { branch, staged, unstaged, untracked }
+let terminalStatuses = {}; // termId -> { claude, tests }
+const STATUS_REFRESH_INTERVAL = 60000;
+let statusInterval = null;
+const MAX_STREAM_TEXT = 200000;
+let terminalEl = null;
+let inputBuffer = '';
+let reconnectAttempts = 0;
+let reconnectTimeout = null;
+
+// Strip ANSI escape codes
+function stripAnsi(str) {
+    return str.replace(/\x1B\[[0-9;]*[a-zA-Z]/g, '')
+              .replace(/\x1B\][^\x07]*\x07/g, '')
+              .replace(/\x1B[()][AB012]/g, '');
+}
+
+// Strip box drawing characters
+function stripBoxChars(str) {
+    return str.replace(/[\u2500-\u257F\u2580-\u259F\u25A0-\u25FF\u2800-\u28FF]/g, '');
+}
+
+// Clean terminal output for display
+function cleanOutput(str) {
+    let cleaned = stripAnsi(str);
+    cleaned = stripBoxChars(cleaned);
+    // Collapse multiple spaces but preserve structure
+    cleaned = cleaned.replace(/[ \t]{3,}/g, '  ');
+    // Remove empty lines but keep some spacing
+    cleaned = cleaned.split('\n')
+        .filter((line, i, arr) => {
+            const trimmed = line.trim();
+            // Keep non-empty lines
+            if (trimmed) return true;
+            // Keep one empty line between sections
+            const prevTrimmed = i > 0 ? arr[i-1].trim() : '';
+            return prevTrimmed !== '';
+        })
+        .join('\n');
+    return cleaned;
+}
+
+// Initialize terminal
+function initTerminal() {
+    terminalEl = document.getElementById('terminal');
+}
+
+// Check the token, then connect. Devices with a second factor enter a code
+// first in a browser that hasn't been confirmed yet.
+let connecting = false;
+
+async function connect() {
+    if (connecting) return;
+    connecting = true;
+    try {
+        const info = await checkAndSaveToken();
+        if (info === false) return;
+        if (info && info.secondFactor) {
+            showCodePrompt();
+            return;
+        }
+        openSocket();
+    } finally {
+        connecting = false;
+    }
+}
+
+function showCodePrompt() {
+    document.getElementById('loadingOverlay').classList.add('hidden');
+    document.getElementById('codeOverlay').classList.remove('hidden');
+    setStatus('disconnected', 'Verification needed');
+    const input = document.getElementById('codeInput');
+    input.value = '';
+    input.focus();
+}
+
+async function submitCode() {
+    const input = document.getElementById('codeInput');
+    const error = document.getElementById('codeError');
+    const code = input.value.replace(/\s/g, '');
+    if (code.length !== 6) {
+        error.textContent = 'Enter the 6-digit code';
+        return;
+    }
+    error.textContent = '';
+    try {
+        const response = await fetch('/api/totp', {
+            method: 'POST',
+            headers: { 'Authorization': 'Bearer ' + token, 'Content-Type': 'application/json' },
+            body: JSON.stringify({ code: code })
+        });
+        if (!response.ok) {
+            input.value = '';
+            error.textContent = response.status === 429 ? 'Too many attempts, try again later' : 'Wrong code';
+            return;
+        }
+    } catch (err) {
+        error.textContent = 'Could not reach Claudilandia';
+        return;
+    }
+    document.getElementById('codeOverlay').classList.add('hidden');
+    document.getElementById('loadingOverlay').classList.remove('hidden');
+    openSocket();
+}
+
+document.getElementById('codeSubmitBtn').addEventListener('click', submitCode);
+document.getElementById('codeInput').addEventListener('keydown', (e) => {
+    if (e.key === 'Enter') submitCode();
+});
+
+// Devices without a token ask for access; the desktop app approves them and
+// the token is picked up by polling. The device key proves this device made
+// the request and gives it the code shown on both screens.
+const DEVICE_KEY_STORAGE = 'claudilandia_device_key';
+const ACCESS_POLL_INTERVAL = 3000;
+let accessRequestId = null;
+let accessPollTimer = null;
+
+function deviceKey() {
+    let key = localStorage.getItem(DEVICE_KEY_STORAGE);
+    if (!key) {
+        const bytes = crypto.getRandomValues(new Uint8Array(32));
+        key = Array.from(bytes, b => b.toString(16).padStart(2, '0')).join('');
+        localStorage.setItem(DEVICE_KEY_STORAGE, key);
+    }
+    return key;
+}
+
+function defaultDeviceName() {
+    const ua = navigator.userAgent;
+    if (/iPad/i.test(ua)) return 'iPad';
+    if (/iPhone/i.test(ua)) return 'iPhone';
+    if (/Android/i.test(ua)) return 'Android';
+    if (/Macintosh/i.test(ua)) return 'Mac';
+    if (/Windows/i.test(ua)) return 'Windows PC';
+    return '';
+}
+
+function showRequestPrompt(message) {
+    document.getElementById('loadingOverlay').classList.add('hidden');
+    document.getElementById('errorOverlay').classList.add('hidden');
+    document.getElementById('requestOverlay').classList.remove('hidden');
+    document.getElementById('requestMessage').textContent = message;
+    setStatus('disconnected', 'Not approved');
+    resetAccessRequest('');
+}
+
+// Back to the name form, e.g. after a denied or expired request
+function resetAccessRequest(error) {
+    clearTimeout(accessPollTimer);
+    accessRequestId = null;
+    const input = document.getElementById('deviceNameInput');
+    if (!input.value) input.value = defaultDeviceName();
+    input.style.display = '';
+    document.getElementById('requestBtn').style.display = '';
+    document.getElementById('requestCode').style.display = 'none';
+    document.getElementById('requestError').textContent = error;
+}
+
+async function submitAccessRequest() {
+    const input = document.getElementById('deviceNameInput');
+    const error = document.getElementById('requestError');
+    const name = input.value.trim();
+    if (!name) {
+        error.textContent = 'Enter a name for this device';
+        return;
+    }
+    error.textContent = '';
+    try {
+        const response = await fetch('/api/access-requests', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ name: name, key: deviceKey() })
+        });
+        if (!response.ok) {
+            if (response.status === 403) {
+                error.textContent = 'Access requests are turned off. Use the link from Claudilandia.';
+            } else {
+                error.textContent = (await response.text()).trim() || 'Request failed';
+            }
+            return;
+        }
+        const data = await response.json();
+        accessRequestId = data.id;
+        input.style.display = 'none';
+        document.getElementById('requestBtn').style.display = 'none';
+        const code = document.getElementById('requestCode');
+        code.innerHTML = 'Waiting for approval. Approve only if Claudilandia shows this code:<strong></strong>';
+        code.querySelector('strong').textContent = data.fingerprint;
+        code.style.display = '';
+        accessPollTimer = setTimeout(pollAccessRequest, ACCESS_POLL_INTERVAL);
+    } catch (err) {
+        error.textContent = 'Could not reach Claudilandia';
+    }
+}
+
+async function pollAccessRequest() {
+    const id = accessRequestId;
+    if (!id) return;
+    try {
+        const response = await fetch('/api/access-requests/' + encodeURIComponent(id), {
+            headers: { 'X-Device-Key': deviceKey() }
+        });
+        if (id !== accessRequestId) return;
+        if (response.status === 404) {
+            resetAccessRequest('The request expired. Ask again.');
+            return;
+        }
+        if (response.ok) {
+            const data = await response.json();
+            if (data.status === 'approved' && data.token) {
+                accessRequestId = null;
+                token = data.token;
+                localStorage.setItem(STORAGE_KEY, token);
+                document.getElementById('requestOverlay').classList.add('hidden');
+                document.getElementById('loadingOverlay').classList.remove('hidden');
+                connect();
+                return;
+            }
+            if (data.status === 'denied') {
+                resetAccessRequest('Access was denied.');
+                return;
+            }
+        }
+    } catch (err) {
+        // Offline for a moment; keep asking
+    }
+    accessPollTimer = setTimeout(pollAccessRequest, ACCESS_POLL_INTERVAL);
+}
+
+document.getElementById('requestBtn').addEventListener('click', submitAccessRequest);
+document.getElementById('deviceNameInput').addEventListener('keydown', (e) => {
+    if (e.key === 'Enter') submitAccessRequest();
+});
+
+// Connect WebSocket
+function openSocket() {
+    const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+    const wsUrl = protocol + '//' + window.location.host + '/ws/terminal?token=' + token;
+
+    ws = new WebSocket(wsUrl);
+
+    ws.onopen = () => {
+        hideOverlays();
+        setStatus('connected', 'Connected');
+        reconnectAttempts = 0;
+        ws.send(JSON.stringify({ type: 'list' }));
+        requestStatus();
+        // Resume the app terminal stream after a reconnect; the server
+        // replays the output sent meanwhile
+        if (currentIsApp && currentTerminalId) {
+            ws.send(JSON.stringify({ type: 'subscribe', termId: currentTerminalId, seq: streamSeq }));
+        }
+    };
+
+    ws.onmessage = (event) => {
+        try {
+            const msg = JSON.parse(event.data);
+            handleMessage(msg);
+        } catch (err) {
+            console.error('Error handling message:', err);
+        }
+    };
+
+    ws.onclose = () => {
+        setStatus('disconnected', 'Disconnected');
+        scheduleReconnect();
+    };
+
+    ws.onerror = (error) => {
+        console.error('WebSocket error:', error);
+        setStatus('disconnected', 'Error');
+    };
+}
+
+// Decode base64 to UTF-8
+function base64ToUtf8(base64) {
+    try {
+        return new TextDecoder('utf-8').decode(base64ToBytes(base64));
+    } catch (err) {
+        return base64 || '';
+    }
+}
+
+function base64ToBytes(base64) {
+    const binary = atob(base64);
+    return Uint8Array.from(binary, c => c.charCodeAt(0));
+}
+
+// Handle server messages
+let lastOutputHash = '';
+
+function handleMessage(msg) {
+    switch (msg.type) {
+        case 'output':
+            if (msg.termId) {
+                if (currentIsApp && msg.termId === currentTerminalId) {
+                    appendStreamOutput(msg);
+                }
+                break;
+            }
+            if (!currentIsApp && terminalEl && document.getElementById('terminalView').classList.contains('active')) {
+                const decoded = base64ToUtf8(msg.data);
+                // Simple hash to detect if content changed
+                const hash = decoded.length + ':' + decoded.substring(0, 100);
+                if (hash !== lastOutputHash) {
+                    lastOutputHash = hash;
+                    const cleaned = cleanOutput(decoded);
+                    terminalEl.textContent = cleaned;
+                    // Auto-scroll to bottom
+                    terminalEl.scrollTop = terminalEl.scrollHeight;
+                }
+            }
+            break;
+
+        case 'terminals':
+            itermTabs = msg.terminals || [];
+            updateTerminals();
+            break;
+
+        case 'projects':
+            // Extract terminals from all projects
+            const allTerminals = [];
+            if (msg.projects) {
+                msg.projects.forEach(p => {
+                    if (p.terminals) {
+                        p.terminals.forEach(t => {
+                            allTerminals.push({
+                                id: t.id,
+                                projectId: p.id,
+                                name: t.name || p.name,
+                                running: t.running,
+                                projectName: p.name,
+                                isApp: true
+                            });
+                        });
+                    }
+                });
+            }
+            appTerminals = allTerminals;
+            projectList = (msg.projects || []).map(p => ({ id: p.id, name: p.name }));
+            updateTerminals();
+            break;
+
+        case 'status':
+            gitStatuses = {};
+            terminalStatuses = {};
+            (msg.statuses || []).forEach(p => {
+                if (p.git) gitStatuses[p.projectId] = p.git;
+                (p.terminals || []).forEach(t => {
+                    terminalStatuses[t.id] = { claude: t.claude || '', tests: t.tests || null };
+                });
+            });
+            renderTerminals();
+            break;
+
+        case 'gitStatus':
+            gitStatuses[msg.projectId] = msg.git;
+            renderTerminals();
+            break;
+
+        case 'claudeStatus':
+            terminalStatus(msg.termId).claude = msg.claude || '';
+            renderTerminals();
+            break;
+
+        case 'testStatus':
+            terminalStatus(msg.termId).tests = msg.tests || null;
+            renderTerminals();
+            break;
+
+        case 'subscribed':
+            // A fresh subscription, or a server that restarted, starts here
+            if (msg.termId === currentTerminalId && (!streamSeq || (msg.seq || 0) < streamSeq)) {
+                streamSeq = msg.seq || 0;
+            }
+            sendResize();
+            break;
+
+        case 'containers':
+            if (dockerProject && msg.projectId === dockerProject.id) {
+                renderContainers(msg.containers || []);
+            }
+            break;
+
+        case 'error':
+            console.error('Server error:', msg.message);
+            if (dockerOpen && dockerProject) {
+                showDockerMessage(msg.message);
+            }
+            if (terminalEl) {
+                terminalEl.textContent += '\nError: ' + msg.message + '\n';
+            }
+            break;
+
+        case 'pong':
+            clearTimeout(pongTimeout);
+            pongTimeout = null;
+            break;
+    }
+}
+
+// Update terminals list
+function updateTerminals() {
+    terminals = itermTabs.concat(appTerminals);
+    renderTerminals();
+}
+
+// Append app terminal output by offset: a replay after a reconnect may
+// repeat output already shown
+function appendStreamOutput(msg) {
+    let bytes;
+    try {
+        bytes = base64ToBytes(msg.data || '');
+    } catch (err) {
+        return;
+    }
+    if (msg.seq) {
+        if (msg.seq <= streamSeq && !msg.message) return;
+        const start = msg.seq - bytes.length;
+        if (start < streamSeq && !msg.message) {
+            bytes = bytes.slice(streamSeq - start);
+        }
+        streamSeq = msg.seq;
+    }
+    if (msg.message) {
+        appendOutput('\n[' + msg.message + ']\n');
+    }
+    appendOutput(new TextDecoder('utf-8').decode(bytes));
+}
+
+// Append streamed app terminal output, keeping the tail
+function appendOutput(text) {
+    if (!terminalEl) return;
+    streamText += text.replace(/\r\n/g, '\n').replace(/\r/g, '');
+    if (streamText.length > MAX_STREAM_TEXT) {
+        streamText = streamText.slice(-MAX_STREAM_TEXT);
+    }
+    const atBottom = terminalEl.scrollTop + terminalEl.clientHeight >= terminalEl.scrollHeight - 20;
+    terminalEl.textContent = stripAnsi(streamText);
+    if (atBottom) {
+        terminalEl.scrollTop = terminalEl.scrollHeight;
+    }
+}
+
+// Render terminals list
+function renderTerminals() {
+    const list = document.getElementById('terminalList');
+
+    if (terminals.length === 0) {
+        list.innerHTML = renderProjectStatuses() + '<div class="no-terminals">' +
+            '<h3>No Terminals</h3>' +
+            '<p>Open a terminal in iTerm2 or Claudilandia to see it here</p>' +
+            '</div>';
+        return;
+    }
+
+    list.innerHTML = renderProjectStatuses() + terminals.map(t => {
+        let statusText = t.isApp
+            ? escapeHtml(t.projectName || '') + ' · ' + (t.running ? 'Running' : 'Stopped')
+            : (t.running ? 'Active' : 'Idle');
+        if (t.isApp) {
+            statusText += terminalStatusText(terminalStatuses[t.id]);
+        }
+        return '<button class="terminal-btn" data-id="' + escapeHtml(t.id) + '">' +
+            '<span class="icon">' + (t.isApp ? '🖥️' : '💻') + '</span>' +
+            '<span class="info">' +
+            '<span class="name">' + escapeHtml(t.name) + '</span>' +
+            '<span class="status-text">' + statusText + '</span>' +
+            '</span>' +
+            (t.running ? '<span class="active-indicator"></span>' : '') +
+            '</button>';
+    }).join('');
+
+    // Add click handlers
+    list.querySelectorAll('.terminal-btn').forEach(btn => {
+        btn.addEventListener('click', () => {
+            selectTerminal(btn.dataset.id);
+        });
+    });
+}
+
+// Status: git per project, Claude and tests per terminal, so projects can be
+// triaged without reading their output
+
+function requestStatus() {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ type: 'status' }));
+    }
+}
+
+// Git status isn't pushed on every change; refresh it while the page is seen
+function startStatusRefresh() {
+    clearInterval(statusInterval);
+    statusInterval = setInterval(() => {
+        if (document.visibilityState === 'visible') requestStatus();
+    }, STATUS_REFRESH_INTERVAL);
+}
+
+function terminalStatus(termId) {
+    if (!terminalStatuses[termId]) {
+        terminalStatuses[termId] = { claude: '', tests: null };
+    }
+    return terminalStatuses[termId];
+}
+
+const CLAUDE_LABELS = {
+    working: 'Claude working',
+    idle: 'Claude idle',
+    needs_action: 'Needs input',
+    waiting_permission: 'Needs permission',
+    rate_limited: 'Rate limited',
+    compacting: 'Compacting',
+    plan_mode: 'Plan mode'
+};
+const CLAUDE_ATTENTION = ['needs_action', 'waiting_permission', 'rate_limited'];
+
+function terminalStatusText(status) {
+    if (!status) return '';
+    let text = '';
+    if (status.claude && CLAUDE_LABELS[status.claude]) {
+        const cls = CLAUDE_ATTENTION.includes(status.claude) ? 'badge attention' : 'badge';
+        text += ' · <span class="' + cls + '">' + CLAUDE_LABELS[status.claude] + '</span>';
+    }
+    const tests = status.tests;
+    if (tests && tests.status && tests.status !== 'none') {
+        if (tests.status === 'running') {
+            text += ' · <span class="badge">Tests running</span>';
+        } else if (tests.failed > 0) {
+            const names = (tests.failedTests || []).join('\n');
+            text += ' · <span class="badge failed" title="' + escapeHtml(names) + '">' +
+                tests.failed + ' failed, ' + tests.passed + ' passed</span>';
+        } else {
+            text += ' · <span class="badge passed">' + tests.passed + ' passed</span>';
+        }
+    }
+    return text;
+}
+
+function renderProjectStatuses() {
+    const rows = projectList.filter(p => gitStatuses[p.id]).map(p => {
+        const git = gitStatuses[p.id];
+        const counts = [];
+        if (git.staged) counts.push(git.staged + ' staged');
+        if (git.unstaged) counts.push(git.unstaged + ' modified');
+        if (git.untracked) counts.push(git.untracked + ' untracked');
+        return '<div class="project-status">' +
+            '<span class="name">' + escapeHtml(p.name) + '</span>' +
+            '<span class="status-text">' + escapeHtml(git.branch || 'detached') + ' · ' +
+            (counts.length ? counts.join(', ') : 'clean') + '</span>' +
+            '</div>';
+    });
+    return rows.join('');
+}
+
+// Select terminal
+function selectTerminal(termId) {
+    unsubscribeCurrent();
+    currentTerminalId = termId;
+    const terminal = terminals.find(t => t.id === termId);
+    currentIsApp = !!(terminal && terminal.isApp);
+
+    document.getElementById('terminalName').textContent = terminal ? terminal.name : 'Terminal';
+    document.getElementById('terminalSelector').style.display = 'none';
+    document.getElementById('terminalView').classList.add('active');
+
+    // Clear terminal and request fresh output
+    terminalEl.textContent = '';
+    lastOutputHash = '';
+    streamText = '';
+
+    // App terminals stream their own output; iTerm2 tabs are switched to
+    if (currentIsApp) {
+        if (ws && ws.readyState === WebSocket.OPEN) {
+            ws.send(JSON.stringify({ type: 'subscribe', termId: termId }));
+        }
+    } else if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            type: 'switchTab',
+            termId: termId
+        }));
+    }
+}
+
+// Stop streaming the current app terminal
+function unsubscribeCurrent() {
+    if (currentIsApp && currentTerminalId && ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ type: 'unsubscribe', termId: currentTerminalId }));
+    }
+    currentIsApp = false;
+    streamText = '';
+    streamSeq = 0;
+}
+
+// Go back to terminal list
+function goBack() {
+    unsubscribeCurrent();
+    currentTerminalId = null;
+    document.getElementById('terminalView').classList.remove('active');
+    document.getElementById('terminalSelector').style.display = 'flex';
+    // Refresh terminals list
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ type: 'list' }));
+    }
+}
+
+// Send the visible size to an app terminal; iTerm2 sizes its own tabs
+function sendResize() {
+    if (!currentIsApp || !currentTerminalId || !terminalEl) return;
+    if (!ws || ws.readyState !== WebSocket.OPEN) return;
+
+    const style = window.getComputedStyle(terminalEl);
+    const fontSize = parseFloat(style.fontSize) || 13;
+    const lineHeight = parseFloat(style.lineHeight) || fontSize * 1.4;
+    const width = terminalEl.clientWidth - parseFloat(style.paddingLeft || 0) - parseFloat(style.paddingRight || 0);
+    const height = terminalEl.clientHeight - parseFloat(style.paddingTop || 0) - parseFloat(style.paddingBottom || 0);
+    const cols = Math.max(20, Math.floor(width / (fontSize * 0.6)));
+    const rows = Math.max(5, Math.floor(height / lineHeight));
+
+    ws.send(JSON.stringify({
+        type: 'resize',
+        termId: currentTerminalId,
+        rows: rows,
+        cols: cols
+    }));
+}
+
+let resizeTimeout = null;
+window.addEventListener('resize', () => {
+    clearTimeout(resizeTimeout);
+    resizeTimeout = setTimeout(sendResize, 200);
+});
+
+// Read-only tokens can only watch: hide everything that sends input
+let viewerMode = false;
+
+function enterViewerMode() {
+    viewerMode = true;
+    document.querySelectorAll('.keyboard-helper, .input-bar, .toolbar').forEach(el => {
+        el.style.display = 'none';
+    });
+    setStatus('connected', 'Connected');
+}
+
+// Status helpers
+function setStatus(state, text) {
+    document.getElementById('statusDot').className = 'status-dot ' + state;
+    document.getElementById('statusText').textContent = viewerMode && state === 'connected' ? text + ' (view only)' : text;
+}
+
+function hideOverlays() {
+    document.getElementById('loadingOverlay').classList.add('hidden');
+    document.getElementById('errorOverlay').classList.add('hidden');
+}
+
+function showError(title, message) {
+    document.getElementById('loadingOverlay').classList.add('hidden');
+    document.getElementById('errorOverlay').classList.remove('hidden');
+    document.getElementById('errorTitle').textContent = title;
+    document.getElementById('errorMessage').textContent = message;
+}
+
+// Reconnect logic
+function scheduleReconnect() {
+    if (reconnectAttempts >= 10) {
+        showError('Connection Failed', 'Unable to reconnect after multiple attempts.');
+        return;
+    }
+    reconnectAttempts++;
+    const delay = Math.min(1000 * Math.pow(2, reconnectAttempts), 30000);
+    setStatus('disconnected', 'Reconnecting in ' + (delay / 1000) + 's...');
+    reconnectTimeout = setTimeout(() => {
+        setStatus('disconnected', 'Reconnecting...');
+        connect();
+    }, delay);
+}
+
+function reconnect() {
+    clearTimeout(reconnectTimeout);
+    reconnectAttempts = 0;
+    hideOverlays();
+    document.getElementById('loadingOverlay').classList.remove('hidden');
+    connect();
+}
+
+// Escape HTML
+function escapeHtml(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML;
+}
+
+// Send terminal input helper
+function sendTerminalInput(data) {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({
+            type: 'input',
+            termId: currentTerminalId || 'active',
+            data: data
+        }));
+    }
+}
+
+// Setup event listeners
+document.getElementById('backBtn').addEventListener('click', goBack);
+
+// Command input handling
+const commandInput = document.getElementById('commandInput');
+const sendBtn = document.getElementById('sendBtn');
+
+function sendCommand() {
+    const cmd = commandInput.value;
+    if (cmd) {
+        // Send command text, then carriage return separately
+        sendTerminalInput(cmd);
+        setTimeout(() => sendTerminalInput('\r'), 50);
+        commandInput.value = '';
+    }
+}
+
+sendBtn.addEventListener('click', sendCommand);
+commandInput.addEventListener('keydown', (e) => {
+    if (e.key === 'Enter') {
+        e.preventDefault();
+        sendCommand();
+    }
+});
+
+// Keyboard helper buttons
+document.querySelectorAll('.key-btn').forEach(btn => {
+    btn.addEventListener('click', () => {
+        const seq = btn.dataset.seq;
+        if (seq) {
+            // Unescape the sequence
+            const unescaped = seq
+                .replace(/\\x([0-9a-fA-F]{2})/g, (_, hex) => String.fromCharCode(parseInt(hex, 16)))
+                .replace(/\\t/g, '\t')
+                .replace(/\\r/g, '\r')
+                .replace(/\\n/g, '\n');
+            sendTerminalInput(unescaped);
+        }
+    });
+});
+
+// Heartbeat
+setInterval(() => {
+    if (ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ type: 'ping' }));
+    }
+}, 30000);
+
+// Reconnect when the app comes back from the background. Phones suspend
+// backgrounded pages, and a socket that still looks open may be long dead,
+// so an open one has to answer a ping in time.
+const RESUME_PONG_TIMEOUT = 5000;
+let pongTimeout = null;
+
+function checkConnection() {
+    if (!token) return;
+    if (!ws || ws.readyState === WebSocket.CLOSING || ws.readyState === WebSocket.CLOSED) {
+        reconnect();
+        return;
+    }
+    if (ws.readyState !== WebSocket.OPEN || pongTimeout) return;
+    ws.send(JSON.stringify({ type: 'ping' }));
+    requestStatus();
+    pongTimeout = setTimeout(() => {
+        pongTimeout = null;
+        // Drop the dead socket without waiting for it to close
+        const stale = ws;
+        stale.onclose = null;
+        stale.onerror = null;
+        stale.onmessage = null;
+        stale.close();
+        reconnect();
+    }, RESUME_PONG_TIMEOUT);
+}
+
+document.addEventListener('visibilitychange', () => {
+    if (document.visibilityState === 'visible') checkConnection();
+});
+window.addEventListener('pageshow', (e) => {
+    if (e.persisted) checkConnection();
+});
+window.addEventListener('online', checkConnection);
+
+// Speech recognition: the browser's when it has one, otherwise (and on
+// Android, where it is unreliable) clips recorded here and transcribed on the
+// desktop
+const SpeechRecognition = window.SpeechRecognition || window.webkitSpeechRecognition;
+const SPEECH_LANG = 'pl-PL';
+const MAX_CLIP_MS = 60000;
+let recognition = null;
+let isRecording = false;
+let serverSpeech = false;
+let mediaRecorder = null;
+let clipTimeout = null;
+
+function initSpeechRecognition() {
+    const micBtn = document.getElementById('micBtn');
+    const micStatus = document.getElementById('micStatus');
+
+    // Push-to-talk
+    function startRecording(e) {
+        e.preventDefault();
+        if (isRecording || micBtn.disabled) return;
+        if (serverSpeech) {
+            startClip();
+        } else if (recognition) {
+            try { recognition.start(); } catch (err) {}
+        }
+    }
+
+    function stopRecording(e) {
+        e.preventDefault();
+        if (!isRecording) return;
+        if (serverSpeech) {
+            stopClip();
+        } else if (recognition) {
+            recognition.stop();
+        }
+    }
+
+    micBtn.addEventListener('touchstart', startRecording, { passive: false });
+    micBtn.addEventListener('touchend', stopRecording, { passive: false });
+    micBtn.addEventListener('touchcancel', stopRecording, { passive: false });
+    micBtn.addEventListener('mousedown', startRecording);
+    micBtn.addEventListener('mouseup', stopRecording);
+    micBtn.addEventListener('mouseleave', stopRecording);
+
+    if (!SpeechRecognition) {
+        micStatus.textContent = 'Not supported';
+        micBtn.style.opacity = '0.5';
+        micBtn.disabled = true;
+        return;
+    }
+
+    recognition = new SpeechRecognition();
+    recognition.continuous = true;
+    recognition.interimResults = true;
+    recognition.lang = SPEECH_LANG;
+
+    let finalTranscript = '';
+
+    recognition.onstart = () => {
+        isRecording = true;
+        micBtn.classList.add('recording');
+        micStatus.classList.add('recording');
+        micStatus.textContent = 'Listening...';
+        finalTranscript = '';
+    };
+
+    recognition.onresult = (event) => {
+        let interimTranscript = '';
+        for (let i = event.resultIndex; i < event.results.length; i++) {
+            const transcript = event.results[i][0].transcript;
+            if (event.results[i].isFinal) {
+                finalTranscript += transcript;
+            } else {
+                interimTranscript += transcript;
+            }
+        }
+        micStatus.textContent = finalTranscript + interimTranscript || 'Listening...';
+    };
+
+    recognition.onend = () => {
+        isRecording = false;
+        micBtn.classList.remove('recording');
+        micStatus.classList.remove('recording');
+
+        if (finalTranscript.trim()) {
+            sendTerminalInput(finalTranscript.trim() + '\n');
+            micStatus.textContent = 'Sent!';
+        } else {
+            micStatus.textContent = '';
+        }
+
+        setTimeout(() => {
+            if (!isRecording) micStatus.textContent = '';
+        }, 2000);
+    };
+
+    recognition.onerror = (event) => {
+        isRecording = false;
+        micBtn.classList.remove('recording');
+        micStatus.classList.remove('recording');
+        micStatus.textContent = event.error === 'not-allowed' ? 'Mic denied' : 'Error';
+    };
+}
+
+// Switch voice input to desktop transcription when the browser can record
+// and its own recognition is missing or unreliable
+function enableServerSpeech() {
+    if (!window.MediaRecorder || !navigator.mediaDevices) return;
+    if (SpeechRecognition && !/Android/i.test(navigator.userAgent)) return;
+    serverSpeech = true;
+    const micBtn = document.getElementById('micBtn');
+    const micStatus = document.getElementById('micStatus');
+    micBtn.disabled = false;
+    micBtn.style.opacity = '';
+    micStatus.textContent = '';
+}
+
+function setMicRecording(recording) {
+    isRecording = recording;
+    document.getElementById('micBtn').classList.toggle('recording', recording);
+    document.getElementById('micStatus').classList.toggle('recording', recording);
+}
+
+async function startClip() {
+    const micStatus = document.getElementById('micStatus');
+    setMicRecording(true);
+    micStatus.textContent = 'Listening...';
+    let stream;
+    try {
+        stream = await navigator.mediaDevices.getUserMedia({ audio: true });
+    } catch (err) {
+        setMicRecording(false);
+        micStatus.textContent = 'Mic denied';
+        return;
+    }
+    // Released before the mic was ready
+    if (!isRecording) {
+        stream.getTracks().forEach(t => t.stop());
+        micStatus.textContent = '';
+        return;
+    }
+
+    const chunks = [];
+    mediaRecorder = new MediaRecorder(stream);
+    mediaRecorder.ondataavailable = (e) => {
+        if (e.data.size > 0) chunks.push(e.data);
+    };
+    mediaRecorder.onstop = () => {
+        stream.getTracks().forEach(t => t.stop());
+        const type = (mediaRecorder.mimeType || 'audio/webm').split(';')[0];
+        mediaRecorder = null;
+        if (chunks.length) transcribeClip(new Blob(chunks, { type: type }));
+    };
+    mediaRecorder.start();
+    clipTimeout = setTimeout(stopClip, MAX_CLIP_MS);
+}
+
+function stopClip() {
+    clearTimeout(clipTimeout);
+    setMicRecording(false);
+    if (mediaRecorder && mediaRecorder.state !== 'inactive') {
+        mediaRecorder.stop();
+    }
+}
+
+async function transcribeClip(blob) {
+    const micStatus = document.getElementById('micStatus');
+    micStatus.textContent = 'Transcribing...';
+    try {
+        const response = await fetch('/api/transcribe?lang=' + encodeURIComponent(SPEECH_LANG), {
+            method: 'POST',
+            headers: { 'Authorization': 'Bearer ' + token, 'Content-Type': blob.type },
+            body: blob
+        });
+        if (!response.ok) throw new Error((await response.text()).trim());
+        const data = await response.json();
+        if (data.text) {
+            sendTerminalInput(data.text + '\n');
+            micStatus.textContent = 'Sent!';
+        } else {
+            micStatus.textContent = 'No speech';
+        }
+    } catch (err) {
+        console.error('Transcription failed:', err);
+        micStatus.textContent = 'Error';
+    }
+    setTimeout(() => {
+        if (!isRecording) micStatus.textContent = '';
+    }, 2000);
+}
+
+// Service worker: caches the client so it can be installed to the home
+// screen and opens while offline (browsers require HTTPS, or localhost)
+async function registerServiceWorker() {
+    if (!('serviceWorker' in navigator)) return;
+    try {
+        await navigator.serviceWorker.register('/sw.js');
+        const registration = await navigator.serviceWorker.ready;
+        // Keep this page for launches without a token in the URL
+        if (params.get('token') && registration.active) {
+            registration.active.postMessage({ type: 'cache-shell', url: location.pathname + location.search });
+        }
+    } catch (err) {
+        console.error('Service worker unavailable:', err);
+    }
+}
+
+// Push notifications (saved devices only; browsers require HTTPS)
+let pushRegistration = null;
+
+async function initPush() {
+    if (pushRegistration) return; // Already set up on an earlier connect
+    if (!('serviceWorker' in navigator) || !('PushManager' in window) || !('Notification' in window)) return;
+    try {
+        pushRegistration = await navigator.serviceWorker.register('/sw.js');
+        const subscription = await pushRegistration.pushManager.getSubscription();
+        const pushBtn = document.getElementById('pushBtn');
+        pushBtn.style.display = '';
+        pushBtn.classList.toggle('on', !!subscription);
+        pushBtn.addEventListener('click', togglePush);
+        if (subscription) {
+            // Re-register in case the server forgot it
+            await sendSubscription('POST', subscription);
+        }
+    } catch (err) {
+        console.error('Push notifications unavailable:', err);
+    }
+}
+
+async function togglePush() {
+    const pushBtn = document.getElementById('pushBtn');
+    try {
+        const existing = await pushRegistration.pushManager.getSubscription();
+        if (existing) {
+            await sendSubscription('DELETE', existing);
+            await existing.unsubscribe();
+            pushBtn.classList.remove('on');
+            return;
+        }
+        if (await Notification.requestPermission() !== 'granted') return;
+        const response = await fetch('/api/push', { headers: { 'Authorization': 'Bearer ' + token } });
+        if (!response.ok) throw new Error(await response.text());
+        const { publicKey } = await response.json();
+        const subscription = await pushRegistration.pushManager.subscribe({
+            userVisibleOnly: true,
+            applicationServerKey: base64UrlToBytes(publicKey)
+        });
+        await sendSubscription('POST', subscription);
+        pushBtn.classList.add('on');
+    } catch (err) {
+        console.error('Failed to change notifications:', err);
+    }
+}
+
+async function sendSubscription(method, subscription) {
+    const response = await fetch('/api/push', {
+        method: method,
+        headers: { 'Authorization': 'Bearer ' + token, 'Content-Type': 'application/json' },
+        body: JSON.stringify(subscription)
+    });
+    if (!response.ok) throw new Error(await response.text());
+}
+
+function base64UrlToBytes(str) {
+    const base64 = (str + '='.repeat((4 - str.length % 4) % 4)).replace(/-/g, '+').replace(/_/g, '/');
+    return Uint8Array.from(atob(base64), c => c.charCodeAt(0));
+}
+
+// File browser: projects, then their directories
+let filesOpen = false;
+let filesProject = null; // { id, name } when browsing a project
+let filesPath = ''; // Directory within the project
+
+function filesURL(extra) {
+    let url = '/api/files?token=' + encodeURIComponent(token);
+    if (filesProject) url += '&project=' + encodeURIComponent(filesProject.id);
+    return url + (extra || '');
+}
+
+function toggleFiles() {
+    if (!filesOpen && dockerOpen) toggleDocker();
+    filesOpen = !filesOpen;
+    document.getElementById('filesBtn').classList.toggle('on', filesOpen);
+    document.getElementById('filesView').style.display = filesOpen ? 'flex' : 'none';
+    if (filesOpen) {
+        if (currentTerminalId) goBack();
+        document.getElementById('terminalSelector').style.display = 'none';
+        loadFiles();
+    } else {
+        document.getElementById('terminalSelector').style.display = 'flex';
+    }
+}
+
+async function loadFiles() {
+    const list = document.getElementById('filesList');
+    document.getElementById('filesPath').textContent = filesProject
+        ? filesProject.name + (filesPath ? '/' + filesPath : '')
+        : 'Projects';
+    document.getElementById('uploadBtn').style.display = filesProject && !viewerMode ? '' : 'none';
+    try {
+        const response = await fetch(filesURL('&path=' + encodeURIComponent(filesPath)));
+        if (!response.ok) throw new Error(await response.text());
+        const items = await response.json();
+        if (items.length === 0) {
+            list.innerHTML = '<div class="no-terminals"><p>' + (filesProject ? 'Empty folder' : 'No projects') + '</p></div>';
+            return;
+        }
+        list.innerHTML = items.map((item, i) => {
+            const isDir = !filesProject || item.dir;
+            const detail = !filesProject ? 'Project' : (item.dir ? 'Folder' : formatSize(item.size));
+            return '<button class="terminal-btn" data-index="' + i + '">' +
+                '<span class="icon">' + (isDir ? '📁' : '📄') + '</span>' +
+                '<span class="info">' +
+                '<span class="name">' + escapeHtml(item.name) + '</span>' +
+                '<span class="status-text">' + detail + '</span>' +
+                '</span></button>';
+        }).join('');
+        list.querySelectorAll('.terminal-btn').forEach(btn => {
+            btn.addEventListener('click', () => openFileItem(items[Number(btn.dataset.index)]));
+        });
+    } catch (err) {
+        list.innerHTML = '<div class="no-terminals"><p>' + escapeHtml(err.message || 'Failed to load files') + '</p></div>';
+    }
+}
+
+function openFileItem(item) {
+    if (!filesProject) {
+        filesProject = item;
+        filesPath = '';
+        loadFiles();
+    } else if (item.dir) {
+        filesPath = filesPath ? filesPath + '/' + item.name : item.name;
+        loadFiles();
+    } else {
+        // Images open in the browser; everything else downloads
+        const path = filesPath ? filesPath + '/' + item.name : item.name;
+        const inline = /\.(png|jpe?g|gif|webp|txt|log)$/i.test(item.name) ? '&inline=1' : '';
+        window.open(filesURL('&path=' + encodeURIComponent(path) + inline), '_blank');
+    }
+}
+
+function filesBack() {
+    if (filesPath) {
+        filesPath = filesPath.includes('/') ? filesPath.slice(0, filesPath.lastIndexOf('/')) : '';
+        loadFiles();
+    } else if (filesProject) {
+        filesProject = null;
+        loadFiles();
+    } else {
+        toggleFiles();
+    }
+}
+
+async function uploadFile(file, overwrite) {
+    const form = new FormData();
+    form.append('file', file);
+    const url = filesURL('&path=' + encodeURIComponent(filesPath) + (overwrite ? '&overwrite=1' : ''));
+    try {
+        const response = await fetch(url, { method: 'POST', body: form });
+        if (response.status === 409 && !overwrite) {
+            if (confirm(file.name + ' already exists. Replace it?')) return uploadFile(file, true);
+            return;
+        }
+        if (!response.ok) throw new Error(await response.text());
+        loadFiles();
+    } catch (err) {
+        alert('Upload failed: ' + (err.message || err));
+    }
+}
+
+function formatSize(bytes) {
+    if (bytes < 1024) return bytes + ' B';
+    if (bytes < 1024 * 1024) return (bytes / 1024).toFixed(1) + ' KB';
+    return (bytes / (1024 * 1024)).toFixed(1) + ' MB';
+}
+
+document.getElementById('filesBtn').addEventListener('click', toggleFiles);
+document.getElementById('filesBackBtn').addEventListener('click', filesBack);
+document.getElementById('uploadBtn').addEventListener('click', () => document.getElementById('uploadInput').click());
+document.getElementById('uploadInput').addEventListener('change', (e) => {
+    const file = e.target.files[0];
+    e.target.value = '';
+    if (file) uploadFile(file, false);
+});
+
+// Docker: a project's containers, to start, stop or restart them
+let dockerOpen = false;
+let dockerProject = null; // { id, name } when showing a project's containers
+
+function toggleDocker() {
+    if (!dockerOpen && filesOpen) toggleFiles();
+    dockerOpen = !dockerOpen;
+    document.getElementById('dockerBtn').classList.toggle('on', dockerOpen);
+    document.getElementById('dockerView').style.display = dockerOpen ? 'flex' : 'none';
+    if (dockerOpen) {
+        if (currentTerminalId) goBack();
+        document.getElementById('terminalSelector').style.display = 'none';
+        dockerProject = null;
+        renderDockerProjects();
+    } else {
+        document.getElementById('terminalSelector').style.display = 'flex';
+    }
+}
+
+function renderDockerProjects() {
+    const list = document.getElementById('dockerList');
+    document.getElementById('dockerTitle').textContent = 'Docker';
+    document.getElementById('dockerRefreshBtn').style.display = 'none';
+    if (projectList.length === 0) {
+        list.innerHTML = '<div class="no-terminals"><p>No projects</p></div>';
+        return;
+    }
+    list.innerHTML = projectList.map((p, i) =>
+        '<button class="terminal-btn" data-index="' + i + '">' +
+        '<span class="icon">📦</span>' +
+        '<span class="info"><span class="name">' + escapeHtml(p.name) + '</span></span>' +
+        '</button>').join('');
+    list.querySelectorAll('.terminal-btn').forEach(btn => {
+        btn.addEventListener('click', () => {
+            dockerProject = projectList[Number(btn.dataset.index)];
+            document.getElementById('dockerTitle').textContent = dockerProject.name;
+            document.getElementById('dockerRefreshBtn').style.display = '';
+            showDockerMessage('Loading...');
+            requestContainers();
+        });
+    });
+}
+
+function requestContainers() {
+    if (dockerProject && ws && ws.readyState === WebSocket.OPEN) {
+        ws.send(JSON.stringify({ type: 'containers', projectId: dockerProject.id }));
+    }
+}
+
+function showDockerMessage(text) {
+    document.getElementById('dockerList').innerHTML =
+        '<div class="no-terminals"><p>' + escapeHtml(text) + '</p></div>';
+}
+
+function renderContainers(containers) {
+    if (containers.length === 0) {
+        showDockerMessage('No containers for this project');
+        return;
+    }
+    const list = document.getElementById('dockerList');
+    list.innerHTML = containers.map(c => {
+        const running = c.state === 'running';
+        const health = c.health && c.health !== 'none' ? ' · ' + escapeHtml(c.health) : '';
+        const actions = viewerMode ? '' : '<span class="container-actions">' +
+            (running
+                ? '<button class="header-btn on" data-id="' + escapeHtml(c.id) + '" data-action="restart">Restart</button>' +
+                  '<button class="header-btn on" data-id="' + escapeHtml(c.id) + '" data-action="stop">Stop</button>'
+                : '<button class="header-btn on" data-id="' + escapeHtml(c.id) + '" data-action="start">Start</button>') +
+            '</span>';
+        return '<div class="terminal-btn container-row">' +
+            '<span class="info">' +
+            '<span class="name">' + escapeHtml(c.service || c.id) + '</span>' +
+            '<span class="status-text">' + escapeHtml(c.status || c.state) + health + '</span>' +
+            '</span>' +
+            (running ? '<span class="active-indicator"></span>' : '') +
+            actions +
+            '</div>';
+    }).join('');
+    list.querySelectorAll('.container-actions button').forEach(btn => {
+        btn.addEventListener('click', () => containerAction(btn.dataset.id, btn.dataset.action, containers));
+    });
+}
+
+function containerAction(id, action, containers) {
+    const container = containers.find(c => c.id === id);
+    const name = container ? container.service || id : id;
+    if (action !== 'start' && !confirm(action.charAt(0).toUpperCase() + action.slice(1) + ' ' + name + '?')) {
+        return;
+    }
+    if (!dockerProject || !ws || ws.readyState !== WebSocket.OPEN) return;
+    showDockerMessage((action === 'start' ? 'Starting ' : action === 'stop' ? 'Stopping ' : 'Restarting ') + name + '...');
+    ws.send(JSON.stringify({ type: 'containerAction', projectId: dockerProject.id, containerId: id, action: action }));
+}
+
+function dockerBack() {
+    if (dockerProject) {
+        dockerProject = null;
+        renderDockerProjects();
+    } else {
+        toggleDocker();
+    }
+}
+
+document.getElementById('dockerBtn').addEventListener('click', toggleDocker);
+document.getElementById('dockerBackBtn').addEventListener('click', dockerBack);
+document.getElementById('dockerRefreshBtn').addEventListener('click', requestContainers);
+
+// Initialize
+initTerminal();
+initSpeechRecognition();
+registerServiceWorker();
+connect();
+startStatusRefresh();
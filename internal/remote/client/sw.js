@@ -0,0 +1,90 @@
+// Cached copies of the client, so it opens from the home screen and while
+// the connection is down. Bump the version when the cached files change
+// shape.
+const CACHE_NAME = 'claudilandia-remote-v1';
+const SHELL_KEY = '/';
+const STATIC_ASSETS = [
+    '/assets/style.css',
+    '/assets/app.js',
+    '/assets/icon-256.png',
+    '/assets/icon-512.png'
+];
+
+self.addEventListener('install', (event) => {
+    event.waitUntil(caches.open(CACHE_NAME)
+        .then((cache) => cache.addAll(STATIC_ASSETS))
+        .then(() => self.skipWaiting()));
+});
+
+self.addEventListener('activate', (event) => {
+    event.waitUntil(caches.keys()
+        .then((keys) => Promise.all(keys.filter((key) => key !== CACHE_NAME).map((key) => caches.delete(key))))
+        .then(() => self.clients.claim()));
+});
+
+// The page needs a token, which isn't in the URL when the app is opened from
+// the home screen; the client asks for a copy to be kept once it loaded with
+// one, and it stands in for the page then
+self.addEventListener('message', (event) => {
+    if (event.data && event.data.type === 'cache-shell' && event.data.url) {
+        event.waitUntil(fetch(event.data.url).then((response) => {
+            if (response.ok) {
+                return caches.open(CACHE_NAME).then((cache) => cache.put(SHELL_KEY, response));
+            }
+        }).catch(() => {}));
+    }
+});
+
+// Pages and assets come from the network first, so an updated app shows up
+// right away, and from the cache when that fails. API calls and the
+// WebSocket are never cached.
+self.addEventListener('fetch', (event) => {
+    const url = new URL(event.request.url);
+    if (event.request.method !== 'GET' || url.origin !== self.location.origin) return;
+
+    if (event.request.mode === 'navigate') {
+        event.respondWith(fetch(event.request).then((response) => {
+            if (response.ok) {
+                const copy = response.clone();
+                caches.open(CACHE_NAME).then((cache) => cache.put(SHELL_KEY, copy));
+                return response;
+            }
+            return caches.match(SHELL_KEY).then((cached) => cached || response);
+        }, () => caches.match(SHELL_KEY).then((cached) => cached || Response.error())));
+        return;
+    }
+
+    if (url.pathname.startsWith('/assets/')) {
+        event.respondWith(fetch(event.request).then((response) => {
+            if (response.ok) {
+                const copy = response.clone();
+                caches.open(CACHE_NAME).then((cache) => cache.put(url.pathname, copy));
+            }
+            return response;
+        }, () => caches.match(url.pathname).then((cached) => cached || Response.error())));
+    }
+});
+
+self.addEventListener('push', (event) => {
+    let msg = {};
+    try {
+        msg = event.data ? event.data.json() : {};
+    } catch (err) {
+        msg = { title: 'Claudilandia', body: event.data ? event.data.text() : '' };
+    }
+    event.waitUntil(self.registration.showNotification(msg.title || 'Claudilandia', {
+        body: msg.body || '',
+        tag: msg.tag || undefined,
+        renotify: !!msg.tag
+    }));
+});
+
+self.addEventListener('notificationclick', (event) => {
+    event.notification.close();
+    event.waitUntil(clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+        for (const win of windows) {
+            if ('focus' in win) return win.focus();
+        }
+        return clients.openWindow('/');
+    }));
+});
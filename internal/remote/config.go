@@ -2,6 +2,7 @@ package remote
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -9,13 +10,48 @@ import (
 type Config struct {
 	Enabled          bool   `json:"enabled"`          // Enable ngrok tunnel
 	SavedDevicesOnly bool   `json:"savedDevicesOnly"` // Only allow saved devices (no new token)
+	AccessRequests   bool   `json:"accessRequests"`   // Let new devices ask for access from the login page
 	Port             int    `json:"port"`
+	BindHost         string `json:"bindHost"` // address to listen on, BindAuto picks one
 	NgrokPlan        string `json:"ngrokPlan"`   // "free" or "premium"
 	Subdomain        string `json:"subdomain"`   // only for premium
 	TokenExpiry      int    `json:"tokenExpiry"` // hours, default 24
 	NgrokAPIPort     int    `json:"ngrokApiPort"` // ngrok API port, default 4040
+	TLSMode          string `json:"tlsMode"`      // "" for plain HTTP, "self-signed" or "custom"
+	CertFile         string `json:"certFile"`     // PEM certificate, custom TLS only
+	KeyFile          string `json:"keyFile"`      // PEM private key, custom TLS only
+	RecordSessions   bool   `json:"recordSessions"` // Record the terminal traffic of client sessions
+	RecordingDays    int    `json:"recordingDays"`  // days recordings are kept, 0 keeps them
+	NgrokRegion      string `json:"ngrokRegion"` // "" for the closest region, or one of NgrokRegions
+	NgrokDomain      string `json:"ngrokDomain"` // reserved domain, e.g. myapp.ngrok.app
+	NgrokEdge        string `json:"ngrokEdge"`   // edge ID (edghts_...) to serve instead of a domain
+	ExtraTunnels     []TunnelConfig `json:"extraTunnels"` // more local ports to share, e.g. a dev server
+	MaxAuthAttempts  int    `json:"maxAuthAttempts"`    // failed attempts before a lockout, default 50
+	AuthLockoutMins  int    `json:"authLockoutMinutes"` // lockout duration, default 1
+	WhisperModel     string `json:"whisperModel"` // whisper.cpp model for voice input, "" uses macOS speech recognition
 }
 
+// TunnelConfig is a local port shared through ngrok next to the remote
+// access server
+type TunnelConfig struct {
+	Name   string `json:"name"` // defaults to port-<port>
+	Port   int    `json:"port"`
+	Domain string `json:"domain"` // reserved domain, optional
+}
+
+// NgrokRegions are the ngrok regions a tunnel can run in
+var NgrokRegions = []string{"us", "eu", "ap", "au", "sa", "jp", "in"}
+
+// maxExtraTunnels limits the ports shared next to the remote access server
+const maxExtraTunnels = 4
+
+// TLS modes of the remote access server
+const (
+	TLSModeOff        = ""
+	TLSModeSelfSigned = "self-signed"
+	TLSModeCustom     = "custom"
+)
+
 // DefaultConfig returns the default remote access configuration
 func DefaultConfig() Config {
 	return Config{
@@ -23,8 +59,10 @@ func DefaultConfig() Config {
 		Port:         9090,
 		NgrokPlan:    "free",
 		Subdomain:    "",
-		TokenExpiry:  24,
-		NgrokAPIPort: 4040,
+		TokenExpiry:     24,
+		NgrokAPIPort:    4040,
+		MaxAuthAttempts: DefaultMaxAuthAttempts,
+		AuthLockoutMins: DefaultAuthLockoutMinutes,
 	}
 }
 
@@ -57,6 +95,15 @@ func (c *Config) Validate() *ValidationError {
 		c.NgrokPlan = "free"
 	}
 
+	// Validate bind address
+	if c.BindHost != BindAuto {
+		host := normalizeBindHost(c.BindHost)
+		if host == "" {
+			warnings = append(warnings, fmt.Sprintf("invalid bind address '%s', choosing one automatically", c.BindHost))
+		}
+		c.BindHost = host
+	}
+
 	// Validate token expiry (1 hour to 1 week)
 	if c.TokenExpiry < 1 || c.TokenExpiry > 168 {
 		warnings = append(warnings, fmt.Sprintf("invalid token expiry %d hours (must be 1-168), using default 24", c.TokenExpiry))
@@ -69,11 +116,77 @@ func (c *Config) Validate() *ValidationError {
 		c.NgrokAPIPort = 4040
 	}
 
+	// Validate the auth rate-limit policy; unset values take the defaults
+	if c.MaxAuthAttempts == 0 {
+		c.MaxAuthAttempts = DefaultMaxAuthAttempts
+	} else if c.MaxAuthAttempts < 1 || c.MaxAuthAttempts > 1000 {
+		warnings = append(warnings, fmt.Sprintf("invalid max auth attempts %d (must be 1-1000), using default %d", c.MaxAuthAttempts, DefaultMaxAuthAttempts))
+		c.MaxAuthAttempts = DefaultMaxAuthAttempts
+	}
+	if c.AuthLockoutMins == 0 {
+		c.AuthLockoutMins = DefaultAuthLockoutMinutes
+	} else if c.AuthLockoutMins < 1 || c.AuthLockoutMins > 1440 {
+		warnings = append(warnings, fmt.Sprintf("invalid auth lockout %d minutes (must be 1-1440), using default %d", c.AuthLockoutMins, DefaultAuthLockoutMinutes))
+		c.AuthLockoutMins = DefaultAuthLockoutMinutes
+	}
+
+	// Validate TLS mode; a custom certificate needs both files
+	if c.TLSMode != TLSModeOff && c.TLSMode != TLSModeSelfSigned && c.TLSMode != TLSModeCustom {
+		warnings = append(warnings, fmt.Sprintf("invalid TLS mode '%s', using plain HTTP", c.TLSMode))
+		c.TLSMode = TLSModeOff
+	}
+	if c.TLSMode == TLSModeCustom && (c.CertFile == "" || c.KeyFile == "") {
+		warnings = append(warnings, "custom TLS needs a certificate and a key file, using a self-signed certificate")
+		c.TLSMode = TLSModeSelfSigned
+	}
+
+	// Validate recording retention (up to a year)
+	if c.RecordingDays < 0 || c.RecordingDays > 365 {
+		warnings = append(warnings, fmt.Sprintf("invalid recording retention %d days (must be 0-365), keeping recordings", c.RecordingDays))
+		c.RecordingDays = 0
+	}
+
 	// Warn if subdomain is set but plan is free
 	if c.Subdomain != "" && c.NgrokPlan == "free" {
 		warnings = append(warnings, "subdomain is set but ngrok plan is 'free' - subdomain will be ignored")
 	}
 
+	// Validate ngrok region, reserved domain and edge
+	if c.NgrokRegion != "" && !slices.Contains(NgrokRegions, c.NgrokRegion) {
+		warnings = append(warnings, fmt.Sprintf("invalid ngrok region '%s', using the closest region", c.NgrokRegion))
+		c.NgrokRegion = ""
+	}
+	c.NgrokDomain = normalizeDomain(c.NgrokDomain)
+	if c.NgrokDomain != "" && !validDomain(c.NgrokDomain) {
+		warnings = append(warnings, fmt.Sprintf("invalid ngrok domain '%s', using a random URL", c.NgrokDomain))
+		c.NgrokDomain = ""
+	}
+	if c.NgrokEdge != "" && !strings.HasPrefix(c.NgrokEdge, "edghts_") {
+		warnings = append(warnings, fmt.Sprintf("invalid ngrok edge '%s' (expected an edghts_ ID), ignoring it", c.NgrokEdge))
+		c.NgrokEdge = ""
+	}
+	if c.NgrokEdge != "" && c.NgrokDomain == "" {
+		warnings = append(warnings, "an ngrok edge needs its domain to show the public URL")
+	}
+
+	c.WhisperModel = strings.TrimSpace(c.WhisperModel)
+
+	// Validate extra tunnels; invalid ones are dropped
+	var tunnels []TunnelConfig
+	for _, t := range c.ExtraTunnels {
+		if err := c.validateTunnel(t, tunnels); err != nil {
+			warnings = append(warnings, err.Error()+", skipping it")
+			continue
+		}
+		t.Domain = normalizeDomain(t.Domain)
+		tunnels = append(tunnels, t)
+	}
+	if len(tunnels) > maxExtraTunnels {
+		warnings = append(warnings, fmt.Sprintf("at most %d extra tunnels, skipping the rest", maxExtraTunnels))
+		tunnels = tunnels[:maxExtraTunnels]
+	}
+	c.ExtraTunnels = tunnels
+
 	if len(warnings) > 0 {
 		return &ValidationError{Warnings: warnings}
 	}
@@ -100,8 +213,99 @@ func (c *Config) ValidateStrict() error {
 		errors = append(errors, fmt.Sprintf("ngrok API port must be 1024-65535, got %d", c.NgrokAPIPort))
 	}
 
+	if c.MaxAuthAttempts < 1 || c.MaxAuthAttempts > 1000 {
+		errors = append(errors, fmt.Sprintf("max auth attempts must be 1-1000, got %d", c.MaxAuthAttempts))
+	}
+
+	if c.AuthLockoutMins < 1 || c.AuthLockoutMins > 1440 {
+		errors = append(errors, fmt.Sprintf("auth lockout must be 1-1440 minutes, got %d", c.AuthLockoutMins))
+	}
+
+	if c.TLSMode != TLSModeOff && c.TLSMode != TLSModeSelfSigned && c.TLSMode != TLSModeCustom {
+		errors = append(errors, fmt.Sprintf("TLS mode must be '', 'self-signed' or 'custom', got '%s'", c.TLSMode))
+	}
+
+	if c.TLSMode == TLSModeCustom && (c.CertFile == "" || c.KeyFile == "") {
+		errors = append(errors, "custom TLS needs a certificate and a key file")
+	}
+
+	if c.RecordingDays < 0 || c.RecordingDays > 365 {
+		errors = append(errors, fmt.Sprintf("recording retention must be 0-365 days, got %d", c.RecordingDays))
+	}
+
+	if c.NgrokRegion != "" && !slices.Contains(NgrokRegions, c.NgrokRegion) {
+		errors = append(errors, fmt.Sprintf("ngrok region must be one of %s, got '%s'", strings.Join(NgrokRegions, ", "), c.NgrokRegion))
+	}
+
+	if c.NgrokDomain != "" && !validDomain(normalizeDomain(c.NgrokDomain)) {
+		errors = append(errors, fmt.Sprintf("invalid ngrok domain '%s'", c.NgrokDomain))
+	}
+
+	if c.NgrokEdge != "" && !strings.HasPrefix(c.NgrokEdge, "edghts_") {
+		errors = append(errors, fmt.Sprintf("ngrok edge must be an edghts_ ID, got '%s'", c.NgrokEdge))
+	}
+
+	if len(c.ExtraTunnels) > maxExtraTunnels {
+		errors = append(errors, fmt.Sprintf("at most %d extra tunnels, got %d", maxExtraTunnels, len(c.ExtraTunnels)))
+	}
+	for i, t := range c.ExtraTunnels {
+		if err := c.validateTunnel(t, c.ExtraTunnels[:i]); err != nil {
+			errors = append(errors, err.Error())
+		}
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("config validation failed: %s", strings.Join(errors, "; "))
 	}
 	return nil
 }
+
+// validateTunnel checks an extra tunnel against the server and the tunnels
+// before it
+func (c *Config) validateTunnel(t TunnelConfig, previous []TunnelConfig) error {
+	if t.Port < 1 || t.Port > 65535 {
+		return fmt.Errorf("invalid tunnel port %d", t.Port)
+	}
+	if t.Port == c.Port {
+		return fmt.Errorf("tunnel port %d is the remote access port", t.Port)
+	}
+	if t.Domain != "" && !validDomain(normalizeDomain(t.Domain)) {
+		return fmt.Errorf("invalid domain '%s' for tunnel port %d", t.Domain, t.Port)
+	}
+	for _, p := range previous {
+		if p.Port == t.Port {
+			return fmt.Errorf("tunnel port %d is listed twice", t.Port)
+		}
+		if t.Name != "" && p.Name == t.Name {
+			return fmt.Errorf("tunnel name '%s' is used twice", t.Name)
+		}
+	}
+	return nil
+}
+
+// normalizeDomain strips the scheme and path people paste along with a
+// domain
+func normalizeDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	domain = strings.TrimPrefix(strings.TrimPrefix(domain, "https://"), "http://")
+	domain, _, _ = strings.Cut(domain, "/")
+	return strings.ToLower(domain)
+}
+
+// validDomain reports whether domain looks like a host name
+func validDomain(domain string) bool {
+	if len(domain) > 253 || !strings.Contains(domain, ".") {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"projecthub/internal/logging"
+
+	"github.com/gorilla/websocket"
+)
+
+// Container actions a client may ask for
+const (
+	ContainerStart   = "start"
+	ContainerStop    = "stop"
+	ContainerRestart = "restart"
+)
+
+// ContainerInfo is one of a project's containers
+type ContainerInfo struct {
+	ID      string `json:"id"`
+	Service string `json:"service"` // compose service name
+	State   string `json:"state"`   // running, exited, restarting, ...
+	Health  string `json:"health"`  // healthy, unhealthy, starting or none
+	Status  string `json:"status"`  // Docker's human readable status
+}
+
+// allowsProjectDocker reports whether the client may see and control the
+// containers of a project
+func (c *ApprovedClient) allowsProjectDocker(projectID string) bool {
+	return c.Allows(CapabilityDocker) && c.AllowsProject(projectID)
+}
+
+// handleListContainers sends a client a project's containers
+func (s *Server) handleListContainers(conn *websocket.Conn, client *ClientInfo, msg *ClientMessage) {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		s.sendError(conn, client, "Project handler not configured")
+		return
+	}
+	if msg.ProjectID == "" {
+		s.sendError(conn, client, "Project ID required")
+		return
+	}
+	if !client.scope.allowsProjectDocker(msg.ProjectID) {
+		s.sendError(conn, client, "Access to Docker is not allowed for this device")
+		return
+	}
+
+	s.sendContainers(conn, client, handler, msg.ProjectID)
+}
+
+// handleContainerAction starts, stops or restarts one of a project's
+// containers, then sends the project's containers again
+func (s *Server) handleContainerAction(conn *websocket.Conn, client *ClientInfo, msg *ClientMessage) {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		s.sendError(conn, client, "Project handler not configured")
+		return
+	}
+	if msg.ProjectID == "" || msg.ContainerID == "" {
+		s.sendError(conn, client, "Project ID and Container ID required")
+		return
+	}
+	if !client.scope.allowsProjectDocker(msg.ProjectID) {
+		s.sendError(conn, client, "Access to Docker is not allowed for this device")
+		return
+	}
+	switch msg.Action {
+	case ContainerStart, ContainerStop, ContainerRestart:
+	default:
+		s.sendError(conn, client, fmt.Sprintf("Unknown container action: %q", msg.Action))
+		return
+	}
+
+	// Only the project's own containers can be controlled
+	containers, err := handler.GetContainers(msg.ProjectID)
+	if err != nil {
+		s.sendError(conn, client, fmt.Sprintf("Failed to list containers: %v", err))
+		return
+	}
+	var target *ContainerInfo
+	for i := range containers {
+		if containers[i].ID == msg.ContainerID {
+			target = &containers[i]
+			break
+		}
+	}
+	if target == nil {
+		s.sendError(conn, client, "Container not found in this project")
+		return
+	}
+
+	if err := handler.ContainerAction(target.ID, msg.Action); err != nil {
+		s.sendError(conn, client, fmt.Sprintf("Failed to %s %s: %v", msg.Action, target.Service, err))
+		return
+	}
+	logging.Info("Remote container action", "client", client.ID, "container", target.ID, "service", target.Service, "action", msg.Action)
+	s.audit(client, AuditContainer, "", fmt.Sprintf("%s %s in project %s", msg.Action, target.Service, msg.ProjectID))
+
+	s.sendContainers(conn, client, handler, msg.ProjectID)
+}
+
+// sendContainers sends a client a project's containers
+func (s *Server) sendContainers(conn *websocket.Conn, client *ClientInfo, handler ProjectHandler, projectID string) {
+	containers, err := handler.GetContainers(projectID)
+	if err != nil {
+		s.sendError(conn, client, fmt.Sprintf("Failed to list containers: %v", err))
+		return
+	}
+
+	msgBytes, err := json.Marshal(ServerMessage{Type: MsgTypeContainers, ProjectID: projectID, Containers: containers})
+	if err != nil {
+		logging.Error("Failed to marshal containers", "error", err)
+		return
+	}
+	client.writeMu.Lock()
+	if err := conn.WriteMessage(websocket.TextMessage, msgBytes); err != nil {
+		logging.Debug("Failed to send containers", "error", err)
+	}
+	client.writeMu.Unlock()
+}
@@ -0,0 +1,392 @@
+package remote
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// maxUploadSize limits files pushed from remote clients
+const maxUploadSize = 100 << 20
+
+// errOutsideProject means a path points outside its project's root
+var errOutsideProject = errors.New("path is outside the project")
+
+// errGitDir means a path is in a .git directory. Its config and hooks run
+// code the next time git runs, which the files capability doesn't give.
+var errGitDir = errors.New("path is in the .git directory")
+
+// inlineTypes are the only types files are shown inline as. Anything else,
+// HTML and SVG especially, could run script on this origin, where the token
+// is stored, so it is always downloaded.
+var inlineTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".txt":  "text/plain; charset=utf-8",
+	".log":  "text/plain; charset=utf-8",
+}
+
+// FileEntry is a file or directory in a remote file listing
+type FileEntry struct {
+	Name    string    `json:"name"`
+	Dir     bool      `json:"dir"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// FileProject is a project whose files a remote client may browse
+type FileProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// handleFiles serves the files of the projects a client may access. GET
+// without a project lists the projects, GET on a directory lists it and GET on
+// a file downloads it (shown inline with inline=1, images and plain text
+// only). POST uploads a multipart "file" into a directory; it won't replace a
+// file unless overwrite=1. Paths are relative to the project root and can't
+// leave it, symlinks included, or enter a .git directory.
+func (s *Server) handleFiles(w http.ResponseWriter, r *http.Request) {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	token := r.Header.Get("Authorization")
+	if strings.HasPrefix(token, "Bearer ") {
+		token = strings.TrimPrefix(token, "Bearer ")
+	} else {
+		token = r.URL.Query().Get("token")
+	}
+
+	if !s.validateToken(token) {
+		s.recordFailedAuth(clientIP)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.resetAuthAttempts(clientIP)
+
+	if !s.checkSecondFactor(w, r, token) {
+		return
+	}
+
+	scope := s.approvedScope(token)
+	if !scope.Allows(CapabilityFiles) {
+		http.Error(w, "Not allowed for this device", http.StatusForbidden)
+		return
+	}
+
+	projectID := r.URL.Query().Get("project")
+	if projectID == "" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Project required", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.fileProjects(scope))
+		return
+	}
+
+	root := s.projectRoot(projectID)
+	if root == "" || !scope.AllowsProject(projectID) {
+		http.Error(w, "Project not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.serveProjectPath(w, r, token, projectID, root)
+	case http.MethodPost:
+		if s.IsViewerToken(token) {
+			http.Error(w, "View-only device", http.StatusForbidden)
+			return
+		}
+		s.receiveUpload(w, r, token, projectID, root)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// fileProjects returns the projects a client may browse
+func (s *Server) fileProjects(scope *ApprovedClient) []FileProject {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	projects := []FileProject{}
+	if handler == nil {
+		return projects
+	}
+	for _, p := range handler.GetProjects() {
+		if p.Path != "" && scope.AllowsProject(p.ID) {
+			projects = append(projects, FileProject{ID: p.ID, Name: p.Name})
+		}
+	}
+	return projects
+}
+
+// projectRoot returns the directory of a project, or "" when it isn't known
+func (s *Server) projectRoot(projectID string) string {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		return ""
+	}
+	for _, p := range handler.GetProjects() {
+		if p.ID == projectID {
+			return p.Path
+		}
+	}
+	return ""
+}
+
+// serveProjectPath lists a directory or downloads a file
+func (s *Server) serveProjectPath(w http.ResponseWriter, r *http.Request, token, projectID, root string) {
+	target, rel, err := resolveProjectPath(root, r.URL.Query().Get("path"))
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+
+	if info.IsDir() {
+		entries, err := listDir(target)
+		if err != nil {
+			writeFileError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	f, err := os.Open(target)
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	defer f.Close()
+
+	s.auditRequest(r, token, AuditDownload, "", projectID+": "+rel)
+	contentType, inline := inlineTypes[strings.ToLower(filepath.Ext(info.Name()))]
+	if inline && r.URL.Query().Get("inline") == "1" {
+		w.Header().Set("Content-Type", contentType)
+	} else {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name()}))
+	}
+	// Project files never run script here, even when a browser renders one
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// receiveUpload saves the multipart "file" of a request into a project
+// directory. It is written to a temporary file first so a broken upload
+// doesn't leave half a file behind.
+func (s *Server) receiveUpload(w http.ResponseWriter, r *http.Request, token, projectID, root string) {
+	dir, dirRel, err := resolveProjectPath(root, r.URL.Query().Get("path"))
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		http.Error(w, "Not a directory", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Expected a multipart upload", http.StatusBadRequest)
+		return
+	}
+	var part io.ReadCloser
+	var name string
+	for {
+		p, err := reader.NextPart()
+		if err != nil {
+			http.Error(w, "No file in upload", http.StatusBadRequest)
+			return
+		}
+		if p.FormName() == "file" {
+			part, name = p, p.FileName()
+			break
+		}
+		p.Close()
+	}
+	defer part.Close()
+
+	// Keep only the base name; browsers may send a path
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" || isGitDir(name) {
+		http.Error(w, "Invalid file name", http.StatusBadRequest)
+		return
+	}
+	target := filepath.Join(dir, name)
+	if info, err := os.Lstat(target); err == nil {
+		if !info.Mode().IsRegular() {
+			http.Error(w, "Can't replace "+name, http.StatusConflict)
+			return
+		}
+		if r.URL.Query().Get("overwrite") != "1" {
+			http.Error(w, name+" already exists", http.StatusConflict)
+			return
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		writeFileError(w, err)
+		return
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+	size, err := io.Copy(tmp, part)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("File larger than %d MB", maxUploadSize>>20), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Upload failed", http.StatusBadRequest)
+		return
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		writeFileError(w, err)
+		return
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		writeFileError(w, err)
+		return
+	}
+
+	logging.Info("Remote file uploaded", "project", projectID, "file", target, "size", size)
+	rel := path.Join(dirRel, name)
+	s.auditRequest(r, token, AuditUpload, "", fmt.Sprintf("%s: %s (%d bytes)", projectID, rel, size))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"path": rel,
+		"size": size,
+	})
+}
+
+// resolveProjectPath returns the absolute path of a path relative to a
+// project root, and the path within the project with symlinks resolved ("."
+// for the root). ".." can't climb above the root, and a symlink that leads
+// outside it is refused, as is anything in a .git directory.
+func resolveProjectPath(root, rel string) (string, string, error) {
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", "", err
+	}
+	clean := path.Clean("/" + rel)
+	if inGitDir(clean) {
+		return "", "", errGitDir
+	}
+	target := filepath.Join(realRoot, filepath.FromSlash(clean))
+	realTarget, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return "", "", err
+	}
+	within, err := filepath.Rel(realRoot, realTarget)
+	if err != nil || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+		return "", "", errOutsideProject
+	}
+	// A symlink may lead into one too
+	if inGitDir(filepath.ToSlash(within)) {
+		return "", "", errGitDir
+	}
+	return realTarget, filepath.ToSlash(within), nil
+}
+
+// inGitDir reports whether a slash-separated path has a .git component
+func inGitDir(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if isGitDir(part) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGitDir reports whether a file name is .git, in any case since macOS and
+// Windows file systems ignore it
+func isGitDir(name string) bool {
+	return strings.EqualFold(name, ".git")
+}
+
+// listDir lists a directory, directories first. The .git directory is left
+// out.
+func listDir(dir string) ([]FileEntry, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]FileEntry, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		if isGitDir(e.Name()) {
+			continue
+		}
+		// Stat follows symlinks so linked directories can be opened
+		info, err := os.Stat(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		entry := FileEntry{Name: e.Name(), Dir: info.IsDir(), ModTime: info.ModTime()}
+		if !entry.Dir {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries, nil
+}
+
+// writeFileError answers a failed file operation without revealing absolute
+// paths
+func writeFileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, errOutsideProject):
+		http.Error(w, "Path is outside the project", http.StatusForbidden)
+	case errors.Is(err, errGitDir):
+		http.Error(w, "The .git directory can't be accessed", http.StatusForbidden)
+	case errors.Is(err, os.ErrNotExist):
+		http.Error(w, "Not found", http.StatusNotFound)
+	case errors.Is(err, os.ErrPermission):
+		http.Error(w, "Permission denied", http.StatusForbidden)
+	default:
+		logging.Warn("Remote file access failed", "error", err)
+		http.Error(w, "File access failed", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,80 @@
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveProjectPathRefusesGitDir(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{".git/hooks", "src"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, ".git", "config"), []byte("[core]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, ".git"), filepath.Join(root, "src", "meta")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rel  string
+		want error
+	}{
+		{rel: "src", want: nil},
+		{rel: ".git", want: errGitDir},
+		{rel: ".git/config", want: errGitDir},
+		{rel: ".GIT/hooks", want: errGitDir},
+		{rel: "src/../.git/config", want: errGitDir},
+		{rel: "src/meta/config", want: errGitDir},
+		{rel: "../outside", want: os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		_, _, err := resolveProjectPath(root, tt.rel)
+		if (tt.want == nil && err != nil) || (tt.want != nil && !errors.Is(err, tt.want)) {
+			t.Errorf("resolveProjectPath(%q) error = %v, want %v", tt.rel, err, tt.want)
+		}
+	}
+}
+
+func TestUploadRefusesGitDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(nil)
+
+	upload := func(dir, name string) int {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile("file", name)
+		part.Write([]byte("#!/bin/sh\n"))
+		mw.Close()
+		r := httptest.NewRequest(http.MethodPost, "/api/files?project=p&overwrite=1&path="+dir, &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		s.receiveUpload(w, r, "token", "p", root)
+		return w.Code
+	}
+
+	if code := upload(".git/hooks", "pre-commit"); code != http.StatusForbidden {
+		t.Errorf("upload into .git/hooks status = %d, want %d", code, http.StatusForbidden)
+	}
+	if code := upload("", ".Git"); code != http.StatusBadRequest {
+		t.Errorf("upload named .Git status = %d, want %d", code, http.StatusBadRequest)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".git", "hooks", "pre-commit")); !os.IsNotExist(err) {
+		t.Errorf("hook was written: %v", err)
+	}
+	if code := upload("", "notes.txt"); code != http.StatusCreated {
+		t.Errorf("upload of a project file status = %d, want %d", code, http.StatusCreated)
+	}
+}
@@ -3,8 +3,13 @@ package remote
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -12,18 +17,32 @@ import (
 	"projecthub/internal/logging"
 )
 
-// NgrokTunnel manages an ngrok tunnel
+// remoteTunnelName is the ngrok tunnel of the remote access server
+const remoteTunnelName = "remote"
+
+// NgrokTunnel manages the ngrok agent: one process serving the remote
+// access server's tunnel and any extra tunnels
 type NgrokTunnel struct {
-	cmd       *exec.Cmd
-	publicURL string
-	running   bool
-	apiPort   int
-	mu        sync.RWMutex
+	cmd        *exec.Cmd
+	publicURL  string
+	running    bool
+	apiPort    int
+	configFile string       // generated agent config, removed on stop
+	tunnels    []TunnelInfo // extra tunnels, with their public URLs
+	mu         sync.RWMutex
+}
+
+// TunnelInfo is a running extra tunnel
+type TunnelInfo struct {
+	Name      string `json:"name"`
+	Port      int    `json:"port"`
+	PublicURL string `json:"publicUrl"` // "" when ngrok didn't start it
 }
 
 // NgrokAPIResponse represents the ngrok API tunnels response
 type NgrokAPIResponse struct {
 	Tunnels []struct {
+		Name      string `json:"name"`
 		PublicURL string `json:"public_url"`
 		Proto     string `json:"proto"`
 	} `json:"tunnels"`
@@ -55,13 +74,30 @@ func (n *NgrokTunnel) Start(config Config) (string, error) {
 		n.apiPort = config.NgrokAPIPort
 	}
 
-	// Build ngrok command
-	args := []string{"http", fmt.Sprintf("%d", config.Port)}
+	// The tunnels are defined in a generated config file, loaded after the
+	// user's own config (which holds the authtoken), so one agent serves
+	// them all; free plans allow one agent session only
+	names, configYAML := n.buildConfig(config)
+	f, err := os.CreateTemp("", "projecthub-ngrok-*.yml")
+	if err != nil {
+		return "", fmt.Errorf("failed to write ngrok config: %v", err)
+	}
+	_, err = f.WriteString(configYAML)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write ngrok config: %v", err)
+	}
+	n.configFile = f.Name()
 
-	// Add subdomain for premium users
-	if config.NgrokPlan == "premium" && config.Subdomain != "" {
-		args = append(args, "--subdomain", config.Subdomain)
+	args := []string{"start"}
+	args = append(args, names...)
+	if userConfig := ngrokUserConfig(); userConfig != "" {
+		args = append(args, "--config", userConfig)
 	}
+	args = append(args, "--config", n.configFile)
 
 	logging.Info("Starting ngrok tunnel", "args", strings.Join(args, " "), "apiPort", n.apiPort)
 
@@ -69,26 +105,31 @@ func (n *NgrokTunnel) Start(config Config) (string, error) {
 
 	// Start ngrok in background
 	if err := n.cmd.Start(); err != nil {
+		os.Remove(n.configFile)
+		n.configFile = ""
 		return "", fmt.Errorf("failed to start ngrok: %v", err)
 	}
 
 	n.running = true
 
-	// Wait for ngrok to be ready and get the public URL
-	var publicURL string
+	// Wait for ngrok to be ready and get the public URLs
+	var urls map[string]string
 	var lastErr error
 
 	for i := 0; i < 10; i++ {
 		time.Sleep(500 * time.Millisecond)
 
-		url, err := n.getPublicURLInternal()
-		if err == nil && url != "" {
-			publicURL = url
+		urls, lastErr = n.getTunnelURLsInternal()
+		if lastErr == nil && len(urls) >= len(names) {
 			break
 		}
-		lastErr = err
 	}
 
+	publicURL := urls[remoteTunnelName]
+	if publicURL == "" && config.NgrokEdge != "" && config.NgrokDomain != "" {
+		// Labeled tunnels don't report the edge's URL
+		publicURL = "https://" + config.NgrokDomain
+	}
 	if publicURL == "" {
 		n.stopInternal()
 		if lastErr != nil {
@@ -98,13 +139,100 @@ func (n *NgrokTunnel) Start(config Config) (string, error) {
 	}
 
 	n.publicURL = publicURL
-	logging.Info("ngrok tunnel started", "url", publicURL)
+	for i := range n.tunnels {
+		n.tunnels[i].PublicURL = urls[names[i+1]]
+		if n.tunnels[i].PublicURL == "" {
+			logging.Warn("ngrok extra tunnel not started", "name", n.tunnels[i].Name, "port", n.tunnels[i].Port)
+		}
+	}
+	logging.Info("ngrok tunnel started", "url", publicURL, "extraTunnels", len(n.tunnels))
 
 	return publicURL, nil
 }
 
-// getPublicURLInternal fetches the public URL from ngrok's local API (must be called with lock held)
-func (n *NgrokTunnel) getPublicURLInternal() (string, error) {
+// buildConfig writes the agent config of the tunnels, returning their names
+// (the remote access server's first) and the YAML. It also records the extra
+// tunnels (must be called with lock held).
+func (n *NgrokTunnel) buildConfig(config Config) ([]string, string) {
+	var b strings.Builder
+	b.WriteString("version: \"2\"\n")
+	fmt.Fprintf(&b, "web_addr: localhost:%d\n", n.apiPort)
+	if config.NgrokRegion != "" {
+		fmt.Fprintf(&b, "region: %s\n", config.NgrokRegion)
+	}
+	b.WriteString("tunnels:\n")
+
+	// The remote access server, at the address it listens on; with TLS the
+	// tunnel forwards to HTTPS
+	target := strconv.Itoa(config.Port)
+	host := "localhost"
+	if listen := config.ListenHost(); !isWildcardHost(listen) {
+		host = listen
+		target = net.JoinHostPort(host, target)
+	}
+	if config.TLSMode != TLSModeOff {
+		target = "https://" + net.JoinHostPort(host, strconv.Itoa(config.Port))
+	}
+	fmt.Fprintf(&b, "  %s:\n", remoteTunnelName)
+	fmt.Fprintf(&b, "    addr: %s\n", strconv.Quote(target))
+	switch {
+	case config.NgrokEdge != "":
+		fmt.Fprintf(&b, "    labels:\n      - %s\n", strconv.Quote("edge="+config.NgrokEdge))
+	case config.NgrokDomain != "":
+		b.WriteString("    proto: http\n")
+		fmt.Fprintf(&b, "    domain: %s\n", strconv.Quote(config.NgrokDomain))
+	case config.NgrokPlan == "premium" && config.Subdomain != "":
+		// Subdomain for premium users
+		b.WriteString("    proto: http\n")
+		fmt.Fprintf(&b, "    subdomain: %s\n", strconv.Quote(config.Subdomain))
+	default:
+		b.WriteString("    proto: http\n")
+	}
+
+	// Extra tunnels; dev servers often refuse unknown hosts, so they get
+	// the Host header they expect
+	names := []string{remoteTunnelName}
+	n.tunnels = nil
+	for _, t := range config.ExtraTunnels {
+		name := t.Name
+		if name == "" || slices.Contains(names, name) {
+			name = fmt.Sprintf("port-%d", t.Port)
+		}
+		names = append(names, name)
+		n.tunnels = append(n.tunnels, TunnelInfo{Name: name, Port: t.Port})
+
+		fmt.Fprintf(&b, "  %s:\n", strconv.Quote(name))
+		b.WriteString("    proto: http\n")
+		fmt.Fprintf(&b, "    addr: %d\n", t.Port)
+		b.WriteString("    host_header: rewrite\n")
+		if t.Domain != "" {
+			fmt.Fprintf(&b, "    domain: %s\n", strconv.Quote(t.Domain))
+		}
+	}
+	return names, b.String()
+}
+
+// ngrokUserConfig returns the user's ngrok config file, which holds the
+// authtoken, or "" when there is none
+func ngrokUserConfig() string {
+	var candidates []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		candidates = append(candidates, filepath.Join(dir, "ngrok", "ngrok.yml"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".ngrok2", "ngrok.yml")) // ngrok v2
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// getTunnelURLsInternal fetches the public URLs of the tunnels from ngrok's
+// local API, by tunnel name (must be called with lock held)
+func (n *NgrokTunnel) getTunnelURLsInternal() (map[string]string, error) {
 	apiURL := fmt.Sprintf("http://localhost:%d/api/tunnels", n.apiPort)
 
 	client := &http.Client{
@@ -113,38 +241,40 @@ func (n *NgrokTunnel) getPublicURLInternal() (string, error) {
 
 	resp, err := client.Get(apiURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("ngrok API returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("ngrok API returned status %d", resp.StatusCode)
 	}
 
 	var apiResp NgrokAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Find HTTPS tunnel
+	// Prefer HTTPS; older agents also open an HTTP tunnel named "<name> (http)"
+	urls := make(map[string]string)
 	for _, tunnel := range apiResp.Tunnels {
-		if tunnel.Proto == "https" || strings.HasPrefix(tunnel.PublicURL, "https://") {
-			return tunnel.PublicURL, nil
+		name := strings.TrimSuffix(tunnel.Name, " (http)")
+		https := tunnel.Proto == "https" || strings.HasPrefix(tunnel.PublicURL, "https://")
+		if urls[name] == "" || https {
+			urls[name] = tunnel.PublicURL
 		}
 	}
 
-	// Fallback to any tunnel
-	if len(apiResp.Tunnels) > 0 {
-		return apiResp.Tunnels[0].PublicURL, nil
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no tunnels found")
 	}
-
-	return "", fmt.Errorf("no tunnels found")
+	return urls, nil
 }
 
 // stopInternal stops the tunnel (must be called with lock held)
 func (n *NgrokTunnel) stopInternal() {
 	n.running = false
 	n.publicURL = ""
+	n.tunnels = nil
 
 	if n.cmd != nil && n.cmd.Process != nil {
 		logging.Info("Stopping ngrok tunnel")
@@ -154,6 +284,11 @@ func (n *NgrokTunnel) stopInternal() {
 		n.cmd.Wait()
 		n.cmd = nil
 	}
+
+	if n.configFile != "" {
+		os.Remove(n.configFile)
+		n.configFile = ""
+	}
 }
 
 // Stop stops the ngrok tunnel
@@ -192,13 +327,25 @@ func (n *NgrokTunnel) RefreshURL() (string, error) {
 		return "", fmt.Errorf("tunnel not running")
 	}
 
-	url, err := n.getPublicURLInternal()
+	urls, err := n.getTunnelURLsInternal()
 	if err != nil {
 		return "", err
 	}
+	// A labeled tunnel reports no URL; keep the edge's
+	if url := urls[remoteTunnelName]; url != "" {
+		n.publicURL = url
+	}
+	for i := range n.tunnels {
+		n.tunnels[i].PublicURL = urls[n.tunnels[i].Name]
+	}
+	return n.publicURL, nil
+}
 
-	n.publicURL = url
-	return url, nil
+// GetTunnels returns the extra tunnels
+func (n *NgrokTunnel) GetTunnels() []TunnelInfo {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return append([]TunnelInfo{}, n.tunnels...)
 }
 
 // GetAPIPort returns the configured ngrok API port
@@ -0,0 +1,452 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// Web Push settings
+const (
+	pushTTL          = 24 * time.Hour // How long the push service keeps an undelivered message
+	pushJWTLifetime  = 12 * time.Hour // The longest push services accept is 24h
+	pushTimeout      = 10 * time.Second
+	pushRecordSize   = 4096
+	pushVAPIDSubject = "mailto:noreply@claudilandia.app"
+	vapidKeysFile    = "remote-vapid.json"
+)
+
+// ErrPushGone means the push service dropped the subscription; it should be
+// forgotten
+var ErrPushGone = errors.New("push subscription expired")
+
+// VAPIDKeys identify the server to push services, base64url encoded
+type VAPIDKeys struct {
+	PublicKey  string `json:"publicKey"`  // Uncompressed P-256 point
+	PrivateKey string `json:"privateKey"` // SEC 1 DER
+}
+
+// PushSubscription is a browser's push subscription, as PushSubscription.toJSON
+// gives it, with the access token of the client that made it
+type PushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+	Token string `json:"token,omitempty"`
+}
+
+// PushMessage is the payload the service worker shows as a notification
+type PushMessage struct {
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	ProjectID string `json:"projectId,omitempty"`
+	Tag       string `json:"tag,omitempty"` // Replaces an earlier notification with the same tag
+}
+
+// LoadVAPIDKeys loads the VAPID keys saved in dataDir, creating them on
+// first use. Push services tie subscriptions to the public key, so it must
+// not change.
+func LoadVAPIDKeys(dataDir string) (VAPIDKeys, error) {
+	path := filepath.Join(dataDir, vapidKeysFile)
+	if data, err := os.ReadFile(path); err == nil {
+		var keys VAPIDKeys
+		if err := json.Unmarshal(data, &keys); err == nil && keys.PublicKey != "" && keys.PrivateKey != "" {
+			return keys, nil
+		}
+	}
+
+	keys, err := GenerateVAPIDKeys()
+	if err != nil {
+		return VAPIDKeys{}, err
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return VAPIDKeys{}, err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return VAPIDKeys{}, fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return VAPIDKeys{}, fmt.Errorf("failed to save VAPID keys: %w", err)
+	}
+	logging.Info("VAPID keys created for push notifications")
+	return keys, nil
+}
+
+// GenerateVAPIDKeys creates a new VAPID key pair
+func GenerateVAPIDKeys() (VAPIDKeys, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return VAPIDKeys{}, fmt.Errorf("failed to generate VAPID key: %w", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return VAPIDKeys{}, fmt.Errorf("failed to encode VAPID key: %w", err)
+	}
+	public, err := key.PublicKey.ECDH()
+	if err != nil {
+		return VAPIDKeys{}, fmt.Errorf("failed to encode VAPID key: %w", err)
+	}
+	return VAPIDKeys{
+		PublicKey:  base64.RawURLEncoding.EncodeToString(public.Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(der),
+	}, nil
+}
+
+// SendPush encrypts a message for a subscription (RFC 8291) and posts it to
+// the subscription's push service, signed with the VAPID keys (RFC 8292)
+func SendPush(sub PushSubscription, keys VAPIDKeys, msg PushMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	body, err := encryptPush(sub, payload)
+	if err != nil {
+		return err
+	}
+	auth, err := vapidAuthorization(sub.Endpoint, keys)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid push endpoint: %w", err)
+	}
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", fmt.Sprintf("%d", int(pushTTL.Seconds())))
+	req.Header.Set("Urgency", "high")
+
+	client := &http.Client{Timeout: pushTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrPushGone
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("push service returned %s", resp.Status)
+	}
+	return nil
+}
+
+// encryptPush encrypts a payload as a single aes128gcm record for the
+// subscription's keys
+func encryptPush(sub PushSubscription, payload []byte) ([]byte, error) {
+	uaPublicBytes, err := decodeBase64URL(sub.Keys.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+	authSecret, err := decodeBase64URL(sub.Keys.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription secret: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription key: %w", err)
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+	shared, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	// Mix the subscription's auth secret into the shared secret, then derive
+	// the content key and nonce from it and the salt
+	prkKey, err := hkdf.Extract(sha256.New, shared, authSecret)
+	if err != nil {
+		return nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublicBytes) + string(asPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := append(append([]byte{}, payload...), 0x02) // Last record delimiter
+	if len(plaintext)+gcm.Overhead() > pushRecordSize {
+		return nil, fmt.Errorf("push message too large: %d bytes", len(payload))
+	}
+
+	// Header: salt, record size, key ID length and key ID (our public key)
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, pushRecordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// vapidAuthorization returns the Authorization header for a push endpoint:
+// a JWT for the endpoint's origin signed with the VAPID key
+func vapidAuthorization(endpoint string, keys VAPIDKeys) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint: %s", endpoint)
+	}
+	der, err := decodeBase64URL(keys.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid VAPID key: %w", err)
+	}
+	key, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return "", fmt.Errorf("invalid VAPID key: %w", err)
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(pushJWTLifetime).Unix(),
+		"sub": pushVAPIDSubject,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	signature := make([]byte, 64) // JWS wants r and s as fixed-size big-endian
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	jwt := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return fmt.Sprintf("vapid t=%s, k=%s", jwt, keys.PublicKey), nil
+}
+
+// decodeBase64URL decodes base64url with or without padding, as browsers
+// aren't consistent
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// SetVAPIDKeys sets the keys push messages are signed with
+func (s *Server) SetVAPIDKeys(keys VAPIDKeys) {
+	s.mu.Lock()
+	s.vapidKeys = keys
+	s.mu.Unlock()
+}
+
+// SetPushSubscriptions loads push subscriptions (for persistence)
+func (s *Server) SetPushSubscriptions(subs []PushSubscription) {
+	s.mu.Lock()
+	s.pushSubs = make(map[string]PushSubscription)
+	for _, sub := range subs {
+		s.pushSubs[sub.Endpoint] = sub
+	}
+	s.mu.Unlock()
+}
+
+// GetPushSubscriptions returns all push subscriptions
+func (s *Server) GetPushSubscriptions() []PushSubscription {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]PushSubscription, 0, len(s.pushSubs))
+	for _, sub := range s.pushSubs {
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// SetPushChangeCallback sets a callback for when push subscriptions change
+func (s *Server) SetPushChangeCallback(cb func()) {
+	s.mu.Lock()
+	s.onPushChange = cb
+	s.mu.Unlock()
+}
+
+// Notify sends a push message to the subscribed devices allowed to see the
+// project. Subscriptions whose token was revoked or that the push service
+// dropped are removed.
+func (s *Server) Notify(msg PushMessage) {
+	s.mu.RLock()
+	keys := s.vapidKeys
+	var targets []PushSubscription
+	var revoked []string
+	for endpoint, sub := range s.pushSubs {
+		client, approved := s.approvedClients[sub.Token]
+		switch {
+		case !approved:
+			revoked = append(revoked, endpoint)
+		case client.AllowsProject(msg.ProjectID):
+			targets = append(targets, sub)
+		}
+	}
+	s.mu.RUnlock()
+
+	if keys.PrivateKey == "" {
+		return
+	}
+	if len(revoked) > 0 {
+		s.removePushSubscriptions(revoked)
+	}
+
+	go func() {
+		var gone []string
+		for _, sub := range targets {
+			err := SendPush(sub, keys, msg)
+			if errors.Is(err, ErrPushGone) {
+				gone = append(gone, sub.Endpoint)
+			} else if err != nil {
+				logging.Warn("Push notification failed", "error", err)
+			}
+		}
+		if len(gone) > 0 {
+			s.removePushSubscriptions(gone)
+		}
+	}()
+}
+
+// removePushSubscriptions forgets subscriptions by endpoint
+func (s *Server) removePushSubscriptions(endpoints []string) {
+	s.mu.Lock()
+	for _, endpoint := range endpoints {
+		delete(s.pushSubs, endpoint)
+	}
+	cb := s.onPushChange
+	s.mu.Unlock()
+
+	if cb != nil {
+		cb()
+	}
+}
+
+// handlePush serves the VAPID public key (GET) and takes subscriptions
+// (POST) and unsubscriptions (DELETE). Only saved devices can subscribe: a
+// temporary token expires, but a subscription would outlive it.
+func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	token := r.Header.Get("Authorization")
+	if strings.HasPrefix(token, "Bearer ") {
+		token = strings.TrimPrefix(token, "Bearer ")
+	} else {
+		token = r.URL.Query().Get("token")
+	}
+
+	if !s.validateToken(token) {
+		s.recordFailedAuth(clientIP)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	s.resetAuthAttempts(clientIP)
+
+	if !s.checkSecondFactor(w, r, token) {
+		return
+	}
+
+	if !s.IsApprovedToken(token) {
+		http.Error(w, "Notifications need a saved device", http.StatusForbidden)
+		return
+	}
+
+	s.mu.RLock()
+	publicKey := s.vapidKeys.PublicKey
+	s.mu.RUnlock()
+	if publicKey == "" {
+		http.Error(w, "Push notifications not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"publicKey": publicKey})
+
+	case http.MethodPost, http.MethodDelete:
+		var sub PushSubscription
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&sub); err != nil || sub.Endpoint == "" {
+			http.Error(w, "Invalid subscription", http.StatusBadRequest)
+			return
+		}
+		if u, err := url.Parse(sub.Endpoint); err != nil || u.Scheme != "https" {
+			http.Error(w, "Invalid subscription endpoint", http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		if r.Method == http.MethodPost {
+			sub.Token = token
+			s.pushSubs[sub.Endpoint] = sub
+		} else {
+			delete(s.pushSubs, sub.Endpoint)
+		}
+		cb := s.onPushChange
+		s.mu.Unlock()
+
+		if cb != nil {
+			cb()
+		}
+		logging.Info("Remote push subscription changed", "subscribed", r.Method == http.MethodPost)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
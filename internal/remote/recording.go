@@ -0,0 +1,458 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"projecthub/internal/logging"
+)
+
+// Session recording settings
+const (
+	RecordingsDir       = "remote-sessions"
+	recordingMetaFile   = "session.json"
+	recordingInputFile  = "input.log"
+	iTermRecordingName  = "iterm2"
+	defaultCastCols     = 80 // Size of a recording until the client resizes
+	defaultCastRows     = 24
+	clearScreenSequence = "\x1b[2J\x1b[H"
+)
+
+// recordingIDPattern matches the IDs of recordings, so an ID can't name a
+// path outside the recordings directory
+var recordingIDPattern = regexp.MustCompile(`^[0-9]{8}-[0-9]{6}-[0-9a-f]+$`)
+
+// RecordingInfo describes a recorded remote session: one asciicast file per
+// terminal the client watched, and the client's input
+type RecordingInfo struct {
+	ID         string    `json:"id"`
+	ClientName string    `json:"clientName,omitempty"` // Saved device name; empty for temporary tokens
+	RemoteAddr string    `json:"remoteAddr"`
+	UserAgent  string    `json:"userAgent"`
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"` // Zero while recording
+	Dir        string    `json:"dir"`
+	Casts      []string  `json:"casts"` // Asciicast files, one per terminal
+	Size       int64     `json:"size"`
+	Active     bool      `json:"active"`
+}
+
+// recordingMeta is what a session's session.json holds
+type recordingMeta struct {
+	ClientName string    `json:"clientName,omitempty"`
+	RemoteAddr string    `json:"remoteAddr"`
+	UserAgent  string    `json:"userAgent"`
+	StartedAt  time.Time `json:"startedAt"`
+	EndedAt    time.Time `json:"endedAt"`
+}
+
+// SessionRecorder records the terminal traffic of remote client sessions
+// into a directory per session
+type SessionRecorder struct {
+	dir       string
+	mu        sync.Mutex
+	retention time.Duration // Recordings older than this are deleted; 0 keeps them
+	active    map[string]bool
+}
+
+// NewSessionRecorder creates a recorder keeping sessions under dir
+func NewSessionRecorder(dir string) *SessionRecorder {
+	return &SessionRecorder{dir: dir, active: make(map[string]bool)}
+}
+
+// SetRetention sets how many days recordings are kept (0 keeps them) and
+// deletes the ones past it
+func (r *SessionRecorder) SetRetention(days int) {
+	r.mu.Lock()
+	r.retention = time.Duration(days) * 24 * time.Hour
+	r.mu.Unlock()
+	r.prune()
+}
+
+// prune deletes the finished recordings older than the retention
+func (r *SessionRecorder) prune() {
+	r.mu.Lock()
+	retention := r.retention
+	r.mu.Unlock()
+	if retention <= 0 {
+		return
+	}
+
+	recordings, err := r.List()
+	if err != nil {
+		logging.Warn("Failed to prune remote session recordings", "error", err)
+		return
+	}
+	cutoff := time.Now().Add(-retention)
+	for _, rec := range recordings {
+		if rec.Active || rec.EndedAt.IsZero() || rec.EndedAt.After(cutoff) {
+			continue
+		}
+		if err := r.Delete(rec.ID); err != nil {
+			logging.Warn("Failed to delete expired remote session recording", "id", rec.ID, "error", err)
+		}
+	}
+}
+
+// List returns the recorded sessions, newest first
+func (r *SessionRecorder) List() ([]RecordingInfo, error) {
+	dirEntries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return []RecordingInfo{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recordings: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recordings := []RecordingInfo{}
+	for _, e := range dirEntries {
+		if !e.IsDir() || !recordingIDPattern.MatchString(e.Name()) {
+			continue
+		}
+		dir := filepath.Join(r.dir, e.Name())
+		data, err := os.ReadFile(filepath.Join(dir, recordingMetaFile))
+		if err != nil {
+			continue
+		}
+		var meta recordingMeta
+		if json.Unmarshal(data, &meta) != nil {
+			continue
+		}
+		info := RecordingInfo{
+			ID:         e.Name(),
+			ClientName: meta.ClientName,
+			RemoteAddr: meta.RemoteAddr,
+			UserAgent:  meta.UserAgent,
+			StartedAt:  meta.StartedAt,
+			EndedAt:    meta.EndedAt,
+			Dir:        dir,
+			Casts:      []string{},
+			Active:     r.active[e.Name()],
+		}
+		files, _ := os.ReadDir(dir)
+		for _, f := range files {
+			if fi, err := f.Info(); err == nil {
+				info.Size += fi.Size()
+			}
+			if strings.HasSuffix(f.Name(), ".cast") {
+				info.Casts = append(info.Casts, f.Name())
+			}
+		}
+		recordings = append(recordings, info)
+	}
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].StartedAt.After(recordings[j].StartedAt)
+	})
+	return recordings, nil
+}
+
+// Delete removes a recorded session. Sessions still being recorded can't be
+// deleted.
+func (r *SessionRecorder) Delete(id string) error {
+	if !recordingIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid recording ID: %s", id)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active[id] {
+		return fmt.Errorf("session is still being recorded")
+	}
+	dir := filepath.Join(r.dir, id)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("recording not found: %s", id)
+	}
+	return os.RemoveAll(dir)
+}
+
+// start begins recording a client's session
+func (r *SessionRecorder) start(client *ClientInfo) (*sessionRecording, error) {
+	r.prune()
+
+	meta := recordingMeta{
+		RemoteAddr: client.RemoteAddr,
+		UserAgent:  client.UserAgent,
+		StartedAt:  client.ConnectedAt,
+	}
+	if client.scope != nil {
+		meta.ClientName = client.scope.Name
+	}
+	id := client.ConnectedAt.Format("20060102-150405") + "-" + client.ID
+	dir := filepath.Join(r.dir, id)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	rec := &sessionRecording{
+		recorder: r,
+		id:       id,
+		dir:      dir,
+		meta:     meta,
+		casts:    make(map[string]*castFile),
+		sizes:    make(map[string][2]int),
+	}
+	if err := rec.writeMeta(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.active[id] = true
+	r.mu.Unlock()
+	return rec, nil
+}
+
+// sessionRecording is the recording of one client session. Its methods do
+// nothing on a nil recording, so callers needn't check whether recording
+// is on.
+type sessionRecording struct {
+	recorder *SessionRecorder
+	id       string
+	dir      string
+	meta     recordingMeta
+	mu       sync.Mutex
+	casts    map[string]*castFile // termID -> asciicast
+	sizes    map[string][2]int    // termID -> last cols, rows from the client
+	input    *os.File
+	failed   bool // Stop writing after the first error
+}
+
+// castFile is an asciicast v2 file being written
+type castFile struct {
+	f       *os.File
+	pending []byte // Start of a UTF-8 sequence split across chunks
+}
+
+// output records output sent to the client
+func (rec *sessionRecording) output(termID string, data []byte) {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	cast := rec.cast(termID)
+	if cast == nil {
+		return
+	}
+	var text string
+	if termID == "" {
+		// iTerm2 output is the whole screen, with bare newlines
+		text = clearScreenSequence + strings.ReplaceAll(string(data), "\n", "\r\n")
+	} else {
+		data = append(cast.pending, data...)
+		cut := incompleteUTF8Suffix(data)
+		cast.pending = append([]byte(nil), data[len(data)-cut:]...)
+		text = string(data[:len(data)-cut])
+	}
+	if text != "" {
+		rec.writeEvent(cast, "o", text)
+	}
+}
+
+// resize records the terminal size the client asked for
+func (rec *sessionRecording) resize(termID string, rows, cols int) {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	rec.sizes[termID] = [2]int{cols, rows}
+	if cast, ok := rec.casts[termID]; ok {
+		rec.writeEvent(cast, "r", fmt.Sprintf("%dx%d", cols, rows))
+	}
+}
+
+// inputEntry is a line of a session's input log
+type inputEntry struct {
+	Time     time.Time `json:"time"`
+	Terminal string    `json:"terminal"`
+	Data     string    `json:"data"`
+}
+
+// recordInput records input the client typed
+func (rec *sessionRecording) recordInput(termID string, data string) {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.failed {
+		return
+	}
+	if rec.input == nil {
+		f, err := os.OpenFile(filepath.Join(rec.dir, recordingInputFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			rec.fail(err)
+			return
+		}
+		rec.input = f
+	}
+	if termID == "" {
+		termID = iTermRecordingName
+	}
+	line, _ := json.Marshal(inputEntry{Time: time.Now(), Terminal: termID, Data: data})
+	if _, err := rec.input.Write(append(line, '\n')); err != nil {
+		rec.fail(err)
+	}
+}
+
+// close finishes the recording
+func (rec *sessionRecording) close() {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	for _, cast := range rec.casts {
+		cast.f.Close()
+	}
+	if rec.input != nil {
+		rec.input.Close()
+	}
+	rec.meta.EndedAt = time.Now()
+	if err := rec.writeMeta(); err != nil {
+		logging.Warn("Failed to finish remote session recording", "id", rec.id, "error", err)
+	}
+	rec.mu.Unlock()
+
+	rec.recorder.mu.Lock()
+	delete(rec.recorder.active, rec.id)
+	rec.recorder.mu.Unlock()
+}
+
+// cast returns the asciicast of a terminal, creating it on first output.
+// Called with rec.mu held.
+func (rec *sessionRecording) cast(termID string) *castFile {
+	if rec.failed {
+		return nil
+	}
+	if cast, ok := rec.casts[termID]; ok {
+		return cast
+	}
+
+	name := iTermRecordingName
+	if termID != "" {
+		name = safeFileName(termID)
+	}
+	f, err := os.OpenFile(filepath.Join(rec.dir, name+".cast"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		rec.fail(err)
+		return nil
+	}
+	cols, rows := defaultCastCols, defaultCastRows
+	if size, ok := rec.sizes[termID]; ok {
+		cols, rows = size[0], size[1]
+	}
+	title := "iTerm2"
+	if termID != "" {
+		title = termID
+	}
+	header, _ := json.Marshal(map[string]interface{}{
+		"version":   2,
+		"width":     cols,
+		"height":    rows,
+		"timestamp": rec.meta.StartedAt.Unix(),
+		"title":     title,
+	})
+	if _, err := f.Write(append(header, '\n')); err != nil {
+		f.Close()
+		rec.fail(err)
+		return nil
+	}
+	cast := &castFile{f: f}
+	rec.casts[termID] = cast
+	return cast
+}
+
+// writeEvent appends an event to an asciicast. Called with rec.mu held.
+func (rec *sessionRecording) writeEvent(cast *castFile, kind, data string) {
+	elapsed := time.Since(rec.meta.StartedAt).Seconds()
+	line, _ := json.Marshal([]interface{}{float64(int64(elapsed*1e6)) / 1e6, kind, data})
+	if _, err := cast.f.Write(append(line, '\n')); err != nil {
+		rec.fail(err)
+	}
+}
+
+// fail stops a recording that can't be written. Called with rec.mu held.
+func (rec *sessionRecording) fail(err error) {
+	rec.failed = true
+	logging.Warn("Remote session recording stopped", "id", rec.id, "error", err)
+}
+
+// writeMeta saves the session's description
+func (rec *sessionRecording) writeMeta() error {
+	data, err := json.MarshalIndent(rec.meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(rec.dir, recordingMetaFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// incompleteUTF8Suffix returns how many bytes at the end of data start a
+// UTF-8 sequence that isn't complete yet
+func incompleteUTF8Suffix(data []byte) int {
+	for i := 1; i <= utf8.UTFMax-1 && i <= len(data); i++ {
+		b := data[len(data)-i]
+		if b < utf8.RuneSelf {
+			return 0 // ASCII
+		}
+		if utf8.RuneStart(b) {
+			if !utf8.FullRune(data[len(data)-i:]) {
+				return i
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// safeFileName replaces the characters of a terminal ID that don't belong in
+// a file name
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// SetSessionRecorder sets where client sessions are recorded; nil stops
+// recording new sessions
+func (s *Server) SetSessionRecorder(recorder *SessionRecorder) {
+	s.mu.Lock()
+	s.recorder = recorder
+	s.mu.Unlock()
+}
+
+// startRecording starts recording a client's session when recording is on
+func (s *Server) startRecording(client *ClientInfo) {
+	s.mu.RLock()
+	recorder := s.recorder
+	s.mu.RUnlock()
+
+	if recorder == nil {
+		return
+	}
+	rec, err := recorder.start(client)
+	if err != nil {
+		logging.Warn("Failed to record remote session", "clientId", client.ID, "error", err)
+		return
+	}
+	client.recording = rec
+}
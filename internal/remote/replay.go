@@ -0,0 +1,91 @@
+package remote
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+)
+
+// Output replay: mobile connections drop all the time, and output sent while
+// a client was away would leave a gap in its terminal. The recent output of
+// each app terminal is kept, numbered by byte offset; output messages carry
+// the offset they end at, and a client resubscribing with the last one it
+// saw gets what it missed.
+const replayBufferSize = 64 << 10 // per terminal
+
+// outputReplay is the recent output of an app terminal
+type outputReplay struct {
+	data []byte
+	end  int64 // offset of the byte after data, counted from the terminal's first output
+}
+
+// appendReplay keeps output for replay and returns the offset it ends at.
+// Callers hold s.batchMu.
+func (s *Server) appendReplay(termID string, data []byte) int64 {
+	replay, exists := s.replays[termID]
+	if !exists {
+		replay = &outputReplay{}
+		s.replays[termID] = replay
+	}
+	replay.data = append(replay.data, data...)
+	replay.end += int64(len(data))
+	// Trimmed in one go past twice the size, so chatty terminals don't copy
+	// the buffer on every chunk
+	if len(replay.data) > 2*replayBufferSize {
+		replay.data = append([]byte(nil), replay.data[len(replay.data)-replayBufferSize:]...)
+	}
+	return replay.end
+}
+
+// replaySince returns a terminal's output after an offset and the offset it
+// ends at; seq 0 replays nothing. missed is set when part of it is no longer
+// kept. An offset past the end is from before the server restarted, so all
+// that is kept is new to the client.
+func (s *Server) replaySince(termID string, seq int64) (data []byte, end int64, missed bool) {
+	s.batchMu.Lock()
+	defer s.batchMu.Unlock()
+
+	replay, exists := s.replays[termID]
+	if !exists {
+		return nil, 0, seq > 0
+	}
+	start := replay.end - int64(len(replay.data))
+	switch {
+	case seq <= 0 || seq == replay.end:
+		return nil, replay.end, false
+	case seq < start || seq > replay.end:
+		return append([]byte(nil), replay.data...), replay.end, true
+	}
+	return append([]byte(nil), replay.data[seq-start:]...), replay.end, false
+}
+
+// ForgetOutput drops the output kept for a terminal, e.g. when it exits
+func (s *Server) ForgetOutput(termID string) {
+	s.batchMu.Lock()
+	delete(s.replays, termID)
+	s.batchMu.Unlock()
+}
+
+// sendReplay sends a resubscribing client the output it missed
+func (s *Server) sendReplay(conn *websocket.Conn, client *ClientInfo, termID string, data []byte, end int64, missed bool) {
+	msg := ServerMessage{
+		Type:   MsgTypeOutput,
+		TermID: termID,
+		Data:   base64.StdEncoding.EncodeToString(data),
+		Seq:    end,
+	}
+	if missed {
+		msg.Message = "Some output was missed while disconnected"
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	client.writeMu.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, msgBytes)
+	client.writeMu.Unlock()
+	if err == nil {
+		client.recording.output(termID, data)
+	}
+}
@@ -0,0 +1,98 @@
+package remote
+
+import "slices"
+
+// Capabilities an approved client can be limited to: terminals, files, git
+// status and Docker containers.
+const (
+	CapabilityTerminals = "terminals"
+	CapabilityFiles     = "files"
+	CapabilityGit       = "git"
+	CapabilityDocker    = "docker"
+)
+
+// AllowsProject reports whether the client may access a project. Clients
+// without a project list may access all of them.
+func (c *ApprovedClient) AllowsProject(projectID string) bool {
+	if c == nil || len(c.Projects) == 0 {
+		return true
+	}
+	for _, id := range c.Projects {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the client may use a capability. Clients without a
+// capability list may use all of them.
+func (c *ApprovedClient) Allows(capability string) bool {
+	if c == nil || len(c.Capabilities) == 0 {
+		return true
+	}
+	for _, allowed := range c.Capabilities {
+		if allowed == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsProjectTerminals reports whether the client may use the terminals
+// of a project
+func (c *ApprovedClient) allowsProjectTerminals(projectID string) bool {
+	return c.Allows(CapabilityTerminals) && c.AllowsProject(projectID)
+}
+
+// allowsITerm reports whether the client may see and type in iTerm2. Its
+// tabs belong to no project, so clients limited to projects can't.
+func (c *ApprovedClient) allowsITerm() bool {
+	return c.Allows(CapabilityTerminals) && (c == nil || len(c.Projects) == 0)
+}
+
+// sameScope reports whether two approved clients may access the same things
+func (c *ApprovedClient) sameScope(other *ApprovedClient) bool {
+	return c.Viewer == other.Viewer &&
+		slices.Equal(c.Projects, other.Projects) &&
+		slices.Equal(c.Capabilities, other.Capabilities)
+}
+
+// scopedProjects returns the projects a client may see, with their terminals
+// only when it may use them
+func scopedProjects(projects []ProjectInfo, scope *ApprovedClient) []ProjectInfo {
+	if scope == nil {
+		return projects
+	}
+	scoped := make([]ProjectInfo, 0, len(projects))
+	for _, p := range projects {
+		if !scope.AllowsProject(p.ID) {
+			continue
+		}
+		if !scope.Allows(CapabilityTerminals) {
+			p.Terminals = []TerminalInfo{}
+		}
+		scoped = append(scoped, p)
+	}
+	return scoped
+}
+
+// terminalProject returns the ID of the project an app terminal belongs to,
+// or "" when it isn't known
+func (s *Server) terminalProject(terminalID string) string {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		return ""
+	}
+	for _, p := range handler.GetProjects() {
+		for _, t := range p.Terminals {
+			if t.ID == terminalID {
+				return p.ID
+			}
+		}
+	}
+	return ""
+}
@@ -4,17 +4,22 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/subtle"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"projecthub/internal/iterm"
 	"projecthub/internal/logging"
+	"projecthub/internal/testing"
 
 	"github.com/gorilla/websocket"
 )
@@ -23,26 +28,33 @@ import (
 type MessageType string
 
 const (
-	MsgTypeInput          MessageType = "input"
-	MsgTypeResize         MessageType = "resize"
-	MsgTypeList           MessageType = "list"
-	MsgTypeOutput         MessageType = "output"
-	MsgTypeTerminals      MessageType = "terminals"
-	MsgTypeProjects       MessageType = "projects"
-	MsgTypeError          MessageType = "error"
-	MsgTypePing           MessageType = "ping"
-	MsgTypePong           MessageType = "pong"
-	MsgTypeCreateTerminal MessageType = "createTerminal"
-	MsgTypeRenameTerminal MessageType = "renameTerminal"
-	MsgTypeDeleteTerminal MessageType = "deleteTerminal"
-	MsgTypeSwitchTab      MessageType = "switchTab"
+	MsgTypeInput           MessageType = "input"
+	MsgTypeResize          MessageType = "resize"
+	MsgTypeList            MessageType = "list"
+	MsgTypeOutput          MessageType = "output"
+	MsgTypeTerminals       MessageType = "terminals"
+	MsgTypeProjects        MessageType = "projects"
+	MsgTypeError           MessageType = "error"
+	MsgTypePing            MessageType = "ping"
+	MsgTypePong            MessageType = "pong"
+	MsgTypeCreateTerminal  MessageType = "createTerminal"
+	MsgTypeRenameTerminal  MessageType = "renameTerminal"
+	MsgTypeDeleteTerminal  MessageType = "deleteTerminal"
+	MsgTypeSwitchTab       MessageType = "switchTab"
+	MsgTypeSubscribe       MessageType = "subscribe"   // Stream an app terminal's output
+	MsgTypeUnsubscribe     MessageType = "unsubscribe" // Stop streaming it
+	MsgTypeSubscribed      MessageType = "subscribed"
+	MsgTypeStatus          MessageType = "status"          // Git, test and Claude status of the projects
+	MsgTypeGitStatus       MessageType = "gitStatus"       // A project's git status changed
+	MsgTypeTestStatus      MessageType = "testStatus"      // A terminal's test run changed
+	MsgTypeClaudeStatus    MessageType = "claudeStatus"    // A terminal's Claude status changed
+	MsgTypeContainers      MessageType = "containers"      // A project's Docker containers
+	MsgTypeContainerAction MessageType = "containerAction" // Start, stop or restart a container
 )
 
 // Security constants
 const (
 	maxClients       = 10             // Maximum concurrent connections
-	maxAuthAttempts  = 50             // Max failed auth attempts before lockout
-	authLockoutTime  = 1 * time.Minute // Lockout duration after max attempts
 	minResizeRows    = 1
 	maxResizeRows    = 500
 	minResizeCols    = 1
@@ -52,26 +64,35 @@ const (
 
 // ClientMessage represents a message from the client
 type ClientMessage struct {
-	Type      MessageType `json:"type"`
-	TermID    string      `json:"termId,omitempty"`
-	ProjectID string      `json:"projectId,omitempty"`
-	Data      string      `json:"data,omitempty"` // base64 encoded for input
-	Name      string      `json:"name,omitempty"` // for create/rename terminal
-	Rows      int         `json:"rows,omitempty"`
-	Cols      int         `json:"cols,omitempty"`
+	Type        MessageType `json:"type"`
+	TermID      string      `json:"termId,omitempty"`
+	ProjectID   string      `json:"projectId,omitempty"`
+	Data        string      `json:"data,omitempty"` // base64 encoded for input
+	Name        string      `json:"name,omitempty"` // for create/rename terminal
+	Rows        int         `json:"rows,omitempty"`
+	Cols        int         `json:"cols,omitempty"`
+	ContainerID string      `json:"containerId,omitempty"` // for container actions
+	Seq         int64       `json:"seq,omitempty"`         // for subscribe: last output offset seen, to replay what came after
+	Action      string      `json:"action,omitempty"`      // ContainerStart, ContainerStop or ContainerRestart
 }
 
 // ServerMessage represents a message to the client
 type ServerMessage struct {
-	Type      MessageType    `json:"type"`
-	TermID    string         `json:"termId,omitempty"`
-	ProjectID string         `json:"projectId,omitempty"`
-	Data      string         `json:"data,omitempty"` // base64 encoded for output
-	Terminals []TerminalInfo `json:"terminals,omitempty"`
-	Projects  []ProjectInfo  `json:"projects,omitempty"`
-	Terminal  *TerminalInfo  `json:"terminal,omitempty"` // for single terminal responses
-	Message   string         `json:"message,omitempty"`
-	Success   bool           `json:"success,omitempty"`
+	Type       MessageType     `json:"type"`
+	TermID     string          `json:"termId,omitempty"`
+	ProjectID  string          `json:"projectId,omitempty"`
+	Data       string          `json:"data,omitempty"` // base64 encoded for output
+	Terminals  []TerminalInfo  `json:"terminals,omitempty"`
+	Projects   []ProjectInfo   `json:"projects,omitempty"`
+	Terminal   *TerminalInfo   `json:"terminal,omitempty"` // for single terminal responses
+	Message    string          `json:"message,omitempty"`
+	Success    bool            `json:"success,omitempty"`
+	Statuses   []ProjectStatus `json:"statuses,omitempty"`
+	Git        *GitStatusInfo  `json:"git,omitempty"`
+	Tests      *TestStatusInfo `json:"tests,omitempty"`
+	Claude     string          `json:"claude,omitempty"`
+	Containers []ContainerInfo `json:"containers,omitempty"`
+	Seq        int64           `json:"seq,omitempty"` // app terminal output offset the output (or subscription) ends at
 }
 
 // TerminalInfo for client
@@ -100,7 +121,16 @@ type ClientInfo struct {
 	TerminalID  string    `json:"terminalId"`
 	UserAgent   string    `json:"userAgent"`
 	RemoteAddr  string    `json:"remoteAddr"`
+	Viewer      bool      `json:"viewer"` // Connected with a read-only token
 	writeMu     sync.Mutex // Per-connection mutex for thread-safe writes
+	// App terminals whose output streams to this client; without any the
+	// client follows the active iTerm2 session
+	subscriptions map[string]bool
+	// Approved client the token belongs to, limiting what it may access;
+	// nil for the temporary token, which may access everything
+	scope *ApprovedClient
+	// Recording of the session; nil when sessions aren't recorded
+	recording *sessionRecording
 }
 
 // authAttempt tracks failed authentication attempts
@@ -115,6 +145,13 @@ type ApprovedClient struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"createdAt"`
 	LastUsed  time.Time `json:"lastUsed"`
+	Viewer    bool      `json:"viewer"` // Read-only: can watch terminals but not type or change them
+	// Limits on what the client may access; empty lists allow everything
+	Projects     []string `json:"projects,omitempty"`     // Project IDs
+	Capabilities []string `json:"capabilities,omitempty"` // CapabilityTerminals, CapabilityFiles, CapabilityGit, CapabilityDocker
+	// TOTP second factor; browsers not yet confirmed with a code are refused
+	TOTPSecret  string   `json:"totpSecret,omitempty"`
+	TOTPDevices []string `json:"totpDevices,omitempty"` // Hashes of the device keys of confirmed browsers
 }
 
 // ProjectHandler is the interface for project/terminal operations
@@ -123,6 +160,17 @@ type ProjectHandler interface {
 	CreateTerminal(projectID, name string) (*TerminalInfo, error)
 	RenameTerminal(projectID, terminalID, name string) error
 	DeleteTerminal(projectID, terminalID string) error
+	HasTerminal(terminalID string) bool // Whether the ID is an app (PTY) terminal
+	WriteTerminal(terminalID string, data []byte) error
+	ResizeTerminal(terminalID string, rows, cols int) error
+	// RunTests types the project's test command into a terminal, a new one
+	// when terminalID is ""
+	RunTests(projectID, terminalID string) (*TestRunInfo, error)
+	GetTestSummary(terminalID string) *testing.TestSummary
+	GetGitStatus(projectID string) *GitStatusInfo // nil when the project isn't a git repository
+	GetClaudeStatus(terminalID string) string
+	GetContainers(projectID string) ([]ContainerInfo, error)
+	ContainerAction(containerID, action string) error
 }
 
 // Server handles remote terminal access via WebSocket
@@ -134,9 +182,14 @@ type Server struct {
 	approvedClients  map[string]*ApprovedClient // token -> client info
 	clients          map[*websocket.Conn]*ClientInfo
 	authAttempts     map[string]*authAttempt // IP -> auth attempts
+	totpAttempts     map[string]*authAttempt // token -> wrong codes, only reset by a right one
+	maxAuthAttempts  int                     // failed attempts before a lockout
+	authLockout      time.Duration           // how long a lockout lasts
+	bannedIPs        map[string]BannedIP     // refused for good
 	mu               sync.RWMutex
 	authMu           sync.RWMutex
 	port             int
+	bindHost         string // "" listens on every interface
 	server           *http.Server
 	upgrader         websocket.Upgrader
 	running          bool
@@ -144,6 +197,22 @@ type Server struct {
 	outputTicker     *time.Ticker
 	stopOutput       chan struct{}
 	lastOutput       string // track last output to detect changes
+	tlsCert          *tls.Certificate // serve HTTPS when set
+	vapidKeys        VAPIDKeys
+	pushSubs         map[string]PushSubscription // endpoint -> subscription
+	onPushChange     func()                      // callback when push subscriptions change
+	auditLog         *AuditLog                   // records client actions when set
+	recorder         *SessionRecorder            // records client sessions when set
+	outputBatches    map[string]*outputBatch     // termID -> output not sent yet
+	replays          map[string]*outputReplay    // termID -> recent output, for clients that reconnect
+	testStatuses     map[string]testing.TestStatus // termID -> test status last sent
+	transcriber      Transcriber                 // speech-to-text for voice input when set
+	transcribeMu     sync.Mutex                  // one transcription at a time
+	totpSteps        map[string]int64            // token -> last TOTP time step used, against replays
+	accessRequests   map[string]*AccessRequest   // ID -> device asking for access
+	onAccessRequest  func(AccessRequest)         // access requests are accepted when set
+	batchMu          sync.Mutex
+	flushMu          sync.Mutex // serializes output flushes
 }
 
 // NewServer creates a new remote access server
@@ -152,15 +221,26 @@ func NewServer(ic *iterm.Controller) *Server {
 		itermController: ic,
 		clients:         make(map[*websocket.Conn]*ClientInfo),
 		authAttempts:    make(map[string]*authAttempt),
+		totpAttempts:    make(map[string]*authAttempt),
+		maxAuthAttempts: DefaultMaxAuthAttempts,
+		authLockout:     DefaultAuthLockoutMinutes * time.Minute,
+		bannedIPs:       make(map[string]BannedIP),
 		approvedClients: make(map[string]*ApprovedClient),
+		pushSubs:        make(map[string]PushSubscription),
+		outputBatches:   make(map[string]*outputBatch),
+		replays:         make(map[string]*outputReplay),
+		testStatuses:    make(map[string]testing.TestStatus),
+		totpSteps:       make(map[string]int64),
+		accessRequests:  make(map[string]*AccessRequest),
 		port:            9090,
 		stopOutput:      make(chan struct{}),
 	}
 
 	s.upgrader = websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin:     s.checkOrigin,
+		ReadBufferSize:    1024,
+		WriteBufferSize:   1024,
+		CheckOrigin:       s.checkOrigin,
+		EnableCompression: true, // permessage-deflate; terminal output compresses well
 	}
 
 	return s
@@ -235,14 +315,29 @@ func (s *Server) GetApprovedClients() []*ApprovedClient {
 	return clients
 }
 
-// SetApprovedClients loads approved clients (for persistence)
+// SetApprovedClients loads approved clients (for persistence). Connected
+// clients whose scope changed, or whose token is gone, are disconnected so
+// they reconnect with the new scope.
 func (s *Server) SetApprovedClients(clients []*ApprovedClient) {
 	s.mu.Lock()
 	s.approvedClients = make(map[string]*ApprovedClient)
 	for _, c := range clients {
 		s.approvedClients[c.Token] = c
 	}
+	var stale []*websocket.Conn
+	for conn, client := range s.clients {
+		if client.scope == nil {
+			continue
+		}
+		if approved, exists := s.approvedClients[client.scope.Token]; !exists || !client.scope.sameScope(approved) {
+			stale = append(stale, conn)
+		}
+	}
 	s.mu.Unlock()
+
+	for _, conn := range stale {
+		conn.Close()
+	}
 }
 
 // IsApprovedToken checks if a token is an approved permanent token
@@ -253,6 +348,27 @@ func (s *Server) IsApprovedToken(token string) bool {
 	return exists
 }
 
+// approvedScope returns a copy of the approved client a token belongs to, or
+// nil for other tokens
+func (s *Server) approvedScope(token string) *ApprovedClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	client, exists := s.approvedClients[token]
+	if !exists {
+		return nil
+	}
+	scope := *client
+	return &scope
+}
+
+// IsViewerToken checks if a token is an approved read-only token
+func (s *Server) IsViewerToken(token string) bool {
+	s.mu.RLock()
+	client, exists := s.approvedClients[token]
+	s.mu.RUnlock()
+	return exists && client.Viewer
+}
+
 // UpdateApprovedClientLastUsed updates the last used time for an approved client
 func (s *Server) UpdateApprovedClientLastUsed(token string) {
 	s.mu.Lock()
@@ -262,6 +378,29 @@ func (s *Server) UpdateApprovedClientLastUsed(token string) {
 	s.mu.Unlock()
 }
 
+// SetTLSCertificate sets the certificate to serve HTTPS with, or nil for
+// plain HTTP. It applies from the next Start.
+func (s *Server) SetTLSCertificate(cert *tls.Certificate) {
+	s.mu.Lock()
+	s.tlsCert = cert
+	s.mu.Unlock()
+}
+
+// IsTLS returns whether the server serves HTTPS
+func (s *Server) IsTLS() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tlsCert != nil
+}
+
+// GetFingerprint returns the SHA-256 fingerprint of the TLS certificate, or
+// "" without TLS
+func (s *Server) GetFingerprint() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return CertificateFingerprint(s.tlsCert)
+}
+
 // checkOrigin validates the request origin for CORS
 func (s *Server) checkOrigin(r *http.Request) bool {
 	origin := r.Header.Get("Origin")
@@ -271,11 +410,18 @@ func (s *Server) checkOrigin(r *http.Request) bool {
 		return true
 	}
 
+	// Same origin as the page, e.g. the web client opened by LAN address
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return true
+	}
+
 	// Allow localhost for development
 	if strings.HasPrefix(origin, "http://localhost") ||
 		strings.HasPrefix(origin, "http://127.0.0.1") ||
+		strings.HasPrefix(origin, "http://[::1]") ||
 		strings.HasPrefix(origin, "https://localhost") ||
-		strings.HasPrefix(origin, "https://127.0.0.1") {
+		strings.HasPrefix(origin, "https://127.0.0.1") ||
+		strings.HasPrefix(origin, "https://[::1]") {
 		return true
 	}
 
@@ -349,25 +495,30 @@ func (s *Server) validateToken(token string) bool {
 	return tokenMatch && notExpired
 }
 
-// checkRateLimit checks if the IP is rate limited
+// checkRateLimit checks if the IP is rate limited or banned
 func (s *Server) checkRateLimit(ip string) bool {
 	s.authMu.RLock()
+	_, banned := s.bannedIPs[ip]
 	attempt, exists := s.authAttempts[ip]
+	maxAttempts, lockout := s.maxAuthAttempts, s.authLockout
 	s.authMu.RUnlock()
 
+	if banned {
+		return false
+	}
 	if !exists {
 		return true // Not rate limited
 	}
 
 	// Check if lockout has expired
-	if time.Since(attempt.lastTime) > authLockoutTime {
+	if time.Since(attempt.lastTime) > lockout {
 		s.authMu.Lock()
 		delete(s.authAttempts, ip)
 		s.authMu.Unlock()
 		return true
 	}
 
-	return attempt.count < maxAuthAttempts
+	return attempt.count < maxAttempts
 }
 
 // recordFailedAuth records a failed authentication attempt
@@ -382,7 +533,7 @@ func (s *Server) recordFailedAuth(ip string) {
 	s.authAttempts[ip].count++
 	s.authAttempts[ip].lastTime = time.Now()
 
-	if s.authAttempts[ip].count >= maxAuthAttempts {
+	if s.authAttempts[ip].count >= s.maxAuthAttempts {
 		logging.Warn("IP locked out due to failed auth attempts", "ip", ip)
 	}
 }
@@ -394,15 +545,30 @@ func (s *Server) resetAuthAttempts(ip string) {
 	s.authMu.Unlock()
 }
 
-// getClientIP extracts client IP from request
+// getClientIP returns the address a request came from, which rate limits,
+// bans and lockouts are keyed on. X-Forwarded-For is only trusted from
+// loopback, where the ngrok agent connects from, and then only its last
+// entry, the address the agent saw; earlier entries are whatever the client
+// sent.
 func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (for ngrok)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		parts := strings.Split(xff, ",")
-		return strings.TrimSpace(parts[0])
+	// RemoteAddr is "host:port", or "[host]:port" for IPv6
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+	ip := net.ParseIP(peer)
+	if ip == nil {
+		return peer
+	}
+	if ip.IsLoopback() {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			if forwarded := net.ParseIP(strings.TrimSpace(hops[len(hops)-1])); forwarded != nil {
+				return forwarded.String()
+			}
+		}
 	}
-	// Fall back to RemoteAddr
-	return strings.Split(r.RemoteAddr, ":")[0]
+	return ip.String()
 }
 
 // Start starts the remote access server
@@ -415,6 +581,8 @@ func (s *Server) Start(port int) error {
 	s.port = port
 	s.running = true
 	s.stopOutput = make(chan struct{})
+	cert := s.tlsCert
+	host := s.bindHost
 	s.mu.Unlock()
 
 	// Start output polling for iTerm2 content
@@ -426,13 +594,35 @@ func (s *Server) Start(port int) error {
 	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/api/terminals", s.handleTerminalsList)
 	mux.HandleFunc("/api/token-info", s.handleTokenInfo)
+	mux.HandleFunc("/api/push", s.handlePush)
+	mux.HandleFunc("/api/files", s.handleFiles)
+	mux.HandleFunc("GET /api/projects", s.handleAPIProjects)
+	mux.HandleFunc("POST /api/terminals/{id}/input", s.handleAPITerminalInput)
+	mux.HandleFunc("POST /api/tests/run", s.handleAPIRunTests)
+	mux.HandleFunc("GET /api/tests/{terminalId}", s.handleAPITestStatus)
+	mux.HandleFunc("POST /api/transcribe", s.handleTranscribe)
+	mux.HandleFunc("POST /api/totp", s.handleTOTPVerify)
+	mux.HandleFunc("POST /api/access-requests", s.handleCreateAccessRequest)
+	mux.HandleFunc("GET /api/access-requests/{id}", s.handleAccessRequestStatus)
+	mux.HandleFunc("/sw.js", s.serveServiceWorker)
+	mux.HandleFunc("/manifest.webmanifest", s.serveManifest)
+	mux.HandleFunc("GET /assets/{name}", s.handleAssets)
 
 	s.server = &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
 		Handler: mux,
 	}
 
-	logging.Info("Remote access server starting", "port", port)
+	if cert != nil {
+		s.server.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{*cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+		logging.Info("Remote access server starting", "addr", s.server.Addr, "tls", true, "fingerprint", CertificateFingerprint(cert))
+		return s.server.ListenAndServeTLS("", "")
+	}
+
+	logging.Info("Remote access server starting", "addr", s.server.Addr)
 	logging.Warn("Remote access server running without TLS - use ngrok for secure access")
 
 	return s.server.ListenAndServe()
@@ -458,9 +648,14 @@ func (s *Server) startOutputPolling() {
 
 // pollAndBroadcastOutput polls iTerm2 for output and broadcasts changes
 func (s *Server) pollAndBroadcastOutput() {
-	// Check if we have any connected clients
+	// Check if we have any connected clients following iTerm2
 	s.mu.RLock()
-	clientCount := len(s.clients)
+	clientCount := 0
+	for _, info := range s.clients {
+		if len(info.subscriptions) == 0 && info.scope.allowsITerm() {
+			clientCount++
+		}
+	}
 	s.mu.RUnlock()
 
 	if clientCount == 0 {
@@ -509,6 +704,7 @@ func (s *Server) Stop() error {
 		close(s.stopOutput)
 		s.stopOutput = nil
 	}
+	s.dropOutputBatches()
 
 	// Copy client list to close outside the main lock
 	clientsToClose := make([]*struct {
@@ -558,6 +754,22 @@ func (s *Server) GetPort() int {
 	return s.port
 }
 
+// SetBindHost sets the address to listen on, "" for every interface. It
+// applies from the next Start.
+func (s *Server) SetBindHost(host string) {
+	s.mu.Lock()
+	s.bindHost = host
+	s.mu.Unlock()
+}
+
+// GetBindHost returns the address the server listens on, "" for every
+// interface
+func (s *Server) GetBindHost() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bindHost
+}
+
 // GetClients returns list of connected clients
 func (s *Server) GetClients() []ClientInfo {
 	s.mu.RLock()
@@ -571,19 +783,47 @@ func (s *Server) GetClients() []ClientInfo {
 			TerminalID:  info.TerminalID,
 			UserAgent:   info.UserAgent,
 			RemoteAddr:  info.RemoteAddr,
+			Viewer:      info.Viewer,
 		})
 	}
 	return clients
 }
 
-// BroadcastOutput sends terminal output to all clients watching that terminal
+// BroadcastOutput sends terminal output to the clients subscribed to that
+// terminal. An empty termID is iTerm2 output, sent to the clients not
+// subscribed to any app terminal and allowed to see iTerm2. Output is
+// batched: it goes out within outputFlushInterval, in one frame per terminal.
 func (s *Server) BroadcastOutput(termID string, data string) {
 	logging.Debug("BroadcastOutput called", "termID", termID, "dataLen", len(data))
 
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		logging.Error("Invalid output for broadcast", "error", err)
+		return
+	}
+
+	s.mu.RLock()
+	connected := len(s.clients) > 0
+	s.mu.RUnlock()
+	if !connected {
+		// Kept for the clients that come back
+		if termID != "" {
+			s.batchMu.Lock()
+			s.appendReplay(termID, raw)
+			s.batchMu.Unlock()
+		}
+		return
+	}
+	s.queueOutput(termID, raw)
+}
+
+// sendOutput sends output to the clients following the terminal
+func (s *Server) sendOutput(termID string, data []byte, seq int64) {
 	msg := ServerMessage{
 		Type:   MsgTypeOutput,
 		TermID: termID,
-		Data:   data, // Already base64 encoded from app.go
+		Data:   base64.StdEncoding.EncodeToString(data),
+		Seq:    seq,
 	}
 
 	msgBytes, err := json.Marshal(msg)
@@ -600,8 +840,7 @@ func (s *Server) BroadcastOutput(termID string, data string) {
 
 	logging.Debug("Checking clients for broadcast", "totalClients", len(s.clients))
 	for conn, info := range s.clients {
-		// Broadcast to all if termID is empty, or if client is watching this specific terminal
-		shouldSend := termID == "" || info.TerminalID == termID || info.TerminalID == ""
+		shouldSend := info.subscriptions[termID] || (termID == "" && len(info.subscriptions) == 0 && info.scope.allowsITerm())
 		logging.Debug("Client check", "clientTermID", info.TerminalID, "broadcastTermID", termID, "shouldSend", shouldSend)
 		if shouldSend {
 			clients = append(clients, &struct {
@@ -619,7 +858,9 @@ func (s *Server) BroadcastOutput(termID string, data string) {
 		c.info.writeMu.Unlock()
 		if err != nil {
 			logging.Debug("Failed to write to client", "error", err)
+			continue
 		}
+		c.info.recording.output(termID, data)
 	}
 }
 
@@ -642,17 +883,6 @@ func (s *Server) BroadcastProjectsList() {
 		projects = []ProjectInfo{}
 	}
 
-	msg := ServerMessage{
-		Type:     MsgTypeProjects,
-		Projects: projects,
-	}
-
-	msgBytes, err := json.Marshal(msg)
-	if err != nil {
-		logging.Error("Failed to marshal projects list broadcast", "error", err)
-		return
-	}
-
 	s.mu.RLock()
 	clients := make([]*struct {
 		conn *websocket.Conn
@@ -667,10 +897,21 @@ func (s *Server) BroadcastProjectsList() {
 	}
 	s.mu.RUnlock()
 
-	// Write to clients outside the main lock, using per-connection mutex
+	// Write to clients outside the main lock, using per-connection mutex.
+	// Each client gets the projects its scope allows.
 	for _, c := range clients {
+		msg := ServerMessage{
+			Type:     MsgTypeProjects,
+			Projects: scopedProjects(projects, c.info.scope),
+		}
+		msgBytes, err := json.Marshal(msg)
+		if err != nil {
+			logging.Error("Failed to marshal projects list broadcast", "error", err)
+			return
+		}
+
 		c.info.writeMu.Lock()
-		err := c.conn.WriteMessage(websocket.TextMessage, msgBytes)
+		err = c.conn.WriteMessage(websocket.TextMessage, msgBytes)
 		c.info.writeMu.Unlock()
 		if err != nil {
 			logging.Debug("Failed to broadcast projects list to client", "error", err)
@@ -715,7 +956,24 @@ func (s *Server) handleTerminalsList(w http.ResponseWriter, r *http.Request) {
 
 	s.resetAuthAttempts(clientIP)
 
-	terminals := s.getTerminalsList()
+	if !s.checkSecondFactor(w, r, token) {
+		return
+	}
+
+	// iTerm2 tabs, then the app terminals of the projects the client may see
+	scope := s.approvedScope(token)
+	terminals := []TerminalInfo{}
+	if scope.allowsITerm() {
+		terminals = s.getTerminalsList()
+	}
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+	if handler != nil {
+		for _, p := range scopedProjects(handler.GetProjects(), scope) {
+			terminals = append(terminals, p.Terminals...)
+		}
+	}
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(terminals); err != nil {
 		logging.Error("Failed to encode terminals list", "error", err)
@@ -751,8 +1009,13 @@ func (s *Server) handleTokenInfo(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"valid":    true,
-		"approved": isApproved,
+		"valid":      true,
+		"approved":   isApproved,
+		"viewer":     s.IsViewerToken(token),
+		"transcribe": s.canTranscribe(token),
+		"docker":     s.approvedScope(token).Allows(CapabilityDocker),
+		// A code has to be entered before connecting from this browser
+		"secondFactor": s.needsSecondFactor(token, r),
 	})
 }
 
@@ -809,6 +1072,11 @@ func (s *Server) handleTerminalWS(w http.ResponseWriter, r *http.Request) {
 
 	s.resetAuthAttempts(clientIP)
 
+	if !s.checkSecondFactor(w, r, token) {
+		logging.Warn("Remote access rejected: second factor needed", "ip", clientIP)
+		return
+	}
+
 	// Check connection limit
 	s.mu.RLock()
 	clientCount := len(s.clients)
@@ -838,18 +1106,25 @@ func (s *Server) handleTerminalWS(w http.ResponseWriter, r *http.Request) {
 
 	// Register client
 	clientInfo := &ClientInfo{
-		ID:          clientID,
-		ConnectedAt: time.Now(),
-		TerminalID:  r.URL.Query().Get("termId"),
-		UserAgent:   r.UserAgent(),
-		RemoteAddr:  r.RemoteAddr,
+		ID:            clientID,
+		ConnectedAt:   time.Now(),
+		TerminalID:    r.URL.Query().Get("termId"),
+		UserAgent:     r.UserAgent(),
+		RemoteAddr:    r.RemoteAddr,
+		Viewer:        s.IsViewerToken(token),
+		subscriptions: make(map[string]bool),
+		scope:         s.approvedScope(token),
 	}
 
+	// Recording starts before the client is registered and output reaches it
+	s.startRecording(clientInfo)
+
 	s.mu.Lock()
 	s.clients[conn] = clientInfo
 	s.mu.Unlock()
 
-	logging.Info("Remote client connected", "clientId", clientID, "remoteAddr", r.RemoteAddr)
+	logging.Info("Remote client connected", "clientId", clientID, "remoteAddr", r.RemoteAddr, "viewer", clientInfo.Viewer)
+	s.audit(clientInfo, AuditConnect, "", clientInfo.UserAgent)
 
 	// Send initial terminals list (iTerm2 tabs)
 	s.sendTerminalsList(conn, clientInfo)
@@ -861,6 +1136,8 @@ func (s *Server) handleTerminalWS(w http.ResponseWriter, r *http.Request) {
 		s.mu.Unlock()
 		conn.Close()
 		logging.Info("Remote client disconnected", "clientId", clientID)
+		s.audit(clientInfo, AuditDisconnect, "", "")
+		clientInfo.recording.close()
 	}()
 
 	for {
@@ -884,6 +1161,18 @@ func (s *Server) handleTerminalWS(w http.ResponseWriter, r *http.Request) {
 
 // handleClientMessage processes a message from the client
 func (s *Server) handleClientMessage(conn *websocket.Conn, client *ClientInfo, msg *ClientMessage) {
+	// Viewers watch without changing anything. Their resizes are dropped
+	// quietly, since the web client sends them on its own.
+	if client.Viewer {
+		switch msg.Type {
+		case MsgTypeResize:
+			return
+		case MsgTypeInput, MsgTypeCreateTerminal, MsgTypeRenameTerminal, MsgTypeDeleteTerminal, MsgTypeSwitchTab, MsgTypeContainerAction:
+			s.sendError(conn, client, "Read-only access: this device can only watch")
+			return
+		}
+	}
+
 	switch msg.Type {
 	case MsgTypeInput:
 		logging.Debug("Received input message", "termID", msg.TermID, "dataLen", len(msg.Data))
@@ -893,29 +1182,37 @@ func (s *Server) handleClientMessage(conn *websocket.Conn, client *ClientInfo, m
 		if msg.TermID != "" {
 			client.TerminalID = msg.TermID
 		}
+		subscribed := client.subscriptions[msg.TermID]
+		handler := s.projectHandler
 		s.mu.Unlock()
 
+		// Input for a subscribed app terminal goes straight to its PTY
+		if subscribed && handler != nil {
+			if err := handler.WriteTerminal(msg.TermID, []byte(msg.Data)); err != nil {
+				s.sendError(conn, client, fmt.Sprintf("Failed to write to terminal: %v", err))
+				return
+			}
+			s.audit(client, AuditInput, msg.TermID, describeInput(msg.Data))
+			client.recording.recordInput(msg.TermID, msg.Data)
+			return
+		}
+
+		if !client.scope.allowsITerm() {
+			s.sendError(conn, client, "Access to iTerm2 is not allowed for this device")
+			return
+		}
+
 		// Write to iTerm2 active session
 		if s.itermController != nil {
 			// The input is sent directly to iTerm2's active session
-			// For special keys, we handle them appropriately
-			input := msg.Data
-
-			// Check if this is a special key (Enter)
-			pressEnter := false
-			if input == "\r" || input == "\n" {
-				input = ""
-				pressEnter = true
-			} else if strings.HasSuffix(input, "\r") || strings.HasSuffix(input, "\n") {
-				input = strings.TrimSuffix(strings.TrimSuffix(input, "\r"), "\n")
-				pressEnter = true
-			}
-
+			input, pressEnter := splitITermInput(msg.Data)
 			if err := s.itermController.WriteText(input, pressEnter); err != nil {
 				logging.Error("Failed to write to iTerm2", "error", err)
 				s.sendError(conn, client, fmt.Sprintf("Failed to write to iTerm2: %v", err))
 			} else {
 				logging.Debug("Wrote to iTerm2 successfully")
+				s.audit(client, AuditInput, "iTerm2", describeInput(msg.Data))
+				client.recording.recordInput("", msg.Data)
 			}
 		} else {
 			s.sendError(conn, client, "iTerm2 controller not available")
@@ -927,14 +1224,40 @@ func (s *Server) handleClientMessage(conn *websocket.Conn, client *ClientInfo, m
 		if msg.TermID != "" {
 			client.TerminalID = msg.TermID
 		}
+		subscribed := client.subscriptions[msg.TermID]
+		handler := s.projectHandler
 		s.mu.Unlock()
 
+		// App terminals take the client's size
+		if subscribed && handler != nil {
+			rows := max(minResizeRows, min(msg.Rows, maxResizeRows))
+			cols := max(minResizeCols, min(msg.Cols, maxResizeCols))
+			if err := handler.ResizeTerminal(msg.TermID, rows, cols); err != nil {
+				s.sendError(conn, client, fmt.Sprintf("Failed to resize terminal: %v", err))
+				return
+			}
+			client.recording.resize(msg.TermID, rows, cols)
+			return
+		}
+
 		// iTerm2 manages its own terminal sizing - we don't resize it
 		// Just acknowledge the resize request (needed for xterm.js on client)
 		logging.Debug("Resize request received (iTerm2 manages sizing)", "rows", msg.Rows, "cols", msg.Cols)
 
 	case MsgTypeList:
 		s.sendTerminalsList(conn, client)
+		s.sendProjectsList(conn, client)
+
+	case MsgTypeStatus:
+		s.sendStatus(conn, client)
+
+	case MsgTypeSubscribe:
+		s.handleSubscribe(conn, client, msg)
+
+	case MsgTypeUnsubscribe:
+		s.mu.Lock()
+		delete(client.subscriptions, msg.TermID)
+		s.mu.Unlock()
 
 	case MsgTypeCreateTerminal:
 		s.handleCreateTerminal(conn, client, msg)
@@ -948,16 +1271,95 @@ func (s *Server) handleClientMessage(conn *websocket.Conn, client *ClientInfo, m
 	case MsgTypeSwitchTab:
 		s.handleSwitchTab(conn, client, msg)
 
+	case MsgTypeContainers:
+		s.handleListContainers(conn, client, msg)
+
+	case MsgTypeContainerAction:
+		s.handleContainerAction(conn, client, msg)
+
 	case MsgTypePing:
 		s.sendPong(conn, client)
 	}
 }
 
+// splitITermInput separates a trailing Enter from input for iTerm2, which
+// types text and presses Enter separately
+func splitITermInput(input string) (string, bool) {
+	if input == "\r" || input == "\n" {
+		return "", true
+	}
+	if strings.HasSuffix(input, "\r") || strings.HasSuffix(input, "\n") {
+		return strings.TrimSuffix(strings.TrimSuffix(input, "\r"), "\n"), true
+	}
+	return input, false
+}
+
+// handleSubscribe starts streaming an app terminal's output to a client
+func (s *Server) handleSubscribe(conn *websocket.Conn, client *ClientInfo, msg *ClientMessage) {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	if handler == nil {
+		s.sendError(conn, client, "Project handler not configured")
+		return
+	}
+
+	if msg.TermID == "" {
+		s.sendError(conn, client, "Terminal ID required")
+		return
+	}
+
+	if !handler.HasTerminal(msg.TermID) {
+		s.sendError(conn, client, fmt.Sprintf("Terminal not found: %s", msg.TermID))
+		return
+	}
+
+	if !client.scope.allowsProjectTerminals(s.terminalProject(msg.TermID)) {
+		s.sendError(conn, client, "Access to this terminal is not allowed for this device")
+		return
+	}
+
+	// No output is flushed meanwhile, so the client gets everything after
+	// the replay. A batch still pending may repeat its end; clients drop
+	// what they already have by offset.
+	s.flushMu.Lock()
+	defer s.flushMu.Unlock()
+
+	replay, end, missed := s.replaySince(msg.TermID, msg.Seq)
+
+	s.mu.Lock()
+	client.subscriptions[msg.TermID] = true
+	client.TerminalID = msg.TermID
+	s.mu.Unlock()
+
+	logging.Info("Remote client subscribed to terminal", "clientId", client.ID, "termID", msg.TermID, "replayed", len(replay))
+
+	response := ServerMessage{
+		Type:    MsgTypeSubscribed,
+		TermID:  msg.TermID,
+		Success: true,
+		Seq:     end,
+	}
+	msgBytes, _ := json.Marshal(response)
+	client.writeMu.Lock()
+	conn.WriteMessage(websocket.TextMessage, msgBytes)
+	client.writeMu.Unlock()
+
+	if len(replay) > 0 || missed {
+		s.sendReplay(conn, client, msg.TermID, replay, end, missed)
+	}
+}
+
 // sendTerminalsList sends the list of terminals to a client
 func (s *Server) sendTerminalsList(conn *websocket.Conn, client *ClientInfo) {
+	terminals := []TerminalInfo{}
+	if client.scope.allowsITerm() {
+		terminals = s.getTerminalsList()
+	}
 	msg := ServerMessage{
 		Type:      MsgTypeTerminals,
-		Terminals: s.getTerminalsList(),
+		Terminals: terminals,
 	}
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
@@ -987,7 +1389,7 @@ func (s *Server) sendProjectsList(conn *websocket.Conn, client *ClientInfo) {
 
 	msg := ServerMessage{
 		Type:     MsgTypeProjects,
-		Projects: projects,
+		Projects: scopedProjects(projects, client.scope),
 	}
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
@@ -1017,6 +1419,11 @@ func (s *Server) handleCreateTerminal(conn *websocket.Conn, client *ClientInfo,
 		return
 	}
 
+	if !client.scope.allowsProjectTerminals(msg.ProjectID) {
+		s.sendError(conn, client, "Access to this project is not allowed for this device")
+		return
+	}
+
 	name := msg.Name
 	if name == "" {
 		name = "Terminal"
@@ -1027,6 +1434,7 @@ func (s *Server) handleCreateTerminal(conn *websocket.Conn, client *ClientInfo,
 		s.sendError(conn, client, fmt.Sprintf("Failed to create terminal: %v", err))
 		return
 	}
+	s.audit(client, AuditCreateTerminal, term.ID, fmt.Sprintf("Created %q in project %s", term.Name, msg.ProjectID))
 
 	// Send success response with new terminal
 	response := ServerMessage{
@@ -1059,6 +1467,11 @@ func (s *Server) handleRenameTerminal(conn *websocket.Conn, client *ClientInfo,
 		return
 	}
 
+	if !client.scope.allowsProjectTerminals(msg.ProjectID) || (client.scope != nil && s.terminalProject(msg.TermID) != msg.ProjectID) {
+		s.sendError(conn, client, "Access to this terminal is not allowed for this device")
+		return
+	}
+
 	if msg.Name == "" {
 		s.sendError(conn, client, "New name required")
 		return
@@ -1068,6 +1481,7 @@ func (s *Server) handleRenameTerminal(conn *websocket.Conn, client *ClientInfo,
 		s.sendError(conn, client, fmt.Sprintf("Failed to rename terminal: %v", err))
 		return
 	}
+	s.audit(client, AuditRenameTerminal, msg.TermID, fmt.Sprintf("Renamed to %q", msg.Name))
 
 	// Send success response
 	response := ServerMessage{
@@ -1100,10 +1514,16 @@ func (s *Server) handleDeleteTerminal(conn *websocket.Conn, client *ClientInfo,
 		return
 	}
 
+	if !client.scope.allowsProjectTerminals(msg.ProjectID) || (client.scope != nil && s.terminalProject(msg.TermID) != msg.ProjectID) {
+		s.sendError(conn, client, "Access to this terminal is not allowed for this device")
+		return
+	}
+
 	if err := handler.DeleteTerminal(msg.ProjectID, msg.TermID); err != nil {
 		s.sendError(conn, client, fmt.Sprintf("Failed to delete terminal: %v", err))
 		return
 	}
+	s.audit(client, AuditDeleteTerminal, msg.TermID, "Deleted from project "+msg.ProjectID)
 
 	// Send success response
 	response := ServerMessage{
@@ -1122,6 +1542,11 @@ func (s *Server) handleDeleteTerminal(conn *websocket.Conn, client *ClientInfo,
 
 // handleSwitchTab switches to the specified iTerm2 tab
 func (s *Server) handleSwitchTab(conn *websocket.Conn, client *ClientInfo, msg *ClientMessage) {
+	if !client.scope.allowsITerm() {
+		s.sendError(conn, client, "Access to iTerm2 is not allowed for this device")
+		return
+	}
+
 	if s.itermController == nil {
 		s.sendError(conn, client, "iTerm2 controller not available")
 		return
@@ -1147,6 +1572,7 @@ func (s *Server) handleSwitchTab(conn *websocket.Conn, client *ClientInfo, msg *
 	}
 
 	logging.Info("Switched iTerm2 tab via remote", "windowID", windowID, "tabIndex", tabIndex)
+	s.audit(client, AuditSwitchTab, msg.TermID, fmt.Sprintf("Window %d, tab %d", windowID, tabIndex))
 
 	// Update client's current terminal
 	s.mu.Lock()
@@ -1189,7 +1615,7 @@ func (s *Server) sendPong(conn *websocket.Conn, client *ClientInfo) {
 	client.writeMu.Unlock()
 }
 
-// serveClient serves the web client HTML
+// serveClient serves the web client page
 func (s *Server) serveClient(w http.ResponseWriter, r *http.Request) {
 	clientIP := getClientIP(r)
 
@@ -1199,15 +1625,18 @@ func (s *Server) serveClient(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate token (from query param for initial page load)
+	// Validate token (from query param for initial page load). Without one
+	// the page still loads: it can use a token saved on the device or ask
+	// for access.
 	token := r.URL.Query().Get("token")
-	if !s.validateToken(token) {
+	if token != "" && !s.validateToken(token) {
 		s.recordFailedAuth(clientIP)
 		http.Error(w, "Unauthorized - Invalid or expired token", http.StatusUnauthorized)
 		return
 	}
-
-	s.resetAuthAttempts(clientIP)
+	if token != "" {
+		s.resetAuthAttempts(clientIP)
+	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -1217,5 +1646,5 @@ func (s *Server) serveClient(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Header().Set("Pragma", "no-cache")
 	w.Header().Set("Expires", "0")
-	w.Write([]byte(clientHTML))
+	serveClientFile(w, r, "index.html")
 }
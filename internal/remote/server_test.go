@@ -0,0 +1,167 @@
+package remote
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestGetClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "direct",
+			remoteAddr: "203.0.113.9:51000",
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "direct with spoofed header",
+			remoteAddr: "203.0.113.9:51000",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "203.0.113.9",
+		},
+		{
+			name:       "direct ipv6",
+			remoteAddr: "[2001:db8::1]:51000",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "2001:db8::1",
+		},
+		{
+			name:       "tunnel",
+			remoteAddr: "127.0.0.1:51000",
+			forwarded:  []string{"198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "tunnel takes the last hop",
+			remoteAddr: "127.0.0.1:51000",
+			forwarded:  []string{"10.0.0.1, 198.51.100.7"},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "tunnel takes the last header",
+			remoteAddr: "[::1]:51000",
+			forwarded:  []string{"10.0.0.1", "2001:db8::7"},
+			want:       "2001:db8::7",
+		},
+		{
+			name:       "tunnel with garbage",
+			remoteAddr: "127.0.0.1:51000",
+			forwarded:  []string{"198.51.100.7, not-an-ip"},
+			want:       "127.0.0.1",
+		},
+		{
+			name:       "local without header",
+			remoteAddr: "127.0.0.1:51000",
+			want:       "127.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/token-info", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if got := getClientIP(r); got != tt.want {
+				t.Errorf("getClientIP(%s, %q) = %s, want %s", tt.remoteAddr, tt.forwarded, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockoutIgnoresSpoofedForwardedFor(t *testing.T) {
+	s := NewServer(nil)
+	s.SetAuthPolicy(3, time.Minute)
+
+	// A client rotating X-Forwarded-For is still locked out by its own address
+	codes := make([]int, 0, 4)
+	for _, spoofed := range []string{"198.51.100.1", "198.51.100.2", "198.51.100.3", "198.51.100.4"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/token-info", nil)
+		r.RemoteAddr = "203.0.113.9:51000"
+		r.Header.Set("X-Forwarded-For", spoofed)
+		r.Header.Set("Authorization", "Bearer wrong-token")
+		w := httptest.NewRecorder()
+		s.handleTokenInfo(w, r)
+		codes = append(codes, w.Code)
+	}
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("responses = %v, want %v", codes, want)
+		}
+	}
+
+	// and the addresses it claimed to be are not locked out
+	if !s.checkRateLimit("198.51.100.1") {
+		t.Error("spoofed address was locked out")
+	}
+	if s.checkRateLimit("203.0.113.9") {
+		t.Error("sending address was not locked out")
+	}
+}
+
+func TestScopeChangeDisconnectsClients(t *testing.T) {
+	s := NewServer(nil)
+	limited := &ApprovedClient{Token: "limited", Projects: []string{"p1"}}
+	renamed := &ApprovedClient{Token: "renamed", Name: "phone"}
+	s.SetApprovedClients([]*ApprovedClient{limited, renamed})
+
+	registered := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := s.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.clients[conn] = &ClientInfo{scope: s.approvedClients[r.URL.Query().Get("token")]}
+		s.mu.Unlock()
+		registered <- struct{}{}
+	}))
+	defer srv.Close()
+
+	dial := func(token string) *websocket.Conn {
+		t.Helper()
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?token="+token, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-registered
+		return conn
+	}
+	limitedConn := dial("limited")
+	defer limitedConn.Close()
+	renamedConn := dial("renamed")
+	defer renamedConn.Close()
+
+	// Widening the scope of one client and renaming the other
+	s.SetApprovedClients([]*ApprovedClient{
+		{Token: "limited", Projects: []string{"p1", "p2"}},
+		{Token: "renamed", Name: "tablet"},
+	})
+
+	limitedConn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := limitedConn.ReadMessage(); err == nil || isTimeout(err) {
+		t.Errorf("client whose scope changed is still connected: %v", err)
+	}
+	renamedConn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, err := renamedConn.ReadMessage(); !isTimeout(err) {
+		t.Errorf("client whose scope didn't change was disconnected: %v", err)
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
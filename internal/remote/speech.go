@@ -0,0 +1,158 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// Speech-to-text for remote voice input: browsers' SpeechRecognition is
+// unreliable on Android, so the client can record a short clip instead and
+// have the desktop transcribe it.
+const (
+	maxSpeechBody     = 10 << 20 // A minute of compressed audio is well below this
+	speechTimeout     = 60 * time.Second
+	defaultSpeechLang = "en-US"
+)
+
+// Transcriber turns an audio file into text. lang is a BCP 47 tag such as
+// "pl-PL".
+type Transcriber func(ctx context.Context, audioPath, lang string) (string, error)
+
+// speechLangPattern matches the language tags passed on to transcribers
+var speechLangPattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// speechExtensions maps the audio types browsers record to file extensions;
+// transcribers tell the format by the extension
+var speechExtensions = map[string]string{
+	"audio/webm":  ".webm",
+	"audio/ogg":   ".ogg",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/aac":   ".aac",
+	"audio/mpeg":  ".mp3",
+	"audio/wav":   ".wav",
+	"audio/x-wav": ".wav",
+	"audio/wave":  ".wav",
+}
+
+// SetTranscriber sets how audio is transcribed; nil turns the endpoint off
+func (s *Server) SetTranscriber(t Transcriber) {
+	s.mu.Lock()
+	s.transcriber = t
+	s.mu.Unlock()
+}
+
+// canTranscribe reports whether a token may use speech-to-text. The text is
+// typed into terminals, so view-only devices and devices without terminal
+// access can't.
+func (s *Server) canTranscribe(token string) bool {
+	s.mu.RLock()
+	available := s.transcriber != nil
+	s.mu.RUnlock()
+	return available && !s.IsViewerToken(token) && s.approvedScope(token).Allows(CapabilityTerminals)
+}
+
+// handleTranscribe transcribes a recorded clip (POST /api/transcribe?lang=...
+// with the audio as the body) and returns {"text": "..."}. The client types
+// the text into its terminal like any other input. One clip is transcribed
+// at a time.
+func (s *Server) handleTranscribe(w http.ResponseWriter, r *http.Request) {
+	token := s.authorizeAPI(w, r)
+	if token == "" {
+		return
+	}
+	if s.IsViewerToken(token) {
+		http.Error(w, "View-only device", http.StatusForbidden)
+		return
+	}
+	if !s.approvedScope(token).Allows(CapabilityTerminals) {
+		http.Error(w, "Access to terminals is not allowed for this device", http.StatusForbidden)
+		return
+	}
+
+	s.mu.RLock()
+	transcribe := s.transcriber
+	s.mu.RUnlock()
+	if transcribe == nil {
+		http.Error(w, "Speech-to-text is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		lang = defaultSpeechLang
+	}
+	if !speechLangPattern.MatchString(lang) {
+		http.Error(w, "Invalid language", http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	ext, ok := speechExtensions[mediaType]
+	if !ok {
+		http.Error(w, "Unsupported audio type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if !s.transcribeMu.TryLock() {
+		http.Error(w, "Another clip is being transcribed", http.StatusTooManyRequests)
+		return
+	}
+	defer s.transcribeMu.Unlock()
+
+	audioPath, err := saveSpeechClip(http.MaxBytesReader(w, r.Body, maxSpeechBody), ext)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Audio clip too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to read audio: %v", err), http.StatusBadRequest)
+		return
+	}
+	defer os.Remove(audioPath)
+
+	ctx, cancel := context.WithTimeout(r.Context(), speechTimeout)
+	defer cancel()
+	start := time.Now()
+	text, err := transcribe(ctx, audioPath, lang)
+	if err != nil {
+		logging.Warn("Remote transcription failed", "error", err)
+		http.Error(w, fmt.Sprintf("Transcription failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	logging.Debug("Remote clip transcribed", "lang", lang, "took", time.Since(start), "chars", len(text))
+
+	writeJSON(w, http.StatusOK, map[string]string{"text": strings.TrimSpace(text)})
+}
+
+// saveSpeechClip writes an uploaded clip to a temporary file, which the
+// caller removes
+func saveSpeechClip(body io.Reader, ext string) (string, error) {
+	f, err := os.CreateTemp("", "remote-speech-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(f, body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n == 0 {
+		err = fmt.Errorf("empty audio clip")
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
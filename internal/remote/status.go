@@ -0,0 +1,228 @@
+package remote
+
+import (
+	"encoding/json"
+
+	"projecthub/internal/logging"
+	"projecthub/internal/testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// maxStatusFailedTests caps the failed test names sent with a test status;
+// the phone shows a few, the REST API has the full summary
+const maxStatusFailedTests = 10
+
+// GitStatusInfo is a project's branch and how many files are changed
+type GitStatusInfo struct {
+	Branch    string `json:"branch"`
+	Staged    int    `json:"staged"`
+	Unstaged  int    `json:"unstaged"`
+	Untracked int    `json:"untracked"`
+}
+
+// TestStatusInfo is the outcome of a terminal's last test run, without the
+// per-test details of testing.TestSummary
+type TestStatusInfo struct {
+	Runner      testing.TestRunner `json:"runner"`
+	Status      testing.TestStatus `json:"status"`
+	Passed      int                `json:"passed"`
+	Failed      int                `json:"failed"`
+	Skipped     int                `json:"skipped"`
+	Total       int                `json:"total"`
+	Duration    float64            `json:"duration"` // in milliseconds
+	FailedTests []string           `json:"failedTests,omitempty"`
+}
+
+// TerminalStatus is what is known about an app terminal besides its output
+type TerminalStatus struct {
+	ID     string          `json:"id"`
+	Claude string          `json:"claude,omitempty"` // claude.Status; empty when Claude isn't running
+	Tests  *TestStatusInfo `json:"tests,omitempty"`
+}
+
+// ProjectStatus is a project's git status and the status of its terminals
+type ProjectStatus struct {
+	ProjectID string           `json:"projectId"`
+	Git       *GitStatusInfo   `json:"git,omitempty"`
+	Terminals []TerminalStatus `json:"terminals"`
+}
+
+// newTestStatusInfo condenses a test summary for clients
+func newTestStatusInfo(summary *testing.TestSummary) *TestStatusInfo {
+	if summary == nil {
+		return nil
+	}
+	info := &TestStatusInfo{
+		Runner:   summary.Runner,
+		Status:   summary.Status,
+		Passed:   summary.Passed,
+		Failed:   summary.Failed,
+		Skipped:  summary.Skipped,
+		Total:    summary.Total,
+		Duration: summary.Duration,
+	}
+	for _, t := range summary.FailedTests {
+		if len(info.FailedTests) == maxStatusFailedTests {
+			break
+		}
+		info.FailedTests = append(info.FailedTests, t.Name)
+	}
+	return info
+}
+
+// claudeStatusValue drops the detector's "none", which clients read as an
+// empty status
+func claudeStatusValue(status string) string {
+	if status == "none" {
+		return ""
+	}
+	return status
+}
+
+// sendStatus sends a client the status of the projects it may see. Git
+// status needs the git capability; terminal status needs the terminals one.
+func (s *Server) sendStatus(conn *websocket.Conn, client *ClientInfo) {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+
+	statuses := []ProjectStatus{}
+	if handler != nil {
+		for _, p := range scopedProjects(handler.GetProjects(), client.scope) {
+			status := ProjectStatus{ProjectID: p.ID, Terminals: []TerminalStatus{}}
+			if client.scope.Allows(CapabilityGit) {
+				status.Git = handler.GetGitStatus(p.ID)
+			}
+			for _, t := range p.Terminals {
+				status.Terminals = append(status.Terminals, TerminalStatus{
+					ID:     t.ID,
+					Claude: claudeStatusValue(handler.GetClaudeStatus(t.ID)),
+					Tests:  newTestStatusInfo(handler.GetTestSummary(t.ID)),
+				})
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	msgBytes, err := json.Marshal(ServerMessage{Type: MsgTypeStatus, Statuses: statuses})
+	if err != nil {
+		logging.Error("Failed to marshal status", "error", err)
+		return
+	}
+	client.writeMu.Lock()
+	if err := conn.WriteMessage(websocket.TextMessage, msgBytes); err != nil {
+		logging.Debug("Failed to send status", "error", err)
+	}
+	client.writeMu.Unlock()
+}
+
+// BroadcastGitStatus sends a project's current git status to the clients
+// allowed to see it. The status is only looked up when such a client is
+// connected.
+func (s *Server) BroadcastGitStatus(projectID string) {
+	s.mu.RLock()
+	handler := s.projectHandler
+	s.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+
+	s.broadcastStatus(func(scope *ApprovedClient) bool {
+		return scope.Allows(CapabilityGit) && scope.AllowsProject(projectID)
+	}, func() *ServerMessage {
+		git := handler.GetGitStatus(projectID)
+		if git == nil {
+			return nil
+		}
+		return &ServerMessage{Type: MsgTypeGitStatus, ProjectID: projectID, Git: git}
+	})
+}
+
+// BroadcastClaudeStatus sends a terminal's Claude status to the clients
+// allowed to use the terminal
+func (s *Server) BroadcastClaudeStatus(projectID, termID, status string) {
+	s.broadcastStatus(func(scope *ApprovedClient) bool {
+		return scope.allowsProjectTerminals(projectID)
+	}, func() *ServerMessage {
+		return &ServerMessage{
+			Type:      MsgTypeClaudeStatus,
+			ProjectID: projectID,
+			TermID:    termID,
+			Claude:    claudeStatusValue(status),
+		}
+	})
+}
+
+// BroadcastTestStatus sends a terminal's test run to the clients allowed to
+// use the terminal. Counts change with every test while a run goes on, so a
+// run is sent when it starts and when it ends, not in between. A nil summary
+// forgets the terminal.
+func (s *Server) BroadcastTestStatus(projectID, termID string, summary *testing.TestSummary) {
+	s.mu.Lock()
+	if summary == nil {
+		delete(s.testStatuses, termID)
+		s.mu.Unlock()
+		return
+	}
+	last, sent := s.testStatuses[termID]
+	s.testStatuses[termID] = summary.Status
+	s.mu.Unlock()
+	if sent && last == summary.Status && !summary.Status.IsFinal() {
+		return
+	}
+
+	s.broadcastStatus(func(scope *ApprovedClient) bool {
+		return scope.allowsProjectTerminals(projectID)
+	}, func() *ServerMessage {
+		return &ServerMessage{
+			Type:      MsgTypeTestStatus,
+			ProjectID: projectID,
+			TermID:    termID,
+			Tests:     newTestStatusInfo(summary),
+		}
+	})
+}
+
+// broadcastStatus sends a status message to the clients whose scope allows
+// it. The message is built only when there is a client to send it to, and
+// isn't sent when build returns nil.
+func (s *Server) broadcastStatus(allowed func(scope *ApprovedClient) bool, build func() *ServerMessage) {
+	s.mu.RLock()
+	clients := make([]*struct {
+		conn *websocket.Conn
+		info *ClientInfo
+	}, 0)
+	for conn, info := range s.clients {
+		if allowed(info.scope) {
+			clients = append(clients, &struct {
+				conn *websocket.Conn
+				info *ClientInfo
+			}{conn, info})
+		}
+	}
+	s.mu.RUnlock()
+	if len(clients) == 0 {
+		return
+	}
+
+	msg := build()
+	if msg == nil {
+		return
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		logging.Error("Failed to marshal status broadcast", "type", msg.Type, "error", err)
+		return
+	}
+
+	// Write to clients outside the main lock, using per-connection mutex
+	for _, c := range clients {
+		c.info.writeMu.Lock()
+		err := c.conn.WriteMessage(websocket.TextMessage, msgBytes)
+		c.info.writeMu.Unlock()
+		if err != nil {
+			logging.Debug("Failed to broadcast status to client", "type", msg.Type, "error", err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// Self-signed certificate settings
+const (
+	selfSignedCertFile = "remote-cert.pem"
+	selfSignedKeyFile  = "remote-key.pem"
+	selfSignedValidity = 825 * 24 * time.Hour // The longest validity iOS accepts
+	selfSignedRenewal  = 30 * 24 * time.Hour  // Renew when expiring sooner than this
+)
+
+// LoadTLSCertificate returns the certificate the config asks for, or nil for
+// plain HTTP. The self-signed certificate is kept in dataDir so its
+// fingerprint stays the same across restarts.
+func LoadTLSCertificate(config Config, dataDir string) (*tls.Certificate, error) {
+	switch config.TLSMode {
+	case TLSModeOff:
+		return nil, nil
+	case TLSModeCustom:
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+		}
+		if cert.Leaf == nil {
+			if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+				return nil, fmt.Errorf("failed to parse TLS certificate: %w", err)
+			}
+		}
+		return &cert, nil
+	case TLSModeSelfSigned:
+		return selfSignedCertificate(dataDir)
+	default:
+		return nil, fmt.Errorf("unknown TLS mode: %s", config.TLSMode)
+	}
+}
+
+// CertificateFingerprint returns the SHA-256 fingerprint of a certificate,
+// formatted like browsers show it: "AB:CD:..."
+func CertificateFingerprint(cert *tls.Certificate) string {
+	if cert == nil || len(cert.Certificate) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(cert.Certificate[0])
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// LANAddresses returns the addresses of the machine's active network
+// interfaces, IPv4 first; loopback and link-local addresses are excluded
+func LANAddresses() []string {
+	var addrs, ipv6 []string
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return addrs
+	}
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		ifaceAddrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range ifaceAddrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || !ipNet.IP.IsGlobalUnicast() {
+				continue
+			}
+			if ipNet.IP.To4() != nil {
+				addrs = append(addrs, ipNet.IP.String())
+			} else {
+				ipv6 = append(ipv6, ipNet.IP.String())
+			}
+		}
+	}
+	return append(addrs, ipv6...)
+}
+
+// selfSignedCertificate loads the saved self-signed certificate, creating a
+// new one when there is none or it is about to expire. It isn't renewed when
+// the LAN addresses change: browsers warn about self-signed certificates
+// anyway, and a stable fingerprint is what devices check.
+func selfSignedCertificate(dataDir string) (*tls.Certificate, error) {
+	certPath := filepath.Join(dataDir, selfSignedCertFile)
+	keyPath := filepath.Join(dataDir, selfSignedKeyFile)
+
+	if cert, err := tls.LoadX509KeyPair(certPath, keyPath); err == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err == nil && time.Until(leaf.NotAfter) > selfSignedRenewal {
+			cert.Leaf = leaf
+			return &cert, nil
+		}
+	}
+
+	certPEM, keyPEM, err := generateSelfSigned()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save TLS key: %w", err)
+	}
+	if err := os.WriteFile(certPath, certPEM, 0644); err != nil {
+		return nil, fmt.Errorf("failed to save TLS certificate: %w", err)
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+	logging.Info("Self-signed TLS certificate created", "fingerprint", CertificateFingerprint(&cert))
+	return &cert, nil
+}
+
+// generateSelfSigned creates a certificate and ECDSA key for localhost, the
+// host name and the LAN addresses, PEM encoded
+func generateSelfSigned() ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate TLS key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate certificate serial: %w", err)
+	}
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		dnsNames = append(dnsNames, hostname)
+	}
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	for _, addr := range LANAddresses() {
+		ips = append(ips, net.ParseIP(addr))
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "ProjectHub Remote Access"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create TLS certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode TLS key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,284 @@
+package remote
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"projecthub/internal/logging"
+)
+
+// TOTP second factor (RFC 6238) for approved clients: with a secret enrolled,
+// a browser has to enter a code from an authenticator app before the token
+// is accepted from it. A confirmed browser gets a random device key in an
+// HttpOnly cookie, and the client keeps its hash; a stolen token alone, from
+// any address, is still asked for a code.
+const (
+	TOTPIssuer       = "Claudilandia"
+	totpDigits       = 6
+	totpModulus      = 1_000_000 // 10^totpDigits
+	totpPeriod       = 30        // seconds
+	totpSkew         = 1         // steps accepted either side, for clock drift
+	totpSecretSize   = 20        // bytes, the HMAC-SHA1 key size
+	totpDeviceCookie = "claudilandia_device"
+	totpDeviceSize   = 32                   // bytes of a device key
+	totpDeviceAge    = 365 * 24 * time.Hour // cookie lifetime
+	maxTOTPDevices   = 20                   // browsers remembered per client, oldest dropped
+)
+
+// totpEncoding is the base32 alphabet authenticator apps expect, unpadded
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPEnrollment is a new secret to add to an authenticator app
+type TOTPEnrollment struct {
+	Secret string `json:"secret"` // base32
+	URI    string `json:"uri"`    // otpauth:// URI for a QR code
+}
+
+// NewTOTPEnrollment generates a secret for an approved client
+func NewTOTPEnrollment(account string) (*TOTPEnrollment, error) {
+	key := make([]byte, totpSecretSize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	secret := totpEncoding.EncodeToString(key)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", TOTPIssuer)
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + TOTPIssuer + ":" + account,
+		RawQuery: params.Encode(),
+	}
+	return &TOTPEnrollment{Secret: secret, URI: uri.String()}, nil
+}
+
+// ValidateTOTP checks a code against a secret at time t and returns the time
+// step it matched, so a code can't be used twice
+func ValidateTOTP(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil || len(key) == 0 {
+		return 0, false
+	}
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	step := t.Unix() / totpPeriod
+	for i := -totpSkew; i <= totpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step+int64(i))), []byte(code)) == 1 {
+			return step + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// totpCode computes the HOTP code (RFC 4226) of a time step
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulus)
+}
+
+// deviceKeyHash returns the hash a client keeps of a request's device key,
+// or "" when the request has none
+func deviceKeyHash(r *http.Request) string {
+	cookie, err := r.Cookie(totpDeviceCookie)
+	if err != nil || !validDeviceCookie(cookie.Value) {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(cookie.Value))
+	return hex.EncodeToString(sum[:])
+}
+
+// validDeviceCookie reports whether a cookie value is a device key
+func validDeviceCookie(value string) bool {
+	if len(value) != 2*totpDeviceSize {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
+// needsSecondFactor reports whether a token has to be confirmed with a code
+// before it is accepted from the browser that sent the request
+func (s *Server) needsSecondFactor(token string, r *http.Request) bool {
+	device := deviceKeyHash(r)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	client, exists := s.approvedClients[token]
+	return exists && client.TOTPSecret != "" && (device == "" || !slices.Contains(client.TOTPDevices, device))
+}
+
+// checkSecondFactor rejects a valid token sent by a browser it wasn't
+// confirmed in with a code. On failure the response is written.
+func (s *Server) checkSecondFactor(w http.ResponseWriter, r *http.Request, token string) bool {
+	if !s.needsSecondFactor(token, r) {
+		return true
+	}
+	http.Error(w, "Verification code required", http.StatusForbidden)
+	return false
+}
+
+// checkTOTPLimit reports whether a token may try another code. Wrong codes
+// are counted per token: any request with the token resets the address's
+// counter, so that one alone wouldn't stop guessing.
+func (s *Server) checkTOTPLimit(token string) bool {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	attempt, exists := s.totpAttempts[token]
+	if !exists {
+		return true
+	}
+	if time.Since(attempt.lastTime) > s.authLockout {
+		delete(s.totpAttempts, token)
+		return true
+	}
+	return attempt.count < s.maxAuthAttempts
+}
+
+// recordFailedTOTP records a wrong code for a token
+func (s *Server) recordFailedTOTP(token string) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	attempt, exists := s.totpAttempts[token]
+	if !exists {
+		attempt = &authAttempt{}
+		s.totpAttempts[token] = attempt
+	}
+	attempt.count++
+	attempt.lastTime = time.Now()
+}
+
+// handleTOTPVerify confirms a token in the caller's browser with a code
+// (POST /api/totp with {"code": "123456"}) and sets its device key cookie.
+// Wrong codes count against both the address and the token, so guessing
+// runs into the lockout.
+func (s *Server) handleTOTPVerify(w http.ResponseWriter, r *http.Request) {
+	clientIP := getClientIP(r)
+
+	if !s.checkRateLimit(clientIP) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	token := r.Header.Get("Authorization")
+	if strings.HasPrefix(token, "Bearer ") {
+		token = strings.TrimPrefix(token, "Bearer ")
+	} else {
+		token = r.URL.Query().Get("token")
+	}
+
+	if !s.validateToken(token) {
+		s.recordFailedAuth(clientIP)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if !s.checkTOTPLimit(token) {
+		http.Error(w, "Too many attempts", http.StatusTooManyRequests)
+		return
+	}
+
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(&body); err != nil || body.Code == "" {
+		http.Error(w, "Expected {\"code\": \"...\"}", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	client, exists := s.approvedClients[token]
+	if !exists || client.TOTPSecret == "" {
+		s.mu.Unlock()
+		http.Error(w, "No second factor enrolled for this device", http.StatusBadRequest)
+		return
+	}
+	name := client.Name
+	step, ok := ValidateTOTP(client.TOTPSecret, body.Code, time.Now())
+	if !ok || step <= s.totpSteps[token] {
+		s.mu.Unlock()
+		s.recordFailedAuth(clientIP)
+		s.recordFailedTOTP(token)
+		logging.Warn("Remote second factor rejected", "client", name, "ip", clientIP)
+		http.Error(w, "Invalid code", http.StatusUnauthorized)
+		return
+	}
+	s.totpSteps[token] = step
+	s.mu.Unlock()
+
+	// The browser's device key is reused, so one browser confirmed for
+	// several tokens keeps a single cookie
+	key := ""
+	if cookie, err := r.Cookie(totpDeviceCookie); err == nil && validDeviceCookie(cookie.Value) {
+		key = cookie.Value
+	} else {
+		keyBytes := make([]byte, totpDeviceSize)
+		if _, err := rand.Read(keyBytes); err != nil {
+			http.Error(w, "Failed to confirm device", http.StatusInternalServerError)
+			return
+		}
+		key = hex.EncodeToString(keyBytes)
+	}
+	sum := sha256.Sum256([]byte(key))
+	device := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	if client, exists := s.approvedClients[token]; exists && !slices.Contains(client.TOTPDevices, device) {
+		// A new slice: the change callback reads the old one unlocked
+		devices := append(slices.Clone(client.TOTPDevices), device)
+		if len(devices) > maxTOTPDevices {
+			devices = devices[len(devices)-maxTOTPDevices:]
+		}
+		client.TOTPDevices = devices
+	}
+	cb := s.onApprovedChange
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     totpDeviceCookie,
+		Value:    key,
+		Path:     "/",
+		MaxAge:   int(totpDeviceAge / time.Second),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	s.resetAuthAttempts(clientIP)
+	s.authMu.Lock()
+	delete(s.totpAttempts, token)
+	s.authMu.Unlock()
+	logging.Info("Remote second factor confirmed", "client", name, "ip", clientIP)
+	s.auditRequest(r, token, AuditSecondFactor, "", "confirmed a browser from "+clientIP)
+	if cb != nil {
+		cb()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const totpTestToken = "approved-token"
+
+// totpTestRequest is a request through the ngrok agent from addr
+func totpTestRequest(method, target, body, addr string, cookies ...*http.Cookie) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.RemoteAddr = "127.0.0.1:51000"
+	r.Header.Set("X-Forwarded-For", addr)
+	r.Header.Set("Authorization", "Bearer "+totpTestToken)
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+	return r
+}
+
+// needsCode asks /api/token-info whether a request has to enter a code
+func needsCode(t *testing.T, s *Server, r *http.Request) bool {
+	t.Helper()
+	w := httptest.NewRecorder()
+	s.handleTokenInfo(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("token-info status = %d", w.Code)
+	}
+	var info struct {
+		SecondFactor bool `json:"secondFactor"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&info); err != nil {
+		t.Fatal(err)
+	}
+	return info.SecondFactor
+}
+
+func TestSecondFactorConfirmsBrowserNotAddress(t *testing.T) {
+	enrollment, err := NewTOTPEnrollment("phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(nil)
+	s.SetApprovedClients([]*ApprovedClient{{Token: totpTestToken, Name: "phone", TOTPSecret: enrollment.Secret}})
+
+	const ownerIP = "198.51.100.7"
+	if !needsCode(t, s, totpTestRequest(http.MethodGet, "/api/token-info", "", ownerIP)) {
+		t.Fatal("enrolled client was not asked for a code")
+	}
+
+	// The owner confirms their browser
+	key, _ := totpEncoding.DecodeString(enrollment.Secret)
+	code := totpCode(key, time.Now().Unix()/totpPeriod)
+	w := httptest.NewRecorder()
+	s.handleTOTPVerify(w, totpTestRequest(http.MethodPost, "/api/totp", `{"code": "`+code+`"}`, ownerIP))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("verify status = %d: %s", w.Code, w.Body.String())
+	}
+	var device *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == totpDeviceCookie {
+			device = c
+		}
+	}
+	if device == nil || !device.HttpOnly {
+		t.Fatalf("verify set no HttpOnly device cookie: %v", w.Result().Cookies())
+	}
+
+	if needsCode(t, s, totpTestRequest(http.MethodGet, "/api/token-info", "", ownerIP, device)) {
+		t.Error("confirmed browser was asked for a code again")
+	}
+
+	// A stolen token is still asked for a code, whatever address it claims
+	spoofed := httptest.NewRequest(http.MethodGet, "/api/token-info", nil)
+	spoofed.RemoteAddr = "203.0.113.9:51000"
+	spoofed.Header.Set("X-Forwarded-For", ownerIP)
+	spoofed.Header.Set("Authorization", "Bearer "+totpTestToken)
+	if !needsCode(t, s, spoofed) {
+		t.Error("spoofed X-Forwarded-For skipped the code")
+	}
+	if !needsCode(t, s, totpTestRequest(http.MethodGet, "/api/token-info", "", ownerIP)) {
+		t.Error("the owner's address without the device cookie skipped the code")
+	}
+	forged := &http.Cookie{Name: totpDeviceCookie, Value: strings.Repeat("0", 2*totpDeviceSize)}
+	if !needsCode(t, s, totpTestRequest(http.MethodGet, "/api/token-info", "", ownerIP, forged)) {
+		t.Error("an unknown device key skipped the code")
+	}
+
+	// and can't get in through the API either
+	w = httptest.NewRecorder()
+	s.handleTerminalsList(w, totpTestRequest(http.MethodGet, "/api/terminals", "", ownerIP))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("terminals without the device cookie status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestWrongCodesLockOutDespiteTokenRequests(t *testing.T) {
+	enrollment, err := NewTOTPEnrollment("phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(nil)
+	s.SetAuthPolicy(3, time.Minute)
+	s.SetApprovedClients([]*ApprovedClient{{Token: totpTestToken, Name: "phone", TOTPSecret: enrollment.Secret}})
+
+	wrong := "000000"
+	for n := 0; ; n++ {
+		if _, ok := ValidateTOTP(enrollment.Secret, wrong, time.Now()); !ok {
+			break
+		}
+		wrong = fmt.Sprintf("%06d", n)
+	}
+
+	// Requests with the token alone reset the address's counter between guesses
+	const ownerIP = "198.51.100.7"
+	codes := []int{}
+	for i := 0; i < 4; i++ {
+		needsCode(t, s, totpTestRequest(http.MethodGet, "/api/token-info", "", ownerIP))
+		w := httptest.NewRecorder()
+		s.handleTOTPVerify(w, totpTestRequest(http.MethodPost, "/api/totp", `{"code": "`+wrong+`"}`, ownerIP))
+		codes = append(codes, w.Code)
+	}
+	want := []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("responses = %v, want %v", codes, want)
+		}
+	}
+
+	// and the right code is refused too until the lockout ends
+	key, _ := totpEncoding.DecodeString(enrollment.Secret)
+	w := httptest.NewRecorder()
+	s.handleTOTPVerify(w, totpTestRequest(http.MethodPost, "/api/totp", `{"code": "`+totpCode(key, time.Now().Unix()/totpPeriod)+`"}`, ownerIP))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("right code during the lockout status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
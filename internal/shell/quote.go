@@ -0,0 +1,9 @@
+// Package shell quotes values for shell command lines
+package shell
+
+import "strings"
+
+// Quote wraps a value in single quotes for use in a shell command line
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
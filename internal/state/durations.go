@@ -0,0 +1,114 @@
+package state
+
+import (
+	"sort"
+	"time"
+)
+
+// maxRunTestDurations is how many of a run's slowest tests keep their duration
+const maxRunTestDurations = 200
+
+// defaultSlowestTests is the slowest-tests limit used when none is given
+const defaultSlowestTests = 10
+
+// TestDurationReport shows how the test durations of a project change over
+// its test history
+type TestDurationReport struct {
+	Runs    []RunDuration `json:"runs"`    // Suite duration per run, oldest first
+	Slowest []SlowTest    `json:"slowest"` // Slowest tests by latest duration
+}
+
+// RunDuration is the suite duration of one test run
+type RunDuration struct {
+	Timestamp time.Time `json:"timestamp"`
+	Runner    string    `json:"runner"`
+	Duration  int64     `json:"duration"` // in milliseconds
+	Total     int       `json:"total"`
+}
+
+// SlowTest is a test with its durations across runs
+type SlowTest struct {
+	Name     string    `json:"name"`
+	Runner   string    `json:"runner"`
+	Duration float64   `json:"duration"` // Latest duration in milliseconds
+	Average  float64   `json:"average"`  // Average over the runs that timed it
+	Change   float64   `json:"change"`   // Percent change of the latest duration against earlier runs
+	Trend    []float64 `json:"trend"`    // Durations, oldest first
+}
+
+// GetTestDurationReport returns the suite duration trend of a project and its
+// limit slowest tests
+func (m *Manager) GetTestDurationReport(projectID string, limit int) TestDurationReport {
+	return testDurationReport(m.GetTestHistory(projectID), limit)
+}
+
+func testDurationReport(history []TestRun, limit int) TestDurationReport {
+	if limit <= 0 {
+		limit = defaultSlowestTests
+	}
+	report := TestDurationReport{Runs: []RunDuration{}, Slowest: []SlowTest{}}
+
+	type testKey struct{ runner, name string }
+	tests := make(map[testKey]*SlowTest)
+	for _, run := range finishedRuns(history) {
+		report.Runs = append(report.Runs, RunDuration{
+			Timestamp: run.Timestamp,
+			Runner:    run.Runner,
+			Duration:  run.Duration,
+			Total:     run.Total,
+		})
+		for name, duration := range run.TestDurations {
+			key := testKey{run.Runner, name}
+			test, ok := tests[key]
+			if !ok {
+				test = &SlowTest{Name: name, Runner: run.Runner}
+				tests[key] = test
+			}
+			test.Trend = append(test.Trend, duration)
+		}
+	}
+
+	for _, test := range tests {
+		var sum float64
+		for _, duration := range test.Trend {
+			sum += duration
+		}
+		last := len(test.Trend) - 1
+		test.Duration = test.Trend[last]
+		test.Average = sum / float64(len(test.Trend))
+		if last > 0 {
+			if earlier := (sum - test.Duration) / float64(last); earlier > 0 {
+				test.Change = (test.Duration - earlier) / earlier * 100
+			}
+		}
+		report.Slowest = append(report.Slowest, *test)
+	}
+	sort.Slice(report.Slowest, func(i, j int) bool {
+		if report.Slowest[i].Duration != report.Slowest[j].Duration {
+			return report.Slowest[i].Duration > report.Slowest[j].Duration
+		}
+		return report.Slowest[i].Name < report.Slowest[j].Name
+	})
+	if len(report.Slowest) > limit {
+		report.Slowest = report.Slowest[:limit]
+	}
+	return report
+}
+
+// trimTestDurations keeps the max slowest tests of a run
+func trimTestDurations(durations map[string]float64, max int) map[string]float64 {
+	if len(durations) <= max {
+		return durations
+	}
+	names := make([]string, 0, len(durations))
+	for name := range durations {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return durations[names[i]] > durations[names[j]] })
+
+	trimmed := make(map[string]float64, max)
+	for _, name := range names[:max] {
+		trimmed[name] = durations[name]
+	}
+	return trimmed
+}
@@ -0,0 +1,130 @@
+package state
+
+import (
+	"sort"
+	"time"
+)
+
+// FlakyTest is a test that both passed and failed over the same code
+type FlakyTest struct {
+	Name       string    `json:"name"`
+	Runner     string    `json:"runner"`
+	Passes     int       `json:"passes"`   // Runs over unchanged code the test passed in
+	Failures   int       `json:"failures"` // Runs over unchanged code the test failed in
+	Flips      int       `json:"flips"`    // Result changes between consecutive runs of the same code
+	LastFailed time.Time `json:"lastFailed"`
+}
+
+// GetFlakyTests returns the tests of a project whose result changed between
+// runs that saw the same code, most flips first
+func (m *Manager) GetFlakyTests(projectID string) []FlakyTest {
+	return flakyTests(m.GetTestHistory(projectID))
+}
+
+// flakyTests finds flaky tests in a test history. Runs are grouped by runner
+// and code version; within a group a test passed in every finished run that
+// doesn't list it as failed.
+func flakyTests(history []TestRun) []FlakyTest {
+	type groupKey struct{ runner, version string }
+	groups := make(map[groupKey][]TestRun)
+	var order []groupKey
+	for _, run := range finishedRuns(history) {
+		if run.CodeVersion == "" {
+			continue
+		}
+		key := groupKey{run.Runner, run.CodeVersion}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], run)
+	}
+
+	type testKey struct{ runner, name string }
+	found := make(map[testKey]*FlakyTest)
+	for _, key := range order {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+		names := make(map[string]bool)
+		for _, run := range group {
+			for _, name := range run.FailedTests {
+				names[name] = true
+			}
+		}
+
+		for name := range names {
+			var passes, failures, flips int
+			var lastFailed time.Time
+			for i, run := range group {
+				failed := containsName(run.FailedTests, name)
+				if failed {
+					failures++
+					lastFailed = run.Timestamp
+				} else {
+					passes++
+				}
+				if i > 0 && failed != containsName(group[i-1].FailedTests, name) {
+					flips++
+				}
+			}
+			if flips == 0 {
+				continue
+			}
+
+			tk := testKey{key.runner, name}
+			flaky, ok := found[tk]
+			if !ok {
+				flaky = &FlakyTest{Name: name, Runner: key.runner}
+				found[tk] = flaky
+			}
+			flaky.Passes += passes
+			flaky.Failures += failures
+			flaky.Flips += flips
+			if lastFailed.After(flaky.LastFailed) {
+				flaky.LastFailed = lastFailed
+			}
+		}
+	}
+
+	result := make([]FlakyTest, 0, len(found))
+	for _, flaky := range found {
+		result = append(result, *flaky)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Flips != result[j].Flips {
+			return result[i].Flips > result[j].Flips
+		}
+		if !result[i].LastFailed.Equal(result[j].LastFailed) {
+			return result[i].LastFailed.After(result[j].LastFailed)
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// finishedRuns returns the runs in a test history that ran to a result,
+// oldest first
+func finishedRuns(history []TestRun) []TestRun {
+	runs := make([]TestRun, 0, len(history))
+	for _, run := range history {
+		if run.Total == 0 {
+			continue
+		}
+		if run.Status != "passed" && run.Status != "failed" && run.Status != "mixed" {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Timestamp.Before(runs[j].Timestamp) })
+	return runs
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
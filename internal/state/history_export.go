@@ -0,0 +1,197 @@
+package state
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Test history retention, in runs; coverage keeps as many points
+const (
+	DefaultTestHistoryLimit = 20
+	MaxTestHistoryLimit     = 1000
+)
+
+// Export formats
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// CoverageRecord is a point in a project's coverage history
+type CoverageRecord struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Lines         float64   `json:"lines"`
+	Functions     float64   `json:"functions"`
+	Branches      float64   `json:"branches"`
+	MutationScore float64   `json:"mutationScore,omitempty"`
+}
+
+// TestHistoryExport is the JSON export of a project's history
+type TestHistoryExport struct {
+	ProjectID   string           `json:"projectId"`
+	ProjectName string           `json:"projectName"`
+	ExportedAt  time.Time        `json:"exportedAt"`
+	TestRuns    []TestRun        `json:"testRuns"` // Oldest first
+	Coverage    []CoverageRecord `json:"coverage"` // Oldest first
+}
+
+// testHistoryLimit is how many runs and coverage points a project keeps.
+// Callers hold m.mu.
+func testHistoryLimit(project *ProjectState) int {
+	if project.TestHistoryLimit <= 0 {
+		return DefaultTestHistoryLimit
+	}
+	return project.TestHistoryLimit
+}
+
+// GetProjectTestHistoryLimit returns how many test runs a project keeps
+func (m *Manager) GetProjectTestHistoryLimit(projectID string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		return DefaultTestHistoryLimit
+	}
+	return testHistoryLimit(project)
+}
+
+// SetProjectTestHistoryLimit sets how many test runs and coverage points a
+// project keeps, dropping the oldest beyond it
+func (m *Manager) SetProjectTestHistoryLimit(projectID string, limit int) error {
+	if limit < 1 || limit > MaxTestHistoryLimit {
+		return fmt.Errorf("history limit must be between 1 and %d", MaxTestHistoryLimit)
+	}
+
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.TestHistoryLimit = limit
+	if len(project.TestHistory) > limit {
+		project.TestHistory = project.TestHistory[:limit]
+	}
+	if len(project.CoverageHistory) > limit {
+		project.CoverageHistory = project.CoverageHistory[len(project.CoverageHistory)-limit:]
+	}
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// AddCoverageRecord appends a point to a project's coverage history, unless
+// it repeats the latest one
+func (m *Manager) AddCoverageRecord(projectID string, record CoverageRecord) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	if n := len(project.CoverageHistory); n > 0 {
+		last := project.CoverageHistory[n-1]
+		last.Timestamp = record.Timestamp
+		if last == record {
+			m.mu.Unlock()
+			return nil // Same report read again, e.g. after a restart
+		}
+	}
+	project.CoverageHistory = append(project.CoverageHistory, record)
+	if limit := testHistoryLimit(project); len(project.CoverageHistory) > limit {
+		project.CoverageHistory = project.CoverageHistory[len(project.CoverageHistory)-limit:]
+	}
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// ExportTestHistory renders a project's test runs and coverage history as
+// JSON, or as CSV with one row per run or coverage point
+func (m *Manager) ExportTestHistory(projectID, format string) ([]byte, error) {
+	m.mu.RLock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.RUnlock()
+		return nil, os.ErrNotExist
+	}
+	export := TestHistoryExport{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		ExportedAt:  time.Now(),
+		TestRuns:    make([]TestRun, len(project.TestHistory)),
+		Coverage:    append([]CoverageRecord{}, project.CoverageHistory...),
+	}
+	// Stored newest first
+	for i, run := range project.TestHistory {
+		export.TestRuns[len(project.TestHistory)-1-i] = run
+	}
+	m.mu.RUnlock()
+
+	switch format {
+	case ExportJSON:
+		return json.MarshalIndent(export, "", "  ")
+	case ExportCSV:
+		return exportHistoryCSV(export)
+	}
+	return nil, fmt.Errorf("unknown export format: %s", format)
+}
+
+// exportHistoryCSV writes runs and coverage points as rows of one table,
+// told apart by the kind column and ordered by time
+func exportHistoryCSV(export TestHistoryExport) ([]byte, error) {
+	type row struct {
+		at     time.Time
+		fields []string
+	}
+	var rows []row
+	for _, run := range export.TestRuns {
+		rows = append(rows, row{run.Timestamp, []string{
+			"test-run", run.Timestamp.Format(time.RFC3339), run.Runner, run.Status,
+			strconv.Itoa(run.Passed), strconv.Itoa(run.Failed), strconv.Itoa(run.Skipped), strconv.Itoa(run.Total),
+			strconv.FormatInt(run.Duration, 10), strings.Join(run.FailedTests, "; "), run.CodeVersion,
+			"", "", "", "",
+		}})
+	}
+	for _, point := range export.Coverage {
+		rows = append(rows, row{point.Timestamp, []string{
+			"coverage", point.Timestamp.Format(time.RFC3339), "", "",
+			"", "", "", "", "", "", "",
+			formatPct(point.Lines), formatPct(point.Functions), formatPct(point.Branches), formatPct(point.MutationScore),
+		}})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].at.Before(rows[j].at) })
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{
+		"kind", "timestamp", "runner", "status", "passed", "failed", "skipped", "total",
+		"durationMs", "failedTests", "codeVersion", "lines", "functions", "branches", "mutationScore",
+	})
+	for _, r := range rows {
+		w.Write(r.fields)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func formatPct(pct float64) string {
+	if pct == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(pct, 'f', 2, 64)
+}
@@ -1,14 +1,18 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
+	"projecthub/internal/logging"
+
 	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -30,7 +34,9 @@ type Manager struct {
 	ctx       context.Context
 	state     *AppState
 	statePath string
+	store     *sqliteStore // nil keeps state in statePath
 	mu        sync.RWMutex
+	writeMu   sync.Mutex // keeps a save's snapshot and write together so saves land in order
 
 	// Debounced save
 	saveTimer *time.Timer
@@ -54,8 +60,21 @@ func NewManager() (*Manager, error) {
 		statePath: filepath.Join(configDir, "state.json"),
 	}
 
+	dbPath := filepath.Join(configDir, "state.db")
+	store, err := openSQLiteStore(dbPath)
+	if err != nil {
+		// Once migrated, the state only lives in the database
+		if _, statErr := os.Stat(dbPath); statErr == nil {
+			return nil, fmt.Errorf("cannot open %s: %w", dbPath, err)
+		}
+		logging.Warn("State database unavailable, using state.json", "error", err)
+	} else {
+		m.store = store
+	}
+
 	// Load existing state or migrate from old format
 	if err := m.load(); err != nil {
+		m.Close()
 		return nil, err
 	}
 
@@ -68,42 +87,28 @@ func (m *Manager) SetContext(ctx context.Context) {
 }
 
 func (m *Manager) load() error {
+	// Try the state database; an empty one is filled from state.json below
+	if m.store != nil {
+		state, err := m.store.load()
+		if err != nil {
+			return err
+		}
+		if state != nil {
+			m.state = state
+			m.initState()
+			return nil
+		}
+	}
+
 	// Try to load new state format
 	data, err := os.ReadFile(m.statePath)
 	if err == nil {
 		var state AppState
 		if err := json.Unmarshal(data, &state); err == nil {
 			m.state = &state
-			// Ensure maps are initialized
-			if m.state.Projects == nil {
-				m.state.Projects = make(map[string]*ProjectState)
-			}
-			// Ensure global prompts are initialized
-			if m.state.GlobalPrompts == nil {
-				m.state.GlobalPrompts = []Prompt{}
-			}
-			if m.state.GlobalPromptCategories == nil {
-				m.state.GlobalPromptCategories = []PromptCategory{}
-			}
-			for _, p := range m.state.Projects {
-				if p.Terminals == nil {
-					p.Terminals = make(map[string]*TerminalState)
-				}
-				if p.EnvVars == nil {
-					p.EnvVars = make(map[string]string)
-				}
-				if p.Browser == nil {
-					p.Browser = &BrowserState{Scale: 100}
-				}
-				if p.Prompts == nil {
-					p.Prompts = []Prompt{}
-				}
-				if p.PromptCategories == nil {
-					p.PromptCategories = []PromptCategory{}
-				}
-				if p.Todos == nil {
-					p.Todos = []TodoItem{}
-				}
+			m.initState()
+			if m.store != nil {
+				return m.migrateToStore()
 			}
 			return nil
 		}
@@ -119,6 +124,90 @@ func (m *Manager) load() error {
 	return nil
 }
 
+// initState ensures maps and lists of loaded state are initialized
+func (m *Manager) initState() {
+	if m.state.Projects == nil {
+		m.state.Projects = make(map[string]*ProjectState)
+	}
+	// Ensure global prompts are initialized
+	if m.state.GlobalPrompts == nil {
+		m.state.GlobalPrompts = []Prompt{}
+	}
+	if m.state.GlobalPromptCategories == nil {
+		m.state.GlobalPromptCategories = []PromptCategory{}
+	}
+	for _, p := range m.state.Projects {
+		if p.Terminals == nil {
+			p.Terminals = make(map[string]*TerminalState)
+		}
+		if p.EnvVars == nil {
+			p.EnvVars = make(map[string]string)
+		}
+		if p.Browser == nil {
+			p.Browser = &BrowserState{Scale: 100}
+		}
+		if p.Prompts == nil {
+			p.Prompts = []Prompt{}
+		}
+		if p.PromptCategories == nil {
+			p.PromptCategories = []PromptCategory{}
+		}
+		if p.Todos == nil {
+			p.Todos = []TodoItem{}
+		}
+	}
+}
+
+// migrateToStore moves state loaded from state.json into the state database.
+// state.json is only renamed to state.json.migrated once the database reads
+// back the same state.
+func (m *Manager) migrateToStore() error {
+	if err := m.saveImmediate(); err != nil {
+		return fmt.Errorf("failed to migrate state to the database: %w", err)
+	}
+
+	if err := m.verifyMigration(); err != nil {
+		// Empty the database again so the next start migrates state.json anew
+		m.store.save(map[string]stateEntity{})
+		return err
+	}
+
+	if err := os.Rename(m.statePath, m.statePath+".migrated"); err != nil {
+		logging.Warn("Failed to rename migrated state.json", "error", err)
+	}
+	logging.Info("Migrated state.json to the state database", "projects", len(m.state.Projects))
+	return nil
+}
+
+// verifyMigration reads the migrated state back from the database and checks
+// it matches the state loaded from state.json
+func (m *Manager) verifyMigration() error {
+	saved, err := stateEntities(m.state)
+	if err != nil {
+		return err
+	}
+	readBack, err := m.store.load()
+	if err != nil {
+		return fmt.Errorf("failed to read back migrated state: %w", err)
+	}
+	if readBack == nil {
+		return fmt.Errorf("migrated state is missing from the database")
+	}
+	loaded, err := stateEntities(readBack)
+	if err != nil {
+		return err
+	}
+	if len(loaded) != len(saved) {
+		return fmt.Errorf("migrated state has %d entities, want %d", len(loaded), len(saved))
+	}
+	for key, entity := range saved {
+		if !bytes.Equal(loaded[key].data, entity.data) {
+			return fmt.Errorf("migrated state differs for %s", key)
+		}
+	}
+	return nil
+}
+
 func (m *Manager) migrateFromOldFormat(oldPath string) error {
 	data, err := os.ReadFile(oldPath)
 	if err != nil {
@@ -177,6 +266,20 @@ func (m *Manager) migrateFromOldFormat(oldPath string) error {
 }
 
 func (m *Manager) saveImmediate() error {
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	if m.store != nil {
+		m.mu.RLock()
+		entities, err := stateEntities(m.state)
+		m.mu.RUnlock()
+
+		if err != nil {
+			return err
+		}
+		return m.store.save(entities)
+	}
+
 	m.mu.RLock()
 	data, err := json.MarshalIndent(m.state, "", "  ")
 	m.mu.RUnlock()
@@ -214,6 +317,23 @@ func (m *Manager) SaveSync() error {
 	return m.saveImmediate()
 }
 
+// Close stops pending saves and closes the state database; call SaveSync first
+func (m *Manager) Close() error {
+	m.saveMu.Lock()
+	if m.saveTimer != nil {
+		m.saveTimer.Stop()
+		m.saveTimer = nil
+	}
+	m.saveMu.Unlock()
+
+	if m.store == nil {
+		return nil
+	}
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+	return m.store.close()
+}
+
 // GetState returns the full app state
 func (m *Manager) GetState() *AppState {
 	m.mu.RLock()
@@ -686,6 +806,31 @@ func (m *Manager) EmitClaudeStatus(terminalID, status string) {
 	}
 }
 
+// SetTerminalClaudeSession stores the detected Claude model and mode of a
+// terminal and emits the change with project context
+func (m *Manager) SetTerminalClaudeSession(terminalID, model, mode string) {
+	m.mu.Lock()
+	projectID := ""
+	for id, p := range m.state.Projects {
+		if t, ok := p.Terminals[terminalID]; ok {
+			t.ClaudeModel = model
+			t.ClaudeMode = mode
+			projectID = id
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if m.ctx != nil && projectID != "" {
+		runtime.EventsEmit(m.ctx, "state:claude:session", map[string]string{
+			"projectId":  projectID,
+			"terminalId": terminalID,
+			"model":      model,
+			"mode":       mode,
+		})
+	}
+}
+
 // UpdateBrowserTabs updates browser tabs for a project
 func (m *Manager) UpdateBrowserTabs(projectID string, tabs []BrowserTab, activeTabID string) error {
 	m.mu.Lock()
@@ -719,9 +864,8 @@ func (m *Manager) SaveTestHistory(projectID string, history []TestRun) error {
 		return os.ErrNotExist
 	}
 
-	// Keep only last 20 runs
-	if len(history) > 20 {
-		history = history[:20]
+	if limit := testHistoryLimit(project); len(history) > limit {
+		history = history[:limit]
 	}
 
 	project.TestHistory = history
@@ -757,13 +901,13 @@ func (m *Manager) AddTestRun(projectID string, run TestRun) error {
 	if project.TestHistory == nil {
 		project.TestHistory = []TestRun{}
 	}
+	run.TestDurations = trimTestDurations(run.TestDurations, maxRunTestDurations)
 
 	// Add to beginning (newest first)
 	project.TestHistory = append([]TestRun{run}, project.TestHistory...)
 
-	// Keep only last 20 runs
-	if len(project.TestHistory) > 20 {
-		project.TestHistory = project.TestHistory[:20]
+	if limit := testHistoryLimit(project); len(project.TestHistory) > limit {
+		project.TestHistory = project.TestHistory[:limit]
 	}
 
 	m.mu.Unlock()
@@ -1138,6 +1282,217 @@ func (m *Manager) SaveTodos(projectID string, todos []TodoItem) error {
 	return nil
 }
 
+// ImportTodos appends todos imported from code comments, skipping those
+// already in the list with the same text and file. It returns how many
+// were added.
+func (m *Manager) ImportTodos(projectID string, todos []TodoItem) (int, error) {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return 0, os.ErrNotExist
+	}
+
+	// Lines move as code is edited; the file and text identify a comment
+	key := func(todo TodoItem) string {
+		file := todo.Source
+		if i := strings.LastIndex(file, ":"); i >= 0 {
+			file = file[:i]
+		}
+		return file + "\x00" + todo.Text
+	}
+	existing := make(map[string]bool, len(project.Todos))
+	for _, todo := range project.Todos {
+		if todo.Source != "" {
+			existing[key(todo)] = true
+		}
+	}
+	added := 0
+	for _, todo := range todos {
+		if existing[key(todo)] {
+			continue
+		}
+		existing[key(todo)] = true
+		if todo.ID == "" {
+			todo.ID = uuid.New().String()
+		}
+		project.Todos = append(project.Todos, todo)
+		added++
+	}
+	m.mu.Unlock()
+
+	if added > 0 {
+		m.Save()
+	}
+
+	return added, nil
+}
+
+// ============================================
+// Docker context
+// ============================================
+
+// GetProjectDockerContext returns the Docker context selected for a project
+func (m *Manager) GetProjectDockerContext(projectID string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		return ""
+	}
+	return project.DockerContext
+}
+
+// SetProjectDockerContext selects the Docker context for a project
+func (m *Manager) SetProjectDockerContext(projectID, contextName string) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.DockerContext = contextName
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// GetProjectK8sTarget returns the Kubernetes context and namespace of a project
+func (m *Manager) GetProjectK8sTarget(projectID string) (kubeContext, namespace string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		return "", ""
+	}
+	return project.K8sContext, project.K8sNamespace
+}
+
+// SetProjectK8sTarget sets the Kubernetes context and namespace of a project
+func (m *Manager) SetProjectK8sTarget(projectID, kubeContext, namespace string) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.K8sContext = kubeContext
+	project.K8sNamespace = namespace
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// GetProjectTestNotifications returns when a project notifies about finished test runs
+func (m *Manager) GetProjectTestNotifications(projectID string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok || project.TestNotifications == "" {
+		return TestNotifyAll
+	}
+	return project.TestNotifications
+}
+
+// SetProjectTestNotifications sets when a project notifies about finished test runs
+func (m *Manager) SetProjectTestNotifications(projectID, mode string) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.TestNotifications = mode
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// GetProjectIDByPath returns the ID of the project at path, or "" when none is
+func (m *Manager) GetProjectIDByPath(path string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for id, project := range m.state.Projects {
+		if project.Path == path {
+			return id
+		}
+	}
+	return ""
+}
+
+// GetProjectCoverageThresholds returns a copy of a project's coverage
+// thresholds, or nil when it has none
+func (m *Manager) GetProjectCoverageThresholds(projectID string) *CoverageThresholds {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok || project.CoverageThresholds == nil {
+		return nil
+	}
+	thresholds := *project.CoverageThresholds
+	return &thresholds
+}
+
+// SetProjectCoverageThresholds sets a project's coverage thresholds; nil removes them
+func (m *Manager) SetProjectCoverageThresholds(projectID string, thresholds *CoverageThresholds) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.CoverageThresholds = thresholds
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
+// GetProjectIgnorePatterns returns a copy of a project's custom ignore patterns
+func (m *Manager) GetProjectIgnorePatterns(projectID string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		return []string{}
+	}
+	return append([]string{}, project.IgnorePatterns...)
+}
+
+// SetProjectIgnorePatterns sets a project's custom ignore patterns
+func (m *Manager) SetProjectIgnorePatterns(projectID string, patterns []string) error {
+	m.mu.Lock()
+	project, ok := m.state.Projects[projectID]
+	if !ok {
+		m.mu.Unlock()
+		return os.ErrNotExist
+	}
+
+	project.IgnorePatterns = patterns
+	m.mu.Unlock()
+
+	m.Save()
+
+	return nil
+}
+
 // ============================================
 // Approved Remote Clients
 // ============================================
@@ -1179,6 +1534,42 @@ func (m *Manager) SetApprovedClients(clients interface{}) {
 	m.Save()
 }
 
+// GetRemotePushSubscriptions returns the remote clients' push subscriptions
+func (m *Manager) GetRemotePushSubscriptions() []RemotePushSubscription {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]RemotePushSubscription, len(m.state.RemotePushSubscriptions))
+	copy(result, m.state.RemotePushSubscriptions)
+	return result
+}
+
+// SetRemotePushSubscriptions saves the remote clients' push subscriptions
+func (m *Manager) SetRemotePushSubscriptions(subs []RemotePushSubscription) {
+	m.mu.Lock()
+	m.state.RemotePushSubscriptions = subs
+	m.mu.Unlock()
+	m.Save()
+}
+
+// GetBannedRemoteIPs returns the addresses banned from remote access
+func (m *Manager) GetBannedRemoteIPs() []BannedRemoteIP {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]BannedRemoteIP, len(m.state.BannedRemoteIPs))
+	copy(result, m.state.BannedRemoteIPs)
+	return result
+}
+
+// SetBannedRemoteIPs saves the addresses banned from remote access
+func (m *Manager) SetBannedRemoteIPs(bans []BannedRemoteIP) {
+	m.mu.Lock()
+	m.state.BannedRemoteIPs = bans
+	m.mu.Unlock()
+	m.Save()
+}
+
 // GetTerminalTheme returns the current terminal theme name
 func (m *Manager) GetTerminalTheme() string {
 	m.mu.RLock()
@@ -1340,3 +1731,55 @@ func (m *Manager) SavePomodoroSettings(sessionMinutes, breakMinutes int) {
 	m.mu.Unlock()
 	m.Save()
 }
+
+// GetDockerHost returns the custom Docker daemon address, or "" to autodetect
+func (m *Manager) GetDockerHost() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state.DockerHost
+}
+
+// SetDockerHost saves the custom Docker daemon address
+func (m *Manager) SetDockerHost(host string) {
+	m.mu.Lock()
+	m.state.DockerHost = host
+	m.mu.Unlock()
+	m.Save()
+}
+
+// GetDockerRegistries returns the registries the user logged in to
+func (m *Manager) GetDockerRegistries() []DockerRegistry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]DockerRegistry, len(m.state.DockerRegistries))
+	copy(result, m.state.DockerRegistries)
+	return result
+}
+
+// SaveDockerRegistry records a registry login, replacing any previous one
+func (m *Manager) SaveDockerRegistry(server, username string) {
+	m.mu.Lock()
+	registries := []DockerRegistry{}
+	for _, r := range m.state.DockerRegistries {
+		if r.Server != server {
+			registries = append(registries, r)
+		}
+	}
+	m.state.DockerRegistries = append(registries, DockerRegistry{Server: server, Username: username})
+	m.mu.Unlock()
+	m.Save()
+}
+
+// RemoveDockerRegistry forgets a registry login
+func (m *Manager) RemoveDockerRegistry(server string) {
+	m.mu.Lock()
+	registries := []DockerRegistry{}
+	for _, r := range m.state.DockerRegistries {
+		if r.Server != server {
+			registries = append(registries, r)
+		}
+	}
+	m.state.DockerRegistries = registries
+	m.mu.Unlock()
+	m.Save()
+}
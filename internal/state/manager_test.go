@@ -0,0 +1,144 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// testConfigDir points the home directory at a temporary one and returns
+// its ~/.projecthub
+func testConfigDir(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".projecthub")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// writeStateJSON writes a state.json with one project
+func writeStateJSON(t *testing.T, dir string) {
+	t.Helper()
+	state := NewAppState()
+	state.ActiveProject = "p1"
+	state.TerminalTheme = "nord"
+	state.Projects["p1"] = NewProjectState("p1", "project", "/tmp/project", "#6366f1", "📁")
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "state.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagerMigratesStateJSON(t *testing.T) {
+	dir := testConfigDir(t)
+	writeStateJSON(t, dir)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.GetProject("p1") == nil || m.GetTerminalTheme() != "nord" {
+		t.Fatal("state.json was not loaded")
+	}
+	m.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, "state.json")); !os.IsNotExist(err) {
+		t.Errorf("state.json still exists after the migration: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "state.json.migrated")); err != nil {
+		t.Errorf("state.json was not kept as state.json.migrated: %v", err)
+	}
+
+	// The next start reads the database
+	m, err = NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if m.GetProject("p1") == nil || m.GetActiveProjectID() != "p1" || m.GetTerminalTheme() != "nord" {
+		t.Error("migrated state did not load from the database")
+	}
+}
+
+func TestManagerRefusesUnreadableDatabase(t *testing.T) {
+	dir := testConfigDir(t)
+	writeStateJSON(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "state.db"), []byte("not a database, not even close"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err := NewManager(); err == nil {
+		m.Close()
+		t.Fatal("NewManager started with an unreadable state.db")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "state.json")); err != nil {
+		t.Errorf("state.json was not kept: %v", err)
+	}
+}
+
+func TestManagerConcurrentSaves(t *testing.T) {
+	testConfigDir(t)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const projects = 8
+	ids := make([]string, projects)
+	for i := range ids {
+		p, err := m.CreateProject(fmt.Sprintf("project %d", i), t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = p.ID
+	}
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			for n := 0; n < 10; n++ {
+				m.SetProjectIgnorePatterns(id, []string{fmt.Sprintf("%d-%d", i, n)})
+				m.SetVoiceLang(fmt.Sprintf("lang-%d-%d", i, n))
+				if err := m.SaveSync(); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i, id)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+	m.SetVoiceLang("final")
+	if err := m.SaveSync(); err != nil {
+		t.Fatal(err)
+	}
+	m.Close()
+
+	// The last save wins for every entity
+	m, err = NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if lang := m.GetVoiceLang(); lang != "final" {
+		t.Errorf("voice language = %q, want %q", lang, "final")
+	}
+	for i, id := range ids {
+		want := fmt.Sprintf("%d-9", i)
+		if got := m.GetProjectIgnorePatterns(id); len(got) != 1 || got[0] != want {
+			t.Errorf("project %d ignore patterns = %v, want [%s]", i, got, want)
+		}
+	}
+}
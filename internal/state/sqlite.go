@@ -0,0 +1,211 @@
+package state
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite" // pure Go, no cgo
+)
+
+// SQLite store: state.db keeps one row per entity, the app-wide settings and
+// each project, so a save only rewrites the entities that changed, all in one
+// transaction.
+const (
+	entityApp     = "app"
+	entityProject = "project"
+)
+
+// sqliteOptions wait for a lock held by another connection instead of
+// failing, and use write-ahead logging so a crash mid-write can't corrupt
+// the database
+const sqliteOptions = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS entities (
+	kind TEXT NOT NULL,
+	id TEXT NOT NULL,
+	data TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	PRIMARY KEY (kind, id)
+)`
+
+// sqliteStore saves state entities to a SQLite database
+type sqliteStore struct {
+	db *sql.DB
+
+	mu      sync.Mutex
+	written map[string][sha256.Size]byte // entity key -> hash of the data last written
+}
+
+// stateEntity is the JSON of one row
+type stateEntity struct {
+	kind string
+	id   string
+	data []byte
+}
+
+// openSQLiteStore opens (or creates) the database at path
+func openSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path+sqliteOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	// One connection: writes are serialized anyway, and the pragmas are per connection
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create state database: %w", err)
+	}
+	return &sqliteStore{
+		db:      db,
+		written: make(map[string][sha256.Size]byte),
+	}, nil
+}
+
+// close closes the database
+func (s *sqliteStore) close() error {
+	return s.db.Close()
+}
+
+// entityKey identifies an entity in the written map
+func entityKey(kind, id string) string {
+	return kind + "/" + id
+}
+
+// load reads the state from the database; it returns nil when the database
+// is empty
+func (s *sqliteStore) load() (*AppState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query("SELECT kind, id, data FROM entities")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state database: %w", err)
+	}
+	defer rows.Close()
+
+	var state *AppState
+	projects := make(map[string]*ProjectState)
+	written := make(map[string][sha256.Size]byte)
+	for rows.Next() {
+		var kind, id string
+		var data []byte
+		if err := rows.Scan(&kind, &id, &data); err != nil {
+			return nil, fmt.Errorf("failed to read state database: %w", err)
+		}
+
+		switch kind {
+		case entityApp:
+			var app AppState
+			if err := json.Unmarshal(data, &app); err != nil {
+				return nil, fmt.Errorf("failed to read app state: %w", err)
+			}
+			state = &app
+		case entityProject:
+			var project ProjectState
+			if err := json.Unmarshal(data, &project); err != nil {
+				return nil, fmt.Errorf("failed to read project %s: %w", id, err)
+			}
+			projects[id] = &project
+		default:
+			continue
+		}
+		written[entityKey(kind, id)] = sha256.Sum256(data)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read state database: %w", err)
+	}
+	s.written = written
+
+	if state == nil {
+		if len(projects) == 0 {
+			return nil, nil
+		}
+		state = NewAppState()
+	}
+	state.Projects = projects
+	return state, nil
+}
+
+// save writes the entities that changed since the last save and deletes the
+// projects that are gone, in one transaction
+func (s *sqliteStore) save(entities map[string]stateEntity) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make(map[string][sha256.Size]byte)
+	for key, entity := range entities {
+		hash := sha256.Sum256(entity.data)
+		if prev, exists := s.written[key]; !exists || prev != hash {
+			changed[key] = hash
+		}
+	}
+	var deleted []string
+	for key := range s.written {
+		if _, exists := entities[key]; !exists {
+			deleted = append(deleted, key)
+		}
+	}
+	if len(changed) == 0 && len(deleted) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for key := range changed {
+		entity := entities[key]
+		if _, err := tx.Exec(`INSERT INTO entities (kind, id, data, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (kind, id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`,
+			entity.kind, entity.id, string(entity.data), now); err != nil {
+			return fmt.Errorf("failed to save %s: %w", key, err)
+		}
+	}
+	for _, key := range deleted {
+		kind, id, _ := strings.Cut(key, "/")
+		if _, err := tx.Exec("DELETE FROM entities WHERE kind = ? AND id = ?", kind, id); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+
+	for key, hash := range changed {
+		s.written[key] = hash
+	}
+	for _, key := range deleted {
+		delete(s.written, key)
+	}
+	return nil
+}
+
+// stateEntities splits the state into the app-wide settings and its projects
+func stateEntities(state *AppState) (map[string]stateEntity, error) {
+	entities := make(map[string]stateEntity, len(state.Projects)+1)
+
+	app := *state
+	app.Projects = nil
+	data, err := json.Marshal(&app)
+	if err != nil {
+		return nil, err
+	}
+	entities[entityKey(entityApp, "")] = stateEntity{kind: entityApp, data: data}
+
+	for id, project := range state.Projects {
+		data, err := json.Marshal(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode project %s: %w", id, err)
+		}
+		entities[entityKey(entityProject, id)] = stateEntity{kind: entityProject, id: id, data: data}
+	}
+	return entities, nil
+}
@@ -0,0 +1,68 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	store, err := openSQLiteStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.close()
+
+	if state, err := store.load(); err != nil || state != nil {
+		t.Fatalf("empty database loaded %v, %v; want nil, nil", state, err)
+	}
+
+	// Quotes, tabs and newlines are data, not SQL
+	state := NewAppState()
+	state.TerminalTheme = "it's\t\"dracula\"\n'); DROP TABLE entities; --"
+	state.ActiveProject = "p1"
+	state.Projects["p1"] = NewProjectState("p1", "O'Brien's project", "/tmp/a\tb", "#6366f1", "📁")
+	state.Projects["p1"].Notes = "line one\nline 'two'\r\n"
+	state.Projects["p2"] = NewProjectState("p2", "second", "/tmp/c", "#8b5cf6", "🚀")
+
+	entities, err := stateEntities(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.save(entities); err != nil {
+		t.Fatal(err)
+	}
+
+	// A project removed from the state is removed from the database
+	delete(state.Projects, "p2")
+	entities, err = stateEntities(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.save(entities); err != nil {
+		t.Fatal(err)
+	}
+	store.close()
+
+	reopened, err := openSQLiteStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.close()
+	loaded, err := reopened.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded == nil {
+		t.Fatal("saved state did not load")
+	}
+	if loaded.TerminalTheme != state.TerminalTheme || loaded.ActiveProject != "p1" {
+		t.Errorf("app state = %q, %q; want %q, %q", loaded.TerminalTheme, loaded.ActiveProject, state.TerminalTheme, "p1")
+	}
+	if len(loaded.Projects) != 1 || loaded.Projects["p1"] == nil {
+		t.Fatalf("projects = %v, want only p1", loaded.Projects)
+	}
+	if p := loaded.Projects["p1"]; p.Name != "O'Brien's project" || p.Path != "/tmp/a\tb" || p.Notes != state.Projects["p1"].Notes {
+		t.Errorf("project = %q, %q, %q", p.Name, p.Path, p.Notes)
+	}
+}
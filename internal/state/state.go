@@ -8,6 +8,8 @@ type TodoItem struct {
 	Text      string    `json:"text"`
 	Completed bool      `json:"completed"`
 	CreatedAt time.Time `json:"createdAt"`
+	Source    string    `json:"source,omitempty"` // "file:line" of an imported code comment
+	Author    string    `json:"author,omitempty"` // Who wrote the imported comment
 }
 
 // ApprovedRemoteClient represents a permanently approved remote client
@@ -16,6 +18,27 @@ type ApprovedRemoteClient struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"createdAt"`
 	LastUsed  time.Time `json:"lastUsed"`
+	Viewer    bool      `json:"viewer,omitempty"` // Read-only access
+	// Limits on what the client may access; empty lists allow everything
+	Projects     []string `json:"projects,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+	// TOTP second factor and the browsers confirmed with a code
+	TOTPSecret  string   `json:"totpSecret,omitempty"`
+	TOTPDevices []string `json:"totpDevices,omitempty"`
+}
+
+// RemotePushSubscription is a remote client's web push subscription
+type RemotePushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	P256dh   string `json:"p256dh"`
+	Auth     string `json:"auth"`
+	Token    string `json:"token"` // Approved client that subscribed
+}
+
+// BannedRemoteIP is an address refused remote access for good
+type BannedRemoteIP struct {
+	IP       string    `json:"ip"`
+	BannedAt time.Time `json:"bannedAt"`
 }
 
 // WindowState represents the application window position and size
@@ -37,6 +60,10 @@ type AppState struct {
 	GlobalPromptCategories []PromptCategory `json:"globalPromptCategories"`
 	// Approved remote clients (permanent tokens)
 	ApprovedRemoteClients []ApprovedRemoteClient `json:"approvedRemoteClients"`
+	// Web push subscriptions of remote clients
+	RemotePushSubscriptions []RemotePushSubscription `json:"remotePushSubscriptions,omitempty"`
+	// Addresses banned from remote access
+	BannedRemoteIPs []BannedRemoteIP `json:"bannedRemoteIps,omitempty"`
 	// Terminal theme (global for all terminals)
 	TerminalTheme string `json:"terminalTheme"`
 	// Terminal font size (global for all terminals)
@@ -52,6 +79,16 @@ type AppState struct {
 	Window *WindowState `json:"window"`
 	// Pomodoro timer settings
 	Pomodoro *PomodoroSettings `json:"pomodoro"`
+	// Custom Docker daemon address (empty = autodetect)
+	DockerHost string `json:"dockerHost,omitempty"`
+	// Registries logged in to (credentials live in the keychain)
+	DockerRegistries []DockerRegistry `json:"dockerRegistries,omitempty"`
+}
+
+// DockerRegistry is a container registry the user logged in to
+type DockerRegistry struct {
+	Server   string `json:"server"`
+	Username string `json:"username"`
 }
 
 // PomodoroSettings stores the user's pomodoro timer preferences
@@ -85,8 +122,19 @@ type ProjectState struct {
 	// Project notes (markdown)
 	Notes string `json:"notes"`
 
-	// Test history
-	TestHistory []TestRun `json:"testHistory"`
+	// Test history, newest first, and coverage history, oldest first
+	TestHistory      []TestRun        `json:"testHistory"`
+	CoverageHistory  []CoverageRecord `json:"coverageHistory,omitempty"`
+	TestHistoryLimit int              `json:"testHistoryLimit,omitempty"` // Runs and points kept (0 = DefaultTestHistoryLimit)
+
+	// When to notify about test runs finishing in the background ("" = all)
+	TestNotifications string `json:"testNotifications,omitempty"`
+
+	// Coverage the project has to keep (nil = no thresholds)
+	CoverageThresholds *CoverageThresholds `json:"coverageThresholds,omitempty"`
+
+	// Files the structure scanner skips on top of .gitignore, as gitignore patterns
+	IgnorePatterns []string `json:"ignorePatterns,omitempty"`
 
 	// Custom prompts for Claude Code
 	Prompts          []Prompt         `json:"prompts"`
@@ -95,6 +143,13 @@ type ProjectState struct {
 	// Todo items for dashboard
 	Todos []TodoItem `json:"todos"`
 
+	// Docker context used while the project is active ("" = default daemon)
+	DockerContext string `json:"dockerContext,omitempty"`
+
+	// Kubernetes context and namespace ("" = kubeconfig defaults)
+	K8sContext   string `json:"k8sContext,omitempty"`
+	K8sNamespace string `json:"k8sNamespace,omitempty"`
+
 	// Metadata
 	BrowserTabs []string          `json:"browserTabs"`
 	EnvVars     map[string]string `json:"envVars"`
@@ -102,6 +157,24 @@ type ProjectState struct {
 	CreatedAt   time.Time         `json:"createdAt"`
 }
 
+// Test notification modes
+const (
+	TestNotifyAll      = "all"      // Every finished run
+	TestNotifyFailures = "failures" // Runs with failing tests
+	TestNotifyOff      = "off"
+)
+
+// CoverageThresholds are minimum coverage percentages for a project; zero
+// leaves a metric unchecked
+type CoverageThresholds struct {
+	Lines      float64 `json:"lines"`
+	Statements float64 `json:"statements"`
+	Functions  float64 `json:"functions"`
+	Branches   float64 `json:"branches"`
+	MaxDrop    float64 `json:"maxDrop"`    // Points a metric may fall from one report to the next (0 = unchecked)
+	BlockReady bool    `json:"blockReady"` // A breach marks the project not ready to commit
+}
+
 // TerminalState represents a terminal session within a project
 type TerminalState struct {
 	ID        string `json:"id"`
@@ -110,6 +183,10 @@ type TerminalState struct {
 	WorkDir   string `json:"workDir"`
 	Running   bool   `json:"running"`
 
+	// Claude session detected from output; terminals are cleared at startup
+	ClaudeModel string `json:"claudeModel,omitempty"`
+	ClaudeMode  string `json:"claudeMode,omitempty"`
+
 	// Runtime only - not persisted
 	ClaudeStatus string `json:"-"`
 }
@@ -143,16 +220,20 @@ type BrowserState struct {
 
 // TestRun represents a single test run result
 type TestRun struct {
-	ID         int64     `json:"id"`
-	TerminalID string    `json:"terminalId"`
-	Runner     string    `json:"runner"`
-	Status     string    `json:"status"`
-	Passed     int       `json:"passed"`
-	Failed     int       `json:"failed"`
-	Skipped    int       `json:"skipped"`
-	Total      int       `json:"total"`
-	Duration   int64     `json:"duration"`
-	Timestamp  time.Time `json:"timestamp"`
+	ID          int64     `json:"id"`
+	TerminalID  string    `json:"terminalId"`
+	Runner      string    `json:"runner"`
+	Status      string    `json:"status"`
+	Passed      int       `json:"passed"`
+	Failed      int       `json:"failed"`
+	Skipped     int       `json:"skipped"`
+	Total       int       `json:"total"`
+	Duration    int64     `json:"duration"`
+	Timestamp   time.Time `json:"timestamp"`
+	FailedTests []string  `json:"failedTests,omitempty"` // Names of the tests that failed
+	CodeVersion string    `json:"codeVersion,omitempty"` // Git HEAD plus uncommitted changes the run saw
+
+	TestDurations map[string]float64 `json:"testDurations,omitempty"` // Test name -> duration in ms
 }
 
 // Prompt represents a custom prompt for Claude Code
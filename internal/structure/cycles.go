@@ -0,0 +1,137 @@
+package structure
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// DependencyCycle is a group of files that import each other, directly or
+// through one another
+type DependencyCycle struct {
+	Files []string `json:"files"` // Every file in the group, relative to the project, sorted
+	Path  []string `json:"path"`  // One import loop through the group, starting and ending at Files[0]
+}
+
+// DependencyCycles finds the JS/TS files of a project caught in import cycles
+func (s *Scanner) DependencyCycles(projectPath string) ([]DependencyCycle, error) {
+	graph, err := s.ImportGraph(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := []DependencyCycle{}
+	for _, component := range graph.stronglyConnected() {
+		if len(component) < 2 {
+			continue // A file can't import itself; the graph drops those
+		}
+		sort.Strings(component)
+		loop := graph.shortestLoop(component[0], component)
+		cycles = append(cycles, DependencyCycle{
+			Files: relativeFiles(projectPath, component),
+			Path:  relativeFiles(projectPath, loop),
+		})
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Files[0] < cycles[j].Files[0] })
+	return cycles, nil
+}
+
+// stronglyConnected groups the files into strongly connected components
+// (Tarjan's algorithm): files in one component all reach each other
+func (g *ImportGraph) stronglyConnected() [][]string {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var visit func(file string)
+	visit = func(file string) {
+		index[file] = len(index)
+		lowlink[file] = index[file]
+		stack = append(stack, file)
+		onStack[file] = true
+
+		for _, target := range g.imports[file] {
+			if _, seen := index[target]; !seen {
+				visit(target)
+				lowlink[file] = min(lowlink[file], lowlink[target])
+			} else if onStack[target] {
+				lowlink[file] = min(lowlink[file], index[target])
+			}
+		}
+
+		if lowlink[file] == index[file] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == file {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	files := make([]string, 0, len(g.imports))
+	for file := range g.imports {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if _, seen := index[file]; !seen {
+			visit(file)
+		}
+	}
+	return components
+}
+
+// shortestLoop finds the shortest import path from start back to itself,
+// staying inside component
+func (g *ImportGraph) shortestLoop(start string, component []string) []string {
+	inside := make(map[string]bool, len(component))
+	for _, file := range component {
+		inside[file] = true
+	}
+
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, target := range g.imports[current] {
+			if target == start {
+				loop := []string{start}
+				for file := current; file != start; file = parent[file] {
+					loop = append(loop, file)
+				}
+				loop = append(loop, start)
+				// Collected backwards from the end of the loop
+				for i, j := 1, len(loop)-2; i < j; i, j = i+1, j-1 {
+					loop[i], loop[j] = loop[j], loop[i]
+				}
+				return loop
+			}
+			if _, seen := parent[target]; !seen && inside[target] {
+				parent[target] = current
+				queue = append(queue, target)
+			}
+		}
+	}
+	return nil
+}
+
+// relativeFiles makes paths relative to the project, with forward slashes
+func relativeFiles(projectPath string, files []string) []string {
+	relative := make([]string, len(files))
+	for i, file := range files {
+		rel, err := filepath.Rel(projectPath, file)
+		if err != nil {
+			rel = file
+		}
+		relative[i] = filepath.ToSlash(rel)
+	}
+	return relative
+}
@@ -0,0 +1,50 @@
+package structure
+
+import "path/filepath"
+
+// GitFlags mark a file changed in git, or a directory with such files below it
+type GitFlags struct {
+	Staged    bool `json:"staged,omitempty"`
+	Modified  bool `json:"modified,omitempty"`
+	Untracked bool `json:"untracked,omitempty"`
+}
+
+func (f GitFlags) merge(other GitFlags) GitFlags {
+	return GitFlags{
+		Staged:    f.Staged || other.Staged,
+		Modified:  f.Modified || other.Modified,
+		Untracked: f.Untracked || other.Untracked,
+	}
+}
+
+// WithGitStatus returns a copy of the tree with the changed files, keyed by
+// absolute path, flagged along with the directories above them. Directories
+// cut from a lazy tree are flagged too.
+func (n *FileNode) WithGitStatus(changed map[string]GitFlags) *FileNode {
+	flags := make(map[string]GitFlags, len(changed))
+	for path, fileFlags := range changed {
+		flags[path] = fileFlags
+		for dir := filepath.Dir(path); isWithin(n.Path, dir); dir = filepath.Dir(dir) {
+			flags[dir] = flags[dir].merge(fileFlags)
+			if dir == n.Path {
+				break
+			}
+		}
+	}
+	return n.withFlags(flags)
+}
+
+func (n *FileNode) withFlags(flags map[string]GitFlags) *FileNode {
+	copied := *n
+	copied.Git = nil
+	if f, ok := flags[n.Path]; ok {
+		copied.Git = &f
+	}
+	if n.Children != nil {
+		copied.Children = make([]FileNode, len(n.Children))
+		for i := range n.Children {
+			copied.Children[i] = *n.Children[i].withFlags(flags)
+		}
+	}
+	return &copied
+}
@@ -0,0 +1,192 @@
+package structure
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ignoreRule is one line of a .gitignore or a custom ignore pattern
+type ignoreRule struct {
+	pattern  *regexp.Regexp
+	negate   bool // "!pattern" brings back a file an earlier rule ignored
+	dirOnly  bool // "pattern/" matches only directories
+	anchored bool // Patterns with a slash match the path from their base, others any name
+}
+
+// ignoreMatcher applies a project's .gitignore files, in the project root and
+// below, followed by its custom patterns
+type ignoreMatcher struct {
+	root   string
+	custom []ignoreRule
+
+	mu   sync.Mutex
+	dirs map[string][]ignoreRule // directory -> rules of its .gitignore, read on first use
+}
+
+// SetIgnorePatterns sets a project's custom ignore patterns, in .gitignore
+// syntax relative to the project root. They apply after the .gitignore files.
+func (s *Scanner) SetIgnorePatterns(projectPath string, patterns []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(patterns) == 0 {
+		delete(s.ignorePatterns, projectPath)
+		return
+	}
+	s.ignorePatterns[projectPath] = append([]string{}, patterns...)
+}
+
+// ignoreMatcher returns a matcher for a project's ignore rules
+func (s *Scanner) ignoreMatcher(projectPath string) *ignoreMatcher {
+	s.mu.RLock()
+	patterns := s.ignorePatterns[projectPath]
+	s.mu.RUnlock()
+
+	m := &ignoreMatcher{
+		root: projectPath,
+		dirs: make(map[string][]ignoreRule),
+	}
+	for _, pattern := range patterns {
+		if rule, ok := parseIgnoreRule(pattern); ok {
+			m.custom = append(m.custom, rule)
+		}
+	}
+	return m
+}
+
+// ignored reports whether the rules exclude a path inside the project.
+// The last matching rule decides, as in git.
+func (m *ignoreMatcher) ignored(path string, isDir bool) bool {
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	ignored := false
+	// .gitignore files from the root down to the path's directory
+	dir := m.root
+	parts := strings.Split(rel, "/")
+	for i := range parts {
+		for _, rule := range m.gitignore(dir) {
+			if rule.matches(strings.Join(parts[i:], "/"), isDir) {
+				ignored = !rule.negate
+			}
+		}
+		dir = filepath.Join(dir, parts[i])
+	}
+	for _, rule := range m.custom {
+		if rule.matches(rel, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// gitignore returns the rules of a directory's .gitignore
+func (m *ignoreMatcher) gitignore(dir string) []ignoreRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rules, ok := m.dirs[dir]; ok {
+		return rules
+	}
+	var rules []ignoreRule
+	if file, err := os.Open(filepath.Join(dir, ".gitignore")); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if rule, ok := parseIgnoreRule(scanner.Text()); ok {
+				rules = append(rules, rule)
+			}
+		}
+		file.Close()
+	}
+	m.dirs[dir] = rules
+	return rules
+}
+
+// matches reports whether a rule matches a path relative to its base
+func (r ignoreRule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if !r.anchored {
+		rel = rel[strings.LastIndex(rel, "/")+1:]
+	}
+	return r.pattern.MatchString(rel)
+}
+
+// parseIgnoreRule reads a .gitignore line; blank lines and comments give no rule
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:] // Escaped leading "!" or "#"
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		rule.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	pattern, err := regexp.Compile("^" + globToRegexp(line) + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globToRegexp translates gitignore wildcards: "*" and "?" stay within a
+// path segment, "**" spans segments
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**") {
+				i++
+				if strings.HasPrefix(glob[i+1:], "/") {
+					i++
+					b.WriteString("(?:.*/)?") // "**/" also matches no directory
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			if end := strings.IndexByte(glob[i+1:], ']'); end >= 0 {
+				class := glob[i+1 : i+1+end]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += end + 1
+			} else {
+				b.WriteString(regexp.QuoteMeta("["))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
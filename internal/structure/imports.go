@@ -0,0 +1,135 @@
+package structure
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ImportGraph records which project files import which, for JS/TS sources.
+// Only relative imports are followed; packages are outside the project.
+type ImportGraph struct {
+	files     []string            // every JS/TS file scanned
+	imports   map[string][]string // file -> files it imports
+	importers map[string][]string // file -> files importing it
+}
+
+// importPattern matches static imports, re-exports, dynamic imports and require calls
+var importPattern = regexp.MustCompile(`(?:import|export)\s[^'";]*?from\s*['"]([^'"]+)['"]|import\s*\(?\s*['"]([^'"]+)['"]|require\(\s*['"]([^'"]+)['"]\s*\)`)
+
+// ImportGraph scans the project's JS/TS files and resolves their relative imports
+func (s *Scanner) ImportGraph(projectPath string) (*ImportGraph, error) {
+	graph := &ImportGraph{
+		imports:   make(map[string][]string),
+		importers: make(map[string][]string),
+	}
+	ignore := s.ignoreMatcher(projectPath)
+
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectPath && (s.ignoredDirs[name] || strings.HasPrefix(name, ".") || ignore.ignored(path, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isScriptLanguage(s.fileLanguage(name)) || ignore.ignored(path, false) {
+			return nil
+		}
+
+		graph.files = append(graph.files, path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		for _, match := range importPattern.FindAllStringSubmatch(string(content), -1) {
+			spec := match[1] + match[2] + match[3]
+			if !strings.HasPrefix(spec, ".") {
+				continue
+			}
+			if target := s.resolveImport(filepath.Dir(path), spec); target != "" && target != path {
+				graph.imports[path] = append(graph.imports[path], target)
+				graph.importers[target] = append(graph.importers[target], path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// resolveImport finds the file a relative import refers to, trying the
+// extensions and index files bundlers and TypeScript resolve
+func (s *Scanner) resolveImport(dir, spec string) string {
+	base := filepath.Join(dir, filepath.FromSlash(spec))
+	candidates := []string{base}
+	// ESM TypeScript imports name the compiled file: "./util.js" -> util.ts
+	if ext := filepath.Ext(base); ext == ".js" || ext == ".jsx" || ext == ".mjs" || ext == ".cjs" {
+		trimmed := strings.TrimSuffix(base, ext)
+		candidates = append(candidates, trimmed+".ts", trimmed+".tsx", trimmed+".mts", trimmed+".cts")
+	}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range resolveExtensions {
+		candidates = append(candidates, filepath.Join(base, "index"+ext))
+	}
+
+	for _, candidate := range candidates {
+		if !isScriptLanguage(s.fileLanguage(candidate)) {
+			continue
+		}
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// resolveExtensions are tried, in order, for imports without an extension
+var resolveExtensions = []string{".ts", ".tsx", ".js", ".jsx", ".mts", ".mjs", ".cts", ".cjs", ".vue", ".svelte"}
+
+// isScriptLanguage reports whether files of a language take part in the
+// import graph
+func isScriptLanguage(language string) bool {
+	switch language {
+	case LangJavaScript, LangTypeScript, LangVue, LangSvelte:
+		return true
+	}
+	return false
+}
+
+// Imports returns the project files a file imports
+func (g *ImportGraph) Imports(file string) []string {
+	return g.imports[file]
+}
+
+// Dependents returns the files that import file, directly or through other
+// files, sorted by path
+func (g *ImportGraph) Dependents(file string) []string {
+	seen := map[string]bool{file: true}
+	queue := []string{file}
+	var dependents []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, importer := range g.importers[current] {
+			if seen[importer] {
+				continue
+			}
+			seen[importer] = true
+			dependents = append(dependents, importer)
+			queue = append(queue, importer)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
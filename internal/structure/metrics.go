@@ -0,0 +1,90 @@
+package structure
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// maxMeasuredSize is the largest file whose lines are counted; bigger ones
+// are mostly generated or minified and only get their size
+const maxMeasuredSize = 1 << 20
+
+// FileMetrics are size figures of a file, or their sums over a directory
+type FileMetrics struct {
+	Lines      int   `json:"lines"`      // Lines of code, without blank and comment-only lines
+	Size       int64 `json:"size"`       // Bytes
+	Complexity int   `json:"complexity"` // Branch points, a rough cyclomatic complexity
+}
+
+// branchPatterns match the keywords and operators that add a path through
+// code, per language
+var (
+	branchPattern       = regexp.MustCompile(`\b(?:if|for|while|case|catch)\b|&&|\|\|`)
+	pythonBranchPattern = regexp.MustCompile(`\b(?:if|elif|for|while|except|case|and|or)\b`)
+	rustBranchPattern   = regexp.MustCompile(`\b(?:if|for|while|loop)\b|=>|&&|\|\|`)
+)
+
+// measureFile counts a source file's lines of code and branch points
+func measureFile(path, language string, size int64) *FileMetrics {
+	metrics := &FileMetrics{Size: size}
+	if size > maxMeasuredSize {
+		return metrics
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return metrics
+	}
+
+	pattern, lineComment := branchPattern, "//"
+	switch language {
+	case LangPython:
+		pattern, lineComment = pythonBranchPattern, "#"
+	case LangRust:
+		pattern = rustBranchPattern
+	}
+
+	inBlock := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), maxMeasuredSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if inBlock {
+			end := strings.Index(line, "*/")
+			if end < 0 {
+				continue
+			}
+			inBlock = false
+			line = strings.TrimSpace(line[end+2:])
+		}
+		if language != LangPython && strings.HasPrefix(line, "/*") {
+			end := strings.Index(line[2:], "*/")
+			if end < 0 {
+				inBlock = true
+				continue
+			}
+			line = strings.TrimSpace(line[end+4:])
+		}
+		if line == "" || strings.HasPrefix(line, lineComment) {
+			continue
+		}
+		metrics.Lines++
+		metrics.Complexity += len(pattern.FindAllStringIndex(line, -1))
+	}
+	return metrics
+}
+
+// addMetrics adds a file's or subdirectory's metrics to a directory
+func (n *FileNode) addMetrics(metrics *FileMetrics) {
+	if metrics == nil {
+		return
+	}
+	if n.Metrics == nil {
+		n.Metrics = &FileMetrics{}
+	}
+	n.Metrics.Lines += metrics.Lines
+	n.Metrics.Size += metrics.Size
+	n.Metrics.Complexity += metrics.Complexity
+}
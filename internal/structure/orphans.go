@@ -0,0 +1,153 @@
+package structure
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// entryNames are file names, without extension, that a project runs or
+// builds from when they sit in the project root or src/
+var entryNames = map[string]bool{"index": true, "main": true, "app": true, "server": true, "cli": true}
+
+// entryDirs hold files loaded by convention: framework routes, scripts run
+// directly, and tests
+var entryDirs = map[string]bool{"pages": true, "app": true, "routes": true, "api": true, "scripts": true, "__tests__": true, "__mocks__": true, "e2e": true}
+
+// entryFilePattern matches tests, stories, configs and type declarations
+var entryFilePattern = regexp.MustCompile(`\.(?:test|spec|stories|story|config|setup)\.[^.]+$|\.d\.[mc]?ts$`)
+
+// scriptSrcPattern matches script tags in HTML pages
+var scriptSrcPattern = regexp.MustCompile(`<script[^>]*\ssrc=["']([^"']+)["']`)
+
+// OrphanFiles returns the JS/TS files nothing in the project imports and
+// that aren't entry points, relative to the project and sorted. They are
+// candidates for deletion: files loaded by globs or by name from other
+// tools can't be told apart.
+func (s *Scanner) OrphanFiles(projectPath string) ([]string, error) {
+	graph, err := s.ImportGraph(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	entries := s.entryPoints(projectPath)
+
+	orphans := []string{}
+	for _, file := range graph.files {
+		if len(graph.importers[file]) > 0 || entries[file] || isEntryByConvention(projectPath, file) {
+			continue
+		}
+		orphans = append(orphans, file)
+	}
+	orphans = relativeFiles(projectPath, orphans)
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// isEntryByConvention reports whether a file is loaded by naming
+// convention rather than by an import
+func isEntryByConvention(projectPath, file string) bool {
+	if entryFilePattern.MatchString(filepath.Base(file)) {
+		return true
+	}
+	rel, err := filepath.Rel(projectPath, file)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if entryDirs[dir] {
+			return true
+		}
+	}
+	name := strings.TrimSuffix(parts[len(parts)-1], filepath.Ext(file))
+	return entryNames[name] && (len(parts) == 1 || (len(parts) == 2 && parts[0] == "src"))
+}
+
+// entryPoints collects the files package.json manifests and HTML pages
+// point at
+func (s *Scanner) entryPoints(projectPath string) map[string]bool {
+	entries := make(map[string]bool)
+	ignore := s.ignoreMatcher(projectPath)
+
+	add := func(dir, ref string) {
+		if ref == "" || strings.Contains(ref, "://") {
+			return
+		}
+		if !strings.HasPrefix(ref, ".") && !strings.HasPrefix(ref, "/") {
+			ref = "./" + ref
+		}
+		if strings.HasPrefix(ref, "/") {
+			dir, ref = projectPath, "."+ref // Root-relative, as in Vite's index.html
+		}
+		if file := s.resolveImport(dir, ref); file != "" {
+			entries[file] = true
+		}
+	}
+
+	filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectPath && (s.ignoredDirs[name] || strings.HasPrefix(name, ".") || ignore.ignored(path, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case name == "package.json":
+			for _, ref := range manifestEntries(path) {
+				add(filepath.Dir(path), ref)
+			}
+		case strings.HasSuffix(name, ".html"):
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			for _, match := range scriptSrcPattern.FindAllStringSubmatch(string(content), -1) {
+				add(filepath.Dir(path), match[1])
+			}
+		}
+		return nil
+	})
+	return entries
+}
+
+// manifestEntries returns the paths a package.json names in main, module,
+// browser, types, bin and exports
+func manifestEntries(path string) []string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var manifest map[string]interface{}
+	if json.Unmarshal(content, &manifest) != nil {
+		return nil
+	}
+
+	var refs []string
+	var collect func(value interface{})
+	collect = func(value interface{}) {
+		switch v := value.(type) {
+		case string:
+			refs = append(refs, v)
+		case map[string]interface{}:
+			for _, nested := range v {
+				collect(nested)
+			}
+		case []interface{}:
+			for _, nested := range v {
+				collect(nested)
+			}
+		}
+	}
+	for _, field := range []string{"main", "module", "browser", "types", "typings", "bin", "exports"} {
+		collect(manifest[field])
+	}
+	return refs
+}
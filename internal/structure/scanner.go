@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // FileNode represents a file or directory in the project structure
@@ -13,15 +15,46 @@ type FileNode struct {
 	Path      string     `json:"path"`
 	IsDir     bool       `json:"isDir"`
 	Children  []FileNode `json:"children,omitempty"`
-	FileCount int        `json:"fileCount,omitempty"` // Count of JS/TS files (for directories only)
+	FileCount int        `json:"fileCount,omitempty"` // Count of source files (for directories only)
+	Language  string     `json:"language,omitempty"`  // Source language (for files only)
+	// Count of source files per language (for directories only)
+	Languages map[string]int `json:"languages,omitempty"`
+	// Size and complexity, summed over source files for directories
+	Metrics *FileMetrics `json:"metrics,omitempty"`
+	// Children were left out of a lazily delivered tree (for directories only)
+	HasMore bool `json:"hasMore,omitempty"`
+	// Git changes of the file, or of files below the directory
+	Git *GitFlags `json:"git,omitempty"`
 }
 
-// Scanner scans project directories for JS/TS files
+// Trees of projects with more source files than LazyTreeThreshold are
+// delivered LazyTreeDepth levels deep; deeper directories are loaded on demand
+const (
+	LazyTreeThreshold = 50000
+	LazyTreeDepth     = 2
+)
+
+// Source languages recognized by the scanner
+const (
+	LangJavaScript = "javascript"
+	LangTypeScript = "typescript"
+	LangVue        = "vue"
+	LangSvelte     = "svelte"
+	LangGo         = "go"
+	LangPython     = "python"
+	LangRust       = "rust"
+	LangJava       = "java"
+)
+
+// Scanner scans project directories for source files
 type Scanner struct {
 	// Directories to ignore
 	ignoredDirs map[string]bool
-	// File extensions to include
-	allowedExtensions map[string]bool
+	// Language of each file extension to include
+	languages map[string]string
+
+	mu             sync.RWMutex
+	ignorePatterns map[string][]string // projectPath -> custom ignore patterns
 }
 
 // NewScanner creates a new Scanner instance
@@ -42,19 +75,27 @@ func NewScanner() *Scanner {
 			"vendor":       true,
 			".vscode":      true,
 			".idea":        true,
+			"target":       true, // Rust and Maven builds
+			"__pycache__":  true,
+			"venv":         true,
 		},
-		allowedExtensions: map[string]bool{
-			".js":   true,
-			".jsx":  true,
-			".ts":   true,
-			".tsx":  true,
-			".mjs":  true,
-			".mts":  true,
-			".cjs":  true,
-			".cts":  true,
-			".vue":  true,
-			".svelte": true,
+		languages: map[string]string{
+			".js":     LangJavaScript,
+			".jsx":    LangJavaScript,
+			".ts":     LangTypeScript,
+			".tsx":    LangTypeScript,
+			".mjs":    LangJavaScript,
+			".mts":    LangTypeScript,
+			".cjs":    LangJavaScript,
+			".cts":    LangTypeScript,
+			".vue":    LangVue,
+			".svelte": LangSvelte,
+			".go":     LangGo,
+			".py":     LangPython,
+			".rs":     LangRust,
+			".java":   LangJava,
 		},
+		ignorePatterns: make(map[string][]string),
 	}
 }
 
@@ -69,28 +110,43 @@ func (s *Scanner) ScanProject(projectPath string) (*FileNode, error) {
 		return nil, os.ErrNotExist
 	}
 
-	root := s.scanDir(projectPath, filepath.Base(projectPath))
-	return root, nil
+	listings := make(map[string]*dirListing)
+	s.listTree(projectPath, listings, s.ignoreMatcher(projectPath))
+	return buildNode(listings, projectPath, filepath.Base(projectPath)), nil
 }
 
-// scanDir recursively scans a directory
-func (s *Scanner) scanDir(dirPath, name string) *FileNode {
-	node := &FileNode{
-		Name:     name,
-		Path:     dirPath,
-		IsDir:    true,
-		Children: []FileNode{},
+// dirListing is what the scanner keeps of a directory's entries
+type dirListing struct {
+	modTime time.Time
+	dirs    []string               // Subdirectories that aren't ignored, sorted
+	files   map[string]*sourceFile // Source file name -> details
+}
+
+// sourceFile is a listed source file
+type sourceFile struct {
+	language string
+	modTime  time.Time
+	metrics  *FileMetrics
+}
+
+// listDir reads a directory's subdirectories and source files, or returns
+// nil when the directory is gone. Metrics are taken from previous, if given,
+// for files that haven't changed since.
+func (s *Scanner) listDir(dirPath string, previous *dirListing, ignore *ignoreMatcher) *dirListing {
+	info, err := os.Stat(dirPath)
+	if err != nil || !info.IsDir() {
+		return nil
+	}
+	listing := &dirListing{
+		modTime: info.ModTime(),
+		files:   make(map[string]*sourceFile),
 	}
 
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
-		return node
+		return listing
 	}
 
-	var dirs []os.DirEntry
-	var files []os.DirEntry
-
-	// Separate dirs and files
 	for _, entry := range entries {
 		entryName := entry.Name()
 
@@ -101,52 +157,135 @@ func (s *Scanner) scanDir(dirPath, name string) *FileNode {
 
 		if entry.IsDir() {
 			// Skip ignored directories
-			if s.ignoredDirs[entryName] {
+			if s.ignoredDirs[entryName] || ignore.ignored(filepath.Join(dirPath, entryName), true) {
 				continue
 			}
-			dirs = append(dirs, entry)
-		} else {
-			// Only include files with allowed extensions
-			ext := strings.ToLower(filepath.Ext(entryName))
-			if s.allowedExtensions[ext] {
-				files = append(files, entry)
+			listing.dirs = append(listing.dirs, entryName)
+		} else if language := s.fileLanguage(entryName); language != "" {
+			// Only include source files
+			if ignore.ignored(filepath.Join(dirPath, entryName), false) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
 			}
+			file := &sourceFile{language: language, modTime: info.ModTime()}
+			if previous != nil {
+				if old, ok := previous.files[entryName]; ok && old.modTime.Equal(file.modTime) && old.metrics.Size == info.Size() {
+					file.metrics = old.metrics
+				}
+			}
+			if file.metrics == nil {
+				file.metrics = measureFile(filepath.Join(dirPath, entryName), language, info.Size())
+			}
+			listing.files[entryName] = file
 		}
 	}
 
-	// Sort dirs and files alphabetically
-	sort.Slice(dirs, func(i, j int) bool {
-		return strings.ToLower(dirs[i].Name()) < strings.ToLower(dirs[j].Name())
-	})
-	sort.Slice(files, func(i, j int) bool {
-		return strings.ToLower(files[i].Name()) < strings.ToLower(files[j].Name())
+	sort.Slice(listing.dirs, func(i, j int) bool {
+		return strings.ToLower(listing.dirs[i]) < strings.ToLower(listing.dirs[j])
 	})
+	return listing
+}
+
+// listTree lists a directory and everything below it into listings
+func (s *Scanner) listTree(dirPath string, listings map[string]*dirListing, ignore *ignoreMatcher) {
+	listing := s.listDir(dirPath, nil, ignore)
+	if listing == nil {
+		return
+	}
+	listings[dirPath] = listing
+	for _, dir := range listing.dirs {
+		s.listTree(filepath.Join(dirPath, dir), listings, ignore)
+	}
+}
+
+// buildNode builds the tree below a directory from its listings
+func buildNode(listings map[string]*dirListing, dirPath, name string) *FileNode {
+	node := &FileNode{
+		Name:     name,
+		Path:     dirPath,
+		IsDir:    true,
+		Children: []FileNode{},
+	}
+
+	listing := listings[dirPath]
+	if listing == nil {
+		return node
+	}
 
 	// Process directories first
-	for _, dir := range dirs {
-		childPath := filepath.Join(dirPath, dir.Name())
-		childNode := s.scanDir(childPath, dir.Name())
-		// Only add directories that have JS/TS files or subdirectories with JS/TS files
+	for _, dir := range listing.dirs {
+		childNode := buildNode(listings, filepath.Join(dirPath, dir), dir)
+		// Only add directories that have source files or subdirectories with source files
 		if childNode.FileCount > 0 || len(childNode.Children) > 0 {
 			node.Children = append(node.Children, *childNode)
 			node.FileCount += childNode.FileCount
+			for language, count := range childNode.Languages {
+				node.countLanguage(language, count)
+			}
+			node.addMetrics(childNode.Metrics)
 		}
 	}
 
-	// Then add files
+	// Then add files, sorted alphabetically
+	files := make([]string, 0, len(listing.files))
+	for file := range listing.files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return strings.ToLower(files[i]) < strings.ToLower(files[j])
+	})
 	for _, file := range files {
-		filePath := filepath.Join(dirPath, file.Name())
+		source := listing.files[file]
 		node.Children = append(node.Children, FileNode{
-			Name:  file.Name(),
-			Path:  filePath,
-			IsDir: false,
+			Name:     file,
+			Path:     filepath.Join(dirPath, file),
+			IsDir:    false,
+			Language: source.language,
+			Metrics:  source.metrics,
 		})
 		node.FileCount++
+		node.countLanguage(source.language, 1)
+		node.addMetrics(source.metrics)
 	}
 
 	return node
 }
 
+// Shallow returns a copy of the tree cut depth levels below node. Directories
+// whose children were cut keep their counts and are marked HasMore.
+func (n *FileNode) Shallow(depth int) *FileNode {
+	copied := *n
+	if !n.IsDir {
+		return &copied
+	}
+	if depth <= 0 {
+		copied.HasMore = len(n.Children) > 0
+		copied.Children = nil
+		return &copied
+	}
+	copied.Children = make([]FileNode, len(n.Children))
+	for i := range n.Children {
+		copied.Children[i] = *n.Children[i].Shallow(depth - 1)
+	}
+	return &copied
+}
+
+// fileLanguage returns the language of a source file, or "" for other files
+func (s *Scanner) fileLanguage(name string) string {
+	return s.languages[strings.ToLower(filepath.Ext(name))]
+}
+
+// countLanguage adds files of a language to a directory's counts
+func (n *FileNode) countLanguage(language string, count int) {
+	if n.Languages == nil {
+		n.Languages = make(map[string]int)
+	}
+	n.Languages[language] += count
+}
+
 // GetFolderHierarchy returns only the folder structure (no files) for graph visualization
 func (s *Scanner) GetFolderHierarchy(projectPath string) (*FileNode, error) {
 	fullTree, err := s.ScanProject(projectPath)
@@ -169,6 +308,8 @@ func (s *Scanner) filterDirsOnly(node *FileNode) *FileNode {
 		Path:      node.Path,
 		IsDir:     true,
 		FileCount: node.FileCount,
+		Languages: node.Languages,
+		Metrics:   node.Metrics,
 		Children:  []FileNode{},
 	}
 
@@ -0,0 +1,243 @@
+package structure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Search limits
+const (
+	DefaultSearchResults = 500
+	MaxSearchResults     = 5000
+	maxSearchFileSize    = 2 << 20 // Larger files are skipped, mostly generated or data
+	maxSearchLineLength  = 500     // Longer matched lines are cut around the match
+)
+
+// SearchOptions control a project search
+type SearchOptions struct {
+	CaseSensitive bool   `json:"caseSensitive"`
+	Regex         bool   `json:"regex"`     // The query is a Go regular expression
+	WholeWord     bool   `json:"wholeWord"` // Matches must start and end at word boundaries
+	Include       string `json:"include"`   // Glob on file names or relative paths, e.g. "*.go"
+	MaxResults    int    `json:"maxResults"`
+	ContextLines  int    `json:"contextLines"` // Lines shown before and after each match, up to 5
+}
+
+// SearchMatch is a line matching a search
+type SearchMatch struct {
+	File   string   `json:"file"` // Relative to the project
+	Line   int      `json:"line"`
+	Column int      `json:"column"` // Byte offset of the match in Text, from 0
+	Length int      `json:"length"`
+	Text   string   `json:"text"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// SearchResult is the outcome of a project search
+type SearchResult struct {
+	Query         string        `json:"query"`
+	Matches       []SearchMatch `json:"matches"`
+	FilesSearched int           `json:"filesSearched"`
+	FilesMatched  int           `json:"filesMatched"`
+	Truncated     bool          `json:"truncated"` // MaxResults was reached
+}
+
+// Search looks for query in the project's text files, skipping what the
+// scanner ignores. Files are searched in parallel; the search stops when ctx
+// is cancelled or MaxResults matches are found.
+func (s *Scanner) Search(ctx context.Context, projectPath, query string, opts SearchOptions) (*SearchResult, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	pattern, err := searchPattern(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = DefaultSearchResults
+	}
+	if opts.MaxResults > MaxSearchResults {
+		opts.MaxResults = MaxSearchResults
+	}
+	opts.ContextLines = max(0, min(opts.ContextLines, 5))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ignore := s.ignoreMatcher(projectPath)
+	result := &SearchResult{Query: query, Matches: []SearchMatch{}}
+	var mu sync.Mutex
+	files := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range files {
+				matches := searchFile(ctx, path, pattern, opts.ContextLines)
+				mu.Lock()
+				result.FilesSearched++
+				if len(matches) > 0 && !result.Truncated {
+					result.FilesMatched++
+					rel, _ := filepath.Rel(projectPath, path)
+					for i := range matches {
+						matches[i].File = filepath.ToSlash(rel)
+					}
+					if room := opts.MaxResults - len(result.Matches); len(matches) >= room {
+						matches = matches[:room]
+						result.Truncated = true
+						cancel()
+					}
+					result.Matches = append(result.Matches, matches...)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	walkErr := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectPath && (s.ignoredDirs[name] || strings.HasPrefix(name, ".") || ignore.ignored(path, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !searchIncludes(projectPath, path, opts.Include) || ignore.ignored(path, false) {
+			return nil
+		}
+		select {
+		case files <- path:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	})
+	close(files)
+	wg.Wait()
+
+	// Stopping at MaxResults cancels the walk too; only an outside cancel is an error
+	if walkErr != nil && !result.Truncated {
+		return nil, walkErr
+	}
+
+	sort.SliceStable(result.Matches, func(i, j int) bool {
+		if result.Matches[i].File != result.Matches[j].File {
+			return result.Matches[i].File < result.Matches[j].File
+		}
+		return result.Matches[i].Line < result.Matches[j].Line
+	})
+	return result, nil
+}
+
+// searchPattern compiles the query with the options applied
+func searchPattern(query string, opts SearchOptions) (*regexp.Regexp, error) {
+	expr := query
+	if !opts.Regex {
+		expr = regexp.QuoteMeta(query)
+	}
+	if opts.WholeWord {
+		expr = `\b(?:` + expr + `)\b`
+	}
+	if !opts.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search pattern: %w", err)
+	}
+	return pattern, nil
+}
+
+// searchIncludes reports whether a file passes the include glob, matched
+// against its name and its path relative to the project
+func searchIncludes(projectPath, path, include string) bool {
+	if include == "" {
+		return true
+	}
+	if ok, _ := filepath.Match(include, filepath.Base(path)); ok {
+		return true
+	}
+	rel, err := filepath.Rel(projectPath, path)
+	if err != nil {
+		return false
+	}
+	ok, _ := filepath.Match(include, filepath.ToSlash(rel))
+	return ok
+}
+
+// searchFile returns the matching lines of a text file, with context
+func searchFile(ctx context.Context, path string, pattern *regexp.Regexp, contextLines int) []SearchMatch {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxSearchFileSize {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || isBinary(content) {
+		return nil
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), maxSearchFileSize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	var matches []SearchMatch
+	for i, line := range lines {
+		if i%1000 == 0 && ctx.Err() != nil {
+			return nil
+		}
+		loc := pattern.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		text, column := clipLine(line, loc[0], loc[1])
+		match := SearchMatch{
+			Line:   i + 1,
+			Column: column,
+			Length: loc[1] - loc[0],
+			Text:   text,
+		}
+		if contextLines > 0 {
+			match.Before = append([]string{}, lines[max(0, i-contextLines):i]...)
+			match.After = append([]string{}, lines[i+1:min(len(lines), i+1+contextLines)]...)
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// clipLine cuts a long line around a match, returning the text and the
+// match's offset in it
+func clipLine(line string, start, end int) (string, int) {
+	if len(line) <= maxSearchLineLength {
+		return line, start
+	}
+	from := max(0, start-maxSearchLineLength/4)
+	to := min(len(line), max(end, from+maxSearchLineLength))
+	return line[from:to], start - from
+}
+
+// isBinary treats content with a NUL byte near the start as binary
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content[:min(len(content), 8000)], 0) >= 0
+}
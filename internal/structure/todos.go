@@ -0,0 +1,91 @@
+package structure
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxCodeTodos caps how many comments a scan returns
+const maxCodeTodos = 2000
+
+// CodeTodo is a TODO, FIXME or HACK comment in a source file
+type CodeTodo struct {
+	File   string `json:"file"` // Relative to the project
+	Line   int    `json:"line"`
+	Marker string `json:"marker"` // TODO, FIXME or HACK
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"` // From git blame, for committed lines
+}
+
+// todoPattern matches a marker right after a comment opener, with an
+// optional "(owner)" and colon: "// TODO(ann): text", "# FIXME text"
+var todoPattern = regexp.MustCompile(`(?:^|\s)(?://+|#|/\*+|\*|<!--)\s*(TODO|FIXME|HACK)\b(?:\([^)]*\))?:?\s*(.*)`)
+
+// CodeTodos scans a project's source files for TODO, FIXME and HACK
+// comments, sorted by file and line
+func (s *Scanner) CodeTodos(projectPath string) ([]CodeTodo, error) {
+	ignore := s.ignoreMatcher(projectPath)
+	todos := []CodeTodo{}
+
+	err := filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != projectPath && (s.ignoredDirs[name] || strings.HasPrefix(name, ".") || ignore.ignored(path, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if s.fileLanguage(name) == "" || ignore.ignored(path, false) {
+			return nil
+		}
+		if len(todos) >= maxCodeTodos {
+			return filepath.SkipAll
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer file.Close()
+		rel, _ := filepath.Rel(projectPath, path)
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), maxMeasuredSize)
+		for line := 1; scanner.Scan(); line++ {
+			match := todoPattern.FindStringSubmatch(scanner.Text())
+			if match == nil {
+				continue
+			}
+			text := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(match[2]), "*/"))
+			text = strings.TrimSpace(strings.TrimSuffix(text, "-->"))
+			todos = append(todos, CodeTodo{
+				File:   filepath.ToSlash(rel),
+				Line:   line,
+				Marker: match[1],
+				Text:   text,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(todos, func(i, j int) bool {
+		if todos[i].File != todos[j].File {
+			return todos[i].File < todos[j].File
+		}
+		return todos[i].Line < todos[j].Line
+	})
+	if len(todos) > maxCodeTodos {
+		todos = todos[:maxCodeTodos]
+	}
+	return todos, nil
+}
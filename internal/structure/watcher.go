@@ -0,0 +1,389 @@
+package structure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce gathers the notifications of a burst of changes, like a
+// branch switch, into one update
+const watchDebounce = 200 * time.Millisecond
+
+// StructureDelta is how a watched project's tree changed since the last
+// check. A renamed file shows up as removed under its old path and added
+// under the new one.
+type StructureDelta struct {
+	ProjectPath string     `json:"projectPath"`
+	Added       []FileNode `json:"added"`   // New source files
+	Removed     []string   `json:"removed"` // Removed files and directories; a directory covers everything below it
+	Changed     []FileNode `json:"changed"` // Files with new metrics after a save
+}
+
+// Watcher keeps the trees of watched projects up to date without rescanning
+// them. Every listed directory is watched for file system notifications;
+// only the directories they name are read again, and only files whose
+// modification time moved are measured again.
+type Watcher struct {
+	mu       sync.Mutex
+	checkMu  sync.Mutex // one update at a time
+	scanner  *Scanner
+	projects map[string]*watchedTree // projectPath -> cached tree
+	onChange func(delta *StructureDelta)
+}
+
+type watchedTree struct {
+	listings map[string]*dirListing // directory -> entries; replaced, never modified
+	tree     *FileNode              // built from listings, nil after a change
+	ignore   *ignoreMatcher
+	fsw      *fsnotify.Watcher
+	pending  map[string]bool // directories with changes waiting for the debounce
+	timer    *time.Timer     // debounce
+}
+
+// NewWatcher creates a watcher that lists directories with scanner
+func NewWatcher(scanner *Scanner) *Watcher {
+	return &Watcher{
+		scanner:  scanner,
+		projects: make(map[string]*watchedTree),
+	}
+}
+
+// SetChangeHandler sets the callback for changes to a watched tree
+func (w *Watcher) SetChangeHandler(handler func(delta *StructureDelta)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onChange = handler
+}
+
+// WatchProject starts watching a project and returns its tree. Only the
+// first call scans the project; later ones return the cached tree.
+func (w *Watcher) WatchProject(projectPath string) (*FileNode, error) {
+	w.mu.Lock()
+	if project, ok := w.projects[projectPath]; ok {
+		tree := project.currentTree(projectPath)
+		w.mu.Unlock()
+		return tree, nil
+	}
+	w.mu.Unlock()
+
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", projectPath, err)
+	}
+	listings := make(map[string]*dirListing)
+	ignore := w.scanner.ignoreMatcher(projectPath)
+	w.scanner.listTree(projectPath, listings, ignore)
+	for dir := range listings {
+		fsw.Add(dir)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	project, ok := w.projects[projectPath]
+	if !ok {
+		project = &watchedTree{listings: listings, ignore: ignore, fsw: fsw, pending: make(map[string]bool)}
+		w.projects[projectPath] = project
+		go w.watchEvents(projectPath, project)
+	} else {
+		fsw.Close()
+	}
+	return project.currentTree(projectPath), nil
+}
+
+// UnwatchProject stops watching a project and drops its cached tree, so the
+// next WatchProject scans it again
+func (w *Watcher) UnwatchProject(projectPath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if project, ok := w.projects[projectPath]; ok {
+		if project.timer != nil {
+			project.timer.Stop()
+		}
+		project.fsw.Close()
+		delete(w.projects, projectPath)
+	}
+}
+
+// StopAll stops watching every project
+func (w *Watcher) StopAll() {
+	w.mu.Lock()
+	paths := make([]string, 0, len(w.projects))
+	for path := range w.projects {
+		paths = append(paths, path)
+	}
+	w.mu.Unlock()
+
+	for _, path := range paths {
+		w.UnwatchProject(path)
+	}
+}
+
+// watchEvents queues the directories a project's notifications name until
+// it is unwatched
+func (w *Watcher) watchEvents(projectPath string, project *watchedTree) {
+	for {
+		select {
+		case event, ok := <-project.fsw.Events:
+			if !ok {
+				return
+			}
+			// Attribute changes alone don't touch the tree
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			w.queueDir(projectPath, project, filepath.Dir(event.Name))
+		case _, ok := <-project.fsw.Errors:
+			// An overflow loses events: read every directory again
+			if !ok {
+				return
+			}
+			w.mu.Lock()
+			dirs := make([]string, 0, len(project.listings))
+			for dir := range project.listings {
+				dirs = append(dirs, dir)
+			}
+			w.mu.Unlock()
+			for _, dir := range dirs {
+				w.queueDir(projectPath, project, dir)
+			}
+		}
+	}
+}
+
+// queueDir records a directory to read again and restarts the debounce
+func (w *Watcher) queueDir(projectPath string, project *watchedTree, dir string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.projects[projectPath] != project {
+		return
+	}
+	project.pending[dir] = true
+	if project.timer != nil {
+		project.timer.Stop()
+	}
+	project.timer = time.AfterFunc(watchDebounce, func() { w.checkProject(projectPath) })
+}
+
+// NodeChildren returns the children of a directory in a watched project's
+// tree, one level deep, for trees delivered with Shallow
+func (w *Watcher) NodeChildren(dirPath string) ([]FileNode, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// The innermost project, should watched projects be nested
+	projectPath := ""
+	for path := range w.projects {
+		if isWithin(path, dirPath) && len(path) > len(projectPath) {
+			projectPath = path
+		}
+	}
+	if projectPath == "" {
+		return nil, fmt.Errorf("%s is not in a watched project", dirPath)
+	}
+
+	node := w.projects[projectPath].currentTree(projectPath)
+	rel, _ := filepath.Rel(projectPath, dirPath)
+	for _, name := range strings.Split(filepath.ToSlash(rel), "/") {
+		if name == "." {
+			continue
+		}
+		var child *FileNode
+		for i := range node.Children {
+			if node.Children[i].IsDir && node.Children[i].Name == name {
+				child = &node.Children[i]
+				break
+			}
+		}
+		if child == nil {
+			return nil, os.ErrNotExist
+		}
+		node = child
+	}
+	return node.Shallow(1).Children, nil
+}
+
+// currentTree returns the cached tree, building it after a change.
+// Callers hold w.mu.
+func (t *watchedTree) currentTree(projectPath string) *FileNode {
+	if t.tree == nil {
+		t.tree = buildNode(t.listings, projectPath, filepath.Base(projectPath))
+	}
+	return t.tree
+}
+
+// checkProject reads the directories notifications named again, updates
+// the cached listings and reports the difference
+func (w *Watcher) checkProject(projectPath string) {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	w.mu.Lock()
+	project, ok := w.projects[projectPath]
+	if !ok {
+		w.mu.Unlock()
+		return
+	}
+	previous := make(map[string]*dirListing, len(project.listings))
+	for dir, listing := range project.listings {
+		previous[dir] = listing
+	}
+	dirs := project.pending
+	project.pending = make(map[string]bool)
+	w.mu.Unlock()
+
+	// A deleted directory is skipped here; its parent changed as well. Only
+	// files whose modification time moved are measured again.
+	changed := make(map[string]*dirListing)
+	for dir := range dirs {
+		listing, ok := previous[dir]
+		if !ok {
+			continue
+		}
+		if current := w.scanner.listDir(dir, listing, project.ignore); current != nil {
+			changed[dir] = current
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	delta := &StructureDelta{
+		ProjectPath: projectPath,
+		Added:       []FileNode{},
+		Removed:     []string{},
+		Changed:     []FileNode{},
+	}
+	updated := make(map[string]*dirListing)
+	var addedDirs, removedDirs []string
+	for dir, current := range changed {
+		old := previous[dir]
+		updated[dir] = current
+
+		for name, file := range current.files {
+			if before, ok := old.files[name]; !ok {
+				delta.Added = append(delta.Added, file.node(dir, name))
+			} else if *before.metrics != *file.metrics {
+				delta.Changed = append(delta.Changed, file.node(dir, name))
+			}
+		}
+		for name := range old.files {
+			if _, ok := current.files[name]; !ok {
+				delta.Removed = append(delta.Removed, filepath.Join(dir, name))
+			}
+		}
+
+		for _, name := range newEntries(current.dirs, old.dirs) {
+			subtree := make(map[string]*dirListing)
+			w.scanner.listTree(filepath.Join(dir, name), subtree, project.ignore)
+			for subdir, listing := range subtree {
+				addedDirs = append(addedDirs, subdir)
+				updated[subdir] = listing
+				for fileName, file := range listing.files {
+					delta.Added = append(delta.Added, file.node(subdir, fileName))
+				}
+			}
+		}
+		for _, name := range newEntries(old.dirs, current.dirs) {
+			path := filepath.Join(dir, name)
+			removedDirs = append(removedDirs, path)
+			// Directories without source files aren't in the tree
+			if hasSourceFiles(previous, path) {
+				delta.Removed = append(delta.Removed, path)
+			}
+		}
+	}
+
+	w.mu.Lock()
+	if w.projects[projectPath] != project {
+		w.mu.Unlock()
+		return // Unwatched or watched again meanwhile
+	}
+	listings := make(map[string]*dirListing, len(previous))
+	for dir, listing := range project.listings {
+		listings[dir] = listing
+	}
+	// A renamed directory keeps its watch under the old name, so watches are
+	// removed before the new name is added
+	for _, path := range removedDirs {
+		for dir := range listings {
+			if isWithin(path, dir) {
+				project.fsw.Remove(dir)
+				delete(listings, dir)
+			}
+		}
+	}
+	for _, dir := range addedDirs {
+		project.fsw.Add(dir)
+	}
+	for dir, listing := range updated {
+		listings[dir] = listing
+	}
+	project.listings = listings
+	hasChanges := len(delta.Added) > 0 || len(delta.Removed) > 0 || len(delta.Changed) > 0
+	if hasChanges {
+		project.tree = nil
+	}
+	onChange := w.onChange
+	w.mu.Unlock()
+
+	if hasChanges && onChange != nil {
+		sort.Slice(delta.Added, func(i, j int) bool { return delta.Added[i].Path < delta.Added[j].Path })
+		sort.Strings(delta.Removed)
+		sort.Slice(delta.Changed, func(i, j int) bool { return delta.Changed[i].Path < delta.Changed[j].Path })
+		onChange(delta)
+	}
+}
+
+// node returns the tree node of a listed file
+func (f *sourceFile) node(dir, name string) FileNode {
+	return FileNode{
+		Name:     name,
+		Path:     filepath.Join(dir, name),
+		Language: f.language,
+		Metrics:  f.metrics,
+	}
+}
+
+// newEntries returns the names in current that aren't in old
+func newEntries(current, old []string) []string {
+	seen := make(map[string]bool, len(old))
+	for _, name := range old {
+		seen[name] = true
+	}
+	var added []string
+	for _, name := range current {
+		if !seen[name] {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+// hasSourceFiles reports whether a directory or any below it had source files
+func hasSourceFiles(listings map[string]*dirListing, path string) bool {
+	for dir, listing := range listings {
+		if len(listing.files) > 0 && isWithin(path, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin reports whether path is dir or below it
+func isWithin(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
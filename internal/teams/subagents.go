@@ -0,0 +1,237 @@
+package teams
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// subagentWindow is how recently a transcript must have changed to be scanned
+	subagentWindow = time.Hour
+	// maxSubagentSessions caps how many sessions are shown
+	maxSubagentSessions = 20
+	// subagentPreviewLen bounds prompt and result previews
+	subagentPreviewLen = 300
+)
+
+// Subagent is one Task tool call spawned by a single Claude session
+type Subagent struct {
+	ID          string `json:"id"` // tool_use id
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Prompt      string `json:"prompt"`
+	Status      string `json:"status"` // running, completed or failed
+	StartedAt   int64  `json:"startedAt"`
+	EndedAt     int64  `json:"endedAt,omitempty"`
+	Result      string `json:"result,omitempty"`
+}
+
+// SessionSubagents is the subagent activity of one session transcript
+type SessionSubagents struct {
+	SessionID    string     `json:"sessionId"`
+	ProjectPath  string     `json:"projectPath"`
+	Subagents    []Subagent `json:"subagents"`
+	LastModified int64      `json:"lastModified"`
+}
+
+// transcriptLine is the subset of a ~/.claude/projects transcript line used
+type transcriptLine struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Cwd       string `json:"cwd"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// transcriptBlock is a content block of a transcript message
+type transcriptBlock struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Input struct {
+		Description  string `json:"description"`
+		Prompt       string `json:"prompt"`
+		SubagentType string `json:"subagent_type"`
+	} `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	IsError   bool            `json:"is_error"`
+	Content   json.RawMessage `json:"content"`
+}
+
+// cachedTranscript avoids re-reading transcripts that didn't change
+type cachedTranscript struct {
+	modTime time.Time
+	size    int64
+	session *SessionSubagents
+}
+
+// scanSubagents reads recently active transcripts for Task tool calls
+func (w *Watcher) scanSubagents() {
+	cutoff := time.Now().Add(-subagentWindow)
+	files, _ := filepath.Glob(filepath.Join(w.projectsDir, "*", "*.jsonl"))
+
+	var sessions []*SessionSubagents
+	var hashParts []string
+	cache := make(map[string]cachedTranscript)
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil || info.ModTime().Before(cutoff) {
+			continue
+		}
+
+		cached, ok := w.transcripts[f]
+		if !ok || !cached.modTime.Equal(info.ModTime()) || cached.size != info.Size() {
+			cached = cachedTranscript{modTime: info.ModTime(), size: info.Size(), session: readSessionSubagents(f)}
+			if cached.session != nil {
+				cached.session.LastModified = info.ModTime().UnixMilli()
+			}
+		}
+		cache[f] = cached
+		if cached.session == nil {
+			continue
+		}
+		sessions = append(sessions, cached.session)
+		hashParts = append(hashParts, f+":"+info.ModTime().String())
+	}
+	w.transcripts = cache
+
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastModified > sessions[j].LastModified })
+	if len(sessions) > maxSubagentSessions {
+		sessions = sessions[:maxSubagentSessions]
+	}
+
+	sort.Strings(hashParts)
+	newHash := strings.Join(hashParts, "|")
+	if newHash == w.lastSubagentHash {
+		return
+	}
+	w.lastSubagentHash = newHash
+
+	w.mu.Lock()
+	w.subagents = sessions
+	w.mu.Unlock()
+
+	if w.subagentCallback != nil {
+		w.subagentCallback(w.GetSubagentSessions())
+	}
+}
+
+// readSessionSubagents pairs the Task tool calls of a transcript with their
+// results. Transcripts that never spawned a subagent return nil.
+func readSessionSubagents(path string) *SessionSubagents {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	session := &SessionSubagents{SessionID: strings.TrimSuffix(filepath.Base(path), ".jsonl")}
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line transcriptLine
+		if json.Unmarshal(scanner.Bytes(), &line) != nil || (line.Type != "assistant" && line.Type != "user") {
+			continue
+		}
+		if session.ProjectPath == "" && line.Cwd != "" {
+			session.ProjectPath = filepath.Clean(line.Cwd)
+		}
+		var blocks []transcriptBlock
+		if json.Unmarshal(line.Message.Content, &blocks) != nil {
+			continue // Plain string content
+		}
+		var ts int64
+		if t, err := time.Parse(time.RFC3339, line.Timestamp); err == nil {
+			ts = t.UnixMilli()
+		}
+
+		for _, b := range blocks {
+			switch {
+			case b.Type == "tool_use" && (b.Name == "Task" || b.Name == "Agent"):
+				index[b.ID] = len(session.Subagents)
+				session.Subagents = append(session.Subagents, Subagent{
+					ID:          b.ID,
+					Type:        b.Input.SubagentType,
+					Description: b.Input.Description,
+					Prompt:      preview(b.Input.Prompt),
+					Status:      "running",
+					StartedAt:   ts,
+				})
+			case b.Type == "tool_result":
+				i, ok := index[b.ToolUseID]
+				if !ok {
+					continue
+				}
+				sub := &session.Subagents[i]
+				sub.Status = "completed"
+				if b.IsError {
+					sub.Status = "failed"
+				}
+				sub.EndedAt = ts
+				sub.Result = preview(resultText(b.Content))
+			}
+		}
+	}
+
+	if len(session.Subagents) == 0 {
+		return nil
+	}
+	return session
+}
+
+// resultText extracts the text of a tool result, which is either a string or
+// a list of text blocks
+func resultText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(content, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// preview trims text to subagentPreviewLen runes
+func preview(s string) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > subagentPreviewLen {
+		return string(r[:subagentPreviewLen]) + "..."
+	}
+	return s
+}
+
+// SetSubagentCallback sets the callback for subagent activity updates
+func (w *Watcher) SetSubagentCallback(fn func([]SessionSubagents)) {
+	w.subagentCallback = fn
+}
+
+// GetSubagentSessions returns the recent sessions that spawned subagents, most recently active first
+func (w *Watcher) GetSubagentSessions() []SessionSubagents {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	result := make([]SessionSubagents, 0, len(w.subagents))
+	for _, s := range w.subagents {
+		result = append(result, *s)
+	}
+	return result
+}
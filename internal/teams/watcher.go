@@ -80,16 +80,25 @@ type Watcher struct {
 	mu             sync.RWMutex
 	updateCallback func(teams map[string]*TeamSnapshot)
 	lastHash       string // simple change detection
+
+	// Subagents spawned by single sessions, read from ~/.claude/projects transcripts
+	projectsDir      string
+	subagents        []*SessionSubagents
+	transcripts      map[string]cachedTranscript
+	subagentCallback func([]SessionSubagents)
+	lastSubagentHash string
 }
 
 // NewWatcher creates a new teams watcher
 func NewWatcher() *Watcher {
 	homeDir, _ := os.UserHomeDir()
 	return &Watcher{
-		teamsDir: filepath.Join(homeDir, ".claude", "teams"),
-		tasksDir: filepath.Join(homeDir, ".claude", "tasks"),
-		teams:    make(map[string]*TeamSnapshot),
-		history:  NewHistory(),
+		teamsDir:    filepath.Join(homeDir, ".claude", "teams"),
+		tasksDir:    filepath.Join(homeDir, ".claude", "tasks"),
+		projectsDir: filepath.Join(homeDir, ".claude", "projects"),
+		teams:       make(map[string]*TeamSnapshot),
+		transcripts: make(map[string]cachedTranscript),
+		history:     NewHistory(),
 	}
 }
 
@@ -102,6 +111,7 @@ func (w *Watcher) SetUpdateCallback(fn func(map[string]*TeamSnapshot)) {
 func (w *Watcher) StartPolling(interval time.Duration, stopChan chan struct{}) {
 	// Initial scan
 	w.scan()
+	w.scanSubagents()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -110,6 +120,7 @@ func (w *Watcher) StartPolling(interval time.Duration, stopChan chan struct{}) {
 		select {
 		case <-ticker.C:
 			w.scan()
+			w.scanSubagents()
 		case <-stopChan:
 			return
 		}
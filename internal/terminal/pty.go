@@ -61,17 +61,30 @@ func (m *Manager) Create(name, workDir string) (*Terminal, error) {
 
 // CreateWithID creates a new terminal session with a specific ID
 func (m *Manager) CreateWithID(id, name, workDir string) (*Terminal, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.start(id, name, workDir, exec.Command(defaultShell(), "-l"))
+}
+
+// CreateCommandWithID creates a terminal session running a single command
+// through the user's login shell (so PATH matches their normal terminal).
+// The terminal exits when the command does.
+func (m *Manager) CreateCommandWithID(id, name, workDir, command string) (*Terminal, error) {
+	return m.start(id, name, workDir, exec.Command(defaultShell(), "-l", "-c", command))
+}
 
-	// Get default shell
+// defaultShell returns the user's shell
+func defaultShell() string {
 	shell := os.Getenv("SHELL")
 	if shell == "" {
 		shell = "/bin/zsh"
 	}
+	return shell
+}
+
+// start runs cmd in a new PTY and registers the terminal
+func (m *Manager) start(id, name, workDir string, cmd *exec.Cmd) (*Terminal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Create command
-	cmd := exec.Command(shell, "-l")
 	cmd.Dir = workDir
 	cmd.Env = append(os.Environ(),
 		"TERM=xterm-256color",
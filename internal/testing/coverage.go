@@ -1,9 +1,11 @@
 package testing
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
@@ -14,6 +16,34 @@ type CoverageSummary struct {
 	ByFile      map[string]CoverageMetrics `json:"byFile,omitempty"`
 	LastUpdated time.Time                  `json:"lastUpdated"`
 	ProjectPath string                     `json:"projectPath"`
+	Format      string                     `json:"format,omitempty"` // istanbul, lcov, cobertura or go
+
+	files map[string]*FileCoverage // Per-file breakdown, when the report has one
+}
+
+// FileCoverage is the coverage of one source file, by function and line
+type FileCoverage struct {
+	Path           string             `json:"path"` // Relative to the project
+	Metrics        CoverageMetrics    `json:"metrics"`
+	Functions      []FunctionCoverage `json:"functions"`
+	UncoveredLines []LineRange        `json:"uncoveredLines"` // Instrumented lines never run
+
+	lines map[int]int // Instrumented line -> hits, when the report has line data
+}
+
+// FunctionCoverage is the line coverage of one function
+type FunctionCoverage struct {
+	Name      string         `json:"name"`
+	StartLine int            `json:"startLine"`
+	EndLine   int            `json:"endLine,omitempty"`
+	Hits      int            `json:"hits"` // Calls, or summed line hits when the report has no call counts
+	Lines     CoverageDetail `json:"lines"`
+}
+
+// LineRange is an inclusive range of line numbers
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
 }
 
 // CoverageMetrics represents coverage percentages
@@ -43,6 +73,8 @@ type CoverageHistoryEntry struct {
 	Lines     float64   `json:"lines"`
 	Functions float64   `json:"functions"`
 	Branches  float64   `json:"branches"`
+
+	MutationScore float64 `json:"mutationScore,omitempty"` // Latest mutation score, when mutation tests ran
 }
 
 // CoverageWatcher watches for coverage file changes
@@ -52,6 +84,11 @@ type CoverageWatcher struct {
 	projectHistory  map[string]*CoverageHistory
 	watchedPaths    map[string]time.Time // path -> last mod time
 	onUpdate        func(projectPath string, summary *CoverageSummary)
+
+	projectMutation map[string]*MutationSummary
+	mutationMods    map[string]time.Time          // projectPath -> mod time of the last mutation report read
+	mutationRuns    map[string]context.CancelFunc // projectPath -> running mutation tool
+	onMutation      func(projectPath string, summary *MutationSummary)
 }
 
 // NewCoverageWatcher creates a new coverage watcher
@@ -60,6 +97,9 @@ func NewCoverageWatcher() *CoverageWatcher {
 		projectCoverage: make(map[string]*CoverageSummary),
 		projectHistory:  make(map[string]*CoverageHistory),
 		watchedPaths:    make(map[string]time.Time),
+		projectMutation: make(map[string]*MutationSummary),
+		mutationMods:    make(map[string]time.Time),
+		mutationRuns:    make(map[string]context.CancelFunc),
 	}
 }
 
@@ -78,6 +118,7 @@ func (w *CoverageWatcher) WatchProject(projectPath string) {
 
 	// Initial check
 	w.checkCoverage(projectPath)
+	w.checkMutation(projectPath)
 }
 
 // UnwatchProject stops watching a project
@@ -99,22 +140,46 @@ func (w *CoverageWatcher) CheckAll() {
 
 	for _, path := range paths {
 		w.checkCoverage(path)
+		w.checkMutation(path)
 	}
 }
 
-// checkCoverage checks for coverage file updates in a project
+// coverageReports are the report files looked for, relative to the project
+var coverageReports = []string{
+	"coverage/coverage-summary.json",
+	"coverage/coverage-final.json",
+	".nyc_output/coverage-summary.json",
+	"coverage/lcov.info",
+	"lcov.info",
+	"coverage/cobertura-coverage.xml",
+	"coverage/cobertura.xml",
+	"cobertura.xml",
+	"coverage.xml",
+	"target/tarpaulin/cobertura.xml",
+	"coverage.out",
+	"cover.out",
+}
+
+// checkCoverage checks for coverage file updates in a project. When several
+// reports exist, the most recently written one wins.
 func (w *CoverageWatcher) checkCoverage(projectPath string) {
-	coveragePaths := []string{
-		filepath.Join(projectPath, "coverage", "coverage-summary.json"),
-		filepath.Join(projectPath, "coverage", "coverage-final.json"),
-		filepath.Join(projectPath, ".nyc_output", "coverage-summary.json"),
+	type report struct {
+		path string
+		info os.FileInfo
 	}
-
-	for _, coveragePath := range coveragePaths {
-		info, err := os.Stat(coveragePath)
-		if err != nil {
-			continue
+	var reports []report
+	for _, name := range coverageReports {
+		path := filepath.Join(projectPath, filepath.FromSlash(name))
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			reports = append(reports, report{path, info})
 		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].info.ModTime().After(reports[j].info.ModTime())
+	})
+
+	for _, r := range reports {
+		coveragePath, info := r.path, r.info
 
 		w.mu.RLock()
 		lastMod, exists := w.watchedPaths[projectPath]
@@ -147,6 +212,8 @@ func (w *CoverageWatcher) checkCoverage(projectPath string) {
 			Lines:     summary.Total.Lines.Pct,
 			Functions: summary.Total.Functions.Pct,
 			Branches:  summary.Total.Branches.Pct,
+
+			MutationScore: w.latestMutationScore(projectPath),
 		})
 		// Keep only last 50 entries
 		if len(history.Entries) > 50 {
@@ -165,13 +232,22 @@ func (w *CoverageWatcher) checkCoverage(projectPath string) {
 	}
 }
 
-// parseCoverageFile parses a coverage summary JSON file
+// parseCoverageFile parses a coverage report, picking the format from its name
 func (w *CoverageWatcher) parseCoverageFile(path, projectPath string) (*CoverageSummary, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
+	switch filepath.Ext(path) {
+	case ".info":
+		return parseLcov(data, projectPath)
+	case ".xml":
+		return parseCobertura(data, projectPath)
+	case ".out":
+		return parseGoCoverProfile(data, projectPath)
+	}
+
 	// Try to parse as Istanbul/NYC format first
 	var istanbulFormat struct {
 		Total map[string]struct {
@@ -186,6 +262,7 @@ func (w *CoverageWatcher) parseCoverageFile(path, projectPath string) (*Coverage
 		summary := &CoverageSummary{
 			LastUpdated: time.Now(),
 			ProjectPath: projectPath,
+			Format:      CoverageFormatIstanbul,
 		}
 
 		if lines, ok := istanbulFormat.Total["lines"]; ok {
@@ -220,6 +297,7 @@ func (w *CoverageWatcher) parseCoverageFile(path, projectPath string) (*Coverage
 				Pct:     branches.Pct,
 			}
 		}
+		summary.ByFile = parseIstanbulFiles(data, projectPath)
 
 		return summary, nil
 	}
@@ -231,6 +309,7 @@ func (w *CoverageWatcher) parseCoverageFile(path, projectPath string) (*Coverage
 			summary := &CoverageSummary{
 				LastUpdated: time.Now(),
 				ProjectPath: projectPath,
+				Format:      CoverageFormatIstanbul,
 			}
 
 			if lines, ok := total["lines"].(map[string]interface{}); ok {
@@ -253,6 +332,31 @@ func (w *CoverageWatcher) parseCoverageFile(path, projectPath string) (*Coverage
 	return nil, os.ErrNotExist
 }
 
+// parseIstanbulFiles reads the per-file entries of an Istanbul summary, which
+// are keyed by absolute path. Entries Istanbul couldn't compute ("pct":
+// "Unknown") are left out.
+func parseIstanbulFiles(data []byte, projectPath string) map[string]CoverageMetrics {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	files := make(map[string]CoverageMetrics)
+	for path, raw := range entries {
+		if path == "total" {
+			continue
+		}
+		var metrics CoverageMetrics
+		if err := json.Unmarshal(raw, &metrics); err != nil {
+			continue
+		}
+		files[relativeCoveragePath(projectPath, projectPath, path)] = metrics
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	return files
+}
+
 func parseCoverageDetail(data map[string]interface{}) CoverageDetail {
 	detail := CoverageDetail{}
 	if v, ok := data["total"].(float64); ok {
@@ -277,6 +381,32 @@ func (w *CoverageWatcher) GetCoverage(projectPath string) *CoverageSummary {
 	return w.projectCoverage[projectPath]
 }
 
+// GetFileCoverage returns the coverage of one file of a project, nil when the
+// latest report doesn't include it. file may be absolute or project-relative.
+// Reports without line data (Istanbul summaries) only give the file's totals.
+func (w *CoverageWatcher) GetFileCoverage(projectPath, file string) *FileCoverage {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	summary := w.projectCoverage[projectPath]
+	if summary == nil {
+		return nil
+	}
+	path := relativeCoveragePath(projectPath, projectPath, file)
+	if detail, ok := summary.files[path]; ok {
+		return detail
+	}
+	if metrics, ok := summary.ByFile[path]; ok {
+		return &FileCoverage{
+			Path:           path,
+			Metrics:        metrics,
+			Functions:      []FunctionCoverage{},
+			UncoveredLines: []LineRange{},
+		}
+	}
+	return nil
+}
+
 // GetHistory returns coverage history for a project
 func (w *CoverageWatcher) GetHistory(projectPath string) *CoverageHistory {
 	w.mu.RLock()
@@ -284,6 +414,20 @@ func (w *CoverageWatcher) GetHistory(projectPath string) *CoverageHistory {
 	return w.projectHistory[projectPath]
 }
 
+// GetLatestHistoryEntry returns a copy of the newest point in a project's
+// coverage history, or nil
+func (w *CoverageWatcher) GetLatestHistoryEntry(projectPath string) *CoverageHistoryEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	history := w.projectHistory[projectPath]
+	if history == nil || len(history.Entries) == 0 {
+		return nil
+	}
+	entry := history.Entries[len(history.Entries)-1]
+	return &entry
+}
+
 // GetAllCoverage returns coverage for all watched projects
 func (w *CoverageWatcher) GetAllCoverage() map[string]*CoverageSummary {
 	w.mu.RLock()
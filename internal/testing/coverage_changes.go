@@ -0,0 +1,95 @@
+package testing
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// ChangeCoverage is how much of a set of changed lines the latest coverage
+// report covers, e.g. the changes on a branch
+type ChangeCoverage struct {
+	Base     string               `json:"base,omitempty"` // What the changes are compared against
+	Lines    CoverageDetail       `json:"lines"`          // Changed lines that are instrumented, and how many ran
+	Files    []FileChangeCoverage `json:"files"`
+	LineData bool                 `json:"lineData"` // False when the report only has per-file totals
+	Format   string               `json:"format,omitempty"`
+}
+
+// FileChangeCoverage is the coverage of the changed lines of one file
+type FileChangeCoverage struct {
+	Path           string         `json:"path"`
+	ChangedLines   int            `json:"changedLines"`
+	Lines          CoverageDetail `json:"lines"`          // Changed lines that are instrumented
+	UncoveredLines []LineRange    `json:"uncoveredLines"` // Changed lines that never ran
+	FileLines      CoverageDetail `json:"fileLines"`      // Line coverage of the whole file
+	InReport       bool           `json:"inReport"`       // Source files the tests never loaded are missing
+}
+
+// GetChangeCoverage scores changed lines, given per project-relative file,
+// against the project's latest coverage report. Nil without a report.
+func (w *CoverageWatcher) GetChangeCoverage(projectPath string, changes map[string][]int) *ChangeCoverage {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	summary := w.projectCoverage[projectPath]
+	if summary == nil {
+		return nil
+	}
+	result := &ChangeCoverage{
+		Files:    []FileChangeCoverage{},
+		LineData: len(summary.files) > 0,
+		Format:   summary.Format,
+	}
+
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var total, covered int
+	for _, path := range paths {
+		lines := changes[path]
+		if len(lines) == 0 {
+			continue
+		}
+		path = filepath.ToSlash(path)
+		file := FileChangeCoverage{Path: path, ChangedLines: len(lines), UncoveredLines: []LineRange{}}
+
+		if detail, ok := summary.files[path]; ok {
+			file.InReport = true
+			file.FileLines = detail.Metrics.Lines
+			fileTotal, fileCovered := 0, 0
+			sorted := append([]int(nil), lines...)
+			sort.Ints(sorted)
+			for _, line := range sorted {
+				hits, instrumented := detail.lines[line]
+				if !instrumented {
+					continue
+				}
+				fileTotal++
+				if hits > 0 {
+					fileCovered++
+					continue
+				}
+				n := len(file.UncoveredLines)
+				if n > 0 && file.UncoveredLines[n-1].End == line-1 {
+					file.UncoveredLines[n-1].End = line
+				} else {
+					file.UncoveredLines = append(file.UncoveredLines, LineRange{Start: line, End: line})
+				}
+			}
+			file.Lines = newCoverageDetail(fileTotal, fileCovered)
+			total += fileTotal
+			covered += fileCovered
+		} else if metrics, ok := summary.ByFile[path]; ok {
+			file.InReport = true
+			file.FileLines = metrics.Lines
+		} else if isTestFile(filepath.Base(path)) || !isWatchedSource(path) {
+			continue
+		}
+		result.Files = append(result.Files, file)
+	}
+	result.Lines = newCoverageDetail(total, covered)
+	return result
+}
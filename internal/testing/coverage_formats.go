@@ -0,0 +1,485 @@
+package testing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coverage report formats CoverageWatcher reads
+const (
+	CoverageFormatIstanbul  = "istanbul"
+	CoverageFormatLcov      = "lcov"
+	CoverageFormatCobertura = "cobertura"
+	CoverageFormatGo        = "go"
+)
+
+// fileCoverage collects the hits of one source file while a report is parsed
+type fileCoverage struct {
+	lines             map[int]int // Line number -> hits
+	functions         map[string]*functionHits
+	branches          int
+	branchesCovered   int
+	statements        int
+	statementsCovered int
+}
+
+// functionHits collects the hits of one function
+type functionHits struct {
+	line    int
+	endLine int         // 0 when the report doesn't say where the function ends
+	hits    int         // Calls, or summed line hits
+	lines   map[int]int // The function's own lines, when the report lists them
+}
+
+func newFileCoverage() *fileCoverage {
+	return &fileCoverage{lines: make(map[int]int), functions: make(map[string]*functionHits)}
+}
+
+func (fc *fileCoverage) function(name string) *functionHits {
+	fn, ok := fc.functions[name]
+	if !ok {
+		fn = &functionHits{}
+		fc.functions[name] = fn
+	}
+	return fn
+}
+
+// coverageFiles maps project-relative paths to their coverage
+type coverageFiles map[string]*fileCoverage
+
+func (f coverageFiles) get(path string) *fileCoverage {
+	fc, ok := f[path]
+	if !ok {
+		fc = newFileCoverage()
+		f[path] = fc
+	}
+	return fc
+}
+
+// newCoverageDetail builds a metric with its percentage rounded like Istanbul's
+func newCoverageDetail(total, covered int) CoverageDetail {
+	detail := CoverageDetail{Total: total, Covered: covered}
+	if total > 0 {
+		detail.Pct = math.Round(float64(covered)/float64(total)*10000) / 100
+	}
+	return detail
+}
+
+// metrics turns collected hits into coverage metrics
+func (fc *fileCoverage) metrics() CoverageMetrics {
+	covered := 0
+	for _, hits := range fc.lines {
+		if hits > 0 {
+			covered++
+		}
+	}
+	functionsCovered := 0
+	for _, fn := range fc.functions {
+		if fn.hits > 0 {
+			functionsCovered++
+		}
+	}
+	return CoverageMetrics{
+		Lines:      newCoverageDetail(len(fc.lines), covered),
+		Statements: newCoverageDetail(fc.statements, fc.statementsCovered),
+		Functions:  newCoverageDetail(len(fc.functions), functionsCovered),
+		Branches:   newCoverageDetail(fc.branches, fc.branchesCovered),
+	}
+}
+
+// detail breaks a file's coverage down by function and uncovered lines
+func (fc *fileCoverage) detail(path string, metrics CoverageMetrics) *FileCoverage {
+	file := &FileCoverage{
+		Path:           path,
+		Metrics:        metrics,
+		Functions:      []FunctionCoverage{},
+		UncoveredLines: []LineRange{},
+		lines:          fc.lines,
+	}
+
+	lineNumbers := make([]int, 0, len(fc.lines))
+	for line := range fc.lines {
+		lineNumbers = append(lineNumbers, line)
+	}
+	sort.Ints(lineNumbers)
+
+	// Consecutive instrumented lines without hits form one range
+	for i, line := range lineNumbers {
+		if fc.lines[line] > 0 {
+			continue
+		}
+		n := len(file.UncoveredLines)
+		if n > 0 && i > 0 && file.UncoveredLines[n-1].End == lineNumbers[i-1] {
+			file.UncoveredLines[n-1].End = line
+		} else {
+			file.UncoveredLines = append(file.UncoveredLines, LineRange{Start: line, End: line})
+		}
+	}
+
+	names := make([]string, 0, len(fc.functions))
+	for name := range fc.functions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := fc.functions[names[i]], fc.functions[names[j]]
+		if a.line != b.line {
+			return a.line < b.line
+		}
+		return names[i] < names[j]
+	})
+	for i, name := range names {
+		fn := fc.functions[name]
+		lines := fn.lines
+		end := fn.endLine
+		if len(lines) == 0 {
+			// Without an end line, a function runs up to the next one
+			if end == 0 && len(lineNumbers) > 0 {
+				end = lineNumbers[len(lineNumbers)-1]
+				for _, next := range names[i+1:] {
+					if start := fc.functions[next].line; start > fn.line {
+						end = start - 1
+						break
+					}
+				}
+			}
+			lines = make(map[int]int)
+			for _, line := range lineNumbers {
+				if line >= fn.line && line <= end {
+					lines[line] = fc.lines[line]
+				}
+			}
+		}
+		covered := 0
+		for _, hits := range lines {
+			if hits > 0 {
+				covered++
+			}
+		}
+		file.Functions = append(file.Functions, FunctionCoverage{
+			Name:      name,
+			StartLine: fn.line,
+			EndLine:   end,
+			Hits:      fn.hits,
+			Lines:     newCoverageDetail(len(lines), covered),
+		})
+	}
+	return file
+}
+
+// buildCoverageSummary totals the files of a parsed report
+func buildCoverageSummary(projectPath, format string, files coverageFiles) (*CoverageSummary, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files in %s coverage report", format)
+	}
+	summary := &CoverageSummary{
+		ByFile:      make(map[string]CoverageMetrics),
+		LastUpdated: time.Now(),
+		ProjectPath: projectPath,
+		Format:      format,
+		files:       make(map[string]*FileCoverage),
+	}
+	var total [4][2]int // lines, statements, functions, branches: total, covered
+	for path, fc := range files {
+		m := fc.metrics()
+		summary.ByFile[path] = m
+		summary.files[path] = fc.detail(path, m)
+		for i, d := range []CoverageDetail{m.Lines, m.Statements, m.Functions, m.Branches} {
+			total[i][0] += d.Total
+			total[i][1] += d.Covered
+		}
+	}
+	summary.Total = CoverageMetrics{
+		Lines:      newCoverageDetail(total[0][0], total[0][1]),
+		Statements: newCoverageDetail(total[1][0], total[1][1]),
+		Functions:  newCoverageDetail(total[2][0], total[2][1]),
+		Branches:   newCoverageDetail(total[3][0], total[3][1]),
+	}
+	return summary, nil
+}
+
+// relativeCoveragePath turns a report's file path into a project-relative one
+func relativeCoveragePath(projectPath, base, path string) string {
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	if rel, err := filepath.Rel(projectPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// parseLcov parses an lcov tracefile (lcov.info)
+func parseLcov(data []byte, projectPath string) (*CoverageSummary, error) {
+	files := make(coverageFiles)
+	var current *fileCoverage
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		switch key {
+		case "SF":
+			current = files.get(relativeCoveragePath(projectPath, projectPath, value))
+		case "end_of_record":
+			current = nil
+		}
+		if current == nil {
+			continue
+		}
+		fields := strings.Split(value, ",")
+		switch key {
+		case "DA": // line,hits[,checksum]
+			if len(fields) >= 2 {
+				line, _ := strconv.Atoi(fields[0])
+				hits, _ := strconv.Atoi(fields[1])
+				current.lines[line] += hits
+			}
+		case "FN": // line,name or, since lcov 2.0, line,end line,name
+			if len(fields) >= 2 {
+				line, _ := strconv.Atoi(fields[0])
+				endLine := 0
+				if len(fields) >= 3 {
+					if end, err := strconv.Atoi(fields[1]); err == nil {
+						endLine = end
+						fields = append(fields[:1], fields[2:]...)
+					}
+				}
+				fn := current.function(strings.Join(fields[1:], ","))
+				fn.line, fn.endLine = line, endLine
+			}
+		case "FNDA": // hits,name
+			if len(fields) >= 2 {
+				hits, _ := strconv.Atoi(fields[0])
+				current.function(strings.Join(fields[1:], ",")).hits += hits
+			}
+		case "BRDA": // line,block,branch,taken ("-" when never reached)
+			if len(fields) == 4 {
+				current.branches++
+				if taken, err := strconv.Atoi(fields[3]); err == nil && taken > 0 {
+					current.branchesCovered++
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return buildCoverageSummary(projectPath, CoverageFormatLcov, files)
+}
+
+// coberturaReport is the subset of a Cobertura XML report that is read
+type coberturaReport struct {
+	XMLName  xml.Name `xml:"coverage"`
+	Sources  []string `xml:"sources>source"`
+	Packages []struct {
+		Classes []struct {
+			Filename string `xml:"filename,attr"`
+			Methods  []struct {
+				Name      string          `xml:"name,attr"`
+				Signature string          `xml:"signature,attr"`
+				Lines     []coberturaLine `xml:"lines>line"`
+			} `xml:"methods>method"`
+			Lines []coberturaLine `xml:"lines>line"`
+		} `xml:"classes>class"`
+	} `xml:"packages>package"`
+}
+
+type coberturaLine struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr"` // e.g. "50% (1/2)"
+}
+
+var conditionCoveragePattern = regexp.MustCompile(`\((\d+)/(\d+)\)`)
+
+// parseCobertura parses a Cobertura XML report (coverage.py, Istanbul, tarpaulin, JaCoCo converters)
+func parseCobertura(data []byte, projectPath string) (*CoverageSummary, error) {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	// File names are relative to the first source inside the project
+	base := projectPath
+	for _, source := range report.Sources {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(projectPath, source)
+		}
+		base = source
+		break
+	}
+
+	files := make(coverageFiles)
+	for _, pkg := range report.Packages {
+		for _, class := range pkg.Classes {
+			fc := files.get(relativeCoveragePath(projectPath, base, class.Filename))
+			for _, line := range class.Lines {
+				if hits, seen := fc.lines[line.Number]; !seen || line.Hits > hits {
+					fc.lines[line.Number] = line.Hits
+				}
+				if !line.Branch {
+					continue
+				}
+				if match := conditionCoveragePattern.FindStringSubmatch(line.ConditionCoverage); match != nil {
+					covered, _ := strconv.Atoi(match[1])
+					total, _ := strconv.Atoi(match[2])
+					fc.branches += total
+					fc.branchesCovered += covered
+				}
+			}
+			for _, method := range class.Methods {
+				fn := fc.function(method.Name + method.Signature)
+				if fn.lines == nil {
+					fn.lines = make(map[int]int)
+				}
+				for _, line := range method.Lines {
+					fn.hits += line.Hits
+					fn.lines[line.Number] += line.Hits
+					if fn.line == 0 || line.Number < fn.line {
+						fn.line = line.Number
+					}
+					if line.Number > fn.endLine {
+						fn.endLine = line.Number
+					}
+				}
+			}
+		}
+	}
+	return buildCoverageSummary(projectPath, CoverageFormatCobertura, files)
+}
+
+// goModulePath reads the module path from a project's go.mod
+func goModulePath(projectPath string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+	return ""
+}
+
+// goCoverBlockPattern matches "file.go:12.34,15.2 3 1" coverprofile lines
+var goCoverBlockPattern = regexp.MustCompile(`^(.+\.go):(\d+)\.\d+,(\d+)\.\d+ (\d+) (\d+)$`)
+
+// parseGoCoverProfile parses a `go test -coverprofile` file. Percentages are
+// statement based like `go tool cover`; lines are the lines blocks span.
+func parseGoCoverProfile(data []byte, projectPath string) (*CoverageSummary, error) {
+	if !bytes.HasPrefix(data, []byte("mode:")) {
+		return nil, fmt.Errorf("not a Go coverage profile")
+	}
+	modulePrefix := ""
+	if module := goModulePath(projectPath); module != "" {
+		modulePrefix = module + "/"
+	}
+
+	// Profiles merged from several packages can list a block more than once
+	type block struct {
+		statements, hits int
+	}
+	blocks := make(map[string]map[string]block)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	files := make(coverageFiles)
+	for scanner.Scan() {
+		match := goCoverBlockPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		path := strings.TrimPrefix(match[1], modulePrefix)
+		if filepath.IsAbs(path) {
+			path = relativeCoveragePath(projectPath, projectPath, path)
+		}
+		start, _ := strconv.Atoi(match[2])
+		end, _ := strconv.Atoi(match[3])
+		statements, _ := strconv.Atoi(match[4])
+		hits, _ := strconv.Atoi(match[5])
+
+		fc := files.get(path)
+		for line := start; line <= end; line++ {
+			if prev, seen := fc.lines[line]; !seen || hits > prev {
+				fc.lines[line] = hits
+			}
+		}
+		if blocks[path] == nil {
+			blocks[path] = make(map[string]block)
+		}
+		key := strings.Fields(match[0])[0] // file.go:start.col,end.col
+		if prev, seen := blocks[path][key]; !seen || hits > prev.hits {
+			blocks[path][key] = block{statements: statements, hits: hits}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for path, fileBlocks := range blocks {
+		fc := files[path]
+		for _, b := range fileBlocks {
+			fc.statements += b.statements
+			if b.hits > 0 {
+				fc.statementsCovered += b.statements
+			}
+		}
+		addGoFunctions(fc, filepath.Join(projectPath, filepath.FromSlash(path)))
+	}
+	return buildCoverageSummary(projectPath, CoverageFormatGo, files)
+}
+
+// addGoFunctions adds the functions of a Go source file, which coverprofiles
+// don't list, with the hits of the lines they span
+func addGoFunctions(fc *fileCoverage, sourcePath string) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, sourcePath, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if index, ok := recv.(*ast.IndexExpr); ok {
+				recv = index.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				name = ident.Name + "." + name
+			}
+		}
+		hits := fc.function(name)
+		hits.line = fset.Position(fn.Pos()).Line
+		hits.endLine = fset.Position(fn.End()).Line
+		for line := hits.line; line <= hits.endLine; line++ {
+			hits.hits += fc.lines[line]
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	gotesting "testing"
+)
+
+func TestCoverageFormats(t *gotesting.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		format    string
+		byFile    string
+		lines     CoverageDetail
+		functions CoverageDetail
+		branches  CoverageDetail
+	}{
+		{
+			name: "lcov",
+			file: "coverage/lcov.info",
+			content: "TN:\nSF:src/math.js\nFN:1,add\nFN:5,divide\nFNDA:3,add\nFNDA:0,divide\n" +
+				"DA:1,3\nDA:2,3\nDA:5,0\nDA:6,0\nBRDA:6,0,0,0\nBRDA:6,0,1,-\nLF:4\nLH:2\nend_of_record\n",
+			format:    CoverageFormatLcov,
+			byFile:    "src/math.js",
+			lines:     CoverageDetail{Total: 4, Covered: 2, Pct: 50},
+			functions: CoverageDetail{Total: 2, Covered: 1, Pct: 50},
+			branches:  CoverageDetail{Total: 2, Covered: 0, Pct: 0},
+		},
+		{
+			name: "cobertura",
+			file: "coverage.xml",
+			content: `<?xml version="1.0" ?>
+<coverage line-rate="0.75" branch-rate="0.5" version="7.4">
+	<sources><source>src</source></sources>
+	<packages><package name="app"><classes>
+		<class name="calc.py" filename="app/calc.py">
+			<methods><method name="add" signature="(a, b)"><lines><line number="2" hits="1"/></lines></method></methods>
+			<lines>
+				<line number="1" hits="1"/>
+				<line number="2" hits="1"/>
+				<line number="4" hits="1" branch="true" condition-coverage="50% (1/2)"/>
+				<line number="5" hits="0"/>
+			</lines>
+		</class>
+	</classes></package></packages>
+</coverage>`,
+			format:    CoverageFormatCobertura,
+			byFile:    "src/app/calc.py",
+			lines:     CoverageDetail{Total: 4, Covered: 3, Pct: 75},
+			functions: CoverageDetail{Total: 1, Covered: 1, Pct: 100},
+			branches:  CoverageDetail{Total: 2, Covered: 1, Pct: 50},
+		},
+		{
+			name: "go coverprofile",
+			file: "coverage.out",
+			content: "mode: set\n" +
+				"example.com/demo/calc/calc.go:3.24,5.2 1 1\n" +
+				"example.com/demo/calc/calc.go:7.27,8.12 1 1\n" +
+				"example.com/demo/calc/calc.go:8.12,10.3 2 0\n" +
+				"example.com/demo/calc/calc.go:3.24,5.2 1 0\n",
+			format: CoverageFormatGo,
+			byFile: "calc/calc.go",
+			lines:  CoverageDetail{Total: 7, Covered: 5, Pct: 71.43},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *gotesting.T) {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644)
+			path := filepath.Join(dir, filepath.FromSlash(tt.file))
+			os.MkdirAll(filepath.Dir(path), 0755)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			summary, err := NewCoverageWatcher().parseCoverageFile(path, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.Format != tt.format {
+				t.Errorf("format = %q, want %q", summary.Format, tt.format)
+			}
+			if _, ok := summary.ByFile[tt.byFile]; !ok || len(summary.ByFile) != 1 {
+				t.Errorf("files = %v, want only %s", summary.ByFile, tt.byFile)
+			}
+			if summary.Total.Lines != tt.lines {
+				t.Errorf("lines = %+v, want %+v", summary.Total.Lines, tt.lines)
+			}
+			if summary.Total.Functions != tt.functions {
+				t.Errorf("functions = %+v, want %+v", summary.Total.Functions, tt.functions)
+			}
+			if summary.Total.Branches != tt.branches {
+				t.Errorf("branches = %+v, want %+v", summary.Total.Branches, tt.branches)
+			}
+		})
+	}
+}
+
+func TestGetFileCoverage(t *gotesting.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "calc.go"), []byte(`package demo
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func Div(a, b int) int {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "coverage.out"), []byte("mode: set\n"+
+		"example.com/demo/calc.go:3.24,5.2 1 1\n"+
+		"example.com/demo/calc.go:7.24,8.12 1 0\n"+
+		"example.com/demo/calc.go:8.12,10.3 1 0\n"+
+		"example.com/demo/calc.go:11.2,11.14 1 0\n"), 0644)
+
+	w := NewCoverageWatcher()
+	w.WatchProject(dir)
+	file := w.GetFileCoverage(dir, filepath.Join(dir, "calc.go"))
+	if file == nil {
+		t.Fatal("no coverage for calc.go")
+	}
+	if len(file.UncoveredLines) != 1 || file.UncoveredLines[0] != (LineRange{Start: 7, End: 11}) {
+		t.Errorf("uncovered lines = %v, want [{7 11}]", file.UncoveredLines)
+	}
+	want := []FunctionCoverage{
+		{Name: "Add", StartLine: 3, EndLine: 5, Hits: 3, Lines: CoverageDetail{Total: 3, Covered: 3, Pct: 100}},
+		{Name: "Div", StartLine: 7, EndLine: 12, Hits: 0, Lines: CoverageDetail{Total: 5, Covered: 0, Pct: 0}},
+	}
+	if len(file.Functions) != len(want) {
+		t.Fatalf("functions = %+v, want %+v", file.Functions, want)
+	}
+	for i := range want {
+		if file.Functions[i] != want[i] {
+			t.Errorf("function %d = %+v, want %+v", i, file.Functions[i], want[i])
+		}
+	}
+	if w.GetFileCoverage(dir, "other.go") != nil {
+		t.Error("expected no coverage for a file outside the report")
+	}
+}
+
+func TestGetChangeCoverage(t *gotesting.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/demo\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "coverage.out"), []byte("mode: set\n"+
+		"example.com/demo/calc.go:3.24,5.2 1 1\n"+
+		"example.com/demo/calc.go:7.24,8.12 1 0\n"+
+		"example.com/demo/calc.go:8.12,10.3 1 0\n"), 0644)
+
+	w := NewCoverageWatcher()
+	w.WatchProject(dir)
+	result := w.GetChangeCoverage(dir, map[string][]int{
+		"calc.go":  {4, 8, 9, 13}, // 13 isn't instrumented
+		"new.go":   {1, 2},
+		"notes.md": {1},
+	})
+	if result == nil {
+		t.Fatal("no change coverage")
+	}
+	if result.Lines.Total != 3 || result.Lines.Covered != 1 {
+		t.Errorf("changed lines = %+v, want 1 of 3 covered", result.Lines)
+	}
+	if len(result.Files) != 2 {
+		t.Fatalf("files = %+v, want calc.go and new.go", result.Files)
+	}
+	calc, added := result.Files[0], result.Files[1]
+	if calc.Path != "calc.go" || !calc.InReport || len(calc.UncoveredLines) != 1 || calc.UncoveredLines[0] != (LineRange{Start: 8, End: 9}) {
+		t.Errorf("calc.go = %+v, want uncovered lines 8-9", calc)
+	}
+	if added.Path != "new.go" || added.InReport || added.Lines.Total != 0 {
+		t.Errorf("new.go = %+v, want it listed outside the report", added)
+	}
+}
@@ -0,0 +1,120 @@
+package testing
+
+import (
+	"fmt"
+	"time"
+)
+
+// CoverageThresholds are the coverage percentages a project has to keep.
+// Zero leaves a metric unchecked.
+type CoverageThresholds struct {
+	Lines      float64 `json:"lines"`
+	Statements float64 `json:"statements"`
+	Functions  float64 `json:"functions"`
+	Branches   float64 `json:"branches"`
+	MaxDrop    float64 `json:"maxDrop"` // Points a metric may fall from the previous report
+}
+
+// CoverageGate is how a project's latest coverage report compares with its
+// thresholds and with the report before it
+type CoverageGate struct {
+	ProjectPath string              `json:"projectPath"`
+	Passed      bool                `json:"passed"`
+	Violations  []CoverageViolation `json:"violations"`
+	CheckedAt   time.Time           `json:"checkedAt"`
+}
+
+// CoverageViolation is a metric below its threshold or fallen too far
+type CoverageViolation struct {
+	Metric    string  `json:"metric"` // lines, statements, functions or branches
+	Actual    float64 `json:"actual"`
+	Threshold float64 `json:"threshold,omitempty"` // Set when below the threshold
+	Previous  float64 `json:"previous,omitempty"`  // Set when fallen from the previous report
+	Message   string  `json:"message"`
+}
+
+// CheckCoverageGate compares a coverage report with thresholds and with the
+// previous report, if there is one
+func CheckCoverageGate(summary *CoverageSummary, previous *CoverageHistoryEntry, thresholds CoverageThresholds) *CoverageGate {
+	gate := &CoverageGate{
+		ProjectPath: summary.ProjectPath,
+		Violations:  []CoverageViolation{},
+		CheckedAt:   time.Now(),
+	}
+
+	minimums := []struct {
+		metric    string
+		actual    float64
+		threshold float64
+	}{
+		{"lines", summary.Total.Lines.Pct, thresholds.Lines},
+		{"statements", summary.Total.Statements.Pct, thresholds.Statements},
+		{"functions", summary.Total.Functions.Pct, thresholds.Functions},
+		{"branches", summary.Total.Branches.Pct, thresholds.Branches},
+	}
+	for _, m := range minimums {
+		// Reports without a metric (Go profiles have no branches) leave it at zero
+		if m.threshold <= 0 || !hasMetric(summary, m.metric) || m.actual >= m.threshold {
+			continue
+		}
+		gate.Violations = append(gate.Violations, CoverageViolation{
+			Metric:    m.metric,
+			Actual:    m.actual,
+			Threshold: m.threshold,
+			Message:   fmt.Sprintf("%s coverage %.1f%% is below %.1f%%", m.metric, m.actual, m.threshold),
+		})
+	}
+
+	if previous != nil && thresholds.MaxDrop > 0 {
+		drops := []struct {
+			metric           string
+			actual, previous float64
+		}{
+			{"lines", summary.Total.Lines.Pct, previous.Lines},
+			{"functions", summary.Total.Functions.Pct, previous.Functions},
+			{"branches", summary.Total.Branches.Pct, previous.Branches},
+		}
+		for _, d := range drops {
+			if !hasMetric(summary, d.metric) || d.previous-d.actual <= thresholds.MaxDrop {
+				continue
+			}
+			gate.Violations = append(gate.Violations, CoverageViolation{
+				Metric:   d.metric,
+				Actual:   d.actual,
+				Previous: d.previous,
+				Message:  fmt.Sprintf("%s coverage fell from %.1f%% to %.1f%%", d.metric, d.previous, d.actual),
+			})
+		}
+	}
+
+	gate.Passed = len(gate.Violations) == 0
+	return gate
+}
+
+func hasMetric(summary *CoverageSummary, metric string) bool {
+	switch metric {
+	case "lines":
+		return summary.Total.Lines.Total > 0
+	case "statements":
+		return summary.Total.Statements.Total > 0
+	case "functions":
+		return summary.Total.Functions.Total > 0
+	case "branches":
+		return summary.Total.Branches.Total > 0
+	}
+	return false
+}
+
+// GetPreviousCoverage returns the history entry before the latest report,
+// or nil when there is only one
+func (w *CoverageWatcher) GetPreviousCoverage(projectPath string) *CoverageHistoryEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	history := w.projectHistory[projectPath]
+	if history == nil || len(history.Entries) < 2 {
+		return nil
+	}
+	entry := history.Entries[len(history.Entries)-2]
+	return &entry
+}
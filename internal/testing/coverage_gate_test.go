@@ -0,0 +1,25 @@
+package testing
+
+import gotesting "testing"
+
+func TestCheckCoverageGate(t *gotesting.T) {
+	summary := &CoverageSummary{ProjectPath: "/p", Total: CoverageMetrics{
+		Lines:     CoverageDetail{Total: 100, Covered: 70, Pct: 70},
+		Functions: CoverageDetail{Total: 10, Covered: 9, Pct: 90},
+	}}
+
+	gate := CheckCoverageGate(summary, nil, CoverageThresholds{Lines: 80, Functions: 80, Branches: 50})
+	if gate.Passed || len(gate.Violations) != 1 || gate.Violations[0].Metric != "lines" {
+		t.Errorf("below threshold: %+v", gate)
+	}
+
+	previous := &CoverageHistoryEntry{Lines: 75, Functions: 91}
+	gate = CheckCoverageGate(summary, previous, CoverageThresholds{Lines: 60, MaxDrop: 2})
+	if gate.Passed || len(gate.Violations) != 1 || gate.Violations[0].Previous != 75 {
+		t.Errorf("drop: %+v", gate)
+	}
+
+	if gate := CheckCoverageGate(summary, previous, CoverageThresholds{Lines: 60, MaxDrop: 5}); !gate.Passed {
+		t.Errorf("within limits: %+v", gate)
+	}
+}
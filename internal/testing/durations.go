@@ -0,0 +1,85 @@
+package testing
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	// Go: "--- PASS: TestAdd (0.12s)", subtests indented
+	goDurationPattern = regexp.MustCompile(`(?m)^\s*--- (PASS|FAIL|SKIP): (\S+) \(([\d.]+)s\)`)
+	// Vitest, Jest and Playwright list reporters: "✓ adds (3 ms)", "✓ src/a.test.ts > adds 12ms",
+	// "✓  1 [chromium] › example.spec.ts:3:5 › has title (1.2s)"
+	tickDurationPattern = regexp.MustCompile(`(?m)^\s*([✓✔√×✕✗✘])\s+(.+?)\s+\(?(\d+(?:\.\d+)?)\s?(ms|s)\)?\s*$`)
+	// Vitest file lines: "src/a.test.ts (3 tests) 5ms"
+	vitestFileLinePattern = regexp.MustCompile(`\(\d+ tests?(?: \| \d+ \w+)*\)$`)
+	// Playwright prefixes each test with its index
+	playwrightIndexPattern = regexp.MustCompile(`^\d+\s+`)
+)
+
+// parseTestDurations returns the tests with a printed duration in output of
+// the built-in runners
+func parseTestDurations(runner TestRunner, text string) []TestResult {
+	var results []TestResult
+	switch runner {
+	case RunnerGo:
+		for _, match := range goDurationPattern.FindAllStringSubmatch(text, -1) {
+			seconds, _ := strconv.ParseFloat(match[3], 64)
+			results = append(results, TestResult{
+				Name:     match[2],
+				Status:   goDurationStatus(match[1]),
+				Duration: seconds * 1000,
+			})
+		}
+	case RunnerVitest, RunnerJest, RunnerPlaywright:
+		for _, match := range tickDurationPattern.FindAllStringSubmatch(text, -1) {
+			name := match[2]
+			if vitestFileLinePattern.MatchString(name) {
+				continue
+			}
+			if runner == RunnerPlaywright {
+				name = playwrightIndexPattern.ReplaceAllString(name, "")
+			}
+			status := StatusPassed
+			if match[1] != "✓" && match[1] != "✔" && match[1] != "√" {
+				status = StatusFailed
+			}
+			duration, _ := strconv.ParseFloat(match[3], 64)
+			if match[4] == "s" {
+				duration *= 1000
+			}
+			results = append(results, TestResult{Name: name, Status: status, Duration: duration})
+		}
+	}
+	return results
+}
+
+func goDurationStatus(word string) TestStatus {
+	switch word {
+	case "PASS":
+		return StatusPassed
+	case "FAIL":
+		return StatusFailed
+	}
+	return StatusNone
+}
+
+// mergeTestDurations adds found to results, replacing tests seen before
+func mergeTestDurations(results, found []TestResult) []TestResult {
+	if len(found) == 0 {
+		return results
+	}
+	index := make(map[string]int, len(results))
+	for i, test := range results {
+		index[test.Name] = i
+	}
+	for _, test := range found {
+		if i, ok := index[test.Name]; ok {
+			results[i] = test
+			continue
+		}
+		index[test.Name] = len(results)
+		results = append(results, test)
+	}
+	return results
+}
@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"strings"
+	gotesting "testing"
+)
+
+func TestTestDurations(t *gotesting.T) {
+	tests := []struct {
+		name      string
+		runner    TestRunner
+		output    string
+		durations map[string]float64
+	}{
+		{
+			name:   "go test -v",
+			runner: RunnerGo,
+			output: "=== RUN   TestAdd\n--- PASS: TestAdd (0.12s)\n=== RUN   TestSlow\n" +
+				"=== RUN   TestSlow/case\n    --- PASS: TestSlow/case (1.50s)\n--- FAIL: TestSlow (1.50s)\nFAIL\n",
+			durations: map[string]float64{"TestAdd": 120, "TestSlow/case": 1500, "TestSlow": 1500},
+		},
+		{
+			name:   "jest verbose",
+			runner: RunnerJest,
+			output: "PASS src/math.test.js\n  math\n    ✓ adds (3 ms)\n    ✕ divides (12 ms)\n" +
+				"Tests:       1 failed, 1 passed, 2 total\n",
+			durations: map[string]float64{"adds": 3, "divides": 12},
+		},
+		{
+			name:   "playwright list",
+			runner: RunnerPlaywright,
+			output: "Running 2 tests using 1 worker\n\n" +
+				"  ✓  1 [chromium] › example.spec.ts:3:5 › has title (1.2s)\n" +
+				"  ✓  2 [chromium] › example.spec.ts:8:5 › get started link (850ms)\n\n  2 passed (3.1s)\n",
+			durations: map[string]float64{
+				"[chromium] › example.spec.ts:3:5 › has title":        1200,
+				"[chromium] › example.spec.ts:8:5 › get started link": 850,
+			},
+		},
+		{
+			name:   "node --test TAP",
+			runner: RunnerTAP,
+			output: "TAP version 13\n# Subtest: adds\n    ok 1 - inner\n      ---\n      duration_ms: 0.1\n      ...\n" +
+				"ok 1 - adds\n  ---\n  duration_ms: 0.5\n  ...\nok 2 - waits\n  ---\n  duration_ms: 250.25\n  ...\n" +
+				"1..2\n# pass 2\n# fail 0\n",
+			durations: map[string]float64{"adds": 0.5, "waits": 250.25},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *gotesting.T) {
+			w := NewWatcher()
+			w.terminalStates["term"] = &TerminalTestState{Summary: &TestSummary{Runner: tt.runner, Status: StatusNone}}
+
+			var summary *TestSummary
+			for _, line := range strings.SplitAfter(tt.output, "\n") {
+				summary, _ = w.Analyze("term", []byte(line))
+			}
+
+			got := make(map[string]float64)
+			for _, test := range summary.TestDurations {
+				got[test.Name] = test.Duration
+			}
+			if len(got) != len(tt.durations) {
+				t.Fatalf("durations = %v, want %v", got, tt.durations)
+			}
+			for name, want := range tt.durations {
+				if d, ok := got[name]; !ok || d < want-0.001 || d > want+0.001 {
+					t.Errorf("duration of %q = %v, want %v", name, d, want)
+				}
+			}
+		})
+	}
+}
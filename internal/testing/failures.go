@@ -0,0 +1,425 @@
+package testing
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TestFailure locates a failed test's assertion in the source, for jump-to-source
+type TestFailure struct {
+	Test     string `json:"test"`
+	File     string `json:"file"` // As printed: relative to the run's directory, or absolute
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+var (
+	// Go: "    calc_test.go:12: got 3, want 2"
+	goRunPattern      = regexp.MustCompile(`^=== (?:RUN|CONT)\s+(\S+)`)
+	goResultPattern   = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+)`)
+	goLocationPattern = regexp.MustCompile(`^\s+(\S+\.go):(\d+): ?(.*)$`)
+	// testify's assertion details
+	testifyErrorPattern    = regexp.MustCompile(`^\s+Error:\s+(.+)$`)
+	testifyExpectedPattern = regexp.MustCompile(`^\s+expected\s*: (.*)$`)
+	testifyActualPattern   = regexp.MustCompile(`^\s+actual\s*: (.*)$`)
+
+	// Jest: "  ● math › divides"
+	jestFailureHeader = regexp.MustCompile(`(?m)^\s*● (.+)$`)
+	// Vitest: " FAIL  src/math.test.ts > math > divides"
+	vitestFailureHeader = regexp.MustCompile(`(?m)^\s*FAIL\s+(\S+ > .+)$`)
+	// Playwright: "  1) [chromium] › example.spec.ts:3:5 › has title ────"
+	playwrightFailureHeader = regexp.MustCompile(`(?m)^\s*\d+\) (\[.+?\] › .+?)\s*─*\s*$`)
+	// Stack frames: "at Object.<anonymous> (src/math.test.js:11:26)", "❯ src/math.test.ts:11:26"
+	jsFramePattern = regexp.MustCompile(`(?m)^\s*(?:at |❯ )(?:.*? \()?((?:[A-Za-z]:)?[^\s():]+):(\d+):(\d+)\)?\s*$`)
+	// "Expected: 2" / "Received: 3", "Expected pattern: /x/" / "Received string: "y""
+	jsExpectedPattern = regexp.MustCompile(`(?m)^\s*Expected(?: \w+)?: +(.+)$`)
+	jsReceivedPattern = regexp.MustCompile(`(?m)^\s*Received(?: \w+)?: +(.+)$`)
+	// Diffs follow a "- Expected" / "+ Received" legend
+	jsDiffLegendPattern = regexp.MustCompile(`(?m)^\s*\+ Received`)
+	jsDiffLinePattern   = regexp.MustCompile(`^\s*([-+]) (.*)$`)
+
+	// pytest: "____ test_divide ____", "tests/test_math.py:5: AssertionError"
+	pytestFailureHeader   = regexp.MustCompile(`(?m)^_{3,} (\S.*?) _{3,}$`)
+	pytestLocationPattern = regexp.MustCompile(`(?m)^(\S+\.py):(\d+): \w+`)
+	pytestErrorPattern    = regexp.MustCompile(`(?m)^E\s+(.+)$`)
+	pytestComparePattern  = regexp.MustCompile(`(?m)^E\s+assert (.+?) == (.+)$`)
+
+	// cargo: "---- tests::divides stdout ----", "panicked at src/lib.rs:12:9:"
+	cargoFailureHeader   = regexp.MustCompile(`(?m)^---- (\S+) stdout ----$`)
+	cargoLocationPattern = regexp.MustCompile(`([^\s:'"]+\.rs):(\d+):(\d+)`)
+	cargoMessagePattern  = regexp.MustCompile(`panicked at '([^'\n]*)|panicked at \S+:\n(.+)`)
+	cargoLeftPattern     = regexp.MustCompile("(?m)^\\s*left: `?(.*?)`?,?$")
+	cargoRightPattern    = regexp.MustCompile("(?m)^\\s*right: `?(.*?)`?,?$")
+
+	// TAP (node --test) YAML diagnostics of a failed top-level test
+	tapFailureHeader   = regexp.MustCompile(`(?m)^not ok \d+(?: -)? ?([^#\n]*)$`)
+	tapLocationPattern = regexp.MustCompile(`(?m)^  location: '(.+):(\d+):(\d+)'$`)
+	tapErrorPattern    = regexp.MustCompile(`(?m)^  error: '?(.*?)'?$`)
+	tapExpectedPattern = regexp.MustCompile(`(?m)^  expected: '?(.*?)'?$`)
+	tapActualPattern   = regexp.MustCompile(`(?m)^  actual: '?(.*?)'?$`)
+	tapNextTestPattern = regexp.MustCompile(`(?m)^(?:not )?ok \d+`)
+
+	// JUnit: "at com.example.CalcTest.testDivide(CalcTest.java:12)"
+	junitFramePattern   = regexp.MustCompile(`(?m)^\s*at ([\w.$<>]+)\((\w+\.(?:java|kt|scala|groovy)):(\d+)\)`)
+	junitComparePattern = regexp.MustCompile(`expected: ?<(.*?)> but was: ?<(.*?)>`)
+)
+
+// parseFailures returns where the failed tests in output of the built-in
+// runners failed
+func parseFailures(runner TestRunner, text string) []TestFailure {
+	switch runner {
+	case RunnerGo:
+		return goFailures(text)
+	case RunnerJest:
+		return jsFailures(text, jestFailureHeader)
+	case RunnerVitest:
+		return jsFailures(text, vitestFailureHeader)
+	case RunnerPlaywright:
+		return jsFailures(text, playwrightFailureHeader)
+	}
+	return nil
+}
+
+// goFailures reads the file:line lines go test prints for failed tests. In
+// verbose mode they come before "--- FAIL", otherwise after it.
+func goFailures(text string) []TestFailure {
+	located := make(map[string]*TestFailure)
+	var order []string
+	failed := make(map[string]bool)
+	current := ""
+	for _, line := range strings.Split(text, "\n") {
+		if match := goRunPattern.FindStringSubmatch(line); match != nil {
+			current = match[1]
+			continue
+		}
+		if match := goResultPattern.FindStringSubmatch(line); match != nil {
+			current = match[2]
+			if match[1] == "FAIL" {
+				failed[current] = true
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		if match := goLocationPattern.FindStringSubmatch(line); match != nil {
+			if _, ok := located[current]; !ok {
+				lineNumber, _ := strconv.Atoi(match[2])
+				located[current] = &TestFailure{Test: current, File: match[1], Line: lineNumber, Message: strings.TrimSpace(match[3])}
+				order = append(order, current)
+			}
+			continue
+		}
+		failure := located[current]
+		if failure == nil {
+			continue
+		}
+		if match := testifyErrorPattern.FindStringSubmatch(line); match != nil && failure.Message == "" {
+			failure.Message = strings.TrimSpace(match[1])
+		} else if match := testifyExpectedPattern.FindStringSubmatch(line); match != nil && failure.Expected == "" {
+			failure.Expected = strings.TrimSpace(match[1])
+		} else if match := testifyActualPattern.FindStringSubmatch(line); match != nil && failure.Actual == "" {
+			failure.Actual = strings.TrimSpace(match[1])
+		}
+	}
+
+	// Passing tests print locations too, through t.Log
+	var failures []TestFailure
+	for _, name := range order {
+		if failed[name] {
+			failures = append(failures, *located[name])
+		}
+	}
+	return failures
+}
+
+// jsFailures reads the failure reports Jest, Vitest and Playwright print
+// after the run: a header naming the test, the error, a diff and the stack
+func jsFailures(text string, header *regexp.Regexp) []TestFailure {
+	var failures []TestFailure
+	for _, block := range failureBlocks(text, header) {
+		failure := TestFailure{Test: block.name, Message: firstLine(block.body)}
+		for _, frame := range jsFramePattern.FindAllStringSubmatch(block.body, -1) {
+			if strings.Contains(frame[1], "node_modules") || strings.HasPrefix(frame[1], "node:") {
+				continue
+			}
+			failure.File = frame[1]
+			failure.Line, _ = strconv.Atoi(frame[2])
+			failure.Column, _ = strconv.Atoi(frame[3])
+			break
+		}
+		if failure.File == "" {
+			// Playwright names the test by its location
+			file, line, column, ok := headerLocation(block.name)
+			if !ok {
+				continue
+			}
+			failure.File, failure.Line, failure.Column = file, line, column
+		}
+
+		if match := jsExpectedPattern.FindStringSubmatch(block.body); match != nil {
+			failure.Expected = strings.TrimSpace(match[1])
+		}
+		if match := jsReceivedPattern.FindStringSubmatch(block.body); match != nil {
+			failure.Actual = strings.TrimSpace(match[1])
+		}
+		if failure.Expected == "" && failure.Actual == "" {
+			failure.Expected, failure.Actual = diffSides(block.body)
+		}
+		// Vitest headers start with the file; the test path follows
+		if parts := strings.SplitN(failure.Test, " > ", 2); len(parts) == 2 && header == vitestFailureHeader {
+			failure.Test = parts[1]
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+// headerLocation finds "file:line:column" in a Playwright test title
+var headerLocationPattern = regexp.MustCompile(`(\S+):(\d+):(\d+)`)
+
+func headerLocation(name string) (string, int, int, bool) {
+	match := headerLocationPattern.FindStringSubmatch(name)
+	if match == nil {
+		return "", 0, 0, false
+	}
+	line, _ := strconv.Atoi(match[2])
+	column, _ := strconv.Atoi(match[3])
+	return match[1], line, column, true
+}
+
+// diffSides splits the diff after a "- Expected / + Received" legend into
+// the expected and received lines
+func diffSides(body string) (expected, actual string) {
+	legend := jsDiffLegendPattern.FindStringIndex(body)
+	if legend == nil {
+		return "", ""
+	}
+	var minus, plus []string
+	started := false
+	for _, line := range strings.Split(body[legend[1]:], "\n")[1:] {
+		if strings.TrimSpace(line) == "" {
+			if started {
+				break
+			}
+			continue
+		}
+		match := jsDiffLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			if started {
+				break
+			}
+			continue
+		}
+		started = true
+		if match[1] == "-" {
+			minus = append(minus, match[2])
+		} else {
+			plus = append(plus, match[2])
+		}
+	}
+	return strings.Join(minus, "\n"), strings.Join(plus, "\n")
+}
+
+func (pytestParser) Failures(run string) []TestFailure {
+	var failures []TestFailure
+	for _, block := range failureBlocks(run, pytestFailureHeader) {
+		match := pytestLocationPattern.FindStringSubmatch(block.body)
+		if match == nil {
+			continue
+		}
+		failure := TestFailure{Test: block.name, File: match[1]}
+		failure.Line, _ = strconv.Atoi(match[2])
+		if match := pytestErrorPattern.FindStringSubmatch(block.body); match != nil {
+			failure.Message = strings.TrimSpace(match[1])
+		}
+		if match := pytestComparePattern.FindStringSubmatch(block.body); match != nil {
+			failure.Actual, failure.Expected = strings.TrimSpace(match[1]), strings.TrimSpace(match[2])
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func (cargoParser) Failures(run string) []TestFailure {
+	var failures []TestFailure
+	for _, block := range failureBlocks(run, cargoFailureHeader) {
+		match := cargoLocationPattern.FindStringSubmatch(block.body)
+		if match == nil {
+			continue
+		}
+		failure := TestFailure{Test: block.name, File: match[1]}
+		failure.Line, _ = strconv.Atoi(match[2])
+		failure.Column, _ = strconv.Atoi(match[3])
+		if match := cargoMessagePattern.FindStringSubmatch(block.body); match != nil {
+			failure.Message = strings.TrimSpace(match[1] + match[2])
+		}
+		// assert_eq!(actual, expected) is the usual order
+		if match := cargoLeftPattern.FindStringSubmatch(block.body); match != nil {
+			failure.Actual = match[1]
+		}
+		if match := cargoRightPattern.FindStringSubmatch(block.body); match != nil {
+			failure.Expected = match[1]
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func (tapParser) Failures(run string) []TestFailure {
+	var failures []TestFailure
+	for _, block := range failureBlocks(run, tapFailureHeader) {
+		body := block.body
+		if next := tapNextTestPattern.FindStringIndex(body); next != nil {
+			body = body[:next[0]]
+		}
+		match := tapLocationPattern.FindStringSubmatch(body)
+		if match == nil {
+			continue
+		}
+		failure := TestFailure{Test: strings.TrimSpace(block.name), File: match[1]}
+		failure.Line, _ = strconv.Atoi(match[2])
+		failure.Column, _ = strconv.Atoi(match[3])
+		if match := tapErrorPattern.FindStringSubmatch(body); match != nil {
+			failure.Message = match[1]
+		}
+		if match := tapExpectedPattern.FindStringSubmatch(body); match != nil {
+			failure.Expected = match[1]
+		}
+		if match := tapActualPattern.FindStringSubmatch(body); match != nil {
+			failure.Actual = match[1]
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func (junitParser) Failures(run string) []TestFailure {
+	var failures []TestFailure
+	for _, block := range failureBlocks(run, junitFailedPattern) {
+		failure := TestFailure{Test: block.name, Message: firstLine(block.body)}
+		for _, frame := range junitFramePattern.FindAllStringSubmatch(block.body, -1) {
+			if isFrameworkFrame(frame[1]) {
+				continue
+			}
+			failure.File = frame[2]
+			failure.Line, _ = strconv.Atoi(frame[3])
+			break
+		}
+		if failure.File == "" {
+			continue
+		}
+		if match := junitComparePattern.FindStringSubmatch(block.body); match != nil {
+			failure.Expected, failure.Actual = match[1], match[2]
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+// isFrameworkFrame reports whether a stack frame's method belongs to the JVM
+// or a test framework rather than the project
+func isFrameworkFrame(method string) bool {
+	for _, prefix := range []string{"org.junit.", "junit.", "org.opentest4j.", "org.assertj.", "org.hamcrest.",
+		"java.", "javax.", "jdk.", "sun.", "kotlin.", "org.apache.maven.", "org.gradle."} {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// failureBlock is the output between one failure header and the next
+type failureBlock struct {
+	name string
+	body string
+}
+
+func failureBlocks(text string, header *regexp.Regexp) []failureBlock {
+	matches := header.FindAllStringSubmatchIndex(text, -1)
+	blocks := make([]failureBlock, 0, len(matches))
+	for i, match := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		blocks = append(blocks, failureBlock{
+			name: strings.TrimSpace(text[match[2]:match[3]]),
+			body: text[match[1]:end],
+		})
+	}
+	return blocks
+}
+
+// firstLine returns the first non-blank line of text
+func firstLine(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// mergeFailures adds found to failures, skipping locations seen before
+func mergeFailures(failures, found []TestFailure) []TestFailure {
+	for _, failure := range found {
+		seen := false
+		for _, existing := range failures {
+			if existing.Test == failure.Test && existing.File == failure.File && existing.Line == failure.Line {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// ResolveFailureFile finds the file a failure location names. Runners print
+// paths relative to where they ran, which may be a subdirectory (Go prints
+// just the file name), so a missing path is looked up by its trailing
+// components below the project.
+func ResolveFailureFile(projectPath, file string) string {
+	file = filepath.FromSlash(file)
+	if filepath.IsAbs(file) {
+		return file
+	}
+	if candidate := filepath.Join(projectPath, file); fileExists(candidate) {
+		return candidate
+	}
+
+	suffix := string(filepath.Separator) + file
+	found := ""
+	filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != projectPath && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, suffix) {
+			found = path
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,117 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	gotesting "testing"
+)
+
+func TestFailureLocations(t *gotesting.T) {
+	tests := []struct {
+		name     string
+		failures func(string) []TestFailure
+		output   string
+		want     []TestFailure
+	}{
+		{
+			name:     "go test -v with testify",
+			failures: func(s string) []TestFailure { return parseFailures(RunnerGo, s) },
+			output: "=== RUN   TestAdd\n    calc_test.go:8: adding\n--- PASS: TestAdd (0.00s)\n" +
+				"=== RUN   TestDivide\n    calc_test.go:14: \n        \tError Trace:\t/src/calc_test.go:14\n" +
+				"        \tError:      \tNot equal: \n        \t            \texpected: 2\n" +
+				"        \t            \tactual  : 3\n--- FAIL: TestDivide (0.00s)\nFAIL\n",
+			want: []TestFailure{{Test: "TestDivide", File: "calc_test.go", Line: 14, Message: "Not equal:", Expected: "2", Actual: "3"}},
+		},
+		{
+			name:     "go test",
+			failures: func(s string) []TestFailure { return parseFailures(RunnerGo, s) },
+			output:   "--- FAIL: TestSub (0.00s)\n    calc_test.go:20: got 1, want 2\nFAIL\nFAIL\tdemo\t0.01s\n",
+			want:     []TestFailure{{Test: "TestSub", File: "calc_test.go", Line: 20, Message: "got 1, want 2"}},
+		},
+		{
+			name:     "jest",
+			failures: func(s string) []TestFailure { return parseFailures(RunnerJest, s) },
+			output: "  ● math › divides\n\n    expect(received).toBe(expected) // Object.is equality\n\n" +
+				"    Expected: 2\n    Received: 3\n\n    > 11 |     expect(divide(6, 2)).toBe(2);\n\n" +
+				"      at Object.toBe (src/math.test.js:11:26)\n\nTests:       1 failed, 1 passed, 2 total\n",
+			want: []TestFailure{{Test: "math › divides", File: "src/math.test.js", Line: 11, Column: 26,
+				Message: "expect(received).toBe(expected) // Object.is equality", Expected: "2", Actual: "3"}},
+		},
+		{
+			name:     "vitest diff",
+			failures: func(s string) []TestFailure { return parseFailures(RunnerVitest, s) },
+			output: " FAIL  src/math.test.ts > math > divides\nAssertionError: expected 3 to be 2 // Object.is equality\n\n" +
+				"- Expected\n+ Received\n\n- 2\n+ 3\n\n ❯ src/math.test.ts:11:26\n\n⎯⎯⎯⎯⎯⎯⎯[1/1]⎯\n",
+			want: []TestFailure{{Test: "math > divides", File: "src/math.test.ts", Line: 11, Column: 26,
+				Message: "AssertionError: expected 3 to be 2 // Object.is equality", Expected: "2", Actual: "3"}},
+		},
+		{
+			name:     "pytest",
+			failures: pytestParser{}.Failures,
+			output: "________________________ test_divide ________________________\n\n    def test_divide():\n" +
+				">       assert divide(6, 2) == 2\nE       assert 3.0 == 2\n\ntests/test_math.py:5: AssertionError\n",
+			want: []TestFailure{{Test: "test_divide", File: "tests/test_math.py", Line: 5, Message: "assert 3.0 == 2", Expected: "2", Actual: "3.0"}},
+		},
+		{
+			name:     "cargo test",
+			failures: cargoParser{}.Failures,
+			output: "---- tests::divides stdout ----\nthread 'tests::divides' panicked at src/lib.rs:12:9:\n" +
+				"assertion `left == right` failed\n  left: 3\n right: 2\n\nfailures:\n    tests::divides\n",
+			want: []TestFailure{{Test: "tests::divides", File: "src/lib.rs", Line: 12, Column: 9,
+				Message: "assertion `left == right` failed", Expected: "2", Actual: "3"}},
+		},
+		{
+			name:     "node --test TAP",
+			failures: tapParser{}.Failures,
+			output: "not ok 1 - divides\n  ---\n  duration_ms: 0.5\n  location: '/src/math.test.js:3:1'\n" +
+				"  failureType: 'testCodeFailure'\n  error: 'Expected values to be strictly equal'\n  expected: 2\n  actual: 3\n  ...\n" +
+				"ok 2 - adds\n",
+			want: []TestFailure{{Test: "divides", File: "/src/math.test.js", Line: 3, Column: 1,
+				Message: "Expected values to be strictly equal", Expected: "2", Actual: "3"}},
+		},
+		{
+			name:     "maven surefire",
+			failures: junitParser{}.Failures,
+			output: "[ERROR] testDivide(com.example.CalcTest)  Time elapsed: 0.01 s  <<< FAILURE!\n" +
+				"org.opentest4j.AssertionFailedError: expected: <2> but was: <3>\n" +
+				"\tat org.junit.jupiter.api.AssertEquals.failNotEqual(AssertEquals.java:197)\n" +
+				"\tat com.example.CalcTest.testDivide(CalcTest.java:12)\n",
+			want: []TestFailure{{Test: "testDivide(com.example.CalcTest)", File: "CalcTest.java", Line: 12,
+				Message: "org.opentest4j.AssertionFailedError: expected: <2> but was: <3>", Expected: "2", Actual: "3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *gotesting.T) {
+			got := tt.failures(tt.output)
+			if len(got) != len(tt.want) {
+				t.Fatalf("failures = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("failure %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolveFailureFile(t *gotesting.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pkg", "calc", "calc_test.go")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range []string{"pkg/calc/calc_test.go", "calc/calc_test.go", "calc_test.go"} {
+		if got := ResolveFailureFile(dir, file); got != path {
+			t.Errorf("ResolveFailureFile(%q) = %q, want %q", file, got, path)
+		}
+	}
+	if got := ResolveFailureFile(dir, "missing_test.go"); got != "" {
+		t.Errorf("ResolveFailureFile(missing) = %q, want empty", got)
+	}
+}
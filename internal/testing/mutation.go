@@ -0,0 +1,469 @@
+package testing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mutation testing tools
+const (
+	MutationStryker     = "stryker"
+	MutationGoMutesting = "go-mutesting"
+)
+
+// maxSurvivors caps the surviving mutants kept per report
+const maxSurvivors = 100
+
+// MutationSummary is the result of a mutation testing run: how many
+// deliberately introduced bugs (mutants) the tests caught
+type MutationSummary struct {
+	ProjectPath string    `json:"projectPath"`
+	Tool        string    `json:"tool"`
+	Score       float64   `json:"score"` // Percentage of valid mutants detected
+	Killed      int       `json:"killed"`
+	Timeout     int       `json:"timeout"` // Count as detected
+	Survived    int       `json:"survived"`
+	NoCoverage  int       `json:"noCoverage"` // Survived because no test runs the code
+	Errors      int       `json:"errors"`     // Mutants that didn't compile or crashed; not scored
+	Total       int       `json:"total"`
+	Survivors   []Mutant  `json:"survivors"`
+	LastUpdated time.Time `json:"lastUpdated"`
+
+	// Set while the tool runs from the app, and when that run failed
+	Running bool   `json:"running,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Mutant is a surviving mutation: a change the tests didn't notice
+type Mutant struct {
+	File        string `json:"file"` // Relative to the project
+	Line        int    `json:"line"`
+	Column      int    `json:"column,omitempty"`
+	Mutator     string `json:"mutator"`
+	Replacement string `json:"replacement,omitempty"`
+	NoCoverage  bool   `json:"noCoverage,omitempty"`
+}
+
+// mutationReports are the report files looked for, relative to the project
+var mutationReports = []string{
+	"reports/mutation/mutation.json", // Stryker's json reporter
+	"report.json",                    // go-mutesting (avito-tech)
+}
+
+var (
+	// "The mutation score is 0.750000 (6 passed, 2 failed, 0 duplicated, 0 skipped, total is 8)"
+	goMutestingScorePattern = regexp.MustCompile(`The mutation score is [\d.]+ \((\d+) passed, (\d+) failed, (\d+) duplicated, (\d+) skipped, total is (\d+)\)`)
+	// `FAIL "/tmp/go-mutesting-123/pkg/file.go.3" with checksum ...`; FAIL means the tests still passed
+	goMutestingFailPattern = regexp.MustCompile(`^FAIL "(.+?)\.\d+" with checksum`)
+	goMutestingTmpPrefix   = regexp.MustCompile(`^.*go-mutesting-\d+/`)
+)
+
+// parseMutationReport reads a Stryker mutation.json or a go-mutesting
+// report.json; ok is false for other JSON
+func parseMutationReport(data []byte, projectPath string) (*MutationSummary, bool) {
+	var probe struct {
+		Files map[string]json.RawMessage `json:"files"`
+		Stats json.RawMessage            `json:"stats"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, false
+	}
+	switch {
+	case probe.Files != nil:
+		return parseStrykerReport(data, projectPath)
+	case probe.Stats != nil:
+		return parseGoMutestingReport(data, projectPath)
+	}
+	return nil, false
+}
+
+// parseStrykerReport reads the mutation-testing-report-schema JSON
+func parseStrykerReport(data []byte, projectPath string) (*MutationSummary, bool) {
+	var report struct {
+		Files map[string]struct {
+			Mutants []struct {
+				MutatorName string `json:"mutatorName"`
+				Replacement string `json:"replacement"`
+				Status      string `json:"status"`
+				Location    struct {
+					Start struct {
+						Line   int `json:"line"`
+						Column int `json:"column"`
+					} `json:"start"`
+				} `json:"location"`
+			} `json:"mutants"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, false
+	}
+
+	summary := newMutationSummary(projectPath, MutationStryker)
+	for path, file := range report.Files {
+		for _, mutant := range file.Mutants {
+			switch mutant.Status {
+			case "Killed":
+				summary.Killed++
+			case "Timeout":
+				summary.Timeout++
+			case "Survived", "NoCoverage":
+				if mutant.Status == "Survived" {
+					summary.Survived++
+				} else {
+					summary.NoCoverage++
+				}
+				summary.Survivors = append(summary.Survivors, Mutant{
+					File:        relativeCoveragePath(projectPath, projectPath, path),
+					Line:        mutant.Location.Start.Line,
+					Column:      mutant.Location.Start.Column,
+					Mutator:     mutant.MutatorName,
+					Replacement: mutant.Replacement,
+					NoCoverage:  mutant.Status == "NoCoverage",
+				})
+			case "CompileError", "RuntimeError":
+				summary.Errors++
+			default:
+				continue // Ignored and pending mutants don't count
+			}
+			summary.Total++
+		}
+	}
+	return summary.finish(), true
+}
+
+// parseGoMutestingReport reads the report.json of avito-tech/go-mutesting
+func parseGoMutestingReport(data []byte, projectPath string) (*MutationSummary, bool) {
+	var report struct {
+		Stats struct {
+			TotalMutantsCount int `json:"totalMutantsCount"`
+			KilledCount       int `json:"killedCount"`
+			NotCoveredCount   int `json:"notCoveredCount"`
+			EscapedCount      int `json:"escapedCount"`
+			ErrorCount        int `json:"errorCount"`
+			TimeOutCount      int `json:"timeOutCount"`
+		} `json:"stats"`
+		Escaped []struct {
+			Mutator struct {
+				MutatorName       string `json:"mutatorName"`
+				MutatedSourceCode string `json:"mutatedSourceCode"`
+				OriginalFilePath  string `json:"originalFilePath"`
+				OriginalStartLine int    `json:"originalStartLine"`
+			} `json:"mutator"`
+		} `json:"escaped"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, false
+	}
+
+	stats := report.Stats
+	summary := newMutationSummary(projectPath, MutationGoMutesting)
+	summary.Killed = stats.KilledCount
+	summary.Timeout = stats.TimeOutCount
+	summary.Survived = stats.EscapedCount
+	summary.NoCoverage = stats.NotCoveredCount
+	summary.Errors = stats.ErrorCount
+	summary.Total = stats.TotalMutantsCount
+	for _, escaped := range report.Escaped {
+		summary.Survivors = append(summary.Survivors, Mutant{
+			File:        relativeCoveragePath(projectPath, projectPath, escaped.Mutator.OriginalFilePath),
+			Line:        escaped.Mutator.OriginalStartLine,
+			Mutator:     escaped.Mutator.MutatorName,
+			Replacement: firstLine(escaped.Mutator.MutatedSourceCode),
+		})
+	}
+	return summary.finish(), true
+}
+
+// parseGoMutestingOutput reads the console output of go-mutesting, which
+// prints each surviving mutant's diff followed by a FAIL line
+func parseGoMutestingOutput(text, projectPath string) (*MutationSummary, bool) {
+	match := goMutestingScorePattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil, false
+	}
+	counts := make([]int, len(match))
+	for i := 1; i < len(match); i++ {
+		counts[i], _ = strconv.Atoi(match[i])
+	}
+
+	summary := newMutationSummary(projectPath, MutationGoMutesting)
+	summary.Killed = counts[1]
+	summary.Survived = counts[2]
+	summary.Total = counts[5]
+	summary.Errors = counts[5] - counts[1] - counts[2] // Duplicates and skips aren't scored
+
+	hunkLine := 0
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+			hunkLine, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if m := goMutestingFailPattern.FindStringSubmatch(line); m != nil {
+			file := goMutestingTmpPrefix.ReplaceAllString(m[1], "")
+			if filepath.IsAbs(file) {
+				file = relativeCoveragePath(projectPath, projectPath, file)
+			}
+			summary.Survivors = append(summary.Survivors, Mutant{File: file, Line: hunkLine, Mutator: "mutation"})
+			hunkLine = 0
+		}
+	}
+	return summary.finish(), true
+}
+
+// hunkHeaderPattern matches the new-file start line of a unified diff hunk
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+func newMutationSummary(projectPath, tool string) *MutationSummary {
+	return &MutationSummary{
+		ProjectPath: projectPath,
+		Tool:        tool,
+		Survivors:   []Mutant{},
+		LastUpdated: time.Now(),
+	}
+}
+
+// finish scores the summary and keeps the survivors in file order
+func (s *MutationSummary) finish() *MutationSummary {
+	detected := s.Killed + s.Timeout
+	if valid := detected + s.Survived + s.NoCoverage; valid > 0 {
+		s.Score = float64(detected) / float64(valid) * 100
+	}
+	sort.SliceStable(s.Survivors, func(i, j int) bool {
+		if s.Survivors[i].File != s.Survivors[j].File {
+			return s.Survivors[i].File < s.Survivors[j].File
+		}
+		return s.Survivors[i].Line < s.Survivors[j].Line
+	})
+	if len(s.Survivors) > maxSurvivors {
+		s.Survivors = s.Survivors[:maxSurvivors]
+	}
+	return s
+}
+
+// mutationCommand picks the mutation tool for a project
+func mutationCommand(projectPath string, projectType ProjectType) ([]string, error) {
+	switch projectType {
+	case ProjectNode:
+		if !packageHasDependency(projectPath, "@stryker-mutator/core") {
+			return nil, fmt.Errorf("add @stryker-mutator/core to the project to run mutation tests")
+		}
+		return []string{"npx", "stryker", "run", "--reporters", "json,clear-text,progress"}, nil
+	case ProjectGo:
+		if _, err := exec.LookPath("go-mutesting"); err != nil {
+			return nil, fmt.Errorf("go-mutesting is not installed")
+		}
+		return []string{"go-mutesting", "./..."}, nil
+	}
+	return nil, fmt.Errorf("mutation testing supports JavaScript/TypeScript (Stryker) and Go (go-mutesting)")
+}
+
+func packageHasDependency(projectPath, name string) bool {
+	data, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return false
+	}
+	_, dep := pkg.Dependencies[name]
+	_, dev := pkg.DevDependencies[name]
+	return dep || dev
+}
+
+// checkMutation picks up a mutation report written since the last check.
+// Runs started from the app read their own report when they finish.
+func (w *CoverageWatcher) checkMutation(projectPath string) {
+	w.mu.RLock()
+	_, running := w.mutationRuns[projectPath]
+	w.mu.RUnlock()
+	if running {
+		return
+	}
+
+	for _, name := range mutationReports {
+		path := filepath.Join(projectPath, filepath.FromSlash(name))
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		w.mu.RLock()
+		lastMod, seen := w.mutationMods[projectPath]
+		w.mu.RUnlock()
+		if seen && !info.ModTime().After(lastMod) {
+			return
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		summary, ok := parseMutationReport(data, projectPath)
+		if !ok {
+			continue
+		}
+		summary.LastUpdated = info.ModTime()
+
+		w.mu.Lock()
+		w.mutationMods[projectPath] = info.ModTime()
+		w.mu.Unlock()
+		w.setMutation(projectPath, summary)
+		return
+	}
+}
+
+// setMutation stores a mutation result, records its score in the coverage
+// history and notifies the handler
+func (w *CoverageWatcher) setMutation(projectPath string, summary *MutationSummary) {
+	w.mu.Lock()
+	w.projectMutation[projectPath] = summary
+	scored := !summary.Running && summary.Error == ""
+	if history := w.projectHistory[projectPath]; scored && history != nil && len(history.Entries) > 0 {
+		// The score joins the latest coverage figures as a new point in the trend
+		entry := history.Entries[len(history.Entries)-1]
+		entry.Timestamp = time.Now()
+		entry.MutationScore = summary.Score
+		history.Entries = append(history.Entries, entry)
+		if len(history.Entries) > 50 {
+			history.Entries = history.Entries[len(history.Entries)-50:]
+		}
+	}
+	onMutation := w.onMutation
+	w.mu.Unlock()
+
+	if onMutation != nil {
+		onMutation(projectPath, summary)
+	}
+}
+
+// latestMutationScore is the score coverage history entries carry; zero
+// when the project has none. Callers hold w.mu.
+func (w *CoverageWatcher) latestMutationScore(projectPath string) float64 {
+	if summary := w.projectMutation[projectPath]; summary != nil && summary.Error == "" {
+		return summary.Score
+	}
+	return 0
+}
+
+// SetMutationHandler sets the callback for mutation results and run progress
+func (w *CoverageWatcher) SetMutationHandler(handler func(projectPath string, summary *MutationSummary)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onMutation = handler
+}
+
+// GetMutation returns the latest mutation testing result of a project
+func (w *CoverageWatcher) GetMutation(projectPath string) *MutationSummary {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.projectMutation[projectPath]
+}
+
+// RunMutationTests runs Stryker or go-mutesting on a project in the
+// background. Progress and the result go to the mutation handler.
+func (w *CoverageWatcher) RunMutationTests(projectPath string) error {
+	argv, err := mutationCommand(projectPath, DetectProjectType(projectPath))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.mu.Lock()
+	if _, running := w.mutationRuns[projectPath]; running {
+		w.mu.Unlock()
+		cancel()
+		return fmt.Errorf("mutation tests are already running")
+	}
+	w.mutationRuns[projectPath] = cancel
+	running := newMutationSummary(projectPath, MutationStryker)
+	if argv[0] == MutationGoMutesting {
+		running.Tool = MutationGoMutesting
+	}
+	if previous := w.projectMutation[projectPath]; previous != nil {
+		copied := *previous
+		running = &copied
+	}
+	running.Running = true
+	running.Error = ""
+	w.mu.Unlock()
+	w.setMutation(projectPath, running)
+
+	go func() {
+		defer cancel()
+		started := time.Now()
+		cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+		cmd.Dir = projectPath
+		cmd.Env = append(os.Environ(), "CI=1", "FORCE_COLOR=0")
+		output, runErr := cmd.CombinedOutput()
+
+		// Reports the run wrote are taken from here, not picked up again by polling
+		w.mu.Lock()
+		delete(w.mutationRuns, projectPath)
+		for _, name := range mutationReports {
+			if info, err := os.Stat(filepath.Join(projectPath, filepath.FromSlash(name))); err == nil {
+				w.mutationMods[projectPath] = info.ModTime()
+			}
+		}
+		w.mu.Unlock()
+
+		// go-mutesting exits non-zero when mutants survive, so the output decides
+		var summary *MutationSummary
+		if argv[0] == MutationGoMutesting {
+			summary, _ = parseGoMutestingOutput(stripANSI(string(output)), projectPath)
+		} else if path := filepath.Join(projectPath, filepath.FromSlash(mutationReports[0])); fileExists(path) {
+			if info, _ := os.Stat(path); info.ModTime().After(started) {
+				data, _ := os.ReadFile(path)
+				summary, _ = parseMutationReport(data, projectPath)
+			}
+		}
+		if summary == nil || ctx.Err() != nil {
+			failed := *running
+			failed.Running = false
+			failed.Error = "stopped"
+			if ctx.Err() == nil {
+				if runErr == nil {
+					runErr = fmt.Errorf("no mutation report written")
+				}
+				failed.Error = fmt.Sprintf("%s failed: %s", running.Tool, lastLines(stripANSI(string(output)), runErr))
+			}
+			w.setMutation(projectPath, &failed)
+			return
+		}
+		w.setMutation(projectPath, summary)
+	}()
+	return nil
+}
+
+// StopMutationTests cancels a project's mutation run
+func (w *CoverageWatcher) StopMutationTests(projectPath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if cancel, ok := w.mutationRuns[projectPath]; ok {
+		cancel()
+	}
+}
+
+// StopAll cancels every mutation run
+func (w *CoverageWatcher) StopAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, cancel := range w.mutationRuns {
+		cancel()
+	}
+}
@@ -0,0 +1,45 @@
+package testing
+
+import gotesting "testing"
+
+func TestParseMutationReports(t *gotesting.T) {
+	stryker := `{"schemaVersion": "1", "files": {
+		"/p/src/math.ts": {"language": "typescript", "mutants": [
+			{"id": "1", "mutatorName": "ArithmeticOperator", "replacement": "a - b", "status": "Killed", "location": {"start": {"line": 2, "column": 10}}},
+			{"id": "2", "mutatorName": "ConditionalExpression", "replacement": "true", "status": "Survived", "location": {"start": {"line": 5, "column": 7}}},
+			{"id": "3", "mutatorName": "StringLiteral", "replacement": "\"\"", "status": "NoCoverage", "location": {"start": {"line": 9, "column": 3}}},
+			{"id": "4", "mutatorName": "BlockStatement", "status": "Timeout", "location": {"start": {"line": 1, "column": 1}}},
+			{"id": "5", "mutatorName": "BooleanLiteral", "status": "CompileError", "location": {"start": {"line": 3, "column": 1}}},
+			{"id": "6", "mutatorName": "BooleanLiteral", "status": "Ignored", "location": {"start": {"line": 4, "column": 1}}}
+		]}}}`
+	summary, ok := parseMutationReport([]byte(stryker), "/p")
+	if !ok || summary.Tool != MutationStryker {
+		t.Fatalf("stryker report not read: %+v", summary)
+	}
+	if summary.Score != 50 || summary.Total != 5 || summary.Errors != 1 || len(summary.Survivors) != 2 {
+		t.Errorf("stryker summary = %+v", summary)
+	}
+	if s := summary.Survivors[0]; s.File != "src/math.ts" || s.Line != 5 || s.Mutator != "ConditionalExpression" {
+		t.Errorf("first survivor = %+v", s)
+	}
+
+	avito := `{"stats": {"totalMutantsCount": 4, "killedCount": 3, "escapedCount": 1},
+		"escaped": [{"mutator": {"mutatorName": "branch/if", "mutatedSourceCode": "_ = x\nreturn", "originalFilePath": "/p/calc/calc.go", "originalStartLine": 12}}]}`
+	summary, ok = parseMutationReport([]byte(avito), "/p")
+	if !ok || summary.Score != 75 || len(summary.Survivors) != 1 || summary.Survivors[0].File != "calc/calc.go" || summary.Survivors[0].Replacement != "_ = x" {
+		t.Errorf("go-mutesting report = %+v", summary)
+	}
+
+	output := "PASS \"/tmp/go-mutesting-42/calc/calc.go.0\" with checksum aaa\n" +
+		"--- Original\n+++ New\n@@ -20,7 +20,7 @@\n-\tif x {\n+\tif true {\n" +
+		"FAIL \"/tmp/go-mutesting-42/calc/calc.go.1\" with checksum bbb\n" +
+		"The mutation score is 0.500000 (1 passed, 1 failed, 0 duplicated, 0 skipped, total is 2)\n"
+	summary, ok = parseGoMutestingOutput(output, "/p")
+	if !ok || summary.Score != 50 || len(summary.Survivors) != 1 || summary.Survivors[0].File != "calc/calc.go" || summary.Survivors[0].Line != 20 {
+		t.Errorf("go-mutesting output = %+v", summary)
+	}
+
+	if _, ok := parseMutationReport([]byte(`{"name": "some-package"}`), "/p"); ok {
+		t.Error("unrelated JSON read as a mutation report")
+	}
+}
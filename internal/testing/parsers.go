@@ -0,0 +1,470 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	RunnerCargo TestRunner = "cargo"
+	RunnerTAP   TestRunner = "tap"
+	RunnerJUnit TestRunner = "junit" // JUnit-style console summaries (Maven Surefire, Gradle)
+)
+
+// ProjectType is a project's language ecosystem, detected from its manifest files
+type ProjectType string
+
+const (
+	ProjectUnknown ProjectType = ""
+	ProjectNode    ProjectType = "node"
+	ProjectGo      ProjectType = "go"
+	ProjectPython  ProjectType = "python"
+	ProjectRust    ProjectType = "rust"
+	ProjectJava    ProjectType = "java"
+)
+
+// projectMarkers maps manifest files to the project type they indicate
+var projectMarkers = []struct {
+	file        string
+	projectType ProjectType
+}{
+	{"Cargo.toml", ProjectRust},
+	{"go.mod", ProjectGo},
+	{"pyproject.toml", ProjectPython},
+	{"setup.py", ProjectPython},
+	{"setup.cfg", ProjectPython},
+	{"pytest.ini", ProjectPython},
+	{"tox.ini", ProjectPython},
+	{"requirements.txt", ProjectPython},
+	{"pom.xml", ProjectJava},
+	{"build.gradle", ProjectJava},
+	{"build.gradle.kts", ProjectJava},
+	{"package.json", ProjectNode},
+}
+
+// DetectProjectType looks for manifest files in dir and its parents
+func DetectProjectType(dir string) ProjectType {
+	if dir == "" {
+		return ProjectUnknown
+	}
+	dir = filepath.Clean(dir)
+	for {
+		for _, marker := range projectMarkers {
+			if _, err := os.Stat(filepath.Join(dir, marker.file)); err == nil {
+				return marker.projectType
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ProjectUnknown
+		}
+		dir = parent
+	}
+}
+
+// projectParsers lists the output parsers tried first for each project type,
+// before the built-in JavaScript and Go patterns
+var projectParsers = map[ProjectType][]TestRunner{
+	ProjectPython: {RunnerPytest},
+	ProjectRust:   {RunnerCargo},
+	ProjectJava:   {RunnerJUnit},
+}
+
+// OutputParser parses the console output of one test framework. All methods
+// get ANSI-free text; run is everything printed since the run started.
+type OutputParser interface {
+	Runner() TestRunner
+	// Detect reports whether the output comes from this framework
+	Detect(text string) bool
+	// IsStarting reports whether a new test run starts in the chunk
+	IsStarting(text string) bool
+	// IsComplete reports whether the run has printed its final summary
+	IsComplete(run string) bool
+	// ParseSummary sets the counts and duration of the run, and reports
+	// whether it found any
+	ParseSummary(summary *TestSummary, run string) bool
+	// FailedTests returns the failed tests of the run
+	FailedTests(run string) []TestResult
+	// TestDurations returns the tests of the run that printed a duration
+	TestDurations(run string) []TestResult
+	// Failures returns where the run's failed tests failed
+	Failures(run string) []TestFailure
+}
+
+// defaultParsers are the output parsers every watcher starts with
+func defaultParsers() []OutputParser {
+	return []OutputParser{pytestParser{}, cargoParser{}, junitParser{}, tapParser{}}
+}
+
+// countPairs sums "<n> <word>" pairs by word, e.g. "3 passed, 1 failed"
+var countPairPattern = regexp.MustCompile(`(\d+) (\w+)`)
+
+func countPairs(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, match := range countPairPattern.FindAllStringSubmatch(text, -1) {
+		n, _ := strconv.Atoi(match[1])
+		counts[match[2]] += n
+	}
+	return counts
+}
+
+// pytestParser parses pytest's session output
+type pytestParser struct{}
+
+var (
+	pytestDetectPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`=+ test session starts =+`),
+		regexp.MustCompile(`(?m)^collected \d+ items?`),
+		regexp.MustCompile(`(?m)^platform \w+ -- Python .* pytest-`),
+	}
+	pytestStartPattern = regexp.MustCompile(`test session starts`)
+	// "==== 1 failed, 3 passed, 1 skipped in 0.12s ====" or "3 passed in 0.12s" with -q
+	pytestSummaryPattern = regexp.MustCompile(`(?m)^=*\s*((?:\d+ (?:passed|failed|skipped|errors?|xfailed|xpassed|deselected|warnings?|rerun)(?:, )?)+) in ([\d.]+)s`)
+	// Short test summary info: "FAILED tests/test_x.py::test_a - assert 1 == 2"
+	pytestFailedPattern = regexp.MustCompile(`(?m)^(?:FAILED|ERROR) (\S+)(?: - (.*))?$`)
+	// --durations: "0.52s call     tests/test_api.py::test_slow"
+	pytestDurationPattern = regexp.MustCompile(`(?m)^([\d.]+)s call\s+(\S+)`)
+)
+
+func (pytestParser) Runner() TestRunner { return RunnerPytest }
+
+func (pytestParser) Detect(text string) bool {
+	for _, pattern := range pytestDetectPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (pytestParser) IsStarting(text string) bool {
+	return pytestStartPattern.MatchString(text)
+}
+
+func (pytestParser) IsComplete(run string) bool {
+	return pytestSummaryPattern.MatchString(run)
+}
+
+func (pytestParser) ParseSummary(summary *TestSummary, run string) bool {
+	matches := pytestSummaryPattern.FindAllStringSubmatch(run, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	match := matches[len(matches)-1]
+	counts := countPairs(match[1])
+	summary.Passed = counts["passed"] + counts["xpassed"]
+	summary.Failed = counts["failed"] + counts["error"] + counts["errors"]
+	summary.Skipped = counts["skipped"] + counts["xfailed"]
+	summary.Total = summary.Passed + summary.Failed + summary.Skipped
+	if seconds, err := strconv.ParseFloat(match[2], 64); err == nil {
+		summary.Duration = seconds * 1000
+	}
+	return true
+}
+
+func (pytestParser) FailedTests(run string) []TestResult {
+	var failed []TestResult
+	for _, match := range pytestFailedPattern.FindAllStringSubmatch(run, -1) {
+		failed = append(failed, TestResult{Name: match[1], Status: StatusFailed, Error: strings.TrimSpace(match[2])})
+	}
+	return failed
+}
+
+func (pytestParser) TestDurations(run string) []TestResult {
+	var results []TestResult
+	for _, match := range pytestDurationPattern.FindAllStringSubmatch(run, -1) {
+		seconds, _ := strconv.ParseFloat(match[1], 64)
+		results = append(results, TestResult{Name: match[2], Duration: seconds * 1000})
+	}
+	return results
+}
+
+// cargoParser parses cargo test (libtest) output. Cargo runs one test binary
+// per target and prints a result line for each, so the counts are summed.
+type cargoParser struct{}
+
+var (
+	cargoDetectPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\s*Running (?:unittests |tests/|benches/)`),
+		regexp.MustCompile(`(?m)^test result: (?:ok|FAILED)\.`),
+		regexp.MustCompile(`(?m)^\s*Doc-tests \w+`),
+	}
+	// "Finished `test` profile [unoptimized + debuginfo] target(s) in 0.52s"
+	cargoStartPattern  = regexp.MustCompile("(?m)^\\s*Finished `?test`? (?:profile )?\\[")
+	cargoResultPattern = regexp.MustCompile(`(?m)^test result: (?:ok|FAILED)\. (\d+) passed; (\d+) failed; (\d+) ignored; \d+ measured; \d+ filtered out(?:; finished in ([\d.]+)s)?`)
+	cargoFailedPattern = regexp.MustCompile(`(?m)^test (\S+) (?:- should panic )?\.\.\. FAILED`)
+	// With --report-time: "test tests::adds ... ok <0.001s>"
+	cargoDurationPattern = regexp.MustCompile(`(?m)^test (\S+) (?:- should panic )?\.\.\. (ok|FAILED) <([\d.]+)s>`)
+)
+
+func (cargoParser) Runner() TestRunner { return RunnerCargo }
+
+func (cargoParser) Detect(text string) bool {
+	for _, pattern := range cargoDetectPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cargoParser) IsStarting(text string) bool {
+	return cargoStartPattern.MatchString(text)
+}
+
+func (cargoParser) IsComplete(run string) bool {
+	return cargoResultPattern.MatchString(run)
+}
+
+func (cargoParser) ParseSummary(summary *TestSummary, run string) bool {
+	matches := cargoResultPattern.FindAllStringSubmatch(run, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	summary.Passed, summary.Failed, summary.Skipped, summary.Duration = 0, 0, 0, 0
+	for _, match := range matches {
+		passed, _ := strconv.Atoi(match[1])
+		failed, _ := strconv.Atoi(match[2])
+		ignored, _ := strconv.Atoi(match[3])
+		summary.Passed += passed
+		summary.Failed += failed
+		summary.Skipped += ignored
+		if seconds, err := strconv.ParseFloat(match[4], 64); err == nil {
+			summary.Duration += seconds * 1000
+		}
+	}
+	summary.Total = summary.Passed + summary.Failed + summary.Skipped
+	return true
+}
+
+func (cargoParser) FailedTests(run string) []TestResult {
+	var failed []TestResult
+	for _, match := range cargoFailedPattern.FindAllStringSubmatch(run, -1) {
+		failed = append(failed, TestResult{Name: match[1], Status: StatusFailed})
+	}
+	return failed
+}
+
+func (cargoParser) TestDurations(run string) []TestResult {
+	var results []TestResult
+	for _, match := range cargoDurationPattern.FindAllStringSubmatch(run, -1) {
+		seconds, _ := strconv.ParseFloat(match[3], 64)
+		status := StatusPassed
+		if match[2] == "FAILED" {
+			status = StatusFailed
+		}
+		results = append(results, TestResult{Name: match[1], Status: status, Duration: seconds * 1000})
+	}
+	return results
+}
+
+// tapParser parses Test Anything Protocol output (node --test, tape, prove, bats)
+type tapParser struct{}
+
+var (
+	tapVersionPattern = regexp.MustCompile(`(?m)^TAP version \d+`)
+	tapPlanPattern    = regexp.MustCompile(`(?m)^1\.\.(\d+)`)
+	// Top-level results only; subtests are indented
+	tapResultPattern = regexp.MustCompile(`(?m)^(not )?ok \d+(?: -)? ?([^#\n]*)(#\s*(?i:skip|todo)\b)?`)
+	// Closing comments: "# pass 4" / "# fail 1" / "# skipped 0"
+	tapCommentPattern  = regexp.MustCompile(`(?m)^# (tests|pass|fail|skip|skipped|todo) (\d+)`)
+	tapDurationPattern = regexp.MustCompile(`(?m)^# duration_ms ([\d.]+)`)
+	// YAML diagnostics of a top-level test: "  duration_ms: 0.5"
+	tapTestDurationPattern = regexp.MustCompile(`^  duration_ms: ([\d.]+)`)
+)
+
+func (tapParser) Runner() TestRunner { return RunnerTAP }
+
+func (tapParser) Detect(text string) bool {
+	return tapVersionPattern.MatchString(text) ||
+		(tapPlanPattern.MatchString(text) && tapResultPattern.MatchString(text))
+}
+
+func (tapParser) IsStarting(text string) bool {
+	return tapVersionPattern.MatchString(text)
+}
+
+func (tapParser) IsComplete(run string) bool {
+	if tapCommentPattern.MatchString(run) {
+		return true
+	}
+	plan := tapPlanPattern.FindStringSubmatch(run)
+	if plan == nil {
+		return false
+	}
+	planned, _ := strconv.Atoi(plan[1])
+	return len(tapResultPattern.FindAllString(run, -1)) >= planned
+}
+
+func (tapParser) ParseSummary(summary *TestSummary, run string) bool {
+	comments := make(map[string]int)
+	for _, match := range tapCommentPattern.FindAllStringSubmatch(run, -1) {
+		comments[match[1]], _ = strconv.Atoi(match[2])
+	}
+	if _, ok := comments["pass"]; ok {
+		summary.Passed = comments["pass"]
+		summary.Failed = comments["fail"]
+		summary.Skipped = comments["skip"] + comments["skipped"] + comments["todo"]
+	} else {
+		results := tapResultPattern.FindAllStringSubmatch(run, -1)
+		if len(results) == 0 {
+			return false
+		}
+		summary.Passed, summary.Failed, summary.Skipped = 0, 0, 0
+		for _, match := range results {
+			switch {
+			case match[3] != "":
+				summary.Skipped++
+			case match[1] != "":
+				summary.Failed++
+			default:
+				summary.Passed++
+			}
+		}
+	}
+	summary.Total = summary.Passed + summary.Failed + summary.Skipped
+	if match := tapDurationPattern.FindStringSubmatch(run); match != nil {
+		summary.Duration, _ = strconv.ParseFloat(match[1], 64)
+	}
+	return true
+}
+
+func (tapParser) FailedTests(run string) []TestResult {
+	var failed []TestResult
+	for _, match := range tapResultPattern.FindAllStringSubmatch(run, -1) {
+		if match[1] != "" && match[3] == "" {
+			failed = append(failed, TestResult{Name: strings.TrimSpace(match[2]), Status: StatusFailed})
+		}
+	}
+	return failed
+}
+
+func (tapParser) TestDurations(run string) []TestResult {
+	var results []TestResult
+	var current *TestResult
+	for _, line := range strings.Split(run, "\n") {
+		if match := tapResultPattern.FindStringSubmatch(line); match != nil {
+			status := StatusPassed
+			switch {
+			case match[3] != "":
+				status = StatusNone
+			case match[1] != "":
+				status = StatusFailed
+			}
+			current = &TestResult{Name: strings.TrimSpace(match[2]), Status: status}
+			continue
+		}
+		if match := tapTestDurationPattern.FindStringSubmatch(line); match != nil && current != nil {
+			current.Duration, _ = strconv.ParseFloat(match[1], 64)
+			results = append(results, *current)
+			current = nil
+		}
+	}
+	return results
+}
+
+// junitParser parses JUnit-style console summaries from Maven Surefire and Gradle
+type junitParser struct{}
+
+var (
+	junitDetectPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`T E S T S`),
+		regexp.MustCompile(`Tests run: \d+, Failures: \d+, Errors: \d+, Skipped: \d+`),
+	}
+	junitStartPattern = regexp.MustCompile(`T E S T S`)
+	// Per class lines end with "Time elapsed: ... - in <class>"; the closing
+	// total has nothing after the skipped count
+	junitTotalPattern = regexp.MustCompile(`(?m)Tests run: (\d+), Failures: (\d+), Errors: (\d+), Skipped: (\d+)\s*$`)
+	junitClassPattern = regexp.MustCompile(`Tests run: (\d+), Failures: (\d+), Errors: (\d+), Skipped: (\d+), Time elapsed: ([\d.]+) s`)
+	// Gradle: "5 tests completed, 1 failed, 1 skipped"
+	gradleTotalPattern = regexp.MustCompile(`(\d+) tests? completed(?:, (\d+) failed)?(?:, (\d+) skipped)?`)
+	junitEndPattern    = regexp.MustCompile(`BUILD (?:SUCCESS|FAILURE|SUCCESSFUL|FAILED)`)
+	// "[ERROR] testBar(com.x.FooTest)  Time elapsed: 0.01 s  <<< FAILURE!"
+	junitFailedPattern = regexp.MustCompile(`(?m)^(?:\[ERROR\]\s+)?([\w.$]+(?:\([\w.$]+\))?)\s+Time elapsed: [\d.]+ s\s+<<< (?:FAILURE|ERROR)!`)
+	// Gradle: "FooTest > testBar() FAILED"
+	gradleFailedPattern = regexp.MustCompile(`(?m)^(\S+ > .+?) FAILED$`)
+	// Surefire only times classes: "Tests run: 4, ..., Time elapsed: 0.05 s - in com.example.CalcTest"
+	junitClassDurationPattern = regexp.MustCompile(`Tests run: \d+, Failures: (\d+), Errors: (\d+), Skipped: \d+, Time elapsed: ([\d.]+) s.*?-+ in ([\w.$]+)`)
+)
+
+func (junitParser) Runner() TestRunner { return RunnerJUnit }
+
+func (junitParser) Detect(text string) bool {
+	for _, pattern := range junitDetectPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (junitParser) IsStarting(text string) bool {
+	return junitStartPattern.MatchString(text)
+}
+
+func (junitParser) IsComplete(run string) bool {
+	return junitTotalPattern.MatchString(run) || gradleTotalPattern.MatchString(run) || junitEndPattern.MatchString(run)
+}
+
+func (junitParser) ParseSummary(summary *TestSummary, run string) bool {
+	var total, failures, errors, skipped int
+	if matches := junitTotalPattern.FindAllStringSubmatch(run, -1); len(matches) > 0 {
+		match := matches[len(matches)-1]
+		total, _ = strconv.Atoi(match[1])
+		failures, _ = strconv.Atoi(match[2])
+		errors, _ = strconv.Atoi(match[3])
+		skipped, _ = strconv.Atoi(match[4])
+	} else if matches := junitClassPattern.FindAllStringSubmatch(run, -1); len(matches) > 0 {
+		summary.Duration = 0
+		for _, match := range matches {
+			n, _ := strconv.Atoi(match[1])
+			f, _ := strconv.Atoi(match[2])
+			e, _ := strconv.Atoi(match[3])
+			s, _ := strconv.Atoi(match[4])
+			total, failures, errors, skipped = total+n, failures+f, errors+e, skipped+s
+			if seconds, err := strconv.ParseFloat(match[5], 64); err == nil {
+				summary.Duration += seconds * 1000
+			}
+		}
+	} else if match := gradleTotalPattern.FindStringSubmatch(run); match != nil {
+		total, _ = strconv.Atoi(match[1])
+		failures, _ = strconv.Atoi(match[2])
+		skipped, _ = strconv.Atoi(match[3])
+	} else {
+		return false
+	}
+	summary.Failed = failures + errors
+	summary.Skipped = skipped
+	summary.Passed = total - summary.Failed - skipped
+	if summary.Passed < 0 {
+		summary.Passed = 0
+	}
+	summary.Total = summary.Passed + summary.Failed + summary.Skipped
+	return true
+}
+
+func (junitParser) FailedTests(run string) []TestResult {
+	var failed []TestResult
+	for _, pattern := range []*regexp.Regexp{junitFailedPattern, gradleFailedPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(run, -1) {
+			failed = append(failed, TestResult{Name: match[1], Status: StatusFailed})
+		}
+	}
+	return failed
+}
+
+func (junitParser) TestDurations(run string) []TestResult {
+	var results []TestResult
+	for _, match := range junitClassDurationPattern.FindAllStringSubmatch(run, -1) {
+		seconds, _ := strconv.ParseFloat(match[3], 64)
+		status := StatusPassed
+		if match[1] != "0" || match[2] != "0" {
+			status = StatusFailed
+		}
+		results = append(results, TestResult{Name: match[4], Status: status, Duration: seconds * 1000})
+	}
+	return results
+}
@@ -0,0 +1,136 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	gotesting "testing"
+)
+
+func TestOutputParsers(t *gotesting.T) {
+	tests := []struct {
+		name        string
+		projectFile string
+		output      string
+		runner      TestRunner
+		status      TestStatus
+		passed      int
+		failed      int
+		skipped     int
+		failedTests []string
+	}{
+		{
+			name:        "pytest",
+			projectFile: "pyproject.toml",
+			output: "============================= test session starts ==============================\n" +
+				"platform linux -- Python 3.12.1, pytest-8.0.0, pluggy-1.4.0\n" +
+				"collected 5 items\n\n" +
+				"tests/test_math.py ..F.s                                                  [100%]\n\n" +
+				"=========================== short test summary info ============================\n" +
+				"FAILED tests/test_math.py::test_divide - ZeroDivisionError: division by zero\n" +
+				"==================== 1 failed, 3 passed, 1 skipped in 0.12s ====================\n",
+			runner:      RunnerPytest,
+			status:      StatusMixed,
+			passed:      3,
+			failed:      1,
+			skipped:     1,
+			failedTests: []string{"tests/test_math.py::test_divide"},
+		},
+		{
+			name:        "cargo test sums every test binary",
+			projectFile: "Cargo.toml",
+			output: "   Compiling demo v0.1.0 (/tmp/demo)\n" +
+				"    Finished `test` profile [unoptimized + debuginfo] target(s) in 0.52s\n" +
+				"     Running unittests src/lib.rs (target/debug/deps/demo-1a2b3c)\n\n" +
+				"running 3 tests\n" +
+				"test tests::adds ... ok\n" +
+				"test tests::slow ... ignored\n" +
+				"test tests::subtracts ... ok\n\n" +
+				"test result: ok. 2 passed; 0 failed; 1 ignored; 0 measured; 0 filtered out; finished in 0.00s\n\n" +
+				"     Running tests/api.rs (target/debug/deps/api-4d5e6f)\n\n" +
+				"running 2 tests\n" +
+				"test parses ... ok\n" +
+				"test rejects_empty ... FAILED\n\n" +
+				"test result: FAILED. 1 passed; 1 failed; 0 ignored; 0 measured; 0 filtered out; finished in 0.01s\n",
+			runner:      RunnerCargo,
+			status:      StatusMixed,
+			passed:      3,
+			failed:      1,
+			skipped:     1,
+			failedTests: []string{"rejects_empty"},
+		},
+		{
+			name:        "node --test TAP",
+			projectFile: "package.json",
+			output: "TAP version 13\n" +
+				"# Subtest: adds\n" +
+				"ok 1 - adds\n" +
+				"  ---\n  duration_ms: 0.5\n  ...\n" +
+				"not ok 2 - divides\n" +
+				"ok 3 - later # SKIP\n" +
+				"1..3\n" +
+				"# tests 3\n# suites 0\n# pass 1\n# fail 1\n# cancelled 0\n# skipped 1\n# todo 0\n# duration_ms 42.5\n",
+			runner:      RunnerTAP,
+			status:      StatusMixed,
+			passed:      1,
+			failed:      1,
+			skipped:     1,
+			failedTests: []string{"divides"},
+		},
+		{
+			name:        "maven surefire",
+			projectFile: "pom.xml",
+			output: "[INFO] -------------------------------------------------------\n" +
+				"[INFO]  T E S T S\n" +
+				"[INFO] -------------------------------------------------------\n" +
+				"[INFO] Running com.example.CalcTest\n" +
+				"[ERROR] Tests run: 4, Failures: 1, Errors: 0, Skipped: 1, Time elapsed: 0.05 s <<< FAILURE! - in com.example.CalcTest\n" +
+				"[ERROR] testDivide(com.example.CalcTest)  Time elapsed: 0.01 s  <<< FAILURE!\n" +
+				"[INFO] Results:\n" +
+				"[ERROR] Tests run: 4, Failures: 1, Errors: 0, Skipped: 1\n" +
+				"[INFO] BUILD FAILURE\n",
+			runner:      RunnerJUnit,
+			status:      StatusMixed,
+			passed:      2,
+			failed:      1,
+			skipped:     1,
+			failedTests: []string{"testDivide(com.example.CalcTest)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *gotesting.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.projectFile), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			w := NewWatcher()
+			w.SetWorkDirFunc(func(string) string { return dir })
+			finished := 0
+			w.SetRunFinishedHandler(func(string, TestSummary) { finished++ })
+
+			var summary *TestSummary
+			for _, line := range strings.SplitAfter(tt.output, "\n") {
+				summary, _ = w.Analyze("term", []byte(strings.ReplaceAll(line, "\n", "\r\n")))
+			}
+
+			if summary.Runner != tt.runner || summary.Status != tt.status {
+				t.Errorf("runner, status = %s, %s; want %s, %s", summary.Runner, summary.Status, tt.runner, tt.status)
+			}
+			if finished == 0 {
+				t.Error("run finished handler not called")
+			}
+			if summary.Passed != tt.passed || summary.Failed != tt.failed || summary.Skipped != tt.skipped {
+				t.Errorf("passed, failed, skipped = %d, %d, %d; want %d, %d, %d",
+					summary.Passed, summary.Failed, summary.Skipped, tt.passed, tt.failed, tt.skipped)
+			}
+			var failed []string
+			for _, ft := range summary.FailedTests {
+				failed = append(failed, ft.Name)
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.failedTests, ",") {
+				t.Errorf("failed tests = %v, want %v", failed, tt.failedTests)
+			}
+		})
+	}
+}
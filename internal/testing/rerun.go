@@ -0,0 +1,646 @@
+package testing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"projecthub/internal/structure"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// maxWatchedDirs caps how many directories of a project are watched for
+// changes; each one takes an inotify watch or kqueue descriptors
+const maxWatchedDirs = 5000
+
+// rerunDebounce waits for a burst of changes, like a save of several files or
+// a branch switch, to settle before tests run
+const rerunDebounce = 300 * time.Millisecond
+
+// RerunResult is a test run started by a file change in watch mode
+type RerunResult struct {
+	ProjectPath  string       `json:"projectPath"`
+	ChangedFiles []string     `json:"changedFiles"` // Relative to the project
+	TestFiles    []string     `json:"testFiles"`    // Affected test files, relative to the project
+	Command      string       `json:"command"`
+	Summary      *TestSummary `json:"summary"`
+	Running      bool         `json:"running"`
+	ExitCode     int          `json:"exitCode"`
+	Error        string       `json:"error,omitempty"`
+	StartTime    time.Time    `json:"startTime"`
+	EndTime      time.Time    `json:"endTime,omitempty"`
+}
+
+// RerunWatcher watches projects for changed source files and reruns the
+// tests affected by them. Changes come from file system notifications.
+type RerunWatcher struct {
+	mu       sync.Mutex
+	checkMu  sync.Mutex                 // one checkProject at a time, so changes can't start two runs
+	projects map[string]*watchedProject // projectPath -> state
+	scanner  *structure.Scanner
+	onUpdate func(result *RerunResult)
+}
+
+type watchedProject struct {
+	fsw     *fsnotify.Watcher
+	dirs    int                // directories watched, up to maxWatchedDirs
+	pending map[string]bool    // changed files waiting for the debounce or the current run
+	timer   *time.Timer        // debounce
+	cancel  context.CancelFunc // cancels the current run
+	last    *RerunResult
+}
+
+// NewRerunWatcher creates a watcher that uses scanner for JS/TS import graphs
+func NewRerunWatcher(scanner *structure.Scanner) *RerunWatcher {
+	return &RerunWatcher{
+		projects: make(map[string]*watchedProject),
+		scanner:  scanner,
+	}
+}
+
+// SetUpdateHandler sets the callback for rerun progress and results
+func (w *RerunWatcher) SetUpdateHandler(handler func(result *RerunResult)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onUpdate = handler
+}
+
+// WatchProject starts watch mode for a project; changes made from now on
+// trigger runs
+func (w *RerunWatcher) WatchProject(projectPath string) error {
+	if DetectProjectType(projectPath) == ProjectUnknown {
+		return fmt.Errorf("no supported project found in %s", projectPath)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, exists := w.projects[projectPath]; exists {
+		return nil
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", projectPath, err)
+	}
+	project := &watchedProject{fsw: fsw, pending: make(map[string]bool)}
+	w.addDirs(projectPath, project, projectPath)
+	w.projects[projectPath] = project
+	go w.watchEvents(projectPath, project)
+	return nil
+}
+
+// addDirs watches dir and the directories below it that may hold sources.
+// Called with w.mu held.
+func (w *RerunWatcher) addDirs(projectPath string, project *watchedProject, dir string) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if path != projectPath && (skipDirs[name] || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		if project.dirs >= maxWatchedDirs {
+			slog.Warn("Watch mode directory limit reached, later directories aren't watched",
+				"project", projectPath, "limit", maxWatchedDirs)
+			return filepath.SkipAll
+		}
+		if err := project.fsw.Add(path); err != nil {
+			slog.Debug("Cannot watch directory", "dir", path, "error", err)
+			return nil
+		}
+		project.dirs++
+		return nil
+	})
+}
+
+// watchEvents queues the source files a project's notifications report
+// changed, and watches directories created in it, until it is unwatched
+func (w *RerunWatcher) watchEvents(projectPath string, project *watchedProject) {
+	for {
+		select {
+		case event, ok := <-project.fsw.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if name := info.Name(); skipDirs[name] || strings.HasPrefix(name, ".") {
+						continue
+					}
+					w.mu.Lock()
+					if w.projects[projectPath] == project {
+						w.addDirs(projectPath, project, event.Name)
+					}
+					w.mu.Unlock()
+					// Files may land in it before its watch is added, on a checkout say
+					for _, file := range sourceFilesIn(event.Name) {
+						w.queueChange(projectPath, project, file)
+					}
+					continue
+				}
+			}
+			if isWatchedSource(event.Name) {
+				w.queueChange(projectPath, project, event.Name)
+			}
+		case err, ok := <-project.fsw.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("Watch mode notification error", "project", projectPath, "error", err)
+		}
+	}
+}
+
+// sourceFilesIn lists the source files below a directory, skipping the
+// directories that aren't watched
+func sourceFilesIn(dir string) []string {
+	var files []string
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isWatchedSource(d.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+// queueChange records a changed file and restarts the debounce
+func (w *RerunWatcher) queueChange(projectPath string, project *watchedProject, path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.projects[projectPath] != project {
+		return
+	}
+	project.pending[path] = true
+	if project.timer != nil {
+		project.timer.Stop()
+	}
+	project.timer = time.AfterFunc(rerunDebounce, func() { w.checkProject(projectPath) })
+}
+
+// UnwatchProject stops watch mode for a project and cancels its current run
+func (w *RerunWatcher) UnwatchProject(projectPath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if project, ok := w.projects[projectPath]; ok {
+		project.stop()
+		delete(w.projects, projectPath)
+	}
+}
+
+// stop cancels a project's run and stops its notifications
+func (p *watchedProject) stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.fsw.Close()
+}
+
+// IsWatching reports whether watch mode is on for a project
+func (w *RerunWatcher) IsWatching(projectPath string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.projects[projectPath]
+	return ok
+}
+
+// GetLastResult returns the latest rerun of a project, or nil
+func (w *RerunWatcher) GetLastResult(projectPath string) *RerunResult {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if project, ok := w.projects[projectPath]; ok && project.last != nil {
+		result := *project.last
+		return &result
+	}
+	return nil
+}
+
+// StopAll cancels every running rerun and stops watching all projects
+func (w *RerunWatcher) StopAll() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for path, project := range w.projects {
+		project.stop()
+		delete(w.projects, path)
+	}
+}
+
+// checkProject starts a run for the queued changes of a project, unless one
+// is in progress; its changes wait for it to finish
+func (w *RerunWatcher) checkProject(projectPath string) {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	w.mu.Lock()
+	project, ok := w.projects[projectPath]
+	if !ok {
+		w.mu.Unlock()
+		return
+	}
+	if len(project.pending) == 0 || project.cancel != nil {
+		w.mu.Unlock()
+		return
+	}
+	changed := make([]string, 0, len(project.pending))
+	for path := range project.pending {
+		changed = append(changed, path)
+	}
+	project.pending = make(map[string]bool)
+	w.mu.Unlock()
+
+	sort.Strings(changed)
+	w.rerun(projectPath, changed)
+}
+
+// rerun finds the tests affected by the changed files and runs them in the background
+func (w *RerunWatcher) rerun(projectPath string, changed []string) {
+	projectType := DetectProjectType(projectPath)
+	testFiles := w.AffectedTests(projectPath, projectType, changed)
+	if len(testFiles) == 0 {
+		return
+	}
+	argv, runner := rerunCommand(projectPath, projectType, testFiles)
+	if argv == nil {
+		return
+	}
+
+	result := &RerunResult{
+		ProjectPath:  projectPath,
+		ChangedFiles: relativePaths(projectPath, changed),
+		TestFiles:    relativePaths(projectPath, testFiles),
+		Command:      strings.Join(argv, " "),
+		Running:      true,
+		StartTime:    time.Now(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.mu.Lock()
+	project, ok := w.projects[projectPath]
+	if !ok {
+		w.mu.Unlock()
+		cancel()
+		return
+	}
+	project.cancel = cancel
+	project.last = result
+	w.mu.Unlock()
+
+	go w.runTests(ctx, cancel, result, argv, runner)
+}
+
+// runTests runs the command, feeding its output through an output watcher so
+// progress is reported as the tests complete
+func (w *RerunWatcher) runTests(ctx context.Context, cancel context.CancelFunc, result *RerunResult, argv []string, runner TestRunner) {
+	defer cancel()
+
+	w.emit(result, func(*RerunResult) {})
+	summary, exitCode, err := runTestProcess(ctx, result.ProjectPath, argv, runner, func(summary TestSummary) {
+		w.emit(result, func(r *RerunResult) { r.Summary = &summary })
+	})
+	w.finish(result, summary, exitCode, err)
+}
+
+// runTestProcess runs a test command in dir and parses its output with an
+// output watcher, calling onProgress when the summary's status changes. The
+// summary is nil when the command couldn't start; exit codes aren't errors.
+func runTestProcess(ctx context.Context, dir string, argv []string, runner TestRunner, onProgress func(summary TestSummary)) (*TestSummary, int, error) {
+	parser := NewWatcher()
+	parser.SetWorkDirFunc(func(string) string { return dir })
+	parser.startRun("run", runner)
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CI=1", "FORCE_COLOR=0")
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		cmd.Stderr = cmd.Stdout
+		err = cmd.Start()
+	}
+	if err != nil {
+		return nil, -1, err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		summary, changed := parser.Analyze("run", append(scanner.Bytes(), '\n'))
+		if changed && onProgress != nil {
+			onProgress(*summary)
+		}
+	}
+
+	err = cmd.Wait()
+	exitCode := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode, err = exitErr.ExitCode(), nil
+		}
+	}
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	parser.endRun("run")
+	return parser.GetSummary("run"), exitCode, err
+}
+
+// finish records the outcome of a run and starts the next one if files
+// changed while it ran
+func (w *RerunWatcher) finish(result *RerunResult, summary *TestSummary, exitCode int, err error) {
+	if summary != nil {
+		summary = finalizeRerunSummary(*summary, exitCode)
+	}
+	w.emit(result, func(r *RerunResult) {
+		r.Summary = summary
+		r.Running = false
+		r.ExitCode = exitCode
+		r.EndTime = time.Now()
+		if err != nil {
+			r.Error = err.Error()
+		}
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		slog.Warn("Test rerun failed", "project", result.ProjectPath, "error", err)
+	}
+
+	w.mu.Lock()
+	next := false
+	if project, ok := w.projects[result.ProjectPath]; ok && project.last == result {
+		project.cancel = nil
+		next = len(project.pending) > 0
+	}
+	w.mu.Unlock()
+
+	if next {
+		w.checkProject(result.ProjectPath)
+	}
+}
+
+// emit applies update to a run and sends a copy to the handler, unless the
+// project stopped watching or moved on to another run
+func (w *RerunWatcher) emit(result *RerunResult, update func(r *RerunResult)) {
+	w.mu.Lock()
+	project, ok := w.projects[result.ProjectPath]
+	if !ok || project.last != result {
+		w.mu.Unlock()
+		return
+	}
+	update(result)
+	snapshot := *result
+	handler := w.onUpdate
+	w.mu.Unlock()
+
+	if handler != nil {
+		handler(&snapshot)
+	}
+}
+
+// finalizeRerunSummary settles the status once the command has exited; the
+// exit code decides when the output had no recognizable summary
+func finalizeRerunSummary(summary TestSummary, exitCode int) *TestSummary {
+	if summary.Total == 0 && len(summary.TestDurations) > 0 {
+		for _, test := range summary.TestDurations {
+			switch test.Status {
+			case StatusPassed:
+				summary.Passed++
+			case StatusFailed:
+				summary.Failed++
+			default:
+				summary.Skipped++
+			}
+		}
+		summary.Total = summary.Passed + summary.Failed + summary.Skipped
+	}
+	if len(summary.FailedTests) == 0 {
+		for _, test := range summary.TestDurations {
+			if test.Status == StatusFailed {
+				summary.FailedTests = append(summary.FailedTests, test)
+			}
+		}
+	}
+	if summary.EndTime.IsZero() {
+		summary.EndTime = time.Now()
+	}
+	if summary.Duration == 0 {
+		summary.Duration = float64(summary.EndTime.Sub(summary.StartTime).Milliseconds())
+	}
+	switch {
+	case summary.Failed > 0 && summary.Passed > 0:
+		summary.Status = StatusMixed
+	case summary.Failed > 0 || exitCode != 0:
+		summary.Status = StatusFailed
+	case summary.Passed > 0 || summary.Total == 0:
+		summary.Status = StatusPassed
+	}
+	return &summary
+}
+
+// AffectedTests maps changed files to the test files that cover them: changed
+// test files themselves, tests named after a changed file, and for JS/TS the
+// tests that import a changed file directly or indirectly
+func (w *RerunWatcher) AffectedTests(projectPath string, projectType ProjectType, changed []string) []string {
+	affected := make(map[string]bool)
+	var graph *structure.ImportGraph
+
+	for _, file := range changed {
+		if isTestFile(filepath.Base(file)) {
+			affected[file] = true
+			continue
+		}
+		for _, candidate := range conventionTests(projectPath, file) {
+			affected[candidate] = true
+		}
+
+		if projectType == ProjectNode && w.scanner != nil {
+			if graph == nil {
+				var err error
+				if graph, err = w.scanner.ImportGraph(projectPath); err != nil {
+					slog.Debug("Import graph unavailable", "project", projectPath, "error", err)
+					continue
+				}
+			}
+			for _, dependent := range graph.Dependents(file) {
+				if isTestFile(filepath.Base(dependent)) {
+					affected[dependent] = true
+				}
+			}
+		}
+	}
+
+	tests := make([]string, 0, len(affected))
+	for file := range affected {
+		// End-to-end suites need a running app; they are left to explicit runs
+		if classifyTestType(file, projectPath) == "e2e" {
+			continue
+		}
+		if _, err := os.Stat(file); err == nil {
+			tests = append(tests, file)
+		}
+	}
+	sort.Strings(tests)
+	return tests
+}
+
+// conventionTests lists the test files that by naming convention belong to a source file
+func conventionTests(projectPath, file string) []string {
+	dir := filepath.Dir(file)
+	ext := filepath.Ext(file)
+	name := strings.TrimSuffix(filepath.Base(file), ext)
+
+	var candidates []string
+	switch ext {
+	case ".go":
+		// Go tests cover the whole package
+		matches, _ := filepath.Glob(filepath.Join(dir, "*_test.go"))
+		candidates = append(candidates, matches...)
+	case ".py":
+		for _, testDir := range []string{dir, filepath.Join(dir, "tests"), filepath.Join(projectPath, "tests")} {
+			candidates = append(candidates,
+				filepath.Join(testDir, "test_"+name+".py"),
+				filepath.Join(testDir, name+"_test.py"))
+		}
+	default:
+		if !isJSSource(ext) {
+			return nil
+		}
+		for _, testDir := range []string{dir, filepath.Join(dir, "__tests__")} {
+			for _, kind := range []string{".test", ".spec"} {
+				for _, testExt := range []string{".ts", ".tsx", ".js", ".jsx"} {
+					candidates = append(candidates, filepath.Join(testDir, name+kind+testExt))
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+func isJSSource(ext string) bool {
+	switch ext {
+	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".mts", ".cjs", ".cts", ".vue", ".svelte":
+		return true
+	}
+	return false
+}
+
+// isWatchedSource reports whether changes to a file can affect tests
+func isWatchedSource(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".go" || ext == ".py" || isJSSource(ext)
+}
+
+// rerunCommand builds the command running just the given test files, and
+// names its runner when known
+func rerunCommand(projectPath string, projectType ProjectType, testFiles []string) ([]string, TestRunner) {
+	rel := relativePaths(projectPath, testFiles)
+	switch projectType {
+	case ProjectGo:
+		packages := make(map[string]bool)
+		argv := []string{"go", "test", "-v"}
+		for _, file := range rel {
+			pkg := "./" + filepath.ToSlash(filepath.Dir(file))
+			if !packages[pkg] {
+				packages[pkg] = true
+				argv = append(argv, pkg)
+			}
+		}
+		return argv, RunnerGo
+	case ProjectPython:
+		return append([]string{"python3", "-m", "pytest"}, rel...), RunnerPytest
+	case ProjectNode:
+		switch runner := nodeTestRunner(projectPath); runner {
+		case RunnerVitest:
+			return append([]string{"npx", "vitest", "run"}, rel...), runner
+		case RunnerJest:
+			return append([]string{"npx", "jest"}, rel...), runner
+		}
+		return append([]string{"npm", "test", "--"}, rel...), RunnerUnknown
+	}
+	return nil, RunnerUnknown
+}
+
+// TestCommand returns the command running a project's whole test suite, or
+// nil when the project type isn't known
+func TestCommand(projectPath string) []string {
+	switch DetectProjectType(projectPath) {
+	case ProjectGo:
+		return []string{"go", "test", "./..."}
+	case ProjectPython:
+		return []string{"python3", "-m", "pytest"}
+	case ProjectRust:
+		return []string{"cargo", "test"}
+	case ProjectJava:
+		if _, err := os.Stat(filepath.Join(projectPath, "gradlew")); err == nil {
+			return []string{"./gradlew", "test"}
+		}
+		if _, err := os.Stat(filepath.Join(projectPath, "pom.xml")); err == nil {
+			return []string{"mvn", "test"}
+		}
+		return []string{"gradle", "test"}
+	case ProjectNode:
+		return []string{"npm", "test"}
+	}
+	return nil
+}
+
+// nodeTestRunner picks the unit test runner from package.json dependencies
+func nodeTestRunner(projectPath string) TestRunner {
+	data, err := os.ReadFile(filepath.Join(projectPath, "package.json"))
+	if err != nil {
+		return RunnerUnknown
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return RunnerUnknown
+	}
+	for _, deps := range []map[string]string{pkg.DevDependencies, pkg.Dependencies} {
+		if _, ok := deps["vitest"]; ok {
+			return RunnerVitest
+		}
+		if _, ok := deps["jest"]; ok {
+			return RunnerJest
+		}
+	}
+	return RunnerUnknown
+}
+
+func relativePaths(projectPath string, paths []string) []string {
+	rel := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if r, err := filepath.Rel(projectPath, path); err == nil {
+			path = r
+		}
+		rel = append(rel, path)
+	}
+	return rel
+}
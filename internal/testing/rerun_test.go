@@ -0,0 +1,125 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	gotesting "testing"
+	"time"
+
+	"projecthub/internal/structure"
+)
+
+func TestAffectedTests(t *gotesting.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":               `{"devDependencies": {"vitest": "^1.0.0"}}`,
+		"src/math.ts":                "export const add = (a: number, b: number) => a + b\n",
+		"src/math.test.ts":           "import { add } from './math'\n",
+		"src/calc.ts":                "import { add } from './math.js'\nexport const sum = (xs: number[]) => xs.reduce(add, 0)\n",
+		"src/__tests__/calc.test.ts": "import {\n  sum\n} from '../calc'\n",
+		"src/util/index.ts":          "export const noop = () => {}\n",
+		"src/other.test.ts":          "import { noop } from './util'\n",
+		"e2e/app.spec.ts":            "import { sum } from '../src/calc'\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := NewRerunWatcher(structure.NewScanner())
+	tests := []struct {
+		changed string
+		want    []string
+	}{
+		// Named after the file, imported directly and through calc.ts; the e2e spec is left out
+		{"src/math.ts", []string{"src/__tests__/calc.test.ts", "src/math.test.ts"}},
+		{"src/calc.ts", []string{"src/__tests__/calc.test.ts"}},
+		{"src/util/index.ts", []string{"src/other.test.ts"}},
+		{"src/other.test.ts", []string{"src/other.test.ts"}},
+	}
+	for _, tt := range tests {
+		affected := w.AffectedTests(dir, ProjectNode, []string{filepath.Join(dir, tt.changed)})
+		got := strings.Join(relativePaths(dir, affected), ",")
+		if want := strings.Join(tt.want, ","); filepath.ToSlash(got) != want {
+			t.Errorf("AffectedTests(%s) = %s, want %s", tt.changed, got, want)
+		}
+	}
+
+	argv, _ := rerunCommand(dir, ProjectNode, []string{filepath.Join(dir, "src/math.test.ts")})
+	if got := strings.Join(argv, " "); got != "npx vitest run "+filepath.Join("src", "math.test.ts") {
+		t.Errorf("rerunCommand = %q", got)
+	}
+}
+
+func TestTestCommand(t *gotesting.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{[]string{"go.mod"}, "go test ./..."},
+		{[]string{"package.json"}, "npm test"},
+		{[]string{"pyproject.toml"}, "python3 -m pytest"},
+		{[]string{"Cargo.toml"}, "cargo test"},
+		{[]string{"pom.xml"}, "mvn test"},
+		{[]string{"build.gradle", "gradlew"}, "./gradlew test"},
+		{[]string{"build.gradle"}, "gradle test"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		for _, name := range tt.files {
+			if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := strings.Join(TestCommand(dir), " "); got != tt.want {
+			t.Errorf("TestCommand(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestRerunWatcherRunsOnNotifiedChange(t *gotesting.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/watched\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w := NewRerunWatcher(nil)
+	done := make(chan RerunResult, 1)
+	w.SetUpdateHandler(func(result *RerunResult) {
+		if !result.Running {
+			done <- *result
+		}
+	})
+	if err := w.WatchProject(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer w.StopAll()
+
+	// A directory created after watching started is watched too
+	if err := os.MkdirAll(filepath.Join(dir, "calc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	test := "package calc\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {}\n"
+	if err := os.WriteFile(filepath.Join(dir, "calc", "calc_test.go"), []byte(test), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case result := <-done:
+		if got := strings.Join(result.ChangedFiles, ","); got != filepath.Join("calc", "calc_test.go") {
+			t.Errorf("changed files = %s", got)
+		}
+		if result.ExitCode != 0 || result.Summary == nil || result.Summary.Status != StatusPassed {
+			t.Errorf("run = exit %d, summary %+v, error %q", result.ExitCode, result.Summary, result.Error)
+		}
+	case <-time.After(60 * time.Second):
+		t.Fatal("no rerun after the change")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,13 +20,17 @@ type TestDiscovery struct {
 	TestFiles        []TestFileInfo `json:"testFiles"`
 	ScannedAt        time.Time      `json:"scannedAt"`
 	ProjectPath      string         `json:"projectPath"`
+
+	// Suggested command per test type, for the framework with the most tests of that type
+	Commands map[string]string `json:"commands"`
 }
 
 // TestFileInfo represents information about a single test file
 type TestFileInfo struct {
 	Path      string `json:"path"`
 	TestCount int    `json:"testCount"`
-	Type      string `json:"type"` // unit, e2e, integration
+	Type      string `json:"type"`                // unit, e2e, integration
+	Framework string `json:"framework,omitempty"` // vitest, jest, playwright, cypress, mocha, go, pytest or cargo
 }
 
 // TestScanner handles scanning projects for test files
@@ -51,9 +56,12 @@ var testFilePatterns = []string{
 	"*.spec.tsx",
 	"*.spec.js",
 	"*.spec.jsx",
+	"*.cy.ts",
+	"*.cy.js",
 	"*_test.go",
 	"test_*.py",
 	"*_test.py",
+	"*.rs", // When it contains #[test] functions
 }
 
 // Directories to skip
@@ -70,12 +78,14 @@ var skipDirs = map[string]bool{
 	".pytest_cache":  true,
 	".venv":          true,
 	"venv":           true,
+	"target":         true, // Cargo and Maven build output
 }
 
 // Regex patterns for counting tests
 var jsTestPattern = regexp.MustCompile(`(?m)^\s*(?:it|test)\s*\(`)
 var goTestPattern = regexp.MustCompile(`(?m)^func\s+Test\w+\s*\(`)
-var pyTestPattern = regexp.MustCompile(`(?m)^def\s+test_\w+\s*\(`)
+var pyTestPattern = regexp.MustCompile(`(?m)^\s*(?:async\s+)?def\s+test_\w+\s*\(`) // Functions and TestCase methods
+var rustTestPattern = regexp.MustCompile(`(?m)^\s*#\[(?:(?:\w+::)?test(?:\([^\]]*\))?|rstest)\]`) // #[test], #[tokio::test(...)], #[rstest]
 
 // ScanProjectTests scans a project directory for test files and counts tests
 func (s *TestScanner) ScanProjectTests(projectPath string) (*TestDiscovery, error) {
@@ -83,7 +93,9 @@ func (s *TestScanner) ScanProjectTests(projectPath string) (*TestDiscovery, erro
 		TestFiles:   make([]TestFileInfo, 0),
 		ScannedAt:   time.Now(),
 		ProjectPath: projectPath,
+		Commands:    make(map[string]string),
 	}
+	packages := make(map[string]map[string]bool) // dir -> dependencies of its nearest package.json
 
 	err := filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -98,8 +110,9 @@ func (s *TestScanner) ScanProjectTests(projectPath string) (*TestDiscovery, erro
 			return nil
 		}
 
-		// Check if file matches test patterns
-		if !isTestFile(info.Name()) {
+		// Check if file matches test patterns; Rust tests live in any source file
+		isRust := strings.HasSuffix(strings.ToLower(info.Name()), ".rs")
+		if !isRust && !isTestFile(info.Name()) {
 			return nil
 		}
 
@@ -128,6 +141,7 @@ func (s *TestScanner) ScanProjectTests(projectPath string) (*TestDiscovery, erro
 			Path:      relPath,
 			TestCount: testCount,
 			Type:      testType,
+			Framework: frameworkHint(path, string(content), projectPath, packages),
 		}
 
 		discovery.TestFiles = append(discovery.TestFiles, fileInfo)
@@ -149,6 +163,7 @@ func (s *TestScanner) ScanProjectTests(projectPath string) (*TestDiscovery, erro
 	if err != nil {
 		return nil, err
 	}
+	discovery.Commands = suggestCommands(discovery.TestFiles)
 
 	// Cache the result
 	s.mu.Lock()
@@ -195,6 +210,7 @@ func isTestFile(filename string) bool {
 	testPatterns := []string{
 		".test.ts", ".test.tsx", ".test.js", ".test.jsx",
 		".spec.ts", ".spec.tsx", ".spec.js", ".spec.jsx",
+		".cy.ts", ".cy.js",
 		"_test.go", "_test.py",
 	}
 
@@ -232,6 +248,11 @@ func countTests(filename, content string) int {
 		return len(pyTestPattern.FindAllString(content, -1))
 	}
 
+	// Rust tests
+	if strings.HasSuffix(lowerName, ".rs") {
+		return len(rustTestPattern.FindAllString(content, -1))
+	}
+
 	return 0
 }
 
@@ -255,10 +276,174 @@ func classifyTestType(filePath, projectPath string) string {
 		}
 	}
 
+	// Cargo builds each file in a crate's tests/ directory as an integration test
+	if strings.HasSuffix(lowerPath, ".rs") && strings.Contains(filepath.ToSlash(lowerPath), "/tests/") {
+		return "integration"
+	}
+
 	// Default to unit tests
 	return "unit"
 }
 
+// frameworkHint guesses the framework that runs a test file, from its
+// language, imports and the dependencies of the nearest package.json
+func frameworkHint(path, content, projectPath string, packages map[string]map[string]bool) string {
+	lowerPath := strings.ToLower(filepath.ToSlash(path))
+	switch filepath.Ext(lowerPath) {
+	case ".go":
+		return "go"
+	case ".py":
+		return "pytest" // Also runs unittest TestCase classes
+	case ".rs":
+		return "cargo"
+	}
+
+	switch {
+	case strings.Contains(content, "@playwright/test"):
+		return "playwright"
+	case strings.Contains(lowerPath, "/cypress/") || strings.Contains(lowerPath, ".cy."):
+		return "cypress"
+	case vitestImportPattern.MatchString(content):
+		return "vitest"
+	case jestImportPattern.MatchString(content):
+		return "jest"
+	}
+
+	deps := packageDependencies(filepath.Dir(path), projectPath, packages)
+	for _, framework := range []string{"vitest", "jest", "mocha"} {
+		if deps[framework] {
+			return framework
+		}
+	}
+	return ""
+}
+
+// Imports that name the test framework of a JS/TS file
+var (
+	vitestImportPattern = regexp.MustCompile(`(?:from\s*|require\(\s*)['"]vitest['"]`)
+	jestImportPattern   = regexp.MustCompile(`(?:from\s*|require\(\s*)['"]@jest/globals['"]`)
+)
+
+// packageDependencies returns the dependencies and devDependencies of the
+// package.json nearest to dir, looking no higher than the project root
+func packageDependencies(dir, projectPath string, packages map[string]map[string]bool) map[string]bool {
+	if deps, ok := packages[dir]; ok {
+		return deps
+	}
+
+	var deps map[string]bool
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err == nil {
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		}
+		json.Unmarshal(data, &pkg)
+		deps = make(map[string]bool)
+		for name := range pkg.Dependencies {
+			deps[name] = true
+		}
+		for name := range pkg.DevDependencies {
+			deps[name] = true
+		}
+	} else if parent := filepath.Dir(dir); dir != filepath.Clean(projectPath) && parent != dir {
+		deps = packageDependencies(parent, projectPath, packages)
+	}
+	packages[dir] = deps
+	return deps
+}
+
+// suggestCommands picks, for each test type, the framework with the most
+// tests of that type and the command that runs them
+func suggestCommands(files []TestFileInfo) map[string]string {
+	type tally struct {
+		counts map[string]int
+		dirs   map[string][]string // framework -> directories of its test files
+	}
+	byType := make(map[string]*tally)
+	for _, file := range files {
+		if file.Framework == "" {
+			continue
+		}
+		t, ok := byType[file.Type]
+		if !ok {
+			t = &tally{counts: make(map[string]int), dirs: make(map[string][]string)}
+			byType[file.Type] = t
+		}
+		t.counts[file.Framework] += file.TestCount
+		dir := filepath.ToSlash(filepath.Dir(file.Path))
+		if !containsString(t.dirs[file.Framework], dir) {
+			t.dirs[file.Framework] = append(t.dirs[file.Framework], dir)
+		}
+	}
+
+	commands := make(map[string]string)
+	for testType, t := range byType {
+		best := ""
+		for framework, count := range t.counts {
+			if best == "" || count > t.counts[best] || (count == t.counts[best] && framework < best) {
+				best = framework
+			}
+		}
+		dirs := t.dirs[best]
+		sort.Strings(dirs)
+		if command := suggestCommand(best, testType, dirs); command != "" {
+			commands[testType] = command
+		}
+	}
+	return commands
+}
+
+// suggestCommand returns the command that runs a framework's tests of one
+// type; types other than unit are narrowed to the directories holding them
+func suggestCommand(framework, testType string, dirs []string) string {
+	narrow := ""
+	if testType != "unit" {
+		narrow = " " + strings.Join(dirs, " ")
+	}
+	switch framework {
+	case "go":
+		if testType == "unit" {
+			return "go test ./..."
+		}
+		packages := make([]string, len(dirs))
+		for i, dir := range dirs {
+			packages[i] = dir
+			if dir != "." {
+				packages[i] = "./" + dir
+			}
+		}
+		return "go test " + strings.Join(packages, " ")
+	case "pytest":
+		return "pytest" + narrow
+	case "cargo":
+		if testType == "integration" {
+			return "cargo test --test '*'"
+		}
+		return "cargo test"
+	case "vitest":
+		return "npx vitest run" + narrow
+	case "jest":
+		return "npx jest" + narrow
+	case "playwright":
+		return "npx playwright test" + narrow
+	case "cypress":
+		return "npx cypress run"
+	case "mocha":
+		return "npx mocha"
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // ClearCache clears the discovery cache for a project
 func (s *TestScanner) ClearCache(projectPath string) {
 	s.mu.Lock()
@@ -0,0 +1,81 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	gotesting "testing"
+)
+
+func TestScanProjectTests(t *gotesting.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"package.json":                `{"devDependencies": {"jest": "^29.0.0"}}`,
+		"src/math.test.js":            "test('adds', () => {})\ntest('subtracts', () => {})\n",
+		"web/package.json":            `{"devDependencies": {"vitest": "^1.0.0"}}`,
+		"web/app.spec.ts":             "it('renders', () => {})\n",
+		"e2e/home.spec.ts":            "import { test } from '@playwright/test'\ntest('has title', async () => {})\n",
+		"pkg/calc/calc_test.go":       "package calc\n\nfunc TestAdd(t *testing.T) {}\nfunc TestSub(t *testing.T) {}\nfunc TestMul(t *testing.T) {}\n",
+		"api/integration/api_test.go": "package integration\n\nfunc TestServe(t *testing.T) {}\nfunc TestStop(t *testing.T) {}\n",
+		"tests/test_models.py":        "def test_save():\n    pass\n\nclass TestUser(unittest.TestCase):\n    def test_name(self):\n        pass\n",
+		"crate/src/lib.rs":            "#[cfg(test)]\nmod tests {\n    #[test]\n    fn adds() {}\n    #[tokio::test(flavor = \"multi_thread\")]\n    async fn fetches() {}\n}\n",
+		"crate/src/main.rs":           "fn main() {}\n",
+		"crate/tests/api.rs":          "#[test]\nfn serves() {}\n",
+		"crate/target/debug/gen.rs":   "#[test]\nfn generated() {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	discovery, err := NewTestScanner().ScanProjectTests(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		count     int
+		testType  string
+		framework string
+	}
+	wantFiles := map[string]want{
+		"src/math.test.js":            {2, "unit", "jest"},
+		"web/app.spec.ts":             {1, "unit", "vitest"},
+		"e2e/home.spec.ts":            {1, "e2e", "playwright"},
+		"pkg/calc/calc_test.go":       {3, "unit", "go"},
+		"api/integration/api_test.go": {2, "integration", "go"},
+		"tests/test_models.py":        {2, "unit", "pytest"},
+		"crate/src/lib.rs":            {2, "unit", "cargo"},
+		"crate/tests/api.rs":          {1, "integration", "cargo"},
+	}
+	if len(discovery.TestFiles) != len(wantFiles) {
+		t.Errorf("found %d test files, want %d: %+v", len(discovery.TestFiles), len(wantFiles), discovery.TestFiles)
+	}
+	for _, file := range discovery.TestFiles {
+		w, ok := wantFiles[filepath.ToSlash(file.Path)]
+		if !ok {
+			t.Errorf("unexpected test file %s", file.Path)
+			continue
+		}
+		if file.TestCount != w.count || file.Type != w.testType || file.Framework != w.framework {
+			t.Errorf("%s: count, type, framework = %d, %s, %s; want %d, %s, %s",
+				file.Path, file.TestCount, file.Type, file.Framework, w.count, w.testType, w.framework)
+		}
+	}
+
+	// Each type runs with the framework that has most of its tests
+	wantCommands := map[string]string{
+		"unit":        "go test ./...",
+		"integration": "go test ./api/integration",
+		"e2e":         "npx playwright test e2e",
+	}
+	for testType, command := range wantCommands {
+		if got := discovery.Commands[testType]; got != command {
+			t.Errorf("command for %s = %q, want %q", testType, got, command)
+		}
+	}
+}
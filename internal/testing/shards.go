@@ -0,0 +1,449 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxShards caps how many test processes a sharded run starts
+const maxShards = 16
+
+// Sharding strategies
+const (
+	ShardByFile   = "file"   // Balance shards by the number of tests in each file
+	ShardByTiming = "timing" // Balance by each file's time in earlier sharded runs
+)
+
+// ShardRun is a test suite split across several processes
+type ShardRun struct {
+	ProjectPath string       `json:"projectPath"`
+	Strategy    string       `json:"strategy"`
+	Shards      []Shard      `json:"shards"`
+	Summary     *TestSummary `json:"summary"` // Merged over the shards
+	Running     bool         `json:"running"`
+	Error       string       `json:"error,omitempty"`
+	StartTime   time.Time    `json:"startTime"`
+	EndTime     time.Time    `json:"endTime,omitempty"`
+}
+
+// Shard is one process of a sharded run
+type Shard struct {
+	Index     int          `json:"index"`
+	TestFiles []string     `json:"testFiles"` // Relative to the project
+	Tests     int          `json:"tests"`     // Tests found in the files by discovery
+	Command   string       `json:"command"`
+	Summary   *TestSummary `json:"summary"`
+	Running   bool         `json:"running"`
+	ExitCode  int          `json:"exitCode"`
+	Error     string       `json:"error,omitempty"`
+	EndTime   time.Time    `json:"endTime,omitempty"`
+}
+
+// ShardRunner splits a project's test suite across processes, runs them in
+// parallel and merges their results
+type ShardRunner struct {
+	mu       sync.Mutex
+	projects map[string]*shardedProject // projectPath -> state
+	scanner  *TestScanner
+	onUpdate func(run *ShardRun)
+}
+
+type shardedProject struct {
+	cancel  context.CancelFunc // cancels the current run
+	last    *ShardRun
+	timings map[string]float64 // test file -> milliseconds in earlier runs
+}
+
+// shardUnit is what a shard gets whole: a file, or a Go package
+type shardUnit struct {
+	files  []string // Relative to the project
+	tests  int
+	weight float64
+}
+
+// NewShardRunner creates a shard runner that finds test files with scanner
+func NewShardRunner(scanner *TestScanner) *ShardRunner {
+	return &ShardRunner{
+		projects: make(map[string]*shardedProject),
+		scanner:  scanner,
+	}
+}
+
+// SetUpdateHandler sets the callback for shard progress and results
+func (r *ShardRunner) SetUpdateHandler(handler func(run *ShardRun)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onUpdate = handler
+}
+
+// Start splits the project's unit and integration tests into shards and
+// runs them in the background
+func (r *ShardRunner) Start(projectPath string, shards int, strategy string) (*ShardRun, error) {
+	if shards < 1 || shards > maxShards {
+		return nil, fmt.Errorf("shard count must be between 1 and %d", maxShards)
+	}
+	if strategy != ShardByFile && strategy != ShardByTiming {
+		return nil, fmt.Errorf("unknown sharding strategy: %s", strategy)
+	}
+	projectType := DetectProjectType(projectPath)
+	if argv, _ := rerunCommand(projectPath, projectType, nil); argv == nil {
+		return nil, fmt.Errorf("sharding is not supported for this project")
+	}
+
+	r.mu.Lock()
+	project, ok := r.projects[projectPath]
+	if !ok {
+		project = &shardedProject{timings: make(map[string]float64)}
+		r.projects[projectPath] = project
+	}
+	if project.cancel != nil {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("a sharded run is already in progress")
+	}
+	timings := make(map[string]float64, len(project.timings))
+	for file, ms := range project.timings {
+		timings[file] = ms
+	}
+	r.mu.Unlock()
+
+	discovery, err := r.scanner.ScanProjectTests(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	units := shardUnits(projectType, discovery.TestFiles)
+	if len(units) == 0 {
+		return nil, fmt.Errorf("no test files found")
+	}
+	if strategy == ShardByTiming {
+		weighByTiming(units, timings)
+	}
+
+	run := &ShardRun{
+		ProjectPath: projectPath,
+		Strategy:    strategy,
+		Running:     true,
+		StartTime:   time.Now(),
+	}
+	var commands [][]string
+	var runners []TestRunner
+	for i, shard := range partitionUnits(units, shards) {
+		abs := make([]string, len(shard.files))
+		for j, file := range shard.files {
+			abs[j] = filepath.Join(projectPath, file)
+		}
+		argv, runner := rerunCommand(projectPath, projectType, abs)
+		run.Shards = append(run.Shards, Shard{
+			Index:     i + 1,
+			TestFiles: shard.files,
+			Tests:     shard.tests,
+			Command:   strings.Join(argv, " "),
+			Running:   true,
+		})
+		commands = append(commands, argv)
+		runners = append(runners, runner)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mu.Lock()
+	if project.cancel != nil {
+		r.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("a sharded run is already in progress")
+	}
+	project.cancel = cancel
+	project.last = run
+	snapshot := copyShardRun(run)
+	r.mu.Unlock()
+
+	go r.runShards(ctx, cancel, run, commands, runners)
+	return snapshot, nil
+}
+
+// Stop cancels a project's sharded run
+func (r *ShardRunner) Stop(projectPath string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if project, ok := r.projects[projectPath]; ok && project.cancel != nil {
+		project.cancel()
+	}
+}
+
+// StopAll cancels every sharded run
+func (r *ShardRunner) StopAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, project := range r.projects {
+		if project.cancel != nil {
+			project.cancel()
+		}
+	}
+}
+
+// GetLastRun returns the latest sharded run of a project, or nil
+func (r *ShardRunner) GetLastRun(projectPath string) *ShardRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if project, ok := r.projects[projectPath]; ok && project.last != nil {
+		return copyShardRun(project.last)
+	}
+	return nil
+}
+
+// runShards runs every shard in parallel and merges the results as they come in
+func (r *ShardRunner) runShards(ctx context.Context, cancel context.CancelFunc, run *ShardRun, commands [][]string, runners []TestRunner) {
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range commands {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started := time.Now()
+			summary, exitCode, err := runTestProcess(ctx, run.ProjectPath, commands[i], runners[i], func(summary TestSummary) {
+				r.update(run, func(run *ShardRun) { run.Shards[i].Summary = &summary })
+			})
+			if summary != nil {
+				summary = finalizeRerunSummary(*summary, exitCode)
+			}
+			r.update(run, func(run *ShardRun) {
+				shard := &run.Shards[i]
+				shard.Summary = summary
+				shard.Running = false
+				shard.ExitCode = exitCode
+				shard.EndTime = time.Now()
+				if err != nil {
+					shard.Error = err.Error()
+				}
+			})
+			if err == nil {
+				r.recordTimings(run.ProjectPath, run.Shards[i], time.Since(started))
+			} else if ctx.Err() == nil {
+				slog.Warn("Test shard failed", "project", run.ProjectPath, "shard", i+1, "error", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	if project, ok := r.projects[run.ProjectPath]; ok && project.last == run {
+		project.cancel = nil
+	}
+	r.mu.Unlock()
+	r.update(run, func(run *ShardRun) {
+		run.Running = false
+		run.EndTime = time.Now()
+		if ctx.Err() != nil {
+			run.Error = "stopped"
+		}
+	})
+}
+
+// update applies a change to a run, re-merges the shard summaries and sends
+// a copy to the handler
+func (r *ShardRunner) update(run *ShardRun, change func(run *ShardRun)) {
+	r.mu.Lock()
+	change(run)
+	run.Summary = mergeShardSummaries(run)
+	snapshot := copyShardRun(run)
+	handler := r.onUpdate
+	r.mu.Unlock()
+
+	if handler != nil {
+		handler(snapshot)
+	}
+}
+
+// recordTimings spreads a finished shard's time over its files by their
+// test counts, for balancing later runs by timing
+func (r *ShardRunner) recordTimings(projectPath string, shard Shard, elapsed time.Duration) {
+	if shard.Tests == 0 || len(shard.TestFiles) == 0 {
+		return
+	}
+	counts := make(map[string]int)
+	if discovery := r.scanner.GetCachedDiscovery(projectPath); discovery != nil {
+		for _, file := range discovery.TestFiles {
+			counts[file.Path] = file.TestCount
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	project, ok := r.projects[projectPath]
+	if !ok {
+		return
+	}
+	perTest := float64(elapsed.Milliseconds()) / float64(shard.Tests)
+	for _, file := range shard.TestFiles {
+		if count := counts[file]; count > 0 {
+			project.timings[file] = perTest * float64(count)
+		}
+	}
+}
+
+// shardUnits groups the test files a project's runner can take into units
+// weighted by test count. Go tests are run per package, so a package's files
+// stay together; end-to-end tests need their own runner and are left out.
+func shardUnits(projectType ProjectType, files []TestFileInfo) []*shardUnit {
+	byKey := make(map[string]*shardUnit)
+	var keys []string
+	for _, file := range files {
+		if file.Type == "e2e" || !shardableFile(projectType, file.Path) {
+			continue
+		}
+		key := file.Path
+		if projectType == ProjectGo {
+			key = filepath.Dir(file.Path)
+		}
+		unit, ok := byKey[key]
+		if !ok {
+			unit = &shardUnit{}
+			byKey[key] = unit
+			keys = append(keys, key)
+		}
+		unit.files = append(unit.files, file.Path)
+		unit.tests += file.TestCount
+		unit.weight += float64(file.TestCount)
+	}
+
+	sort.Strings(keys)
+	units := make([]*shardUnit, 0, len(keys))
+	for _, key := range keys {
+		units = append(units, byKey[key])
+	}
+	return units
+}
+
+// shardableFile reports whether the project's test command runs a test file
+func shardableFile(projectType ProjectType, path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".go":
+		return projectType == ProjectGo
+	case ".py":
+		return projectType == ProjectPython
+	case ".js", ".jsx", ".ts", ".tsx":
+		return projectType == ProjectNode
+	}
+	return false
+}
+
+// weighByTiming replaces test-count weights with earlier timings. Files
+// without one are estimated from the average time per test of those with.
+func weighByTiming(units []*shardUnit, timings map[string]float64) {
+	var timedMs float64
+	var timedTests int
+	for _, unit := range units {
+		for _, file := range unit.files {
+			if ms, ok := timings[file]; ok {
+				timedMs += ms
+			}
+		}
+	}
+	if timedMs == 0 {
+		return // Nothing timed yet; test counts it is
+	}
+	for _, unit := range units {
+		if unitTimed(unit, timings) {
+			timedTests += unit.tests
+		}
+	}
+	perTest := timedMs / float64(max(timedTests, 1))
+
+	for _, unit := range units {
+		if !unitTimed(unit, timings) {
+			unit.weight = float64(unit.tests) * perTest
+			continue
+		}
+		unit.weight = 0
+		for _, file := range unit.files {
+			unit.weight += timings[file]
+		}
+	}
+}
+
+func unitTimed(unit *shardUnit, timings map[string]float64) bool {
+	for _, file := range unit.files {
+		if _, ok := timings[file]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// partitionUnits deals units into at most n shards, heaviest first onto the
+// lightest shard, so the shards finish at about the same time
+func partitionUnits(units []*shardUnit, n int) []shardUnit {
+	sorted := append([]*shardUnit(nil), units...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].weight > sorted[j].weight })
+
+	shards := make([]shardUnit, min(n, len(units)))
+	for _, unit := range sorted {
+		lightest := 0
+		for i := range shards {
+			if shards[i].weight < shards[lightest].weight {
+				lightest = i
+			}
+		}
+		shards[lightest].files = append(shards[lightest].files, unit.files...)
+		shards[lightest].tests += unit.tests
+		shards[lightest].weight += unit.weight
+	}
+	for i := range shards {
+		sort.Strings(shards[i].files)
+	}
+	return shards
+}
+
+// mergeShardSummaries adds up the shard summaries into one for the whole run
+func mergeShardSummaries(run *ShardRun) *TestSummary {
+	merged := &TestSummary{Runner: RunnerUnknown, Status: StatusRunning, StartTime: run.StartTime}
+	running := false
+	for _, shard := range run.Shards {
+		running = running || shard.Running
+		if shard.Summary == nil {
+			continue
+		}
+		summary := shard.Summary
+		if merged.Runner == RunnerUnknown {
+			merged.Runner = summary.Runner
+		}
+		merged.Passed += summary.Passed
+		merged.Failed += summary.Failed
+		merged.Skipped += summary.Skipped
+		merged.Total += summary.Total
+		merged.FailedTests = append(merged.FailedTests, summary.FailedTests...)
+		merged.TestDurations = append(merged.TestDurations, summary.TestDurations...)
+		merged.Failures = append(merged.Failures, summary.Failures...)
+		if shard.ExitCode != 0 && summary.Failed == 0 {
+			merged.Failed++ // A shard that crashed without reporting failures still failed
+		}
+	}
+	if running {
+		return merged
+	}
+
+	merged.EndTime = time.Now()
+	merged.Duration = float64(merged.EndTime.Sub(run.StartTime).Milliseconds())
+	switch {
+	case merged.Failed > 0 && merged.Passed > 0:
+		merged.Status = StatusMixed
+	case merged.Failed > 0:
+		merged.Status = StatusFailed
+	default:
+		merged.Status = StatusPassed
+	}
+	return merged
+}
+
+// copyShardRun copies a run for handing out; summaries are replaced, never
+// changed in place, so they can be shared
+func copyShardRun(run *ShardRun) *ShardRun {
+	snapshot := *run
+	snapshot.Shards = append([]Shard(nil), run.Shards...)
+	return &snapshot
+}
@@ -0,0 +1,94 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	gotesting "testing"
+	"time"
+)
+
+func TestPartitionUnits(t *gotesting.T) {
+	files := []TestFileInfo{
+		{Path: "pkg/a/a_test.go", TestCount: 6, Type: "unit"},
+		{Path: "pkg/a/b_test.go", TestCount: 2, Type: "unit"},
+		{Path: "pkg/c/c_test.go", TestCount: 5, Type: "unit"},
+		{Path: "pkg/d/d_test.go", TestCount: 3, Type: "unit"},
+		{Path: "web/app.test.ts", TestCount: 9, Type: "unit"},
+	}
+	units := shardUnits(ProjectGo, files)
+	if len(units) != 3 {
+		t.Fatalf("got %d units, want 3 packages", len(units))
+	}
+
+	shards := partitionUnits(units, 2)
+	var got []string
+	for _, shard := range shards {
+		got = append(got, strings.Join(shard.files, ","))
+	}
+	want := []string{"pkg/a/a_test.go,pkg/a/b_test.go", "pkg/c/c_test.go,pkg/d/d_test.go"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("partition = %v, want %v", got, want)
+	}
+
+	// Timings outweigh test counts; untimed files are estimated per test
+	weighByTiming(units, map[string]float64{"pkg/a/a_test.go": 100, "pkg/c/c_test.go": 900})
+	shards = partitionUnits(units, 2)
+	if got := strings.Join(shards[0].files, ","); got != "pkg/c/c_test.go" {
+		t.Errorf("slowest shard = %s, want pkg/c/c_test.go", got)
+	}
+
+	if shards := partitionUnits(units, 8); len(shards) != 3 {
+		t.Errorf("got %d shards for 3 units, want 3", len(shards))
+	}
+}
+
+func TestShardRunnerMergesShards(t *gotesting.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":           "module shardtest\n\ngo 1.21\n",
+		"a/a_test.go":      "package a\n\nimport \"testing\"\n\nfunc TestOne(t *testing.T) {}\n\nfunc TestTwo(t *testing.T) {}\n",
+		"b/b_test.go":      "package b\n\nimport \"testing\"\n\nfunc TestThree(t *testing.T) { t.Fatal(\"boom\") }\n",
+		"c/c_test.go":      "package c\n\nimport \"testing\"\n\nfunc TestFour(t *testing.T) {}\n",
+		"c/helper_test.go": "package c\n\nimport \"testing\"\n\nfunc TestFive(t *testing.T) {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := NewShardRunner(NewTestScanner())
+	done := make(chan *ShardRun, 16)
+	r.SetUpdateHandler(func(run *ShardRun) {
+		if !run.Running {
+			done <- run
+		}
+	})
+	if _, err := r.Start(dir, 2, ShardByFile); err != nil {
+		t.Fatal(err)
+	}
+
+	var run *ShardRun
+	select {
+	case run = <-done:
+	case <-time.After(2 * time.Minute):
+		t.Fatal("sharded run did not finish")
+	}
+	if len(run.Shards) != 2 {
+		t.Fatalf("got %d shards, want 2", len(run.Shards))
+	}
+	if s := run.Summary; s.Passed != 4 || s.Failed != 1 || s.Status != StatusMixed {
+		t.Errorf("merged summary = %d passed, %d failed, %s", s.Passed, s.Failed, s.Status)
+	}
+	if len(run.Summary.Failures) != 1 || run.Summary.Failures[0].Test != "TestThree" {
+		t.Errorf("merged failures = %+v", run.Summary.Failures)
+	}
+	if _, err := r.Start(dir, 0, ShardByFile); err == nil {
+		t.Error("expected an error for 0 shards")
+	}
+}
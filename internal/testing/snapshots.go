@@ -0,0 +1,434 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotTimeout bounds a suite run made to check or update snapshots
+const snapshotTimeout = 10 * time.Minute
+
+// Snapshot entry statuses
+const (
+	SnapshotObsolete = "obsolete" // No test writes it anymore
+	SnapshotFailed   = "failed"   // The test's output no longer matches it
+)
+
+// SnapshotReport lists the Jest or Vitest snapshots of a project that need
+// attention
+type SnapshotReport struct {
+	ProjectPath   string          `json:"projectPath"`
+	Runner        TestRunner      `json:"runner"`
+	SnapshotFiles int             `json:"snapshotFiles"` // .snap files found
+	Snapshots     int             `json:"snapshots"`     // Snapshots stored in them
+	Entries       []SnapshotEntry `json:"entries"`
+	CheckedAt     time.Time       `json:"checkedAt"`
+}
+
+// SnapshotEntry is an obsolete or failed snapshot. An obsolete entry without
+// a key stands for a whole snapshot file whose test file is gone.
+type SnapshotEntry struct {
+	Status       string `json:"status"`
+	SnapshotFile string `json:"snapshotFile,omitempty"` // Relative to the project; empty for inline snapshots
+	TestFile     string `json:"testFile"`               // Relative to the project
+	Key          string `json:"key,omitempty"`          // As stored, e.g. "renders header 1"
+	Test         string `json:"test,omitempty"`         // Full test name
+	Diff         string `json:"diff,omitempty"`         // Snapshot against received, for failed entries
+}
+
+// snapshotResults is what Jest's --json and Vitest's json reporter share
+type snapshotResults struct {
+	Snapshot struct {
+		FilesRemovedList    []string `json:"filesRemovedList"`
+		UncheckedKeysByFile []struct {
+			FilePath string   `json:"filePath"`
+			Keys     []string `json:"keys"`
+		} `json:"uncheckedKeysByFile"`
+	} `json:"snapshot"`
+	TestResults []struct {
+		Name             string `json:"name"`
+		AssertionResults []struct {
+			FullName        string   `json:"fullName"`
+			Status          string   `json:"status"`
+			FailureMessages []string `json:"failureMessages"`
+		} `json:"assertionResults"`
+	} `json:"testResults"`
+}
+
+var (
+	// Jest: "Snapshot name: `renders header 1`"; Vitest: "Snapshot `renders header 1` mismatched"
+	snapshotNamePattern = regexp.MustCompile("(?m)^.*Snapshot (?:name: )?`(.+)`(?: mismatched)?\\s*$")
+	snapshotKeyCounter  = regexp.MustCompile(` \d+$`)
+	stackFramePattern   = regexp.MustCompile(`(?m)^\s+at .*\n?`)
+)
+
+// CheckSnapshots runs the project's Jest or Vitest suite without writing
+// snapshots and reports the obsolete ones and those that no longer match
+func CheckSnapshots(projectPath string) (*SnapshotReport, error) {
+	runner := nodeTestRunner(projectPath)
+	if runner != RunnerJest && runner != RunnerVitest {
+		return nil, fmt.Errorf("snapshot management needs jest or vitest")
+	}
+
+	outFile, err := os.CreateTemp("", "snapshots-*.json")
+	if err != nil {
+		return nil, err
+	}
+	outFile.Close()
+	defer os.Remove(outFile.Name())
+
+	argv := []string{"npx", "jest", "--ci", "--json", "--outputFile=" + outFile.Name()}
+	if runner == RunnerVitest {
+		argv = []string{"npx", "vitest", "run", "--reporter=json", "--outputFile=" + outFile.Name()}
+	}
+	output, err := runSnapshotCommand(projectPath, argv)
+	data, readErr := os.ReadFile(outFile.Name())
+	if readErr != nil || len(data) == 0 {
+		if err == nil {
+			err = fmt.Errorf("no results written")
+		}
+		return nil, fmt.Errorf("%s failed: %s", runner, lastLines(output, err))
+	}
+	var results snapshotResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to read %s results: %w", runner, err)
+	}
+
+	report := &SnapshotReport{
+		ProjectPath: projectPath,
+		Runner:      runner,
+		Entries:     []SnapshotEntry{},
+		CheckedAt:   time.Now(),
+	}
+	stored := findSnapshotFiles(projectPath)
+	report.SnapshotFiles = len(stored)
+	for _, file := range stored {
+		if content, err := os.ReadFile(file); err == nil {
+			report.Snapshots += len(parseSnapshotFile(string(content)))
+		}
+	}
+
+	// Snapshot files of deleted tests, whether or not the runner noticed them
+	obsoleteFiles := make(map[string]bool)
+	for _, file := range results.Snapshot.FilesRemovedList {
+		obsoleteFiles[file] = true
+	}
+	for _, file := range stored {
+		if !fileExists(snapshotTestFile(file)) {
+			obsoleteFiles[file] = true
+		}
+	}
+	for file := range obsoleteFiles {
+		report.Entries = append(report.Entries, SnapshotEntry{
+			Status:       SnapshotObsolete,
+			SnapshotFile: relativeTo(projectPath, file),
+			TestFile:     relativeTo(projectPath, snapshotTestFile(file)),
+		})
+	}
+
+	for _, unchecked := range results.Snapshot.UncheckedKeysByFile {
+		snapFile := snapshotFileFor(unchecked.FilePath)
+		for _, key := range unchecked.Keys {
+			report.Entries = append(report.Entries, SnapshotEntry{
+				Status:       SnapshotObsolete,
+				SnapshotFile: relativeTo(projectPath, snapFile),
+				TestFile:     relativeTo(projectPath, unchecked.FilePath),
+				Key:          key,
+				Test:         snapshotTestName(key),
+			})
+		}
+	}
+
+	for _, result := range results.TestResults {
+		for _, assertion := range result.AssertionResults {
+			if assertion.Status != "failed" {
+				continue
+			}
+			for _, message := range assertion.FailureMessages {
+				entry, ok := snapshotFailure(stripANSI(message))
+				if !ok {
+					continue
+				}
+				entry.TestFile = relativeTo(projectPath, result.Name)
+				entry.Test = assertion.FullName
+				if snapFile := snapshotFileFor(result.Name); fileExists(snapFile) && !strings.Contains(message, "InlineSnapshot") {
+					entry.SnapshotFile = relativeTo(projectPath, snapFile)
+				}
+				report.Entries = append(report.Entries, entry)
+			}
+		}
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.Status != b.Status {
+			return a.Status == SnapshotFailed // Failures first
+		}
+		if a.TestFile != b.TestFile {
+			return a.TestFile < b.TestFile
+		}
+		return a.Key < b.Key
+	})
+	return report, nil
+}
+
+// UpdateSnapshots rewrites the snapshots of the given entries from what
+// their tests produce now, running only those tests
+func UpdateSnapshots(projectPath string, entries []SnapshotEntry) error {
+	runner := nodeTestRunner(projectPath)
+	if runner != RunnerJest && runner != RunnerVitest {
+		return fmt.Errorf("snapshot management needs jest or vitest")
+	}
+
+	tests := make(map[string][]string) // test file -> test names
+	var files []string
+	for _, entry := range entries {
+		if entry.Test == "" || entry.TestFile == "" {
+			continue
+		}
+		if _, ok := tests[entry.TestFile]; !ok {
+			files = append(files, entry.TestFile)
+		}
+		tests[entry.TestFile] = append(tests[entry.TestFile], regexp.QuoteMeta(entry.Test))
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no snapshots to update")
+	}
+
+	for _, file := range files {
+		pattern := "^(?:" + strings.Join(tests[file], "|") + ")$"
+		argv := []string{"npx", "jest", "--ci=false", "--updateSnapshot", "--testNamePattern", pattern, file}
+		if runner == RunnerVitest {
+			argv = []string{"npx", "vitest", "run", "--update", "--testNamePattern", pattern, file}
+		}
+		if output, err := runSnapshotCommand(projectPath, argv); err != nil {
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) {
+				return fmt.Errorf("%s failed: %w", runner, err)
+			}
+			// Failing assertions other than snapshots still fail the run
+			if !strings.Contains(output, "updated") {
+				return fmt.Errorf("%s failed for %s: %s", runner, file, lastLines(output, err))
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteObsoleteSnapshots removes obsolete snapshots from their files, and
+// deletes snapshot files that end up empty or whose test file is gone
+func DeleteObsoleteSnapshots(projectPath string, entries []SnapshotEntry) (int, error) {
+	keys := make(map[string]map[string]bool) // snapshot file -> keys; nil for the whole file
+	for _, entry := range entries {
+		if entry.Status != SnapshotObsolete || entry.SnapshotFile == "" {
+			continue
+		}
+		path := filepath.Join(projectPath, filepath.FromSlash(entry.SnapshotFile))
+		if rel, err := filepath.Rel(projectPath, path); err != nil || strings.HasPrefix(rel, "..") || filepath.Ext(path) != ".snap" {
+			return 0, fmt.Errorf("not a snapshot file in the project: %s", entry.SnapshotFile)
+		}
+		set, ok := keys[path]
+		switch {
+		case entry.Key == "":
+			keys[path] = nil
+		case ok && set == nil:
+			// The whole file goes already
+		default:
+			if !ok {
+				set = make(map[string]bool)
+				keys[path] = set
+			}
+			set[entry.Key] = true
+		}
+	}
+
+	removed := 0
+	for path, set := range keys {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return removed, err
+		}
+		snapshots := parseSnapshotFile(string(content))
+		remaining := len(snapshots)
+		if set != nil {
+			var b strings.Builder
+			last := 0
+			for _, snapshot := range snapshots {
+				if !set[snapshot.key] {
+					continue
+				}
+				b.WriteString(string(content[last:snapshot.start]))
+				last = snapshot.end
+				remaining--
+				removed++
+			}
+			b.WriteString(string(content[last:]))
+			if remaining > 0 {
+				if err := os.WriteFile(path, []byte(strings.TrimRight(b.String(), "\n")+"\n"), 0644); err != nil {
+					return removed, err
+				}
+				continue
+			}
+		} else {
+			removed += remaining
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		// Leave no empty __snapshots__ directory behind
+		os.Remove(filepath.Dir(path))
+	}
+	return removed, nil
+}
+
+// storedSnapshot is one exports[`key`] = `value`; statement of a .snap
+// file, with its byte range including the blank line after it
+type storedSnapshot struct {
+	key        string
+	start, end int
+}
+
+// parseSnapshotFile finds the snapshots of a Jest or Vitest .snap file.
+// Keys and values are template literals with `, \ and ${ escaped.
+func parseSnapshotFile(content string) []storedSnapshot {
+	const prefix = "exports[`"
+	var snapshots []storedSnapshot
+	for pos := 0; pos < len(content); {
+		i := strings.Index(content[pos:], prefix)
+		if i < 0 {
+			break
+		}
+		start := pos + i
+		if start > 0 && content[start-1] != '\n' {
+			pos = start + len(prefix)
+			continue
+		}
+		key, next, ok := readTemplateLiteral(content, start+len(prefix))
+		if !ok || !strings.HasPrefix(content[next:], "] = `") {
+			break
+		}
+		_, next, ok = readTemplateLiteral(content, next+len("] = `"))
+		if !ok || !strings.HasPrefix(content[next:], ";") {
+			break
+		}
+		end := next + 1
+		for end < len(content) && content[end] == '\n' {
+			end++
+		}
+		snapshots = append(snapshots, storedSnapshot{key: key, start: start, end: end})
+		pos = end
+	}
+	return snapshots
+}
+
+// readTemplateLiteral reads up to the closing backtick from pos and returns
+// the unescaped text and the position after the backtick
+func readTemplateLiteral(content string, pos int) (string, int, bool) {
+	var b strings.Builder
+	for i := pos; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			if i+1 < len(content) {
+				i++
+				b.WriteByte(content[i])
+			}
+		case '`':
+			return b.String(), i + 1, true
+		default:
+			b.WriteByte(content[i])
+		}
+	}
+	return "", len(content), false
+}
+
+// snapshotFailure reads the snapshot key and diff from a failure message;
+// false when the failure isn't a snapshot mismatch
+func snapshotFailure(message string) (SnapshotEntry, bool) {
+	match := snapshotNamePattern.FindStringSubmatchIndex(message)
+	if match == nil {
+		return SnapshotEntry{}, false
+	}
+	diff := stackFramePattern.ReplaceAllString(message[match[1]:], "")
+	return SnapshotEntry{
+		Status: SnapshotFailed,
+		Key:    message[match[2]:match[3]],
+		Diff:   strings.TrimSpace(diff),
+	}, true
+}
+
+// snapshotTestName turns a snapshot key into the test's full name: the
+// counter goes, and Vitest's " > " between describe blocks becomes a space
+func snapshotTestName(key string) string {
+	return strings.ReplaceAll(snapshotKeyCounter.ReplaceAllString(key, ""), " > ", " ")
+}
+
+// findSnapshotFiles lists the .snap files in __snapshots__ directories
+func findSnapshotFiles(projectPath string) []string {
+	var files []string
+	filepath.WalkDir(projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != projectPath && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) == ".snap" && filepath.Base(filepath.Dir(path)) == "__snapshots__" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+// snapshotFileFor returns where a test file's snapshots are stored:
+// src/a.test.ts -> src/__snapshots__/a.test.ts.snap
+func snapshotFileFor(testFile string) string {
+	return filepath.Join(filepath.Dir(testFile), "__snapshots__", filepath.Base(testFile)+".snap")
+}
+
+// snapshotTestFile is the inverse of snapshotFileFor
+func snapshotTestFile(snapFile string) string {
+	return filepath.Join(filepath.Dir(filepath.Dir(snapFile)), strings.TrimSuffix(filepath.Base(snapFile), ".snap"))
+}
+
+func runSnapshotCommand(projectPath string, argv []string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = projectPath
+	cmd.Env = append(os.Environ(), "CI=1", "FORCE_COLOR=0")
+	output, err := cmd.CombinedOutput()
+	return stripANSI(string(output)), err
+}
+
+// lastLines keeps the end of a command's output for an error message
+func lastLines(output string, err error) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return err.Error()
+	}
+	if len(lines) > 10 {
+		lines = lines[len(lines)-10:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func relativeTo(projectPath, path string) string {
+	if rel, err := filepath.Rel(projectPath, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,81 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	gotesting "testing"
+)
+
+const snapFixture = "// Jest Snapshot v1, https://goo.gl/fbAQLP\n\n" +
+	"exports[`Header renders 1`] = `\n<h1>\n  Hello\n</h1>\n`;\n\n" +
+	"exports[`Header renders \\`quoted\\` title 1`] = `\"a \\` b\"`;\n\n" +
+	"exports[`Header old 1`] = `\"gone\"`;\n"
+
+func TestParseSnapshotFile(t *gotesting.T) {
+	snapshots := parseSnapshotFile(snapFixture)
+	want := []string{"Header renders 1", "Header renders `quoted` title 1", "Header old 1"}
+	if len(snapshots) != len(want) {
+		t.Fatalf("got %d snapshots, want %d", len(snapshots), len(want))
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.key != want[i] {
+			t.Errorf("key %d = %q, want %q", i, snapshot.key, want[i])
+		}
+	}
+
+	if got := snapshotTestName("Header > nested > renders 2"); got != "Header nested renders" {
+		t.Errorf("snapshotTestName = %q", got)
+	}
+
+	jest := "Error: expect(received).toMatchSnapshot()\n\nSnapshot name: `Header renders 1`\n\n- Snapshot  - 1\n+ Received  + 1\n\n-   Hello\n+   Hi\n    at Object.<anonymous> (src/Header.test.js:5:20)"
+	entry, ok := snapshotFailure(jest)
+	if !ok || entry.Key != "Header renders 1" || entry.Diff != "- Snapshot  - 1\n+ Received  + 1\n\n-   Hello\n+   Hi" {
+		t.Errorf("jest failure = %+v", entry)
+	}
+	vitest := "Error: Snapshot `Header > renders 1` mismatched\n\n- Expected\n+ Received\n\n- \"a\"\n+ \"b\""
+	if entry, ok := snapshotFailure(vitest); !ok || entry.Key != "Header > renders 1" {
+		t.Errorf("vitest failure = %+v", entry)
+	}
+	if _, ok := snapshotFailure("Error: expect(received).toBe(expected)"); ok {
+		t.Error("plain assertion read as a snapshot failure")
+	}
+}
+
+func TestDeleteObsoleteSnapshots(t *gotesting.T) {
+	dir := t.TempDir()
+	snapDir := filepath.Join(dir, "src", "__snapshots__")
+	if err := os.MkdirAll(snapDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	kept := filepath.Join(snapDir, "Header.test.js.snap")
+	orphan := filepath.Join(snapDir, "Old.test.js.snap")
+	os.WriteFile(kept, []byte(snapFixture), 0644)
+	os.WriteFile(orphan, []byte(snapFixture), 0644)
+
+	removed, err := DeleteObsoleteSnapshots(dir, []SnapshotEntry{
+		{Status: SnapshotObsolete, SnapshotFile: "src/__snapshots__/Header.test.js.snap", Key: "Header old 1"},
+		{Status: SnapshotFailed, SnapshotFile: "src/__snapshots__/Header.test.js.snap", Key: "Header renders 1"},
+		{Status: SnapshotObsolete, SnapshotFile: "src/__snapshots__/Old.test.js.snap"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 4 {
+		t.Errorf("removed %d snapshots, want 4", removed)
+	}
+
+	content, _ := os.ReadFile(kept)
+	if got := parseSnapshotFile(string(content)); len(got) != 2 || got[1].key != "Header renders `quoted` title 1" {
+		t.Errorf("kept snapshots = %+v", got)
+	}
+	if content[len(content)-2] == '\n' {
+		t.Error("trailing blank line left behind")
+	}
+	if fileExists(orphan) {
+		t.Error("obsolete snapshot file not deleted")
+	}
+
+	if _, err := DeleteObsoleteSnapshots(dir, []SnapshotEntry{{Status: SnapshotObsolete, SnapshotFile: "../outside.snap"}}); err == nil {
+		t.Error("expected an error for a path outside the project")
+	}
+}
@@ -28,6 +28,11 @@ const (
 	StatusMixed   TestStatus = "mixed" // Some passed, some failed
 )
 
+// IsFinal reports whether the status is the result of a finished run
+func (s TestStatus) IsFinal() bool {
+	return s == StatusPassed || s == StatusFailed || s == StatusMixed
+}
+
 // TestRunner identifies the test framework
 type TestRunner string
 
@@ -59,6 +64,8 @@ type TestSummary struct {
 	Total         int          `json:"total"`
 	Duration      float64      `json:"duration"` // in milliseconds
 	FailedTests   []TestResult `json:"failedTests,omitempty"`
+	TestDurations []TestResult `json:"testDurations,omitempty"` // Tests that printed a duration
+	Failures      []TestFailure `json:"failures,omitempty"`     // Where failed tests failed
 	StartTime     time.Time    `json:"startTime"`
 	EndTime       time.Time    `json:"endTime,omitempty"`
 	CoveragePercent float64    `json:"coveragePercent,omitempty"`
@@ -70,8 +77,13 @@ type TerminalTestState struct {
 	IsRunning    bool
 	LastActivity time.Time
 	OutputBuffer strings.Builder
+	ProjectType  ProjectType     // Detected from the terminal's working directory
+	runOutput    strings.Builder // Output since the run started, for output parsers
 }
 
+// maxRunOutput caps the output kept for output parsers
+const maxRunOutput = 1024 * 1024
+
 // Watcher analyzes terminal output to detect and parse test results
 type Watcher struct {
 	terminalStates map[string]*TerminalTestState
@@ -82,6 +94,11 @@ type Watcher struct {
 	summaryPatterns  map[TestRunner]*regexp.Regexp
 	failedPatterns   map[TestRunner]*regexp.Regexp
 	coveragePattern  *regexp.Regexp
+
+	// Output parsers for frameworks without built-in patterns, in detection order
+	parsers       []OutputParser
+	workDirFunc   func(termID string) string
+	finishHandler func(termID string, summary TestSummary)
 }
 
 // NewWatcher creates a new test output watcher
@@ -108,11 +125,6 @@ func NewWatcher() *Watcher {
 		regexp.MustCompile(`(?i)jest`),
 		regexp.MustCompile(`PASS|FAIL.*\.test\.(js|ts|jsx|tsx)`),
 	}
-	w.runnerPatterns[RunnerPytest] = []*regexp.Regexp{
-		regexp.MustCompile(`(?i)pytest`),
-		regexp.MustCompile(`=+ test session starts =+`),
-		regexp.MustCompile(`collected \d+ items?`),
-	}
 	w.runnerPatterns[RunnerGo] = []*regexp.Regexp{
 		regexp.MustCompile(`(?i)go test`),
 		regexp.MustCompile(`--- PASS:|--- FAIL:`),
@@ -137,15 +149,83 @@ func NewWatcher() *Watcher {
 	// Coverage pattern (generic)
 	w.coveragePattern = regexp.MustCompile(`(?:All files|Coverage)\s*\|?\s*(\d+(?:\.\d+)?)\s*%`)
 
+	for _, parser := range defaultParsers() {
+		w.RegisterParser(parser)
+	}
+
 	return w
 }
 
+// RegisterParser adds an output parser, replacing any for the same runner
+func (w *Watcher) RegisterParser(parser OutputParser) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, p := range w.parsers {
+		if p.Runner() == parser.Runner() {
+			w.parsers[i] = parser
+			return
+		}
+	}
+	w.parsers = append(w.parsers, parser)
+}
+
+// SetWorkDirFunc sets how a terminal's working directory is looked up, so
+// output parsers can be picked by project type
+func (w *Watcher) SetWorkDirFunc(fn func(termID string) string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.workDirFunc = fn
+}
+
+// SetRunFinishedHandler sets a function called, outside the watcher's lock,
+// each time a test run in a terminal finishes with a result. Output of several
+// test binaries or packages can finish a run more than once.
+func (w *Watcher) SetRunFinishedHandler(fn func(termID string, summary TestSummary)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.finishHandler = fn
+}
+
+// parserFor returns the output parser of a runner, nil for built-in runners
+func (w *Watcher) parserFor(runner TestRunner) OutputParser {
+	for _, p := range w.parsers {
+		if p.Runner() == runner {
+			return p
+		}
+	}
+	return nil
+}
+
 // Analyze processes terminal output and returns test summary if detected
 // Returns (summary, changed) where changed indicates if the status has changed
 func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 	w.mu.Lock()
-	defer w.mu.Unlock()
+	var wasRunning bool
+	var startTime, endTime time.Time
+	if state, ok := w.terminalStates[termID]; ok {
+		wasRunning = state.IsRunning
+		startTime, endTime = state.Summary.StartTime, state.Summary.EndTime
+	}
+
+	summary, changed := w.analyze(termID, data)
+
+	// A run finished when it ended in this chunk, after starting or with a new
+	// result; completion lines repeating an unchanged result don't count
+	started := !summary.StartTime.Equal(startTime)
+	finished := (wasRunning || started || changed) && !w.terminalStates[termID].IsRunning &&
+		!summary.EndTime.Equal(endTime) && summary.Status.IsFinal()
+	handler := w.finishHandler
+	final := *summary
+	w.mu.Unlock()
 
+	if finished && handler != nil {
+		handler(termID, final)
+	}
+	return summary, changed
+}
+
+// analyze updates a terminal's test state from a chunk of output; the caller holds the lock
+func (w *Watcher) analyze(termID string, data []byte) (*TestSummary, bool) {
 	state, exists := w.terminalStates[termID]
 	if !exists {
 		state = &TerminalTestState{
@@ -155,6 +235,9 @@ func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 				StartTime: time.Now(),
 			},
 		}
+		if w.workDirFunc != nil {
+			state.ProjectType = DetectProjectType(w.workDirFunc(termID))
+		}
 		w.terminalStates[termID] = state
 	}
 
@@ -166,11 +249,21 @@ func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 
 	// Detect test runner if not already detected
 	if state.Summary.Runner == RunnerUnknown {
-		state.Summary.Runner = w.detectRunner(text)
+		state.Summary.Runner = w.detectRunner(text, state.ProjectType)
+	}
+	if parser := w.parserFor(state.Summary.Runner); parser != nil {
+		state.OutputBuffer.Reset()
+		w.analyzeWithParser(state, parser, text)
+		return state.Summary, state.Summary.Status != oldStatus
 	}
 
 	// Check if tests are starting
 	if w.isTestStarting(text) {
+		// Go prints a start marker per test, so durations only reset between runs
+		if !state.IsRunning {
+			state.Summary.TestDurations = nil
+			state.Summary.Failures = nil
+		}
 		state.IsRunning = true
 		state.Summary.Status = StatusRunning
 		state.Summary.StartTime = time.Now()
@@ -179,6 +272,8 @@ func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 		state.Summary.Skipped = 0
 		state.Summary.Total = 0
 		state.Summary.FailedTests = nil
+		found := parseTestDurations(state.Summary.Runner, stripANSI(text))
+		state.Summary.TestDurations = mergeTestDurations(state.Summary.TestDurations, found)
 		return state.Summary, state.Summary.Status != oldStatus
 	}
 
@@ -202,6 +297,8 @@ func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 		// Re-parse the entire accumulated buffer to ensure we capture summary
 		// This handles the case where summary and completion come in separate chunks
 		w.parseTestOutput(state, fullBuffer)
+		found := parseFailures(state.Summary.Runner, strings.ReplaceAll(stripANSI(fullBuffer), "\r", ""))
+		state.Summary.Failures = mergeFailures(state.Summary.Failures, found)
 
 		slog.Debug("After re-parsing buffer",
 			"termID", termID,
@@ -236,9 +333,48 @@ func (w *Watcher) Analyze(termID string, data []byte) (*TestSummary, bool) {
 	return state.Summary, state.Summary.Status != oldStatus
 }
 
-// detectRunner identifies the test framework from output
-func (w *Watcher) detectRunner(text string) TestRunner {
+// startRun begins a run of a known runner, for output that isn't from a terminal
+func (w *Watcher) startRun(termID string, runner TestRunner) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state := &TerminalTestState{
+		Summary: &TestSummary{
+			Runner:    runner,
+			Status:    StatusRunning,
+			StartTime: time.Now(),
+		},
+		IsRunning: true,
+	}
+	if w.workDirFunc != nil {
+		state.ProjectType = DetectProjectType(w.workDirFunc(termID))
+	}
+	w.terminalStates[termID] = state
+}
+
+// endRun parses the failures of a run whose output ended without a
+// recognized completion line, for output that isn't from a terminal
+func (w *Watcher) endRun(termID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.terminalStates[termID]
+	if !ok || w.parserFor(state.Summary.Runner) != nil {
+		return
+	}
+	found := parseFailures(state.Summary.Runner, strings.ReplaceAll(stripANSI(state.OutputBuffer.String()), "\r", ""))
+	state.Summary.Failures = mergeFailures(state.Summary.Failures, found)
+}
+
+// detectRunner identifies the test framework from output. The parsers for
+// the project type come first, then the built-in patterns, then any parser.
+func (w *Watcher) detectRunner(text string, projectType ProjectType) TestRunner {
 	cleanText := stripANSI(text)
+	for _, runner := range projectParsers[projectType] {
+		if p := w.parserFor(runner); p != nil && p.Detect(cleanText) {
+			return runner
+		}
+	}
 	for runner, patterns := range w.runnerPatterns {
 		for _, pattern := range patterns {
 			if pattern.MatchString(cleanText) {
@@ -246,9 +382,71 @@ func (w *Watcher) detectRunner(text string) TestRunner {
 			}
 		}
 	}
+	for _, p := range w.parsers {
+		if p.Detect(cleanText) {
+			return p.Runner()
+		}
+	}
 	return RunnerUnknown
 }
 
+// analyzeWithParser updates the summary from the output of a runner that has
+// an output parser. The whole run is re-parsed, so counts spread over several
+// chunks (or several cargo test binaries) add up.
+func (w *Watcher) analyzeWithParser(state *TerminalTestState, parser OutputParser, text string) {
+	cleanText := strings.ReplaceAll(stripANSI(text), "\r", "")
+
+	if parser.IsStarting(cleanText) {
+		state.IsRunning = true
+		state.Summary.Status = StatusRunning
+		state.Summary.StartTime = time.Now()
+		state.Summary.EndTime = time.Time{}
+		state.Summary.Passed = 0
+		state.Summary.Failed = 0
+		state.Summary.Skipped = 0
+		state.Summary.Total = 0
+		state.Summary.Duration = 0
+		state.Summary.FailedTests = nil
+		state.Summary.TestDurations = nil
+		state.Summary.Failures = nil
+		state.runOutput.Reset()
+	}
+	if state.runOutput.Len() > maxRunOutput {
+		state.runOutput.Reset()
+	}
+	state.runOutput.WriteString(cleanText)
+	run := state.runOutput.String()
+
+	before := *state.Summary
+	if !parser.ParseSummary(state.Summary, run) {
+		return
+	}
+	state.Summary.FailedTests = parser.FailedTests(run)
+	state.Summary.TestDurations = parser.TestDurations(run)
+	state.Summary.Failures = parser.Failures(run)
+	if matches := w.coveragePattern.FindStringSubmatch(cleanText); len(matches) > 1 {
+		if cov, err := strconv.ParseFloat(matches[1], 64); err == nil {
+			state.Summary.CoveragePercent = cov
+		}
+	}
+
+	countsChanged := before.Passed != state.Summary.Passed || before.Failed != state.Summary.Failed ||
+		before.Skipped != state.Summary.Skipped
+	if !parser.IsComplete(run) || (!state.IsRunning && !countsChanged) {
+		return
+	}
+	state.IsRunning = false
+	state.Summary.EndTime = time.Now()
+	switch {
+	case state.Summary.Failed > 0 && state.Summary.Passed > 0:
+		state.Summary.Status = StatusMixed
+	case state.Summary.Failed > 0:
+		state.Summary.Status = StatusFailed
+	case state.Summary.Passed > 0:
+		state.Summary.Status = StatusPassed
+	}
+}
+
 // isTestStarting checks if tests are starting
 func (w *Watcher) isTestStarting(text string) bool {
 	cleanText := stripANSI(text)
@@ -348,6 +546,10 @@ func (w *Watcher) parseTestOutput(state *TerminalTestState, text string) {
 		}
 	}
 
+	// Record per-test durations
+	found := parseTestDurations(state.Summary.Runner, cleanText)
+	state.Summary.TestDurations = mergeTestDurations(state.Summary.TestDurations, found)
+
 	// Check for coverage
 	if matches := w.coveragePattern.FindStringSubmatch(cleanText); len(matches) > 1 {
 		if cov, err := strconv.ParseFloat(matches[1], 64); err == nil {
@@ -480,6 +682,7 @@ func (w *Watcher) ResetTerminal(termID string) {
 		}
 		state.IsRunning = false
 		state.OutputBuffer.Reset()
+		state.runOutput.Reset()
 	}
 }
 
@@ -112,6 +112,40 @@ class VoiceRecognizer: NSObject {
         }
     }
 
+    // Transcribe a recorded file instead of the microphone, printing one
+    // final result
+    func transcribe(file: String) {
+        SFSpeechRecognizer.requestAuthorization { [weak self] status in
+            guard let self = self else { return }
+            guard status == .authorized else {
+                self.output(["type": "error", "message": "Speech recognition not authorized. Enable in System Settings → Privacy → Speech Recognition."])
+                exit(1)
+            }
+            guard let speechRecognizer = self.speechRecognizer, speechRecognizer.isAvailable else {
+                self.output(["type": "error", "message": "Speech recognizer not available for this language."])
+                exit(1)
+            }
+
+            let request = SFSpeechURLRecognitionRequest(url: URL(fileURLWithPath: file))
+            request.shouldReportPartialResults = false
+            self.recognitionTask = speechRecognizer.recognitionTask(with: request) { result, error in
+                if let result = result, result.isFinal {
+                    self.output(["type": "final", "text": result.bestTranscription.formattedString])
+                    exit(0)
+                }
+                if let error = error as NSError? {
+                    // No speech in the clip
+                    if error.code == 1110 {
+                        self.output(["type": "final", "text": ""])
+                        exit(0)
+                    }
+                    self.output(["type": "error", "message": error.localizedDescription])
+                    exit(1)
+                }
+            }
+        }
+    }
+
     func stop() {
         running = false
         audioEngine.stop()
@@ -127,6 +161,12 @@ class VoiceRecognizer: NSObject {
 let lang = CommandLine.arguments.count > 1 ? CommandLine.arguments[1] : "en-US"
 let recognizer = VoiceRecognizer(locale: lang)
 
+// voice_input <lang> --file <path> transcribes a recorded file
+if CommandLine.arguments.count > 3 && CommandLine.arguments[2] == "--file" {
+    recognizer.transcribe(file: CommandLine.arguments[3])
+    RunLoop.main.run()
+}
+
 // Listen for "stop" on stdin
 DispatchQueue.global().async {
     while let line = readLine() {